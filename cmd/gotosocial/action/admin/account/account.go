@@ -28,6 +28,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/validate"
 	"golang.org/x/crypto/bcrypt"
@@ -307,6 +308,47 @@ var Disable action.GTSAction = func(ctx context.Context) error {
 	return dbConn.Stop(ctx)
 }
 
+// Enable sets Disabled to false on a user, undoing a previous Disable.
+var Enable action.GTSAction = func(ctx context.Context) error {
+	var state state.State
+	state.Caches.Init()
+	state.Workers.Start()
+
+	dbConn, err := bundb.NewBunDBService(ctx, &state)
+	if err != nil {
+		return fmt.Errorf("error creating dbservice: %s", err)
+	}
+
+	// Set the state DB connection
+	state.DB = dbConn
+
+	username := config.GetAdminAccountUsername()
+	if username == "" {
+		return errors.New("no username set")
+	}
+	if err := validate.Username(username); err != nil {
+		return err
+	}
+
+	a, err := dbConn.GetAccountByUsernameDomain(ctx, username, "")
+	if err != nil {
+		return err
+	}
+
+	u, err := dbConn.GetUserByAccountID(ctx, a.ID)
+	if err != nil {
+		return err
+	}
+
+	disabled := false
+	u.Disabled = &disabled
+	if err := dbConn.UpdateUser(ctx, u, "disabled"); err != nil {
+		return err
+	}
+
+	return dbConn.Stop(ctx)
+}
+
 // Password sets the password of target account.
 var Password action.GTSAction = func(ctx context.Context) error {
 	var state state.State
@@ -355,3 +397,48 @@ var Password action.GTSAction = func(ctx context.Context) error {
 	u.EncryptedPassword = string(pw)
 	return dbConn.UpdateUser(ctx, u, "encrypted_password")
 }
+
+// Quota sets the media storage quota of target account.
+var Quota action.GTSAction = func(ctx context.Context) error {
+	var state state.State
+	state.Caches.Init()
+	state.Workers.Start()
+
+	dbConn, err := bundb.NewBunDBService(ctx, &state)
+	if err != nil {
+		return fmt.Errorf("error creating dbservice: %s", err)
+	}
+
+	// Set the state DB connection
+	state.DB = dbConn
+
+	username := config.GetAdminAccountUsername()
+	if username == "" {
+		return errors.New("no username set")
+	}
+	if err := validate.Username(username); err != nil {
+		return err
+	}
+
+	quota, err := media.ParseAccountQuota(config.GetAdminAccountQuota())
+	if err != nil {
+		return err
+	}
+
+	a, err := dbConn.GetAccountByUsernameDomain(ctx, username, "")
+	if err != nil {
+		return err
+	}
+
+	u, err := dbConn.GetUserByAccountID(ctx, a.ID)
+	if err != nil {
+		return err
+	}
+
+	u.MediaQuota = quota
+	if err := dbConn.UpdateUser(ctx, u, "media_quota"); err != nil {
+		return err
+	}
+
+	return dbConn.Stop(ctx)
+}
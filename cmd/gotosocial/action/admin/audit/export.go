@@ -0,0 +1,121 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// entry is the exported representation of one audit log entry.
+type entry struct {
+	ID         string          `json:"id"`
+	CreatedAt  string          `json:"created_at"`
+	AccountID  string          `json:"account_id"`
+	Username   string          `json:"username,omitempty"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"target_type"`
+	TargetID   string          `json:"target_id,omitempty"`
+	Diff       json.RawMessage `json:"diff"`
+}
+
+// Export exports the moderation audit log to a file, as
+// one JSON object per line, from newest to oldest entry.
+var Export action.GTSAction = func(ctx context.Context) error {
+	var state state.State
+	state.Caches.Init()
+
+	dbConn, err := bundb.NewBunDBService(ctx, &state)
+	if err != nil {
+		return fmt.Errorf("error creating dbservice: %s", err)
+	}
+
+	// Set the state DB connection
+	state.DB = dbConn
+
+	path := config.GetAdminTransPath()
+	if path == "" {
+		return errors.New("no path set")
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+
+	const limit = 100
+	var maxID string
+
+	for {
+		entries, err := dbConn.GetAuditLogEntries(ctx, "", "", "", "", maxID, "", "", limit)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				// No more entries.
+				break
+			}
+			return err
+		}
+
+		for _, e := range entries {
+			out := entry{
+				ID:         e.ID,
+				CreatedAt:  util.FormatISO8601(e.CreatedAt),
+				AccountID:  e.AccountID,
+				Action:     e.Action,
+				TargetType: string(e.TargetType),
+				TargetID:   e.TargetID,
+				Diff:       json.RawMessage(e.Diff),
+			}
+
+			if e.Account != nil {
+				out.Username = e.Account.Username
+			}
+
+			if len(out.Diff) == 0 {
+				out.Diff = json.RawMessage("{}")
+			}
+
+			if err := enc.Encode(out); err != nil {
+				return err
+			}
+		}
+
+		maxID = entries[len(entries)-1].ID
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	return dbConn.Stop(ctx)
+}
@@ -0,0 +1,176 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ipblock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// Create blocks the given IP address or range at the given severity.
+// Blocks created from the CLI are attributed to the instance account.
+var Create action.GTSAction = func(ctx context.Context) error {
+	var state state.State
+	state.Caches.Init()
+
+	dbConn, err := bundb.NewBunDBService(ctx, &state)
+	if err != nil {
+		return fmt.Errorf("error creating dbservice: %s", err)
+	}
+
+	// Set the state DB connection
+	state.DB = dbConn
+
+	ip, err := util.NormalizeIPRange(config.GetAdminIPBlockIP())
+	if err != nil {
+		return err
+	}
+
+	severity := gtsmodel.IPBlockSeverity(config.GetAdminIPBlockSeverity())
+	if err := validate.IPBlockSeverity(severity); err != nil {
+		return err
+	}
+
+	expiresIn := config.GetAdminIPBlockExpiresIn()
+	if expiresIn < 0 {
+		return errors.New("expires-in must not be negative")
+	}
+
+	var expiresAt time.Time
+	if expiresIn > 0 {
+		expiresAt = time.Now().Add(expiresIn)
+	}
+
+	if _, err := dbConn.GetIPBlockByIP(ctx, ip); err == nil {
+		return fmt.Errorf("ip range %s is already blocked", ip)
+	} else if !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	// Make sure the instance account exists even if
+	// the server has never been started with this db.
+	if err := dbConn.CreateInstanceAccount(ctx); err != nil {
+		return fmt.Errorf("error creating instance account: %w", err)
+	}
+
+	instanceAccount, err := dbConn.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return fmt.Errorf("error getting instance account: %w", err)
+	}
+
+	if err := dbConn.PutIPBlock(ctx, &gtsmodel.IPBlock{
+		ID:                 id.NewULID(),
+		IP:                 ip,
+		Severity:           severity,
+		Comment:            text.SanitizePlaintext(config.GetAdminIPBlockComment()),
+		ExpiresAt:          expiresAt,
+		CreatedByAccountID: instanceAccount.ID,
+	}); err != nil {
+		return err
+	}
+
+	return dbConn.Stop(ctx)
+}
+
+// List prints all IP blocks.
+var List action.GTSAction = func(ctx context.Context) error {
+	var state state.State
+	state.Caches.Init()
+
+	dbConn, err := bundb.NewBunDBService(ctx, &state)
+	if err != nil {
+		return fmt.Errorf("error creating dbservice: %s", err)
+	}
+
+	// Set the state DB connection
+	state.DB = dbConn
+
+	blocks, err := dbConn.GetIPBlocks(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	fmtExpiry := func(b *gtsmodel.IPBlock) string {
+		switch {
+		case b.ExpiresAt.IsZero():
+			return "never"
+		case b.Expired(now):
+			return "expired"
+		default:
+			return util.FormatISO8601(b.ExpiresAt)
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintln(w, "id\tip\tseverity\texpires\tcomment")
+	for _, b := range blocks {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", b.ID, b.IP, b.Severity, fmtExpiry(b), b.Comment)
+	}
+	w.Flush()
+
+	return dbConn.Stop(ctx)
+}
+
+// Delete removes the block on the given IP address or range.
+var Delete action.GTSAction = func(ctx context.Context) error {
+	var state state.State
+	state.Caches.Init()
+
+	dbConn, err := bundb.NewBunDBService(ctx, &state)
+	if err != nil {
+		return fmt.Errorf("error creating dbservice: %s", err)
+	}
+
+	// Set the state DB connection
+	state.DB = dbConn
+
+	ip, err := util.NormalizeIPRange(config.GetAdminIPBlockIP())
+	if err != nil {
+		return err
+	}
+
+	block, err := dbConn.GetIPBlockByIP(ctx, ip)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return fmt.Errorf("ip range %s is not blocked", ip)
+		}
+		return err
+	}
+
+	if err := dbConn.DeleteIPBlockByID(ctx, block.ID); err != nil {
+		return err
+	}
+
+	return dbConn.Stop(ctx)
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/trans"
 )
 
@@ -41,14 +42,27 @@ var Export action.GTSAction = func(ctx context.Context) error {
 	// Set the state DB connection
 	state.DB = dbConn
 
-	exporter := trans.NewExporter(dbConn)
+	// Storage is only needed
+	// when bundling media.
+	media := config.GetAdminTransMedia()
+	if media {
+		//nolint:contextcheck
+		storage, err := gtsstorage.AutoConfig()
+		if err != nil {
+			return fmt.Errorf("error creating storage backend: %w", err)
+		}
+		defer storage.Close()
+		state.Storage = storage
+	}
+
+	exporter := trans.NewExporter(dbConn, state.Storage)
 
 	path := config.GetAdminTransPath()
 	if path == "" {
 		return errors.New("no path set")
 	}
 
-	if err := exporter.ExportMinimal(ctx, path); err != nil {
+	if err := exporter.ExportFull(ctx, path, media); err != nil {
 		return err
 	}
 
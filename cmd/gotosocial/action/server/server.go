@@ -21,7 +21,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/netip"
 	"os"
 	"os/signal"
 	"syscall"
@@ -47,6 +49,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/metrics"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/oidc"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
@@ -79,6 +82,9 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		return fmt.Errorf("error initializing tracing: %w", err)
 	}
 
+	// Initialize Metrics
+	metrics.Initialize(&state)
+
 	// Open connection to the database
 	dbService, err := bundb.NewBunDBService(ctx, &state)
 	if err != nil {
@@ -160,7 +166,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	}
 
 	// Create the processor using all the other services we've created so far.
-	processor := processing.NewProcessor(typeConverter, federator, oauthServer, mediaManager, &state, emailSender)
+	processor := processing.NewProcessor(typeConverter, federator, oauthServer, mediaManager, &state, emailSender, net.DefaultResolver)
 
 	// Set state client / federator worker enqueue functions
 	state.Workers.EnqueueClientAPI = processor.EnqueueClientAPI
@@ -181,10 +187,14 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	if config.GetTracingEnabled() {
 		middlewares = append(middlewares, tracing.InstrumentGin())
 	}
+	if config.GetMetricsEnabled() {
+		middlewares = append(middlewares, metrics.InstrumentGin())
+	}
 	middlewares = append(middlewares, []gin.HandlerFunc{
 		// note: hooks adding ctx fields must be ABOVE
 		// the logger, otherwise won't be accessible.
 		middleware.Logger(config.GetLogClientIP()),
+		middleware.IPBlock(dbService),
 		middleware.UserAgent(),
 		middleware.CORS(),
 		middleware.ExtraHeaders(),
@@ -225,14 +235,61 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		nodeInfoModule    = api.NewNodeInfo(processor)                                         // nodeinfo endpoint
 		activityPubModule = api.NewActivityPub(dbService, processor)                           // ActivityPub endpoints
 		webModule         = web.New(dbService, processor)                                      // web pages + user profiles + settings panels etc
+		healthModule      = api.NewHealth(&state)                                              // liveness + readiness probes
 	)
 
 	// create required middleware
 	// rate limiting
 	limit := config.GetAdvancedRateLimitRequests()
-	clLimit := middleware.RateLimit(limit)  // client api
-	s2sLimit := middleware.RateLimit(limit) // server-to-server (AP)
-	fsLimit := middleware.RateLimit(limit)  // fileserver / web templates
+	except, err := parseRateLimitExceptions(config.GetAdvancedRateLimitExceptions())
+	if err != nil {
+		return err
+	}
+
+	// classes of routes with a rate limit budget of their own
+	signInLimit := middleware.RateLimitClass{
+		Name:  "sign_in",
+		Limit: config.GetAdvancedRateLimitSignInRequests(),
+		Routes: []string{
+			"POST /auth/sign_in",
+			"POST /oauth/token",
+			"POST /api/v1/accounts",
+		},
+	}
+	mediaUploadLimit := middleware.RateLimitClass{
+		Name:  "media_upload",
+		Limit: config.GetAdvancedRateLimitMediaUploadRequests(),
+		Routes: []string{
+			"POST /api/:api_version/media",
+		},
+	}
+	statusPostLimit := middleware.RateLimitClass{
+		Name:  "status_post",
+		Limit: config.GetAdvancedRateLimitStatusPostRequests(),
+		Routes: []string{
+			"POST /api/v1/statuses",
+		},
+	}
+	timelineLimit := middleware.RateLimitClass{
+		Name:  "timeline",
+		Limit: config.GetAdvancedRateLimitTimelineRequests(),
+		Routes: []string{
+			"GET /api/v1/timelines/*",
+		},
+	}
+	inboxLimit := middleware.RateLimitClass{
+		Name:  "inbox",
+		Limit: config.GetAdvancedRateLimitInboxRequests(),
+		Routes: []string{
+			"POST /users/:username/inbox",
+		},
+		Verified: true,
+	}
+
+	clLimit := middleware.RateLimit(limit, except, signInLimit, mediaUploadLimit, statusPostLimit, timelineLimit) // client api
+	s2sLimit := middleware.RateLimit(limit, except, inboxLimit)                                                   // server-to-server (AP)
+	fsLimit := middleware.RateLimit(config.GetAdvancedRateLimitFileserverRequests(), except)                      // fileserver
+	webLimit := middleware.RateLimit(limit, except)                                                               // web templates
 
 	// throttling
 	cpuMultiplier := config.GetAdvancedThrottlingMultiplier()
@@ -253,7 +310,17 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	nodeInfoModule.Route(router, s2sLimit, s2sThrottle, gzip)
 	activityPubModule.Route(router, s2sLimit, s2sThrottle, gzip)
 	activityPubModule.RoutePublicKey(router, s2sLimit, pkThrottle, gzip)
-	webModule.Route(router, fsLimit, fsThrottle, gzip)
+	webModule.Route(router, webLimit, fsThrottle, gzip)
+
+	// no rate limiting or throttling, so that probes
+	// still get an answer when the instance is busy
+	healthModule.Route(router)
+
+	if config.GetMetricsEnabled() {
+		// no rate limiting, as scrapers come from
+		// one address; use an auth token instead
+		api.NewMetrics().Route(router)
+	}
 
 	gts, err := gotosocial.NewServer(dbService, router, federator, mediaManager)
 	if err != nil {
@@ -278,3 +345,17 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	log.Info(ctx, "done! exiting...")
 	return nil
 }
+
+// parseRateLimitExceptions parses the given CIDRs
+// into prefixes to exempt from rate limiting.
+func parseRateLimitExceptions(cidrs []string) ([]netip.Prefix, error) {
+	except := make([]netip.Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing rate limit exception %s: %w", cidr, err)
+		}
+		except = append(except, prefix.Masked())
+	}
+	return except, nil
+}
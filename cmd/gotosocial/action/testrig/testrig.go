@@ -36,6 +36,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gotosocial"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/metrics"
 	"github.com/superseriousbusiness/gotosocial/internal/middleware"
 	"github.com/superseriousbusiness/gotosocial/internal/oidc"
 	tlprocessor "github.com/superseriousbusiness/gotosocial/internal/processing/timeline"
@@ -59,6 +60,9 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		return fmt.Errorf("error initializing tracing: %w", err)
 	}
 
+	// Initialize Metrics
+	metrics.Initialize(&state)
+
 	// Initialize caches and database
 	state.DB = testrig.NewTestDB(&state)
 
@@ -129,8 +133,12 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	if config.GetTracingEnabled() {
 		middlewares = append(middlewares, tracing.InstrumentGin())
 	}
+	if config.GetMetricsEnabled() {
+		middlewares = append(middlewares, metrics.InstrumentGin())
+	}
 	middlewares = append(middlewares, []gin.HandlerFunc{
 		middleware.Logger(config.GetLogClientIP()),
+		middleware.IPBlock(state.DB),
 		middleware.UserAgent(),
 		middleware.CORS(),
 		middleware.ExtraHeaders(),
@@ -172,6 +180,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		nodeInfoModule    = api.NewNodeInfo(processor)                                        // nodeinfo endpoint
 		activityPubModule = api.NewActivityPub(state.DB, processor)                           // ActivityPub endpoints
 		webModule         = web.New(state.DB, processor)                                      // web pages + user profiles + settings panels etc
+		healthModule      = api.NewHealth(&state)                                             // liveness + readiness probes
 	)
 
 	// these should be routed in order
@@ -183,6 +192,11 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	activityPubModule.Route(router)
 	activityPubModule.RoutePublicKey(router)
 	webModule.Route(router)
+	healthModule.Route(router)
+
+	if config.GetMetricsEnabled() {
+		api.NewMetrics().Route(router)
+	}
 
 	gts, err := gotosocial.NewServer(state.DB, router, federator, mediaManager)
 	if err != nil {
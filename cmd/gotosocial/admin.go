@@ -20,6 +20,8 @@ package main
 import (
 	"github.com/spf13/cobra"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/account"
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/audit"
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/ipblock"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/media/prune"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/trans"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
@@ -118,6 +120,19 @@ func adminCommands() *cobra.Command {
 	config.AddAdminAccount(adminAccountDisableCmd)
 	adminAccountCmd.AddCommand(adminAccountDisableCmd)
 
+	adminAccountEnableCmd := &cobra.Command{
+		Use:   "enable",
+		Short: "allow a previously disabled local account to sign in and post etc again",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), account.Enable)
+		},
+	}
+	config.AddAdminAccount(adminAccountEnableCmd)
+	adminAccountCmd.AddCommand(adminAccountEnableCmd)
+
 	adminAccountPasswordCmd := &cobra.Command{
 		Use:   "password",
 		Short: "set a new password for the given local account",
@@ -132,6 +147,20 @@ func adminCommands() *cobra.Command {
 	config.AddAdminAccountPassword(adminAccountPasswordCmd)
 	adminAccountCmd.AddCommand(adminAccountPasswordCmd)
 
+	adminAccountQuotaCmd := &cobra.Command{
+		Use:   "quota",
+		Short: "set the media storage quota of the given local account",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), account.Quota)
+		},
+	}
+	config.AddAdminAccount(adminAccountQuotaCmd)
+	config.AddAdminAccountQuota(adminAccountQuotaCmd)
+	adminAccountCmd.AddCommand(adminAccountQuotaCmd)
+
 	adminCmd.AddCommand(adminAccountCmd)
 
 	/*
@@ -149,6 +178,7 @@ func adminCommands() *cobra.Command {
 		},
 	}
 	config.AddAdminTrans(adminExportCmd)
+	config.AddAdminTransMedia(adminExportCmd)
 	adminCmd.AddCommand(adminExportCmd)
 
 	adminImportCmd := &cobra.Command{
@@ -164,6 +194,79 @@ func adminCommands() *cobra.Command {
 	config.AddAdminTrans(adminImportCmd)
 	adminCmd.AddCommand(adminImportCmd)
 
+	/*
+		ADMIN AUDIT LOG COMMANDS
+	*/
+
+	adminAuditLogCmd := &cobra.Command{
+		Use:   "audit-log",
+		Short: "admin commands related to the moderation audit log",
+	}
+
+	adminAuditLogExportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "export the moderation audit log to file at the given path",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), audit.Export)
+		},
+	}
+	config.AddAdminTrans(adminAuditLogExportCmd)
+	adminAuditLogCmd.AddCommand(adminAuditLogExportCmd)
+
+	adminCmd.AddCommand(adminAuditLogCmd)
+
+	/*
+		ADMIN IP BLOCK COMMANDS
+	*/
+
+	adminIPBlockCmd := &cobra.Command{
+		Use:   "ip-block",
+		Short: "admin commands related to blocking IP addresses and ranges",
+	}
+
+	adminIPBlockCreateCmd := &cobra.Command{
+		Use:   "create",
+		Short: "block an IP address or CIDR range at the given severity",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), ipblock.Create)
+		},
+	}
+	config.AddAdminIPBlockCreate(adminIPBlockCreateCmd)
+	adminIPBlockCmd.AddCommand(adminIPBlockCreateCmd)
+
+	adminIPBlockListCmd := &cobra.Command{
+		Use:   "list",
+		Short: "list all IP blocks",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), ipblock.List)
+		},
+	}
+	adminIPBlockCmd.AddCommand(adminIPBlockListCmd)
+
+	adminIPBlockDeleteCmd := &cobra.Command{
+		Use:   "delete",
+		Short: "remove the block on an IP address or CIDR range",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), ipblock.Delete)
+		},
+	}
+	config.AddAdminIPBlock(adminIPBlockDeleteCmd)
+	adminIPBlockCmd.AddCommand(adminIPBlockDeleteCmd)
+
+	adminCmd.AddCommand(adminIPBlockCmd)
+
 	/*
 		ADMIN MEDIA COMMANDS
 	*/
@@ -222,6 +325,22 @@ func adminCommands() *cobra.Command {
 
 	adminMediaCmd.AddCommand(adminMediaPruneCmd)
 
+	/*
+		ADMIN MEDIA DEDUPE COMMANDS
+	*/
+	adminMediaDedupeCmd := &cobra.Command{
+		Use:   "dedupe",
+		Short: "move existing media and emojis into content-addressed storage, storing identical files only once",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), prune.Dedupe)
+		},
+	}
+	config.AddAdminMediaPrune(adminMediaDedupeCmd)
+	adminMediaCmd.AddCommand(adminMediaDedupeCmd)
+
 	adminCmd.AddCommand(adminMediaCmd)
 
 	return adminCmd
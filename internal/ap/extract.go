@@ -391,6 +391,41 @@ func ExtractURL(i WithURL) (*url.URL, error) {
 	return nil, gtserror.New("no valid URL property found")
 }
 
+// ExtractMediaURL extracts the href of the first Link it can
+// find in the given WithURL interface's url property whose
+// mediaType starts with the given prefix, eg., "video/", or
+// nil if no such Link is found. This is useful for objects
+// like Video and Audio, which link to the media file(s) they
+// represent alongside a web page (eg., PeerTube videos).
+func ExtractMediaURL(i WithURL, mediaTypePrefix string) *url.URL {
+	urlProp := i.GetActivityStreamsUrl()
+	if urlProp == nil {
+		return nil
+	}
+
+	for iter := urlProp.Begin(); iter != urlProp.End(); iter = iter.Next() {
+		if !iter.IsActivityStreamsLink() {
+			continue
+		}
+
+		link := iter.GetActivityStreamsLink()
+		mediaTypeProp := link.GetActivityStreamsMediaType()
+		if mediaTypeProp == nil || !strings.HasPrefix(mediaTypeProp.Get(), mediaTypePrefix) {
+			continue
+		}
+
+		hrefProp := link.GetActivityStreamsHref()
+		if hrefProp == nil || !hrefProp.IsIRI() {
+			continue
+		}
+
+		// Found it.
+		return hrefProp.GetIRI()
+	}
+
+	return nil
+}
+
 // ExtractPublicKey extracts the public key, public key ID, and public
 // key owner ID from an interface, or an error if something goes wrong.
 func ExtractPublicKey(i WithPublicKey) (
@@ -515,6 +550,31 @@ func ExtractAttachment(i Attachmentable) (*gtsmodel.MediaAttachment, error) {
 	}, nil
 }
 
+// ExtractPollOptions returns the names of the
+// choices of the given Pollable, taken from either
+// its oneOf or anyOf property, or nil if none found.
+func ExtractPollOptions(i Pollable) []string {
+	var options []string
+
+	if oneOfProp := i.GetActivityStreamsOneOf(); oneOfProp != nil {
+		for iter := oneOfProp.Begin(); iter != oneOfProp.End(); iter = iter.Next() {
+			if named, ok := iter.GetType().(WithName); ok {
+				options = append(options, ExtractName(named))
+			}
+		}
+	}
+
+	if anyOfProp := i.GetActivityStreamsAnyOf(); anyOfProp != nil {
+		for iter := anyOfProp.Begin(); iter != anyOfProp.End(); iter = iter.Next() {
+			if named, ok := iter.GetType().(WithName); ok {
+				options = append(options, ExtractName(named))
+			}
+		}
+	}
+
+	return options
+}
+
 // ExtractBlurhash extracts the blurhash string value
 // from the given WithBlurhash interface, or returns
 // an empty string if nothing is found.
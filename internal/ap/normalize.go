@@ -75,7 +75,7 @@ func NormalizeIncomingActivityObject(activity pub.Activity, rawJSON map[string]i
 	}
 
 	switch t.GetTypeName() {
-	case ObjectArticle, ObjectDocument, ObjectImage, ObjectVideo, ObjectNote, ObjectPage, ObjectEvent, ObjectPlace, ObjectProfile:
+	case ObjectArticle, ObjectAudio, ObjectDocument, ObjectImage, ObjectVideo, ObjectNote, ObjectPage, ObjectEvent, ObjectPlace, ObjectProfile, ActivityQuestion:
 		statusable, ok := t.(Statusable)
 		if !ok {
 			// Object is not Statusable;
@@ -29,7 +29,7 @@ import (
 // ResolveStatusable tries to resolve the given bytes into an ActivityPub Statusable representation.
 // It will then perform normalization on the Statusable.
 //
-// Works for: Article, Document, Image, Video, Note, Page, Event, Place, Profile
+// Works for: Article, Audio, Document, Image, Video, Note, Page, Event, Place, Profile, Question
 func ResolveStatusable(ctx context.Context, b []byte) (Statusable, error) {
 	rawStatusable := make(map[string]interface{})
 	if err := json.Unmarshal(b, &rawStatusable); err != nil {
@@ -41,6 +41,25 @@ func ResolveStatusable(ctx context.Context, b []byte) (Statusable, error) {
 		return nil, gtserror.Newf("error resolving json into ap vocab type: %w", err)
 	}
 
+	statusable, ok := ToStatusable(t)
+	if !ok {
+		err = gtserror.Newf("could not resolve %T to Statusable", t)
+		return nil, gtserror.SetWrongType(err)
+	}
+
+	NormalizeIncomingContent(statusable, rawStatusable)
+	NormalizeIncomingAttachments(statusable, rawStatusable)
+	NormalizeIncomingSummary(statusable, rawStatusable)
+	NormalizeIncomingName(statusable, rawStatusable)
+
+	return statusable, nil
+}
+
+// ToStatusable returns the given ActivityPub type as a Statusable,
+// if it's one of the object types that we can convert to a status.
+//
+// Works for: Article, Audio, Document, Image, Video, Note, Page, Event, Place, Profile, Question
+func ToStatusable(t vocab.Type) (Statusable, bool) {
 	var (
 		statusable Statusable
 		ok         bool
@@ -49,6 +68,8 @@ func ResolveStatusable(ctx context.Context, b []byte) (Statusable, error) {
 	switch t.GetTypeName() {
 	case ObjectArticle:
 		statusable, ok = t.(vocab.ActivityStreamsArticle)
+	case ObjectAudio:
+		statusable, ok = t.(vocab.ActivityStreamsAudio)
 	case ObjectDocument:
 		statusable, ok = t.(vocab.ActivityStreamsDocument)
 	case ObjectImage:
@@ -65,19 +86,11 @@ func ResolveStatusable(ctx context.Context, b []byte) (Statusable, error) {
 		statusable, ok = t.(vocab.ActivityStreamsPlace)
 	case ObjectProfile:
 		statusable, ok = t.(vocab.ActivityStreamsProfile)
+	case ActivityQuestion:
+		statusable, ok = t.(vocab.ActivityStreamsQuestion)
 	}
 
-	if !ok {
-		err = gtserror.Newf("could not resolve %T to Statusable", t)
-		return nil, gtserror.SetWrongType(err)
-	}
-
-	NormalizeIncomingContent(statusable, rawStatusable)
-	NormalizeIncomingAttachments(statusable, rawStatusable)
-	NormalizeIncomingSummary(statusable, rawStatusable)
-	NormalizeIncomingName(statusable, rawStatusable)
-
-	return statusable, nil
+	return statusable, ok
 }
 
 // ResolveStatusable tries to resolve the given bytes into an ActivityPub Accountable representation.
@@ -40,12 +40,16 @@ func (a *ActivityPub) Route(r router.Router, m ...gin.HandlerFunc) {
 	emojiGroup := r.AttachGroup("emoji")
 	usersGroup := r.AttachGroup("users")
 
-	// attach shared, non-global middlewares to both of these groups
+	// attach shared, non-global middlewares to both of these groups;
+	// signatures are checked first so that requests from blocked
+	// domains are refused without using up any rate limit budget
 	cacheControlMiddleware := middleware.CacheControl("no-store")
+	emojiGroup.Use(a.signatureCheckMiddleware)
+	usersGroup.Use(a.signatureCheckMiddleware)
 	emojiGroup.Use(m...)
 	usersGroup.Use(m...)
-	emojiGroup.Use(a.signatureCheckMiddleware, cacheControlMiddleware)
-	usersGroup.Use(a.signatureCheckMiddleware, cacheControlMiddleware)
+	emojiGroup.Use(cacheControlMiddleware)
+	usersGroup.Use(cacheControlMiddleware)
 
 	a.emoji.Route(emojiGroup.Handle)
 	a.users.Route(usersGroup.Handle)
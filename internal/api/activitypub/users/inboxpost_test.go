@@ -37,6 +37,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/api/activitypub/users"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
@@ -561,6 +562,39 @@ func (suite *InboxPostTestSuite) TestPostUnauthorized() {
 	)
 }
 
+func (suite *InboxPostTestSuite) TestPostRateLimited() {
+	var (
+		requestingAccount = suite.testAccounts["remote_account_1"]
+		targetAccount     = suite.testAccounts["local_account_1"]
+		chargedDomain     string
+	)
+
+	// Post an empty create.
+	create := streams.NewActivityStreamsCreate()
+
+	suite.inboxPost(
+		create,
+		requestingAccount,
+		targetAccount,
+		http.StatusTooManyRequests,
+		`{"error":"Too Many Requests"}`,
+		suite.signatureCheck,
+		// Pretend the verified domain has
+		// used up its rate limit budget.
+		func(c *gin.Context) {
+			ctx := gtscontext.SetRateLimit(c.Request.Context(), func(domain string) bool {
+				chargedDomain = domain
+				return false
+			})
+			c.Request = c.Request.WithContext(ctx)
+		},
+	)
+
+	// The domain of the verified
+	// sender should've been charged.
+	suite.Equal(requestingAccount.Domain, chargedDomain)
+}
+
 func TestInboxPostTestSuite(t *testing.T) {
 	suite.Run(t, &InboxPostTestSuite{})
 }
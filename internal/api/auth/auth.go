@@ -50,29 +50,36 @@ const (
 
 	// OauthTokenPath is the API path to use for granting token requests to users with valid credentials
 	OauthTokenPath = "/token" // #nosec G101 else we get a hardcoded credentials warning
+	// OauthRevokePath is the API path to use for revoking tokens
+	OauthRevokePath = "/revoke"
 	// OauthAuthorizePath is the API path for authorization requests (eg., authorize this app to act on my behalf as a user)
 	OauthAuthorizePath = "/authorize"
 	// OauthFinalizePath is the API path for completing user registration with additional user details
 	OauthFinalizePath = "/finalize"
 	// OauthOobTokenPath is the path for serving an html representation of an oob token page.
 	OauthOobTokenPath = "/oob" // #nosec G101 else we get a hardcoded credentials warning
+	// OauthDeviceCodePath is the API path for clients to request a device code, to start a device authorization grant
+	OauthDeviceCodePath = "/device/code"
+	// OauthDevicePath is the path for users to enter the user code shown on their device, and approve or deny it
+	OauthDevicePath = "/device"
 
 	/*
 		params / session keys
 	*/
 
-	callbackStateParam   = "state"
-	callbackCodeParam    = "code"
-	sessionUserID        = "userid"
-	sessionClientID      = "client_id"
-	sessionRedirectURI   = "redirect_uri"
-	sessionForceLogin    = "force_login"
-	sessionResponseType  = "response_type"
-	sessionScope         = "scope"
-	sessionInternalState = "internal_state"
-	sessionClientState   = "client_state"
-	sessionClaims        = "claims"
-	sessionAppID         = "app_id"
+	callbackStateParam    = "state"
+	callbackCodeParam     = "code"
+	sessionUserID         = "userid"
+	sessionClientID       = "client_id"
+	sessionRedirectURI    = "redirect_uri"
+	sessionForceLogin     = "force_login"
+	sessionResponseType   = "response_type"
+	sessionScope          = "scope"
+	sessionInternalState  = "internal_state"
+	sessionClientState    = "client_state"
+	sessionClaims         = "claims"
+	sessionAppID          = "app_id"
+	sessionDeviceUserCode = "device_user_code"
 )
 
 type Module struct {
@@ -102,10 +109,14 @@ func (m *Module) RouteAuth(attachHandler func(method string, path string, f ...g
 // RouteOauth routes all paths that should have an 'oauth' prefix
 func (m *Module) RouteOauth(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodPost, OauthTokenPath, m.TokenPOSTHandler)
+	attachHandler(http.MethodPost, OauthRevokePath, m.RevokePOSTHandler)
 	attachHandler(http.MethodGet, OauthAuthorizePath, m.AuthorizeGETHandler)
 	attachHandler(http.MethodPost, OauthAuthorizePath, m.AuthorizePOSTHandler)
 	attachHandler(http.MethodPost, OauthFinalizePath, m.FinalizePOSTHandler)
 	attachHandler(http.MethodGet, OauthOobTokenPath, m.OobHandler)
+	attachHandler(http.MethodPost, OauthDeviceCodePath, m.DeviceCodePOSTHandler)
+	attachHandler(http.MethodGet, OauthDevicePath, m.DeviceGETHandler)
+	attachHandler(http.MethodPost, OauthDevicePath, m.DevicePOSTHandler)
 }
 
 func (m *Module) clearSession(s sessions.Session) {
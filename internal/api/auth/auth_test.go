@@ -66,6 +66,7 @@ type AuthStandardTestSuite struct {
 const (
 	sessionUserID   = "userid"
 	sessionClientID = "client_id"
+	sessionScope    = "scope"
 )
 
 func (suite *AuthStandardTestSuite) SetupSuite() {
@@ -18,6 +18,7 @@
 package auth
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -56,6 +57,12 @@ func (m *Module) AuthorizeGETHandler(c *gin.Context) {
 			return
 		}
 
+		if errWithCode := m.validateAuthScope(c.Request.Context(), form); errWithCode != nil {
+			m.clearSession(s)
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
 		if errWithCode := saveAuthFormToSession(s, form); errWithCode != nil {
 			m.clearSession(s)
 			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
@@ -265,6 +272,28 @@ func (m *Module) AuthorizePOSTHandler(c *gin.Context) {
 	}
 }
 
+// validateAuthScope checks that the scopes requested in the given
+// OAuthAuthorize form are known, and permitted by the application
+// doing the requesting, normalizing them on the form if so.
+func (m *Module) validateAuthScope(ctx context.Context, form *apimodel.OAuthAuthorize) gtserror.WithCode {
+	app := &gtsmodel.Application{}
+	if err := m.db.GetWhere(ctx, []db.Where{{Key: sessionClientID, Value: form.ClientID}}, app); err != nil {
+		safe := fmt.Sprintf("application for %s %s could not be retrieved", sessionClientID, form.ClientID)
+		if errors.Is(err, db.ErrNoEntries) {
+			return gtserror.NewErrorBadRequest(err, safe, oauth.HelpfulAdvice)
+		}
+		return gtserror.NewErrorInternalError(err, safe, oauth.HelpfulAdvice)
+	}
+
+	scope, err := oauth.ValidateScopes(form.Scope, app.Scopes)
+	if err != nil {
+		return gtserror.NewErrorBadRequest(err, err.Error(), oauth.HelpfulAdvice)
+	}
+
+	form.Scope = scope
+	return nil
+}
+
 // saveAuthFormToSession checks the given OAuthAuthorize form,
 // and stores the values in the form into the session.
 func saveAuthFormToSession(s sessions.Session, form *apimodel.OAuthAuthorize) gtserror.WithCode {
@@ -301,6 +330,7 @@ func saveAuthFormToSession(s sessions.Session, form *apimodel.OAuthAuthorize) gt
 	s.Set(sessionScope, form.Scope)
 	s.Set(sessionInternalState, uuid.NewString())
 	s.Set(sessionClientState, form.State)
+	s.Delete(sessionDeviceUserCode)
 
 	if err := s.Save(); err != nil {
 		err := fmt.Errorf("error saving form values onto session: %s", err)
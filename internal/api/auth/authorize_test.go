@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"testing"
 	"time"
 
@@ -113,6 +114,40 @@ func (suite *AuthAuthorizeTestSuite) TestAccountAuthorizeHandler() {
 	}
 }
 
+func (suite *AuthAuthorizeTestSuite) TestAuthorizeScope() {
+	app := suite.testApplications["application_1"]
+
+	for _, test := range []struct {
+		scope                  string
+		expectedStatusCode     int
+		expectedLocationHeader string
+		expectedSessionScope   string
+	}{
+		{"read:statuses write", http.StatusSeeOther, "/auth" + auth.AuthSignInPath, "read:statuses write"},
+		{"", http.StatusSeeOther, "/auth" + auth.AuthSignInPath, "read"},
+		{"read admin:write", http.StatusBadRequest, "", ""},
+		{"read:everything", http.StatusBadRequest, "", ""},
+	} {
+		query := url.Values{
+			"response_type": {"code"},
+			"client_id":     {app.ClientID},
+			"redirect_uri":  {app.RedirectURI},
+			"scope":         {test.scope},
+		}
+
+		ctx, recorder := suite.newContext(http.MethodGet, auth.OauthAuthorizePath+"?"+query.Encode(), nil, "")
+
+		suite.authModule.AuthorizeGETHandler(ctx)
+
+		suite.Equal(test.expectedStatusCode, recorder.Code, "failed on scope %q", test.scope)
+		suite.Equal(test.expectedLocationHeader, recorder.Header().Get("Location"), "failed on scope %q", test.scope)
+
+		if test.expectedSessionScope != "" {
+			suite.Equal(test.expectedSessionScope, sessions.Default(ctx).Get(sessionScope), "failed on scope %q", test.scope)
+		}
+	}
+}
+
 func TestAccountUpdateTestSuite(t *testing.T) {
 	suite.Run(t, new(AuthAuthorizeTestSuite))
 }
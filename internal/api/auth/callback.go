@@ -156,7 +156,7 @@ func (m *Module) CallbackGETHandler(c *gin.Context) {
 		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err), m.processor.InstanceGetV1)
 		return
 	}
-	c.Redirect(http.StatusFound, "/oauth"+OauthAuthorizePath)
+	c.Redirect(http.StatusFound, signedInRedirect(s))
 }
 
 // FinalizePOSTHandler registers the user after additional data has been provided
@@ -233,7 +233,7 @@ func (m *Module) FinalizePOSTHandler(c *gin.Context) {
 		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err), m.processor.InstanceGetV1)
 		return
 	}
-	c.Redirect(http.StatusFound, "/oauth"+OauthAuthorizePath)
+	c.Redirect(http.StatusFound, signedInRedirect(s))
 }
 
 func (m *Module) fetchUserForClaims(ctx context.Context, claims *oidc.Claims, ip net.IP, appID string) (*gtsmodel.User, gtserror.WithCode) {
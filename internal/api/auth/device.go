@@ -0,0 +1,299 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type deviceAuthorizationRequestForm struct {
+	ClientID     string `form:"client_id" json:"client_id" xml:"client_id"`
+	ClientSecret string `form:"client_secret" json:"client_secret" xml:"client_secret"`
+	Scope        string `form:"scope" json:"scope" xml:"scope"`
+}
+
+type deviceResolveForm struct {
+	UserCode string `form:"user_code"`
+	Action   string `form:"action"`
+}
+
+// DeviceCodePOSTHandler should be served as a POST at https://example.org/oauth/device/code
+// It starts the device authorization grant described in RFC 8628, returning a device code
+// for the client to poll the token endpoint with, and a user code for the user to enter at
+// https://example.org/oauth/device. Clients may authenticate using either the request form,
+// or HTTP basic auth; the client secret is optional, for clients that can't keep it secret.
+func (m *Module) DeviceCodePOSTHandler(c *gin.Context) {
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &deviceAuthorizationRequestForm{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.OAuthErrorHandler(c, gtserror.NewErrorBadRequest(oauth.InvalidRequest(), err.Error()))
+		return
+	}
+
+	if clientID, clientSecret, ok := c.Request.BasicAuth(); ok {
+		form.ClientID = clientID
+		form.ClientSecret = clientSecret
+	}
+
+	resp, errWithCode := m.processor.OAuthDeviceAuthorize(c.Request.Context(), form.ClientID, form.ClientSecret, form.Scope)
+	if errWithCode != nil {
+		apiutil.OAuthErrorHandler(c, errWithCode)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeviceGETHandler should be served as GET at https://example.org/oauth/device
+// It presents a page where the user can enter the user code shown on their device,
+// and then approve or deny the application that's trying to authorize. If the user
+// isn't signed in yet once they've entered a valid code, they're sent to the sign in
+// page first, and then brought back here.
+func (m *Module) DeviceGETHandler(c *gin.Context) {
+	if _, err := apiutil.NegotiateAccept(c, apiutil.HTMLAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	s := sessions.Default(c)
+	userCode := c.Query("user_code")
+
+	// UserID will be set in the session if the user has already signed in.
+	userID, _ := s.Get(sessionUserID).(string)
+
+	var acct *gtsmodel.Account
+	if userID != "" {
+		var (
+			user        *gtsmodel.User
+			errWithCode gtserror.WithCode
+		)
+
+		user, acct, errWithCode = m.sessionUserAccount(c.Request.Context(), userID)
+		if errWithCode != nil {
+			m.clearSession(s)
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		if ensureUserIsAuthorizedOrRedirect(c, user, acct) {
+			return
+		}
+	}
+
+	instance, errWithCode := m.processor.InstanceGetV1(c.Request.Context())
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	obj := gin.H{
+		"instance": instance,
+		"userCode": userCode,
+	}
+
+	if acct != nil {
+		obj["user"] = acct.Username
+	}
+
+	if userCode == "" {
+		// No code entered yet, just show the form.
+		c.HTML(http.StatusOK, "device.tmpl", obj)
+		return
+	}
+
+	da, errWithCode := m.processor.OAuthGetDeviceAuthorization(c.Request.Context(), userCode)
+	if errWithCode != nil {
+		// Show the form again so the
+		// user can correct their typo.
+		obj["error"] = errWithCode.Safe()
+		c.HTML(errWithCode.Code(), "device.tmpl", obj)
+		return
+	}
+
+	if userID == "" {
+		// Remember the code and client so we can pick up
+		// where we left off once the user has signed in.
+		s.Set(sessionDeviceUserCode, da.UserCode)
+		s.Set(sessionClientID, da.ClientID)
+		s.Set(sessionInternalState, uuid.NewString())
+		if err := s.Save(); err != nil {
+			err := fmt.Errorf("error saving device user code onto session: %s", err)
+			apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+			return
+		}
+
+		c.Redirect(http.StatusSeeOther, "/auth"+AuthSignInPath)
+		return
+	}
+
+	app, errWithCode := m.deviceApplication(c.Request.Context(), da.ClientID)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Show the application and the scope it's after; the
+	// user can then approve or deny it, which will POST to
+	// the DevicePOSTHandler.
+	obj["appname"] = app.Name
+	obj["appwebsite"] = app.Website
+	obj["scope"] = da.Scope
+	obj["userCode"] = da.UserCode
+	c.HTML(http.StatusOK, "device.tmpl", obj)
+}
+
+// DevicePOSTHandler should be served as POST at https://example.org/oauth/device
+// It approves or denies the device authorization for the submitted user code, on
+// behalf of the signed in user. Once approved, the device polling the token endpoint
+// will receive a token for the user.
+func (m *Module) DevicePOSTHandler(c *gin.Context) {
+	s := sessions.Default(c)
+
+	form := &deviceResolveForm{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	var approve bool
+	switch form.Action {
+	case "approve":
+		approve = true
+	case "deny":
+		approve = false
+	default:
+		err := fmt.Errorf("action %q was not recognised, must be approve or deny", form.Action)
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	userID, ok := s.Get(sessionUserID).(string)
+	if !ok || userID == "" {
+		err := fmt.Errorf("key %s was not found in session", sessionUserID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	user, acct, errWithCode := m.sessionUserAccount(c.Request.Context(), userID)
+	if errWithCode != nil {
+		m.clearSession(s)
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if ensureUserIsAuthorizedOrRedirect(c, user, acct) {
+		return
+	}
+
+	da, errWithCode := m.processor.OAuthGetDeviceAuthorization(c.Request.Context(), form.UserCode)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	app, errWithCode := m.deviceApplication(c.Request.Context(), da.ClientID)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if errWithCode := m.processor.OAuthResolveDeviceAuthorization(c.Request.Context(), da.UserCode, user.ID, approve); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// we're done with the session now, so just clear it out
+	m.clearSession(s)
+
+	instance, errWithCode := m.processor.InstanceGetV1(c.Request.Context())
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	c.HTML(http.StatusOK, "device.tmpl", gin.H{
+		"instance": instance,
+		"resolved": true,
+		"approved": approve,
+		"appname":  app.Name,
+	})
+}
+
+// sessionUserAccount gets the user with the given ID from
+// the session, and the account belonging to that user.
+func (m *Module) sessionUserAccount(ctx context.Context, userID string) (*gtsmodel.User, *gtsmodel.Account, gtserror.WithCode) {
+	user, err := m.db.GetUserByID(ctx, userID)
+	if err != nil {
+		safe := fmt.Sprintf("user with id %s could not be retrieved", userID)
+		if errors.Is(err, db.ErrNoEntries) {
+			return nil, nil, gtserror.NewErrorBadRequest(err, safe, oauth.HelpfulAdvice)
+		}
+		return nil, nil, gtserror.NewErrorInternalError(err, safe, oauth.HelpfulAdvice)
+	}
+
+	acct, err := m.db.GetAccountByID(ctx, user.AccountID)
+	if err != nil {
+		safe := fmt.Sprintf("account with id %s could not be retrieved", user.AccountID)
+		if errors.Is(err, db.ErrNoEntries) {
+			return nil, nil, gtserror.NewErrorBadRequest(err, safe, oauth.HelpfulAdvice)
+		}
+		return nil, nil, gtserror.NewErrorInternalError(err, safe, oauth.HelpfulAdvice)
+	}
+
+	return user, acct, nil
+}
+
+// deviceApplication gets the application
+// belonging to the client with the given ID.
+func (m *Module) deviceApplication(ctx context.Context, clientID string) (*gtsmodel.Application, gtserror.WithCode) {
+	app := &gtsmodel.Application{}
+	if err := m.db.GetWhere(ctx, []db.Where{{Key: sessionClientID, Value: clientID}}, app); err != nil {
+		err := fmt.Errorf("application for %s %s could not be retrieved: %w", sessionClientID, clientID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	return app, nil
+}
+
+// signedInRedirect returns where to send a user once they've
+// signed in: back to the device page if that's where they came
+// from, otherwise on to the authorize page.
+func signedInRedirect(s sessions.Session) string {
+	if userCode, ok := s.Get(sessionDeviceUserCode).(string); ok && userCode != "" {
+		return oauth.DeviceVerificationPath + "?user_code=" + url.QueryEscape(userCode)
+	}
+	return "/oauth" + OauthAuthorizePath
+}
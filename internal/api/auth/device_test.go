@@ -0,0 +1,227 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/auth"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type DeviceTestSuite struct {
+	AuthStandardTestSuite
+}
+
+func (suite *DeviceTestSuite) do(handler func(*gin.Context), method string, path string, form url.Values, accept string, userID string) (int, http.Header, string) {
+	var body []byte
+	var contentType string
+	if form != nil {
+		body = []byte(form.Encode())
+		contentType = "application/x-www-form-urlencoded"
+	}
+
+	ctx, recorder := suite.newContext(method, path, body, contentType)
+	ctx.Request.Header.Set("accept", accept)
+
+	if userID != "" {
+		testSession := sessions.Default(ctx)
+		testSession.Set(sessionUserID, userID)
+		if err := testSession.Save(); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	handler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	suite.NoError(err)
+
+	return recorder.Code, recorder.Header(), string(b)
+}
+
+func (suite *DeviceTestSuite) deviceCode(form url.Values) (int, map[string]interface{}) {
+	code, _, body := suite.do(suite.authModule.DeviceCodePOSTHandler, http.MethodPost, "oauth/device/code", form, "application/json", "")
+
+	resp := map[string]interface{}{}
+	suite.NoError(json.Unmarshal([]byte(body), &resp))
+	return code, resp
+}
+
+func (suite *DeviceTestSuite) poll(deviceCode string) (int, map[string]interface{}) {
+	testClient := suite.testClients["local_account_1"]
+
+	code, _, body := suite.do(suite.authModule.TokenPOSTHandler, http.MethodPost, "oauth/token", url.Values{
+		"grant_type":  {oauth.DeviceCodeGrantType},
+		"client_id":   {testClient.ID},
+		"device_code": {deviceCode},
+	}, "application/json", "")
+
+	resp := map[string]interface{}{}
+	suite.NoError(json.Unmarshal([]byte(body), &resp))
+	return code, resp
+}
+
+func (suite *DeviceTestSuite) resolve(userCode string, action string) (int, string) {
+	testUser := suite.testUsers["local_account_1"]
+
+	code, _, body := suite.do(suite.authModule.DevicePOSTHandler, http.MethodPost, "oauth/device", url.Values{
+		"user_code": {userCode},
+		"action":    {action},
+	}, "text/html", testUser.ID)
+	return code, body
+}
+
+func (suite *DeviceTestSuite) TestDeviceFlowApprove() {
+	testClient := suite.testClients["local_account_1"]
+	testApp := suite.testApplications["application_1"]
+	testUser := suite.testUsers["local_account_1"]
+
+	code, resp := suite.deviceCode(url.Values{
+		"client_id": {testClient.ID},
+		"scope":     {"read:statuses"},
+	})
+	suite.Equal(http.StatusOK, code)
+
+	deviceCode, _ := resp["device_code"].(string)
+	userCode, _ := resp["user_code"].(string)
+	suite.NotEmpty(deviceCode)
+	suite.Regexp(`^[A-Z]{4}-[A-Z]{4}$`, userCode)
+	suite.Equal("http://localhost:8080/oauth/device", resp["verification_uri"])
+	suite.Equal("http://localhost:8080/oauth/device?user_code="+userCode, resp["verification_uri_complete"])
+	suite.EqualValues(600, resp["expires_in"])
+	suite.EqualValues(5, resp["interval"])
+
+	// The user hasn't done anything yet.
+	code, resp = suite.poll(deviceCode)
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Equal("authorization_pending", resp["error"])
+
+	// Polling again straight away is too quick.
+	code, resp = suite.poll(deviceCode)
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Equal("slow_down", resp["error"])
+
+	da := &gtsmodel.DeviceAuthorization{}
+	suite.NoError(suite.db.GetWhere(context.Background(), []db.Where{{Key: "device_code", Value: deviceCode}}, da))
+	suite.Equal(10, da.Interval)
+
+	// A signed out user entering the code is sent to sign in first...
+	code, header, _ := suite.do(suite.authModule.DeviceGETHandler, http.MethodGet, "oauth/device?user_code="+url.QueryEscape(userCode), nil, "text/html", "")
+	suite.Equal(http.StatusSeeOther, code)
+	suite.Equal("/auth"+auth.AuthSignInPath, header.Get("Location"))
+
+	// ...and once signed in they're shown the application, even
+	// if they typed the code in lowercase and without the dash.
+	typed := strings.ToLower(strings.ReplaceAll(userCode, "-", ""))
+	code, _, body := suite.do(suite.authModule.DeviceGETHandler, http.MethodGet, "oauth/device?user_code="+typed, nil, "text/html", testUser.ID)
+	suite.Equal(http.StatusOK, code)
+	suite.Contains(body, testApp.Name)
+	suite.Contains(body, "read:statuses")
+	suite.Contains(body, userCode)
+
+	code, body = suite.resolve(typed, "approve")
+	suite.Equal(http.StatusOK, code)
+	suite.Contains(body, "return to your device")
+
+	// The code can't be used a second time.
+	code, _ = suite.resolve(userCode, "approve")
+	suite.Equal(http.StatusNotFound, code)
+
+	code, resp = suite.poll(deviceCode)
+	suite.Equal(http.StatusOK, code)
+	suite.Equal("Bearer", resp["token_type"])
+	suite.Equal("read:statuses", resp["scope"])
+
+	accessToken, _ := resp["access_token"].(string)
+	token := &gtsmodel.Token{}
+	suite.NoError(suite.db.GetWhere(context.Background(), []db.Where{{Key: "access", Value: accessToken}}, token))
+	suite.Equal(testUser.ID, token.UserID)
+	suite.Equal(testClient.ID, token.ClientID)
+
+	// The device code is spent now.
+	code, resp = suite.poll(deviceCode)
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Equal("invalid_grant", resp["error"])
+}
+
+func (suite *DeviceTestSuite) TestDeviceFlowDeny() {
+	testClient := suite.testClients["local_account_1"]
+
+	code, resp := suite.deviceCode(url.Values{
+		"client_id":     {testClient.ID},
+		"client_secret": {testClient.Secret},
+	})
+	suite.Equal(http.StatusOK, code)
+
+	code, body := suite.resolve(resp["user_code"].(string), "deny")
+	suite.Equal(http.StatusOK, code)
+	suite.Contains(body, "was denied access")
+
+	code, resp = suite.poll(resp["device_code"].(string))
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Equal("access_denied", resp["error"])
+}
+
+func (suite *DeviceTestSuite) TestDeviceCodeBadScope() {
+	testClient := suite.testClients["local_account_1"]
+
+	code, resp := suite.deviceCode(url.Values{
+		"client_id": {testClient.ID},
+		"scope":     {"read admin:write"},
+	})
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Equal("invalid_scope", resp["error"])
+}
+
+func (suite *DeviceTestSuite) TestDeviceCodeWrongSecret() {
+	testClient := suite.testClients["local_account_1"]
+
+	code, resp := suite.deviceCode(url.Values{
+		"client_id":     {testClient.ID},
+		"client_secret": {"nope"},
+	})
+	suite.Equal(http.StatusUnauthorized, code)
+	suite.Equal("invalid_client", resp["error"])
+}
+
+func (suite *DeviceTestSuite) TestDevicePageUnknownCode() {
+	testUser := suite.testUsers["local_account_1"]
+
+	code, _, body := suite.do(suite.authModule.DeviceGETHandler, http.MethodGet, "oauth/device?user_code=BCDF-GHJK", nil, "text/html", testUser.ID)
+	suite.Equal(http.StatusNotFound, code)
+	suite.Contains(body, "code BCDF-GHJK not recognised, or it has expired")
+}
+
+func TestDeviceTestSuite(t *testing.T) {
+	suite.Run(t, &DeviceTestSuite{})
+}
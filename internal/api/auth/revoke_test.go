@@ -0,0 +1,145 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type RevokeTestSuite struct {
+	AuthStandardTestSuite
+}
+
+func (suite *RevokeTestSuite) revoke(form url.Values) (int, string) {
+	ctx, recorder := suite.newContext(http.MethodPost, "oauth/revoke", []byte(form.Encode()), "application/x-www-form-urlencoded")
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.authModule.RevokePOSTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	suite.NoError(err)
+
+	return recorder.Code, string(b)
+}
+
+func (suite *RevokeTestSuite) TestRevokeOK() {
+	testClient := suite.testClients["local_account_1"]
+	testToken := suite.testTokens["local_account_1"]
+
+	code, body := suite.revoke(url.Values{
+		"token":           {testToken.Access},
+		"token_type_hint": {"access_token"},
+		"client_id":       {testClient.ID},
+		"client_secret":   {testClient.Secret},
+	})
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(`{}`, body)
+
+	_, err := suite.db.GetTokenByID(context.Background(), testToken.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *RevokeTestSuite) TestRevokeBasicAuth() {
+	testClient := suite.testClients["local_account_1"]
+	testToken := suite.testTokens["local_account_1"]
+
+	ctx, recorder := suite.newContext(http.MethodPost, "oauth/revoke", []byte("token="+testToken.Access), "application/x-www-form-urlencoded")
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.SetBasicAuth(testClient.ID, testClient.Secret)
+
+	suite.authModule.RevokePOSTHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	_, err := suite.db.GetTokenByID(context.Background(), testToken.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *RevokeTestSuite) TestRevokeUnknownToken() {
+	testClient := suite.testClients["local_account_1"]
+
+	code, body := suite.revoke(url.Values{
+		"token":         {"not a real token"},
+		"client_id":     {testClient.ID},
+		"client_secret": {testClient.Secret},
+	})
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(`{}`, body)
+}
+
+func (suite *RevokeTestSuite) TestRevokeWrongSecret() {
+	testClient := suite.testClients["local_account_1"]
+	testToken := suite.testTokens["local_account_1"]
+
+	code, body := suite.revoke(url.Values{
+		"token":         {testToken.Access},
+		"client_id":     {testClient.ID},
+		"client_secret": {"nope"},
+	})
+	suite.Equal(http.StatusUnauthorized, code)
+	suite.True(strings.HasPrefix(body, `{"error":"invalid_client"`), body)
+
+	_, err := suite.db.GetTokenByID(context.Background(), testToken.ID)
+	suite.NoError(err)
+}
+
+func (suite *RevokeTestSuite) TestRevokeOtherClientsToken() {
+	testClient := suite.testClients["local_account_1"]
+	testToken := suite.testTokens["local_account_2"]
+
+	code, body := suite.revoke(url.Values{
+		"token":         {testToken.Access},
+		"client_id":     {testClient.ID},
+		"client_secret": {testClient.Secret},
+	})
+	suite.Equal(http.StatusForbidden, code)
+	suite.Equal(`{"error":"unauthorized_client","error_description":"Forbidden: token was not issued to client `+testClient.ID+`"}`, body)
+
+	dbToken := &gtsmodel.Token{}
+	err := suite.db.GetByID(context.Background(), testToken.ID, dbToken)
+	suite.NoError(err)
+}
+
+func (suite *RevokeTestSuite) TestRevokeUnsupportedTokenType() {
+	testClient := suite.testClients["local_account_1"]
+	testToken := suite.testTokens["local_account_1"]
+
+	code, body := suite.revoke(url.Values{
+		"token":           {testToken.Access},
+		"token_type_hint": {"id_token"},
+		"client_id":       {testClient.ID},
+		"client_secret":   {testClient.Secret},
+	})
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Equal(`{"error":"unsupported_token_type","error_description":"Bad Request: token_type_hint id_token is not supported"}`, body)
+}
+
+func TestRevokeTestSuite(t *testing.T) {
+	suite.Run(t, &RevokeTestSuite{})
+}
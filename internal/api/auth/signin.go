@@ -104,7 +104,7 @@ func (m *Module) SignInPOSTHandler(c *gin.Context) {
 		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
 	}
 
-	c.Redirect(http.StatusFound, "/oauth"+OauthAuthorizePath)
+	c.Redirect(http.StatusFound, signedInRedirect(s))
 }
 
 // ValidatePassword takes an email address and a password.
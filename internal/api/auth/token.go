@@ -35,6 +35,8 @@ type tokenRequestForm struct {
 	ClientID     *string `form:"client_id" json:"client_id" xml:"client_id"`
 	ClientSecret *string `form:"client_secret" json:"client_secret" xml:"client_secret"`
 	Scope        *string `form:"scope" json:"scope" xml:"scope"`
+	RefreshToken *string `form:"refresh_token" json:"refresh_token" xml:"refresh_token"`
+	DeviceCode   *string `form:"device_code" json:"device_code" xml:"device_code"`
 }
 
 // TokenPOSTHandler should be served as a POST at https://example.org/oauth/token
@@ -53,6 +55,11 @@ func (m *Module) TokenPOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if form.GrantType != nil && *form.GrantType == oauth.DeviceCodeGrantType {
+		m.deviceTokenRequest(c, form)
+		return
+	}
+
 	c.Request.Form = url.Values{}
 
 	var grantType string
@@ -60,7 +67,7 @@ func (m *Module) TokenPOSTHandler(c *gin.Context) {
 		grantType = *form.GrantType
 		c.Request.Form.Set("grant_type", grantType)
 	} else {
-		help = append(help, "grant_type was not set in the token request form, but must be set to authorization_code or client_credentials")
+		help = append(help, "grant_type was not set in the token request form, but must be set to authorization_code, client_credentials or refresh_token")
 	}
 
 	if form.ClientID != nil {
@@ -77,7 +84,7 @@ func (m *Module) TokenPOSTHandler(c *gin.Context) {
 
 	if form.RedirectURI != nil {
 		c.Request.Form.Set("redirect_uri", *form.RedirectURI)
-	} else {
+	} else if grantType != "refresh_token" {
 		help = append(help, "redirect_uri was not set in the token request form")
 	}
 
@@ -93,6 +100,16 @@ func (m *Module) TokenPOSTHandler(c *gin.Context) {
 		help = append(help, "code was not set in the token request form, but must be set since grant_type is authorization_code")
 	}
 
+	if form.RefreshToken != nil {
+		if grantType != "refresh_token" {
+			help = append(help, "a refresh_token was provided in the token request form, but grant_type was not set to refresh_token")
+		} else {
+			c.Request.Form.Set("refresh_token", *form.RefreshToken)
+		}
+	} else if grantType == "refresh_token" {
+		help = append(help, "refresh_token was not set in the token request form, but must be set since grant_type is refresh_token")
+	}
+
 	if form.Scope != nil {
 		c.Request.Form.Set("scope", *form.Scope)
 	}
@@ -112,3 +129,38 @@ func (m *Module) TokenPOSTHandler(c *gin.Context) {
 	c.Header("Pragma", "no-cache")
 	c.JSON(http.StatusOK, token)
 }
+
+// deviceTokenRequest handles a token request from a client polling
+// with a device code, which isn't supported by the oauth2 library, so
+// is handled separately. The client secret is optional for this grant,
+// and may be provided in the request form or using HTTP basic auth.
+func (m *Module) deviceTokenRequest(c *gin.Context, form *tokenRequestForm) {
+	var clientID, clientSecret, deviceCode string
+
+	if form.ClientID != nil {
+		clientID = *form.ClientID
+	}
+
+	if form.ClientSecret != nil {
+		clientSecret = *form.ClientSecret
+	}
+
+	if id, secret, ok := c.Request.BasicAuth(); ok {
+		clientID = id
+		clientSecret = secret
+	}
+
+	if form.DeviceCode != nil {
+		deviceCode = *form.DeviceCode
+	}
+
+	token, errWithCode := m.processor.OAuthHandleDeviceTokenRequest(c.Request.Context(), clientID, clientSecret, deviceCode)
+	if errWithCode != nil {
+		apiutil.OAuthErrorHandler(c, errWithCode)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+	c.JSON(http.StatusOK, token)
+}
@@ -26,7 +26,9 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/auth"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/testrig"
@@ -50,7 +52,7 @@ func (suite *TokenTestSuite) TestPOSTTokenEmptyForm() {
 	b, err := ioutil.ReadAll(result.Body)
 	suite.NoError(err)
 
-	suite.Equal(`{"error":"invalid_request","error_description":"Bad Request: grant_type was not set in the token request form, but must be set to authorization_code or client_credentials: client_id was not set in the token request form: client_secret was not set in the token request form: redirect_uri was not set in the token request form"}`, string(b))
+	suite.Equal(`{"error":"invalid_request","error_description":"Bad Request: grant_type was not set in the token request form, but must be set to authorization_code, client_credentials or refresh_token: client_id was not set in the token request form: client_secret was not set in the token request form: redirect_uri was not set in the token request form"}`, string(b))
 }
 
 func (suite *TokenTestSuite) TestRetrieveClientCredentialsOK() {
@@ -209,6 +211,106 @@ func (suite *TokenTestSuite) TestRetrieveAuthorizationCodeWrongGrantType() {
 	suite.Equal(`{"error":"invalid_request","error_description":"Bad Request: a code was provided in the token request form, but grant_type was not set to authorization_code"}`, string(b))
 }
 
+func (suite *TokenTestSuite) postTokenForm(form map[string]string) (int, []byte) {
+	requestBody, w, err := testrig.CreateMultipartFormData("", "", form)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ctx, recorder := suite.newContext(http.MethodPost, "oauth/token", requestBody.Bytes(), w.FormDataContentType())
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.authModule.TokenPOSTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	return recorder.Code, b
+}
+
+func (suite *TokenTestSuite) TestRefreshToken() {
+	// Recreate the oauth server with
+	// expiring access tokens configured.
+	config.SetOAuthAccessTokenExpiry(time.Hour)
+	suite.processor = testrig.NewTestProcessor(&suite.state, suite.federator, suite.emailSender, suite.mediaManager)
+	suite.authModule = auth.New(suite.db, suite.processor, suite.idp)
+
+	testClient := suite.testClients["local_account_1"]
+	testUserAuthorizationToken := suite.testTokens["local_account_1_user_authorization_token"]
+
+	code, b := suite.postTokenForm(map[string]string{
+		"grant_type":    "authorization_code",
+		"client_id":     testClient.ID,
+		"client_secret": testClient.Secret,
+		"redirect_uri":  "http://localhost:8080",
+		"code":          testUserAuthorizationToken.Code,
+	})
+	suite.Equal(http.StatusOK, code)
+
+	t := &apimodel.Token{}
+	if err := json.Unmarshal(b, t); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEmpty(t.AccessToken)
+	suite.NotEmpty(t.RefreshToken)
+	suite.Equal(int64(3600), t.ExpiresIn)
+
+	// Exchange the refresh token
+	// for a narrower scoped token.
+	code, b = suite.postTokenForm(map[string]string{
+		"grant_type":    "refresh_token",
+		"client_id":     testClient.ID,
+		"client_secret": testClient.Secret,
+		"refresh_token": t.RefreshToken,
+		"scope":         "read",
+	})
+	suite.Equal(http.StatusOK, code)
+
+	refreshed := &apimodel.Token{}
+	if err := json.Unmarshal(b, refreshed); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEmpty(refreshed.AccessToken)
+	suite.NotEqual(t.AccessToken, refreshed.AccessToken)
+	suite.NotEmpty(refreshed.RefreshToken)
+	suite.NotEqual(t.RefreshToken, refreshed.RefreshToken)
+	suite.Equal("read", refreshed.Scope)
+	suite.Equal(int64(3600), refreshed.ExpiresIn)
+
+	// The old token should be gone,
+	// and the new one in its place.
+	err := suite.db.GetWhere(context.Background(), []db.Where{{Key: "access", Value: t.AccessToken}}, &gtsmodel.Token{})
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	dbToken := &gtsmodel.Token{}
+	err = suite.db.GetWhere(context.Background(), []db.Where{{Key: "access", Value: refreshed.AccessToken}}, dbToken)
+	suite.NoError(err)
+	suite.Equal(suite.testUsers["local_account_1"].ID, dbToken.UserID)
+	suite.WithinDuration(time.Now().Add(time.Hour), dbToken.AccessExpiresAt, time.Minute)
+
+	// The old refresh token can't be reused.
+	code, _ = suite.postTokenForm(map[string]string{
+		"grant_type":    "refresh_token",
+		"client_id":     testClient.ID,
+		"client_secret": testClient.Secret,
+		"refresh_token": t.RefreshToken,
+	})
+	suite.Equal(http.StatusBadRequest, code)
+
+	// Nor can the scope be widened.
+	code, _ = suite.postTokenForm(map[string]string{
+		"grant_type":    "refresh_token",
+		"client_id":     testClient.ID,
+		"client_secret": testClient.Secret,
+		"refresh_token": refreshed.RefreshToken,
+		"scope":         "read write",
+	})
+	suite.Equal(http.StatusBadRequest, code)
+}
+
 func TestTokenTestSuite(t *testing.T) {
 	suite.Run(t, &TokenTestSuite{})
 }
@@ -27,10 +27,12 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/blocks"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/bookmarks"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/customemojis"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/exports"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/favourites"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/featuredtags"
 	filter "github.com/superseriousbusiness/gotosocial/internal/api/client/filters"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/followrequests"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/imports"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/instance"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/lists"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/media"
@@ -58,10 +60,12 @@ type Client struct {
 	blocks         *blocks.Module         // api/v1/blocks
 	bookmarks      *bookmarks.Module      // api/v1/bookmarks
 	customEmojis   *customemojis.Module   // api/v1/custom_emojis
+	exports        *exports.Module        // api/v1/exports
 	favourites     *favourites.Module     // api/v1/favourites
 	featuredTags   *featuredtags.Module   // api/v1/featured_tags
 	filters        *filter.Module         // api/v1/filters
 	followRequests *followrequests.Module // api/v1/follow_requests
+	imports        *imports.Module        // api/v1/import
 	instance       *instance.Module       // api/v1/instance
 	lists          *lists.Module          // api/v1/lists
 	media          *media.Module          // api/v1/media, api/v2/media
@@ -95,10 +99,12 @@ func (c *Client) Route(r router.Router, m ...gin.HandlerFunc) {
 	c.blocks.Route(h)
 	c.bookmarks.Route(h)
 	c.customEmojis.Route(h)
+	c.exports.Route(h)
 	c.favourites.Route(h)
 	c.featuredTags.Route(h)
 	c.filters.Route(h)
 	c.followRequests.Route(h)
+	c.imports.Route(h)
 	c.instance.Route(h)
 	c.lists.Route(h)
 	c.media.Route(h)
@@ -123,10 +129,12 @@ func NewClient(db db.DB, p *processing.Processor) *Client {
 		blocks:         blocks.New(p),
 		bookmarks:      bookmarks.New(p),
 		customEmojis:   customemojis.New(p),
+		exports:        exports.New(p),
 		favourites:     favourites.New(p),
 		featuredTags:   featuredtags.New(p),
 		filters:        filter.New(p),
 		followRequests: followrequests.New(p),
+		imports:        imports.New(p),
 		instance:       instance.New(p),
 		lists:          lists.New(p),
 		media:          media.New(p),
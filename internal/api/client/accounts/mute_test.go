@@ -0,0 +1,107 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package accounts_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/accounts"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type MuteTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *MuteTestSuite) postMute(path string, targetID string, form url.Values, handler gin.HandlerFunc) (int, *apimodel.Relationship) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080%s", strings.Replace(path, ":id", targetID, 1)), strings.NewReader(form.Encode()))
+	ctx.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   accounts.IDKey,
+			Value: targetID,
+		},
+	}
+
+	handler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if recorder.Code != http.StatusOK {
+		return recorder.Code, nil
+	}
+
+	relationship := &apimodel.Relationship{}
+	if err := json.Unmarshal(b, relationship); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return recorder.Code, relationship
+}
+
+func (suite *MuteTestSuite) TestMuteUnmute() {
+	targetID := suite.testAccounts["local_account_2"].ID
+
+	code, relationship := suite.postMute(accounts.MutePath, targetID, url.Values{"notifications": {"false"}}, suite.accountsModule.AccountMutePOSTHandler)
+	suite.Equal(http.StatusOK, code)
+	suite.True(relationship.Muting)
+	suite.False(relationship.MutingNotifications)
+
+	// Muting again updates notifications.
+	code, relationship = suite.postMute(accounts.MutePath, targetID, url.Values{}, suite.accountsModule.AccountMutePOSTHandler)
+	suite.Equal(http.StatusOK, code)
+	suite.True(relationship.Muting)
+	suite.True(relationship.MutingNotifications)
+
+	code, relationship = suite.postMute(accounts.UnmutePath, targetID, url.Values{}, suite.accountsModule.AccountUnmutePOSTHandler)
+	suite.Equal(http.StatusOK, code)
+	suite.False(relationship.Muting)
+	suite.False(relationship.MutingNotifications)
+}
+
+func (suite *MuteTestSuite) TestMuteSelf() {
+	code, _ := suite.postMute(accounts.MutePath, suite.testAccounts["local_account_1"].ID, url.Values{}, suite.accountsModule.AccountMutePOSTHandler)
+	suite.Equal(http.StatusNotAcceptable, code)
+}
+
+func TestMuteTestSuite(t *testing.T) {
+	suite.Run(t, new(MuteTestSuite))
+}
@@ -26,6 +26,7 @@ import (
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
@@ -53,7 +54,10 @@ import (
 //	-
 //		name: type
 //		in: formData
-//		description: Type of action to be taken, currently only supports `suspend`.
+//		description: >-
+//			Type of action to be taken, one of `disable`, `enable`, `silence`, `unsilence`,
+//			`sensitive`, `unsensitive` or `suspend`. `sensitize` and `unsensitize` are
+//			accepted as alternative names for `sensitive` and `unsensitive`.
 //		type: string
 //		required: true
 //	-
@@ -61,6 +65,19 @@ import (
 //		in: formData
 //		description: Optional text describing why this action was taken.
 //		type: string
+//	-
+//		name: report_id
+//		in: formData
+//		description: >-
+//			ID of a report to link this action to.
+//			The report must target the account, and will be resolved if it is not already.
+//		type: string
+//	-
+//		name: send_email_notification
+//		in: formData
+//		description: Email the owner of the account (if it is a local account) to explain what happened.
+//		type: boolean
+//		default: false
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -106,6 +123,11 @@ func (m *Module) AccountActionPOSTHandler(c *gin.Context) {
 		return
 	}
 
+	m.accountAction(c, authed, form)
+}
+
+// accountAction performs the action described by form on the account with ID in the path.
+func (m *Module) accountAction(c *gin.Context, authed *oauth.Auth, form *apimodel.AdminAccountActionRequest) {
 	targetAcctID := c.Param(IDKey)
 	if targetAcctID == "" {
 		err := errors.New("no account id specified")
@@ -121,3 +143,148 @@ func (m *Module) AccountActionPOSTHandler(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "OK"})
 }
+
+// AccountEnablePOSTHandler swagger:operation POST /api/v1/admin/accounts/{id}/enable adminAccountEnable
+//
+// Re-enable a local account that was previously disabled.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: id
+//		required: true
+//		in: path
+//		description: ID of the account.
+//		type: string
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: OK
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) AccountEnablePOSTHandler(c *gin.Context) {
+	m.accountUndoAction(c, gtsmodel.AdminActionEnable)
+}
+
+// AccountUnsilencePOSTHandler swagger:operation POST /api/v1/admin/accounts/{id}/unsilence adminAccountUnsilence
+//
+// Lift a silence from an account.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: id
+//		required: true
+//		in: path
+//		description: ID of the account.
+//		type: string
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: OK
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) AccountUnsilencePOSTHandler(c *gin.Context) {
+	m.accountUndoAction(c, gtsmodel.AdminActionUnsilence)
+}
+
+// AccountUnsensitivePOSTHandler swagger:operation POST /api/v1/admin/accounts/{id}/unsensitive adminAccountUnsensitive
+//
+// Stop forcing all media of an account to be marked as sensitive.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: id
+//		required: true
+//		in: path
+//		description: ID of the account.
+//		type: string
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: OK
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) AccountUnsensitivePOSTHandler(c *gin.Context) {
+	m.accountUndoAction(c, gtsmodel.AdminActionUnsensitize)
+}
+
+// accountUndoAction performs the given undo action type on the account with ID in the path.
+func (m *Module) accountUndoAction(c *gin.Context, actionType gtsmodel.AdminActionType) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	m.accountAction(c, authed, &apimodel.AdminAccountActionRequest{
+		Type: string(actionType),
+	})
+}
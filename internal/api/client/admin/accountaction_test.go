@@ -0,0 +1,177 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type AccountActionTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *AccountActionTestSuite) accountAction(path string, accountID string, form url.Values, handler func(*gin.Context)) int {
+	recorder := httptest.NewRecorder()
+	path = strings.ReplaceAll(path, ":"+admin.IDKey, accountID)
+	ctx := suite.newContext(recorder, http.MethodPost, []byte(form.Encode()), path, "application/x-www-form-urlencoded")
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   admin.IDKey,
+			Value: accountID,
+		},
+	}
+
+	handler(ctx)
+	return recorder.Code
+}
+
+func (suite *AccountActionTestSuite) TestSilenceWithReport() {
+	account := suite.testAccounts["remote_account_1"]
+	report := suite.testReports["local_account_2_report_remote_account_1"]
+
+	code := suite.accountAction(admin.AccountsActionPath, account.ID, url.Values{
+		"type":      {"silence"},
+		"text":      {"too loud"},
+		"report_id": {report.ID},
+	}, suite.adminModule.AccountActionPOSTHandler)
+	suite.Equal(http.StatusOK, code)
+
+	dbAccount, err := suite.db.GetAccountByID(context.Background(), account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(dbAccount.IsSilenced())
+
+	// Report should now be resolved, without leaking the admin's text.
+	dbReport, err := suite.db.GetReportByID(context.Background(), report.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(dbReport.ActionTakenAt.IsZero())
+	suite.Equal(suite.testAccounts["admin_account"].ID, dbReport.ActionTakenByAccountID)
+	suite.Equal("The reported account has been silenced.", dbReport.ActionTaken)
+
+	// Undo the silence again.
+	code = suite.accountAction(admin.AccountsUnsilencePath, account.ID, nil, suite.adminModule.AccountUnsilencePOSTHandler)
+	suite.Equal(http.StatusOK, code)
+
+	dbAccount, err = suite.db.GetAccountByID(context.Background(), account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(dbAccount.IsSilenced())
+}
+
+func (suite *AccountActionTestSuite) TestSilenceWrongReport() {
+	code := suite.accountAction(admin.AccountsActionPath, suite.testAccounts["local_account_1"].ID, url.Values{
+		"type":      {"silence"},
+		"report_id": {suite.testReports["local_account_2_report_remote_account_1"].ID},
+	}, suite.adminModule.AccountActionPOSTHandler)
+	suite.Equal(http.StatusBadRequest, code)
+
+	dbAccount, err := suite.db.GetAccountByID(context.Background(), suite.testAccounts["local_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(dbAccount.IsSilenced())
+}
+
+func (suite *AccountActionTestSuite) TestSensitiveAndUnsensitive() {
+	account := suite.testAccounts["remote_account_1"]
+
+	code := suite.accountAction(admin.AccountsActionPath, account.ID, url.Values{
+		"type": {"sensitive"},
+	}, suite.adminModule.AccountActionPOSTHandler)
+	suite.Equal(http.StatusOK, code)
+
+	dbAccount, err := suite.db.GetAccountByID(context.Background(), account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(dbAccount.IsSensitized())
+
+	code = suite.accountAction(admin.AccountsUnsensitivePath, account.ID, nil, suite.adminModule.AccountUnsensitivePOSTHandler)
+	suite.Equal(http.StatusOK, code)
+
+	dbAccount, err = suite.db.GetAccountByID(context.Background(), account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(dbAccount.IsSensitized())
+}
+
+func (suite *AccountActionTestSuite) TestDisableAndEnableWithEmail() {
+	account := suite.testAccounts["local_account_2"]
+
+	code := suite.accountAction(admin.AccountsActionPath, account.ID, url.Values{
+		"type":                    {"disable"},
+		"text":                    {"taking a break"},
+		"send_email_notification": {"true"},
+	}, suite.adminModule.AccountActionPOSTHandler)
+	suite.Equal(http.StatusOK, code)
+
+	user, err := suite.db.GetUserByAccountID(context.Background(), account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(*user.Disabled)
+
+	if !testrig.WaitFor(func() bool {
+		return suite.sentEmails[user.Email] != ""
+	}) {
+		suite.FailNow("timed out waiting for email")
+	}
+	suite.Equal("To: tortle.dude@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Moderation Action\r\n\r\nHello 1happyturtle!\r\n\r\nA moderator of GoToSocial Testrig Instance (http://localhost:8080) has taken action on your account:\r\n\r\nYour account has been disabled: you will no longer be able to log in.\r\n\r\nThe moderator left the following comment: taking a break\r\n\r\n", suite.sentEmails[user.Email])
+
+	code = suite.accountAction(admin.AccountsEnablePath, account.ID, nil, suite.adminModule.AccountEnablePOSTHandler)
+	suite.Equal(http.StatusOK, code)
+
+	user, err = suite.db.GetUserByAccountID(context.Background(), account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(*user.Disabled)
+}
+
+func (suite *AccountActionTestSuite) TestDisableRemote() {
+	code := suite.accountAction(admin.AccountsActionPath, suite.testAccounts["remote_account_1"].ID, url.Values{
+		"type": {"disable"},
+	}, suite.adminModule.AccountActionPOSTHandler)
+	suite.Equal(http.StatusBadRequest, code)
+}
+
+func (suite *AccountActionTestSuite) TestUnknownType() {
+	code := suite.accountAction(admin.AccountsActionPath, suite.testAccounts["remote_account_1"].ID, url.Values{
+		"type": {"yeet"},
+	}, suite.adminModule.AccountActionPOSTHandler)
+	suite.Equal(http.StatusBadRequest, code)
+}
+
+func TestAccountActionTestSuite(t *testing.T) {
+	suite.Run(t, &AccountActionTestSuite{})
+}
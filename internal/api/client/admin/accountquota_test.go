@@ -0,0 +1,112 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type AccountQuotaTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *AccountQuotaTestSuite) quotaRequest(method string, accountID string, body string) (*apimodel.MediaQuota, int) {
+	recorder := httptest.NewRecorder()
+	path := strings.ReplaceAll(admin.AccountsQuotaPath, ":"+admin.IDKey, accountID)
+	ctx := suite.newContext(recorder, method, []byte(body), path, "application/json")
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   admin.IDKey,
+			Value: accountID,
+		},
+	}
+
+	if method == http.MethodGet {
+		suite.adminModule.AccountQuotaGETHandler(ctx)
+	} else {
+		suite.adminModule.AccountQuotaPOSTHandler(ctx)
+	}
+
+	if recorder.Code != http.StatusOK {
+		return nil, recorder.Code
+	}
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	quota := &apimodel.MediaQuota{}
+	if err := json.Unmarshal(b, quota); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return quota, recorder.Code
+}
+
+func (suite *AccountQuotaTestSuite) TestAccountQuotaGet() {
+	quota, code := suite.quotaRequest(http.MethodGet, suite.testAccounts["local_account_1"].ID, "")
+	suite.Equal(http.StatusOK, code)
+	suite.EqualValues(4463269, quota.Usage)
+	suite.Zero(quota.Limit)
+	suite.False(quota.Override)
+}
+
+func (suite *AccountQuotaTestSuite) TestAccountQuotaSetAndReset() {
+	accountID := suite.testAccounts["local_account_1"].ID
+
+	quota, code := suite.quotaRequest(http.MethodPost, accountID, `{"quota":"1MiB"}`)
+	suite.Equal(http.StatusOK, code)
+	suite.EqualValues(1048576, quota.Limit)
+	suite.True(quota.Override)
+
+	// Override should be stored on the user.
+	quota, code = suite.quotaRequest(http.MethodGet, accountID, "")
+	suite.Equal(http.StatusOK, code)
+	suite.EqualValues(1048576, quota.Limit)
+	suite.True(quota.Override)
+
+	quota, code = suite.quotaRequest(http.MethodPost, accountID, `{"quota":"default"}`)
+	suite.Equal(http.StatusOK, code)
+	suite.Zero(quota.Limit)
+	suite.False(quota.Override)
+}
+
+func (suite *AccountQuotaTestSuite) TestAccountQuotaSetInvalid() {
+	_, code := suite.quotaRequest(http.MethodPost, suite.testAccounts["local_account_1"].ID, `{"quota":"lots"}`)
+	suite.Equal(http.StatusBadRequest, code)
+}
+
+func (suite *AccountQuotaTestSuite) TestAccountQuotaRemoteAccount() {
+	_, code := suite.quotaRequest(http.MethodGet, suite.testAccounts["remote_account_1"].ID, "")
+	suite.Equal(http.StatusNotFound, code)
+}
+
+func TestAccountQuotaTestSuite(t *testing.T) {
+	suite.Run(t, &AccountQuotaTestSuite{})
+}
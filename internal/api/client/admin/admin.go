@@ -21,30 +21,53 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
 
 const (
-	BasePath               = "/v1/admin"
-	EmojiPath              = BasePath + "/custom_emojis"
-	EmojiPathWithID        = EmojiPath + "/:" + IDKey
-	EmojiCategoriesPath    = EmojiPath + "/categories"
-	DomainBlocksPath       = BasePath + "/domain_blocks"
-	DomainBlocksPathWithID = DomainBlocksPath + "/:" + IDKey
-	AccountsPath           = BasePath + "/accounts"
-	AccountsPathWithID     = AccountsPath + "/:" + IDKey
-	AccountsActionPath     = AccountsPathWithID + "/action"
-	MediaCleanupPath       = BasePath + "/media_cleanup"
-	MediaRefetchPath       = BasePath + "/media_refetch"
-	ReportsPath            = BasePath + "/reports"
-	ReportsPathWithID      = ReportsPath + "/:" + IDKey
-	ReportsResolvePath     = ReportsPathWithID + "/resolve"
-	EmailPath              = BasePath + "/email"
-	EmailTestPath          = EmailPath + "/test"
+	BasePath                    = "/v1/admin"
+	EmojiPath                   = BasePath + "/custom_emojis"
+	EmojiPathWithID             = EmojiPath + "/:" + IDKey
+	EmojiCategoriesPath         = EmojiPath + "/categories"
+	DomainBlocksPath            = BasePath + "/domain_blocks"
+	DomainBlocksPathWithID      = DomainBlocksPath + "/:" + IDKey
+	EmailDomainBlocksPath       = BasePath + "/email_domain_blocks"
+	EmailDomainBlocksPathWithID = EmailDomainBlocksPath + "/:" + IDKey
+	IPBlocksPath                = BasePath + "/ip_blocks"
+	IPBlocksPathWithID          = IPBlocksPath + "/:" + IDKey
+	AccountsPath                = BasePath + "/accounts"
+	AccountsPathWithID          = AccountsPath + "/:" + IDKey
+	AccountsActionPath          = AccountsPathWithID + "/action"
+	AccountsEnablePath          = AccountsPathWithID + "/enable"
+	AccountsUnsilencePath       = AccountsPathWithID + "/unsilence"
+	AccountsUnsensitivePath     = AccountsPathWithID + "/unsensitive"
+	AccountsQuotaPath           = AccountsPathWithID + "/quota"
+	MediaCleanupPath            = BasePath + "/media_cleanup"
+	MediaRefetchPath            = BasePath + "/media_refetch"
+	ReportsPath                 = BasePath + "/reports"
+	ReportsPathWithID           = ReportsPath + "/:" + IDKey
+	ReportsResolvePath          = ReportsPathWithID + "/resolve"
+	ReportsReopenPath           = ReportsPathWithID + "/reopen"
+	ReportsAssignPath           = ReportsPathWithID + "/assign"
+	ReportsUnassignPath         = ReportsPathWithID + "/unassign"
+	ReportsNotesPath            = ReportsPathWithID + "/notes"
+	ReportsNotesPathWithID      = ReportsNotesPath + "/:" + NoteIDKey
+	ReportsHistoryPath          = ReportsPathWithID + "/history"
+	AuditLogPath                = BasePath + "/audit_log"
+	MeasuresPath                = BasePath + "/measures"
+	DimensionsPath              = BasePath + "/dimensions"
+	RetentionPath               = BasePath + "/retention"
+	EmailPath                   = BasePath + "/email"
+	InstanceRulesPath           = BasePath + "/instance/rules"
+	InstanceRulesPathWithID     = InstanceRulesPath + "/:" + IDKey
+	EmailTestPath               = EmailPath + "/test"
 
 	ExportQueryKey        = "export"
 	ImportQueryKey        = "import"
 	IDKey                 = "id"
+	NoteIDKey             = "note_id"
 	FilterQueryKey        = "filter"
 	MaxShortcodeDomainKey = "max_shortcode_domain"
 	MinShortcodeDomainKey = "min_shortcode_domain"
@@ -53,6 +76,9 @@ const (
 	ResolvedKey           = "resolved"
 	AccountIDKey          = "account_id"
 	TargetAccountIDKey    = "target_account_id"
+	ActionKey             = "action"
+	TargetTypeKey         = "target_type"
+	TargetIDKey           = "target_id"
 	MaxIDKey              = "max_id"
 	SinceIDKey            = "since_id"
 	MinIDKey              = "min_id"
@@ -70,31 +96,71 @@ func New(processor *processing.Processor) *Module {
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	// emoji stuff
-	attachHandler(http.MethodPost, EmojiPath, m.EmojiCreatePOSTHandler)
-	attachHandler(http.MethodGet, EmojiPath, m.EmojisGETHandler)
-	attachHandler(http.MethodDelete, EmojiPathWithID, m.EmojiDELETEHandler)
-	attachHandler(http.MethodGet, EmojiPathWithID, m.EmojiGETHandler)
-	attachHandler(http.MethodPatch, EmojiPathWithID, m.EmojiPATCHHandler)
-	attachHandler(http.MethodGet, EmojiCategoriesPath, m.EmojiCategoriesGETHandler)
+	attachHandler(http.MethodPost, EmojiPath, middleware.RequireScope(oauth.ScopeAdminWrite), m.EmojiCreatePOSTHandler)
+	attachHandler(http.MethodGet, EmojiPath, middleware.RequireScope(oauth.ScopeAdminRead), m.EmojisGETHandler)
+	attachHandler(http.MethodDelete, EmojiPathWithID, middleware.RequireScope(oauth.ScopeAdminWrite), m.EmojiDELETEHandler)
+	attachHandler(http.MethodGet, EmojiPathWithID, middleware.RequireScope(oauth.ScopeAdminRead), m.EmojiGETHandler)
+	attachHandler(http.MethodPatch, EmojiPathWithID, middleware.RequireScope(oauth.ScopeAdminWrite), m.EmojiPATCHHandler)
+	attachHandler(http.MethodGet, EmojiCategoriesPath, middleware.RequireScope(oauth.ScopeAdminRead), m.EmojiCategoriesGETHandler)
 
 	// domain block stuff
-	attachHandler(http.MethodPost, DomainBlocksPath, m.DomainBlocksPOSTHandler)
-	attachHandler(http.MethodGet, DomainBlocksPath, m.DomainBlocksGETHandler)
-	attachHandler(http.MethodGet, DomainBlocksPathWithID, m.DomainBlockGETHandler)
-	attachHandler(http.MethodDelete, DomainBlocksPathWithID, m.DomainBlockDELETEHandler)
+	attachHandler(http.MethodPost, DomainBlocksPath, middleware.RequireScope(oauth.ScopeAdminWriteDomainBlocks), m.DomainBlocksPOSTHandler)
+	attachHandler(http.MethodGet, DomainBlocksPath, middleware.RequireScope(oauth.ScopeAdminReadDomainBlocks), m.DomainBlocksGETHandler)
+	attachHandler(http.MethodGet, DomainBlocksPathWithID, middleware.RequireScope(oauth.ScopeAdminReadDomainBlocks), m.DomainBlockGETHandler)
+	attachHandler(http.MethodDelete, DomainBlocksPathWithID, middleware.RequireScope(oauth.ScopeAdminWriteDomainBlocks), m.DomainBlockDELETEHandler)
+
+	// email domain block stuff
+	attachHandler(http.MethodPost, EmailDomainBlocksPath, middleware.RequireScope(oauth.ScopeAdminWriteEmailDomainBlocks), m.EmailDomainBlocksPOSTHandler)
+	attachHandler(http.MethodGet, EmailDomainBlocksPath, middleware.RequireScope(oauth.ScopeAdminReadEmailDomainBlocks), m.EmailDomainBlocksGETHandler)
+	attachHandler(http.MethodGet, EmailDomainBlocksPathWithID, middleware.RequireScope(oauth.ScopeAdminReadEmailDomainBlocks), m.EmailDomainBlockGETHandler)
+	attachHandler(http.MethodDelete, EmailDomainBlocksPathWithID, middleware.RequireScope(oauth.ScopeAdminWriteEmailDomainBlocks), m.EmailDomainBlockDELETEHandler)
+
+	// ip block stuff
+	attachHandler(http.MethodPost, IPBlocksPath, middleware.RequireScope(oauth.ScopeAdminWriteIPBlocks), m.IPBlocksPOSTHandler)
+	attachHandler(http.MethodGet, IPBlocksPath, middleware.RequireScope(oauth.ScopeAdminReadIPBlocks), m.IPBlocksGETHandler)
+	attachHandler(http.MethodGet, IPBlocksPathWithID, middleware.RequireScope(oauth.ScopeAdminReadIPBlocks), m.IPBlockGETHandler)
+	attachHandler(http.MethodPut, IPBlocksPathWithID, middleware.RequireScope(oauth.ScopeAdminWriteIPBlocks), m.IPBlockPUTHandler)
+	attachHandler(http.MethodDelete, IPBlocksPathWithID, middleware.RequireScope(oauth.ScopeAdminWriteIPBlocks), m.IPBlockDELETEHandler)
 
 	// accounts stuff
-	attachHandler(http.MethodPost, AccountsActionPath, m.AccountActionPOSTHandler)
+	attachHandler(http.MethodPost, AccountsActionPath, middleware.RequireScope(oauth.ScopeAdminWriteAccounts), m.AccountActionPOSTHandler)
+	attachHandler(http.MethodPost, AccountsEnablePath, middleware.RequireScope(oauth.ScopeAdminWriteAccounts), m.AccountEnablePOSTHandler)
+	attachHandler(http.MethodPost, AccountsUnsilencePath, middleware.RequireScope(oauth.ScopeAdminWriteAccounts), m.AccountUnsilencePOSTHandler)
+	attachHandler(http.MethodPost, AccountsUnsensitivePath, middleware.RequireScope(oauth.ScopeAdminWriteAccounts), m.AccountUnsensitivePOSTHandler)
+	attachHandler(http.MethodGet, AccountsQuotaPath, middleware.RequireScope(oauth.ScopeAdminReadAccounts), m.AccountQuotaGETHandler)
+	attachHandler(http.MethodPost, AccountsQuotaPath, middleware.RequireScope(oauth.ScopeAdminWriteAccounts), m.AccountQuotaPOSTHandler)
 
 	// media stuff
-	attachHandler(http.MethodPost, MediaCleanupPath, m.MediaCleanupPOSTHandler)
-	attachHandler(http.MethodPost, MediaRefetchPath, m.MediaRefetchPOSTHandler)
+	attachHandler(http.MethodPost, MediaCleanupPath, middleware.RequireScope(oauth.ScopeAdminWrite), m.MediaCleanupPOSTHandler)
+	attachHandler(http.MethodPost, MediaRefetchPath, middleware.RequireScope(oauth.ScopeAdminWrite), m.MediaRefetchPOSTHandler)
 
 	// reports stuff
-	attachHandler(http.MethodGet, ReportsPath, m.ReportsGETHandler)
-	attachHandler(http.MethodGet, ReportsPathWithID, m.ReportGETHandler)
-	attachHandler(http.MethodPost, ReportsResolvePath, m.ReportResolvePOSTHandler)
+	attachHandler(http.MethodGet, ReportsPath, middleware.RequireScope(oauth.ScopeAdminReadReports), m.ReportsGETHandler)
+	attachHandler(http.MethodGet, ReportsPathWithID, middleware.RequireScope(oauth.ScopeAdminReadReports), m.ReportGETHandler)
+	attachHandler(http.MethodPost, ReportsResolvePath, middleware.RequireScope(oauth.ScopeAdminWriteReports), m.ReportResolvePOSTHandler)
+	attachHandler(http.MethodPost, ReportsReopenPath, middleware.RequireScope(oauth.ScopeAdminWriteReports), m.ReportReopenPOSTHandler)
+	attachHandler(http.MethodPost, ReportsAssignPath, middleware.RequireScope(oauth.ScopeAdminWriteReports), m.ReportAssignPOSTHandler)
+	attachHandler(http.MethodPost, ReportsUnassignPath, middleware.RequireScope(oauth.ScopeAdminWriteReports), m.ReportUnassignPOSTHandler)
+	attachHandler(http.MethodGet, ReportsNotesPath, middleware.RequireScope(oauth.ScopeAdminReadReports), m.ReportNotesGETHandler)
+	attachHandler(http.MethodPost, ReportsNotesPath, middleware.RequireScope(oauth.ScopeAdminWriteReports), m.ReportNotePOSTHandler)
+	attachHandler(http.MethodDelete, ReportsNotesPathWithID, middleware.RequireScope(oauth.ScopeAdminWriteReports), m.ReportNoteDELETEHandler)
+	attachHandler(http.MethodGet, ReportsHistoryPath, middleware.RequireScope(oauth.ScopeAdminReadReports), m.ReportHistoryGETHandler)
+
+	// audit log stuff
+	attachHandler(http.MethodGet, AuditLogPath, middleware.RequireScope(oauth.ScopeAdminRead), m.AuditLogGETHandler)
+
+	// metrics stuff
+	attachHandler(http.MethodPost, MeasuresPath, middleware.RequireScope(oauth.ScopeAdminRead), m.MeasuresPOSTHandler)
+	attachHandler(http.MethodPost, DimensionsPath, middleware.RequireScope(oauth.ScopeAdminRead), m.DimensionsPOSTHandler)
+	attachHandler(http.MethodPost, RetentionPath, middleware.RequireScope(oauth.ScopeAdminRead), m.RetentionPOSTHandler)
+
+	// instance rules stuff
+	attachHandler(http.MethodGet, InstanceRulesPath, middleware.RequireScope(oauth.ScopeAdminRead), m.RulesGETHandler)
+	attachHandler(http.MethodPost, InstanceRulesPath, middleware.RequireScope(oauth.ScopeAdminWrite), m.RulePOSTHandler)
+	attachHandler(http.MethodGet, InstanceRulesPathWithID, middleware.RequireScope(oauth.ScopeAdminRead), m.RuleGETHandler)
+	attachHandler(http.MethodPatch, InstanceRulesPathWithID, middleware.RequireScope(oauth.ScopeAdminWrite), m.RulePATCHHandler)
+	attachHandler(http.MethodDelete, InstanceRulesPathWithID, middleware.RequireScope(oauth.ScopeAdminWrite), m.RuleDELETEHandler)
 
 	// email stuff
-	attachHandler(http.MethodPost, EmailTestPath, m.EmailTestPOSTHandler)
+	attachHandler(http.MethodPost, EmailTestPath, middleware.RequireScope(oauth.ScopeAdminWrite), m.EmailTestPOSTHandler)
 }
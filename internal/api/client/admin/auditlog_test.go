@@ -0,0 +1,100 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type AuditLogTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *AuditLogTestSuite) getAuditLog(query url.Values) ([]*apimodel.AdminAuditLogEntry, string) {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, nil, admin.AuditLogPath+"?"+query.Encode(), "")
+
+	suite.adminModule.AuditLogGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	entries := []*apimodel.AdminAuditLogEntry{}
+	if err := json.Unmarshal(b, &entries); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return entries, recorder.Header().Get("Link")
+}
+
+func (suite *AuditLogTestSuite) TestAuditLogEmpty() {
+	entries, link := suite.getAuditLog(url.Values{})
+	suite.Empty(entries)
+	suite.Empty(link)
+}
+
+func (suite *AuditLogTestSuite) TestAuditLogAccountAction() {
+	account := suite.testAccounts["remote_account_1"]
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, []byte(url.Values{
+		"type": {"silence"},
+	}.Encode()), "/api/v1/admin/accounts/"+account.ID+"/action", "application/x-www-form-urlencoded")
+	ctx.AddParam(admin.IDKey, account.ID)
+	suite.adminModule.AccountActionPOSTHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	entries, link := suite.getAuditLog(url.Values{
+		"target_type": {"account"},
+		"target_id":   {account.ID},
+	})
+	if !suite.Len(entries, 1) {
+		suite.FailNow("")
+	}
+
+	entry := entries[0]
+	suite.Equal("silence", entry.Action)
+	suite.Equal("account", entry.TargetType)
+	suite.Equal(account.ID, entry.TargetID)
+	suite.Equal(suite.testAccounts["admin_account"].ID, entry.AccountID)
+	suite.NotNil(entry.Account)
+	suite.JSONEq(`{"silenced":{"old":false,"new":true}}`, string(entry.Diff))
+	suite.Contains(link, "/api/v1/admin/audit_log?limit=20&max_id="+entry.ID)
+
+	// Filtering on another action should give nothing.
+	entries, _ = suite.getAuditLog(url.Values{
+		"action": {"suspend"},
+	})
+	suite.Empty(entries)
+}
+
+func TestAuditLogTestSuite(t *testing.T) {
+	suite.Run(t, &AuditLogTestSuite{})
+}
@@ -0,0 +1,206 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type EmailDomainBlocksTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *EmailDomainBlocksTestSuite) do(handler gin.HandlerFunc, method string, path string, id string, body []byte, contentType string, expectedCode int) []byte {
+	if id != "" {
+		path += "/" + id
+	}
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, method, body, path, contentType)
+	if id != "" {
+		ctx.AddParam(admin.IDKey, id)
+	}
+
+	handler(ctx)
+	suite.Equal(expectedCode, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return b
+}
+
+func (suite *EmailDomainBlocksTestSuite) create(domain string, expectedCode int) []byte {
+	form := url.Values{"domain": {domain}}
+	return suite.do(suite.adminModule.EmailDomainBlocksPOSTHandler, http.MethodPost, admin.EmailDomainBlocksPath, "", []byte(form.Encode()), "application/x-www-form-urlencoded", expectedCode)
+}
+
+func (suite *EmailDomainBlocksTestSuite) importList(list string, expectedCode int) []byte {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	fw, err := w.CreateFormFile("domains", "disposable_email_blocklist.conf")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if _, err := fw.Write([]byte(list)); err != nil {
+		suite.FailNow(err.Error())
+	}
+	if err := w.Close(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, body.Bytes(), admin.EmailDomainBlocksPath+"?import=true", w.FormDataContentType())
+
+	suite.adminModule.EmailDomainBlocksPOSTHandler(ctx)
+	suite.Equal(expectedCode, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return b
+}
+
+func (suite *EmailDomainBlocksTestSuite) domains() []string {
+	b := suite.do(suite.adminModule.EmailDomainBlocksGETHandler, http.MethodGet, admin.EmailDomainBlocksPath, "", nil, "", http.StatusOK)
+
+	blocks := []*apimodel.EmailDomainBlock{}
+	if err := json.Unmarshal(b, &blocks); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	domains := []string{}
+	for _, block := range blocks {
+		domains = append(domains, block.Domain)
+	}
+	return domains
+}
+
+func (suite *EmailDomainBlocksTestSuite) TestEmailDomainBlocksGet() {
+	suite.Equal([]string{"disposable.example.org"}, suite.domains())
+}
+
+func (suite *EmailDomainBlocksTestSuite) TestEmailDomainBlockCreate() {
+	b := suite.create("@Throwaway.Example.NET", http.StatusOK)
+
+	block := &apimodel.EmailDomainBlock{}
+	if err := json.Unmarshal(b, block); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEmpty(block.ID)
+	suite.Equal("throwaway.example.net", block.Domain)
+	suite.Equal(suite.testAccounts["admin_account"].ID, block.CreatedBy)
+
+	// Creating it again should give back the same block.
+	b = suite.create("throwaway.example.net", http.StatusOK)
+	again := &apimodel.EmailDomainBlock{}
+	if err := json.Unmarshal(b, again); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(block.ID, again.ID)
+
+	suite.Equal([]string{"disposable.example.org", "throwaway.example.net"}, suite.domains())
+
+	// Sign-ups from a subdomain should now be blocked.
+	available, err := suite.db.IsEmailAvailable(context.Background(), "someone@mail.throwaway.example.net")
+	suite.False(available)
+	suite.EqualError(err, "email domain mail.throwaway.example.net is blocked")
+}
+
+func (suite *EmailDomainBlocksTestSuite) TestEmailDomainBlockCreateInvalid() {
+	b := suite.create("not a domain", http.StatusBadRequest)
+	suite.Equal(`{"error":"Bad Request: email domain 'not a domain' is not a valid domain"}`, string(b))
+}
+
+func (suite *EmailDomainBlocksTestSuite) TestEmailDomainBlocksImport() {
+	b := suite.importList(`# disposable email providers
+mailinator.example
+
+disposable.example.org
+Throwaway.Example.NET
+not a domain
+mailinator.example
+`, http.StatusOK)
+
+	blocks := []*apimodel.EmailDomainBlock{}
+	if err := json.Unmarshal(b, &blocks); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Existing, duplicate and invalid domains are skipped.
+	if !suite.Len(blocks, 2) {
+		suite.FailNow("")
+	}
+	suite.Equal("mailinator.example", blocks[0].Domain)
+	suite.Equal("throwaway.example.net", blocks[1].Domain)
+
+	suite.Equal([]string{
+		"disposable.example.org",
+		"mailinator.example",
+		"throwaway.example.net",
+	}, suite.domains())
+}
+
+func (suite *EmailDomainBlocksTestSuite) TestEmailDomainBlocksImportNoValid() {
+	b := suite.importList("# nothing here\nnot a domain\n", http.StatusBadRequest)
+	suite.Equal(`{"error":"Bad Request: EmailDomainBlocksImport: attachment contained no valid domains (1 invalid)"}`, string(b))
+}
+
+func (suite *EmailDomainBlocksTestSuite) TestEmailDomainBlockGetAndDelete() {
+	block := testrig.NewTestEmailDomainBlocks()["disposable.example.org"]
+
+	b := suite.do(suite.adminModule.EmailDomainBlockGETHandler, http.MethodGet, admin.EmailDomainBlocksPath, block.ID, nil, "", http.StatusOK)
+	got := &apimodel.EmailDomainBlock{}
+	if err := json.Unmarshal(b, got); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("disposable.example.org", got.Domain)
+
+	suite.do(suite.adminModule.EmailDomainBlockDELETEHandler, http.MethodDelete, admin.EmailDomainBlocksPath, block.ID, nil, "", http.StatusOK)
+	suite.Empty(suite.domains())
+
+	// The block should no longer apply.
+	available, err := suite.db.IsEmailAvailable(context.Background(), "someone@disposable.example.org")
+	suite.NoError(err)
+	suite.True(available)
+
+	b = suite.do(suite.adminModule.EmailDomainBlockGETHandler, http.MethodGet, admin.EmailDomainBlocksPath, block.ID, nil, "", http.StatusNotFound)
+	suite.Equal(`{"error":"Not Found"}`, string(b))
+}
+
+func TestEmailDomainBlocksTestSuite(t *testing.T) {
+	suite.Run(t, &EmailDomainBlocksTestSuite{})
+}
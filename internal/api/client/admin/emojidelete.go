@@ -99,7 +99,7 @@ func (m *Module) EmojiDELETEHandler(c *gin.Context) {
 		return
 	}
 
-	emoji, errWithCode := m.processor.Admin().EmojiDelete(c.Request.Context(), emojiID)
+	emoji, errWithCode := m.processor.Admin().EmojiDelete(c.Request.Context(), authed.Account, emojiID)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
@@ -0,0 +1,184 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type IPBlocksTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *IPBlocksTestSuite) do(handler gin.HandlerFunc, method string, id string, form url.Values, expectedCode int) []byte {
+	path := admin.IPBlocksPath
+	if id != "" {
+		path += "/" + id
+	}
+
+	var body []byte
+	if form != nil {
+		body = []byte(form.Encode())
+	}
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, method, body, path, "application/x-www-form-urlencoded")
+	if id != "" {
+		ctx.AddParam(admin.IDKey, id)
+	}
+
+	handler(ctx)
+	suite.Equal(expectedCode, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return b
+}
+
+func (suite *IPBlocksTestSuite) unmarshal(b []byte) *apimodel.AdminIPBlock {
+	block := &apimodel.AdminIPBlock{}
+	if err := json.Unmarshal(b, block); err != nil {
+		suite.FailNow(err.Error())
+	}
+	return block
+}
+
+func (suite *IPBlocksTestSuite) TestIPBlocksGet() {
+	b := suite.do(suite.adminModule.IPBlocksGETHandler, http.MethodGet, "", nil, http.StatusOK)
+
+	blocks := []*apimodel.AdminIPBlock{}
+	if err := json.Unmarshal(b, &blocks); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ips := []string{}
+	for _, block := range blocks {
+		ips = append(ips, block.IP)
+	}
+	suite.ElementsMatch([]string{
+		"198.51.100.0/24",
+		"198.51.100.64/26",
+		"2001:db8::/32",
+		"203.0.113.0/24",
+	}, ips)
+}
+
+func (suite *IPBlocksTestSuite) TestIPBlockGet() {
+	testBlock := testrig.NewTestIPBlocks()["expired"]
+	b := suite.do(suite.adminModule.IPBlockGETHandler, http.MethodGet, testBlock.ID, nil, http.StatusOK)
+
+	block := suite.unmarshal(b)
+	suite.Equal(testBlock.ID, block.ID)
+	suite.Equal("203.0.113.0/24", block.IP)
+	suite.Equal("no_access", block.Severity)
+	if suite.NotNil(block.ExpiresAt) {
+		suite.Equal("2023-08-21T10:15:00.000Z", *block.ExpiresAt)
+	}
+}
+
+func (suite *IPBlocksTestSuite) TestIPBlockCreate() {
+	b := suite.do(suite.adminModule.IPBlocksPOSTHandler, http.MethodPost, "", url.Values{
+		"ip":         {"192.0.2.17/28"},
+		"severity":   {"sign_up_block"},
+		"comment":    {"spam sign-ups"},
+		"expires_in": {"86400"},
+	}, http.StatusOK)
+
+	block := suite.unmarshal(b)
+	suite.NotEmpty(block.ID)
+	suite.Equal("192.0.2.16/28", block.IP)
+	suite.Equal("sign_up_block", block.Severity)
+	suite.Equal("spam sign-ups", block.Comment)
+	suite.NotNil(block.ExpiresAt)
+
+	// Blocking the same range again should conflict.
+	b = suite.do(suite.adminModule.IPBlocksPOSTHandler, http.MethodPost, "", url.Values{
+		"ip":       {"192.0.2.16/28"},
+		"severity": {"no_access"},
+	}, http.StatusConflict)
+	suite.Equal(`{"error":"Conflict: ip range 192.0.2.16/28 is already blocked"}`, string(b))
+}
+
+func (suite *IPBlocksTestSuite) TestIPBlockCreateSingleAddress() {
+	b := suite.do(suite.adminModule.IPBlocksPOSTHandler, http.MethodPost, "", url.Values{
+		"ip":       {"2001:db9::1"},
+		"severity": {"sign_up_requires_approval"},
+	}, http.StatusOK)
+
+	block := suite.unmarshal(b)
+	suite.Equal("2001:db9::1/128", block.IP)
+	suite.Nil(block.ExpiresAt)
+}
+
+func (suite *IPBlocksTestSuite) TestIPBlockCreateInvalid() {
+	b := suite.do(suite.adminModule.IPBlocksPOSTHandler, http.MethodPost, "", url.Values{
+		"ip":       {"192.0.2.0/24"},
+		"severity": {"everything"},
+	}, http.StatusBadRequest)
+	suite.Equal(`{"error":"Bad Request: ip block severity 'everything' was not recognized, valid options are 'sign_up_requires_approval', 'sign_up_block', 'no_access'"}`, string(b))
+
+	suite.do(suite.adminModule.IPBlocksPOSTHandler, http.MethodPost, "", url.Values{
+		"ip":       {"not an ip"},
+		"severity": {"no_access"},
+	}, http.StatusBadRequest)
+}
+
+func (suite *IPBlocksTestSuite) TestIPBlockUpdate() {
+	testBlock := testrig.NewTestIPBlocks()["sign_up_block"]
+	b := suite.do(suite.adminModule.IPBlockPUTHandler, http.MethodPut, testBlock.ID, url.Values{
+		"severity": {"no_access"},
+		"comment":  {"they came back"},
+	}, http.StatusOK)
+
+	block := suite.unmarshal(b)
+	suite.Equal(testBlock.IP, block.IP)
+	suite.Equal("no_access", block.Severity)
+	suite.Equal("they came back", block.Comment)
+
+	// Moving it onto an existing range should conflict.
+	suite.do(suite.adminModule.IPBlockPUTHandler, http.MethodPut, testBlock.ID, url.Values{
+		"ip": {"198.51.100.0/24"},
+	}, http.StatusConflict)
+}
+
+func (suite *IPBlocksTestSuite) TestIPBlockDelete() {
+	testBlock := testrig.NewTestIPBlocks()["no_access"]
+	b := suite.do(suite.adminModule.IPBlockDELETEHandler, http.MethodDelete, testBlock.ID, nil, http.StatusOK)
+	suite.Equal(testBlock.IP, suite.unmarshal(b).IP)
+
+	b = suite.do(suite.adminModule.IPBlockGETHandler, http.MethodGet, testBlock.ID, nil, http.StatusNotFound)
+	suite.Equal(`{"error":"Not Found"}`, string(b))
+}
+
+func TestIPBlocksTestSuite(t *testing.T) {
+	suite.Run(t, &IPBlocksTestSuite{})
+}
@@ -0,0 +1,344 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// MeasuresPOSTHandler swagger:operation POST /api/v1/admin/measures adminMeasures
+//
+// Get quantitative measures of instance activity, for each day of the given period.
+//
+// Available measures are new_users, active_users, interactions, opened_reports,
+// resolved_reports, and the GoToSocial specific statuses, known_instances and
+// media_storage, which are computed from daily activity rollups.
+//
+// The known_instances and media_storage measures are snapshots of the size of the
+// instance at the end of each day. Days for which no rollup was collected at the time
+// have no snapshot, so their value is 0.
+//
+// The instance_accounts, instance_statuses, instance_reports and instance_media_attachments
+// measures count activity originating from one remote domain, given as eg. instance_accounts[domain].
+//
+// Unknown measures are ignored.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	consumes:
+//	- application/json
+//	- application/xml
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: keys[]
+//		type: array
+//		items:
+//			type: string
+//		description: Keys of the measures to return.
+//		in: formData
+//		required: true
+//	-
+//		name: start_at
+//		type: string
+//		description: First day of the period to measure (eg., 2023-07-01). Defaults to 30 days before end_at.
+//		in: formData
+//	-
+//		name: end_at
+//		type: string
+//		description: Last day of the period to measure (eg., 2023-07-31). Defaults to today.
+//		in: formData
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: Requested measures.
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/adminMeasure"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) MeasuresPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.AdminMeasuresRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	// Form encoded requests give domain params
+	// as eg. instance_accounts[domain]=example.org.
+	for key, params := range map[string]**apimodel.AdminMeasureParams{
+		"instance_accounts":          &form.InstanceAccounts,
+		"instance_statuses":          &form.InstanceStatuses,
+		"instance_reports":           &form.InstanceReports,
+		"instance_media_attachments": &form.InstanceMediaAttachments,
+	} {
+		if domain := c.PostForm(key + "[domain]"); *params == nil && domain != "" {
+			*params = &apimodel.AdminMeasureParams{Domain: domain}
+		}
+	}
+
+	measures, errWithCode := m.processor.Admin().MeasuresGet(c.Request.Context(), form)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	c.JSON(http.StatusOK, measures)
+}
+
+// DimensionsPOSTHandler swagger:operation POST /api/v1/admin/dimensions adminDimensions
+//
+// Get qualitative breakdowns of instance activity in the given period.
+//
+// Available dimensions are languages (of local statuses), servers (remote domains
+// posting the most statuses), space_usage (current storage use) and software_versions.
+//
+// Unknown dimensions are ignored.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	consumes:
+//	- application/json
+//	- application/xml
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: keys[]
+//		type: array
+//		items:
+//			type: string
+//		description: Keys of the dimensions to return.
+//		in: formData
+//		required: true
+//	-
+//		name: start_at
+//		type: string
+//		description: First day of the period to look at (eg., 2023-07-01). Defaults to 30 days before end_at.
+//		in: formData
+//	-
+//		name: end_at
+//		type: string
+//		description: Last day of the period to look at (eg., 2023-07-31). Defaults to today.
+//		in: formData
+//	-
+//		name: limit
+//		type: integer
+//		description: Max number of items to return per dimension.
+//		default: 10
+//		maximum: 100
+//		in: formData
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: Requested dimensions.
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/adminDimension"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) DimensionsPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.AdminDimensionsRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	dimensions, errWithCode := m.processor.Admin().DimensionsGet(c.Request.Context(), form)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	c.JSON(http.StatusOK, dimensions)
+}
+
+// RetentionPOSTHandler swagger:operation POST /api/v1/admin/retention adminRetention
+//
+// Get user retention data for cohorts of users who signed up in each day or month of the given period.
+//
+// For each cohort, the number and rate of its users who were active (signed in, posted or faved)
+// in each following period is returned. Activity is taken from daily activity rollups.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	consumes:
+//	- application/json
+//	- application/xml
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: start_at
+//		type: string
+//		description: First day of the period to look at (eg., 2023-07-01). Defaults to 30 days before end_at.
+//		in: formData
+//	-
+//		name: end_at
+//		type: string
+//		description: Last day of the period to look at (eg., 2023-07-31). Defaults to today.
+//		in: formData
+//	-
+//		name: frequency
+//		type: string
+//		description: Size of cohort periods, either day or month.
+//		default: day
+//		in: formData
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: Retention data per cohort.
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/adminCohort"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) RetentionPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.AdminRetentionRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	cohorts, errWithCode := m.processor.Admin().RetentionGet(c.Request.Context(), form)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	c.JSON(http.StatusOK, cohorts)
+}
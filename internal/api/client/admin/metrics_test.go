@@ -0,0 +1,213 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type MetricsTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *MetricsTestSuite) post(path string, body []byte, contentType string, handler func(*gin.Context), expectedCode int, v any) {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, body, path, contentType)
+
+	handler(ctx)
+	suite.Equal(expectedCode, recorder.Code)
+	if expectedCode != http.StatusOK {
+		return
+	}
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := json.Unmarshal(b, v); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *MetricsTestSuite) TestMeasuresDefaultPeriod() {
+	measures := []*apimodel.AdminMeasure{}
+	suite.post(admin.MeasuresPath, []byte(url.Values{
+		"keys[]": {"new_users", "active_users", "media_storage", "not_a_measure"},
+	}.Encode()), "application/x-www-form-urlencoded", suite.adminModule.MeasuresPOSTHandler, http.StatusOK, &measures)
+
+	// Unknown measure should be ignored.
+	if !suite.Len(measures, 3) {
+		suite.FailNow("")
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	for _, measure := range measures {
+		if !suite.Len(measure.Data, 30) {
+			suite.FailNow("")
+		}
+		suite.Equal(today.Add(-29*24*time.Hour).Format("2006-01-02T15:04:05.000Z"), measure.Data[0].Date)
+		suite.Equal(today.Format("2006-01-02T15:04:05.000Z"), measure.Data[29].Date)
+	}
+
+	suite.Equal("new_users", measures[0].Key)
+	suite.Equal("0", measures[0].Total)
+	suite.Equal("0", measures[0].PreviousTotal)
+
+	suite.Equal("active_users", measures[1].Key)
+	suite.Equal("0", measures[1].Total)
+
+	// Storage is a snapshot of the current size.
+	suite.Equal("media_storage", measures[2].Key)
+	suite.Equal("bytes", measures[2].Unit)
+	suite.Equal("4679809", measures[2].Total)
+	suite.Equal("4.46MiB", measures[2].HumanValue)
+}
+
+func (suite *MetricsTestSuite) TestMeasuresPeriod() {
+	measures := []*apimodel.AdminMeasure{}
+	suite.post(admin.MeasuresPath, []byte(`{
+		"keys": ["opened_reports", "instance_accounts"],
+		"start_at": "2022-05-01",
+		"end_at": "2022-05-31",
+		"instance_accounts": {"domain": "example.org"}
+	}`), "application/json", suite.adminModule.MeasuresPOSTHandler, http.StatusOK, &measures)
+
+	if !suite.Len(measures, 2) {
+		suite.FailNow("")
+	}
+
+	for _, measure := range measures {
+		suite.Len(measure.Data, 31)
+		suite.Equal("2022-05-01T00:00:00.000Z", measure.Data[0].Date)
+		suite.Equal("2022-05-31T00:00:00.000Z", measure.Data[30].Date)
+	}
+
+	suite.Equal("opened_reports", measures[0].Key)
+	suite.Equal("instance_accounts", measures[1].Key)
+
+	// Missing rollups for past days should now be stored.
+	start := time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)
+	activities, err := suite.db.GetDailyActivities(context.Background(), start, start.AddDate(0, 0, 31))
+	suite.NoError(err)
+	suite.Len(activities, 31)
+
+	// But without a made up snapshot of
+	// the instance size on those days.
+	for _, activity := range activities {
+		suite.Zero(activity.KnownInstances)
+		suite.Zero(activity.LocalMediaBytes + activity.RemoteMediaBytes + activity.EmojiBytes)
+	}
+}
+
+func (suite *MetricsTestSuite) TestMeasuresInstanceNoDomain() {
+	suite.post(admin.MeasuresPath, []byte(url.Values{
+		"keys[]": {"instance_statuses"},
+	}.Encode()), "application/x-www-form-urlencoded", suite.adminModule.MeasuresPOSTHandler, http.StatusBadRequest, nil)
+}
+
+func (suite *MetricsTestSuite) TestMeasuresBadPeriod() {
+	suite.post(admin.MeasuresPath, []byte(url.Values{
+		"keys[]":   {"new_users"},
+		"start_at": {"2022-05-31"},
+		"end_at":   {"2022-05-01"},
+	}.Encode()), "application/x-www-form-urlencoded", suite.adminModule.MeasuresPOSTHandler, http.StatusBadRequest, nil)
+
+	suite.post(admin.MeasuresPath, []byte(url.Values{
+		"keys[]":   {"new_users"},
+		"start_at": {"2020-01-01"},
+		"end_at":   {"2022-01-01"},
+	}.Encode()), "application/x-www-form-urlencoded", suite.adminModule.MeasuresPOSTHandler, http.StatusBadRequest, nil)
+}
+
+func (suite *MetricsTestSuite) TestDimensions() {
+	dimensions := []*apimodel.AdminDimension{}
+	suite.post(admin.DimensionsPath, []byte(url.Values{
+		"keys[]": {"space_usage", "software_versions"},
+	}.Encode()), "application/x-www-form-urlencoded", suite.adminModule.DimensionsPOSTHandler, http.StatusOK, &dimensions)
+
+	if !suite.Len(dimensions, 2) {
+		suite.FailNow("")
+	}
+
+	suite.Equal("space_usage", dimensions[0].Key)
+	if suite.Len(dimensions[0].Data, 3) {
+		suite.Equal("media", dimensions[0].Data[0].Key)
+		suite.Equal("4532670", dimensions[0].Data[0].Value)
+		suite.Equal("bytes", dimensions[0].Data[0].Unit)
+	}
+
+	suite.Equal("software_versions", dimensions[1].Key)
+	if suite.Len(dimensions[1].Data, 3) {
+		suite.Equal("gotosocial", dimensions[1].Data[0].Key)
+		suite.NotEmpty(dimensions[1].Data[0].Value)
+	}
+}
+
+func (suite *MetricsTestSuite) TestRetention() {
+	// Collect rollups for the last week first.
+	now := time.Now()
+	if err := suite.processor.Admin().RollupActivity(context.Background(), now); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	today := now.UTC().Truncate(24 * time.Hour)
+	rollups, err := suite.db.GetDailyActivities(context.Background(), today.Add(-7*24*time.Hour), today)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(rollups, 7)
+
+	cohorts := []*apimodel.AdminCohort{}
+	suite.post(admin.RetentionPath, []byte(url.Values{
+		"frequency": {"day"},
+	}.Encode()), "application/x-www-form-urlencoded", suite.adminModule.RetentionPOSTHandler, http.StatusOK, &cohorts)
+
+	if !suite.Len(cohorts, 30) {
+		suite.FailNow("")
+	}
+
+	for i, cohort := range cohorts {
+		suite.Equal("day", cohort.Frequency)
+		suite.Len(cohort.Data, 30-i)
+		suite.Equal(cohort.Period, cohort.Data[0].Date)
+		suite.Equal("0", cohort.Data[0].Value)
+	}
+}
+
+func (suite *MetricsTestSuite) TestRetentionBadFrequency() {
+	suite.post(admin.RetentionPath, []byte(url.Values{
+		"frequency": {"fortnight"},
+	}.Encode()), "application/x-www-form-urlencoded", suite.adminModule.RetentionPOSTHandler, http.StatusBadRequest, nil)
+}
+
+func TestMetricsTestSuite(t *testing.T) {
+	suite.Run(t, &MetricsTestSuite{})
+}
@@ -0,0 +1,137 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type ReportAssignTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *ReportAssignTestSuite) do(handler gin.HandlerFunc, path string, reportID string, form url.Values, expectedCode int) []byte {
+	var body []byte
+	if form != nil {
+		body = []byte(form.Encode())
+	}
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, body, "/api"+admin.ReportsPath+"/"+reportID+path, "application/x-www-form-urlencoded")
+	ctx.AddParam(admin.IDKey, reportID)
+
+	handler(ctx)
+	suite.Equal(expectedCode, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return b
+}
+
+func (suite *ReportAssignTestSuite) report(b []byte) *apimodel.AdminReport {
+	report := &apimodel.AdminReport{}
+	if err := json.Unmarshal(b, report); err != nil {
+		suite.FailNow(err.Error())
+	}
+	return report
+}
+
+func (suite *ReportAssignTestSuite) history(reportID string) []string {
+	b := suite.do(suite.adminModule.ReportHistoryGETHandler, "/history", reportID, nil, http.StatusOK)
+
+	entries := []*apimodel.AdminAuditLogEntry{}
+	if err := json.Unmarshal(b, &entries); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	actions := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		actions = append(actions, entry.Action)
+	}
+	return actions
+}
+
+func (suite *ReportAssignTestSuite) TestAssignToSelfAndUnassign() {
+	reportID := suite.testReports["local_account_2_report_remote_account_1"].ID
+	adminAccount := suite.testAccounts["admin_account"]
+
+	report := suite.report(suite.do(suite.adminModule.ReportAssignPOSTHandler, "/assign", reportID, url.Values{}, http.StatusOK))
+	suite.Equal(adminAccount.ID, report.AssignedAccount.ID)
+
+	report = suite.report(suite.do(suite.adminModule.ReportUnassignPOSTHandler, "/unassign", reportID, nil, http.StatusOK))
+	suite.Nil(report.AssignedAccount)
+
+	suite.Equal([]string{"unassign", "assign"}, suite.history(reportID))
+}
+
+func (suite *ReportAssignTestSuite) TestAssignToNonModerator() {
+	reportID := suite.testReports["local_account_2_report_remote_account_1"].ID
+	zork := suite.testAccounts["local_account_1"]
+
+	b := suite.do(suite.adminModule.ReportAssignPOSTHandler, "/assign", reportID, url.Values{
+		"account_id": {zork.ID},
+	}, http.StatusBadRequest)
+	suite.Equal(`{"error":"Bad Request: account 01F8MH1H7YV1Z7D2C8K2730QBF is not a moderator"}`, string(b))
+	suite.Empty(suite.history(reportID))
+}
+
+func (suite *ReportAssignTestSuite) TestResolveAssignsResolver() {
+	reportID := suite.testReports["local_account_2_report_remote_account_1"].ID
+	adminAccount := suite.testAccounts["admin_account"]
+
+	report := suite.report(suite.do(suite.adminModule.ReportResolvePOSTHandler, "/resolve", reportID, nil, http.StatusOK))
+	suite.True(report.ActionTaken)
+	suite.Equal(adminAccount.ID, report.AssignedAccount.ID)
+}
+
+func (suite *ReportAssignTestSuite) TestReopen() {
+	reportID := suite.testReports["remote_account_1_report_local_account_2"].ID
+
+	report := suite.report(suite.do(suite.adminModule.ReportReopenPOSTHandler, "/reopen", reportID, nil, http.StatusOK))
+	suite.False(report.ActionTaken)
+	suite.Nil(report.ActionTakenAt)
+	suite.Nil(report.ActionTakenByAccount)
+	suite.Nil(report.ActionTakenComment)
+
+	// Reopening leaves the
+	// assigned moderator alone.
+	suite.NotNil(report.AssignedAccount)
+
+	suite.Equal([]string{"reopen"}, suite.history(reportID))
+}
+
+func (suite *ReportAssignTestSuite) TestReopenNotFound() {
+	suite.do(suite.adminModule.ReportReopenPOSTHandler, "/reopen", "01H7F0Q5XWJ0B1M7V2D7QG3N8T", nil, http.StatusNotFound)
+}
+
+func TestReportAssignTestSuite(t *testing.T) {
+	suite.Run(t, &ReportAssignTestSuite{})
+}
@@ -0,0 +1,190 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ReportNotesTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *ReportNotesTestSuite) do(handler gin.HandlerFunc, reportID string, noteID string, form url.Values, expectedCode int) []byte {
+	path := "/api" + admin.ReportsPath + "/" + reportID + "/notes"
+	if noteID != "" {
+		path += "/" + noteID
+	}
+
+	var body []byte
+	if form != nil {
+		body = []byte(form.Encode())
+	}
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, body, path, "application/x-www-form-urlencoded")
+	ctx.AddParam(admin.IDKey, reportID)
+	if noteID != "" {
+		ctx.AddParam(admin.NoteIDKey, noteID)
+	}
+
+	handler(ctx)
+	suite.Equal(expectedCode, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return b
+}
+
+func (suite *ReportNotesTestSuite) getNotes(reportID string) []*apimodel.AdminReportNote {
+	b := suite.do(suite.adminModule.ReportNotesGETHandler, reportID, "", nil, http.StatusOK)
+
+	notes := []*apimodel.AdminReportNote{}
+	if err := json.Unmarshal(b, &notes); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return notes
+}
+
+func (suite *ReportNotesTestSuite) TestReportNotesGet() {
+	report := suite.testReports["local_account_2_report_remote_account_1"]
+
+	notes := suite.getNotes(report.ID)
+	if !suite.Len(notes, 1) {
+		suite.FailNow("")
+	}
+
+	note := notes[0]
+	suite.Equal("01H7EY2Z3N0B4QW8V5KJ6A1XCM", note.ID)
+	suite.Equal(report.ID, note.ReportID)
+	suite.Nil(note.InReplyToID)
+	suite.Equal("admin", note.Account.Username)
+	suite.Equal("Looked at the reported post, it does seem to be a bit much. Will check with the remote admin.", note.Content)
+}
+
+func (suite *ReportNotesTestSuite) TestReportNotesGetNone() {
+	report := suite.testReports["remote_account_1_report_local_account_2"]
+	suite.Empty(suite.getNotes(report.ID))
+}
+
+func (suite *ReportNotesTestSuite) TestReportNoteCreateNotifiesModerators() {
+	var (
+		ctx    = context.Background()
+		report = suite.testReports["local_account_2_report_remote_account_1"]
+		parent = testrig.NewTestReportNotes()["admin_account_note_on_local_account_2_report"]
+	)
+
+	// Make zork a moderator, so that
+	// there's someone else to notify.
+	user := new(gtsmodel.User)
+	*user = *suite.testUsers["local_account_1"]
+	user.Moderator = testrig.TrueBool()
+	if err := suite.db.UpdateUser(ctx, user, "moderator"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	b := suite.do(suite.adminModule.ReportNotePOSTHandler, report.ID, "", url.Values{
+		"content":        {"Remote admin says they've dealt with it."},
+		"in_reply_to_id": {parent.ID},
+	}, http.StatusOK)
+
+	note := &apimodel.AdminReportNote{}
+	if err := json.Unmarshal(b, note); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEmpty(note.ID)
+	suite.Equal(parent.ID, *note.InReplyToID)
+	suite.Equal("Remote admin says they've dealt with it.", note.Content)
+	suite.Len(suite.getNotes(report.ID), 2)
+
+	// Zork should be emailed...
+	if !testrig.WaitFor(func() bool {
+		return suite.sentEmails[user.Email] != ""
+	}) {
+		suite.FailNow("timed out waiting for email")
+	}
+	suite.Equal("To: zork@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial New Report Note\r\n\r\nHello moderator of GoToSocial Testrig Instance (http://localhost:8080)!\r\n\r\nadmin left a note on a report:\r\n\r\nRemote admin says they've dealt with it.\r\n\r\nTo view the report, paste the following link into your browser: http://localhost:8080/settings/admin/reports/01GP3AWY4CRDVRNZKW0TEAMB5R\r\n\r\n", suite.sentEmails[user.Email])
+
+	// ...and notified in-app, but the
+	// note author should get neither.
+	notifs, err := suite.db.GetAccountNotifications(ctx, user.AccountID, "", "", "", 20, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.NotificationReportNote, notifs[0].NotificationType)
+	suite.Equal(report.ID, notifs[0].ReportID)
+	suite.Equal(suite.testAccounts["admin_account"].ID, notifs[0].OriginAccountID)
+
+	suite.Empty(suite.sentEmails[suite.testUsers["admin_account"].Email])
+}
+
+func (suite *ReportNotesTestSuite) TestReportNoteCreateEmpty() {
+	report := suite.testReports["local_account_2_report_remote_account_1"]
+
+	b := suite.do(suite.adminModule.ReportNotePOSTHandler, report.ID, "", url.Values{
+		"content": {""},
+	}, http.StatusBadRequest)
+	suite.Equal(`{"error":"Bad Request: note content must be provided, and must be no more than 5000 chars"}`, string(b))
+}
+
+func (suite *ReportNotesTestSuite) TestReportNoteCreateReplyToOtherReport() {
+	report := suite.testReports["remote_account_1_report_local_account_2"]
+	parent := testrig.NewTestReportNotes()["admin_account_note_on_local_account_2_report"]
+
+	b := suite.do(suite.adminModule.ReportNotePOSTHandler, report.ID, "", url.Values{
+		"content":        {"this note is on the wrong report"},
+		"in_reply_to_id": {parent.ID},
+	}, http.StatusBadRequest)
+	suite.Equal(`{"error":"Bad Request: in_reply_to_id 01H7EY2Z3N0B4QW8V5KJ6A1XCM is not a note on report 01GP3DFY9XQ1TJMZT5BGAZPXX7"}`, string(b))
+}
+
+func (suite *ReportNotesTestSuite) TestReportNoteDelete() {
+	report := suite.testReports["local_account_2_report_remote_account_1"]
+	note := testrig.NewTestReportNotes()["admin_account_note_on_local_account_2_report"]
+
+	suite.do(suite.adminModule.ReportNoteDELETEHandler, report.ID, note.ID, nil, http.StatusOK)
+	suite.Empty(suite.getNotes(report.ID))
+}
+
+func (suite *ReportNotesTestSuite) TestReportNoteDeleteWrongReport() {
+	report := suite.testReports["remote_account_1_report_local_account_2"]
+	note := testrig.NewTestReportNotes()["admin_account_note_on_local_account_2_report"]
+
+	suite.do(suite.adminModule.ReportNoteDELETEHandler, report.ID, note.ID, nil, http.StatusNotFound)
+}
+
+func TestReportNotesTestSuite(t *testing.T) {
+	suite.Run(t, &ReportNotesTestSuite{})
+}
@@ -161,6 +161,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "confirmed": false,
       "approved": false,
       "disabled": false,
+      "sensitized": false,
       "silenced": false,
       "suspended": false,
       "account": {
@@ -202,6 +203,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "confirmed": true,
       "approved": true,
       "disabled": false,
+      "sensitized": false,
       "silenced": false,
       "suspended": false,
       "account": {
@@ -258,6 +260,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "confirmed": true,
       "approved": true,
       "disabled": false,
+      "sensitized": false,
       "silenced": false,
       "suspended": false,
       "account": {
@@ -304,6 +307,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "confirmed": true,
       "approved": true,
       "disabled": false,
+      "sensitized": false,
       "silenced": false,
       "suspended": false,
       "account": {
@@ -335,7 +339,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "created_by_application_id": "01F8MGXQRHYF5QPMTMXP78QC2F"
     },
     "statuses": [],
-    "rule_ids": [],
+    "rules": [],
     "action_taken_comment": "user was warned not to be a turtle anymore"
   },
   {
@@ -363,6 +367,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "confirmed": true,
       "approved": true,
       "disabled": false,
+      "sensitized": false,
       "silenced": false,
       "suspended": false,
       "account": {
@@ -419,6 +424,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "confirmed": false,
       "approved": false,
       "disabled": false,
+      "sensitized": false,
       "silenced": false,
       "suspended": false,
       "account": {
@@ -528,7 +534,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
         "poll": null
       }
     ],
-    "rule_ids": [],
+    "rules": [],
     "action_taken_comment": null
   }
 ]`, string(b))
@@ -575,6 +581,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
       "confirmed": true,
       "approved": true,
       "disabled": false,
+      "sensitized": false,
       "silenced": false,
       "suspended": false,
       "account": {
@@ -631,6 +638,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
       "confirmed": false,
       "approved": false,
       "disabled": false,
+      "sensitized": false,
       "silenced": false,
       "suspended": false,
       "account": {
@@ -740,7 +748,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
         "poll": null
       }
     ],
-    "rule_ids": [],
+    "rules": [],
     "action_taken_comment": null
   }
 ]`, string(b))
@@ -787,6 +795,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
       "confirmed": true,
       "approved": true,
       "disabled": false,
+      "sensitized": false,
       "silenced": false,
       "suspended": false,
       "account": {
@@ -843,6 +852,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
       "confirmed": false,
       "approved": false,
       "disabled": false,
+      "sensitized": false,
       "silenced": false,
       "suspended": false,
       "account": {
@@ -952,7 +962,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
         "poll": null
       }
     ],
-    "rule_ids": [],
+    "rules": [],
     "action_taken_comment": null
   }
 ]`, string(b))
@@ -0,0 +1,185 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type RulesTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *RulesTestSuite) do(handler gin.HandlerFunc, method string, id string, form url.Values, expectedCode int) []byte {
+	path := admin.InstanceRulesPath
+	if id != "" {
+		path += "/" + id
+	}
+
+	var body []byte
+	if form != nil {
+		body = []byte(form.Encode())
+	}
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, method, body, path, "application/x-www-form-urlencoded")
+	if id != "" {
+		ctx.AddParam(admin.IDKey, id)
+	}
+
+	handler(ctx)
+	suite.Equal(expectedCode, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return b
+}
+
+func (suite *RulesTestSuite) getRules() []*apimodel.AdminInstanceRule {
+	b := suite.do(suite.adminModule.RulesGETHandler, http.MethodGet, "", nil, http.StatusOK)
+
+	rules := []*apimodel.AdminInstanceRule{}
+	if err := json.Unmarshal(b, &rules); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return rules
+}
+
+func (suite *RulesTestSuite) ruleTexts() []string {
+	texts := []string{}
+	for _, rule := range suite.getRules() {
+		texts = append(texts, rule.Text)
+	}
+	return texts
+}
+
+func (suite *RulesTestSuite) TestRulesGet() {
+	rules := suite.getRules()
+
+	// Deleted rule shouldn't be included.
+	if !suite.Len(rules, 2) {
+		suite.FailNow("")
+	}
+	suite.Equal("01H6Y8HD6R6C0N5M3MHW3X4PZ1", rules[0].ID)
+	suite.Equal("Be nice to each other.", rules[0].Text)
+	suite.Equal(0, rules[0].Order)
+	suite.Equal("01H6Y8J2ZK0QEB9G5XG6Q7VCA2", rules[1].ID)
+	suite.Equal(1, rules[1].Order)
+}
+
+func (suite *RulesTestSuite) TestRuleGetDeleted() {
+	deleted := testrig.NewTestRules()["deleted_rule"]
+	b := suite.do(suite.adminModule.RuleGETHandler, http.MethodGet, deleted.ID, nil, http.StatusNotFound)
+	suite.Equal(`{"error":"Not Found"}`, string(b))
+}
+
+func (suite *RulesTestSuite) TestRuleCreate() {
+	b := suite.do(suite.adminModule.RulePOSTHandler, http.MethodPost, "", url.Values{
+		"text": {"No posting before coffee."},
+	}, http.StatusOK)
+
+	rule := &apimodel.AdminInstanceRule{}
+	if err := json.Unmarshal(b, rule); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEmpty(rule.ID)
+	suite.Equal("No posting before coffee.", rule.Text)
+	suite.Equal(2, rule.Order)
+
+	suite.Equal([]string{
+		"Be nice to each other.",
+		"No advertising or spam.",
+		"No posting before coffee.",
+	}, suite.ruleTexts())
+}
+
+func (suite *RulesTestSuite) TestRuleCreateEmpty() {
+	b := suite.do(suite.adminModule.RulePOSTHandler, http.MethodPost, "", url.Values{
+		"text": {""},
+	}, http.StatusBadRequest)
+	suite.Equal(`{"error":"Bad Request: rule text must be provided, and must be no more than 1000 chars"}`, string(b))
+}
+
+func (suite *RulesTestSuite) TestRuleUpdate() {
+	// Add a third rule, then move it to the top
+	// while changing its text at the same time.
+	b := suite.do(suite.adminModule.RulePOSTHandler, http.MethodPost, "", url.Values{
+		"text": {"No posting before coffee."},
+	}, http.StatusOK)
+
+	rule := &apimodel.AdminInstanceRule{}
+	if err := json.Unmarshal(b, rule); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	b = suite.do(suite.adminModule.RulePATCHHandler, http.MethodPatch, rule.ID, url.Values{
+		"text":  {"No posting before tea."},
+		"order": {"0"},
+	}, http.StatusOK)
+
+	if err := json.Unmarshal(b, rule); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("No posting before tea.", rule.Text)
+	suite.Equal(0, rule.Order)
+
+	suite.Equal([]string{
+		"No posting before tea.",
+		"Be nice to each other.",
+		"No advertising or spam.",
+	}, suite.ruleTexts())
+}
+
+func (suite *RulesTestSuite) TestRuleUpdateOrderOutOfRange() {
+	rule := testrig.NewTestRules()["rule1"]
+	b := suite.do(suite.adminModule.RulePATCHHandler, http.MethodPatch, rule.ID, url.Values{
+		"order": {"2"},
+	}, http.StatusBadRequest)
+	suite.Equal(`{"error":"Bad Request: rule order must be between 0 and 1"}`, string(b))
+}
+
+func (suite *RulesTestSuite) TestRuleDelete() {
+	rule := testrig.NewTestRules()["rule1"]
+	suite.do(suite.adminModule.RuleDELETEHandler, http.MethodDelete, rule.ID, nil, http.StatusOK)
+
+	suite.Equal([]string{
+		"No advertising or spam.",
+	}, suite.ruleTexts())
+
+	// Deleting again should be not found.
+	suite.do(suite.adminModule.RuleDELETEHandler, http.MethodDelete, rule.ID, nil, http.StatusNotFound)
+}
+
+func TestRulesTestSuite(t *testing.T) {
+	suite.Run(t, &RulesTestSuite{})
+}
@@ -0,0 +1,140 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package imports
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// ImportCreatePOSTHandler swagger:operation POST /api/v1/import importCreate
+//
+// Import a CSV file of follows, blocks, mutes, bookmarks or lists, or an archive of posts, as exported from Mastodon or GoToSocial.
+//
+// The file is imported in the background; use the returned import to check on its progress,
+// and on any rows which couldn't be imported. Only one import can be in progress at a time.
+//
+// Importing domain blocks is not supported, as GoToSocial doesn't support those for users yet.
+//
+// Archives (zip or tar.gz) are imported by recreating the public, unlisted and followers-only posts in their
+// `outbox.json` as your own posts, keeping their original dates and re-uploading their media. Replies to
+// other posts in the archive are threaded together again. Posts already imported from the archive are skipped.
+//
+//	---
+//	tags:
+//	- import
+//
+//	consumes:
+//	- multipart/form-data
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: data
+//		in: formData
+//		description: The CSV file or archive to import.
+//		type: file
+//		required: true
+//	-
+//		name: type
+//		in: formData
+//		description: What kind of data is imported.
+//		type: string
+//		enum:
+//			- following
+//			- blocks
+//			- muting
+//			- bookmarks
+//			- lists
+//			- archive
+//		required: true
+//	-
+//		name: mode
+//		in: formData
+//		description: >-
+//			Whether to keep existing data not in the file (merge),
+//			or remove it (overwrite).
+//		type: string
+//		enum:
+//			- merge
+//			- overwrite
+//		default: merge
+//	-
+//		name: federate
+//		in: formData
+//		description: >-
+//			When importing an archive, whether to send imported posts out to your followers,
+//			as if they'd just been posted. If false, imported posts are local-only, and only visible on your profile.
+//		type: boolean
+//		default: false
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write
+//
+//	responses:
+//		'200':
+//			name: import
+//			description: The newly created import.
+//			schema:
+//				"$ref": "#/definitions/import"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'409':
+//			description: conflict (an import is already in progress)
+//		'422':
+//			description: unprocessable (the type of data can't be imported)
+//		'500':
+//			description: internal server error
+func (m *Module) ImportCreatePOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.ImportRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	imp, errWithCode := m.processor.Importer().ImportCreate(c.Request.Context(), authed.Account, form)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	c.JSON(http.StatusOK, imp)
+}
@@ -102,7 +102,17 @@ func (suite *InstancePatchTestSuite) TestInstancePatch1() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "image/heif",
+        "image/avif",
+        "video/mp4",
+        "video/webm",
+        "video/x-matroska",
+        "video/quicktime",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/flac",
+        "audio/mp4",
+        "audio/wav"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -160,6 +170,16 @@ func (suite *InstancePatchTestSuite) TestInstancePatch1() {
       "name": "admin"
     }
   },
+  "rules": [
+    {
+      "id": "01H6Y8HD6R6C0N5M3MHW3X4PZ1",
+      "text": "Be nice to each other."
+    },
+    {
+      "id": "01H6Y8J2ZK0QEB9G5XG6Q7VCA2",
+      "text": "No advertising or spam."
+    }
+  ],
   "max_toot_chars": 5000
 }`, dst.String())
 }
@@ -206,7 +226,17 @@ func (suite *InstancePatchTestSuite) TestInstancePatch2() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "image/heif",
+        "image/avif",
+        "video/mp4",
+        "video/webm",
+        "video/x-matroska",
+        "video/quicktime",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/flac",
+        "audio/mp4",
+        "audio/wav"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -264,6 +294,16 @@ func (suite *InstancePatchTestSuite) TestInstancePatch2() {
       "name": "admin"
     }
   },
+  "rules": [
+    {
+      "id": "01H6Y8HD6R6C0N5M3MHW3X4PZ1",
+      "text": "Be nice to each other."
+    },
+    {
+      "id": "01H6Y8J2ZK0QEB9G5XG6Q7VCA2",
+      "text": "No advertising or spam."
+    }
+  ],
   "max_toot_chars": 5000
 }`, dst.String())
 }
@@ -310,7 +350,17 @@ func (suite *InstancePatchTestSuite) TestInstancePatch3() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "image/heif",
+        "image/avif",
+        "video/mp4",
+        "video/webm",
+        "video/x-matroska",
+        "video/quicktime",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/flac",
+        "audio/mp4",
+        "audio/wav"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -368,6 +418,16 @@ func (suite *InstancePatchTestSuite) TestInstancePatch3() {
       "name": "admin"
     }
   },
+  "rules": [
+    {
+      "id": "01H6Y8HD6R6C0N5M3MHW3X4PZ1",
+      "text": "Be nice to each other."
+    },
+    {
+      "id": "01H6Y8J2ZK0QEB9G5XG6Q7VCA2",
+      "text": "No advertising or spam."
+    }
+  ],
   "max_toot_chars": 5000
 }`, dst.String())
 }
@@ -465,7 +525,17 @@ func (suite *InstancePatchTestSuite) TestInstancePatch6() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "image/heif",
+        "image/avif",
+        "video/mp4",
+        "video/webm",
+        "video/x-matroska",
+        "video/quicktime",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/flac",
+        "audio/mp4",
+        "audio/wav"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -523,6 +593,16 @@ func (suite *InstancePatchTestSuite) TestInstancePatch6() {
       "name": "admin"
     }
   },
+  "rules": [
+    {
+      "id": "01H6Y8HD6R6C0N5M3MHW3X4PZ1",
+      "text": "Be nice to each other."
+    },
+    {
+      "id": "01H6Y8J2ZK0QEB9G5XG6Q7VCA2",
+      "text": "No advertising or spam."
+    }
+  ],
   "max_toot_chars": 5000
 }`, dst.String())
 }
@@ -591,7 +671,17 @@ func (suite *InstancePatchTestSuite) TestInstancePatch8() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "image/heif",
+        "image/avif",
+        "video/mp4",
+        "video/webm",
+        "video/x-matroska",
+        "video/quicktime",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/flac",
+        "audio/mp4",
+        "audio/wav"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -651,6 +741,16 @@ func (suite *InstancePatchTestSuite) TestInstancePatch8() {
       "name": "admin"
     }
   },
+  "rules": [
+    {
+      "id": "01H6Y8HD6R6C0N5M3MHW3X4PZ1",
+      "text": "Be nice to each other."
+    },
+    {
+      "id": "01H6Y8J2ZK0QEB9G5XG6Q7VCA2",
+      "text": "No advertising or spam."
+    }
+  ],
   "max_toot_chars": 5000
 }`, dst.String())
 
@@ -732,7 +832,17 @@ func (suite *InstancePatchTestSuite) TestInstancePatch9() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "image/heif",
+        "image/avif",
+        "video/mp4",
+        "video/webm",
+        "video/x-matroska",
+        "video/quicktime",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/flac",
+        "audio/mp4",
+        "audio/wav"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -790,6 +900,16 @@ func (suite *InstancePatchTestSuite) TestInstancePatch9() {
       "name": "admin"
     }
   },
+  "rules": [
+    {
+      "id": "01H6Y8HD6R6C0N5M3MHW3X4PZ1",
+      "text": "Be nice to each other."
+    },
+    {
+      "id": "01H6Y8J2ZK0QEB9G5XG6Q7VCA2",
+      "text": "No advertising or spam."
+    }
+  ],
   "max_toot_chars": 5000
 }`, dst.String())
 }
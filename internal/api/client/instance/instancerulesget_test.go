@@ -0,0 +1,64 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package instance_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/instance"
+)
+
+type InstanceRulesGetTestSuite struct {
+	InstanceStandardTestSuite
+}
+
+func (suite *InstanceRulesGetTestSuite) TestInstanceRulesGet() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, instance.InstanceRulesPath, nil, "", false)
+
+	suite.instanceModule.InstanceRulesGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	suite.NoError(err)
+	dst := new(bytes.Buffer)
+	err = json.Indent(dst, b, "", "  ")
+	suite.NoError(err)
+
+	// Deleted rule is not shown.
+	suite.Equal(`[
+  {
+    "id": "01H6Y8HD6R6C0N5M3MHW3X4PZ1",
+    "text": "Be nice to each other."
+  },
+  {
+    "id": "01H6Y8J2ZK0QEB9G5XG6Q7VCA2",
+    "text": "No advertising or spam."
+  }
+]`, dst.String())
+}
+
+func TestInstanceRulesGetTestSuite(t *testing.T) {
+	suite.Run(t, &InstanceRulesGetTestSuite{})
+}
@@ -21,6 +21,8 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
 
@@ -48,14 +50,14 @@ func New(processor *processing.Processor) *Module {
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	// create / get / update / delete lists
-	attachHandler(http.MethodPost, BasePath, m.ListCreatePOSTHandler)
-	attachHandler(http.MethodGet, BasePath, m.ListsGETHandler)
-	attachHandler(http.MethodGet, BasePathWithID, m.ListGETHandler)
-	attachHandler(http.MethodPut, BasePathWithID, m.ListUpdatePUTHandler)
-	attachHandler(http.MethodDelete, BasePathWithID, m.ListDELETEHandler)
+	attachHandler(http.MethodPost, BasePath, middleware.RequireScope(oauth.ScopeWriteLists), m.ListCreatePOSTHandler)
+	attachHandler(http.MethodGet, BasePath, middleware.RequireScope(oauth.ScopeReadLists), m.ListsGETHandler)
+	attachHandler(http.MethodGet, BasePathWithID, middleware.RequireScope(oauth.ScopeReadLists), m.ListGETHandler)
+	attachHandler(http.MethodPut, BasePathWithID, middleware.RequireScope(oauth.ScopeWriteLists), m.ListUpdatePUTHandler)
+	attachHandler(http.MethodDelete, BasePathWithID, middleware.RequireScope(oauth.ScopeWriteLists), m.ListDELETEHandler)
 
 	// get / add / remove list accounts
-	attachHandler(http.MethodGet, AccountsPath, m.ListAccountsGETHandler)
-	attachHandler(http.MethodPost, AccountsPath, m.ListAccountsPOSTHandler)
-	attachHandler(http.MethodDelete, AccountsPath, m.ListAccountsDELETEHandler)
+	attachHandler(http.MethodGet, AccountsPath, middleware.RequireScope(oauth.ScopeReadLists), m.ListAccountsGETHandler)
+	attachHandler(http.MethodPost, AccountsPath, middleware.RequireScope(oauth.ScopeWriteLists), m.ListAccountsPOSTHandler)
+	attachHandler(http.MethodDelete, AccountsPath, middleware.RequireScope(oauth.ScopeWriteLists), m.ListAccountsDELETEHandler)
 }
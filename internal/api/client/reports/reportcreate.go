@@ -28,6 +28,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/regexes"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // ReportPOSTHandler swagger:operation POST /api/v1/reports reportCreate
@@ -101,6 +102,15 @@ func (m *Module) ReportPOSTHandler(c *gin.Context) {
 		return
 	}
 
+	for _, ruleID := range form.RuleIDs {
+		if !regexes.ULID.MatchString(ruleID) {
+			err = fmt.Errorf("rule_id %s was not valid", ruleID)
+			apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+			return
+		}
+	}
+	form.RuleIDs = util.UniqueStrings(form.RuleIDs)
+
 	apiReport, errWithCode := m.processor.Report().Create(c.Request.Context(), authed.Account, form)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
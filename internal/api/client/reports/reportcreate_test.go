@@ -18,6 +18,7 @@
 package reports_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -52,17 +53,13 @@ func (suite *ReportCreateTestSuite) createReport(expectedHTTPStatus int, expecte
 	// create the request
 	ctx.Request = httptest.NewRequest(http.MethodPost, config.GetProtocol()+"://"+config.GetHost()+"/api/"+reports.BasePath, nil)
 	ctx.Request.Header.Set("accept", "application/json")
-	ruleIDs := make([]string, 0, len(form.RuleIDs))
-	for _, r := range form.RuleIDs {
-		ruleIDs = append(ruleIDs, strconv.Itoa(r))
-	}
 	ctx.Request.Form = url.Values{
 		"account_id":   {form.AccountID},
 		"status_ids[]": form.StatusIDs,
 		"comment":      {form.Comment},
 		"forward":      {strconv.FormatBool(form.Forward)},
 		"category":     {form.Category},
-		"rule_ids[]":   ruleIDs,
+		"rule_ids[]":   form.RuleIDs,
 	}
 
 	// trigger the handler
@@ -195,6 +192,104 @@ func (suite *ReportCreateTestSuite) TestCreateReport7() {
 	suite.Nil(report)
 }
 
+func (suite *ReportCreateTestSuite) TestCreateReportWithRules() {
+	targetAccount := suite.testAccounts["remote_account_1"]
+	rules := testrig.NewTestRules()
+
+	form := &apimodel.ReportCreateRequest{
+		AccountID: targetAccount.ID,
+		Comment:   "this person is a pain",
+		StatusIDs: []string{},
+		RuleIDs:   []string{rules["rule1"].ID, rules["rule2"].ID},
+	}
+
+	report, err := suite.createReport(http.StatusOK, "", form)
+	suite.NoError(err)
+	suite.NotEmpty(report)
+	suite.ReportOK(form, report)
+	suite.Equal("violation", report.Category)
+	suite.Equal(form.RuleIDs, report.RuleIDs)
+
+	// Rules should be stored with the report.
+	dbReport, err := suite.db.GetReportByID(context.Background(), report.ID)
+	suite.NoError(err)
+	suite.Equal("violation", dbReport.Category)
+	suite.Len(dbReport.Rules, 2)
+}
+
+func (suite *ReportCreateTestSuite) TestCreateReportDuplicateRules() {
+	rule1 := testrig.NewTestRules()["rule1"]
+
+	form := &apimodel.ReportCreateRequest{
+		AccountID: suite.testAccounts["remote_account_1"].ID,
+		RuleIDs:   []string{rule1.ID, rule1.ID},
+	}
+
+	report, err := suite.createReport(http.StatusOK, "", form)
+	suite.NoError(err)
+	suite.Equal("violation", report.Category)
+	suite.Equal([]string{rule1.ID}, report.RuleIDs)
+}
+
+func (suite *ReportCreateTestSuite) TestCreateReportCategory() {
+	targetAccount := suite.testAccounts["remote_account_1"]
+
+	form := &apimodel.ReportCreateRequest{
+		AccountID: targetAccount.ID,
+		Category:  "spam",
+	}
+
+	report, err := suite.createReport(http.StatusOK, "", form)
+	suite.NoError(err)
+	suite.Equal("spam", report.Category)
+	suite.Empty(report.RuleIDs)
+}
+
+func (suite *ReportCreateTestSuite) TestCreateReportRulesWrongCategory() {
+	form := &apimodel.ReportCreateRequest{
+		AccountID: suite.testAccounts["remote_account_1"].ID,
+		Category:  "spam",
+		RuleIDs:   []string{testrig.NewTestRules()["rule1"].ID},
+	}
+
+	report, err := suite.createReport(http.StatusBadRequest, `{"error":"Bad Request: rule_ids can only be given with category violation"}`, form)
+	suite.NoError(err)
+	suite.Nil(report)
+}
+
+func (suite *ReportCreateTestSuite) TestCreateReportInvalidCategory() {
+	form := &apimodel.ReportCreateRequest{
+		AccountID: suite.testAccounts["remote_account_1"].ID,
+		Category:  "boring",
+	}
+
+	report, err := suite.createReport(http.StatusBadRequest, `{"error":"Bad Request: report category must be one of 'spam', 'legal', 'violation', 'other'"}`, form)
+	suite.NoError(err)
+	suite.Nil(report)
+}
+
+func (suite *ReportCreateTestSuite) TestCreateReportDeletedRule() {
+	form := &apimodel.ReportCreateRequest{
+		AccountID: suite.testAccounts["remote_account_1"].ID,
+		RuleIDs:   []string{testrig.NewTestRules()["deleted_rule"].ID},
+	}
+
+	report, err := suite.createReport(http.StatusBadRequest, `{"error":"Bad Request: rule with ID 01H6Y8JF3V7RSW2T4N9K0XB8D3 has been deleted"}`, form)
+	suite.NoError(err)
+	suite.Nil(report)
+}
+
+func (suite *ReportCreateTestSuite) TestCreateReportUnknownRule() {
+	form := &apimodel.ReportCreateRequest{
+		AccountID: suite.testAccounts["remote_account_1"].ID,
+		RuleIDs:   []string{"01H6Y9Q4ZJ7W0E6S5XN2C8R3VB"},
+	}
+
+	report, err := suite.createReport(http.StatusBadRequest, `{"error":"Bad Request: one or more rule_ids do not exist"}`, form)
+	suite.NoError(err)
+	suite.Nil(report)
+}
+
 func TestReportCreateTestSuite(t *testing.T) {
 	suite.Run(t, &ReportCreateTestSuite{})
 }
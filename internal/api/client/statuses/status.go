@@ -21,6 +21,8 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
 
@@ -78,26 +80,26 @@ func New(processor *processing.Processor) *Module {
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	// create / get / delete status
-	attachHandler(http.MethodPost, BasePath, m.StatusCreatePOSTHandler)
-	attachHandler(http.MethodGet, BasePathWithID, m.StatusGETHandler)
-	attachHandler(http.MethodDelete, BasePathWithID, m.StatusDELETEHandler)
+	attachHandler(http.MethodPost, BasePath, middleware.RequireScope(oauth.ScopeWriteStatuses), m.StatusCreatePOSTHandler)
+	attachHandler(http.MethodGet, BasePathWithID, middleware.RequireScope(oauth.ScopeReadStatuses), m.StatusGETHandler)
+	attachHandler(http.MethodDelete, BasePathWithID, middleware.RequireScope(oauth.ScopeWriteStatuses), m.StatusDELETEHandler)
 
 	// fave stuff
-	attachHandler(http.MethodPost, FavouritePath, m.StatusFavePOSTHandler)
-	attachHandler(http.MethodPost, UnfavouritePath, m.StatusUnfavePOSTHandler)
-	attachHandler(http.MethodGet, FavouritedPath, m.StatusFavedByGETHandler)
+	attachHandler(http.MethodPost, FavouritePath, middleware.RequireScope(oauth.ScopeWriteFavourites), m.StatusFavePOSTHandler)
+	attachHandler(http.MethodPost, UnfavouritePath, middleware.RequireScope(oauth.ScopeWriteFavourites), m.StatusUnfavePOSTHandler)
+	attachHandler(http.MethodGet, FavouritedPath, middleware.RequireScope(oauth.ScopeReadAccounts), m.StatusFavedByGETHandler)
 
 	// pin stuff
-	attachHandler(http.MethodPost, PinPath, m.StatusPinPOSTHandler)
-	attachHandler(http.MethodPost, UnpinPath, m.StatusUnpinPOSTHandler)
+	attachHandler(http.MethodPost, PinPath, middleware.RequireScope(oauth.ScopeWriteAccounts), m.StatusPinPOSTHandler)
+	attachHandler(http.MethodPost, UnpinPath, middleware.RequireScope(oauth.ScopeWriteAccounts), m.StatusUnpinPOSTHandler)
 
 	// reblog stuff
-	attachHandler(http.MethodPost, ReblogPath, m.StatusBoostPOSTHandler)
-	attachHandler(http.MethodPost, UnreblogPath, m.StatusUnboostPOSTHandler)
-	attachHandler(http.MethodGet, RebloggedPath, m.StatusBoostedByGETHandler)
-	attachHandler(http.MethodPost, BookmarkPath, m.StatusBookmarkPOSTHandler)
-	attachHandler(http.MethodPost, UnbookmarkPath, m.StatusUnbookmarkPOSTHandler)
+	attachHandler(http.MethodPost, ReblogPath, middleware.RequireScope(oauth.ScopeWriteStatuses), m.StatusBoostPOSTHandler)
+	attachHandler(http.MethodPost, UnreblogPath, middleware.RequireScope(oauth.ScopeWriteStatuses), m.StatusUnboostPOSTHandler)
+	attachHandler(http.MethodGet, RebloggedPath, middleware.RequireScope(oauth.ScopeReadAccounts), m.StatusBoostedByGETHandler)
+	attachHandler(http.MethodPost, BookmarkPath, middleware.RequireScope(oauth.ScopeWriteBookmarks), m.StatusBookmarkPOSTHandler)
+	attachHandler(http.MethodPost, UnbookmarkPath, middleware.RequireScope(oauth.ScopeWriteBookmarks), m.StatusUnbookmarkPOSTHandler)
 
 	// context / status thread
-	attachHandler(http.MethodGet, ContextPath, m.StatusContextGETHandler)
+	attachHandler(http.MethodGet, ContextPath, middleware.RequireScope(oauth.ScopeReadStatuses), m.StatusContextGETHandler)
 }
@@ -19,6 +19,7 @@ package streaming
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"codeberg.org/gruf/go-kv"
@@ -173,6 +174,12 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 				return nil, gtserror.NewErrorUnauthorized(err, err.Error())
 			}
 
+			if scope := authed.Token.GetScope(); !oauth.ScopesPermit(scope, oauth.ScopeReadStatuses) &&
+				!oauth.ScopesPermit(scope, oauth.ScopeReadNotifications) {
+				err := fmt.Errorf("token is missing scope %s", oauth.ScopeReadStatuses)
+				return nil, gtserror.NewErrorForbidden(err, err.Error())
+			}
+
 			return authed.Account, nil
 		}()
 	}
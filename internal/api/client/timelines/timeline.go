@@ -21,6 +21,8 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
 
@@ -56,7 +58,7 @@ func New(processor *processing.Processor) *Module {
 }
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
-	attachHandler(http.MethodGet, HomeTimeline, m.HomeTimelineGETHandler)
-	attachHandler(http.MethodGet, PublicTimeline, m.PublicTimelineGETHandler)
-	attachHandler(http.MethodGet, ListTimeline, m.ListTimelineGETHandler)
+	attachHandler(http.MethodGet, HomeTimeline, middleware.RequireScope(oauth.ScopeReadStatuses), m.HomeTimelineGETHandler)
+	attachHandler(http.MethodGet, PublicTimeline, middleware.RequireScope(oauth.ScopeReadStatuses), m.PublicTimelineGETHandler)
+	attachHandler(http.MethodGet, ListTimeline, middleware.RequireScope(oauth.ScopeReadLists), m.ListTimelineGETHandler)
 }
@@ -0,0 +1,90 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/user"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type EmailChangeTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *EmailChangeTestSuite) emailChange(form url.Values, expectedCode int) string {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080%s", user.EmailChangePath), nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.Form = form
+	suite.userModule.EmailChangePOSTHandler(ctx)
+
+	suite.Equal(expectedCode, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return string(b)
+}
+
+func (suite *EmailChangeTestSuite) TestEmailChangePOST() {
+	b := suite.emailChange(url.Values{
+		"password":  {"password"},
+		"new_email": {"zork.new@example.org"},
+	}, http.StatusAccepted)
+	suite.Equal(`{"status":"Accepted"}`, b)
+
+	dbUser, err := suite.db.GetUserByID(context.Background(), suite.testUsers["local_account_1"].ID)
+	suite.NoError(err)
+	suite.Equal("zork@example.org", dbUser.Email)
+	suite.Equal("zork.new@example.org", dbUser.UnconfirmedEmail)
+}
+
+func (suite *EmailChangeTestSuite) TestEmailChangeBlockedDomain() {
+	b := suite.emailChange(url.Values{
+		"password":  {"password"},
+		"new_email": {"zork@mail.disposable.example.org"},
+	}, http.StatusBadRequest)
+	suite.Equal(`{"error":"Bad Request: email domain mail.disposable.example.org is blocked"}`, b)
+}
+
+func (suite *EmailChangeTestSuite) TestEmailChangeMissingPassword() {
+	b := suite.emailChange(url.Values{
+		"new_email": {"zork.new@example.org"},
+	}, http.StatusBadRequest)
+	suite.Equal(`{"error":"Bad Request: email change request missing field password"}`, b)
+}
+
+func TestEmailChangeTestSuite(t *testing.T) {
+	suite.Run(t, &EmailChangeTestSuite{})
+}
@@ -0,0 +1,168 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// EmailPreferencesGETHandler swagger:operation GET /api/v1/user/email_preferences userEmailPreferencesGet
+//
+// Get the email preferences of authenticated user.
+//
+//	---
+//	tags:
+//	- user
+//
+//	produces:
+//	- application/json
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:accounts
+//
+//	responses:
+//		'200':
+//			description: The user's email preferences.
+//			schema:
+//				"$ref": "#/definitions/emailPreferences"
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal error
+func (m *Module) EmailPreferencesGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	c.JSON(http.StatusOK, m.processor.User().EmailPreferencesGet(authed.User))
+}
+
+// EmailPreferencesPATCHHandler swagger:operation PATCH /api/v1/user/email_preferences userEmailPreferencesUpdate
+//
+// Update the email preferences of authenticated user.
+//
+// Each of mentions, direct, follows, follow_requests and reports_closed can be
+// set to `off` (no emails), `immediate` (an email as soon as it happens), or
+// `digest` (included in a periodic digest email). Digests are sent `daily` or
+// `weekly`, depending on digest_frequency.
+//
+// Only the preferences provided will be changed.
+//
+//	---
+//	tags:
+//	- user
+//
+//	consumes:
+//	- application/json
+//	- application/xml
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: mentions
+//		in: formData
+//		description: Email preference for mentions in posts that aren't direct messages.
+//		type: string
+//	-
+//		name: direct
+//		in: formData
+//		description: Email preference for direct messages.
+//		type: string
+//	-
+//		name: follows
+//		in: formData
+//		description: Email preference for new followers.
+//		type: string
+//	-
+//		name: follow_requests
+//		in: formData
+//		description: Email preference for new follow requests.
+//		type: string
+//	-
+//		name: reports_closed
+//		in: formData
+//		description: Email preference for your reports being closed by a moderator.
+//		type: string
+//	-
+//		name: digest_frequency
+//		in: formData
+//		description: How often to send digest emails, `daily` or `weekly`.
+//		type: string
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:accounts
+//
+//	responses:
+//		'200':
+//			description: The user's updated email preferences.
+//			schema:
+//				"$ref": "#/definitions/emailPreferences"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal error
+func (m *Module) EmailPreferencesPATCHHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.EmailPreferencesUpdateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	prefs, errWithCode := m.processor.User().EmailPreferencesUpdate(c.Request.Context(), authed.User, form)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	c.JSON(http.StatusOK, prefs)
+}
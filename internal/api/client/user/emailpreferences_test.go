@@ -0,0 +1,119 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/user"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type EmailPreferencesTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *EmailPreferencesTestSuite) newContext(recorder *httptest.ResponseRecorder, method string, form url.Values) *gin.Context {
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(method, fmt.Sprintf("http://localhost:8080%s", user.EmailPreferencesPath), nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.Form = form
+	return ctx
+}
+
+func (suite *EmailPreferencesTestSuite) TestEmailPreferencesGETDefaults() {
+	recorder := httptest.NewRecorder()
+	suite.userModule.EmailPreferencesGETHandler(suite.newContext(recorder, http.MethodGet, nil))
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(`{"mentions":"off","direct":"off","follows":"off","follow_requests":"off","reports_closed":"immediate","digest_frequency":"daily"}`, string(b))
+}
+
+func (suite *EmailPreferencesTestSuite) TestEmailPreferencesPATCH() {
+	recorder := httptest.NewRecorder()
+	suite.userModule.EmailPreferencesPATCHHandler(suite.newContext(recorder, http.MethodPatch, url.Values{
+		"mentions":         {"digest"},
+		"direct":           {"immediate"},
+		"reports_closed":   {"off"},
+		"digest_frequency": {"weekly"},
+	}))
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(`{"mentions":"digest","direct":"immediate","follows":"off","follow_requests":"off","reports_closed":"off","digest_frequency":"weekly"}`, string(b))
+
+	dbUser, err := suite.db.GetUserByID(context.Background(), suite.testUsers["local_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("digest", dbUser.EmailMentions)
+	suite.Equal("immediate", dbUser.EmailDirect)
+	suite.Empty(dbUser.EmailFollows)
+	suite.Equal("off", dbUser.EmailReportsClosed)
+	suite.Equal("weekly", dbUser.EmailDigestFrequency)
+}
+
+func (suite *EmailPreferencesTestSuite) TestEmailPreferencesPATCHInvalid() {
+	recorder := httptest.NewRecorder()
+	suite.userModule.EmailPreferencesPATCHHandler(suite.newContext(recorder, http.MethodPatch, url.Values{
+		"follows": {"sometimes"},
+	}))
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(`{"error":"Bad Request: follows: email preference 'sometimes' was not recognized, valid options are 'off', 'immediate', 'digest'"}`, string(b))
+}
+
+func (suite *EmailPreferencesTestSuite) TestEmailPreferencesPATCHEmpty() {
+	recorder := httptest.NewRecorder()
+	suite.userModule.EmailPreferencesPATCHHandler(suite.newContext(recorder, http.MethodPatch, url.Values{}))
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(`{"error":"Bad Request: no email preferences provided"}`, string(b))
+}
+
+func TestEmailPreferencesTestSuite(t *testing.T) {
+	suite.Run(t, &EmailPreferencesTestSuite{})
+}
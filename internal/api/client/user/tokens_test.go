@@ -0,0 +1,256 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/user"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type TokensTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *TokensTestSuite) newContext(recorder *httptest.ResponseRecorder, method string, path string) *gin.Context {
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(method, "http://localhost:8080"+path, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	return ctx
+}
+
+func (suite *TokensTestSuite) TestTokensGET() {
+	recorder := httptest.NewRecorder()
+	suite.userModule.TokensGETHandler(suite.newContext(recorder, http.MethodGet, user.TokensPath))
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	tokens := []*apimodel.TokenInfo{}
+	if err := json.Unmarshal(b, &tokens); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Only the access token should be listed,
+	// not the unexchanged authorization code.
+	if !suite.Len(tokens, 1) {
+		suite.FailNow("", "unexpected tokens: %s", b)
+	}
+	suite.Equal(suite.testTokens["local_account_1"].ID, tokens[0].ID)
+	suite.Equal("read write follow push", tokens[0].Scope)
+	suite.Equal("really cool gts application", tokens[0].Application.Name)
+	suite.Equal("https://reallycool.app", tokens[0].Application.Website)
+	suite.NotEmpty(tokens[0].CreatedAt)
+	suite.Nil(tokens[0].LastUsed)
+}
+
+func (suite *TokensTestSuite) TestTokenInvalidate() {
+	tokenID := suite.testTokens["local_account_1"].ID
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, user.TokensPath+"/"+tokenID+"/invalidate")
+	ctx.AddParam(user.IDKey, tokenID)
+	suite.userModule.TokenInvalidatePOSTHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	_, err := suite.db.GetTokenByID(context.Background(), tokenID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *TokensTestSuite) TestTokenInvalidateOtherUser() {
+	tokenID := suite.testTokens["local_account_2"].ID
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, user.TokensPath+"/"+tokenID+"/invalidate")
+	ctx.AddParam(user.IDKey, tokenID)
+	suite.userModule.TokenInvalidatePOSTHandler(ctx)
+	suite.Equal(http.StatusNotFound, recorder.Code)
+
+	_, err := suite.db.GetTokenByID(context.Background(), tokenID)
+	suite.NoError(err)
+}
+
+func (suite *TokensTestSuite) createPersonalToken(form url.Values) (int, *apimodel.TokenInfo) {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, user.TokensPath)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "http://localhost:8080"+user.TokensPath, strings.NewReader(form.Encode()))
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	suite.userModule.PersonalTokenCreatePOSTHandler(ctx)
+
+	if recorder.Code != http.StatusOK {
+		return recorder.Code, nil
+	}
+
+	token := &apimodel.TokenInfo{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), token); err != nil {
+		suite.FailNow(err.Error())
+	}
+	return recorder.Code, token
+}
+
+// validate returns the ID of the user that the given
+// access token is valid for, or an error if it's not.
+func (suite *TokensTestSuite) validate(access string) (string, error) {
+	req := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/accounts/verify_credentials", nil)
+	req.Header.Set("Authorization", "Bearer "+access)
+
+	ti, err := suite.processor.OAuthValidateBearerToken(req)
+	if err != nil {
+		return "", err
+	}
+	return ti.GetUserID(), nil
+}
+
+func (suite *TokensTestSuite) TestPersonalTokenCreate() {
+	testUser := suite.testUsers["local_account_1"]
+
+	code, token := suite.createPersonalToken(url.Values{
+		"name":       {"My backup script"},
+		"scope":      {"read:statuses write:statuses"},
+		"expires_in": {"3600"},
+	})
+	if !suite.Equal(http.StatusOK, code) {
+		suite.FailNow("")
+	}
+
+	suite.Equal("My backup script", token.Name)
+	suite.Equal("My backup script", token.Application.Name)
+	suite.Equal("read:statuses write:statuses", token.Scope)
+	suite.NotNil(token.ExpiresAt)
+	suite.True(strings.HasPrefix(token.AccessToken, oauth.PersonalTokenPrefix))
+
+	// Only a hash of the token should be stored.
+	dbToken, err := suite.db.GetTokenByID(context.Background(), token.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	sum := sha256.Sum256([]byte(token.AccessToken))
+	suite.Equal(hex.EncodeToString(sum[:]), dbToken.Access)
+
+	// The token can be used to access the API as the user.
+	userID, err := suite.validate(token.AccessToken)
+	suite.NoError(err)
+	suite.Equal(testUser.ID, userID)
+
+	// But not by presenting the hash.
+	_, err = suite.validate(dbToken.Access)
+	suite.Error(err)
+
+	// It's listed without the token itself.
+	recorder := httptest.NewRecorder()
+	suite.userModule.TokensGETHandler(suite.newContext(recorder, http.MethodGet, user.TokensPath))
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Contains(recorder.Body.String(), `"name":"My backup script"`)
+	suite.NotContains(recorder.Body.String(), token.AccessToken)
+
+	// Once revoked, it can't be used, and
+	// its client and application are gone.
+	recorder = httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, user.TokensPath+"/"+token.ID+"/invalidate")
+	ctx.AddParam(user.IDKey, token.ID)
+	suite.userModule.TokenInvalidatePOSTHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	_, err = suite.validate(token.AccessToken)
+	suite.Error(err)
+
+	err = suite.db.GetByID(context.Background(), dbToken.ClientID, &gtsmodel.Client{})
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	_, err = suite.db.GetApplicationByClientID(context.Background(), dbToken.ClientID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *TokensTestSuite) TestPersonalTokenCreateBadRequest() {
+	config.SetOAuthPersonalTokensScopes([]string{"read", "write:statuses"})
+
+	for _, form := range []url.Values{
+		{"scope": {"read"}},
+		{"name": {strings.Repeat("a", 65)}},
+		{"name": {"bot"}, "scope": {"write"}},
+		{"name": {"bot"}, "scope": {"admin:read"}},
+		{"name": {"bot"}, "expires_in": {"-1"}},
+	} {
+		code, _ := suite.createPersonalToken(form)
+		suite.Equal(http.StatusBadRequest, code, form.Encode())
+	}
+
+	code, token := suite.createPersonalToken(url.Values{"name": {"bot"}, "scope": {"read write:statuses"}})
+	suite.Equal(http.StatusOK, code)
+	suite.Equal("read write:statuses", token.Scope)
+	suite.Nil(token.ExpiresAt)
+}
+
+func (suite *TokensTestSuite) TestPersonalTokenCreateExceedsCaller() {
+	// The instance allows admin scopes, but the
+	// calling token only has read write follow push.
+	config.SetOAuthPersonalTokensScopes([]string{"read", "write", "admin:read", "admin:write"})
+
+	for _, scope := range []string{"admin:write", "read admin:read", "admin"} {
+		code, _ := suite.createPersonalToken(url.Values{"name": {"bot"}, "scope": {scope}})
+		suite.Equal(http.StatusForbidden, code, scope)
+	}
+
+	code, token := suite.createPersonalToken(url.Values{"name": {"bot"}, "scope": {"read write:statuses"}})
+	suite.Equal(http.StatusOK, code)
+	suite.Equal("read write:statuses", token.Scope)
+}
+
+func (suite *TokensTestSuite) TestPersonalTokenDisabled() {
+	code, token := suite.createPersonalToken(url.Values{"name": {"bot"}})
+	suite.Equal(http.StatusOK, code)
+
+	config.SetOAuthPersonalTokensEnabled(false)
+
+	code, _ = suite.createPersonalToken(url.Values{"name": {"another bot"}})
+	suite.Equal(http.StatusForbidden, code)
+
+	// Existing tokens stop working too.
+	_, err := suite.validate(token.AccessToken)
+	suite.Error(err)
+}
+
+func TestTokensTestSuite(t *testing.T) {
+	suite.Run(t, &TokensTestSuite{})
+}
@@ -21,6 +21,8 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
 
@@ -29,6 +31,17 @@ const (
 	BasePath = "/v1/user"
 	// PasswordChangePath is the path for POSTing a password change request.
 	PasswordChangePath = BasePath + "/password_change"
+	// EmailChangePath is the path for POSTing an email change request.
+	EmailChangePath = BasePath + "/email_change"
+	// EmailPreferencesPath is the path for GETting and PATCHing email preferences.
+	EmailPreferencesPath = BasePath + "/email_preferences"
+	// TokensPath is the path for GETting authorized tokens, and POSTing new personal access tokens.
+	TokensPath = BasePath + "/tokens"
+	// TokenInvalidatePath is the path for POSTing a token revocation.
+	TokenInvalidatePath = TokensPath + "/:" + IDKey + "/invalidate"
+
+	// IDKey is the key for the id param of a path.
+	IDKey = "id"
 )
 
 type Module struct {
@@ -42,5 +55,11 @@ func New(processor *processing.Processor) *Module {
 }
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
-	attachHandler(http.MethodPost, PasswordChangePath, m.PasswordChangePOSTHandler)
+	attachHandler(http.MethodPost, PasswordChangePath, middleware.RequireScope(oauth.ScopeWriteAccounts), m.PasswordChangePOSTHandler)
+	attachHandler(http.MethodPost, EmailChangePath, middleware.RequireScope(oauth.ScopeWriteAccounts), m.EmailChangePOSTHandler)
+	attachHandler(http.MethodGet, EmailPreferencesPath, middleware.RequireScope(oauth.ScopeReadAccounts), m.EmailPreferencesGETHandler)
+	attachHandler(http.MethodPatch, EmailPreferencesPath, middleware.RequireScope(oauth.ScopeWriteAccounts), m.EmailPreferencesPATCHHandler)
+	attachHandler(http.MethodGet, TokensPath, middleware.RequireScope(oauth.ScopeReadAccounts), m.TokensGETHandler)
+	attachHandler(http.MethodPost, TokensPath, middleware.RequireScope(oauth.ScopeWriteAccounts), m.PersonalTokenCreatePOSTHandler)
+	attachHandler(http.MethodPost, TokenInvalidatePath, middleware.RequireScope(oauth.ScopeWriteAccounts), m.TokenInvalidatePOSTHandler)
 }
@@ -0,0 +1,45 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/health"
+	"github.com/superseriousbusiness/gotosocial/internal/router"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+)
+
+type Health struct {
+	health *health.Module
+}
+
+func (h *Health) Route(r router.Router, m ...gin.HandlerFunc) {
+	// health probes live at the root
+	healthGroup := r.AttachGroup("")
+
+	// attach middlewares appropriate for this group
+	healthGroup.Use(m...)
+
+	h.health.Route(healthGroup.Handle)
+}
+
+func NewHealth(state *state.State) *Health {
+	return &Health{
+		health: health.New(state),
+	}
+}
@@ -0,0 +1,60 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package health
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+)
+
+const (
+	// LivezPath is for liveness probes.
+	LivezPath = "/livez"
+	// ReadyzPath is for readiness probes.
+	ReadyzPath = "/readyz"
+)
+
+type Module struct {
+	state *state.State
+
+	// Checks which touch the database or
+	// storage, with results shared between
+	// requests for a short while.
+	database   *cachedCheck
+	migrations *cachedCheck
+	storage    *cachedCheck
+}
+
+func New(state *state.State) *Module {
+	m := &Module{
+		state: state,
+	}
+	m.database = &cachedCheck{check: m.checkDatabase}
+	m.migrations = &cachedCheck{check: m.checkMigrations}
+	m.storage = &cachedCheck{check: m.checkStorage}
+	return m
+}
+
+func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
+	attachHandler(http.MethodGet, LivezPath, m.LivezGETHandler)
+	attachHandler(http.MethodHead, LivezPath, m.LivezGETHandler)
+	attachHandler(http.MethodGet, ReadyzPath, m.ReadyzGETHandler)
+	attachHandler(http.MethodHead, ReadyzPath, m.ReadyzGETHandler)
+}
@@ -0,0 +1,146 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package health_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/health"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type HealthTestSuite struct {
+	suite.Suite
+	state        state.State
+	healthModule *health.Module
+}
+
+func (suite *HealthTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+
+	suite.state.Caches.Init()
+	suite.state.DB = testrig.NewTestDB(&suite.state)
+	suite.state.Storage = testrig.NewInMemoryStorage()
+	testrig.StartWorkers(&suite.state)
+
+	suite.healthModule = health.New(&suite.state)
+}
+
+func (suite *HealthTestSuite) TearDownTest() {
+	testrig.StopWorkers(&suite.state)
+	testrig.StandardDBTeardown(suite.state.DB)
+}
+
+func (suite *HealthTestSuite) probe(handler gin.HandlerFunc, path string, token string) (int, *apimodel.HealthStatus) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "http://localhost:8080"+path, nil)
+	if token != "" {
+		ctx.Request.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	handler(ctx)
+
+	b, err := io.ReadAll(recorder.Body)
+	suite.NoError(err)
+
+	resp := &apimodel.HealthStatus{}
+	suite.NoError(json.Unmarshal(b, resp))
+
+	return recorder.Code, resp
+}
+
+func (suite *HealthTestSuite) TestReadyz() {
+	code, resp := suite.probe(suite.healthModule.ReadyzGETHandler, health.ReadyzPath, "")
+	suite.Equal(http.StatusOK, code)
+	suite.Equal("ok", resp.Status)
+
+	suite.Len(resp.Checks, 4)
+	for name, check := range resp.Checks {
+		suite.Equal("ok", check.Status, name)
+		suite.Empty(check.Error, name)
+	}
+}
+
+func (suite *HealthTestSuite) TestLivez() {
+	code, resp := suite.probe(suite.healthModule.LivezGETHandler, health.LivezPath, "")
+	suite.Equal(http.StatusOK, code)
+	suite.Equal("ok", resp.Status)
+	suite.Contains(resp.Checks, "workers")
+}
+
+func (suite *HealthTestSuite) TestReadyzWorkersStopped() {
+	testrig.StopWorkers(&suite.state)
+
+	code, resp := suite.probe(suite.healthModule.ReadyzGETHandler, health.ReadyzPath, "")
+	suite.Equal(http.StatusServiceUnavailable, code)
+	suite.Equal("fail", resp.Status)
+
+	workers := resp.Checks["workers"]
+	suite.Equal("fail", workers.Status)
+	suite.Equal([]string{"scheduler", "client_api", "federator", "media", "jobs"}, workers.Details)
+	suite.Equal("ok", resp.Checks["database"].Status)
+}
+
+func (suite *HealthTestSuite) TestReadyzToken() {
+	config.SetHealthAuthToken("secret")
+
+	// No token: only the overall status.
+	code, resp := suite.probe(suite.healthModule.ReadyzGETHandler, health.ReadyzPath, "")
+	suite.Equal(http.StatusOK, code)
+	suite.Equal("ok", resp.Status)
+	suite.Empty(resp.Checks)
+
+	// Wrong token: same.
+	_, resp = suite.probe(suite.healthModule.ReadyzGETHandler, health.ReadyzPath, "nope")
+	suite.Empty(resp.Checks)
+
+	// Right token: full details.
+	_, resp = suite.probe(suite.healthModule.ReadyzGETHandler, health.ReadyzPath, "secret")
+	suite.Len(resp.Checks, 4)
+}
+
+func (suite *HealthTestSuite) TestReadyzCached() {
+	code, _ := suite.probe(suite.healthModule.ReadyzGETHandler, health.ReadyzPath, "")
+	suite.Equal(http.StatusOK, code)
+
+	// Storage and database results should be reused
+	// rather than checked again on every request, so
+	// this shouldn't even touch the (removed) storage.
+	storage := suite.state.Storage
+	suite.state.Storage = nil
+	defer func() { suite.state.Storage = storage }()
+
+	code, resp := suite.probe(suite.healthModule.ReadyzGETHandler, health.ReadyzPath, "")
+	suite.Equal(http.StatusOK, code)
+	suite.Equal("ok", resp.Checks["storage"].Status)
+}
+
+func TestHealthTestSuite(t *testing.T) {
+	suite.Run(t, new(HealthTestSuite))
+}
@@ -0,0 +1,267 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package health
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+const (
+	statusOK   = "ok"
+	statusFail = "fail"
+
+	// checkTimeout is the maximum time
+	// any one check may take to complete.
+	checkTimeout = 5 * time.Second
+
+	// checkCacheTTL is how long the result of
+	// a cached check is reused for, so that
+	// probes (which are unauthenticated and
+	// not rate limited) don't cause a storage
+	// write and database queries every time.
+	checkCacheTTL = 5 * time.Second
+)
+
+// check performs a single health check, returning
+// optional details, or an error if the check failed.
+type check func(ctx context.Context) ([]string, error)
+
+// cachedCheck wraps a check so that its result
+// is shared by all requests within checkCacheTTL.
+// Concurrent requests wait for a single run.
+type cachedCheck struct {
+	check   check
+	mu      sync.Mutex
+	details []string
+	err     error
+	expires time.Time
+}
+
+func (cc *cachedCheck) run(_ context.Context) ([]string, error) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	if time.Now().Before(cc.expires) {
+		return cc.details, cc.err
+	}
+
+	// The result is shared, so don't let one
+	// request's cancellation fail the check.
+	ctx, cncl := context.WithTimeout(context.Background(), checkTimeout)
+	defer cncl()
+
+	cc.details, cc.err = cc.check(ctx)
+	cc.expires = time.Now().Add(checkCacheTTL)
+	return cc.details, cc.err
+}
+
+// LivezGETHandler swagger:operation GET /livez livez
+//
+// Liveness probe.
+//
+// Returns 200 if this GoToSocial process is running and its
+// worker pools and scheduler are accepting work, or 503 if not.
+//
+// Detailed check results are only included if 'health-auth-token' is
+// unset, or if the token is given as an 'Authorization: Bearer' header.
+//
+//	---
+//	tags:
+//	- health
+//
+//	produces:
+//	- application/json
+//
+//	responses:
+//		'200':
+//			schema:
+//				"$ref": "#/definitions/healthStatus"
+//		'503':
+//			schema:
+//				"$ref": "#/definitions/healthStatus"
+func (m *Module) LivezGETHandler(c *gin.Context) {
+	m.respond(c, map[string]check{
+		"workers": m.checkWorkers,
+	})
+}
+
+// ReadyzGETHandler swagger:operation GET /readyz readyz
+//
+// Readiness probe.
+//
+// Returns 200 if this GoToSocial instance is ready to serve traffic, or 503 if not.
+// Checks database connectivity, storage read/write, worker pools and scheduler,
+// and that all database migrations have been applied. Results of the database,
+// migrations and storage checks are cached for a few seconds.
+//
+// Detailed check results are only included if 'health-auth-token' is
+// unset, or if the token is given as an 'Authorization: Bearer' header.
+//
+//	---
+//	tags:
+//	- health
+//
+//	produces:
+//	- application/json
+//
+//	responses:
+//		'200':
+//			schema:
+//				"$ref": "#/definitions/healthStatus"
+//		'503':
+//			schema:
+//				"$ref": "#/definitions/healthStatus"
+func (m *Module) ReadyzGETHandler(c *gin.Context) {
+	m.respond(c, map[string]check{
+		"database":   m.database.run,
+		"migrations": m.migrations.run,
+		"storage":    m.storage.run,
+		"workers":    m.checkWorkers,
+	})
+}
+
+// respond runs the given checks and writes the
+// results as JSON, with a status code reflecting
+// whether every check passed.
+func (m *Module) respond(c *gin.Context, checks map[string]check) {
+	ctx := c.Request.Context()
+
+	resp := apimodel.HealthStatus{
+		Status: statusOK,
+		Checks: make(map[string]apimodel.HealthCheck, len(checks)),
+	}
+
+	for name, check := range checks {
+		result := runCheck(ctx, check)
+		if result.Status != statusOK {
+			resp.Status = statusFail
+		}
+		resp.Checks[name] = result
+	}
+
+	if !detailed(c) {
+		// Only give the overall status.
+		resp.Checks = nil
+	}
+
+	code := http.StatusOK
+	if resp.Status != statusOK {
+		code = http.StatusServiceUnavailable
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(code, resp)
+}
+
+// runCheck runs the given check
+// under timeout, timing the result.
+func runCheck(ctx context.Context, check check) apimodel.HealthCheck {
+	ctx, cncl := context.WithTimeout(ctx, checkTimeout)
+	defer cncl()
+
+	start := time.Now()
+	details, err := check(ctx)
+
+	result := apimodel.HealthCheck{
+		Status:     statusOK,
+		DurationMS: time.Since(start).Milliseconds(),
+		Details:    details,
+	}
+
+	if err != nil {
+		result.Status = statusFail
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+// detailed returns whether the request
+// may see the results of individual checks.
+func detailed(c *gin.Context) bool {
+	token := config.GetHealthAuthToken()
+	if token == "" {
+		return true
+	}
+
+	auth := c.GetHeader("Authorization")
+	given, ok := strings.CutPrefix(auth, "Bearer ")
+	if !ok {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1
+}
+
+func (m *Module) checkDatabase(ctx context.Context) ([]string, error) {
+	return nil, m.state.DB.IsHealthy(ctx)
+}
+
+func (m *Module) checkMigrations(ctx context.Context) ([]string, error) {
+	pending, err := m.state.DB.PendingMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pending) > 0 {
+		return pending, errors.New("database migrations pending")
+	}
+
+	return nil, nil
+}
+
+func (m *Module) checkStorage(ctx context.Context) ([]string, error) {
+	return nil, m.state.Storage.IsHealthy(ctx)
+}
+
+func (m *Module) checkWorkers(ctx context.Context) ([]string, error) {
+	var stopped []string
+
+	workers := &m.state.Workers
+	if !workers.Scheduler.Running() {
+		stopped = append(stopped, "scheduler")
+	}
+	if !workers.ClientAPI.Running() {
+		stopped = append(stopped, "client_api")
+	}
+	if !workers.Federator.Running() {
+		stopped = append(stopped, "federator")
+	}
+	if !workers.Media.Running() {
+		stopped = append(stopped, "media")
+	}
+	if !workers.Jobs.Running() {
+		stopped = append(stopped, "jobs")
+	}
+
+	if len(stopped) > 0 {
+		return stopped, errors.New("not running: " + strings.Join(stopped, ", "))
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,47 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/metrics"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/router"
+)
+
+type Metrics struct{}
+
+func (m *Metrics) Route(r router.Router, mi ...gin.HandlerFunc) {
+	// group metrics endpoint
+	metricsGroup := r.AttachGroup("metrics")
+
+	// attach middlewares appropriate for this group
+	metricsGroup.Use(mi...)
+	metricsGroup.Use(
+		// never cache metrics
+		middleware.CacheControl("no-store"),
+	)
+
+	metricsGroup.Handle(http.MethodGet, "", metrics.Handler())
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{}
+}
@@ -205,6 +205,14 @@ type AccountFollowRequest struct {
 	Notify *bool `form:"notify" json:"notify" xml:"notify"`
 }
 
+// AccountMuteRequest models a request to mute an account.
+//
+// swagger:ignore
+type AccountMuteRequest struct {
+	// Mute notifications from this account too.
+	Notifications *bool `form:"notifications" json:"notifications" xml:"notifications"`
+}
+
 // AccountDeleteRequest models a request to delete an account.
 //
 // swagger:ignore
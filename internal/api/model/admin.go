@@ -17,6 +17,8 @@
 
 package model
 
+import "encoding/json"
+
 // AdminAccountInfo models the admin view of an account's details.
 //
 // swagger:model adminAccountInfo
@@ -62,6 +64,8 @@ type AdminAccountInfo struct {
 	Approved bool `json:"approved"`
 	// Whether the account is currently disabled.
 	Disabled bool `json:"disabled"`
+	// Whether the account's media is currently forced to be marked as sensitive.
+	Sensitized bool `json:"sensitized"`
 	// Whether the account is currently silenced
 	Silenced bool `json:"silenced"`
 	// Whether the account is currently suspended.
@@ -117,9 +121,9 @@ type AdminReport struct {
 	// Array of  statuses that were submitted along with this report.
 	// Will be empty if no status IDs were submitted with the report.
 	Statuses []*Status `json:"statuses"`
-	// Array of rule IDs that were submitted along with this report.
-	// NOT IMPLEMENTED, will always be empty array.
-	Rules []interface{} `json:"rule_ids"`
+	// Array of rules that were cited by this report.
+	// Will be empty if no rules were cited.
+	Rules []*InstanceRule `json:"rules"`
 	// If an action was taken, what comment was made by the admin on the taken action?
 	// Will be null if not set / no action yet taken.
 	// example: Account was suspended.
@@ -134,6 +138,49 @@ type AdminReportResolveRequest struct {
 	ActionTakenComment *string `form:"action_taken_comment" json:"action_taken_comment" xml:"action_taken_comment"`
 }
 
+// AdminReportAssignRequest can be submitted along with a POST to /api/v1/admin/reports/{id}/assign
+//
+// swagger:ignore
+type AdminReportAssignRequest struct {
+	// ID of the moderator account to assign the report to.
+	// Defaults to the account making the request.
+	AccountID string `form:"account_id" json:"account_id" xml:"account_id"`
+}
+
+// AdminReportNote models a note left on a report by an admin or moderator.
+//
+// swagger:model adminReportNote
+type AdminReportNote struct {
+	// ID of the note.
+	// example: 01H7EY2Z3N0B4QW8V5KJ6A1XCM
+	ID string `json:"id"`
+	// The date when this note was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// ID of the report this note was left on.
+	// example: 01GP3AWY4CRDVRNZKW0TEAMB5R
+	ReportID string `json:"report_id"`
+	// ID of the earlier note on the same report that this note replies to.
+	// Null if this note is not a reply.
+	// example: 01H7EY2Z3N0B4QW8V5KJ6A1XCM
+	InReplyToID *string `json:"in_reply_to_id"`
+	// The account that created the note.
+	Account *AdminAccountInfo `json:"account"`
+	// Text content of the note.
+	// example: Checked with the remote admin, they've dealt with it.
+	Content string `json:"content"`
+}
+
+// AdminReportNoteCreateRequest can be submitted along with a POST to /api/v1/admin/reports/{id}/notes
+//
+// swagger:ignore
+type AdminReportNoteCreateRequest struct {
+	// Text content of the note.
+	Content string `form:"content" json:"content" xml:"content"`
+	// ID of an earlier note on the same report to reply to.
+	InReplyToID string `form:"in_reply_to_id" json:"in_reply_to_id" xml:"in_reply_to_id"`
+}
+
 // AdminEmoji models the admin view of a custom emoji.
 //
 // swagger:model adminEmoji
@@ -167,14 +214,30 @@ type AdminEmoji struct {
 //
 // swagger:ignore
 type AdminAccountActionRequest struct {
-	// Type of the account action. One of disable, silence, suspend.
+	// Type of the account action. One of disable, enable, silence, unsilence,
+	// sensitive (or sensitize), unsensitive (or unsensitize), suspend.
 	Type string `form:"type" json:"type" xml:"type"`
 	// Text describing why an action was taken.
 	Text string `form:"text" json:"text" xml:"text"`
+	// ID of a report to link this action to. The report
+	// will be resolved if it is not already resolved.
+	ReportID string `form:"report_id" json:"report_id" xml:"report_id"`
+	// Send an email to the owner of the target account explaining the action.
+	SendEmail bool `form:"send_email_notification" json:"send_email_notification" xml:"send_email_notification"`
 	// ID of the account to be acted on.
 	TargetAccountID string `form:"-" json:"-" xml:"-"`
 }
 
+// AdminAccountQuotaRequest models a request to set the media storage quota of a local account.
+//
+// swagger:ignore
+type AdminAccountQuotaRequest struct {
+	// Max total size of media the account may store, either a number of bytes,
+	// or a size with a unit such as 500MiB. 0 means no limit, and "default"
+	// removes the account's override so that the instance default applies.
+	Quota string `form:"quota" json:"quota" xml:"quota"`
+}
+
 // MediaCleanupRequest models admin media cleanup parameters
 //
 // swagger:parameters mediaCleanup
@@ -189,3 +252,187 @@ type AdminSendTestEmailRequest struct {
 	// Email address to send the test email to.
 	Email string `form:"email" json:"email" xml:"email"`
 }
+
+// AdminAuditLogEntry models one action recorded in the moderation audit log.
+//
+// swagger:model adminAuditLogEntry
+type AdminAuditLogEntry struct {
+	// The ID of the audit log entry.
+	// example: 01H5YB4W6K8B1BB4N4QFZ0F3GG
+	ID string `json:"id"`
+	// When the action was taken (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// The ID of the account that took the action.
+	// example: 01GQ4PHNT622DQ9X95XQX4KKNR
+	AccountID string `json:"account_id"`
+	// The account that took the action.
+	// Null if the account no longer exists.
+	Account *AdminAccountInfo `json:"account"`
+	// The action that was taken.
+	// example: silence
+	Action string `json:"action"`
+	// The type of entity the action was taken on.
+	// One of account, domain_block, emoji, instance, media, report, rule.
+	// example: account
+	TargetType string `json:"target_type"`
+	// The ID of the entity the action was taken on.
+	// Empty if the action has no specific target.
+	// example: 01GQ4PHNT622DQ9X95XQX4KKNR
+	TargetID string `json:"target_id"`
+	// The fields changed by the action, keyed by field name,
+	// each with an "old" and a "new" value.
+	// swagger:type object
+	Diff json.RawMessage `json:"diff"`
+}
+
+// AdminMeasuresRequest models a request for quantitative measures of instance activity.
+//
+// swagger:ignore
+type AdminMeasuresRequest struct {
+	// Measures to return.
+	Keys []string `form:"keys[]" json:"keys" xml:"keys"`
+	// Start of the period to measure, as a date (eg., 2023-07-01), inclusive.
+	StartAt string `form:"start_at" json:"start_at" xml:"start_at"`
+	// End of the period to measure, as a date (eg., 2023-07-31), inclusive.
+	EndAt string `form:"end_at" json:"end_at" xml:"end_at"`
+	// Parameters for the instance_accounts measure.
+	InstanceAccounts *AdminMeasureParams `form:"-" json:"instance_accounts" xml:"instance_accounts"`
+	// Parameters for the instance_statuses measure.
+	InstanceStatuses *AdminMeasureParams `form:"-" json:"instance_statuses" xml:"instance_statuses"`
+	// Parameters for the instance_reports measure.
+	InstanceReports *AdminMeasureParams `form:"-" json:"instance_reports" xml:"instance_reports"`
+	// Parameters for the instance_media_attachments measure.
+	InstanceMediaAttachments *AdminMeasureParams `form:"-" json:"instance_media_attachments" xml:"instance_media_attachments"`
+}
+
+// AdminMeasureParams models additional parameters for one requested measure.
+//
+// swagger:ignore
+type AdminMeasureParams struct {
+	// Domain of the instance to measure.
+	Domain string `form:"domain" json:"domain" xml:"domain"`
+}
+
+// AdminMeasure models a quantitative measure of instance activity over a period of time.
+//
+// swagger:model adminMeasure
+type AdminMeasure struct {
+	// The unique key of this measure.
+	// example: active_users
+	Key string `json:"key"`
+	// The unit of the measure, if any.
+	// example: bytes
+	Unit string `json:"unit,omitempty"`
+	// The numeric total associated with the requested measure.
+	// example: 42
+	Total string `json:"total"`
+	// A human readable formatted value for this measure, if it has a unit.
+	// example: 1.2 MB
+	HumanValue string `json:"human_value,omitempty"`
+	// The numeric total associated with the requested measure,
+	// in the previous period of the same length.
+	// example: 37
+	PreviousTotal string `json:"previous_total,omitempty"`
+	// The data available for the requested measure, split into daily buckets.
+	Data []AdminMeasureData `json:"data"`
+}
+
+// AdminMeasureData models the value of a measure on one day.
+//
+// swagger:model adminMeasureData
+type AdminMeasureData struct {
+	// Midnight on the requested day in the time period (ISO 8601 Datetime).
+	// example: 2023-07-01T00:00:00.000Z
+	Date string `json:"date"`
+	// The numeric value for the requested measure.
+	// example: 3
+	Value string `json:"value"`
+}
+
+// AdminDimensionsRequest models a request for qualitative dimensions of instance activity.
+//
+// swagger:ignore
+type AdminDimensionsRequest struct {
+	// Dimensions to return.
+	Keys []string `form:"keys[]" json:"keys" xml:"keys"`
+	// Start of the period to look at, as a date (eg., 2023-07-01), inclusive.
+	StartAt string `form:"start_at" json:"start_at" xml:"start_at"`
+	// End of the period to look at, as a date (eg., 2023-07-31), inclusive.
+	EndAt string `form:"end_at" json:"end_at" xml:"end_at"`
+	// Max number of results to return per dimension.
+	Limit int `form:"limit" json:"limit" xml:"limit"`
+}
+
+// AdminDimension models a qualitative breakdown of instance activity.
+//
+// swagger:model adminDimension
+type AdminDimension struct {
+	// The unique key of this dimension.
+	// example: languages
+	Key string `json:"key"`
+	// The data available for the requested dimension.
+	Data []AdminDimensionData `json:"data"`
+}
+
+// AdminDimensionData models one item of a dimension.
+//
+// swagger:model adminDimensionData
+type AdminDimensionData struct {
+	// The unique key for this item.
+	// example: en
+	Key string `json:"key"`
+	// A human-readable key for this item.
+	// example: en
+	HumanKey string `json:"human_key"`
+	// The value for this item.
+	// example: 12
+	Value string `json:"value"`
+	// The unit of the value, if any.
+	// example: bytes
+	Unit string `json:"unit,omitempty"`
+	// A human readable formatted value for this item, if it has a unit.
+	// example: 1.2 MB
+	HumanValue string `json:"human_value,omitempty"`
+}
+
+// AdminRetentionRequest models a request for user retention data.
+//
+// swagger:ignore
+type AdminRetentionRequest struct {
+	// Start of the period to look at, as a date (eg., 2023-07-01), inclusive.
+	StartAt string `form:"start_at" json:"start_at" xml:"start_at"`
+	// End of the period to look at, as a date (eg., 2023-07-31), inclusive.
+	EndAt string `form:"end_at" json:"end_at" xml:"end_at"`
+	// Size of the cohort periods, either day or month.
+	Frequency string `form:"frequency" json:"frequency" xml:"frequency"`
+}
+
+// AdminCohort models the retention of users who signed up in one period.
+//
+// swagger:model adminCohort
+type AdminCohort struct {
+	// Start of the period in which the users of this cohort signed up (ISO 8601 Datetime).
+	// example: 2023-07-01T00:00:00.000Z
+	Period string `json:"period"`
+	// Size of the cohort period, either day or month.
+	// example: day
+	Frequency string `json:"frequency"`
+	// Retention data for users who signed up during the period.
+	Data []AdminCohortData `json:"data"`
+}
+
+// AdminCohortData models the activity of one cohort of users in one period.
+//
+// swagger:model adminCohortData
+type AdminCohortData struct {
+	// Start of the period (ISO 8601 Datetime).
+	// example: 2023-07-02T00:00:00.000Z
+	Date string `json:"date"`
+	// Fraction of the cohort that was active in this period.
+	// example: 0.5
+	Rate float64 `json:"rate"`
+	// Number of users of the cohort that were active in this period.
+	// example: 2
+	Value string `json:"value"`
+}
@@ -79,3 +79,35 @@ type DomainBlockCreateRequest struct {
 	// public comment on the reason for the domain block
 	PublicComment string `form:"public_comment" json:"public_comment" xml:"public_comment"`
 }
+
+// EmailDomainBlock represents a block on sign-ups using
+// email addresses at one domain (or any of its subdomains).
+//
+// swagger:model emailDomainBlock
+type EmailDomainBlock struct {
+	// The ID of the email domain block.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+	// The blocked email domain.
+	// example: disposable.example.org
+	Domain string `json:"domain"`
+	// ID of the account that created this email domain block.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	CreatedBy string `json:"created_by,omitempty"`
+	// Time at which this block was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+}
+
+// EmailDomainBlockCreateRequest is the form submitted as a POST
+// to /api/v1/admin/email_domain_blocks to create a new block.
+//
+// swagger:ignore
+type EmailDomainBlockCreateRequest struct {
+	// A plaintext list of domains to block, one per line.
+	// Only used if import=true is specified.
+	Domains *multipart.FileHeader `form:"domains" json:"domains" xml:"domains"`
+	// Email domain to block.
+	Domain string `form:"domain" json:"domain" xml:"domain"`
+}
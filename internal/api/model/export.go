@@ -0,0 +1,50 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// Export models an archive of an account's data, which
+// is built in the background after being requested.
+//
+// swagger:model export
+type Export struct {
+	// ID of the export.
+	// example: 01H8GQ1M5X3T6YV0B2N4K7C9DE
+	ID string `json:"id"`
+	// The date when this export was requested (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Whether the export is still being built, ready to download, or failed.
+	// enum:
+	//   - processing
+	//   - complete
+	//   - failed
+	// example: complete
+	State string `json:"state"`
+	// Size in bytes of the archive, once complete.
+	// example: 1048576
+	Size int64 `json:"size,omitempty"`
+	// Why the export failed, if it did.
+	// example: error writing outbox
+	Error string `json:"error,omitempty"`
+	// The date when this export was completed or failed (ISO 8601 Datetime), or null if it's still processing.
+	// example: 2021-07-30T09:20:25+00:00
+	CompletedAt *string `json:"completed_at"`
+	// The date when this export will be removed (ISO 8601 Datetime), or null if it's still processing.
+	// example: 2021-07-30T09:20:25+00:00
+	ExpiresAt *string `json:"expires_at"`
+}
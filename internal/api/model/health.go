@@ -0,0 +1,46 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// HealthStatus represents the result of a liveness or readiness probe.
+//
+// swagger:model healthStatus
+type HealthStatus struct {
+	// Overall status of the probe: "ok" if all checks passed, "fail" otherwise.
+	// example: ok
+	Status string `json:"status"`
+	// Result of each individual check, keyed by check name.
+	// Only included when the detailed view is permitted.
+	Checks map[string]HealthCheck `json:"checks,omitempty"`
+}
+
+// HealthCheck represents the result of one check within a health probe.
+//
+// swagger:model healthCheck
+type HealthCheck struct {
+	// Status of this check: "ok" or "fail".
+	// example: ok
+	Status string `json:"status"`
+	// Time taken to perform this check, in milliseconds.
+	// example: 2
+	DurationMS int64 `json:"duration_ms"`
+	// Error message if this check failed.
+	Error string `json:"error,omitempty"`
+	// Additional information about this check, if any.
+	Details []string `json:"details,omitempty"`
+}
@@ -0,0 +1,92 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import "mime/multipart"
+
+// Import models the import of a CSV file of follows, blocks etc, or an archive
+// of posts, which is processed in the background after being uploaded.
+//
+// swagger:model import
+type Import struct {
+	// ID of the import.
+	// example: 01H8GQ1M5X3T6YV0B2N4K7C9DE
+	ID string `json:"id"`
+	// The date when this import was uploaded (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// What kind of data is imported.
+	// enum:
+	//   - following
+	//   - blocks
+	//   - muting
+	//   - bookmarks
+	//   - lists
+	//   - archive
+	// example: following
+	Type string `json:"type"`
+	// Whether existing data not in the import is kept (merge) or removed (overwrite).
+	// enum:
+	//   - merge
+	//   - overwrite
+	// example: merge
+	Mode string `json:"mode"`
+	// Whether the import is still in progress, complete, or failed.
+	// enum:
+	//   - processing
+	//   - complete
+	//   - failed
+	// example: complete
+	State string `json:"state"`
+	// Number of rows in the imported file, or posts in the imported archive.
+	// example: 120
+	Total int `json:"total"`
+	// Number of rows processed so far.
+	// example: 100
+	Processed int `json:"processed"`
+	// Number of processed rows which couldn't be imported.
+	// example: 2
+	Failed int `json:"failed"`
+	// Why rows couldn't be imported. Only the first 100 failures are included.
+	// example: ["someone@example.org: account not found"]
+	Failures []string `json:"failures"`
+	// Why the import as a whole failed, if it did.
+	// example: context canceled
+	Error string `json:"error,omitempty"`
+	// The date when this import was completed or failed (ISO 8601 Datetime), or null if it's still in progress.
+	// example: 2021-07-30T09:20:25+00:00
+	CompletedAt *string `json:"completed_at"`
+}
+
+// ImportRequest models a request to import a CSV file or archive.
+//
+// swagger:ignore
+type ImportRequest struct {
+	// CSV file or archive to import, in the format of a Mastodon export.
+	Data *multipart.FileHeader `form:"data" binding:"required"`
+	// What kind of data is imported: following, blocks, muting, bookmarks, lists or archive.
+	Type string `form:"type" binding:"required"`
+	// Whether existing data not in the import is kept (merge) or removed (overwrite).
+	// Defaults to merge.
+	Mode string `form:"mode"`
+	// Whether to federate imported posts, sending them
+	// out to followers as if they'd just been posted.
+	// If not, they're imported as local-only posts.
+	// Only used when importing an archive. Defaults to false.
+	Federate bool `form:"federate"`
+}
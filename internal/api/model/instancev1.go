@@ -82,6 +82,8 @@ type InstanceV1 struct {
 	ThumbnailDescription string `json:"thumbnail_description,omitempty"`
 	// Contact account for the instance.
 	ContactAccount *Account `json:"contact_account,omitempty"`
+	// An itemized list of rules for this instance.
+	Rules []InstanceRule `json:"rules"`
 	// Maximum allowed length of a post on this instance, in characters.
 	//
 	// This is provided for compatibility with Tusky and other apps.
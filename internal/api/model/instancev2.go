@@ -63,8 +63,7 @@ type InstanceV2 struct {
 	//  Hints related to contacting a representative of the instance.
 	Contact InstanceV2Contact `json:"contact"`
 	// An itemized list of rules for this website.
-	// Currently not implemented (will always be empty array).
-	Rules []interface{} `json:"rules"`
+	Rules []InstanceRule `json:"rules"`
 }
 
 // Usage data for this instance.
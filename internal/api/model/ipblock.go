@@ -0,0 +1,77 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// AdminIPBlock models a block on requests from an IP address or range.
+//
+// swagger:model adminIPBlock
+type AdminIPBlock struct {
+	// ID of the IP block.
+	// example: 01H8GQ1M5X3T6YV0B2N4K7C9DE
+	ID string `json:"id"`
+	// Blocked address range in CIDR notation.
+	// example: 192.0.2.0/24
+	IP string `json:"ip"`
+	// What requests from the range are subject to.
+	// enum:
+	//   - sign_up_requires_approval
+	//   - sign_up_block
+	//   - no_access
+	// example: sign_up_block
+	Severity string `json:"severity"`
+	// Private comment on why the range was blocked.
+	// example: spam sign-ups
+	Comment string `json:"comment"`
+	// The date when this block was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// The date when this block expires (ISO 8601 Datetime), or null if it doesn't.
+	// example: 2021-07-30T09:20:25+00:00
+	ExpiresAt *string `json:"expires_at"`
+}
+
+// AdminIPBlockCreateRequest models a request to create an IP block.
+//
+// swagger:ignore
+type AdminIPBlockCreateRequest struct {
+	// IP address or range in CIDR notation to block.
+	IP string `form:"ip" json:"ip" xml:"ip"`
+	// Severity of the block.
+	Severity string `form:"severity" json:"severity" xml:"severity"`
+	// Private comment on why the range was blocked.
+	Comment string `form:"comment" json:"comment" xml:"comment"`
+	// Number of seconds from now after which the block expires.
+	// Zero or unset means the block never expires.
+	ExpiresIn int `form:"expires_in" json:"expires_in" xml:"expires_in"`
+}
+
+// AdminIPBlockUpdateRequest models a request to update an IP block.
+// Only fields which are set will be updated.
+//
+// swagger:ignore
+type AdminIPBlockUpdateRequest struct {
+	// IP address or range in CIDR notation to block.
+	IP *string `form:"ip" json:"ip" xml:"ip"`
+	// Severity of the block.
+	Severity *string `form:"severity" json:"severity" xml:"severity"`
+	// Private comment on why the range was blocked.
+	Comment *string `form:"comment" json:"comment" xml:"comment"`
+	// Number of seconds from now after which the block expires.
+	// Zero means the block never expires.
+	ExpiresIn *int `form:"expires_in" json:"expires_in" xml:"expires_in"`
+}
@@ -54,8 +54,8 @@ type Report struct {
 	StatusIDs []string `json:"status_ids"`
 	// Array of rule IDs that were submitted along with this report.
 	// Will be empty if no rule IDs were submitted.
-	// example: [1, 2]
-	RuleIDs []int `json:"rule_ids"`
+	// example: ["01H6Y8HD6R6C0N5M3MHW3X4PZ1","01H6Y8J2ZK0QEB9G5XG6Q7VCA2"]
+	RuleIDs []string `json:"rule_ids"`
 	// Account that was reported.
 	TargetAccount *Account `json:"target_account"`
 }
@@ -82,15 +82,15 @@ type ReportCreateRequest struct {
 	// default: false
 	// in: formData
 	Forward bool `form:"forward" json:"forward" xml:"forward"`
-	// Specify if the report is due to spam, violation of enumerated instance rules, or some other reason.
-	// Currently only 'other' is supported.
+	// Specify if the report is due to spam, illegal content, violation of enumerated
+	// instance rules, or some other reason. One of `spam`, `legal`, `violation` or `other`.
+	// If rule_ids are given, this defaults to `violation`, and must be `violation` if set.
 	// example: other
 	// default: other
 	// in: formData
 	Category string `form:"category" json:"category" xml:"category"`
 	// IDs of rules on this instance which have been broken according to the reporter.
-	// This is currently not supported, provided only for API compatibility.
-	// example: [1, 2, 3]
+	// example: ["01H6Y8HD6R6C0N5M3MHW3X4PZ1","01H6Y8J2ZK0QEB9G5XG6Q7VCA2"]
 	// in: formData
-	RuleIDs []int `form:"rule_ids[]" json:"rule_ids" xml:"rule_ids"`
+	RuleIDs []string `form:"rule_ids[]" json:"rule_ids" xml:"rule_ids"`
 }
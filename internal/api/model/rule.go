@@ -0,0 +1,71 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// InstanceRule models one rule of this instance.
+//
+// swagger:model instanceRule
+type InstanceRule struct {
+	// ID of the rule.
+	// example: 01H6Y8HD6R6C0N5M3MHW3X4PZ1
+	ID string `json:"id"`
+	// Text of the rule.
+	// example: Be nice to each other.
+	Text string `json:"text"`
+}
+
+// AdminInstanceRule models one rule of this instance, with
+// additional information only visible to admins.
+//
+// swagger:model adminInstanceRule
+type AdminInstanceRule struct {
+	// ID of the rule.
+	// example: 01H6Y8HD6R6C0N5M3MHW3X4PZ1
+	ID string `json:"id"`
+	// The date when this rule was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// The date when this rule was last updated (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	UpdatedAt string `json:"updated_at"`
+	// Text of the rule.
+	// example: Be nice to each other.
+	Text string `json:"text"`
+	// Position of the rule in the list of rules, lowest first.
+	// example: 0
+	Order int `json:"order"`
+}
+
+// InstanceRuleCreateRequest models a request to create an instance rule.
+//
+// swagger:ignore
+type InstanceRuleCreateRequest struct {
+	// Text of the rule.
+	Text string `form:"text" json:"text" xml:"text"`
+}
+
+// InstanceRuleUpdateRequest models a request to update an instance rule.
+//
+// swagger:ignore
+type InstanceRuleUpdateRequest struct {
+	// Text of the rule.
+	Text *string `form:"text" json:"text" xml:"text"`
+	// New position of the rule in the list of rules, starting from 0.
+	// Other rules are shifted to make room.
+	Order *int `form:"order" json:"order" xml:"order"`
+}
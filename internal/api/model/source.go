@@ -38,4 +38,19 @@ type Source struct {
 	Fields []Field `json:"fields"`
 	// The number of pending follow requests.
 	FollowRequestsCount int `json:"follow_requests_count"`
+	// Media storage usage and quota of the account.
+	MediaQuota *MediaQuota `json:"media_quota,omitempty"`
+}
+
+// MediaQuota represents the media storage usage and quota of a local account.
+//
+// swagger:model mediaQuota
+type MediaQuota struct {
+	// Total size in bytes of media currently stored for the account.
+	Usage int64 `json:"usage"`
+	// Max total size in bytes of media the account may store. 0 means no limit.
+	Limit int64 `json:"limit"`
+	// Whether the limit has been set specifically for this account by an admin,
+	// rather than being the instance default.
+	Override bool `json:"override"`
 }
@@ -32,4 +32,54 @@ type Token struct {
 	// When the OAuth token was generated (UNIX timestamp seconds).
 	// example: 1627644520
 	CreatedAt int64 `json:"created_at"`
+	// Seconds until the access token expires, if it expires.
+	// example: 3600
+	ExpiresIn int64 `json:"expires_in,omitempty"`
+	// Refresh token which can be exchanged for a new access token
+	// after this one expires, if access tokens expire on this instance.
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+// TokenInfo represents an OAuth access token which a user has authorized an application
+// to use, or a personal access token which the user has created for themself.
+//
+// swagger:model tokenInfo
+type TokenInfo struct {
+	// Database ID of this token.
+	// example: 01F8MGTQW4DKTDF8SW5CT9HYGA
+	ID string `json:"id"`
+	// When the token was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Approximately when the token was last used (ISO 8601 Datetime).
+	// Null if the token has not been used since this was tracked.
+	// example: 2021-07-30T09:20:25+00:00
+	LastUsed *string `json:"last_used"`
+	// OAuth scopes granted to this token, space-separated.
+	// example: read write
+	Scope string `json:"scope"`
+	// The application which was authorized to use this token.
+	Application *Application `json:"application"`
+	// Name of this token, if it's a personal access token.
+	// example: My backup script
+	Name string `json:"name,omitempty"`
+	// When the token expires (ISO 8601 Datetime).
+	// Null if the token doesn't expire.
+	// example: 2021-08-30T09:20:25+00:00
+	ExpiresAt *string `json:"expires_at"`
+	// The personal access token itself. Only included in
+	// the response when the token is first created.
+	AccessToken string `json:"access_token,omitempty"`
+}
+
+// PersonalTokenCreateRequest models a request to create a personal access token.
+//
+// swagger:ignore
+type PersonalTokenCreateRequest struct {
+	// Name to give the token, so it can be recognized later.
+	Name string `form:"name" json:"name" xml:"name"`
+	// OAuth scopes to grant the token, space-separated. Defaults to 'read'.
+	Scope string `form:"scope" json:"scope" xml:"scope"`
+	// Number of seconds from now that the token should expire. 0 means the token doesn't expire.
+	ExpiresIn int `form:"expires_in" json:"expires_in" xml:"expires_in"`
 }
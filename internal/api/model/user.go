@@ -34,3 +34,70 @@ type PasswordChangeRequest struct {
 	// required: true
 	NewPassword string `form:"new_password" json:"new_password" xml:"new_password" validation:"required"`
 }
+
+// EmailChangeRequest models user email change parameters.
+//
+// swagger:parameters userEmailChange
+type EmailChangeRequest struct {
+	// User's current password, for confirmation.
+	//
+	// in: formData
+	// required: true
+	Password string `form:"password" json:"password" xml:"password" validation:"required"`
+	// Desired new email address.
+	// A confirmation link will be sent to this address.
+	//
+	// in: formData
+	// required: true
+	NewEmail string `form:"new_email" json:"new_email" xml:"new_email" validation:"required"`
+}
+
+// EmailPreferences models a user's choices about which
+// events they want to be emailed about, and how.
+//
+// Each per-type preference is one of:
+//
+//   - `off`: don't send emails about this.
+//   - `immediate`: send an email as soon as this happens.
+//   - `digest`: include this in the periodic digest email.
+//
+// swagger:model emailPreferences
+type EmailPreferences struct {
+	// Email preference for mentions in posts that aren't direct messages.
+	// example: digest
+	Mentions string `json:"mentions"`
+	// Email preference for direct messages.
+	// example: immediate
+	Direct string `json:"direct"`
+	// Email preference for new followers.
+	// example: off
+	Follows string `json:"follows"`
+	// Email preference for new follow requests.
+	// example: digest
+	FollowRequests string `json:"follow_requests"`
+	// Email preference for reports created by this user being closed.
+	// example: immediate
+	ReportsClosed string `json:"reports_closed"`
+	// How often digest emails are sent: `daily` or `weekly`.
+	// example: daily
+	DigestFrequency string `json:"digest_frequency"`
+}
+
+// EmailPreferencesUpdateRequest models an update to a user's email preferences.
+// Fields that are not set will be left unchanged.
+//
+// swagger:ignore
+type EmailPreferencesUpdateRequest struct {
+	// Email preference for mentions in posts that aren't direct messages.
+	Mentions *string `form:"mentions" json:"mentions" xml:"mentions"`
+	// Email preference for direct messages.
+	Direct *string `form:"direct" json:"direct" xml:"direct"`
+	// Email preference for new followers.
+	Follows *string `form:"follows" json:"follows" xml:"follows"`
+	// Email preference for new follow requests.
+	FollowRequests *string `form:"follow_requests" json:"follow_requests" xml:"follow_requests"`
+	// Email preference for reports created by this user being closed.
+	ReportsClosed *string `form:"reports_closed" json:"reports_closed" xml:"reports_closed"`
+	// How often digest emails are sent: `daily` or `weekly`.
+	DigestFrequency *string `form:"digest_frequency" json:"digest_frequency" xml:"digest_frequency"`
+}
@@ -137,4 +137,9 @@ func (c *Caches) setuphooks() {
 		c.Visibility.Invalidate("ItemID", user.AccountID)
 		c.Visibility.Invalidate("RequesterID", user.AccountID)
 	})
+
+	c.GTS.UserMute().SetInvalidateCallback(func(mute *gtsmodel.UserMute) {
+		// Invalidate mute origin account ID cached visibility.
+		c.Visibility.Invalidate("RequesterID", mute.AccountID)
+	})
 }
@@ -21,30 +21,34 @@ import (
 	"codeberg.org/gruf/go-cache/v3/result"
 	"codeberg.org/gruf/go-cache/v3/ttl"
 	"github.com/superseriousbusiness/gotosocial/internal/cache/domain"
+	"github.com/superseriousbusiness/gotosocial/internal/cache/ip"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 type GTSCaches struct {
-	account *result.Cache[*gtsmodel.Account]
-	block   *result.Cache[*gtsmodel.Block]
+	account *ResultCache[*gtsmodel.Account]
+	block   *ResultCache[*gtsmodel.Block]
 	// TODO: maybe should be moved out of here since it's
 	// not actually doing anything with gtsmodel.DomainBlock.
-	domainBlock   *domain.BlockCache
-	emoji         *result.Cache[*gtsmodel.Emoji]
-	emojiCategory *result.Cache[*gtsmodel.EmojiCategory]
-	follow        *result.Cache[*gtsmodel.Follow]
-	followRequest *result.Cache[*gtsmodel.FollowRequest]
-	list          *result.Cache[*gtsmodel.List]
-	listEntry     *result.Cache[*gtsmodel.ListEntry]
-	media         *result.Cache[*gtsmodel.MediaAttachment]
-	mention       *result.Cache[*gtsmodel.Mention]
-	notification  *result.Cache[*gtsmodel.Notification]
-	report        *result.Cache[*gtsmodel.Report]
-	status        *result.Cache[*gtsmodel.Status]
-	statusFave    *result.Cache[*gtsmodel.StatusFave]
-	tombstone     *result.Cache[*gtsmodel.Tombstone]
-	user          *result.Cache[*gtsmodel.User]
+	domainBlock      *domain.BlockCache
+	emailDomainBlock *domain.BlockCache
+	emoji            *ResultCache[*gtsmodel.Emoji]
+	emojiCategory    *ResultCache[*gtsmodel.EmojiCategory]
+	follow           *ResultCache[*gtsmodel.Follow]
+	followRequest    *ResultCache[*gtsmodel.FollowRequest]
+	ipBlock          *ip.BlockCache
+	list             *ResultCache[*gtsmodel.List]
+	listEntry        *ResultCache[*gtsmodel.ListEntry]
+	media            *ResultCache[*gtsmodel.MediaAttachment]
+	mention          *ResultCache[*gtsmodel.Mention]
+	notification     *ResultCache[*gtsmodel.Notification]
+	report           *ResultCache[*gtsmodel.Report]
+	status           *ResultCache[*gtsmodel.Status]
+	statusFave       *ResultCache[*gtsmodel.StatusFave]
+	tombstone        *ResultCache[*gtsmodel.Tombstone]
+	user             *ResultCache[*gtsmodel.User]
+	userMute         *ResultCache[*gtsmodel.UserMute]
 	// TODO: move out of GTS caches since not using database models.
 	webfinger *ttl.Cache[string, string]
 }
@@ -55,10 +59,12 @@ func (c *GTSCaches) Init() {
 	c.initAccount()
 	c.initBlock()
 	c.initDomainBlock()
+	c.initEmailDomainBlock()
 	c.initEmoji()
 	c.initEmojiCategory()
 	c.initFollow()
 	c.initFollowRequest()
+	c.initIPBlock()
 	c.initList()
 	c.initListEntry()
 	c.initMedia()
@@ -69,6 +75,7 @@ func (c *GTSCaches) Init() {
 	c.initStatusFave()
 	c.initTombstone()
 	c.initUser()
+	c.initUserMute()
 	c.initWebfinger()
 }
 
@@ -90,6 +97,7 @@ func (c *GTSCaches) Start() {
 	tryStart(c.statusFave, config.GetCacheGTSStatusFaveSweepFreq())
 	tryStart(c.tombstone, config.GetCacheGTSTombstoneSweepFreq())
 	tryStart(c.user, config.GetCacheGTSUserSweepFreq())
+	tryStart(c.userMute, config.GetCacheGTSUserMuteSweepFreq())
 	tryUntil("starting *gtsmodel.Webfinger cache", 5, func() bool {
 		if sweep := config.GetCacheGTSWebfingerSweepFreq(); sweep > 0 {
 			return c.webfinger.Start(sweep)
@@ -116,16 +124,17 @@ func (c *GTSCaches) Stop() {
 	tryStop(c.statusFave, config.GetCacheGTSStatusFaveSweepFreq())
 	tryStop(c.tombstone, config.GetCacheGTSTombstoneSweepFreq())
 	tryStop(c.user, config.GetCacheGTSUserSweepFreq())
+	tryStop(c.userMute, config.GetCacheGTSUserMuteSweepFreq())
 	tryUntil("stopping *gtsmodel.Webfinger cache", 5, c.webfinger.Stop)
 }
 
 // Account provides access to the gtsmodel Account database cache.
-func (c *GTSCaches) Account() *result.Cache[*gtsmodel.Account] {
+func (c *GTSCaches) Account() *ResultCache[*gtsmodel.Account] {
 	return c.account
 }
 
 // Block provides access to the gtsmodel Block (account) database cache.
-func (c *GTSCaches) Block() *result.Cache[*gtsmodel.Block] {
+func (c *GTSCaches) Block() *ResultCache[*gtsmodel.Block] {
 	return c.block
 }
 
@@ -134,83 +143,98 @@ func (c *GTSCaches) DomainBlock() *domain.BlockCache {
 	return c.domainBlock
 }
 
+// EmailDomainBlock provides access to the email domain block database cache.
+func (c *GTSCaches) EmailDomainBlock() *domain.BlockCache {
+	return c.emailDomainBlock
+}
+
 // Emoji provides access to the gtsmodel Emoji database cache.
-func (c *GTSCaches) Emoji() *result.Cache[*gtsmodel.Emoji] {
+func (c *GTSCaches) Emoji() *ResultCache[*gtsmodel.Emoji] {
 	return c.emoji
 }
 
 // EmojiCategory provides access to the gtsmodel EmojiCategory database cache.
-func (c *GTSCaches) EmojiCategory() *result.Cache[*gtsmodel.EmojiCategory] {
+func (c *GTSCaches) EmojiCategory() *ResultCache[*gtsmodel.EmojiCategory] {
 	return c.emojiCategory
 }
 
 // Follow provides access to the gtsmodel Follow database cache.
-func (c *GTSCaches) Follow() *result.Cache[*gtsmodel.Follow] {
+func (c *GTSCaches) Follow() *ResultCache[*gtsmodel.Follow] {
 	return c.follow
 }
 
 // FollowRequest provides access to the gtsmodel FollowRequest database cache.
-func (c *GTSCaches) FollowRequest() *result.Cache[*gtsmodel.FollowRequest] {
+func (c *GTSCaches) FollowRequest() *ResultCache[*gtsmodel.FollowRequest] {
 	return c.followRequest
 }
 
+// IPBlock provides access to the IP block database cache.
+func (c *GTSCaches) IPBlock() *ip.BlockCache {
+	return c.ipBlock
+}
+
 // List provides access to the gtsmodel List database cache.
-func (c *GTSCaches) List() *result.Cache[*gtsmodel.List] {
+func (c *GTSCaches) List() *ResultCache[*gtsmodel.List] {
 	return c.list
 }
 
 // ListEntry provides access to the gtsmodel ListEntry database cache.
-func (c *GTSCaches) ListEntry() *result.Cache[*gtsmodel.ListEntry] {
+func (c *GTSCaches) ListEntry() *ResultCache[*gtsmodel.ListEntry] {
 	return c.listEntry
 }
 
 // Media provides access to the gtsmodel Media database cache.
-func (c *GTSCaches) Media() *result.Cache[*gtsmodel.MediaAttachment] {
+func (c *GTSCaches) Media() *ResultCache[*gtsmodel.MediaAttachment] {
 	return c.media
 }
 
 // Mention provides access to the gtsmodel Mention database cache.
-func (c *GTSCaches) Mention() *result.Cache[*gtsmodel.Mention] {
+func (c *GTSCaches) Mention() *ResultCache[*gtsmodel.Mention] {
 	return c.mention
 }
 
 // Notification provides access to the gtsmodel Notification database cache.
-func (c *GTSCaches) Notification() *result.Cache[*gtsmodel.Notification] {
+func (c *GTSCaches) Notification() *ResultCache[*gtsmodel.Notification] {
 	return c.notification
 }
 
 // Report provides access to the gtsmodel Report database cache.
-func (c *GTSCaches) Report() *result.Cache[*gtsmodel.Report] {
+func (c *GTSCaches) Report() *ResultCache[*gtsmodel.Report] {
 	return c.report
 }
 
 // Status provides access to the gtsmodel Status database cache.
-func (c *GTSCaches) Status() *result.Cache[*gtsmodel.Status] {
+func (c *GTSCaches) Status() *ResultCache[*gtsmodel.Status] {
 	return c.status
 }
 
 // StatusFave provides access to the gtsmodel StatusFave database cache.
-func (c *GTSCaches) StatusFave() *result.Cache[*gtsmodel.StatusFave] {
+func (c *GTSCaches) StatusFave() *ResultCache[*gtsmodel.StatusFave] {
 	return c.statusFave
 }
 
 // Tombstone provides access to the gtsmodel Tombstone database cache.
-func (c *GTSCaches) Tombstone() *result.Cache[*gtsmodel.Tombstone] {
+func (c *GTSCaches) Tombstone() *ResultCache[*gtsmodel.Tombstone] {
 	return c.tombstone
 }
 
 // User provides access to the gtsmodel User database cache.
-func (c *GTSCaches) User() *result.Cache[*gtsmodel.User] {
+func (c *GTSCaches) User() *ResultCache[*gtsmodel.User] {
 	return c.user
 }
 
+// UserMute provides access to the gtsmodel UserMute database cache.
+func (c *GTSCaches) UserMute() *ResultCache[*gtsmodel.UserMute] {
+	return c.userMute
+}
+
 // Webfinger provides access to the webfinger URL cache.
 func (c *GTSCaches) Webfinger() *ttl.Cache[string, string] {
 	return c.webfinger
 }
 
 func (c *GTSCaches) initAccount() {
-	c.account = result.New([]result.Lookup{
+	c.account = newResultCache(result.New([]result.Lookup{
 		{Name: "ID"},
 		{Name: "URI"},
 		{Name: "URL"},
@@ -224,13 +248,13 @@ func (c *GTSCaches) initAccount() {
 		a2 := new(gtsmodel.Account)
 		*a2 = *a1
 		return a2
-	}, config.GetCacheGTSAccountMaxSize())
+	}, config.GetCacheGTSAccountMaxSize()))
 	c.account.SetTTL(config.GetCacheGTSAccountTTL(), true)
 	c.account.IgnoreErrors(ignoreErrors)
 }
 
 func (c *GTSCaches) initBlock() {
-	c.block = result.New([]result.Lookup{
+	c.block = newResultCache(result.New([]result.Lookup{
 		{Name: "ID"},
 		{Name: "URI"},
 		{Name: "AccountID.TargetAccountID"},
@@ -238,7 +262,7 @@ func (c *GTSCaches) initBlock() {
 		b2 := new(gtsmodel.Block)
 		*b2 = *b1
 		return b2
-	}, config.GetCacheGTSBlockMaxSize())
+	}, config.GetCacheGTSBlockMaxSize()))
 	c.block.SetTTL(config.GetCacheGTSBlockTTL(), true)
 	c.block.IgnoreErrors(ignoreErrors)
 }
@@ -247,8 +271,12 @@ func (c *GTSCaches) initDomainBlock() {
 	c.domainBlock = new(domain.BlockCache)
 }
 
+func (c *GTSCaches) initEmailDomainBlock() {
+	c.emailDomainBlock = new(domain.BlockCache)
+}
+
 func (c *GTSCaches) initEmoji() {
-	c.emoji = result.New([]result.Lookup{
+	c.emoji = newResultCache(result.New([]result.Lookup{
 		{Name: "ID"},
 		{Name: "URI"},
 		{Name: "Shortcode.Domain"},
@@ -257,26 +285,26 @@ func (c *GTSCaches) initEmoji() {
 		e2 := new(gtsmodel.Emoji)
 		*e2 = *e1
 		return e2
-	}, config.GetCacheGTSEmojiMaxSize())
+	}, config.GetCacheGTSEmojiMaxSize()))
 	c.emoji.SetTTL(config.GetCacheGTSEmojiTTL(), true)
 	c.emoji.IgnoreErrors(ignoreErrors)
 }
 
 func (c *GTSCaches) initEmojiCategory() {
-	c.emojiCategory = result.New([]result.Lookup{
+	c.emojiCategory = newResultCache(result.New([]result.Lookup{
 		{Name: "ID"},
 		{Name: "Name"},
 	}, func(c1 *gtsmodel.EmojiCategory) *gtsmodel.EmojiCategory {
 		c2 := new(gtsmodel.EmojiCategory)
 		*c2 = *c1
 		return c2
-	}, config.GetCacheGTSEmojiCategoryMaxSize())
+	}, config.GetCacheGTSEmojiCategoryMaxSize()))
 	c.emojiCategory.SetTTL(config.GetCacheGTSEmojiCategoryTTL(), true)
 	c.emojiCategory.IgnoreErrors(ignoreErrors)
 }
 
 func (c *GTSCaches) initFollow() {
-	c.follow = result.New([]result.Lookup{
+	c.follow = newResultCache(result.New([]result.Lookup{
 		{Name: "ID"},
 		{Name: "URI"},
 		{Name: "AccountID.TargetAccountID"},
@@ -284,12 +312,12 @@ func (c *GTSCaches) initFollow() {
 		f2 := new(gtsmodel.Follow)
 		*f2 = *f1
 		return f2
-	}, config.GetCacheGTSFollowMaxSize())
+	}, config.GetCacheGTSFollowMaxSize()))
 	c.follow.SetTTL(config.GetCacheGTSFollowTTL(), true)
 }
 
 func (c *GTSCaches) initFollowRequest() {
-	c.followRequest = result.New([]result.Lookup{
+	c.followRequest = newResultCache(result.New([]result.Lookup{
 		{Name: "ID"},
 		{Name: "URI"},
 		{Name: "AccountID.TargetAccountID"},
@@ -297,85 +325,89 @@ func (c *GTSCaches) initFollowRequest() {
 		f2 := new(gtsmodel.FollowRequest)
 		*f2 = *f1
 		return f2
-	}, config.GetCacheGTSFollowRequestMaxSize())
+	}, config.GetCacheGTSFollowRequestMaxSize()))
 	c.followRequest.SetTTL(config.GetCacheGTSFollowRequestTTL(), true)
 }
 
+func (c *GTSCaches) initIPBlock() {
+	c.ipBlock = new(ip.BlockCache)
+}
+
 func (c *GTSCaches) initList() {
-	c.list = result.New([]result.Lookup{
+	c.list = newResultCache(result.New([]result.Lookup{
 		{Name: "ID"},
 	}, func(l1 *gtsmodel.List) *gtsmodel.List {
 		l2 := new(gtsmodel.List)
 		*l2 = *l1
 		return l2
-	}, config.GetCacheGTSListMaxSize())
+	}, config.GetCacheGTSListMaxSize()))
 	c.list.SetTTL(config.GetCacheGTSListTTL(), true)
 	c.list.IgnoreErrors(ignoreErrors)
 }
 
 func (c *GTSCaches) initListEntry() {
-	c.listEntry = result.New([]result.Lookup{
+	c.listEntry = newResultCache(result.New([]result.Lookup{
 		{Name: "ID"},
 	}, func(l1 *gtsmodel.ListEntry) *gtsmodel.ListEntry {
 		l2 := new(gtsmodel.ListEntry)
 		*l2 = *l1
 		return l2
-	}, config.GetCacheGTSListEntryMaxSize())
+	}, config.GetCacheGTSListEntryMaxSize()))
 	c.list.SetTTL(config.GetCacheGTSListEntryTTL(), true)
 	c.list.IgnoreErrors(ignoreErrors)
 }
 
 func (c *GTSCaches) initMedia() {
-	c.media = result.New([]result.Lookup{
+	c.media = newResultCache(result.New([]result.Lookup{
 		{Name: "ID"},
 	}, func(m1 *gtsmodel.MediaAttachment) *gtsmodel.MediaAttachment {
 		m2 := new(gtsmodel.MediaAttachment)
 		*m2 = *m1
 		return m2
-	}, config.GetCacheGTSMediaMaxSize())
+	}, config.GetCacheGTSMediaMaxSize()))
 	c.media.SetTTL(config.GetCacheGTSMediaTTL(), true)
 	c.media.IgnoreErrors(ignoreErrors)
 }
 
 func (c *GTSCaches) initMention() {
-	c.mention = result.New([]result.Lookup{
+	c.mention = newResultCache(result.New([]result.Lookup{
 		{Name: "ID"},
 	}, func(m1 *gtsmodel.Mention) *gtsmodel.Mention {
 		m2 := new(gtsmodel.Mention)
 		*m2 = *m1
 		return m2
-	}, config.GetCacheGTSMentionMaxSize())
+	}, config.GetCacheGTSMentionMaxSize()))
 	c.mention.SetTTL(config.GetCacheGTSMentionTTL(), true)
 	c.mention.IgnoreErrors(ignoreErrors)
 }
 
 func (c *GTSCaches) initNotification() {
-	c.notification = result.New([]result.Lookup{
+	c.notification = newResultCache(result.New([]result.Lookup{
 		{Name: "ID"},
 		{Name: "NotificationType.TargetAccountID.OriginAccountID.StatusID"},
 	}, func(n1 *gtsmodel.Notification) *gtsmodel.Notification {
 		n2 := new(gtsmodel.Notification)
 		*n2 = *n1
 		return n2
-	}, config.GetCacheGTSNotificationMaxSize())
+	}, config.GetCacheGTSNotificationMaxSize()))
 	c.notification.SetTTL(config.GetCacheGTSNotificationTTL(), true)
 	c.notification.IgnoreErrors(ignoreErrors)
 }
 
 func (c *GTSCaches) initReport() {
-	c.report = result.New([]result.Lookup{
+	c.report = newResultCache(result.New([]result.Lookup{
 		{Name: "ID"},
 	}, func(r1 *gtsmodel.Report) *gtsmodel.Report {
 		r2 := new(gtsmodel.Report)
 		*r2 = *r1
 		return r2
-	}, config.GetCacheGTSReportMaxSize())
+	}, config.GetCacheGTSReportMaxSize()))
 	c.report.SetTTL(config.GetCacheGTSReportTTL(), true)
 	c.report.IgnoreErrors(ignoreErrors)
 }
 
 func (c *GTSCaches) initStatus() {
-	c.status = result.New([]result.Lookup{
+	c.status = newResultCache(result.New([]result.Lookup{
 		{Name: "ID"},
 		{Name: "URI"},
 		{Name: "URL"},
@@ -383,53 +415,67 @@ func (c *GTSCaches) initStatus() {
 		s2 := new(gtsmodel.Status)
 		*s2 = *s1
 		return s2
-	}, config.GetCacheGTSStatusMaxSize())
+	}, config.GetCacheGTSStatusMaxSize()))
 	c.status.SetTTL(config.GetCacheGTSStatusTTL(), true)
 	c.status.IgnoreErrors(ignoreErrors)
 }
 
 func (c *GTSCaches) initStatusFave() {
-	c.statusFave = result.New([]result.Lookup{
+	c.statusFave = newResultCache(result.New([]result.Lookup{
 		{Name: "ID"},
 		{Name: "AccountID.StatusID"},
 	}, func(f1 *gtsmodel.StatusFave) *gtsmodel.StatusFave {
 		f2 := new(gtsmodel.StatusFave)
 		*f2 = *f1
 		return f2
-	}, config.GetCacheGTSStatusFaveMaxSize())
+	}, config.GetCacheGTSStatusFaveMaxSize()))
 	c.status.SetTTL(config.GetCacheGTSStatusFaveTTL(), true)
 	c.status.IgnoreErrors(ignoreErrors)
 }
 
 func (c *GTSCaches) initTombstone() {
-	c.tombstone = result.New([]result.Lookup{
+	c.tombstone = newResultCache(result.New([]result.Lookup{
 		{Name: "ID"},
 		{Name: "URI"},
 	}, func(t1 *gtsmodel.Tombstone) *gtsmodel.Tombstone {
 		t2 := new(gtsmodel.Tombstone)
 		*t2 = *t1
 		return t2
-	}, config.GetCacheGTSTombstoneMaxSize())
+	}, config.GetCacheGTSTombstoneMaxSize()))
 	c.tombstone.SetTTL(config.GetCacheGTSTombstoneTTL(), true)
 	c.tombstone.IgnoreErrors(ignoreErrors)
 }
 
 func (c *GTSCaches) initUser() {
-	c.user = result.New([]result.Lookup{
+	c.user = newResultCache(result.New([]result.Lookup{
 		{Name: "ID"},
 		{Name: "AccountID"},
 		{Name: "Email"},
 		{Name: "ConfirmationToken"},
 		{Name: "ExternalID"},
+		{Name: "UnsubscribeToken"},
 	}, func(u1 *gtsmodel.User) *gtsmodel.User {
 		u2 := new(gtsmodel.User)
 		*u2 = *u1
 		return u2
-	}, config.GetCacheGTSUserMaxSize())
+	}, config.GetCacheGTSUserMaxSize()))
 	c.user.SetTTL(config.GetCacheGTSUserTTL(), true)
 	c.user.IgnoreErrors(ignoreErrors)
 }
 
+func (c *GTSCaches) initUserMute() {
+	c.userMute = newResultCache(result.New([]result.Lookup{
+		{Name: "ID"},
+		{Name: "AccountID.TargetAccountID"},
+	}, func(m1 *gtsmodel.UserMute) *gtsmodel.UserMute {
+		m2 := new(gtsmodel.UserMute)
+		*m2 = *m1
+		return m2
+	}, config.GetCacheGTSUserMuteMaxSize()))
+	c.userMute.SetTTL(config.GetCacheGTSUserMuteTTL(), true)
+	c.userMute.IgnoreErrors(ignoreErrors)
+}
+
 func (c *GTSCaches) initWebfinger() {
 	c.webfinger = ttl.New[string, string](
 		0,
@@ -0,0 +1,177 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ip
+
+import (
+	"fmt"
+	"net/netip"
+	"sync/atomic"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// BlockCache provides a means of caching IP blocks in memory to reduce load
+// on an underlying storage mechanism, e.g. a database.
+//
+// Like the domain block cache, the in-memory block list is hydrated by a passed
+// loader function during a call to .Severity() when the cache isn't loaded, and
+// .Clear() can be used to invalidate the cache when a block is added / deleted.
+//
+// Blocks are stored in a binary prefix trie, one per address family, so that
+// finding all the blocked ranges which contain an address is a walk of at most
+// 32 (or 128) nodes. Expiry times are kept in the trie, so blocks stop applying
+// when they expire, without the cache needing to be cleared.
+type BlockCache struct {
+	// atomically updated ptr value to the
+	// current IP block cache prefix tries.
+	rootptr atomic.Pointer[root]
+}
+
+// Severity returns the highest severity of any unexpired block containing the given
+// address, or an empty severity if the address is not blocked. If the cache is not
+// currently loaded, then the provided load function is used to hydrate it.
+func (b *BlockCache) Severity(addr netip.Addr, load func() ([]*gtsmodel.IPBlock, error)) (gtsmodel.IPBlockSeverity, error) {
+	// Load the current root pointer value.
+	r := b.rootptr.Load()
+
+	if r == nil {
+		// Cache is not hydrated.
+		//
+		// Load blocks from callback.
+		blocks, err := load()
+		if err != nil {
+			return "", fmt.Errorf("error reloading cache: %w", err)
+		}
+
+		// Allocate new root to store prefixes.
+		r = new(root)
+
+		for _, block := range blocks {
+			prefix, err := netip.ParsePrefix(block.IP)
+			if err != nil {
+				return "", fmt.Errorf("error parsing ip block %s: %w", block.IP, err)
+			}
+			r.Add(prefix, block.Severity, block.ExpiresAt)
+		}
+
+		// Store the new root ptr.
+		b.rootptr.Store(r)
+	}
+
+	// Look for matches in the trie.
+	return r.Match(addr, time.Now()), nil
+}
+
+// Clear will drop the currently loaded block list,
+// triggering a reload on next call to .Severity().
+func (b *BlockCache) Clear() {
+	b.rootptr.Store(nil)
+}
+
+// root holds the prefix tries for both address families.
+type root struct {
+	v4 node
+	v6 node
+}
+
+// Add will add the given prefix to the appropriate trie.
+func (r *root) Add(prefix netip.Prefix, severity gtsmodel.IPBlockSeverity, expiresAt time.Time) {
+	prefix = prefix.Masked()
+	addr := prefix.Addr()
+
+	n := &r.v6
+	if addr.Is4() {
+		n = &r.v4
+	}
+
+	n.add(addr.AsSlice(), prefix.Bits(), entry{
+		severity:  severity,
+		expiresAt: expiresAt,
+	})
+}
+
+// Match will return the highest severity of any
+// unexpired stored prefix containing the address.
+func (r *root) Match(addr netip.Addr, now time.Time) gtsmodel.IPBlockSeverity {
+	// Treat IPv4-mapped IPv6 addresses as IPv4.
+	addr = addr.Unmap()
+
+	n := &r.v6
+	if addr.Is4() {
+		n = &r.v4
+	}
+
+	return n.match(addr.AsSlice(), now)
+}
+
+// entry is one block stored at a trie node.
+type entry struct {
+	severity  gtsmodel.IPBlockSeverity
+	expiresAt time.Time
+}
+
+// node is one node in a binary prefix trie,
+// where the path from the root to the node
+// spells out the bits of a prefix.
+type node struct {
+	child   [2]*node
+	entries []entry
+}
+
+func (n *node) add(addr []byte, bits int, e entry) {
+	for i := 0; i < bits; i++ {
+		b := bit(addr, i)
+		if n.child[b] == nil {
+			n.child[b] = new(node)
+		}
+		n = n.child[b]
+	}
+	n.entries = append(n.entries, e)
+}
+
+func (n *node) match(addr []byte, now time.Time) gtsmodel.IPBlockSeverity {
+	var severity gtsmodel.IPBlockSeverity
+
+	for i := 0; n != nil; i++ {
+		// Every prefix along the path
+		// contains the address, so keep
+		// the most severe of them.
+		for _, e := range n.entries {
+			if !e.expiresAt.IsZero() && !now.Before(e.expiresAt) {
+				continue
+			}
+			if e.severity.Level() > severity.Level() {
+				severity = e.severity
+			}
+		}
+
+		if i == len(addr)*8 {
+			break
+		}
+
+		n = n.child[bit(addr, i)]
+	}
+
+	return severity
+}
+
+// bit returns the i'th bit of addr, counting from the most significant.
+func bit(addr []byte, i int) int {
+	return int(addr[i/8]>>(7-uint(i%8))) & 1
+}
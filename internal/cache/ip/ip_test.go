@@ -0,0 +1,82 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ip_test
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/cache/ip"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func TestBlockCache(t *testing.T) {
+	c := new(ip.BlockCache)
+
+	blocks := []*gtsmodel.IPBlock{
+		{IP: "192.0.2.0/24", Severity: gtsmodel.IPBlockSignUpRequiresApproval},
+		{IP: "192.0.2.128/25", Severity: gtsmodel.IPBlockNoAccess},
+		{IP: "198.51.100.7/32", Severity: gtsmodel.IPBlockSignUpBlock},
+		{IP: "2001:db8::/32", Severity: gtsmodel.IPBlockSignUpBlock},
+		{IP: "203.0.113.0/24", Severity: gtsmodel.IPBlockNoAccess, ExpiresAt: time.Now().Add(-time.Minute)},
+		{IP: "203.0.113.0/28", Severity: gtsmodel.IPBlockSignUpBlock, ExpiresAt: time.Now().Add(time.Hour)},
+	}
+
+	loader := func() ([]*gtsmodel.IPBlock, error) {
+		t.Log("load: returning ip blocks")
+		return blocks, nil
+	}
+
+	for addr, expected := range map[string]gtsmodel.IPBlockSeverity{
+		"192.0.2.1":          gtsmodel.IPBlockSignUpRequiresApproval,
+		"192.0.2.127":        gtsmodel.IPBlockSignUpRequiresApproval,
+		"192.0.2.128":        gtsmodel.IPBlockNoAccess, // most severe of overlapping blocks
+		"192.0.2.255":        gtsmodel.IPBlockNoAccess,
+		"::ffff:192.0.2.200": gtsmodel.IPBlockNoAccess, // ipv4-mapped ipv6
+		"198.51.100.7":       gtsmodel.IPBlockSignUpBlock,
+		"198.51.100.8":       "",
+		"192.0.3.1":          "",
+		"2001:db8::1":        gtsmodel.IPBlockSignUpBlock,
+		"2001:db9::1":        "",
+		"203.0.113.1":        gtsmodel.IPBlockSignUpBlock, // expired block doesn't apply
+		"203.0.113.100":      "",
+	} {
+		severity, err := c.Severity(netip.MustParseAddr(addr), loader)
+		if err != nil {
+			t.Fatalf("error checking %s: %v", addr, err)
+		}
+		if severity != expected {
+			t.Errorf("expected severity %q for %s, got %q", expected, addr, severity)
+		}
+	}
+
+	// Clear the cache
+	c.Clear()
+
+	knownErr := errors.New("known error")
+
+	// Check that reload is actually performed and returns our error
+	if _, err := c.Severity(netip.MustParseAddr("192.0.2.1"), func() ([]*gtsmodel.IPBlock, error) {
+		t.Log("load: returning known error")
+		return nil, knownErr
+	}); !errors.Is(err, knownErr) {
+		t.Errorf("severity did not return expected error: %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cache
+
+import (
+	"reflect"
+	"sync/atomic"
+	"unsafe"
+
+	"codeberg.org/gruf/go-cache/v3/result"
+)
+
+// Stats contains usage statistics of one cache.
+type Stats struct {
+	// Name of the cache, eg. "account".
+	Name string
+
+	// Hits is the number of loads served from the cache.
+	Hits uint64
+
+	// Misses is the number of loads that fell through to the loader.
+	Misses uint64
+
+	// Size is the current number of entries in the cache.
+	Size int
+}
+
+// ResultCache wraps a result.Cache, counting hits and misses on Load().
+type ResultCache[Value any] struct {
+	*result.Cache[Value]
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// newResultCache wraps the given result.Cache.
+func newResultCache[Value any](cache *result.Cache[Value]) *ResultCache[Value] {
+	return &ResultCache[Value]{Cache: cache}
+}
+
+// Load: see result.Cache{}.Load(), additionally counting whether it was a hit or a miss.
+func (c *ResultCache[Value]) Load(lookup string, load func() (Value, error), keyParts ...any) (Value, error) {
+	var missed bool
+
+	v, err := c.Cache.Load(lookup, func() (Value, error) {
+		missed = true
+		return load()
+	}, keyParts...)
+
+	if missed {
+		c.misses.Add(1)
+	} else {
+		c.hits.Add(1)
+	}
+
+	return v, err
+}
+
+// Stats returns the current usage statistics of the cache, with given name.
+func (c *ResultCache[Value]) Stats(name string) Stats {
+	return Stats{
+		Name:   name,
+		Hits:   c.hits.Load(),
+		Misses: c.misses.Load(),
+		Size:   resultCacheLen(c.Cache),
+	}
+}
+
+// resultCacheLen returns the number of entries in the given result.Cache. This
+// isn't exposed by result.Cache{} itself, so is read from its underlying ttl.Cache{}.
+func resultCacheLen[Value any](cache *result.Cache[Value]) int {
+	field := reflect.ValueOf(cache).Elem().FieldByName("cache")
+	ttl := reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr()))
+	return int(ttl.MethodByName("Len").Call(nil)[0].Int())
+}
+
+// Stats returns usage statistics of each of the caches.
+func (c *Caches) Stats() []Stats {
+	return append(c.GTS.Stats(), c.Visibility.Stats("visibility"))
+}
+
+// Stats returns usage statistics of each of the gtsmodel caches.
+func (c *GTSCaches) Stats() []Stats {
+	return []Stats{
+		c.account.Stats("account"),
+		c.block.Stats("block"),
+		c.emoji.Stats("emoji"),
+		c.emojiCategory.Stats("emoji_category"),
+		c.follow.Stats("follow"),
+		c.followRequest.Stats("follow_request"),
+		c.list.Stats("list"),
+		c.listEntry.Stats("list_entry"),
+		c.media.Stats("media"),
+		c.mention.Stats("mention"),
+		c.notification.Stats("notification"),
+		c.report.Stats("report"),
+		c.status.Stats("status"),
+		c.statusFave.Stats("status_fave"),
+		c.tombstone.Stats("tombstone"),
+		c.user.Stats("user"),
+	}
+}
@@ -23,7 +23,6 @@ import (
 	"fmt"
 	"time"
 
-	"codeberg.org/gruf/go-cache/v3/result"
 	errorsv2 "codeberg.org/gruf/go-errors/v2"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
@@ -52,7 +51,7 @@ func (*nocopy) Lock() {}
 func (*nocopy) Unlock() {}
 
 // tryStart will attempt to start the given cache only if sweep duration > 0 (sweeping is enabled).
-func tryStart[ValueType any](cache *result.Cache[ValueType], sweep time.Duration) {
+func tryStart[ValueType any](cache *ResultCache[ValueType], sweep time.Duration) {
 	if sweep > 0 {
 		var z ValueType
 		msg := fmt.Sprintf("starting %T cache", z)
@@ -63,7 +62,7 @@ func tryStart[ValueType any](cache *result.Cache[ValueType], sweep time.Duration
 }
 
 // tryStop will attempt to stop the given cache only if sweep duration > 0 (sweeping is enabled).
-func tryStop[ValueType any](cache *result.Cache[ValueType], sweep time.Duration) {
+func tryStop[ValueType any](cache *ResultCache[ValueType], sweep time.Duration) {
 	if sweep > 0 {
 		var z ValueType
 		msg := fmt.Sprintf("stopping %T cache", z)
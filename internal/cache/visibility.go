@@ -23,13 +23,13 @@ import (
 )
 
 type VisibilityCache struct {
-	*result.Cache[*CachedVisibility]
+	*ResultCache[*CachedVisibility]
 }
 
 // Init will initialize the visibility cache in this collection.
 // NOTE: the cache MUST NOT be in use anywhere, this is not thread-safe.
 func (c *VisibilityCache) Init() {
-	c.Cache = result.New([]result.Lookup{
+	c.ResultCache = newResultCache(result.New([]result.Lookup{
 		{Name: "ItemID", Multi: true},
 		{Name: "RequesterID", Multi: true},
 		{Name: "Type.RequesterID.ItemID"},
@@ -37,19 +37,19 @@ func (c *VisibilityCache) Init() {
 		v2 := new(CachedVisibility)
 		*v2 = *v1
 		return v2
-	}, config.GetCacheVisibilityMaxSize())
-	c.Cache.SetTTL(config.GetCacheVisibilityTTL(), true)
-	c.Cache.IgnoreErrors(ignoreErrors)
+	}, config.GetCacheVisibilityMaxSize()))
+	c.SetTTL(config.GetCacheVisibilityTTL(), true)
+	c.IgnoreErrors(ignoreErrors)
 }
 
 // Start will attempt to start the visibility cache, or panic.
 func (c *VisibilityCache) Start() {
-	tryStart(c.Cache, config.GetCacheVisibilitySweepFreq())
+	tryStart(c.ResultCache, config.GetCacheVisibilitySweepFreq())
 }
 
 // Stop will attempt to stop the visibility cache, or panic.
 func (c *VisibilityCache) Stop() {
-	tryStop(c.Cache, config.GetCacheVisibilitySweepFreq())
+	tryStop(c.ResultCache, config.GetCacheVisibilitySweepFreq())
 }
 
 // VisibilityType represents a visibility lookup type.
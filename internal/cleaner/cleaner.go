@@ -20,6 +20,8 @@ package cleaner
 import (
 	"context"
 	"errors"
+	"path"
+	"strings"
 	"time"
 
 	"codeberg.org/gruf/go-runners"
@@ -30,6 +32,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
 )
 
 const (
@@ -76,7 +79,8 @@ func (c *Cleaner) checkFiles(ctx context.Context, onMissing func() error, files
 	return false, nil
 }
 
-// removeFiles removes the provided files, returning the number of them returned.
+// removeFiles removes the provided files, returning the number of them returned. Files
+// that are content-addressed blobs are only removed once their last reference is released.
 func (c *Cleaner) removeFiles(ctx context.Context, files ...string) (int, error) {
 	if gtscontext.DryRun(ctx) {
 		// Dry run, do nothing.
@@ -88,7 +92,7 @@ func (c *Cleaner) removeFiles(ctx context.Context, files ...string) (int, error)
 	for _, path := range files {
 		// Remove each provided storage path.
 		log.Debugf(ctx, "removing file: %s", path)
-		err := c.state.Storage.Delete(ctx, path)
+		err := c.state.Storage.Release(ctx, c.state.DB, path)
 		if err != nil && !errors.Is(err, storage.ErrNotFound) {
 			errs.Appendf("error removing %s: %v", path, err)
 		}
@@ -105,6 +109,55 @@ func (c *Cleaner) removeFiles(ctx context.Context, files ...string) (int, error)
 	return diff, nil
 }
 
+// dedupeFile copies the file at given path into content-addressed blob storage, calling update()
+// with the new blob storage key, before removing the original file. Returns false if the
+// file is already deduplicated. Context will be checked for `gtscontext.DryRun()`.
+func (c *Cleaner) dedupeFile(ctx context.Context, file string, update func(string) error) (bool, error) {
+	if file == "" || gtsstorage.IsBlobKey(file) {
+		// Nothing to do.
+		return false, nil
+	}
+
+	if gtscontext.DryRun(ctx) {
+		// Dry run, do nothing.
+		return true, nil
+	}
+
+	rc, err := c.state.Storage.GetStream(ctx, file)
+	if errors.Is(err, storage.ErrNotFound) {
+		// Missing files are handled elsewhere.
+		log.Warnf(ctx, "skipping missing file: %s", file)
+		return false, nil
+	} else if err != nil {
+		return false, gtserror.Newf("error opening %s: %w", file, err)
+	}
+
+	// Store file contents by hash, keeping extension.
+	ext := strings.TrimPrefix(path.Ext(file), ".")
+	key, _, err := c.state.Storage.PutBlob(ctx, c.state.DB, rc, ext)
+	_ = rc.Close()
+	if err != nil {
+		return false, gtserror.Newf("error storing blob for %s: %w", file, err)
+	}
+
+	if err := update(key); err != nil {
+		// Database wasn't updated, drop the new reference.
+		if err := c.state.Storage.Release(ctx, c.state.DB, key); err != nil {
+			log.Errorf(ctx, "error releasing blob %s: %v", key, err)
+		}
+		return false, err
+	}
+
+	log.Debugf(ctx, "deduplicated file %s as %s", file, key)
+
+	// Finally remove the original file.
+	if err := c.state.Storage.Delete(ctx, file); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return true, gtserror.Newf("error removing %s: %w", file, err)
+	}
+
+	return true, nil
+}
+
 func scheduleJobs(c *Cleaner) {
 	const day = time.Hour * 24
 
@@ -51,6 +51,16 @@ func (e *Emoji) LogPruneMissing(ctx context.Context) {
 	}
 }
 
+// LogDedupe performs emoji.Dedupe(...), logging the start and outcome.
+func (e *Emoji) LogDedupe(ctx context.Context) {
+	log.Info(ctx, "start")
+	if n, err := e.Dedupe(ctx); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "deduplicated: %d", n)
+	}
+}
+
 // LogFixBroken performs emoji.FixBroken(...), logging the start and outcome.
 func (e *Emoji) LogFixBroken(ctx context.Context) {
 	log.Info(ctx, "start")
@@ -103,6 +113,51 @@ func (e *Emoji) PruneMissing(ctx context.Context) (int, error) {
 	return total, nil
 }
 
+// Dedupe will move the original images of all emojis into content-addressed blob storage,
+// so identical images are only stored once. This is only needed for emojis stored before
+// deduplication was supported. Context will be checked for `gtscontext.DryRun()`.
+func (e *Emoji) Dedupe(ctx context.Context) (int, error) {
+	var (
+		total int
+		maxID string
+	)
+
+	for {
+		// Fetch the next batch of emoji media up to next ID.
+		emojis, err := e.state.DB.GetEmojis(ctx, maxID, selectLimit)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return total, gtserror.Newf("error getting emojis: %w", err)
+		}
+
+		if len(emojis) == 0 {
+			// reached end.
+			break
+		}
+
+		// Use last as the next 'maxID' value.
+		maxID = emojis[len(emojis)-1].ID
+
+		for _, emoji := range emojis {
+			// Check / dedupe emoji image.
+			deduped, err := e.dedupeFile(ctx, emoji.ImagePath, func(key string) error {
+				emoji.ImagePath = key
+				return e.state.DB.UpdateEmoji(ctx, emoji, "image_path")
+			})
+			if err != nil {
+				return total, err
+			}
+
+			if deduped {
+				// Update
+				// count.
+				total++
+			}
+		}
+	}
+
+	return total, nil
+}
+
 // FixBroken will check all emojis for valid related models (e.g. category).
 // Broken media will be automatically updated to remove now-missing models.
 // Context will be checked for `gtscontext.DryRun()` to perform the action.
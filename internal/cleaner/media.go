@@ -29,6 +29,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/regexes"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
@@ -79,6 +80,16 @@ func (m *Media) LogPruneUnused(ctx context.Context) {
 	}
 }
 
+// LogDedupe performs Media.Dedupe(...), logging the start and outcome.
+func (m *Media) LogDedupe(ctx context.Context) {
+	log.Info(ctx, "start")
+	if n, err := m.Dedupe(ctx); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "deduplicated: %d", n)
+	}
+}
+
 // LogFixCacheStates performs Media.FixCacheStates(...), logging the start and outcome.
 func (m *Media) LogFixCacheStates(ctx context.Context) {
 	log.Info(ctx, "start")
@@ -92,10 +103,28 @@ func (m *Media) LogFixCacheStates(ctx context.Context) {
 // PruneOrphaned will delete orphaned files from storage (i.e. media missing a database entry).
 // Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
 func (m *Media) PruneOrphaned(ctx context.Context) (int, error) {
-	var files []string
+	var (
+		files []string
+		blobs int
+	)
 
-	// All media files in storage will have path fitting: {$account}/{$type}/{$size}/{$id}.{$ext}
+	// All media files in storage will have path fitting: {$account}/{$type}/{$size}/{$id}.{$ext},
+	// or for deduplicated originals, the content-addressed blob key.
 	if err := m.state.Storage.WalkKeys(ctx, func(ctx context.Context, path string) error {
+		if storage.IsBlobKey(path) {
+			// Check for and remove unreferenced blob.
+			pruned, err := m.pruneBlob(ctx, path)
+			if err != nil {
+				return gtserror.Newf("error pruning blob: %w", err)
+			}
+
+			if pruned {
+				blobs++
+			}
+
+			return nil
+		}
+
 		if !regexes.FilePath.MatchString(path) {
 			// This is not our expected media
 			// path format, skip this one.
@@ -119,7 +148,50 @@ func (m *Media) PruneOrphaned(ctx context.Context) (int, error) {
 	}
 
 	// Delete all orphaned files from storage.
-	return m.removeFiles(ctx, files...)
+	n, err := m.removeFiles(ctx, files...)
+	return n + blobs, err
+}
+
+// Dedupe will move the original files of all cached media attachments into content-addressed
+// blob storage, so identical files are only stored once. This is only needed for media stored
+// before deduplication was supported. Context will be checked for `gtscontext.DryRun()`.
+func (m *Media) Dedupe(ctx context.Context) (int, error) {
+	var (
+		total int
+		maxID string
+	)
+
+	for {
+		// Fetch the next batch of media attachments up to next max ID.
+		attachments, err := m.state.DB.GetAttachments(ctx, maxID, selectLimit)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return total, gtserror.Newf("error getting attachments: %w", err)
+		}
+
+		if len(attachments) == 0 {
+			// reached end.
+			break
+		}
+
+		// Use last ID as the next 'maxID' value.
+		maxID = attachments[len(attachments)-1].ID
+
+		for _, media := range attachments {
+			// Check / dedupe media attachment.
+			deduped, err := m.dedupe(ctx, media)
+			if err != nil {
+				return total, err
+			}
+
+			if deduped {
+				// Update
+				// count.
+				total++
+			}
+		}
+	}
+
+	return total, nil
 }
 
 // PruneUnused will delete all unused media attachments from the database and storage driver.
@@ -251,6 +323,24 @@ func (m *Media) FixCacheStates(ctx context.Context) (int, error) {
 	return total, nil
 }
 
+// pruneBlob removes the blob at path from storage if there are no references to
+// it, i.e. the database entries referencing it have all been removed, or it was
+// left behind by an interrupted write. Returns whether blob is (to be) removed.
+func (m *Media) pruneBlob(ctx context.Context, path string) (bool, error) {
+	if gtscontext.DryRun(ctx) {
+		// Dry run, only check references.
+		n, err := m.state.DB.GetBlobRefs(ctx, path)
+		return n == 0, err
+	}
+
+	pruned, err := m.state.Storage.PruneBlob(ctx, m.state.DB, path)
+	if pruned {
+		log.Debugf(ctx, "removed unreferenced blob: %s", path)
+	}
+
+	return pruned, err
+}
+
 func (m *Media) isOrphaned(ctx context.Context, path string) (bool, error) {
 	pathParts := regexes.FilePath.FindStringSubmatch(path)
 	if len(pathParts) != 6 {
@@ -497,17 +587,35 @@ func (m *Media) getRelatedStatus(ctx context.Context, media *gtsmodel.MediaAttac
 	return status, false, nil
 }
 
+func (m *Media) dedupe(ctx context.Context, media *gtsmodel.MediaAttachment) (bool, error) {
+	if !*media.Cached {
+		// Nothing stored.
+		return false, nil
+	}
+
+	if media.File.SourcePath != "" {
+		// Converted media, the
+		// original is the source.
+		return m.dedupeFile(ctx, media.File.SourcePath, func(key string) error {
+			media.File.SourcePath = key
+			return m.state.DB.UpdateAttachment(ctx, media, "file_source_path")
+		})
+	}
+
+	return m.dedupeFile(ctx, media.File.Path, func(key string) error {
+		media.File.Path = key
+		return m.state.DB.UpdateAttachment(ctx, media, "file_path")
+	})
+}
+
 func (m *Media) uncache(ctx context.Context, media *gtsmodel.MediaAttachment) error {
 	if gtscontext.DryRun(ctx) {
 		// Dry run, do nothing.
 		return nil
 	}
 
-	// Remove media and thumbnail.
-	_, err := m.removeFiles(ctx,
-		media.File.Path,
-		media.Thumbnail.Path,
-	)
+	// Remove media, thumbnail and any original source.
+	_, err := m.removeFiles(ctx, mediaFiles(media)...)
 	if err != nil {
 		return gtserror.Newf("error removing media files: %w", err)
 	}
@@ -528,11 +636,8 @@ func (m *Media) delete(ctx context.Context, media *gtsmodel.MediaAttachment) err
 		return nil
 	}
 
-	// Remove media and thumbnail.
-	_, err := m.removeFiles(ctx,
-		media.File.Path,
-		media.Thumbnail.Path,
-	)
+	// Remove media, thumbnail and any original source.
+	_, err := m.removeFiles(ctx, mediaFiles(media)...)
 	if err != nil {
 		return gtserror.Newf("error removing media files: %w", err)
 	}
@@ -545,3 +650,21 @@ func (m *Media) delete(ctx context.Context, media *gtsmodel.MediaAttachment) err
 
 	return nil
 }
+
+// mediaFiles returns the storage paths of all files stored for media.
+func mediaFiles(media *gtsmodel.MediaAttachment) []string {
+	if !*media.Cached {
+		// Files were already
+		// released on uncache.
+		return nil
+	}
+
+	files := []string{
+		media.File.Path,
+		media.Thumbnail.Path,
+	}
+	if media.File.SourcePath != "" {
+		files = append(files, media.File.SourcePath)
+	}
+	return files
+}
@@ -396,8 +396,8 @@ func (suite *MediaTestSuite) TestUncacheAndRecache() {
 		// recachedAttachment should be basically the same as the old attachment
 		suite.True(*recachedAttachment.Cached)
 		suite.Equal(original.ID, recachedAttachment.ID)
-		suite.Equal(original.File.Path, recachedAttachment.File.Path)           // file should be stored in the same place
-		suite.Equal(original.Thumbnail.Path, recachedAttachment.Thumbnail.Path) // as should the thumbnail
+		suite.True(storage.IsBlobKey(recachedAttachment.File.Path))             // file should be stored by content hash
+		suite.Equal(original.Thumbnail.Path, recachedAttachment.Thumbnail.Path) // thumbnail should be stored in the same place
 		suite.EqualValues(original.FileMeta, recachedAttachment.FileMeta)       // and the filemeta should be the same
 
 		// recached files should be back in storage
@@ -425,3 +425,104 @@ func (suite *MediaTestSuite) TestUncacheOneNonExistent() {
 	suite.NoError(err)
 	suite.Equal(2, totalUncached)
 }
+
+func (suite *MediaTestSuite) TestDedupe() {
+	ctx := context.Background()
+
+	// Note which attachments actually have files stored.
+	stored := make(map[string]bool)
+	for _, original := range suite.testAttachments {
+		have, err := suite.storage.Has(ctx, original.File.Path)
+		suite.NoError(err)
+		stored[original.ID] = have && *original.Cached
+	}
+
+	// Dry run should leave everything in place.
+	totalDry, err := suite.cleaner.Media().Dedupe(gtscontext.SetDryRun(ctx))
+	suite.NoError(err)
+	suite.NotZero(totalDry)
+
+	for _, original := range suite.testAttachments {
+		attachment, err := suite.db.GetAttachmentByID(ctx, original.ID)
+		suite.NoError(err)
+		suite.Equal(original.File.Path, attachment.File.Path)
+	}
+
+	total, err := suite.cleaner.Media().Dedupe(ctx)
+	suite.NoError(err)
+	suite.NotZero(total)
+
+	for _, original := range suite.testAttachments {
+		if !stored[original.ID] {
+			continue
+		}
+
+		attachment, err := suite.db.GetAttachmentByID(ctx, original.ID)
+		suite.NoError(err)
+
+		// Originals should now be stored by content hash.
+		suite.True(storage.IsBlobKey(attachment.File.Path))
+		suite.Equal(original.Thumbnail.Path, attachment.Thumbnail.Path)
+
+		b, err := suite.storage.Get(ctx, attachment.File.Path)
+		suite.NoError(err)
+		suite.Len(b, attachment.File.FileSize)
+
+		// And no longer at the previous path.
+		have, err := suite.storage.Has(ctx, original.File.Path)
+		suite.NoError(err)
+		suite.False(have)
+
+		refs, err := suite.db.GetBlobRefs(ctx, attachment.File.Path)
+		suite.NoError(err)
+		suite.Positive(refs)
+	}
+
+	// Nothing left to do on a second run,
+	// and nothing should be orphaned.
+	total, err = suite.cleaner.Media().Dedupe(ctx)
+	suite.NoError(err)
+	suite.Zero(total)
+
+	pruned, err := suite.cleaner.Media().PruneOrphaned(ctx)
+	suite.NoError(err)
+	suite.Zero(pruned)
+}
+
+func (suite *MediaTestSuite) TestPruneOrphanedBlob() {
+	ctx := context.Background()
+
+	b, err := os.ReadFile("../media/test/test-jpeg.jpg")
+	suite.NoError(err)
+
+	// Store the same file for two attachments.
+	key, _, err := suite.storage.PutBlob(ctx, suite.db, bytes.NewReader(b), "jpg")
+	suite.NoError(err)
+	_, _, err = suite.storage.PutBlob(ctx, suite.db, bytes.NewReader(b), "jpg")
+	suite.NoError(err)
+
+	// And an orphaned blob that was never referenced.
+	orphan := "blobs/sha256/00/0000000000000000000000000000000000000000000000000000000000000000.jpg"
+	_, err = suite.storage.Put(ctx, orphan, b)
+	suite.NoError(err)
+
+	pruned, err := suite.cleaner.Media().PruneOrphaned(ctx)
+	suite.NoError(err)
+	suite.Equal(1, pruned)
+
+	have, err := suite.storage.Has(ctx, orphan)
+	suite.NoError(err)
+	suite.False(have)
+
+	// Releasing one reference should keep the shared file.
+	suite.NoError(suite.storage.Release(ctx, suite.db, key))
+	have, err = suite.storage.Has(ctx, key)
+	suite.NoError(err)
+	suite.True(have)
+
+	// Releasing the last should remove it.
+	suite.NoError(suite.storage.Release(ctx, suite.db, key))
+	have, err = suite.storage.Has(ctx, key)
+	suite.NoError(err)
+	suite.False(have)
+}
@@ -81,11 +81,14 @@ type Configuration struct {
 	InstanceExposePublicTimeline   bool `name:"instance-expose-public-timeline" usage:"Allow unauthenticated users to query /api/v1/timelines/public"`
 	InstanceDeliverToSharedInboxes bool `name:"instance-deliver-to-shared-inboxes" usage:"Deliver federated messages to shared inboxes, if they're available."`
 
-	AccountsRegistrationOpen bool `name:"accounts-registration-open" usage:"Allow anyone to submit an account signup request. If false, server will be invite-only."`
-	AccountsApprovalRequired bool `name:"accounts-approval-required" usage:"Do account signups require approval by an admin or moderator before user can log in? If false, new registrations will be automatically approved."`
-	AccountsReasonRequired   bool `name:"accounts-reason-required" usage:"Do new account signups require a reason to be submitted on registration?"`
-	AccountsAllowCustomCSS   bool `name:"accounts-allow-custom-css" usage:"Allow accounts to enable custom CSS for their profile pages and statuses."`
-	AccountsCustomCSSLength  int  `name:"accounts-custom-css-length" usage:"Maximum permitted length (characters) of custom CSS for accounts."`
+	AccountsRegistrationOpen    bool          `name:"accounts-registration-open" usage:"Allow anyone to submit an account signup request. If false, server will be invite-only."`
+	AccountsApprovalRequired    bool          `name:"accounts-approval-required" usage:"Do account signups require approval by an admin or moderator before user can log in? If false, new registrations will be automatically approved."`
+	AccountsReasonRequired      bool          `name:"accounts-reason-required" usage:"Do new account signups require a reason to be submitted on registration?"`
+	AccountsAllowCustomCSS      bool          `name:"accounts-allow-custom-css" usage:"Allow accounts to enable custom CSS for their profile pages and statuses."`
+	AccountsCustomCSSLength     int           `name:"accounts-custom-css-length" usage:"Maximum permitted length (characters) of custom CSS for accounts."`
+	AccountsExportExpiry        time.Duration `name:"accounts-export-expiry" usage:"Duration for which archives of account data, exported at the request of users, are kept available to download."`
+	AccountsImportMaxSize       bytesize.Size `name:"accounts-import-max-size" usage:"Max size in bytes of archives of posts which users can upload to import into their account."`
+	AccountsImportMaxOutboxSize bytesize.Size `name:"accounts-import-max-outbox-size" usage:"Max size in bytes of the outbox.json file of an archive of posts being imported, which is read into memory to import it."`
 
 	MediaImageMaxSize        bytesize.Size `name:"media-image-max-size" usage:"Max size of accepted images in bytes"`
 	MediaVideoMaxSize        bytesize.Size `name:"media-video-max-size" usage:"Max size of accepted videos in bytes"`
@@ -94,6 +97,8 @@ type Configuration struct {
 	MediaRemoteCacheDays     int           `name:"media-remote-cache-days" usage:"Number of days to locally cache media from remote instances. If set to 0, remote media will be kept indefinitely."`
 	MediaEmojiLocalMaxSize   bytesize.Size `name:"media-emoji-local-max-size" usage:"Max size in bytes of emojis uploaded to this instance via the admin API."`
 	MediaEmojiRemoteMaxSize  bytesize.Size `name:"media-emoji-remote-max-size" usage:"Max size in bytes of emojis to download from other instances."`
+	MediaAccountQuota        bytesize.Size `name:"media-account-quota" usage:"Max total size in bytes of media stored for each local account. 0 means no limit. Can be overridden for individual accounts by admins."`
+	MediaFFmpegPath          string        `name:"media-ffmpeg-path" usage:"Path to (or name in $PATH of) an ffmpeg binary, used to decode video frames for thumbnails. Empty string disables ffmpeg."`
 
 	StorageBackend       string `name:"storage-backend" usage:"Storage backend to use for media attachments"`
 	StorageLocalBasePath string `name:"storage-local-base-path" usage:"Full path to an already-created directory where gts should store/retrieve media files. Subfolders will be created within this dir."`
@@ -128,11 +133,21 @@ type Configuration struct {
 	OIDCLinkExisting     bool     `name:"oidc-link-existing" usage:"link existing user accounts to OIDC logins based on the stored email value"`
 	OIDCAdminGroups      []string `name:"oidc-admin-groups" usage:"Membership of one of the listed groups makes someone a GtS admin"`
 
+	OAuthAccessTokenExpiry     time.Duration `name:"oauth-access-token-expiry" usage:"Duration after which OAuth access tokens expire and must be refreshed using a refresh token. 0 means access tokens never expire, and no refresh tokens are issued."`
+	OAuthRefreshTokenExpiry    time.Duration `name:"oauth-refresh-token-expiry" usage:"Duration after which unused OAuth refresh tokens expire. 0 means refresh tokens never expire."`
+	OAuthPersonalTokensEnabled bool          `name:"oauth-personal-tokens-enabled" usage:"Allow users to create personal access tokens for their own scripts and bots."`
+	OAuthPersonalTokensScopes  []string      `name:"oauth-personal-tokens-scopes" usage:"Scopes which users may grant to their personal access tokens."`
+
 	TracingEnabled           bool   `name:"tracing-enabled" usage:"Enable OTLP Tracing"`
 	TracingTransport         string `name:"tracing-transport" usage:"grpc or jaeger"`
 	TracingEndpoint          string `name:"tracing-endpoint" usage:"Endpoint of your trace collector. Eg., 'localhost:4317' for gRPC, 'http://localhost:14268/api/traces' for jaeger"`
 	TracingInsecureTransport bool   `name:"tracing-insecure" usage:"Disable HTTPS for the gRPC transport protocol"`
 
+	MetricsEnabled   bool   `name:"metrics-enabled" usage:"Enable Prometheus metrics at /metrics"`
+	MetricsAuthToken string `name:"metrics-auth-token" usage:"If set, requests to /metrics must give this token as an 'Authorization: Bearer' header"`
+
+	HealthAuthToken string `name:"health-auth-token" usage:"If set, detailed /livez and /readyz responses are only shown to requests giving this token as an 'Authorization: Bearer' header"`
+
 	SMTPHost               string `name:"smtp-host" usage:"Host of the smtp server. Eg., 'smtp.eu.mailgun.org'"`
 	SMTPPort               int    `name:"smtp-port" usage:"Port of the smtp server. Eg., 587"`
 	SMTPUsername           string `name:"smtp-username" usage:"Username to authenticate with the smtp server as. Eg., 'postmaster@mail.example.org'"`
@@ -144,21 +159,34 @@ type Configuration struct {
 	SyslogProtocol string `name:"syslog-protocol" usage:"Protocol to use when directing logs to syslog. Leave empty to connect to local syslog."`
 	SyslogAddress  string `name:"syslog-address" usage:"Address:port to send syslog logs to. Leave empty to connect to local syslog."`
 
-	AdvancedCookiesSamesite      string        `name:"advanced-cookies-samesite" usage:"'strict' or 'lax', see https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Set-Cookie/SameSite"`
-	AdvancedRateLimitRequests    int           `name:"advanced-rate-limit-requests" usage:"Amount of HTTP requests to permit within a 5 minute window. 0 or less turns rate limiting off."`
-	AdvancedThrottlingMultiplier int           `name:"advanced-throttling-multiplier" usage:"Multiplier to use per cpu for http request throttling. 0 or less turns throttling off."`
-	AdvancedThrottlingRetryAfter time.Duration `name:"advanced-throttling-retry-after" usage:"Retry-After duration response to send for throttled requests."`
-	AdvancedSenderMultiplier     int           `name:"advanced-sender-multiplier" usage:"Multiplier to use per cpu for batching outgoing fedi messages. 0 or less turns batching off (not recommended)."`
+	AdvancedCookiesSamesite              string        `name:"advanced-cookies-samesite" usage:"'strict' or 'lax', see https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Set-Cookie/SameSite"`
+	AdvancedRateLimitRequests            int           `name:"advanced-rate-limit-requests" usage:"Amount of HTTP requests to permit within a 5 minute window. 0 or less turns rate limiting off."`
+	AdvancedRateLimitExceptions          []string      `name:"advanced-rate-limit-exceptions" usage:"Slice of CIDRs to exclude from rate limiting, eg., 10.0.0.0/8"`
+	AdvancedRateLimitSignInRequests      int           `name:"advanced-rate-limit-sign-in-requests" usage:"Amount of sign-in, sign-up and oauth token requests to permit within a 5 minute window. 0 or less turns rate limiting off for these requests."`
+	AdvancedRateLimitMediaUploadRequests int           `name:"advanced-rate-limit-media-upload-requests" usage:"Amount of media upload requests to permit within a 5 minute window. 0 or less turns rate limiting off for these requests."`
+	AdvancedRateLimitStatusPostRequests  int           `name:"advanced-rate-limit-status-post-requests" usage:"Amount of status posting requests to permit within a 5 minute window. 0 or less turns rate limiting off for these requests."`
+	AdvancedRateLimitTimelineRequests    int           `name:"advanced-rate-limit-timeline-requests" usage:"Amount of timeline read requests to permit within a 5 minute window. 0 or less turns rate limiting off for these requests."`
+	AdvancedRateLimitInboxRequests       int           `name:"advanced-rate-limit-inbox-requests" usage:"Amount of federation inbox requests to permit within a 5 minute window, per IP, or per sender domain once verified. 0 or less turns rate limiting off for these requests."`
+	AdvancedRateLimitFileserverRequests  int           `name:"advanced-rate-limit-fileserver-requests" usage:"Amount of fileserver (media file) requests to permit within a 5 minute window. 0 or less turns rate limiting off for these requests."`
+	AdvancedThrottlingMultiplier         int           `name:"advanced-throttling-multiplier" usage:"Multiplier to use per cpu for http request throttling. 0 or less turns throttling off."`
+	AdvancedThrottlingRetryAfter         time.Duration `name:"advanced-throttling-retry-after" usage:"Retry-After duration response to send for throttled requests."`
+	AdvancedSenderMultiplier             int           `name:"advanced-sender-multiplier" usage:"Multiplier to use per cpu for batching outgoing fedi messages. 0 or less turns batching off (not recommended)."`
 
 	// Cache configuration vars.
 	Cache CacheConfiguration `name:"cache"`
 
 	// TODO: move these elsewhere, these are more ephemeral vs long-running flags like above
-	AdminAccountUsername  string `name:"username" usage:"the username to create/delete/etc"`
-	AdminAccountEmail     string `name:"email" usage:"the email address of this account"`
-	AdminAccountPassword  string `name:"password" usage:"the password to set for this account"`
-	AdminTransPath        string `name:"path" usage:"the path of the file to import from/export to"`
-	AdminMediaPruneDryRun bool   `name:"dry-run" usage:"perform a dry run and only log number of items eligible for pruning"`
+	AdminAccountUsername  string        `name:"username" usage:"the username to create/delete/etc"`
+	AdminAccountEmail     string        `name:"email" usage:"the email address of this account"`
+	AdminAccountPassword  string        `name:"password" usage:"the password to set for this account"`
+	AdminAccountQuota     string        `name:"quota" usage:"the media storage quota to set for this account, eg. 500MiB, 0 for no limit, or 'default' to use the instance default"`
+	AdminTransPath        string        `name:"path" usage:"the path of the file to import from/export to"`
+	AdminTransMedia       bool          `name:"media" usage:"bundle stored media files into the export file"`
+	AdminMediaPruneDryRun bool          `name:"dry-run" usage:"perform a dry run and only log number of items eligible for pruning"`
+	AdminIPBlockIP        string        `name:"ip" usage:"the IP address or CIDR range to block/unblock"`
+	AdminIPBlockSeverity  string        `name:"severity" usage:"severity of the IP block: sign_up_requires_approval, sign_up_block, or no_access"`
+	AdminIPBlockComment   string        `name:"comment" usage:"private comment on why the IP range is blocked"`
+	AdminIPBlockExpiresIn time.Duration `name:"expires-in" usage:"how long until the IP block expires, eg. 72h, or 0 for never"`
 
 	RequestIDHeader string `name:"request-id-header" usage:"Header to extract the Request ID from. Eg.,'X-Request-Id'."`
 }
@@ -240,6 +268,10 @@ type GTSCacheConfiguration struct {
 	UserTTL       time.Duration `name:"user-ttl"`
 	UserSweepFreq time.Duration `name:"user-sweep-freq"`
 
+	UserMuteMaxSize   int           `name:"user-mute-max-size"`
+	UserMuteTTL       time.Duration `name:"user-mute-ttl"`
+	UserMuteSweepFreq time.Duration `name:"user-mute-sweep-freq"`
+
 	WebfingerMaxSize   int           `name:"webfinger-max-size"`
 	WebfingerTTL       time.Duration `name:"webfinger-ttl"`
 	WebfingerSweepFreq time.Duration `name:"webfinger-sweep-freq"`
@@ -61,11 +61,14 @@ var Defaults = Configuration{
 	InstanceExposeSuspendedWeb:     false,
 	InstanceDeliverToSharedInboxes: true,
 
-	AccountsRegistrationOpen: true,
-	AccountsApprovalRequired: true,
-	AccountsReasonRequired:   true,
-	AccountsAllowCustomCSS:   false,
-	AccountsCustomCSSLength:  10000,
+	AccountsRegistrationOpen:    true,
+	AccountsApprovalRequired:    true,
+	AccountsReasonRequired:      true,
+	AccountsAllowCustomCSS:      false,
+	AccountsCustomCSSLength:     10000,
+	AccountsExportExpiry:        7 * 24 * time.Hour,
+	AccountsImportMaxSize:       bytesize.GiB,
+	AccountsImportMaxOutboxSize: 100 * bytesize.MiB,
 
 	MediaImageMaxSize:        10 * bytesize.MiB,
 	MediaVideoMaxSize:        40 * bytesize.MiB,
@@ -74,6 +77,8 @@ var Defaults = Configuration{
 	MediaRemoteCacheDays:     30,
 	MediaEmojiLocalMaxSize:   50 * bytesize.KiB,
 	MediaEmojiRemoteMaxSize:  100 * bytesize.KiB,
+	MediaAccountQuota:        0,
+	MediaFFmpegPath:          "ffmpeg",
 
 	StorageBackend:       "local",
 	StorageLocalBasePath: "/gotosocial/storage",
@@ -103,6 +108,11 @@ var Defaults = Configuration{
 	OIDCScopes:           []string{oidc.ScopeOpenID, "profile", "email", "groups"},
 	OIDCLinkExisting:     false,
 
+	OAuthAccessTokenExpiry:     0,
+	OAuthRefreshTokenExpiry:    720 * time.Hour, // 30 days
+	OAuthPersonalTokensEnabled: true,
+	OAuthPersonalTokensScopes:  []string{"read", "write", "follow", "push", "admin:read", "admin:write"},
+
 	SMTPHost:               "",
 	SMTPPort:               0,
 	SMTPUsername:           "",
@@ -115,14 +125,26 @@ var Defaults = Configuration{
 	TracingEndpoint:          "",
 	TracingInsecureTransport: false,
 
+	MetricsEnabled:   false,
+	MetricsAuthToken: "",
+
+	HealthAuthToken: "",
+
 	SyslogEnabled:  false,
 	SyslogProtocol: "udp",
 	SyslogAddress:  "localhost:514",
 
-	AdvancedCookiesSamesite:      "lax",
-	AdvancedRateLimitRequests:    300, // 1 per second per 5 minutes
-	AdvancedThrottlingMultiplier: 8,   // 8 open requests per CPU
-	AdvancedSenderMultiplier:     2,   // 2 senders per CPU
+	AdvancedCookiesSamesite:              "lax",
+	AdvancedRateLimitRequests:            300, // 1 per second per 5 minutes
+	AdvancedRateLimitExceptions:          []string{},
+	AdvancedRateLimitSignInRequests:      30,
+	AdvancedRateLimitMediaUploadRequests: 60,
+	AdvancedRateLimitStatusPostRequests:  300,
+	AdvancedRateLimitTimelineRequests:    300,
+	AdvancedRateLimitInboxRequests:       1000,
+	AdvancedRateLimitFileserverRequests:  1000,
+	AdvancedThrottlingMultiplier:         8, // 8 open requests per CPU
+	AdvancedSenderMultiplier:             2, // 2 senders per CPU
 
 	Cache: CacheConfiguration{
 		GTS: GTSCacheConfiguration{
@@ -194,6 +216,10 @@ var Defaults = Configuration{
 			UserTTL:       time.Minute * 30,
 			UserSweepFreq: time.Minute,
 
+			UserMuteMaxSize:   1000,
+			UserMuteTTL:       time.Minute * 30,
+			UserMuteSweepFreq: time.Minute,
+
 			WebfingerMaxSize:   250,
 			WebfingerTTL:       time.Hour * 24,
 			WebfingerSweepFreq: time.Minute * 15,
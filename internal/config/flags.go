@@ -86,6 +86,9 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().Bool(AccountsApprovalRequiredFlag(), cfg.AccountsApprovalRequired, fieldtag("AccountsApprovalRequired", "usage"))
 		cmd.Flags().Bool(AccountsReasonRequiredFlag(), cfg.AccountsReasonRequired, fieldtag("AccountsReasonRequired", "usage"))
 		cmd.Flags().Bool(AccountsAllowCustomCSSFlag(), cfg.AccountsAllowCustomCSS, fieldtag("AccountsAllowCustomCSS", "usage"))
+		cmd.Flags().Duration(AccountsExportExpiryFlag(), cfg.AccountsExportExpiry, fieldtag("AccountsExportExpiry", "usage"))
+		cmd.Flags().Uint64(AccountsImportMaxSizeFlag(), uint64(cfg.AccountsImportMaxSize), fieldtag("AccountsImportMaxSize", "usage"))
+		cmd.Flags().Uint64(AccountsImportMaxOutboxSizeFlag(), uint64(cfg.AccountsImportMaxOutboxSize), fieldtag("AccountsImportMaxOutboxSize", "usage"))
 
 		// Media
 		cmd.Flags().Uint64(MediaImageMaxSizeFlag(), uint64(cfg.MediaImageMaxSize), fieldtag("MediaImageMaxSize", "usage"))
@@ -95,6 +98,8 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().Int(MediaRemoteCacheDaysFlag(), cfg.MediaRemoteCacheDays, fieldtag("MediaRemoteCacheDays", "usage"))
 		cmd.Flags().Uint64(MediaEmojiLocalMaxSizeFlag(), uint64(cfg.MediaEmojiLocalMaxSize), fieldtag("MediaEmojiLocalMaxSize", "usage"))
 		cmd.Flags().Uint64(MediaEmojiRemoteMaxSizeFlag(), uint64(cfg.MediaEmojiRemoteMaxSize), fieldtag("MediaEmojiRemoteMaxSize", "usage"))
+		cmd.Flags().Uint64(MediaAccountQuotaFlag(), uint64(cfg.MediaAccountQuota), fieldtag("MediaAccountQuota", "usage"))
+		cmd.Flags().String(MediaFFmpegPathFlag(), cfg.MediaFFmpegPath, fieldtag("MediaFFmpegPath", "usage"))
 
 		// Storage
 		cmd.Flags().String(StorageBackendFlag(), cfg.StorageBackend, fieldtag("StorageBackend", "usage"))
@@ -126,6 +131,12 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().String(OIDCClientSecretFlag(), cfg.OIDCClientSecret, fieldtag("OIDCClientSecret", "usage"))
 		cmd.Flags().StringSlice(OIDCScopesFlag(), cfg.OIDCScopes, fieldtag("OIDCScopes", "usage"))
 
+		// OAuth
+		cmd.Flags().Duration(OAuthAccessTokenExpiryFlag(), cfg.OAuthAccessTokenExpiry, fieldtag("OAuthAccessTokenExpiry", "usage"))
+		cmd.Flags().Duration(OAuthRefreshTokenExpiryFlag(), cfg.OAuthRefreshTokenExpiry, fieldtag("OAuthRefreshTokenExpiry", "usage"))
+		cmd.Flags().Bool(OAuthPersonalTokensEnabledFlag(), cfg.OAuthPersonalTokensEnabled, fieldtag("OAuthPersonalTokensEnabled", "usage"))
+		cmd.Flags().StringSlice(OAuthPersonalTokensScopesFlag(), cfg.OAuthPersonalTokensScopes, fieldtag("OAuthPersonalTokensScopes", "usage"))
+
 		// SMTP
 		cmd.Flags().String(SMTPHostFlag(), cfg.SMTPHost, fieldtag("SMTPHost", "usage"))
 		cmd.Flags().Int(SMTPPortFlag(), cfg.SMTPPort, fieldtag("SMTPPort", "usage"))
@@ -142,6 +153,13 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		// Advanced flags
 		cmd.Flags().String(AdvancedCookiesSamesiteFlag(), cfg.AdvancedCookiesSamesite, fieldtag("AdvancedCookiesSamesite", "usage"))
 		cmd.Flags().Int(AdvancedRateLimitRequestsFlag(), cfg.AdvancedRateLimitRequests, fieldtag("AdvancedRateLimitRequests", "usage"))
+		cmd.Flags().StringSlice(AdvancedRateLimitExceptionsFlag(), cfg.AdvancedRateLimitExceptions, fieldtag("AdvancedRateLimitExceptions", "usage"))
+		cmd.Flags().Int(AdvancedRateLimitSignInRequestsFlag(), cfg.AdvancedRateLimitSignInRequests, fieldtag("AdvancedRateLimitSignInRequests", "usage"))
+		cmd.Flags().Int(AdvancedRateLimitMediaUploadRequestsFlag(), cfg.AdvancedRateLimitMediaUploadRequests, fieldtag("AdvancedRateLimitMediaUploadRequests", "usage"))
+		cmd.Flags().Int(AdvancedRateLimitStatusPostRequestsFlag(), cfg.AdvancedRateLimitStatusPostRequests, fieldtag("AdvancedRateLimitStatusPostRequests", "usage"))
+		cmd.Flags().Int(AdvancedRateLimitTimelineRequestsFlag(), cfg.AdvancedRateLimitTimelineRequests, fieldtag("AdvancedRateLimitTimelineRequests", "usage"))
+		cmd.Flags().Int(AdvancedRateLimitInboxRequestsFlag(), cfg.AdvancedRateLimitInboxRequests, fieldtag("AdvancedRateLimitInboxRequests", "usage"))
+		cmd.Flags().Int(AdvancedRateLimitFileserverRequestsFlag(), cfg.AdvancedRateLimitFileserverRequests, fieldtag("AdvancedRateLimitFileserverRequests", "usage"))
 		cmd.Flags().Int(AdvancedThrottlingMultiplierFlag(), cfg.AdvancedThrottlingMultiplier, fieldtag("AdvancedThrottlingMultiplier", "usage"))
 		cmd.Flags().Duration(AdvancedThrottlingRetryAfterFlag(), cfg.AdvancedThrottlingRetryAfter, fieldtag("AdvancedThrottlingRetryAfter", "usage"))
 		cmd.Flags().Int(AdvancedSenderMultiplierFlag(), cfg.AdvancedSenderMultiplier, fieldtag("AdvancedSenderMultiplier", "usage"))
@@ -184,6 +202,16 @@ func AddAdminAccountCreate(cmd *cobra.Command) {
 	}
 }
 
+// AddAdminAccountQuota attaches flags pertaining to admin account media quota changes.
+func AddAdminAccountQuota(cmd *cobra.Command) {
+	name := AdminAccountQuotaFlag()
+	usage := fieldtag("AdminAccountQuota", "usage")
+	cmd.Flags().String(name, "", usage) // REQUIRED
+	if err := cmd.MarkFlagRequired(name); err != nil {
+		panic(err)
+	}
+}
+
 // AddAdminTrans attaches flags pertaining to import/export commands.
 func AddAdminTrans(cmd *cobra.Command) {
 	name := AdminTransPathFlag()
@@ -194,9 +222,42 @@ func AddAdminTrans(cmd *cobra.Command) {
 	}
 }
 
+// AddAdminTransMedia attaches flags pertaining to bundling media in exports.
+func AddAdminTransMedia(cmd *cobra.Command) {
+	name := AdminTransMediaFlag()
+	usage := fieldtag("AdminTransMedia", "usage")
+	cmd.Flags().Bool(name, false, usage)
+}
+
 // AddAdminMediaPrune attaches flags pertaining to media storage prune commands.
 func AddAdminMediaPrune(cmd *cobra.Command) {
 	name := AdminMediaPruneDryRunFlag()
 	usage := fieldtag("AdminMediaPruneDryRun", "usage")
 	cmd.Flags().Bool(name, true, usage)
 }
+
+// AddAdminIPBlock attaches flags pertaining to admin IP block removal.
+func AddAdminIPBlock(cmd *cobra.Command) {
+	name := AdminIPBlockIPFlag()
+	usage := fieldtag("AdminIPBlockIP", "usage")
+	cmd.Flags().String(name, "", usage) // REQUIRED
+	if err := cmd.MarkFlagRequired(name); err != nil {
+		panic(err)
+	}
+}
+
+// AddAdminIPBlockCreate attaches flags pertaining to admin IP block creation.
+func AddAdminIPBlockCreate(cmd *cobra.Command) {
+	// Requires the ip range
+	AddAdminIPBlock(cmd)
+
+	name := AdminIPBlockSeverityFlag()
+	usage := fieldtag("AdminIPBlockSeverity", "usage")
+	cmd.Flags().String(name, "", usage) // REQUIRED
+	if err := cmd.MarkFlagRequired(name); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().String(AdminIPBlockCommentFlag(), "", fieldtag("AdminIPBlockComment", "usage"))
+	cmd.Flags().Duration(AdminIPBlockExpiresInFlag(), 0, fieldtag("AdminIPBlockExpiresIn", "usage"))
+}
@@ -974,6 +974,81 @@ func GetAccountsCustomCSSLength() int { return global.GetAccountsCustomCSSLength
 // SetAccountsCustomCSSLength safely sets the value for global configuration 'AccountsCustomCSSLength' field
 func SetAccountsCustomCSSLength(v int) { global.SetAccountsCustomCSSLength(v) }
 
+// GetAccountsExportExpiry safely fetches the Configuration value for state's 'AccountsExportExpiry' field
+func (st *ConfigState) GetAccountsExportExpiry() (v time.Duration) {
+	st.mutex.Lock()
+	v = st.config.AccountsExportExpiry
+	st.mutex.Unlock()
+	return
+}
+
+// SetAccountsExportExpiry safely sets the Configuration value for state's 'AccountsExportExpiry' field
+func (st *ConfigState) SetAccountsExportExpiry(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsExportExpiry = v
+	st.reloadToViper()
+}
+
+// AccountsExportExpiryFlag returns the flag name for the 'AccountsExportExpiry' field
+func AccountsExportExpiryFlag() string { return "accounts-export-expiry" }
+
+// GetAccountsExportExpiry safely fetches the value for global configuration 'AccountsExportExpiry' field
+func GetAccountsExportExpiry() time.Duration { return global.GetAccountsExportExpiry() }
+
+// SetAccountsExportExpiry safely sets the value for global configuration 'AccountsExportExpiry' field
+func SetAccountsExportExpiry(v time.Duration) { global.SetAccountsExportExpiry(v) }
+
+// GetAccountsImportMaxSize safely fetches the Configuration value for state's 'AccountsImportMaxSize' field
+func (st *ConfigState) GetAccountsImportMaxSize() (v bytesize.Size) {
+	st.mutex.Lock()
+	v = st.config.AccountsImportMaxSize
+	st.mutex.Unlock()
+	return
+}
+
+// SetAccountsImportMaxSize safely sets the Configuration value for state's 'AccountsImportMaxSize' field
+func (st *ConfigState) SetAccountsImportMaxSize(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsImportMaxSize = v
+	st.reloadToViper()
+}
+
+// AccountsImportMaxSizeFlag returns the flag name for the 'AccountsImportMaxSize' field
+func AccountsImportMaxSizeFlag() string { return "accounts-import-max-size" }
+
+// GetAccountsImportMaxSize safely fetches the value for global configuration 'AccountsImportMaxSize' field
+func GetAccountsImportMaxSize() bytesize.Size { return global.GetAccountsImportMaxSize() }
+
+// SetAccountsImportMaxSize safely sets the value for global configuration 'AccountsImportMaxSize' field
+func SetAccountsImportMaxSize(v bytesize.Size) { global.SetAccountsImportMaxSize(v) }
+
+// GetAccountsImportMaxOutboxSize safely fetches the Configuration value for state's 'AccountsImportMaxOutboxSize' field
+func (st *ConfigState) GetAccountsImportMaxOutboxSize() (v bytesize.Size) {
+	st.mutex.Lock()
+	v = st.config.AccountsImportMaxOutboxSize
+	st.mutex.Unlock()
+	return
+}
+
+// SetAccountsImportMaxOutboxSize safely sets the Configuration value for state's 'AccountsImportMaxOutboxSize' field
+func (st *ConfigState) SetAccountsImportMaxOutboxSize(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsImportMaxOutboxSize = v
+	st.reloadToViper()
+}
+
+// AccountsImportMaxOutboxSizeFlag returns the flag name for the 'AccountsImportMaxOutboxSize' field
+func AccountsImportMaxOutboxSizeFlag() string { return "accounts-import-max-outbox-size" }
+
+// GetAccountsImportMaxOutboxSize safely fetches the value for global configuration 'AccountsImportMaxOutboxSize' field
+func GetAccountsImportMaxOutboxSize() bytesize.Size { return global.GetAccountsImportMaxOutboxSize() }
+
+// SetAccountsImportMaxOutboxSize safely sets the value for global configuration 'AccountsImportMaxOutboxSize' field
+func SetAccountsImportMaxOutboxSize(v bytesize.Size) { global.SetAccountsImportMaxOutboxSize(v) }
+
 // GetMediaImageMaxSize safely fetches the Configuration value for state's 'MediaImageMaxSize' field
 func (st *ConfigState) GetMediaImageMaxSize() (v bytesize.Size) {
 	st.mutex.Lock()
@@ -1149,6 +1224,56 @@ func GetMediaEmojiRemoteMaxSize() bytesize.Size { return global.GetMediaEmojiRem
 // SetMediaEmojiRemoteMaxSize safely sets the value for global configuration 'MediaEmojiRemoteMaxSize' field
 func SetMediaEmojiRemoteMaxSize(v bytesize.Size) { global.SetMediaEmojiRemoteMaxSize(v) }
 
+// GetMediaAccountQuota safely fetches the Configuration value for state's 'MediaAccountQuota' field
+func (st *ConfigState) GetMediaAccountQuota() (v bytesize.Size) {
+	st.mutex.Lock()
+	v = st.config.MediaAccountQuota
+	st.mutex.Unlock()
+	return
+}
+
+// SetMediaAccountQuota safely sets the Configuration value for state's 'MediaAccountQuota' field
+func (st *ConfigState) SetMediaAccountQuota(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaAccountQuota = v
+	st.reloadToViper()
+}
+
+// MediaAccountQuotaFlag returns the flag name for the 'MediaAccountQuota' field
+func MediaAccountQuotaFlag() string { return "media-account-quota" }
+
+// GetMediaAccountQuota safely fetches the value for global configuration 'MediaAccountQuota' field
+func GetMediaAccountQuota() bytesize.Size { return global.GetMediaAccountQuota() }
+
+// SetMediaAccountQuota safely sets the value for global configuration 'MediaAccountQuota' field
+func SetMediaAccountQuota(v bytesize.Size) { global.SetMediaAccountQuota(v) }
+
+// GetMediaFFmpegPath safely fetches the Configuration value for state's 'MediaFFmpegPath' field
+func (st *ConfigState) GetMediaFFmpegPath() (v string) {
+	st.mutex.Lock()
+	v = st.config.MediaFFmpegPath
+	st.mutex.Unlock()
+	return
+}
+
+// SetMediaFFmpegPath safely sets the Configuration value for state's 'MediaFFmpegPath' field
+func (st *ConfigState) SetMediaFFmpegPath(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaFFmpegPath = v
+	st.reloadToViper()
+}
+
+// MediaFFmpegPathFlag returns the flag name for the 'MediaFFmpegPath' field
+func MediaFFmpegPathFlag() string { return "media-ffmpeg-path" }
+
+// GetMediaFFmpegPath safely fetches the value for global configuration 'MediaFFmpegPath' field
+func GetMediaFFmpegPath() string { return global.GetMediaFFmpegPath() }
+
+// SetMediaFFmpegPath safely sets the value for global configuration 'MediaFFmpegPath' field
+func SetMediaFFmpegPath(v string) { global.SetMediaFFmpegPath(v) }
+
 // GetStorageBackend safely fetches the Configuration value for state's 'StorageBackend' field
 func (st *ConfigState) GetStorageBackend() (v string) {
 	st.mutex.Lock()
@@ -1849,6 +1974,106 @@ func GetOIDCAdminGroups() []string { return global.GetOIDCAdminGroups() }
 // SetOIDCAdminGroups safely sets the value for global configuration 'OIDCAdminGroups' field
 func SetOIDCAdminGroups(v []string) { global.SetOIDCAdminGroups(v) }
 
+// GetOAuthAccessTokenExpiry safely fetches the Configuration value for state's 'OAuthAccessTokenExpiry' field
+func (st *ConfigState) GetOAuthAccessTokenExpiry() (v time.Duration) {
+	st.mutex.Lock()
+	v = st.config.OAuthAccessTokenExpiry
+	st.mutex.Unlock()
+	return
+}
+
+// SetOAuthAccessTokenExpiry safely sets the Configuration value for state's 'OAuthAccessTokenExpiry' field
+func (st *ConfigState) SetOAuthAccessTokenExpiry(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.OAuthAccessTokenExpiry = v
+	st.reloadToViper()
+}
+
+// OAuthAccessTokenExpiryFlag returns the flag name for the 'OAuthAccessTokenExpiry' field
+func OAuthAccessTokenExpiryFlag() string { return "oauth-access-token-expiry" }
+
+// GetOAuthAccessTokenExpiry safely fetches the value for global configuration 'OAuthAccessTokenExpiry' field
+func GetOAuthAccessTokenExpiry() time.Duration { return global.GetOAuthAccessTokenExpiry() }
+
+// SetOAuthAccessTokenExpiry safely sets the value for global configuration 'OAuthAccessTokenExpiry' field
+func SetOAuthAccessTokenExpiry(v time.Duration) { global.SetOAuthAccessTokenExpiry(v) }
+
+// GetOAuthRefreshTokenExpiry safely fetches the Configuration value for state's 'OAuthRefreshTokenExpiry' field
+func (st *ConfigState) GetOAuthRefreshTokenExpiry() (v time.Duration) {
+	st.mutex.Lock()
+	v = st.config.OAuthRefreshTokenExpiry
+	st.mutex.Unlock()
+	return
+}
+
+// SetOAuthRefreshTokenExpiry safely sets the Configuration value for state's 'OAuthRefreshTokenExpiry' field
+func (st *ConfigState) SetOAuthRefreshTokenExpiry(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.OAuthRefreshTokenExpiry = v
+	st.reloadToViper()
+}
+
+// OAuthRefreshTokenExpiryFlag returns the flag name for the 'OAuthRefreshTokenExpiry' field
+func OAuthRefreshTokenExpiryFlag() string { return "oauth-refresh-token-expiry" }
+
+// GetOAuthRefreshTokenExpiry safely fetches the value for global configuration 'OAuthRefreshTokenExpiry' field
+func GetOAuthRefreshTokenExpiry() time.Duration { return global.GetOAuthRefreshTokenExpiry() }
+
+// SetOAuthRefreshTokenExpiry safely sets the value for global configuration 'OAuthRefreshTokenExpiry' field
+func SetOAuthRefreshTokenExpiry(v time.Duration) { global.SetOAuthRefreshTokenExpiry(v) }
+
+// GetOAuthPersonalTokensEnabled safely fetches the Configuration value for state's 'OAuthPersonalTokensEnabled' field
+func (st *ConfigState) GetOAuthPersonalTokensEnabled() (v bool) {
+	st.mutex.Lock()
+	v = st.config.OAuthPersonalTokensEnabled
+	st.mutex.Unlock()
+	return
+}
+
+// SetOAuthPersonalTokensEnabled safely sets the Configuration value for state's 'OAuthPersonalTokensEnabled' field
+func (st *ConfigState) SetOAuthPersonalTokensEnabled(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.OAuthPersonalTokensEnabled = v
+	st.reloadToViper()
+}
+
+// OAuthPersonalTokensEnabledFlag returns the flag name for the 'OAuthPersonalTokensEnabled' field
+func OAuthPersonalTokensEnabledFlag() string { return "oauth-personal-tokens-enabled" }
+
+// GetOAuthPersonalTokensEnabled safely fetches the value for global configuration 'OAuthPersonalTokensEnabled' field
+func GetOAuthPersonalTokensEnabled() bool { return global.GetOAuthPersonalTokensEnabled() }
+
+// SetOAuthPersonalTokensEnabled safely sets the value for global configuration 'OAuthPersonalTokensEnabled' field
+func SetOAuthPersonalTokensEnabled(v bool) { global.SetOAuthPersonalTokensEnabled(v) }
+
+// GetOAuthPersonalTokensScopes safely fetches the Configuration value for state's 'OAuthPersonalTokensScopes' field
+func (st *ConfigState) GetOAuthPersonalTokensScopes() (v []string) {
+	st.mutex.Lock()
+	v = st.config.OAuthPersonalTokensScopes
+	st.mutex.Unlock()
+	return
+}
+
+// SetOAuthPersonalTokensScopes safely sets the Configuration value for state's 'OAuthPersonalTokensScopes' field
+func (st *ConfigState) SetOAuthPersonalTokensScopes(v []string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.OAuthPersonalTokensScopes = v
+	st.reloadToViper()
+}
+
+// OAuthPersonalTokensScopesFlag returns the flag name for the 'OAuthPersonalTokensScopes' field
+func OAuthPersonalTokensScopesFlag() string { return "oauth-personal-tokens-scopes" }
+
+// GetOAuthPersonalTokensScopes safely fetches the value for global configuration 'OAuthPersonalTokensScopes' field
+func GetOAuthPersonalTokensScopes() []string { return global.GetOAuthPersonalTokensScopes() }
+
+// SetOAuthPersonalTokensScopes safely sets the value for global configuration 'OAuthPersonalTokensScopes' field
+func SetOAuthPersonalTokensScopes(v []string) { global.SetOAuthPersonalTokensScopes(v) }
+
 // GetTracingEnabled safely fetches the Configuration value for state's 'TracingEnabled' field
 func (st *ConfigState) GetTracingEnabled() (v bool) {
 	st.mutex.Lock()
@@ -1949,6 +2174,81 @@ func GetTracingInsecureTransport() bool { return global.GetTracingInsecureTransp
 // SetTracingInsecureTransport safely sets the value for global configuration 'TracingInsecureTransport' field
 func SetTracingInsecureTransport(v bool) { global.SetTracingInsecureTransport(v) }
 
+// GetMetricsEnabled safely fetches the Configuration value for state's 'MetricsEnabled' field
+func (st *ConfigState) GetMetricsEnabled() (v bool) {
+	st.mutex.Lock()
+	v = st.config.MetricsEnabled
+	st.mutex.Unlock()
+	return
+}
+
+// SetMetricsEnabled safely sets the Configuration value for state's 'MetricsEnabled' field
+func (st *ConfigState) SetMetricsEnabled(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MetricsEnabled = v
+	st.reloadToViper()
+}
+
+// MetricsEnabledFlag returns the flag name for the 'MetricsEnabled' field
+func MetricsEnabledFlag() string { return "metrics-enabled" }
+
+// GetMetricsEnabled safely fetches the value for global configuration 'MetricsEnabled' field
+func GetMetricsEnabled() bool { return global.GetMetricsEnabled() }
+
+// SetMetricsEnabled safely sets the value for global configuration 'MetricsEnabled' field
+func SetMetricsEnabled(v bool) { global.SetMetricsEnabled(v) }
+
+// GetMetricsAuthToken safely fetches the Configuration value for state's 'MetricsAuthToken' field
+func (st *ConfigState) GetMetricsAuthToken() (v string) {
+	st.mutex.Lock()
+	v = st.config.MetricsAuthToken
+	st.mutex.Unlock()
+	return
+}
+
+// SetMetricsAuthToken safely sets the Configuration value for state's 'MetricsAuthToken' field
+func (st *ConfigState) SetMetricsAuthToken(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MetricsAuthToken = v
+	st.reloadToViper()
+}
+
+// MetricsAuthTokenFlag returns the flag name for the 'MetricsAuthToken' field
+func MetricsAuthTokenFlag() string { return "metrics-auth-token" }
+
+// GetMetricsAuthToken safely fetches the value for global configuration 'MetricsAuthToken' field
+func GetMetricsAuthToken() string { return global.GetMetricsAuthToken() }
+
+// SetMetricsAuthToken safely sets the value for global configuration 'MetricsAuthToken' field
+func SetMetricsAuthToken(v string) { global.SetMetricsAuthToken(v) }
+
+// GetHealthAuthToken safely fetches the Configuration value for state's 'HealthAuthToken' field
+func (st *ConfigState) GetHealthAuthToken() (v string) {
+	st.mutex.Lock()
+	v = st.config.HealthAuthToken
+	st.mutex.Unlock()
+	return
+}
+
+// SetHealthAuthToken safely sets the Configuration value for state's 'HealthAuthToken' field
+func (st *ConfigState) SetHealthAuthToken(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.HealthAuthToken = v
+	st.reloadToViper()
+}
+
+// HealthAuthTokenFlag returns the flag name for the 'HealthAuthToken' field
+func HealthAuthTokenFlag() string { return "health-auth-token" }
+
+// GetHealthAuthToken safely fetches the value for global configuration 'HealthAuthToken' field
+func GetHealthAuthToken() string { return global.GetHealthAuthToken() }
+
+// SetHealthAuthToken safely sets the value for global configuration 'HealthAuthToken' field
+func SetHealthAuthToken(v string) { global.SetHealthAuthToken(v) }
+
 // GetSMTPHost safely fetches the Configuration value for state's 'SMTPHost' field
 func (st *ConfigState) GetSMTPHost() (v string) {
 	st.mutex.Lock()
@@ -2224,6 +2524,195 @@ func GetAdvancedRateLimitRequests() int { return global.GetAdvancedRateLimitRequ
 // SetAdvancedRateLimitRequests safely sets the value for global configuration 'AdvancedRateLimitRequests' field
 func SetAdvancedRateLimitRequests(v int) { global.SetAdvancedRateLimitRequests(v) }
 
+// GetAdvancedRateLimitExceptions safely fetches the Configuration value for state's 'AdvancedRateLimitExceptions' field
+func (st *ConfigState) GetAdvancedRateLimitExceptions() (v []string) {
+	st.mutex.Lock()
+	v = st.config.AdvancedRateLimitExceptions
+	st.mutex.Unlock()
+	return
+}
+
+// SetAdvancedRateLimitExceptions safely sets the Configuration value for state's 'AdvancedRateLimitExceptions' field
+func (st *ConfigState) SetAdvancedRateLimitExceptions(v []string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedRateLimitExceptions = v
+	st.reloadToViper()
+}
+
+// AdvancedRateLimitExceptionsFlag returns the flag name for the 'AdvancedRateLimitExceptions' field
+func AdvancedRateLimitExceptionsFlag() string { return "advanced-rate-limit-exceptions" }
+
+// GetAdvancedRateLimitExceptions safely fetches the value for global configuration 'AdvancedRateLimitExceptions' field
+func GetAdvancedRateLimitExceptions() []string { return global.GetAdvancedRateLimitExceptions() }
+
+// SetAdvancedRateLimitExceptions safely sets the value for global configuration 'AdvancedRateLimitExceptions' field
+func SetAdvancedRateLimitExceptions(v []string) { global.SetAdvancedRateLimitExceptions(v) }
+
+// GetAdvancedRateLimitSignInRequests safely fetches the Configuration value for state's 'AdvancedRateLimitSignInRequests' field
+func (st *ConfigState) GetAdvancedRateLimitSignInRequests() (v int) {
+	st.mutex.Lock()
+	v = st.config.AdvancedRateLimitSignInRequests
+	st.mutex.Unlock()
+	return
+}
+
+// SetAdvancedRateLimitSignInRequests safely sets the Configuration value for state's 'AdvancedRateLimitSignInRequests' field
+func (st *ConfigState) SetAdvancedRateLimitSignInRequests(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedRateLimitSignInRequests = v
+	st.reloadToViper()
+}
+
+// AdvancedRateLimitSignInRequestsFlag returns the flag name for the 'AdvancedRateLimitSignInRequests' field
+func AdvancedRateLimitSignInRequestsFlag() string { return "advanced-rate-limit-sign-in-requests" }
+
+// GetAdvancedRateLimitSignInRequests safely fetches the value for global configuration 'AdvancedRateLimitSignInRequests' field
+func GetAdvancedRateLimitSignInRequests() int { return global.GetAdvancedRateLimitSignInRequests() }
+
+// SetAdvancedRateLimitSignInRequests safely sets the value for global configuration 'AdvancedRateLimitSignInRequests' field
+func SetAdvancedRateLimitSignInRequests(v int) { global.SetAdvancedRateLimitSignInRequests(v) }
+
+// GetAdvancedRateLimitMediaUploadRequests safely fetches the Configuration value for state's 'AdvancedRateLimitMediaUploadRequests' field
+func (st *ConfigState) GetAdvancedRateLimitMediaUploadRequests() (v int) {
+	st.mutex.Lock()
+	v = st.config.AdvancedRateLimitMediaUploadRequests
+	st.mutex.Unlock()
+	return
+}
+
+// SetAdvancedRateLimitMediaUploadRequests safely sets the Configuration value for state's 'AdvancedRateLimitMediaUploadRequests' field
+func (st *ConfigState) SetAdvancedRateLimitMediaUploadRequests(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedRateLimitMediaUploadRequests = v
+	st.reloadToViper()
+}
+
+// AdvancedRateLimitMediaUploadRequestsFlag returns the flag name for the 'AdvancedRateLimitMediaUploadRequests' field
+func AdvancedRateLimitMediaUploadRequestsFlag() string {
+	return "advanced-rate-limit-media-upload-requests"
+}
+
+// GetAdvancedRateLimitMediaUploadRequests safely fetches the value for global configuration 'AdvancedRateLimitMediaUploadRequests' field
+func GetAdvancedRateLimitMediaUploadRequests() int {
+	return global.GetAdvancedRateLimitMediaUploadRequests()
+}
+
+// SetAdvancedRateLimitMediaUploadRequests safely sets the value for global configuration 'AdvancedRateLimitMediaUploadRequests' field
+func SetAdvancedRateLimitMediaUploadRequests(v int) {
+	global.SetAdvancedRateLimitMediaUploadRequests(v)
+}
+
+// GetAdvancedRateLimitStatusPostRequests safely fetches the Configuration value for state's 'AdvancedRateLimitStatusPostRequests' field
+func (st *ConfigState) GetAdvancedRateLimitStatusPostRequests() (v int) {
+	st.mutex.Lock()
+	v = st.config.AdvancedRateLimitStatusPostRequests
+	st.mutex.Unlock()
+	return
+}
+
+// SetAdvancedRateLimitStatusPostRequests safely sets the Configuration value for state's 'AdvancedRateLimitStatusPostRequests' field
+func (st *ConfigState) SetAdvancedRateLimitStatusPostRequests(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedRateLimitStatusPostRequests = v
+	st.reloadToViper()
+}
+
+// AdvancedRateLimitStatusPostRequestsFlag returns the flag name for the 'AdvancedRateLimitStatusPostRequests' field
+func AdvancedRateLimitStatusPostRequestsFlag() string {
+	return "advanced-rate-limit-status-post-requests"
+}
+
+// GetAdvancedRateLimitStatusPostRequests safely fetches the value for global configuration 'AdvancedRateLimitStatusPostRequests' field
+func GetAdvancedRateLimitStatusPostRequests() int {
+	return global.GetAdvancedRateLimitStatusPostRequests()
+}
+
+// SetAdvancedRateLimitStatusPostRequests safely sets the value for global configuration 'AdvancedRateLimitStatusPostRequests' field
+func SetAdvancedRateLimitStatusPostRequests(v int) { global.SetAdvancedRateLimitStatusPostRequests(v) }
+
+// GetAdvancedRateLimitTimelineRequests safely fetches the Configuration value for state's 'AdvancedRateLimitTimelineRequests' field
+func (st *ConfigState) GetAdvancedRateLimitTimelineRequests() (v int) {
+	st.mutex.Lock()
+	v = st.config.AdvancedRateLimitTimelineRequests
+	st.mutex.Unlock()
+	return
+}
+
+// SetAdvancedRateLimitTimelineRequests safely sets the Configuration value for state's 'AdvancedRateLimitTimelineRequests' field
+func (st *ConfigState) SetAdvancedRateLimitTimelineRequests(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedRateLimitTimelineRequests = v
+	st.reloadToViper()
+}
+
+// AdvancedRateLimitTimelineRequestsFlag returns the flag name for the 'AdvancedRateLimitTimelineRequests' field
+func AdvancedRateLimitTimelineRequestsFlag() string { return "advanced-rate-limit-timeline-requests" }
+
+// GetAdvancedRateLimitTimelineRequests safely fetches the value for global configuration 'AdvancedRateLimitTimelineRequests' field
+func GetAdvancedRateLimitTimelineRequests() int { return global.GetAdvancedRateLimitTimelineRequests() }
+
+// SetAdvancedRateLimitTimelineRequests safely sets the value for global configuration 'AdvancedRateLimitTimelineRequests' field
+func SetAdvancedRateLimitTimelineRequests(v int) { global.SetAdvancedRateLimitTimelineRequests(v) }
+
+// GetAdvancedRateLimitInboxRequests safely fetches the Configuration value for state's 'AdvancedRateLimitInboxRequests' field
+func (st *ConfigState) GetAdvancedRateLimitInboxRequests() (v int) {
+	st.mutex.Lock()
+	v = st.config.AdvancedRateLimitInboxRequests
+	st.mutex.Unlock()
+	return
+}
+
+// SetAdvancedRateLimitInboxRequests safely sets the Configuration value for state's 'AdvancedRateLimitInboxRequests' field
+func (st *ConfigState) SetAdvancedRateLimitInboxRequests(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedRateLimitInboxRequests = v
+	st.reloadToViper()
+}
+
+// AdvancedRateLimitInboxRequestsFlag returns the flag name for the 'AdvancedRateLimitInboxRequests' field
+func AdvancedRateLimitInboxRequestsFlag() string { return "advanced-rate-limit-inbox-requests" }
+
+// GetAdvancedRateLimitInboxRequests safely fetches the value for global configuration 'AdvancedRateLimitInboxRequests' field
+func GetAdvancedRateLimitInboxRequests() int { return global.GetAdvancedRateLimitInboxRequests() }
+
+// SetAdvancedRateLimitInboxRequests safely sets the value for global configuration 'AdvancedRateLimitInboxRequests' field
+func SetAdvancedRateLimitInboxRequests(v int) { global.SetAdvancedRateLimitInboxRequests(v) }
+
+// GetAdvancedRateLimitFileserverRequests safely fetches the Configuration value for state's 'AdvancedRateLimitFileserverRequests' field
+func (st *ConfigState) GetAdvancedRateLimitFileserverRequests() (v int) {
+	st.mutex.Lock()
+	v = st.config.AdvancedRateLimitFileserverRequests
+	st.mutex.Unlock()
+	return
+}
+
+// SetAdvancedRateLimitFileserverRequests safely sets the Configuration value for state's 'AdvancedRateLimitFileserverRequests' field
+func (st *ConfigState) SetAdvancedRateLimitFileserverRequests(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedRateLimitFileserverRequests = v
+	st.reloadToViper()
+}
+
+// AdvancedRateLimitFileserverRequestsFlag returns the flag name for the 'AdvancedRateLimitFileserverRequests' field
+func AdvancedRateLimitFileserverRequestsFlag() string {
+	return "advanced-rate-limit-fileserver-requests"
+}
+
+// GetAdvancedRateLimitFileserverRequests safely fetches the value for global configuration 'AdvancedRateLimitFileserverRequests' field
+func GetAdvancedRateLimitFileserverRequests() int {
+	return global.GetAdvancedRateLimitFileserverRequests()
+}
+
+// SetAdvancedRateLimitFileserverRequests safely sets the value for global configuration 'AdvancedRateLimitFileserverRequests' field
+func SetAdvancedRateLimitFileserverRequests(v int) { global.SetAdvancedRateLimitFileserverRequests(v) }
+
 // GetAdvancedThrottlingMultiplier safely fetches the Configuration value for state's 'AdvancedThrottlingMultiplier' field
 func (st *ConfigState) GetAdvancedThrottlingMultiplier() (v int) {
 	st.mutex.Lock()
@@ -3580,6 +4069,81 @@ func GetCacheGTSUserSweepFreq() time.Duration { return global.GetCacheGTSUserSwe
 // SetCacheGTSUserSweepFreq safely sets the value for global configuration 'Cache.GTS.UserSweepFreq' field
 func SetCacheGTSUserSweepFreq(v time.Duration) { global.SetCacheGTSUserSweepFreq(v) }
 
+// GetCacheGTSUserMuteMaxSize safely fetches the Configuration value for state's 'Cache.GTS.UserMuteMaxSize' field
+func (st *ConfigState) GetCacheGTSUserMuteMaxSize() (v int) {
+	st.mutex.Lock()
+	v = st.config.Cache.GTS.UserMuteMaxSize
+	st.mutex.Unlock()
+	return
+}
+
+// SetCacheGTSUserMuteMaxSize safely sets the Configuration value for state's 'Cache.GTS.UserMuteMaxSize' field
+func (st *ConfigState) SetCacheGTSUserMuteMaxSize(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.GTS.UserMuteMaxSize = v
+	st.reloadToViper()
+}
+
+// CacheGTSUserMuteMaxSizeFlag returns the flag name for the 'Cache.GTS.UserMuteMaxSize' field
+func CacheGTSUserMuteMaxSizeFlag() string { return "cache-gts-user-mute-max-size" }
+
+// GetCacheGTSUserMuteMaxSize safely fetches the value for global configuration 'Cache.GTS.UserMuteMaxSize' field
+func GetCacheGTSUserMuteMaxSize() int { return global.GetCacheGTSUserMuteMaxSize() }
+
+// SetCacheGTSUserMuteMaxSize safely sets the value for global configuration 'Cache.GTS.UserMuteMaxSize' field
+func SetCacheGTSUserMuteMaxSize(v int) { global.SetCacheGTSUserMuteMaxSize(v) }
+
+// GetCacheGTSUserMuteTTL safely fetches the Configuration value for state's 'Cache.GTS.UserMuteTTL' field
+func (st *ConfigState) GetCacheGTSUserMuteTTL() (v time.Duration) {
+	st.mutex.Lock()
+	v = st.config.Cache.GTS.UserMuteTTL
+	st.mutex.Unlock()
+	return
+}
+
+// SetCacheGTSUserMuteTTL safely sets the Configuration value for state's 'Cache.GTS.UserMuteTTL' field
+func (st *ConfigState) SetCacheGTSUserMuteTTL(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.GTS.UserMuteTTL = v
+	st.reloadToViper()
+}
+
+// CacheGTSUserMuteTTLFlag returns the flag name for the 'Cache.GTS.UserMuteTTL' field
+func CacheGTSUserMuteTTLFlag() string { return "cache-gts-user-mute-ttl" }
+
+// GetCacheGTSUserMuteTTL safely fetches the value for global configuration 'Cache.GTS.UserMuteTTL' field
+func GetCacheGTSUserMuteTTL() time.Duration { return global.GetCacheGTSUserMuteTTL() }
+
+// SetCacheGTSUserMuteTTL safely sets the value for global configuration 'Cache.GTS.UserMuteTTL' field
+func SetCacheGTSUserMuteTTL(v time.Duration) { global.SetCacheGTSUserMuteTTL(v) }
+
+// GetCacheGTSUserMuteSweepFreq safely fetches the Configuration value for state's 'Cache.GTS.UserMuteSweepFreq' field
+func (st *ConfigState) GetCacheGTSUserMuteSweepFreq() (v time.Duration) {
+	st.mutex.Lock()
+	v = st.config.Cache.GTS.UserMuteSweepFreq
+	st.mutex.Unlock()
+	return
+}
+
+// SetCacheGTSUserMuteSweepFreq safely sets the Configuration value for state's 'Cache.GTS.UserMuteSweepFreq' field
+func (st *ConfigState) SetCacheGTSUserMuteSweepFreq(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.GTS.UserMuteSweepFreq = v
+	st.reloadToViper()
+}
+
+// CacheGTSUserMuteSweepFreqFlag returns the flag name for the 'Cache.GTS.UserMuteSweepFreq' field
+func CacheGTSUserMuteSweepFreqFlag() string { return "cache-gts-user-mute-sweep-freq" }
+
+// GetCacheGTSUserMuteSweepFreq safely fetches the value for global configuration 'Cache.GTS.UserMuteSweepFreq' field
+func GetCacheGTSUserMuteSweepFreq() time.Duration { return global.GetCacheGTSUserMuteSweepFreq() }
+
+// SetCacheGTSUserMuteSweepFreq safely sets the value for global configuration 'Cache.GTS.UserMuteSweepFreq' field
+func SetCacheGTSUserMuteSweepFreq(v time.Duration) { global.SetCacheGTSUserMuteSweepFreq(v) }
+
 // GetCacheGTSWebfingerMaxSize safely fetches the Configuration value for state's 'Cache.GTS.WebfingerMaxSize' field
 func (st *ConfigState) GetCacheGTSWebfingerMaxSize() (v int) {
 	st.mutex.Lock()
@@ -3805,6 +4369,31 @@ func GetAdminAccountPassword() string { return global.GetAdminAccountPassword()
 // SetAdminAccountPassword safely sets the value for global configuration 'AdminAccountPassword' field
 func SetAdminAccountPassword(v string) { global.SetAdminAccountPassword(v) }
 
+// GetAdminAccountQuota safely fetches the Configuration value for state's 'AdminAccountQuota' field
+func (st *ConfigState) GetAdminAccountQuota() (v string) {
+	st.mutex.Lock()
+	v = st.config.AdminAccountQuota
+	st.mutex.Unlock()
+	return
+}
+
+// SetAdminAccountQuota safely sets the Configuration value for state's 'AdminAccountQuota' field
+func (st *ConfigState) SetAdminAccountQuota(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminAccountQuota = v
+	st.reloadToViper()
+}
+
+// AdminAccountQuotaFlag returns the flag name for the 'AdminAccountQuota' field
+func AdminAccountQuotaFlag() string { return "quota" }
+
+// GetAdminAccountQuota safely fetches the value for global configuration 'AdminAccountQuota' field
+func GetAdminAccountQuota() string { return global.GetAdminAccountQuota() }
+
+// SetAdminAccountQuota safely sets the value for global configuration 'AdminAccountQuota' field
+func SetAdminAccountQuota(v string) { global.SetAdminAccountQuota(v) }
+
 // GetAdminTransPath safely fetches the Configuration value for state's 'AdminTransPath' field
 func (st *ConfigState) GetAdminTransPath() (v string) {
 	st.mutex.Lock()
@@ -3830,6 +4419,31 @@ func GetAdminTransPath() string { return global.GetAdminTransPath() }
 // SetAdminTransPath safely sets the value for global configuration 'AdminTransPath' field
 func SetAdminTransPath(v string) { global.SetAdminTransPath(v) }
 
+// GetAdminTransMedia safely fetches the Configuration value for state's 'AdminTransMedia' field
+func (st *ConfigState) GetAdminTransMedia() (v bool) {
+	st.mutex.Lock()
+	v = st.config.AdminTransMedia
+	st.mutex.Unlock()
+	return
+}
+
+// SetAdminTransMedia safely sets the Configuration value for state's 'AdminTransMedia' field
+func (st *ConfigState) SetAdminTransMedia(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminTransMedia = v
+	st.reloadToViper()
+}
+
+// AdminTransMediaFlag returns the flag name for the 'AdminTransMedia' field
+func AdminTransMediaFlag() string { return "media" }
+
+// GetAdminTransMedia safely fetches the value for global configuration 'AdminTransMedia' field
+func GetAdminTransMedia() bool { return global.GetAdminTransMedia() }
+
+// SetAdminTransMedia safely sets the value for global configuration 'AdminTransMedia' field
+func SetAdminTransMedia(v bool) { global.SetAdminTransMedia(v) }
+
 // GetAdminMediaPruneDryRun safely fetches the Configuration value for state's 'AdminMediaPruneDryRun' field
 func (st *ConfigState) GetAdminMediaPruneDryRun() (v bool) {
 	st.mutex.Lock()
@@ -3855,6 +4469,106 @@ func GetAdminMediaPruneDryRun() bool { return global.GetAdminMediaPruneDryRun()
 // SetAdminMediaPruneDryRun safely sets the value for global configuration 'AdminMediaPruneDryRun' field
 func SetAdminMediaPruneDryRun(v bool) { global.SetAdminMediaPruneDryRun(v) }
 
+// GetAdminIPBlockIP safely fetches the Configuration value for state's 'AdminIPBlockIP' field
+func (st *ConfigState) GetAdminIPBlockIP() (v string) {
+	st.mutex.Lock()
+	v = st.config.AdminIPBlockIP
+	st.mutex.Unlock()
+	return
+}
+
+// SetAdminIPBlockIP safely sets the Configuration value for state's 'AdminIPBlockIP' field
+func (st *ConfigState) SetAdminIPBlockIP(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminIPBlockIP = v
+	st.reloadToViper()
+}
+
+// AdminIPBlockIPFlag returns the flag name for the 'AdminIPBlockIP' field
+func AdminIPBlockIPFlag() string { return "ip" }
+
+// GetAdminIPBlockIP safely fetches the value for global configuration 'AdminIPBlockIP' field
+func GetAdminIPBlockIP() string { return global.GetAdminIPBlockIP() }
+
+// SetAdminIPBlockIP safely sets the value for global configuration 'AdminIPBlockIP' field
+func SetAdminIPBlockIP(v string) { global.SetAdminIPBlockIP(v) }
+
+// GetAdminIPBlockSeverity safely fetches the Configuration value for state's 'AdminIPBlockSeverity' field
+func (st *ConfigState) GetAdminIPBlockSeverity() (v string) {
+	st.mutex.Lock()
+	v = st.config.AdminIPBlockSeverity
+	st.mutex.Unlock()
+	return
+}
+
+// SetAdminIPBlockSeverity safely sets the Configuration value for state's 'AdminIPBlockSeverity' field
+func (st *ConfigState) SetAdminIPBlockSeverity(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminIPBlockSeverity = v
+	st.reloadToViper()
+}
+
+// AdminIPBlockSeverityFlag returns the flag name for the 'AdminIPBlockSeverity' field
+func AdminIPBlockSeverityFlag() string { return "severity" }
+
+// GetAdminIPBlockSeverity safely fetches the value for global configuration 'AdminIPBlockSeverity' field
+func GetAdminIPBlockSeverity() string { return global.GetAdminIPBlockSeverity() }
+
+// SetAdminIPBlockSeverity safely sets the value for global configuration 'AdminIPBlockSeverity' field
+func SetAdminIPBlockSeverity(v string) { global.SetAdminIPBlockSeverity(v) }
+
+// GetAdminIPBlockComment safely fetches the Configuration value for state's 'AdminIPBlockComment' field
+func (st *ConfigState) GetAdminIPBlockComment() (v string) {
+	st.mutex.Lock()
+	v = st.config.AdminIPBlockComment
+	st.mutex.Unlock()
+	return
+}
+
+// SetAdminIPBlockComment safely sets the Configuration value for state's 'AdminIPBlockComment' field
+func (st *ConfigState) SetAdminIPBlockComment(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminIPBlockComment = v
+	st.reloadToViper()
+}
+
+// AdminIPBlockCommentFlag returns the flag name for the 'AdminIPBlockComment' field
+func AdminIPBlockCommentFlag() string { return "comment" }
+
+// GetAdminIPBlockComment safely fetches the value for global configuration 'AdminIPBlockComment' field
+func GetAdminIPBlockComment() string { return global.GetAdminIPBlockComment() }
+
+// SetAdminIPBlockComment safely sets the value for global configuration 'AdminIPBlockComment' field
+func SetAdminIPBlockComment(v string) { global.SetAdminIPBlockComment(v) }
+
+// GetAdminIPBlockExpiresIn safely fetches the Configuration value for state's 'AdminIPBlockExpiresIn' field
+func (st *ConfigState) GetAdminIPBlockExpiresIn() (v time.Duration) {
+	st.mutex.Lock()
+	v = st.config.AdminIPBlockExpiresIn
+	st.mutex.Unlock()
+	return
+}
+
+// SetAdminIPBlockExpiresIn safely sets the Configuration value for state's 'AdminIPBlockExpiresIn' field
+func (st *ConfigState) SetAdminIPBlockExpiresIn(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminIPBlockExpiresIn = v
+	st.reloadToViper()
+}
+
+// AdminIPBlockExpiresInFlag returns the flag name for the 'AdminIPBlockExpiresIn' field
+func AdminIPBlockExpiresInFlag() string { return "expires-in" }
+
+// GetAdminIPBlockExpiresIn safely fetches the value for global configuration 'AdminIPBlockExpiresIn' field
+func GetAdminIPBlockExpiresIn() time.Duration { return global.GetAdminIPBlockExpiresIn() }
+
+// SetAdminIPBlockExpiresIn safely sets the value for global configuration 'AdminIPBlockExpiresIn' field
+func SetAdminIPBlockExpiresIn(v time.Duration) { global.SetAdminIPBlockExpiresIn(v) }
+
 // GetRequestIDHeader safely fetches the Configuration value for state's 'RequestIDHeader' field
 func (st *ConfigState) GetRequestIDHeader() (v string) {
 	st.mutex.Lock()
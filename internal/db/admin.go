@@ -20,6 +20,7 @@ package db
 import (
 	"context"
 	"net"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
@@ -50,4 +51,23 @@ type Admin interface {
 	// Ie., if the instance is hosted at 'example.org' the instance will have a domain of 'example.org'.
 	// This is needed for things like serving instance information through /api/v1/instance
 	CreateInstanceInstance(ctx context.Context) Error
+
+	// CountActivity counts activity by local accounts between start and end, and takes a
+	// snapshot of the current size of the instance, returning the result as a DailyActivity
+	// for the day starting at start. The returned activity is not stored.
+	CountActivity(ctx context.Context, start time.Time, end time.Time) (*gtsmodel.DailyActivity, Error)
+
+	// CountStatusLanguages returns the languages of statuses posted by local
+	// accounts between start and end, most used first, up to limit.
+	CountStatusLanguages(ctx context.Context, start time.Time, end time.Time, limit int) ([]*gtsmodel.ActivityCount, Error)
+
+	// GetNewAccountIDs returns the account IDs of local users who signed up between start and end.
+	GetNewAccountIDs(ctx context.Context, start time.Time, end time.Time) ([]string, Error)
+
+	// PutDailyActivity stores the given daily activity rollup.
+	PutDailyActivity(ctx context.Context, activity *gtsmodel.DailyActivity) Error
+
+	// GetDailyActivities returns stored daily activity rollups for days
+	// from start (inclusive) until end (exclusive), oldest first.
+	GetDailyActivities(ctx context.Context, start time.Time, end time.Time) ([]*gtsmodel.DailyActivity, Error)
 }
@@ -0,0 +1,54 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Application handles getting/deletion/updating of OAuth applications and their tokens.
+type Application interface {
+	// GetApplicationByClientID gets one application by the ID of its OAuth client.
+	GetApplicationByClientID(ctx context.Context, clientID string) (*gtsmodel.Application, Error)
+
+	// GetTokenByID gets one OAuth token by its db id.
+	GetTokenByID(ctx context.Context, id string) (*gtsmodel.Token, Error)
+
+	// GetAccessTokensByUserID gets all OAuth access tokens belonging to the given user,
+	// most recently created first. Authorization codes which have not yet been exchanged
+	// for an access token are not included.
+	GetAccessTokensByUserID(ctx context.Context, userID string) ([]*gtsmodel.Token, Error)
+
+	// UpdateToken updates one OAuth token by its db id.
+	// The given columns will be updated; if no columns
+	// are provided, then all columns will be updated.
+	UpdateToken(ctx context.Context, token *gtsmodel.Token, columns ...string) Error
+
+	// DeleteTokenByID deletes one OAuth token by its db id.
+	DeleteTokenByID(ctx context.Context, id string) Error
+
+	// DeleteTokensByUserID deletes all OAuth tokens and codes belonging to the given user.
+	DeleteTokensByUserID(ctx context.Context, userID string) Error
+
+	// DeleteApprovedDeviceAuthorization deletes one OAuth device authorization by its db id,
+	// only if it has been approved by a user. It returns whether it was deleted, which can
+	// only be true for one caller, so that an approved device code is only ever spent once.
+	DeleteApprovedDeviceAuthorization(ctx context.Context, id string) (bool, Error)
+}
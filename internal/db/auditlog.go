@@ -0,0 +1,34 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// AuditLog contains functionality for the append-only moderation audit log.
+type AuditLog interface {
+	// PutAuditLogEntry appends the given entry to the audit log.
+	PutAuditLogEntry(ctx context.Context, entry *gtsmodel.AuditLogEntry) error
+
+	// GetAuditLogEntries gets limit n audit log entries using the given parameters, newest first.
+	// Parameters that are empty / zero are ignored. Returns ErrNoEntries if nothing was found.
+	GetAuditLogEntries(ctx context.Context, accountID string, action string, targetType string, targetID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.AuditLogEntry, error)
+}
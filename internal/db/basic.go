@@ -40,6 +40,10 @@ type Basic interface {
 	// IsHealthy should return nil if the database connection is healthy, or an error if not.
 	IsHealthy(ctx context.Context) Error
 
+	// PendingMigrations returns the names of any registered database
+	// migrations that have not yet been applied to the database.
+	PendingMigrations(ctx context.Context) ([]string, Error)
+
 	// GetByID gets one entry by its id. In a database like postgres, this might be the 'id' field of the entry,
 	// for other implementations (for example, in-memory) it might just be the key of a map.
 	// The given interface i will be set to the result of the query, whatever it is. Use a pointer or a slice.
@@ -57,6 +61,11 @@ type Basic interface {
 	// In case of no entries, a 'no entries' error will be returned
 	GetAll(ctx context.Context, i interface{}) Error
 
+	// GetAllPage gets up to limit entries of type i with an id greater than minID, in ascending order of id.
+	// This can be used to page through every entry of a type without loading them all into memory at once.
+	// The given interface i will be set to the result of the query, whatever it is. Use a slice.
+	GetAllPage(ctx context.Context, minID string, limit int, i interface{}) Error
+
 	// Put simply stores i. It is up to the implementation to figure out how to store it, and using what key.
 	// The given interface i will be set to the result of the query, whatever it is. Use a pointer or a slice.
 	Put(ctx context.Context, i interface{}) Error
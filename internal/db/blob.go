@@ -0,0 +1,46 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Blob contains functionality for reference counting content-addressed files in storage.
+type Blob interface {
+	// GetBlobRefs returns the current reference count of the blob with given
+	// storage key, or zero if no such blob is known.
+	GetBlobRefs(ctx context.Context, key string) (int, error)
+
+	// IncrementBlobRefs adds a reference to the blob with given storage key and
+	// size, creating a new entry if none exists, and returns the new reference count.
+	IncrementBlobRefs(ctx context.Context, key string, size int64) (int, error)
+
+	// DecrementBlobRefs removes a reference to the blob with given storage key,
+	// and returns the new reference count. Releasing a blob with no entry returns zero.
+	DecrementBlobRefs(ctx context.Context, key string) (int, error)
+
+	// DeleteBlob deletes the entry for the blob with given storage key.
+	DeleteBlob(ctx context.Context, key string) error
+
+	// ResetBlobRefs replaces all existing blob entries with the given ones,
+	// for when reference counts have been recalculated from scratch.
+	ResetBlobRefs(ctx context.Context, blobs []*gtsmodel.Blob) error
+}
@@ -0,0 +1,120 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type ActivityTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *ActivityTestSuite) TestCountActivity() {
+	var (
+		start = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+		end   = time.Now().Add(24 * time.Hour)
+	)
+
+	activity, err := suite.db.CountActivity(context.Background(), start, end)
+	suite.NoError(err)
+	suite.Equal(start, activity.Day)
+	suite.Equal(len(suite.testUsers), activity.NewUsers)
+	suite.Equal(3, activity.ActiveUsers())
+	suite.Equal(16, activity.Statuses)
+	suite.Equal(8, activity.Interactions)
+	suite.Equal(2, activity.OpenedReports)
+	suite.Equal(1, activity.ResolvedReports)
+	suite.Equal(2, activity.KnownInstances)
+	suite.EqualValues(4532670, activity.LocalMediaBytes)
+	suite.EqualValues(78327, activity.RemoteMediaBytes)
+	suite.EqualValues(68812, activity.EmojiBytes)
+}
+
+func (suite *ActivityTestSuite) TestCountActivityEmptyPeriod() {
+	var (
+		start = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+		end   = start.Add(24 * time.Hour)
+	)
+
+	activity, err := suite.db.CountActivity(context.Background(), start, end)
+	suite.NoError(err)
+	suite.Zero(activity.NewUsers)
+	suite.Zero(activity.ActiveUsers())
+	suite.Zero(activity.Statuses)
+	suite.Zero(activity.Interactions)
+}
+
+func (suite *ActivityTestSuite) TestPutGetDailyActivities() {
+	ctx := context.Background()
+	day := time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	if err := suite.db.PutDailyActivity(ctx, &gtsmodel.DailyActivity{
+		ID:               "01H4BTZ8B6EYCQMHRKM2QSKMV4",
+		Day:              day,
+		NewUsers:         2,
+		ActiveAccountIDs: []string{suite.testAccounts["local_account_1"].ID},
+		Statuses:         5,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	activities, err := suite.db.GetDailyActivities(ctx, day, day.Add(24*time.Hour))
+	suite.NoError(err)
+	if !suite.Len(activities, 1) {
+		suite.FailNow("")
+	}
+	suite.True(day.Equal(activities[0].Day))
+	suite.Equal(2, activities[0].NewUsers)
+	suite.Equal(1, activities[0].ActiveUsers())
+	suite.Equal(5, activities[0].Statuses)
+
+	// Day after has no rollup.
+	activities, err = suite.db.GetDailyActivities(ctx, day.Add(24*time.Hour), day.Add(48*time.Hour))
+	suite.NoError(err)
+	suite.Empty(activities)
+}
+
+func (suite *ActivityTestSuite) TestCountLanguagesAndTopInstances() {
+	var (
+		ctx   = context.Background()
+		start = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+		end   = time.Now().Add(24 * time.Hour)
+	)
+
+	languages, err := suite.db.CountStatusLanguages(ctx, start, end, 10)
+	suite.NoError(err)
+	suite.NotEmpty(languages)
+
+	instances, err := suite.db.GetTopInstances(ctx, start, end, 10)
+	suite.NoError(err)
+	suite.NotEmpty(instances)
+
+	activity, err := suite.db.CountInstanceActivity(ctx, "fossbros-anonymous.io", start, end)
+	suite.NoError(err)
+	suite.NotZero(activity.Accounts)
+}
+
+func TestActivityTestSuite(t *testing.T) {
+	suite.Run(t, new(ActivityTestSuite))
+}
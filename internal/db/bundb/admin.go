@@ -35,6 +35,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/uptrace/bun"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -65,13 +66,8 @@ func (a *adminDB) IsEmailAvailable(ctx context.Context, email string) (bool, db.
 	}
 	domain := strings.Split(m.Address, "@")[1] // domain will always be the second part after @
 
-	// check if the email domain is blocked
-	emailDomainBlockedQ := a.conn.
-		NewSelect().
-		TableExpr("? AS ?", bun.Ident("email_domain_blocks"), bun.Ident("email_domain_block")).
-		Column("email_domain_block.id").
-		Where("? = ?", bun.Ident("email_domain_block.domain"), domain)
-	emailDomainBlocked, err := a.conn.Exists(ctx, emailDomainBlockedQ)
+	// check if the email domain (or a parent of it) is blocked
+	emailDomainBlocked, err := a.state.DB.IsEmailDomainBlocked(ctx, domain)
 	if err != nil {
 		return false, err
 	}
@@ -292,3 +288,251 @@ func (a *adminDB) CreateInstanceInstance(ctx context.Context) db.Error {
 	log.Infof(ctx, "created instance instance %s with id %s", host, i.ID)
 	return nil
 }
+
+// localAccountIDs returns a subquery selecting the IDs of all local accounts.
+func (a *adminDB) localAccountIDs() *bun.SelectQuery {
+	return a.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
+		Column("account.id").
+		Where("? IS NULL", bun.Ident("account.domain"))
+}
+
+// whereBetween limits the given query to rows where column is between start (inclusive) and end (exclusive).
+func whereBetween(q *bun.SelectQuery, column string, start time.Time, end time.Time) *bun.SelectQuery {
+	return q.
+		Where("? >= ?", bun.Ident(column), start).
+		Where("? < ?", bun.Ident(column), end)
+}
+
+func (a *adminDB) CountActivity(ctx context.Context, start time.Time, end time.Time) (*gtsmodel.DailyActivity, db.Error) {
+	var (
+		activity = &gtsmodel.DailyActivity{Day: start}
+		err      error
+	)
+
+	// Count new local users.
+	if activity.NewUsers, err = whereBetween(a.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("users"), bun.Ident("user")),
+		"user.created_at", start, end,
+	).Count(ctx); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	// Count local statuses.
+	if activity.Statuses, err = whereBetween(a.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Where("? = ?", bun.Ident("status.local"), true),
+		"status.created_at", start, end,
+	).Count(ctx); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	// Count interactions with local statuses: faves, boosts and replies by others.
+	faves, err := whereBetween(a.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_faves"), bun.Ident("status_fave")).
+		Where("? IN (?)", bun.Ident("status_fave.target_account_id"), a.localAccountIDs()),
+		"status_fave.created_at", start, end,
+	).Count(ctx)
+	if err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	boosts, err := whereBetween(a.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Where("? IN (?)", bun.Ident("status.boost_of_account_id"), a.localAccountIDs()),
+		"status.created_at", start, end,
+	).Count(ctx)
+	if err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	replies, err := whereBetween(a.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Where("? IN (?)", bun.Ident("status.in_reply_to_account_id"), a.localAccountIDs()).
+		Where("? != ?", bun.Ident("status.in_reply_to_account_id"), bun.Ident("status.account_id")),
+		"status.created_at", start, end,
+	).Count(ctx)
+	if err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	activity.Interactions = faves + boosts + replies
+
+	// Count opened + resolved reports.
+	if activity.OpenedReports, err = whereBetween(a.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("reports"), bun.Ident("report")),
+		"report.created_at", start, end,
+	).Count(ctx); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	if activity.ResolvedReports, err = whereBetween(a.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("reports"), bun.Ident("report")),
+		"report.action_taken_at", start, end,
+	).Count(ctx); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	// Gather local accounts that were active, ie., which
+	// signed in, posted a status or faved something.
+	var activeIDs []string
+
+	for _, q := range []*bun.SelectQuery{
+		whereBetween(a.conn.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+			Column("status.account_id").
+			Distinct().
+			Where("? = ?", bun.Ident("status.local"), true),
+			"status.created_at", start, end,
+		),
+		whereBetween(a.conn.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("status_faves"), bun.Ident("status_fave")).
+			Column("status_fave.account_id").
+			Distinct().
+			Where("? IN (?)", bun.Ident("status_fave.account_id"), a.localAccountIDs()),
+			"status_fave.created_at", start, end,
+		),
+		a.conn.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("users"), bun.Ident("user")).
+			Column("user.account_id").
+			WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.
+					WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+						return whereBetween(q, "user.current_sign_in_at", start, end)
+					}).
+					WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+						return whereBetween(q, "user.last_sign_in_at", start, end)
+					})
+			}),
+	} {
+		ids := []string{}
+		if err := q.Scan(ctx, &ids); err != nil {
+			return nil, a.conn.ProcessError(err)
+		}
+		activeIDs = append(activeIDs, ids...)
+	}
+
+	activity.ActiveAccountIDs = util.UniqueStrings(activeIDs)
+
+	// Snapshot current size of the instance.
+	if activity.KnownInstances, err = a.state.DB.CountInstanceDomains(ctx, config.GetHost()); err != nil {
+		return nil, err
+	}
+
+	for _, media := range []struct {
+		bytes  *int64
+		remote bool
+	}{
+		{bytes: &activity.LocalMediaBytes},
+		{bytes: &activity.RemoteMediaBytes, remote: true},
+	} {
+		q := a.conn.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("media_attachments"), bun.Ident("media_attachment")).
+			ColumnExpr("COALESCE(SUM(? + ? + COALESCE(?, 0)), 0)",
+				bun.Ident("media_attachment.file_file_size"),
+				bun.Ident("media_attachment.thumbnail_file_size"),
+				bun.Ident("media_attachment.file_source_file_size"),
+			).
+			Where("? = ?", bun.Ident("media_attachment.cached"), true)
+
+		if media.remote {
+			q = q.Where("? IS NOT NULL", bun.Ident("media_attachment.remote_url"))
+		} else {
+			q = q.Where("? IS NULL", bun.Ident("media_attachment.remote_url"))
+		}
+
+		if err := q.Scan(ctx, media.bytes); err != nil {
+			return nil, a.conn.ProcessError(err)
+		}
+	}
+
+	if err := a.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("emojis"), bun.Ident("emoji")).
+		ColumnExpr("COALESCE(SUM(? + ?), 0)",
+			bun.Ident("emoji.image_file_size"),
+			bun.Ident("emoji.image_static_file_size"),
+		).
+		Scan(ctx, &activity.EmojiBytes); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	return activity, nil
+}
+
+func (a *adminDB) CountStatusLanguages(ctx context.Context, start time.Time, end time.Time, limit int) ([]*gtsmodel.ActivityCount, db.Error) {
+	counts := []*gtsmodel.ActivityCount{}
+
+	q := whereBetween(a.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		ColumnExpr("? AS ?", bun.Ident("status.language"), bun.Ident("key")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		Where("? = ?", bun.Ident("status.local"), true).
+		Where("? IS NOT NULL", bun.Ident("status.language")),
+		"status.created_at", start, end,
+	).
+		Group("status.language").
+		OrderExpr("? DESC, ? ASC", bun.Ident("count"), bun.Ident("key"))
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &counts); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	return counts, nil
+}
+
+func (a *adminDB) GetNewAccountIDs(ctx context.Context, start time.Time, end time.Time) ([]string, db.Error) {
+	accountIDs := []string{}
+
+	if err := whereBetween(a.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("users"), bun.Ident("user")).
+		Column("user.account_id"),
+		"user.created_at", start, end,
+	).Scan(ctx, &accountIDs); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	return accountIDs, nil
+}
+
+func (a *adminDB) PutDailyActivity(ctx context.Context, activity *gtsmodel.DailyActivity) db.Error {
+	_, err := a.conn.
+		NewInsert().
+		Model(activity).
+		Exec(ctx)
+	return a.conn.ProcessError(err)
+}
+
+func (a *adminDB) GetDailyActivities(ctx context.Context, start time.Time, end time.Time) ([]*gtsmodel.DailyActivity, db.Error) {
+	activities := []*gtsmodel.DailyActivity{}
+
+	if err := whereBetween(a.conn.
+		NewSelect().
+		Model(&activities),
+		"daily_activity.day", start, end,
+	).
+		Order("daily_activity.day ASC").
+		Scan(ctx); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	return activities, nil
+}
@@ -0,0 +1,136 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type applicationDB struct {
+	conn *DBConn
+}
+
+func (a *applicationDB) GetApplicationByClientID(ctx context.Context, clientID string) (*gtsmodel.Application, db.Error) {
+	app := &gtsmodel.Application{}
+
+	if err := a.conn.
+		NewSelect().
+		Model(app).
+		Where("? = ?", bun.Ident("application.client_id"), clientID).
+		Scan(ctx); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	return app, nil
+}
+
+func (a *applicationDB) GetTokenByID(ctx context.Context, id string) (*gtsmodel.Token, db.Error) {
+	token := &gtsmodel.Token{}
+
+	if err := a.conn.
+		NewSelect().
+		Model(token).
+		Where("? = ?", bun.Ident("token.id"), id).
+		Scan(ctx); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	return token, nil
+}
+
+func (a *applicationDB) GetAccessTokensByUserID(ctx context.Context, userID string) ([]*gtsmodel.Token, db.Error) {
+	tokens := []*gtsmodel.Token{}
+
+	if err := a.conn.
+		NewSelect().
+		Model(&tokens).
+		Where("? = ?", bun.Ident("token.user_id"), userID).
+		Where("? != ''", bun.Ident("token.access")).
+		OrderExpr("? DESC", bun.Ident("token.id")).
+		Scan(ctx); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	return tokens, nil
+}
+
+func (a *applicationDB) UpdateToken(ctx context.Context, token *gtsmodel.Token, columns ...string) db.Error {
+	token.UpdatedAt = time.Now()
+	if len(columns) != 0 {
+		columns = append(columns, "updated_at")
+	}
+
+	if _, err := a.conn.
+		NewUpdate().
+		Model(token).
+		Where("? = ?", bun.Ident("token.id"), token.ID).
+		Column(columns...).
+		Exec(ctx); err != nil {
+		return a.conn.ProcessError(err)
+	}
+
+	return nil
+}
+
+func (a *applicationDB) DeleteTokenByID(ctx context.Context, id string) db.Error {
+	if _, err := a.conn.
+		NewDelete().
+		Model((*gtsmodel.Token)(nil)).
+		Where("? = ?", bun.Ident("token.id"), id).
+		Exec(ctx); err != nil {
+		return a.conn.ProcessError(err)
+	}
+
+	return nil
+}
+
+func (a *applicationDB) DeleteTokensByUserID(ctx context.Context, userID string) db.Error {
+	if _, err := a.conn.
+		NewDelete().
+		Model((*gtsmodel.Token)(nil)).
+		Where("? = ?", bun.Ident("token.user_id"), userID).
+		Exec(ctx); err != nil {
+		return a.conn.ProcessError(err)
+	}
+
+	return nil
+}
+
+func (a *applicationDB) DeleteApprovedDeviceAuthorization(ctx context.Context, id string) (bool, db.Error) {
+	res, err := a.conn.
+		NewDelete().
+		Model((*gtsmodel.DeviceAuthorization)(nil)).
+		Where("? = ?", bun.Ident("device_authorization.id"), id).
+		Where("? IS NOT NULL", bun.Ident("device_authorization.user_id")).
+		Exec(ctx)
+	if err != nil {
+		return false, a.conn.ProcessError(err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return false, a.conn.ProcessError(err)
+	}
+
+	return deleted == 1, nil
+}
@@ -0,0 +1,71 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type ApplicationTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *ApplicationTestSuite) TestDeleteApprovedDeviceAuthorization() {
+	ctx := context.Background()
+
+	da := &gtsmodel.DeviceAuthorization{
+		ID:         "01H9E8Q2W6Z4R3T7Y5K1N0XB8C",
+		ClientID:   suite.testClients["local_account_1"].ID,
+		DeviceCode: "some-device-code",
+		UserCode:   "ABCD-EFGH",
+		Scope:      "read",
+		Interval:   5,
+		ExpiresAt:  time.Now().Add(10 * time.Minute),
+	}
+	if err := suite.db.Put(ctx, da); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Not approved yet, so it's left alone.
+	deleted, err := suite.db.DeleteApprovedDeviceAuthorization(ctx, da.ID)
+	suite.NoError(err)
+	suite.False(deleted)
+
+	da.UserID = suite.testUsers["local_account_1"].ID
+	if err := suite.db.UpdateByID(ctx, da, da.ID, "user_id"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Once approved, only the first delete succeeds.
+	deleted, err = suite.db.DeleteApprovedDeviceAuthorization(ctx, da.ID)
+	suite.NoError(err)
+	suite.True(deleted)
+
+	deleted, err = suite.db.DeleteApprovedDeviceAuthorization(ctx, da.ID)
+	suite.NoError(err)
+	suite.False(deleted)
+}
+
+func TestApplicationTestSuite(t *testing.T) {
+	suite.Run(t, new(ApplicationTestSuite))
+}
@@ -0,0 +1,130 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type auditLogDB struct {
+	conn  *DBConn
+	state *state.State
+}
+
+func (a *auditLogDB) PutAuditLogEntry(ctx context.Context, entry *gtsmodel.AuditLogEntry) error {
+	_, err := a.conn.
+		NewInsert().
+		Model(entry).
+		Exec(ctx)
+	return a.conn.ProcessError(err)
+}
+
+func (a *auditLogDB) GetAuditLogEntries(ctx context.Context, accountID string, action string, targetType string, targetID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.AuditLogEntry, error) {
+	var (
+		entries = []*gtsmodel.AuditLogEntry{}
+
+		// Whether we should return entries
+		// from newest to oldest (the default),
+		// or oldest to newest, when paging up.
+		frontToBack = true
+	)
+
+	q := a.conn.
+		NewSelect().
+		Model(&entries)
+
+	if accountID != "" {
+		q = q.Where("? = ?", bun.Ident("audit_log_entry.account_id"), accountID)
+	}
+
+	if action != "" {
+		q = q.Where("? = ?", bun.Ident("audit_log_entry.action"), action)
+	}
+
+	if targetType != "" {
+		q = q.Where("? = ?", bun.Ident("audit_log_entry.target_type"), targetType)
+	}
+
+	if targetID != "" {
+		q = q.Where("? = ?", bun.Ident("audit_log_entry.target_id"), targetID)
+	}
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("audit_log_entry.id"), maxID)
+	}
+
+	if sinceID != "" {
+		q = q.Where("? > ?", bun.Ident("audit_log_entry.id"), sinceID)
+	}
+
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("audit_log_entry.id"), minID)
+
+		// Paging up, so we want the
+		// entries just after minID.
+		frontToBack = false
+	}
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if frontToBack {
+		// Page down.
+		q = q.Order("audit_log_entry.id DESC")
+	} else {
+		// Page up.
+		q = q.Order("audit_log_entry.id ASC")
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	// If we're paging up, we still want entries
+	// to be sorted by ID desc, so reverse slice.
+	if !frontToBack {
+		for l, r := 0, len(entries)-1; l < r; l, r = l+1, r-1 {
+			entries[l], entries[r] = entries[r], entries[l]
+		}
+	}
+
+	// Catch case of no entries early
+	if len(entries) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	for _, entry := range entries {
+		// The acting account may since have been
+		// deleted, so just log any error and continue.
+		account, err := a.state.DB.GetAccountByID(ctx, entry.AccountID)
+		if err != nil {
+			log.Errorf(ctx, "error getting audit log entry %s account: %v", entry.ID, err)
+			continue
+		}
+		entry.Account = account
+	}
+
+	return entries, nil
+}
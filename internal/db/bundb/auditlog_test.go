@@ -0,0 +1,100 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type AuditLogTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *AuditLogTestSuite) TestPutGetAuditLogEntries() {
+	ctx := context.Background()
+	adminAccount := suite.testAccounts["admin_account"]
+
+	for _, entry := range []*gtsmodel.AuditLogEntry{
+		{
+			ID:         "01H6BKQ7Z2Y3NFE1DXW9JTRPVD",
+			AccountID:  adminAccount.ID,
+			Action:     gtsmodel.AuditLogActionCreate,
+			TargetType: gtsmodel.AuditLogTargetDomainBlock,
+			TargetID:   "01H6BKRDN5M7WAY4HK0BZBZ1PS",
+			Diff:       `{"domain":{"old":null,"new":"example.org"}}`,
+		},
+		{
+			ID:         "01H6BKRX1Z9KQ2BD5XJ4S0X3TC",
+			AccountID:  adminAccount.ID,
+			Action:     "silence",
+			TargetType: gtsmodel.AuditLogTargetAccount,
+			TargetID:   suite.testAccounts["remote_account_1"].ID,
+			Diff:       `{"silenced":{"old":false,"new":true}}`,
+		},
+		{
+			ID:         "01H6BKT4Q3ZJ8N1V7C2K5W6XGD",
+			AccountID:  adminAccount.ID,
+			Action:     gtsmodel.AuditLogActionDelete,
+			TargetType: gtsmodel.AuditLogTargetDomainBlock,
+			TargetID:   "01H6BKRDN5M7WAY4HK0BZBZ1PS",
+			Diff:       `{"domain":{"old":"example.org","new":null}}`,
+		},
+	} {
+		if err := suite.db.PutAuditLogEntry(ctx, entry); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// All entries, newest first.
+	entries, err := suite.db.GetAuditLogEntries(ctx, "", "", "", "", "", "", "", 0)
+	suite.NoError(err)
+	if !suite.Len(entries, 3) {
+		suite.FailNow("")
+	}
+	suite.Equal("01H6BKT4Q3ZJ8N1V7C2K5W6XGD", entries[0].ID)
+	suite.Equal("01H6BKRX1Z9KQ2BD5XJ4S0X3TC", entries[1].ID)
+	suite.Equal("01H6BKQ7Z2Y3NFE1DXW9JTRPVD", entries[2].ID)
+	suite.NotNil(entries[0].Account)
+
+	// Filtered by target type.
+	entries, err = suite.db.GetAuditLogEntries(ctx, "", "", string(gtsmodel.AuditLogTargetDomainBlock), "", "", "", "", 0)
+	suite.NoError(err)
+	suite.Len(entries, 2)
+
+	// Paged up from the oldest entry, which
+	// should give the entry just after it.
+	entries, err = suite.db.GetAuditLogEntries(ctx, "", "", "", "", "", "", "01H6BKQ7Z2Y3NFE1DXW9JTRPVD", 1)
+	suite.NoError(err)
+	if suite.Len(entries, 1) {
+		suite.Equal("01H6BKRX1Z9KQ2BD5XJ4S0X3TC", entries[0].ID)
+	}
+
+	// Paged past the last entry.
+	_, err = suite.db.GetAuditLogEntries(ctx, "", "", "", "", "01H6BKQ7Z2Y3NFE1DXW9JTRPVD", "", "", 0)
+	suite.True(errors.Is(err, db.ErrNoEntries))
+}
+
+func TestAuditLogTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditLogTestSuite))
+}
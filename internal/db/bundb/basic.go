@@ -22,9 +22,11 @@ import (
 	"errors"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb/migrations"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
 )
 
 type basicDB struct {
@@ -68,6 +70,21 @@ func (b *basicDB) GetAll(ctx context.Context, i interface{}) db.Error {
 	return b.conn.ProcessError(err)
 }
 
+func (b *basicDB) GetAllPage(ctx context.Context, minID string, limit int, i interface{}) db.Error {
+	q := b.conn.
+		NewSelect().
+		Model(i).
+		OrderExpr("? ASC", bun.Ident("id")).
+		Limit(limit)
+
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("id"), minID)
+	}
+
+	err := q.Scan(ctx)
+	return b.conn.ProcessError(err)
+}
+
 func (b *basicDB) DeleteByID(ctx context.Context, id string, i interface{}) db.Error {
 	q := b.conn.
 		NewDelete().
@@ -163,6 +180,23 @@ func (b *basicDB) IsHealthy(ctx context.Context) db.Error {
 	return b.conn.PingContext(ctx)
 }
 
+func (b *basicDB) PendingMigrations(ctx context.Context) ([]string, db.Error) {
+	migrator := migrate.NewMigrator(b.conn.DB, migrations.Migrations)
+
+	ms, err := migrator.MigrationsWithStatus(ctx)
+	if err != nil {
+		return nil, b.conn.ProcessError(err)
+	}
+
+	unapplied := ms.Unapplied()
+	names := make([]string, 0, len(unapplied))
+	for _, m := range unapplied {
+		names = append(names, m.Name)
+	}
+
+	return names, nil
+}
+
 func (b *basicDB) Stop(ctx context.Context) db.Error {
 	log.Info(ctx, "closing db connection")
 	return b.conn.Close()
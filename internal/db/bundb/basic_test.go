@@ -124,6 +124,32 @@ func (suite *BasicTestSuite) TestGetAllStatuses() {
 	suite.Len(s, 17)
 }
 
+func (suite *BasicTestSuite) TestGetAllPageStatuses() {
+	var (
+		ids   []string
+		minID string
+	)
+
+	for {
+		s := []*gtsmodel.Status{}
+		err := suite.db.GetAllPage(context.Background(), minID, 5, &s)
+		suite.NoError(err)
+		if len(s) == 0 {
+			break
+		}
+		suite.LessOrEqual(len(s), 5)
+
+		for _, status := range s {
+			suite.Greater(status.ID, minID)
+			ids = append(ids, status.ID)
+		}
+		minID = s[len(s)-1].ID
+	}
+
+	suite.Len(ids, 17)
+	suite.IsIncreasing(ids)
+}
+
 func (suite *BasicTestSuite) TestGetAllNotNull() {
 	where := []db.Where{{
 		Key:   "domain",
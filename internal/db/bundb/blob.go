@@ -0,0 +1,157 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type blobDB struct {
+	conn *DBConn
+}
+
+func (b *blobDB) GetBlobRefs(ctx context.Context, key string) (int, error) {
+	refs, err := getBlobRefs(ctx, b.conn.DB, key)
+	return refs, b.conn.ProcessError(err)
+}
+
+func (b *blobDB) IncrementBlobRefs(ctx context.Context, key string, size int64) (int, error) {
+	var refs int
+
+	err := b.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		res, err := tx.NewUpdate().
+			Table("blobs").
+			Set("? = ? + 1", bun.Ident("refs"), bun.Ident("refs")).
+			Set("? = ?", bun.Ident("updated_at"), time.Now()).
+			Where("? = ?", bun.Ident("key"), key).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			// No existing entry, insert new blob.
+			if _, err := tx.NewInsert().
+				Model(&gtsmodel.Blob{
+					Key:  key,
+					Size: size,
+					Refs: 1,
+				}).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		refs, err = getBlobRefs(ctx, tx, key)
+		return err
+	})
+
+	return refs, b.conn.ProcessError(err)
+}
+
+func (b *blobDB) DecrementBlobRefs(ctx context.Context, key string) (int, error) {
+	var refs int
+
+	err := b.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		if _, err := tx.NewUpdate().
+			Table("blobs").
+			Set("? = ? - 1", bun.Ident("refs"), bun.Ident("refs")).
+			Set("? = ?", bun.Ident("updated_at"), time.Now()).
+			Where("? = ?", bun.Ident("key"), key).
+			Where("? > 0", bun.Ident("refs")).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		var err error
+		refs, err = getBlobRefs(ctx, tx, key)
+		return err
+	})
+
+	return refs, b.conn.ProcessError(err)
+}
+
+func (b *blobDB) DeleteBlob(ctx context.Context, key string) error {
+	_, err := b.conn.NewDelete().
+		TableExpr("? AS ?", bun.Ident("blobs"), bun.Ident("blob")).
+		Where("? = ?", bun.Ident("blob.key"), key).
+		Exec(ctx)
+	return b.conn.ProcessError(err)
+}
+
+func (b *blobDB) ResetBlobRefs(ctx context.Context, blobs []*gtsmodel.Blob) error {
+	err := b.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		if _, err := tx.NewDelete().
+			Table("blobs").
+			Where("? IS NOT NULL", bun.Ident("key")).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		// Insert in batches to stay
+		// under query variable limits.
+		const batchSize = 100
+
+		for len(blobs) > 0 {
+			n := len(blobs)
+			if n > batchSize {
+				n = batchSize
+			}
+
+			batch := blobs[:n]
+			blobs = blobs[n:]
+
+			if _, err := tx.NewInsert().
+				Model(&batch).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return b.conn.ProcessError(err)
+}
+
+// getBlobRefs selects the reference count of blob
+// with key using given db, returning zero if not found.
+func getBlobRefs(ctx context.Context, idb bun.IDB, key string) (int, error) {
+	var refs int
+
+	if err := idb.NewSelect().
+		Table("blobs").
+		Column("refs").
+		Where("? = ?", bun.Ident("key"), key).
+		Scan(ctx, &refs); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return refs, nil
+}
@@ -61,16 +61,23 @@ var registerTables = []interface{}{
 type DBService struct {
 	db.Account
 	db.Admin
+	db.Application
+	db.AuditLog
 	db.Basic
+	db.Blob
 	db.Domain
 	db.Emoji
+	db.Export
+	db.Import
 	db.Instance
+	db.IPBlock
 	db.List
 	db.Media
 	db.Mention
 	db.Notification
 	db.Relationship
 	db.Report
+	db.Rule
 	db.Search
 	db.Session
 	db.Status
@@ -167,9 +174,19 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			conn:  conn,
 			state: state,
 		},
+		Application: &applicationDB{
+			conn: conn,
+		},
+		AuditLog: &auditLogDB{
+			conn:  conn,
+			state: state,
+		},
 		Basic: &basicDB{
 			conn: conn,
 		},
+		Blob: &blobDB{
+			conn: conn,
+		},
 		Domain: &domainDB{
 			conn:  conn,
 			state: state,
@@ -178,9 +195,19 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			conn:  conn,
 			state: state,
 		},
+		Export: &exportDB{
+			conn: conn,
+		},
+		Import: &importDB{
+			conn: conn,
+		},
 		Instance: &instanceDB{
 			conn: conn,
 		},
+		IPBlock: &ipBlockDB{
+			conn:  conn,
+			state: state,
+		},
 		List: &listDB{
 			conn:  conn,
 			state: state,
@@ -205,6 +232,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			conn:  conn,
 			state: state,
 		},
+		Rule: &ruleDB{
+			conn:  conn,
+			state: state,
+		},
 		Search: &searchDB{
 			conn:  conn,
 			state: state,
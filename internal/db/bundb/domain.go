@@ -157,3 +157,142 @@ func (d *domainDB) AreURIsBlocked(ctx context.Context, uris []*url.URL) (bool, d
 	}
 	return false, nil
 }
+
+func (d *domainDB) PutEmailDomainBlock(ctx context.Context, block *gtsmodel.EmailDomainBlock) db.Error {
+	return d.PutEmailDomainBlocks(ctx, []*gtsmodel.EmailDomainBlock{block})
+}
+
+func (d *domainDB) PutEmailDomainBlocks(ctx context.Context, blocks []*gtsmodel.EmailDomainBlock) db.Error {
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	// Normalize the domains as punycode
+	for _, block := range blocks {
+		var err error
+		block.Domain, err = util.Punify(block.Domain)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Insert all the blocks in one transaction,
+	// chunked to keep under parameter limits.
+	const chunkSz = 100
+	if err := d.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		for i := 0; i < len(blocks); i += chunkSz {
+			end := i + chunkSz
+			if end > len(blocks) {
+				end = len(blocks)
+			}
+
+			chunk := blocks[i:end]
+			if _, err := tx.NewInsert().
+				Model(&chunk).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return d.conn.ProcessError(err)
+	}
+
+	// Clear the email domain block cache (for later reload)
+	d.state.Caches.GTS.EmailDomainBlock().Clear()
+
+	return nil
+}
+
+func (d *domainDB) GetEmailDomainBlockByID(ctx context.Context, id string) (*gtsmodel.EmailDomainBlock, db.Error) {
+	var block gtsmodel.EmailDomainBlock
+
+	q := d.conn.
+		NewSelect().
+		Model(&block).
+		Relation("CreatedByAccount").
+		Where("? = ?", bun.Ident("email_domain_block.id"), id)
+	if err := q.Scan(ctx); err != nil {
+		return nil, d.conn.ProcessError(err)
+	}
+
+	return &block, nil
+}
+
+func (d *domainDB) GetEmailDomainBlock(ctx context.Context, domain string) (*gtsmodel.EmailDomainBlock, db.Error) {
+	// Normalize the domain as punycode
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	var block gtsmodel.EmailDomainBlock
+
+	q := d.conn.
+		NewSelect().
+		Model(&block).
+		Relation("CreatedByAccount").
+		Where("? = ?", bun.Ident("email_domain_block.domain"), domain).
+		Limit(1)
+	if err := q.Scan(ctx); err != nil {
+		return nil, d.conn.ProcessError(err)
+	}
+
+	return &block, nil
+}
+
+func (d *domainDB) GetEmailDomainBlocks(ctx context.Context) ([]*gtsmodel.EmailDomainBlock, db.Error) {
+	blocks := []*gtsmodel.EmailDomainBlock{}
+
+	q := d.conn.
+		NewSelect().
+		Model(&blocks).
+		Relation("CreatedByAccount").
+		Order("email_domain_block.domain ASC")
+	if err := q.Scan(ctx); err != nil {
+		return nil, d.conn.ProcessError(err)
+	}
+
+	return blocks, nil
+}
+
+func (d *domainDB) DeleteEmailDomainBlockByID(ctx context.Context, id string) db.Error {
+	if _, err := d.conn.NewDelete().
+		Model((*gtsmodel.EmailDomainBlock)(nil)).
+		Where("? = ?", bun.Ident("email_domain_block.id"), id).
+		Exec(ctx); err != nil {
+		return d.conn.ProcessError(err)
+	}
+
+	// Clear the email domain block cache (for later reload)
+	d.state.Caches.GTS.EmailDomainBlock().Clear()
+
+	return nil
+}
+
+func (d *domainDB) IsEmailDomainBlocked(ctx context.Context, domain string) (bool, db.Error) {
+	// Normalize the domain as punycode
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return false, err
+	}
+
+	if domain == "" {
+		return false, nil
+	}
+
+	// Check the cache for an email domain block (hydrating the cache with callback if necessary)
+	return d.state.Caches.GTS.EmailDomainBlock().IsBlocked(domain, func() ([]string, error) {
+		var domains []string
+
+		// Scan list of all blocked email domains from DB
+		q := d.conn.NewSelect().
+			Table("email_domain_blocks").
+			Column("domain")
+		if err := q.Scan(ctx, &domains); err != nil {
+			return nil, d.conn.ProcessError(err)
+		}
+
+		return domains, nil
+	})
+}
@@ -0,0 +1,117 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type exportDB struct {
+	conn *DBConn
+}
+
+func (e *exportDB) GetExportByID(ctx context.Context, id string) (*gtsmodel.Export, db.Error) {
+	export := &gtsmodel.Export{}
+
+	if err := e.conn.
+		NewSelect().
+		Model(export).
+		Where("? = ?", bun.Ident("export.id"), id).
+		Scan(ctx); err != nil {
+		return nil, e.conn.ProcessError(err)
+	}
+
+	return export, nil
+}
+
+func (e *exportDB) GetAccountExports(ctx context.Context, accountID string) ([]*gtsmodel.Export, db.Error) {
+	exports := []*gtsmodel.Export{}
+
+	if err := e.conn.
+		NewSelect().
+		Model(&exports).
+		Where("? = ?", bun.Ident("export.account_id"), accountID).
+		OrderExpr("? DESC", bun.Ident("export.id")).
+		Scan(ctx); err != nil {
+		return nil, e.conn.ProcessError(err)
+	}
+
+	return exports, nil
+}
+
+func (e *exportDB) GetExpiredExports(ctx context.Context, before time.Time) ([]*gtsmodel.Export, db.Error) {
+	exports := []*gtsmodel.Export{}
+
+	if err := e.conn.
+		NewSelect().
+		Model(&exports).
+		Where("? IS NOT NULL", bun.Ident("export.expires_at")).
+		Where("? <= ?", bun.Ident("export.expires_at"), before).
+		Scan(ctx); err != nil {
+		return nil, e.conn.ProcessError(err)
+	}
+
+	return exports, nil
+}
+
+func (e *exportDB) PutExport(ctx context.Context, export *gtsmodel.Export) db.Error {
+	if _, err := e.conn.
+		NewInsert().
+		Model(export).
+		Exec(ctx); err != nil {
+		return e.conn.ProcessError(err)
+	}
+
+	return nil
+}
+
+func (e *exportDB) UpdateExport(ctx context.Context, export *gtsmodel.Export, columns ...string) db.Error {
+	// Update the export's last-updated
+	export.UpdatedAt = time.Now()
+	if len(columns) != 0 {
+		columns = append(columns, "updated_at")
+	}
+
+	if _, err := e.conn.
+		NewUpdate().
+		Model(export).
+		Where("? = ?", bun.Ident("export.id"), export.ID).
+		Column(columns...).
+		Exec(ctx); err != nil {
+		return e.conn.ProcessError(err)
+	}
+
+	return nil
+}
+
+func (e *exportDB) DeleteExportByID(ctx context.Context, id string) db.Error {
+	if _, err := e.conn.
+		NewDelete().
+		Model((*gtsmodel.Export)(nil)).
+		Where("? = ?", bun.Ident("export.id"), id).
+		Exec(ctx); err != nil {
+		return e.conn.ProcessError(err)
+	}
+
+	return nil
+}
@@ -24,6 +24,7 @@ import (
 	"codeberg.org/gruf/go-kv"
 	"codeberg.org/gruf/go-logger/v2/level"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/metrics"
 	"github.com/uptrace/bun"
 )
 
@@ -39,6 +40,9 @@ func (queryHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
 	// Get the DB query duration
 	dur := time.Since(event.StartTime)
 
+	// Record query duration metrics
+	metrics.ObserveDBQuery(event.Operation(), dur)
+
 	switch {
 	// Warn on slow database queries
 	case dur > time.Second:
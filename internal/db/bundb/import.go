@@ -0,0 +1,102 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type importDB struct {
+	conn *DBConn
+}
+
+func (i *importDB) GetImportByID(ctx context.Context, id string) (*gtsmodel.Import, db.Error) {
+	imp := &gtsmodel.Import{}
+
+	if err := i.conn.
+		NewSelect().
+		Model(imp).
+		Where("? = ?", bun.Ident("import.id"), id).
+		Scan(ctx); err != nil {
+		return nil, i.conn.ProcessError(err)
+	}
+
+	return imp, nil
+}
+
+func (i *importDB) GetAccountImports(ctx context.Context, accountID string) ([]*gtsmodel.Import, db.Error) {
+	imports := []*gtsmodel.Import{}
+
+	if err := i.conn.
+		NewSelect().
+		Model(&imports).
+		Where("? = ?", bun.Ident("import.account_id"), accountID).
+		OrderExpr("? DESC", bun.Ident("import.id")).
+		Scan(ctx); err != nil {
+		return nil, i.conn.ProcessError(err)
+	}
+
+	return imports, nil
+}
+
+func (i *importDB) PutImport(ctx context.Context, imp *gtsmodel.Import) db.Error {
+	if _, err := i.conn.
+		NewInsert().
+		Model(imp).
+		Exec(ctx); err != nil {
+		return i.conn.ProcessError(err)
+	}
+
+	return nil
+}
+
+func (i *importDB) UpdateImport(ctx context.Context, imp *gtsmodel.Import, columns ...string) db.Error {
+	// Update the import's last-updated
+	imp.UpdatedAt = time.Now()
+	if len(columns) != 0 {
+		columns = append(columns, "updated_at")
+	}
+
+	if _, err := i.conn.
+		NewUpdate().
+		Model(imp).
+		Where("? = ?", bun.Ident("import.id"), imp.ID).
+		Column(columns...).
+		Exec(ctx); err != nil {
+		return i.conn.ProcessError(err)
+	}
+
+	return nil
+}
+
+func (i *importDB) DeleteAccountImports(ctx context.Context, accountID string) db.Error {
+	if _, err := i.conn.
+		NewDelete().
+		Model((*gtsmodel.Import)(nil)).
+		Where("? = ?", bun.Ident("import.account_id"), accountID).
+		Exec(ctx); err != nil {
+		return i.conn.ProcessError(err)
+	}
+
+	return nil
+}
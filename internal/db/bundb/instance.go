@@ -19,6 +19,7 @@ package bundb
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -188,3 +189,117 @@ func (i *instanceDB) GetInstanceModeratorAddresses(ctx context.Context) ([]strin
 
 	return addresses, nil
 }
+
+func (i *instanceDB) GetInstanceModerators(ctx context.Context) ([]*gtsmodel.User, db.Error) {
+	users := []*gtsmodel.User{}
+
+	// Select approved, confirmed,
+	// and enabled moderators or admins.
+
+	q := i.conn.
+		NewSelect().
+		Model(&users).
+		Where("? = ?", bun.Ident("user.approved"), true).
+		Where("? IS NOT NULL", bun.Ident("user.confirmed_at")).
+		Where("? = ?", bun.Ident("user.disabled"), false).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? = ?", bun.Ident("user.moderator"), true).
+				WhereOr("? = ?", bun.Ident("user.admin"), true)
+		}).
+		OrderExpr("? ASC", bun.Ident("user.id"))
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, i.conn.ProcessError(err)
+	}
+
+	if len(users) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	return users, nil
+}
+
+func (i *instanceDB) CountInstanceActivity(ctx context.Context, domain string, start time.Time, end time.Time) (*gtsmodel.InstanceActivity, db.Error) {
+	var (
+		activity = &gtsmodel.InstanceActivity{}
+		err      error
+	)
+
+	// Count accounts first seen.
+	if activity.Accounts, err = whereBetween(i.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
+		Where("? = ?", bun.Ident("account.domain"), domain),
+		"account.created_at", start, end,
+	).Count(ctx); err != nil {
+		return nil, i.conn.ProcessError(err)
+	}
+
+	// Count statuses posted.
+	if activity.Statuses, err = whereBetween(i.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Join("JOIN ? AS ? ON ? = ?", bun.Ident("accounts"), bun.Ident("account"), bun.Ident("account.id"), bun.Ident("status.account_id")).
+		Where("? = ?", bun.Ident("account.domain"), domain),
+		"status.created_at", start, end,
+	).Count(ctx); err != nil {
+		return nil, i.conn.ProcessError(err)
+	}
+
+	// Count reports targeting accounts on the instance.
+	if activity.Reports, err = whereBetween(i.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("reports"), bun.Ident("report")).
+		Join("JOIN ? AS ? ON ? = ?", bun.Ident("accounts"), bun.Ident("account"), bun.Ident("account.id"), bun.Ident("report.target_account_id")).
+		Where("? = ?", bun.Ident("account.domain"), domain),
+		"report.created_at", start, end,
+	).Count(ctx); err != nil {
+		return nil, i.conn.ProcessError(err)
+	}
+
+	// Sum storage used by cached media.
+	if err := whereBetween(i.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("media_attachments"), bun.Ident("media_attachment")).
+		ColumnExpr("COALESCE(SUM(? + ? + COALESCE(?, 0)), 0)",
+			bun.Ident("media_attachment.file_file_size"),
+			bun.Ident("media_attachment.thumbnail_file_size"),
+			bun.Ident("media_attachment.file_source_file_size"),
+		).
+		Join("JOIN ? AS ? ON ? = ?", bun.Ident("accounts"), bun.Ident("account"), bun.Ident("account.id"), bun.Ident("media_attachment.account_id")).
+		Where("? = ?", bun.Ident("account.domain"), domain).
+		Where("? = ?", bun.Ident("media_attachment.cached"), true),
+		"media_attachment.created_at", start, end,
+	).Scan(ctx, &activity.MediaAttachments); err != nil {
+		return nil, i.conn.ProcessError(err)
+	}
+
+	return activity, nil
+}
+
+func (i *instanceDB) GetTopInstances(ctx context.Context, start time.Time, end time.Time, limit int) ([]*gtsmodel.ActivityCount, db.Error) {
+	counts := []*gtsmodel.ActivityCount{}
+
+	q := whereBetween(i.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		ColumnExpr("? AS ?", bun.Ident("account.domain"), bun.Ident("key")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		Join("JOIN ? AS ? ON ? = ?", bun.Ident("accounts"), bun.Ident("account"), bun.Ident("account.id"), bun.Ident("status.account_id")).
+		Where("? IS NOT NULL", bun.Ident("account.domain")),
+		"status.created_at", start, end,
+	).
+		Group("account.domain").
+		OrderExpr("? DESC, ? ASC", bun.Ident("count"), bun.Ident("key"))
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &counts); err != nil {
+		return nil, i.conn.ProcessError(err)
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,170 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"net/netip"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type ipBlockDB struct {
+	conn  *DBConn
+	state *state.State
+}
+
+func (i *ipBlockDB) GetIPBlockByID(ctx context.Context, id string) (*gtsmodel.IPBlock, db.Error) {
+	return i.getIPBlock(ctx, "id", id)
+}
+
+func (i *ipBlockDB) GetIPBlockByIP(ctx context.Context, ip string) (*gtsmodel.IPBlock, db.Error) {
+	return i.getIPBlock(ctx, "ip", ip)
+}
+
+func (i *ipBlockDB) getIPBlock(ctx context.Context, column string, value string) (*gtsmodel.IPBlock, db.Error) {
+	block := &gtsmodel.IPBlock{}
+
+	if err := i.conn.
+		NewSelect().
+		Model(block).
+		Relation("CreatedByAccount").
+		Where("? = ?", bun.Ident("ip_block."+column), value).
+		Scan(ctx); err != nil {
+		return nil, i.conn.ProcessError(err)
+	}
+
+	return block, nil
+}
+
+func (i *ipBlockDB) GetIPBlocks(ctx context.Context) ([]*gtsmodel.IPBlock, db.Error) {
+	blocks := []*gtsmodel.IPBlock{}
+
+	if err := i.conn.
+		NewSelect().
+		Model(&blocks).
+		Relation("CreatedByAccount").
+		OrderExpr("? ASC", bun.Ident("ip_block.ip")).
+		Scan(ctx); err != nil {
+		return nil, i.conn.ProcessError(err)
+	}
+
+	return blocks, nil
+}
+
+func (i *ipBlockDB) PutIPBlock(ctx context.Context, block *gtsmodel.IPBlock) db.Error {
+	if _, err := i.conn.
+		NewInsert().
+		Model(block).
+		Exec(ctx); err != nil {
+		return i.conn.ProcessError(err)
+	}
+
+	// Clear the IP block cache (for later reload)
+	i.state.Caches.GTS.IPBlock().Clear()
+
+	return nil
+}
+
+func (i *ipBlockDB) UpdateIPBlock(ctx context.Context, block *gtsmodel.IPBlock, columns ...string) db.Error {
+	// Update the block's last-updated
+	block.UpdatedAt = time.Now()
+	if len(columns) != 0 {
+		columns = append(columns, "updated_at")
+	}
+
+	if _, err := i.conn.
+		NewUpdate().
+		Model(block).
+		Where("? = ?", bun.Ident("ip_block.id"), block.ID).
+		Column(columns...).
+		Exec(ctx); err != nil {
+		return i.conn.ProcessError(err)
+	}
+
+	// Clear the IP block cache (for later reload)
+	i.state.Caches.GTS.IPBlock().Clear()
+
+	return nil
+}
+
+func (i *ipBlockDB) DeleteIPBlockByID(ctx context.Context, id string) db.Error {
+	if _, err := i.conn.
+		NewDelete().
+		Model((*gtsmodel.IPBlock)(nil)).
+		Where("? = ?", bun.Ident("ip_block.id"), id).
+		Exec(ctx); err != nil {
+		return i.conn.ProcessError(err)
+	}
+
+	// Clear the IP block cache (for later reload)
+	i.state.Caches.GTS.IPBlock().Clear()
+
+	return nil
+}
+
+func (i *ipBlockDB) DeleteExpiredIPBlocks(ctx context.Context, before time.Time) (int, db.Error) {
+	res, err := i.conn.
+		NewDelete().
+		Model((*gtsmodel.IPBlock)(nil)).
+		Where("? IS NOT NULL", bun.Ident("ip_block.expires_at")).
+		Where("? <= ?", bun.Ident("ip_block.expires_at"), before).
+		Exec(ctx)
+	if err != nil {
+		return 0, i.conn.ProcessError(err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, i.conn.ProcessError(err)
+	}
+
+	if deleted > 0 {
+		// Clear the IP block cache (for later reload)
+		i.state.Caches.GTS.IPBlock().Clear()
+	}
+
+	return int(deleted), nil
+}
+
+func (i *ipBlockDB) GetIPBlockSeverity(ctx context.Context, addr netip.Addr) (gtsmodel.IPBlockSeverity, db.Error) {
+	// Check the cache for a matching block (hydrating the cache with callback if necessary)
+	return i.state.Caches.GTS.IPBlock().Severity(addr, func() ([]*gtsmodel.IPBlock, error) {
+		var blocks []*gtsmodel.IPBlock
+
+		// Scan list of all unexpired blocks from DB; blocks
+		// expiring later are skipped by the cache itself.
+		q := i.conn.NewSelect().
+			Model(&blocks).
+			Column("ip", "severity", "expires_at").
+			WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.
+					Where("? IS NULL", bun.Ident("ip_block.expires_at")).
+					WhereOr("? > ?", bun.Ident("ip_block.expires_at"), time.Now())
+			})
+		if err := q.Scan(ctx); err != nil {
+			return nil, i.conn.ProcessError(err)
+		}
+
+		return blocks, nil
+	})
+}
@@ -272,6 +272,26 @@ func (m *mediaDB) CountRemoteOlderThan(ctx context.Context, olderThan time.Time)
 	return count, nil
 }
 
+func (m *mediaDB) GetAccountMediaUsage(ctx context.Context, accountID string) (int64, error) {
+	var usage int64
+
+	if err := m.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("media_attachments"), bun.Ident("media_attachment")).
+		ColumnExpr("COALESCE(SUM(? + ? + COALESCE(?, 0)), 0)",
+			bun.Ident("media_attachment.file_file_size"),
+			bun.Ident("media_attachment.thumbnail_file_size"),
+			bun.Ident("media_attachment.file_source_file_size"),
+		).
+		Where("? = ?", bun.Ident("media_attachment.account_id"), accountID).
+		Where("? = ?", bun.Ident("media_attachment.cached"), true).
+		Scan(ctx, &usage); err != nil {
+		return 0, m.conn.ProcessError(err)
+	}
+
+	return usage, nil
+}
+
 func (m *mediaDB) GetAttachments(ctx context.Context, maxID string, limit int) ([]*gtsmodel.MediaAttachment, error) {
 	attachmentIDs := []string{}
 
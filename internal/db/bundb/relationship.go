@@ -85,6 +85,17 @@ func (r *relationshipDB) GetRelationship(ctx context.Context, requestingAccount
 		return nil, fmt.Errorf("GetRelationship: error checking blockedBy: %w", err)
 	}
 
+	// check if the requesting account is muting the target account
+	mute, err := r.GetMute(gtscontext.SetBarebones(ctx), requestingAccount, targetAccount)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, fmt.Errorf("GetRelationship: error checking muting: %w", err)
+	}
+
+	if mute != nil {
+		rel.Muting = true
+		rel.MutingNotifications = *mute.Notifications
+	}
+
 	return &rel, nil
 }
 
@@ -0,0 +1,221 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+func (r *relationshipDB) IsMuted(ctx context.Context, sourceAccountID string, targetAccountID string) (bool, error) {
+	mute, err := r.GetMute(
+		gtscontext.SetBarebones(ctx),
+		sourceAccountID,
+		targetAccountID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, err
+	}
+	return (mute != nil), nil
+}
+
+func (r *relationshipDB) GetMuteByID(ctx context.Context, id string) (*gtsmodel.UserMute, error) {
+	return r.getMute(
+		ctx,
+		"ID",
+		func(mute *gtsmodel.UserMute) error {
+			return r.conn.NewSelect().Model(mute).
+				Where("? = ?", bun.Ident("user_mute.id"), id).
+				Scan(ctx)
+		},
+		id,
+	)
+}
+
+func (r *relationshipDB) GetMute(ctx context.Context, sourceAccountID string, targetAccountID string) (*gtsmodel.UserMute, error) {
+	return r.getMute(
+		ctx,
+		"AccountID.TargetAccountID",
+		func(mute *gtsmodel.UserMute) error {
+			return r.conn.NewSelect().Model(mute).
+				Where("? = ?", bun.Ident("user_mute.account_id"), sourceAccountID).
+				Where("? = ?", bun.Ident("user_mute.target_account_id"), targetAccountID).
+				Scan(ctx)
+		},
+		sourceAccountID,
+		targetAccountID,
+	)
+}
+
+func (r *relationshipDB) GetAccountMutes(ctx context.Context, accountID string) ([]*gtsmodel.UserMute, error) {
+	var muteIDs []string
+
+	if err := r.conn.NewSelect().
+		Table("user_mutes").
+		Column("id").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		OrderExpr("? DESC", bun.Ident("id")).
+		Scan(ctx, &muteIDs); err != nil {
+		return nil, r.conn.ProcessError(err)
+	}
+
+	if len(muteIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	mutes := make([]*gtsmodel.UserMute, 0, len(muteIDs))
+	for _, id := range muteIDs {
+		mute, err := r.GetMuteByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		mutes = append(mutes, mute)
+	}
+
+	return mutes, nil
+}
+
+func (r *relationshipDB) getMute(ctx context.Context, lookup string, dbQuery func(*gtsmodel.UserMute) error, keyParts ...any) (*gtsmodel.UserMute, error) {
+	// Fetch mute from cache with loader callback
+	mute, err := r.state.Caches.GTS.UserMute().Load(lookup, func() (*gtsmodel.UserMute, error) {
+		var mute gtsmodel.UserMute
+
+		// Not cached! Perform database query
+		if err := dbQuery(&mute); err != nil {
+			return nil, r.conn.ProcessError(err)
+		}
+
+		return &mute, nil
+	}, keyParts...)
+	if err != nil {
+		// already processed
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// Only a barebones model was requested.
+		return mute, nil
+	}
+
+	// Set the mute target account
+	mute.TargetAccount, err = r.state.DB.GetAccountByID(
+		gtscontext.SetBarebones(ctx),
+		mute.TargetAccountID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error getting mute target account: %w", err)
+	}
+
+	return mute, nil
+}
+
+func (r *relationshipDB) PutMute(ctx context.Context, mute *gtsmodel.UserMute) error {
+	return r.state.Caches.GTS.UserMute().Store(mute, func() error {
+		_, err := r.conn.NewInsert().Model(mute).Exec(ctx)
+		return r.conn.ProcessError(err)
+	})
+}
+
+func (r *relationshipDB) UpdateMute(ctx context.Context, mute *gtsmodel.UserMute, columns ...string) error {
+	mute.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column, ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	return r.state.Caches.GTS.UserMute().Store(mute, func() error {
+		_, err := r.conn.NewUpdate().
+			Model(mute).
+			Where("? = ?", bun.Ident("user_mute.id"), mute.ID).
+			Column(columns...).
+			Exec(ctx)
+		return r.conn.ProcessError(err)
+	})
+}
+
+func (r *relationshipDB) DeleteMute(ctx context.Context, mute *gtsmodel.UserMute) error {
+	defer r.state.Caches.GTS.UserMute().Invalidate("ID", mute.ID)
+
+	// Load mute into cache before attempting a delete,
+	// as we need it cached in order to trigger the invalidate
+	// callback. This in turn invalidates others.
+	_, err := r.GetMuteByID(gtscontext.SetBarebones(ctx), mute.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// not an issue.
+			err = nil
+		}
+		return err
+	}
+
+	// Finally delete mute from DB.
+	_, err = r.conn.NewDelete().
+		Table("user_mutes").
+		Where("? = ?", bun.Ident("id"), mute.ID).
+		Exec(ctx)
+	return r.conn.ProcessError(err)
+}
+
+func (r *relationshipDB) DeleteAccountMutes(ctx context.Context, accountID string) error {
+	var muteIDs []string
+
+	// Get full list of IDs.
+	if err := r.conn.NewSelect().
+		Column("id").
+		Table("user_mutes").
+		WhereOr("? = ? OR ? = ?",
+			bun.Ident("account_id"),
+			accountID,
+			bun.Ident("target_account_id"),
+			accountID,
+		).
+		Scan(ctx, &muteIDs); err != nil {
+		return r.conn.ProcessError(err)
+	}
+
+	defer func() {
+		// Invalidate all IDs on return.
+		for _, id := range muteIDs {
+			r.state.Caches.GTS.UserMute().Invalidate("ID", id)
+		}
+	}()
+
+	// Load all mutes into cache, so that the invalidate
+	// callbacks are triggered for related caches (e.g.
+	// visibility) once they have been deleted.
+	for _, id := range muteIDs {
+		_, err := r.GetMuteByID(gtscontext.SetBarebones(ctx), id)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return err
+		}
+	}
+
+	// Finally delete all from DB.
+	_, err := r.conn.NewDelete().
+		Table("user_mutes").
+		Where("? IN (?)", bun.Ident("id"), bun.In(muteIDs)).
+		Exec(ctx)
+	return r.conn.ProcessError(err)
+}
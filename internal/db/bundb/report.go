@@ -155,6 +155,22 @@ func (r *reportDB) getReport(ctx context.Context, lookup string, dbQuery func(*g
 		}
 	}
 
+	if len(report.RuleIDs) > 0 {
+		// Fetch rules cited by the report
+		report.Rules, err = r.state.DB.GetRulesByIDs(ctx, report.RuleIDs)
+		if err != nil {
+			return nil, fmt.Errorf("error getting report rules: %w", err)
+		}
+	}
+
+	if report.AssignedAccountID != "" {
+		// Set the report assigned account
+		report.AssignedAccount, err = r.state.DB.GetAccountByID(ctx, report.AssignedAccountID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting report assigned account: %w", err)
+		}
+	}
+
 	if report.ActionTakenByAccountID != "" {
 		// Set the report action taken by account
 		report.ActionTakenByAccount, err = r.state.DB.GetAccountByID(ctx, report.ActionTakenByAccountID)
@@ -208,6 +224,14 @@ func (r *reportDB) DeleteReportByID(ctx context.Context, id string) db.Error {
 		return err
 	}
 
+	// Delete any notes left on the report.
+	if _, err := r.conn.NewDelete().
+		TableExpr("? AS ?", bun.Ident("report_notes"), bun.Ident("report_note")).
+		Where("? = ?", bun.Ident("report_note.report_id"), id).
+		Exec(ctx); err != nil {
+		return r.conn.ProcessError(err)
+	}
+
 	// Finally delete report from DB.
 	_, err = r.conn.NewDelete().
 		TableExpr("? AS ?", bun.Ident("reports"), bun.Ident("report")).
@@ -215,3 +239,71 @@ func (r *reportDB) DeleteReportByID(ctx context.Context, id string) db.Error {
 		Exec(ctx)
 	return r.conn.ProcessError(err)
 }
+
+func (r *reportDB) GetReportNoteByID(ctx context.Context, id string) (*gtsmodel.ReportNote, db.Error) {
+	note := &gtsmodel.ReportNote{}
+
+	if err := r.conn.
+		NewSelect().
+		Model(note).
+		Where("? = ?", bun.Ident("report_note.id"), id).
+		Scan(ctx); err != nil {
+		return nil, r.conn.ProcessError(err)
+	}
+
+	if err := r.populateReportNote(ctx, note); err != nil {
+		return nil, err
+	}
+
+	return note, nil
+}
+
+func (r *reportDB) GetReportNotes(ctx context.Context, reportID string) ([]*gtsmodel.ReportNote, db.Error) {
+	notes := []*gtsmodel.ReportNote{}
+
+	if err := r.conn.
+		NewSelect().
+		Model(&notes).
+		Where("? = ?", bun.Ident("report_note.report_id"), reportID).
+		Order("report_note.id ASC").
+		Scan(ctx); err != nil {
+		return nil, r.conn.ProcessError(err)
+	}
+
+	for _, note := range notes {
+		if err := r.populateReportNote(ctx, note); err != nil {
+			return nil, err
+		}
+	}
+
+	return notes, nil
+}
+
+func (r *reportDB) populateReportNote(ctx context.Context, note *gtsmodel.ReportNote) error {
+	var err error
+
+	// Set the note author account
+	note.Account, err = r.state.DB.GetAccountByID(ctx, note.AccountID)
+	if err != nil {
+		return fmt.Errorf("error getting report note account: %w", err)
+	}
+
+	return nil
+}
+
+func (r *reportDB) PutReportNote(ctx context.Context, note *gtsmodel.ReportNote) db.Error {
+	_, err := r.conn.
+		NewInsert().
+		Model(note).
+		Exec(ctx)
+	return r.conn.ProcessError(err)
+}
+
+func (r *reportDB) DeleteReportNoteByID(ctx context.Context, id string) db.Error {
+	_, err := r.conn.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("report_notes"), bun.Ident("report_note")).
+		Where("? = ?", bun.Ident("report_note.id"), id).
+		Exec(ctx)
+	return r.conn.ProcessError(err)
+}
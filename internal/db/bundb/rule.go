@@ -0,0 +1,105 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type ruleDB struct {
+	conn  *DBConn
+	state *state.State
+}
+
+func (r *ruleDB) GetRuleByID(ctx context.Context, id string) (*gtsmodel.Rule, db.Error) {
+	rule := &gtsmodel.Rule{}
+
+	if err := r.conn.
+		NewSelect().
+		Model(rule).
+		Where("? = ?", bun.Ident("rule.id"), id).
+		Scan(ctx); err != nil {
+		return nil, r.conn.ProcessError(err)
+	}
+
+	return rule, nil
+}
+
+func (r *ruleDB) GetRulesByIDs(ctx context.Context, ids []string) ([]*gtsmodel.Rule, db.Error) {
+	rules := []*gtsmodel.Rule{}
+
+	if len(ids) == 0 {
+		return rules, nil
+	}
+
+	if err := r.conn.
+		NewSelect().
+		Model(&rules).
+		Where("? IN (?)", bun.Ident("rule.id"), bun.In(ids)).
+		OrderExpr("? ASC, ? ASC", bun.Ident("rule.order"), bun.Ident("rule.id")).
+		Scan(ctx); err != nil {
+		return nil, r.conn.ProcessError(err)
+	}
+
+	return rules, nil
+}
+
+func (r *ruleDB) GetActiveRules(ctx context.Context) ([]*gtsmodel.Rule, db.Error) {
+	rules := []*gtsmodel.Rule{}
+
+	if err := r.conn.
+		NewSelect().
+		Model(&rules).
+		Where("? = ?", bun.Ident("rule.deleted"), false).
+		OrderExpr("? ASC, ? ASC", bun.Ident("rule.order"), bun.Ident("rule.id")).
+		Scan(ctx); err != nil {
+		return nil, r.conn.ProcessError(err)
+	}
+
+	return rules, nil
+}
+
+func (r *ruleDB) PutRule(ctx context.Context, rule *gtsmodel.Rule) db.Error {
+	_, err := r.conn.
+		NewInsert().
+		Model(rule).
+		Exec(ctx)
+	return r.conn.ProcessError(err)
+}
+
+func (r *ruleDB) UpdateRule(ctx context.Context, rule *gtsmodel.Rule, columns ...string) db.Error {
+	// Update the rule's last-updated
+	rule.UpdatedAt = time.Now()
+	if len(columns) != 0 {
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := r.conn.
+		NewUpdate().
+		Model(rule).
+		Where("? = ?", bun.Ident("rule.id"), rule.ID).
+		Column(columns...).
+		Exec(ctx)
+	return r.conn.ProcessError(err)
+}
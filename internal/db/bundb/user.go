@@ -124,6 +124,52 @@ func (u *userDB) GetUserByConfirmationToken(ctx context.Context, confirmationTok
 	}, confirmationToken)
 }
 
+func (u *userDB) GetUserByUnsubscribeToken(ctx context.Context, unsubscribeToken string) (*gtsmodel.User, db.Error) {
+	return u.state.Caches.GTS.User().Load("UnsubscribeToken", func() (*gtsmodel.User, error) {
+		var user gtsmodel.User
+
+		q := u.conn.
+			NewSelect().
+			Model(&user).
+			Relation("Account").
+			Where("? = ?", bun.Ident("user.unsubscribe_token"), unsubscribeToken)
+
+		if err := q.Scan(ctx); err != nil {
+			return nil, u.conn.ProcessError(err)
+		}
+
+		return &user, nil
+	}, unsubscribeToken)
+}
+
+func (u *userDB) GetUsersForEmailDigest(ctx context.Context) ([]*gtsmodel.User, db.Error) {
+	var users []*gtsmodel.User
+	q := u.conn.
+		NewSelect().
+		Model(&users).
+		Relation("Account").
+		Where("? IS NOT NULL", bun.Ident("user.email")).
+		Where("? IS NOT NULL", bun.Ident("user.confirmed_at")).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			for _, column := range []string{
+				"user.email_mentions",
+				"user.email_direct",
+				"user.email_follows",
+				"user.email_follow_requests",
+				"user.email_reports_closed",
+			} {
+				q = q.WhereOr("? = ?", bun.Ident(column), gtsmodel.EmailPreferenceDigest)
+			}
+			return q
+		})
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, u.conn.ProcessError(err)
+	}
+
+	return users, nil
+}
+
 func (u *userDB) GetAllUsers(ctx context.Context) ([]*gtsmodel.User, db.Error) {
 	var users []*gtsmodel.User
 	q := u.conn.
@@ -32,16 +32,23 @@ const (
 type DB interface {
 	Account
 	Admin
+	Application
+	AuditLog
 	Basic
+	Blob
 	Domain
 	Emoji
+	Export
+	Import
 	Instance
+	IPBlock
 	List
 	Media
 	Mention
 	Notification
 	Relationship
 	Report
+	Rule
 	Search
 	Session
 	Status
@@ -46,4 +46,26 @@ type Domain interface {
 
 	// AreURIsBlocked checks if an instance-level domain block exists for any `host` in the given URI slice, and returns true if even one is found.
 	AreURIsBlocked(ctx context.Context, uris []*url.URL) (bool, Error)
+
+	// PutEmailDomainBlock stores the given email domain block.
+	PutEmailDomainBlock(ctx context.Context, block *gtsmodel.EmailDomainBlock) Error
+
+	// PutEmailDomainBlocks stores all of the given email domain blocks in a single transaction.
+	PutEmailDomainBlocks(ctx context.Context, blocks []*gtsmodel.EmailDomainBlock) Error
+
+	// GetEmailDomainBlockByID returns the email domain block with the given ID.
+	GetEmailDomainBlockByID(ctx context.Context, id string) (*gtsmodel.EmailDomainBlock, Error)
+
+	// GetEmailDomainBlock returns the email domain block exactly matching the given domain.
+	GetEmailDomainBlock(ctx context.Context, domain string) (*gtsmodel.EmailDomainBlock, Error)
+
+	// GetEmailDomainBlocks returns all email domain blocks, sorted by domain.
+	GetEmailDomainBlocks(ctx context.Context) ([]*gtsmodel.EmailDomainBlock, Error)
+
+	// DeleteEmailDomainBlockByID deletes the email domain block with the given ID.
+	DeleteEmailDomainBlockByID(ctx context.Context, id string) Error
+
+	// IsEmailDomainBlocked checks if an email domain block exists for the given domain,
+	// or for any parent domain of it (eg., `mail.example.org` is blocked by `example.org`).
+	IsEmailDomainBlocked(ctx context.Context, domain string) (bool, Error)
 }
@@ -0,0 +1,50 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Export handles getting/creation/deletion/updating of account data exports.
+type Export interface {
+	// GetExportByID gets one export by its db id.
+	GetExportByID(ctx context.Context, id string) (*gtsmodel.Export, Error)
+
+	// GetAccountExports gets all exports of the given account, newest first.
+	GetAccountExports(ctx context.Context, accountID string) ([]*gtsmodel.Export, Error)
+
+	// GetExpiredExports gets all exports which expired before the given time.
+	GetExpiredExports(ctx context.Context, before time.Time) ([]*gtsmodel.Export, Error)
+
+	// PutExport puts the given export in the database.
+	PutExport(ctx context.Context, export *gtsmodel.Export) Error
+
+	// UpdateExport updates one export by its db id.
+	// The given columns will be updated; if no columns are
+	// provided, then all columns will be updated.
+	// updated_at will also be updated, no need to pass this
+	// as a specific column.
+	UpdateExport(ctx context.Context, export *gtsmodel.Export, columns ...string) Error
+
+	// DeleteExportByID deletes one export by its db id.
+	DeleteExportByID(ctx context.Context, id string) Error
+}
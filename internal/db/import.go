@@ -0,0 +1,46 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Import handles getting/creation/deletion/updating of imports of account data.
+type Import interface {
+	// GetImportByID gets one import by its db id.
+	GetImportByID(ctx context.Context, id string) (*gtsmodel.Import, Error)
+
+	// GetAccountImports gets all imports into the given account, newest first.
+	GetAccountImports(ctx context.Context, accountID string) ([]*gtsmodel.Import, Error)
+
+	// PutImport puts the given import in the database.
+	PutImport(ctx context.Context, imp *gtsmodel.Import) Error
+
+	// UpdateImport updates one import by its db id.
+	// The given columns will be updated; if no columns are
+	// provided, then all columns will be updated.
+	// updated_at will also be updated, no need to pass this
+	// as a specific column.
+	UpdateImport(ctx context.Context, imp *gtsmodel.Import, columns ...string) Error
+
+	// DeleteAccountImports deletes all imports into the given account.
+	DeleteAccountImports(ctx context.Context, accountID string) Error
+}
@@ -19,6 +19,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
@@ -46,4 +47,15 @@ type Instance interface {
 	// GetInstanceModeratorAddresses returns a slice of email addresses belonging to active
 	// (as in, not suspended) moderators + admins on this instance.
 	GetInstanceModeratorAddresses(ctx context.Context) ([]string, Error)
+
+	// GetInstanceModerators returns a slice of users who are active (as in, approved,
+	// confirmed, and not disabled) moderators + admins on this instance.
+	GetInstanceModerators(ctx context.Context) ([]*gtsmodel.User, Error)
+
+	// CountInstanceActivity counts activity originating from the given domain between start and end.
+	CountInstanceActivity(ctx context.Context, domain string, start time.Time, end time.Time) (*gtsmodel.InstanceActivity, Error)
+
+	// GetTopInstances returns the domains of the remote instances that
+	// posted the most statuses between start and end, most active first.
+	GetTopInstances(ctx context.Context, start time.Time, end time.Time, limit int) ([]*gtsmodel.ActivityCount, Error)
 }
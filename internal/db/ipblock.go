@@ -0,0 +1,59 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"net/netip"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// IPBlock handles getting/creation/deletion/updating of IP blocks.
+type IPBlock interface {
+	// GetIPBlockByID gets one IP block by its db id.
+	GetIPBlockByID(ctx context.Context, id string) (*gtsmodel.IPBlock, Error)
+
+	// GetIPBlockByIP gets the IP block for exactly the given range, in CIDR notation.
+	GetIPBlockByIP(ctx context.Context, ip string) (*gtsmodel.IPBlock, Error)
+
+	// GetIPBlocks gets all IP blocks, including expired ones, sorted by range.
+	GetIPBlocks(ctx context.Context) ([]*gtsmodel.IPBlock, Error)
+
+	// PutIPBlock puts the given IP block in the database.
+	PutIPBlock(ctx context.Context, block *gtsmodel.IPBlock) Error
+
+	// UpdateIPBlock updates one IP block by its db id.
+	// The given columns will be updated; if no columns are
+	// provided, then all columns will be updated.
+	// updated_at will also be updated, no need to pass this
+	// as a specific column.
+	UpdateIPBlock(ctx context.Context, block *gtsmodel.IPBlock, columns ...string) Error
+
+	// DeleteIPBlockByID deletes one IP block by its db id.
+	DeleteIPBlockByID(ctx context.Context, id string) Error
+
+	// DeleteExpiredIPBlocks deletes all IP blocks which expired before the given time,
+	// returning the number deleted.
+	DeleteExpiredIPBlocks(ctx context.Context, before time.Time) (int, Error)
+
+	// GetIPBlockSeverity returns the highest severity of any unexpired IP block
+	// containing the given address, or an empty severity if it isn't blocked.
+	GetIPBlockSeverity(ctx context.Context, addr netip.Addr) (gtsmodel.IPBlockSeverity, Error)
+}
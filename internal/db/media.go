@@ -70,4 +70,8 @@ type Media interface {
 	// CountLocalUnattachedOlderThan is like GetLocalUnattachedOlderThan, except instead of getting limit n attachments,
 	// it just counts how many local attachments in the database meet the olderThan criteria.
 	CountLocalUnattachedOlderThan(ctx context.Context, olderThan time.Time) (int, Error)
+
+	// GetAccountMediaUsage returns the total size in bytes of all media files
+	// currently stored for the account with given ID, including thumbnails.
+	GetAccountMediaUsage(ctx context.Context, accountID string) (int64, error)
 }
@@ -52,6 +52,30 @@ type Relationship interface {
 	// DeleteAccountBlocks will delete all database blocks to / from the given account ID.
 	DeleteAccountBlocks(ctx context.Context, accountID string) error
 
+	// IsMuted checks whether source account has muted target account.
+	IsMuted(ctx context.Context, sourceAccountID string, targetAccountID string) (bool, error)
+
+	// GetMuteByID fetches mute with given ID from the database.
+	GetMuteByID(ctx context.Context, id string) (*gtsmodel.UserMute, error)
+
+	// GetMute returns the mute from source account targeting target account, if it exists, or an error if it doesn't.
+	GetMute(ctx context.Context, sourceAccountID string, targetAccountID string) (*gtsmodel.UserMute, error)
+
+	// GetAccountMutes returns all mutes created by the given account ID, newest first.
+	GetAccountMutes(ctx context.Context, accountID string) ([]*gtsmodel.UserMute, error)
+
+	// PutMute attempts to place the given account mute in the database.
+	PutMute(ctx context.Context, mute *gtsmodel.UserMute) error
+
+	// UpdateMute updates the given columns of the given account mute in the database.
+	UpdateMute(ctx context.Context, mute *gtsmodel.UserMute, columns ...string) error
+
+	// DeleteMute removes the given account mute from the database.
+	DeleteMute(ctx context.Context, mute *gtsmodel.UserMute) error
+
+	// DeleteAccountMutes will delete all database mutes to / from the given account ID.
+	DeleteAccountMutes(ctx context.Context, accountID string) error
+
 	// GetRelationship retrieves the relationship of the targetAccount to the requestingAccount.
 	GetRelationship(ctx context.Context, requestingAccount string, targetAccount string) (*gtsmodel.Relationship, Error)
 
@@ -40,4 +40,13 @@ type Report interface {
 	UpdateReport(ctx context.Context, report *gtsmodel.Report, columns ...string) (*gtsmodel.Report, Error)
 	// DeleteReportByID deletes report with the given id.
 	DeleteReportByID(ctx context.Context, id string) Error
+
+	// GetReportNoteByID gets one report note by its db id.
+	GetReportNoteByID(ctx context.Context, id string) (*gtsmodel.ReportNote, Error)
+	// GetReportNotes gets all notes left on the report with the given id, oldest first.
+	GetReportNotes(ctx context.Context, reportID string) ([]*gtsmodel.ReportNote, Error)
+	// PutReportNote puts the given report note in the database.
+	PutReportNote(ctx context.Context, note *gtsmodel.ReportNote) Error
+	// DeleteReportNoteByID deletes report note with the given id.
+	DeleteReportNoteByID(ctx context.Context, id string) Error
 }
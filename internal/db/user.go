@@ -37,6 +37,11 @@ type User interface {
 	GetUserByExternalID(ctx context.Context, id string) (*gtsmodel.User, Error)
 	// GetUserByConfirmationToken returns one user by its confirmation token, or an error if something goes wrong.
 	GetUserByConfirmationToken(ctx context.Context, confirmationToken string) (*gtsmodel.User, Error)
+	// GetUserByUnsubscribeToken returns one user by its email unsubscribe token, or an error if something goes wrong.
+	GetUserByUnsubscribeToken(ctx context.Context, unsubscribeToken string) (*gtsmodel.User, Error)
+	// GetUsersForEmailDigest returns all users with a confirmed email address who have
+	// chosen to receive a digest email for at least one email type.
+	GetUsersForEmailDigest(ctx context.Context) ([]*gtsmodel.User, Error)
 	// PutUser will attempt to place user in the database
 	PutUser(ctx context.Context, user *gtsmodel.User) Error
 	// UpdateUser updates one user by its primary key, updating either only the specified columns, or all of them.
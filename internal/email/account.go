@@ -0,0 +1,41 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package email
+
+const (
+	accountActionTemplate = "email_account_action.tmpl"
+	accountActionSubject  = "GoToSocial Moderation Action"
+)
+
+type AccountActionData struct {
+	// Username to be addressed.
+	Username string
+	// URL of the instance to present to the receiver.
+	InstanceURL string
+	// Name of the instance to present to the receiver.
+	InstanceName string
+	// Description of the action taken and
+	// what it means for the receiver's account.
+	Action string
+	// Text left by the admin explaining why the action was taken.
+	Text string
+}
+
+func (s *sender) SendAccountActionEmail(toAddress string, data AccountActionData) error {
+	return s.sendTemplate(accountActionTemplate, accountActionSubject, data, toAddress)
+}
@@ -32,12 +32,18 @@ import (
 )
 
 func (s *sender) sendTemplate(template string, subject string, data any, toAddresses ...string) error {
+	return s.sendUnsubscribableTemplate(template, subject, "", data, toAddresses...)
+}
+
+// sendUnsubscribableTemplate is like sendTemplate, but includes one-click
+// unsubscribe headers pointing to the given unsubscribeURL, if it is set.
+func (s *sender) sendUnsubscribableTemplate(template string, subject string, unsubscribeURL string, data any, toAddresses ...string) error {
 	buf := &bytes.Buffer{}
 	if err := s.template.ExecuteTemplate(buf, template, data); err != nil {
 		return err
 	}
 
-	msg, err := assembleMessage(subject, buf.String(), s.from, toAddresses...)
+	msg, err := assembleMessage(subject, buf.String(), s.from, unsubscribeURL, toAddresses...)
 	if err != nil {
 		return err
 	}
@@ -65,11 +71,19 @@ func loadTemplates(templateBaseDir string) (*template.Template, error) {
 // assembleMessage assembles a valid email message following:
 //   - https://datatracker.ietf.org/doc/html/rfc2822
 //   - https://pkg.go.dev/net/smtp#SendMail
-func assembleMessage(mailSubject string, mailBody string, mailFrom string, mailTo ...string) ([]byte, error) {
+//
+// If unsubscribeURL is set, the message will include the headers
+// described in https://datatracker.ietf.org/doc/html/rfc8058, so
+// that mail clients can offer a one-click unsubscribe button.
+func assembleMessage(mailSubject string, mailBody string, mailFrom string, unsubscribeURL string, mailTo ...string) ([]byte, error) {
 	if strings.ContainsAny(mailSubject, "\r\n") {
 		return nil, errors.New("email subject must not contain newline characters")
 	}
 
+	if strings.ContainsAny(unsubscribeURL, "\r\n") {
+		return nil, errors.New("email unsubscribe url must not contain newline characters")
+	}
+
 	if strings.ContainsAny(mailFrom, "\r\n") {
 		return nil, errors.New("email from address must not contain newline characters")
 	}
@@ -105,6 +119,10 @@ func assembleMessage(mailSubject string, mailBody string, mailFrom string, mailT
 	}
 	msg.WriteString("From: " + mailFrom + CRLF)
 	msg.WriteString("Subject: " + mailSubject + CRLF)
+	if unsubscribeURL != "" {
+		msg.WriteString("List-Unsubscribe: <" + unsubscribeURL + ">" + CRLF)
+		msg.WriteString("List-Unsubscribe-Post: List-Unsubscribe=One-Click" + CRLF)
+	}
 	msg.WriteString(CRLF)
 	msg.WriteString(mailBody)
 	msg.WriteString(CRLF)
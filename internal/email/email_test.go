@@ -185,6 +185,127 @@ func (suite *EmailTestSuite) TestTemplateReportClosedLocalAccountNoComment() {
 	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Report Closed\r\n\r\nHello !\r\n\r\nYou recently reported the account @1happyturtle to the moderator(s) of Test Instance (https://example.org).\r\n\r\nThe report you submitted has now been closed.\r\n\r\nThe moderator who closed the report did not leave a comment.\r\n\r\n", suite.sentEmails["user@example.org"])
 }
 
+func (suite *EmailTestSuite) TestTemplateReportClosedWithRules() {
+	reportClosedData := email.ReportClosedData{
+		InstanceURL:          "https://example.org",
+		InstanceName:         "Test Instance",
+		ReportTargetUsername: "foss_satan",
+		ReportTargetDomain:   "fossbros-anonymous.io",
+		ActionTakenComment:   "User was yeeted. Thank you for reporting!",
+		ReportRules:          []string{"Be nice to each other.", "No advertising or spam."},
+	}
+
+	if err := suite.sender.SendReportClosedEmail("user@example.org", reportClosedData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Report Closed\r\n\r\nHello !\r\n\r\nYou recently reported the account @foss_satan@fossbros-anonymous.io to the moderator(s) of Test Instance (https://example.org).\r\n\r\nYou reported that the account broke the following rule(s):\r\n- Be nice to each other.\r\n- No advertising or spam.\r\n\r\nThe report you submitted has now been closed.\r\n\r\nThe moderator who closed the report left the following comment: User was yeeted. Thank you for reporting!\r\n\r\n", suite.sentEmails["user@example.org"])
+}
+
+func (suite *EmailTestSuite) TestTemplateReportNote() {
+	reportNoteData := email.ReportNoteData{
+		InstanceURL:        "https://example.org",
+		InstanceName:       "Test Instance",
+		ReportURL:          "https://example.org/settings/admin/reports/01GVJHN1RTYZCZTCXVPPPKBX6R",
+		NoteAuthorUsername: "admin",
+		NoteContent:        "Checked with the remote admin, they've dealt with it.",
+	}
+
+	if err := suite.sender.SendReportNoteEmail([]string{"user@example.org"}, reportNoteData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial New Report Note\r\n\r\nHello moderator of Test Instance (https://example.org)!\r\n\r\nadmin left a note on a report:\r\n\r\nChecked with the remote admin, they've dealt with it.\r\n\r\nTo view the report, paste the following link into your browser: https://example.org/settings/admin/reports/01GVJHN1RTYZCZTCXVPPPKBX6R\r\n\r\n", suite.sentEmails["user@example.org"])
+}
+
+func (suite *EmailTestSuite) TestTemplateNotification() {
+	notificationData := email.NotificationData{
+		Username:     "test",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		Item: email.DigestItem{
+			Summary: "@someone@example.com mentioned you",
+			Content: "hey @test, how's it going?",
+			URL:     "https://example.com/@someone/statuses/01H7F1WS1E6C0SF9B1XM0HB8MN",
+		},
+		UnsubscribeURL: "https://example.org/unsubscribe?token=some-token&type=mentions",
+	}
+
+	if err := suite.sender.SendNotificationEmail("user@example.org", notificationData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Notification\r\nList-Unsubscribe: <https://example.org/unsubscribe?token=some-token&type=mentions>\r\nList-Unsubscribe-Post: List-Unsubscribe=One-Click\r\n\r\nHello test!\r\n\r\n@someone@example.com mentioned you on Test Instance (https://example.org).\r\n\r\nhey @test, how's it going?\r\n\r\nTo see it, visit: https://example.com/@someone/statuses/01H7F1WS1E6C0SF9B1XM0HB8MN\r\n\r\nIf you no longer want to receive emails like this, visit: https://example.org/unsubscribe?token=some-token&type=mentions\r\n\r\n", suite.sentEmails["user@example.org"])
+}
+
+func (suite *EmailTestSuite) TestTemplateDigest() {
+	digestData := email.DigestData{
+		Username:     "test",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		Frequency:    "weekly",
+		Sections: []email.DigestSection{
+			{
+				Title: "Mentions",
+				Items: []email.DigestItem{
+					{
+						Summary: "@someone@example.com mentioned you",
+						Content: "hey @test, how's it going?",
+						URL:     "https://example.com/@someone/statuses/01H7F1WS1E6C0SF9B1XM0HB8MN",
+					},
+				},
+			},
+			{
+				Title: "New followers",
+				Items: []email.DigestItem{
+					{
+						Summary: "@someone@example.com followed you",
+						URL:     "https://example.com/@someone",
+					},
+				},
+			},
+		},
+		UnsubscribeURL: "https://example.org/unsubscribe?token=some-token&type=digest",
+	}
+
+	if err := suite.sender.SendDigestEmail("user@example.org", digestData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Digest\r\nList-Unsubscribe: <https://example.org/unsubscribe?token=some-token&type=digest>\r\nList-Unsubscribe-Post: List-Unsubscribe=One-Click\r\n\r\nHello test!\r\n\r\nHere's your weekly digest from Test Instance (https://example.org).\r\n\r\nMentions\r\n\r\n- @someone@example.com mentioned you\r\n  hey @test, how's it going?\r\n  https://example.com/@someone/statuses/01H7F1WS1E6C0SF9B1XM0HB8MN\r\n\r\nNew followers\r\n\r\n- @someone@example.com followed you\r\n  https://example.com/@someone\r\n\r\nIf you no longer want to receive digest emails, visit: https://example.org/unsubscribe?token=some-token&type=digest\r\n\r\n", suite.sentEmails["user@example.org"])
+}
+
+func (suite *EmailTestSuite) TestTemplateAccountActionNoComment() {
+	accountActionData := email.AccountActionData{
+		Username:     "foss_satan",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		Action:       "Your account has been silenced: from now on, your posts will only be shown to your followers.",
+	}
+
+	if err := suite.sender.SendAccountActionEmail("user@example.org", accountActionData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Moderation Action\r\n\r\nHello foss_satan!\r\n\r\nA moderator of Test Instance (https://example.org) has taken action on your account:\r\n\r\nYour account has been silenced: from now on, your posts will only be shown to your followers.\r\n\r\nThe moderator did not leave a comment.\r\n\r\n", suite.sentEmails["user@example.org"])
+}
+
+func (suite *EmailTestSuite) TestTemplateExport() {
+	exportData := email.ExportData{
+		Username:     "foss_satan",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		ExportURL:    "https://example.org/settings/user/export",
+		ExpiresAt:    "Mon, 02 Jan 2006 15:04:05 UTC",
+	}
+
+	if err := suite.sender.SendExportEmail("user@example.org", exportData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Data Export Ready\r\n\r\nHello foss_satan!\r\n\r\nThe export of your account data that you requested from Test Instance (https://example.org) is ready.\r\n\r\nYou can download it from: https://example.org/settings/user/export\r\n\r\nThe export will be available to download until Mon, 02 Jan 2006 15:04:05 UTC, after which it will be removed.\r\n\r\n", suite.sentEmails["user@example.org"])
+}
+
 func TestEmailTestSuite(t *testing.T) {
 	suite.Run(t, new(EmailTestSuite))
 }
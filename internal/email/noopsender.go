@@ -65,16 +65,40 @@ func (s *noopSender) SendNewReportEmail(toAddresses []string, data NewReportData
 }
 
 func (s *noopSender) SendReportClosedEmail(toAddress string, data ReportClosedData) error {
-	return s.sendTemplate(reportClosedTemplate, reportClosedSubject, data, toAddress)
+	return s.sendUnsubscribableTemplate(reportClosedTemplate, reportClosedSubject, data.UnsubscribeURL, data, toAddress)
+}
+
+func (s *noopSender) SendReportNoteEmail(toAddresses []string, data ReportNoteData) error {
+	return s.sendTemplate(reportNoteTemplate, reportNoteSubject, data, toAddresses...)
+}
+
+func (s *noopSender) SendAccountActionEmail(toAddress string, data AccountActionData) error {
+	return s.sendTemplate(accountActionTemplate, accountActionSubject, data, toAddress)
+}
+
+func (s *noopSender) SendNotificationEmail(toAddress string, data NotificationData) error {
+	return s.sendUnsubscribableTemplate(notificationTemplate, notificationSubject, data.UnsubscribeURL, data, toAddress)
+}
+
+func (s *noopSender) SendDigestEmail(toAddress string, data DigestData) error {
+	return s.sendUnsubscribableTemplate(digestTemplate, digestSubject, data.UnsubscribeURL, data, toAddress)
+}
+
+func (s *noopSender) SendExportEmail(toAddress string, data ExportData) error {
+	return s.sendTemplate(exportTemplate, exportSubject, data, toAddress)
 }
 
 func (s *noopSender) sendTemplate(template string, subject string, data any, toAddresses ...string) error {
+	return s.sendUnsubscribableTemplate(template, subject, "", data, toAddresses...)
+}
+
+func (s *noopSender) sendUnsubscribableTemplate(template string, subject string, unsubscribeURL string, data any, toAddresses ...string) error {
 	buf := &bytes.Buffer{}
 	if err := s.template.ExecuteTemplate(buf, template, data); err != nil {
 		return err
 	}
 
-	msg, err := assembleMessage(subject, buf.String(), "test@example.org", toAddresses...)
+	msg, err := assembleMessage(subject, buf.String(), "test@example.org", unsubscribeURL, toAddresses...)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,86 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package email
+
+const (
+	notificationTemplate = "email_notification.tmpl"
+	notificationSubject  = "GoToSocial Notification"
+	digestTemplate       = "email_digest.tmpl"
+	digestSubject        = "GoToSocial Digest"
+)
+
+type NotificationData struct {
+	// Username to be addressed.
+	Username string
+	// URL of the instance to present to the receiver.
+	InstanceURL string
+	// Name of the instance to present to the receiver.
+	InstanceName string
+	// The notification to tell the receiver about.
+	Item DigestItem
+	// URL which can be used to stop receiving
+	// emails about this kind of notification.
+	UnsubscribeURL string
+}
+
+type DigestData struct {
+	// Username to be addressed.
+	Username string
+	// URL of the instance to present to the receiver.
+	InstanceURL string
+	// Name of the instance to present to the receiver.
+	InstanceName string
+	// How often the receiver gets digests, eg., "daily".
+	Frequency string
+	// Sections of the digest, one per kind of
+	// notification, in the order they should be shown.
+	Sections []DigestSection
+	// URL which can be used to stop
+	// receiving digest emails entirely.
+	UnsubscribeURL string
+}
+
+type DigestSection struct {
+	// Title of this section, eg., "Mentions".
+	Title string
+	// Items in this section, newest first.
+	Items []DigestItem
+	// Number of further items in this section
+	// which were left out to keep the email short.
+	More int
+}
+
+type DigestItem struct {
+	// Summary of what happened, eg.,
+	// "@someone@example.org mentioned you".
+	Summary string
+	// Text content of the status this item
+	// concerns, if any, with HTML removed.
+	Content string
+	// URL at which the receiver can view
+	// whatever this item is about.
+	URL string
+}
+
+func (s *sender) SendNotificationEmail(toAddress string, data NotificationData) error {
+	return s.sendUnsubscribableTemplate(notificationTemplate, notificationSubject, data.UnsubscribeURL, data, toAddress)
+}
+
+func (s *sender) SendDigestEmail(toAddress string, data DigestData) error {
+	return s.sendUnsubscribableTemplate(digestTemplate, digestSubject, data.UnsubscribeURL, data, toAddress)
+}
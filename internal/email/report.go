@@ -22,6 +22,8 @@ const (
 	newReportSubject     = "GoToSocial New Report"
 	reportClosedTemplate = "email_report_closed.tmpl"
 	reportClosedSubject  = "GoToSocial Report Closed"
+	reportNoteTemplate   = "email_report_note.tmpl"
+	reportNoteSubject    = "GoToSocial New Report Note"
 )
 
 type NewReportData struct {
@@ -57,8 +59,30 @@ type ReportClosedData struct {
 	ReportTargetDomain string
 	// Comment left by the admin who closed the report.
 	ActionTakenComment string
+	// Text of any instance rules cited by the report.
+	ReportRules []string
+	// URL which can be used to stop receiving
+	// emails about closed reports, if set.
+	UnsubscribeURL string
 }
 
 func (s *sender) SendReportClosedEmail(toAddress string, data ReportClosedData) error {
-	return s.sendTemplate(reportClosedTemplate, reportClosedSubject, data, toAddress)
+	return s.sendUnsubscribableTemplate(reportClosedTemplate, reportClosedSubject, data.UnsubscribeURL, data, toAddress)
+}
+
+type ReportNoteData struct {
+	// URL of the instance to present to the receiver.
+	InstanceURL string
+	// Name of the instance to present to the receiver.
+	InstanceName string
+	// URL to open the report in the settings panel.
+	ReportURL string
+	// Username of the moderator who left the note.
+	NoteAuthorUsername string
+	// Text content of the note.
+	NoteContent string
+}
+
+func (s *sender) SendReportNoteEmail(toAddresses []string, data ReportNoteData) error {
+	return s.sendTemplate(reportNoteTemplate, reportNoteSubject, data, toAddresses...)
 }
@@ -46,6 +46,29 @@ type Sender interface {
 	// SendReportClosedEmail sends an email notification to the given address, letting them
 	// know that a report that they created has been closed / resolved by an admin.
 	SendReportClosedEmail(toAddress string, data ReportClosedData) error
+
+	// SendReportNoteEmail sends an email notification to the given addresses, letting them
+	// know that another moderator has left a note on a report.
+	//
+	// It is expected that the toAddresses have already been filtered to ensure that they
+	// all belong to admins + moderators.
+	SendReportNoteEmail(toAddresses []string, data ReportNoteData) error
+
+	// SendAccountActionEmail sends an email notification to the given address, letting them
+	// know that an admin has taken (or undone) a moderation action on their account.
+	SendAccountActionEmail(toAddress string, data AccountActionData) error
+
+	// SendNotificationEmail sends an email to the given address, letting them know
+	// about a single new notification, as soon as it has been created.
+	SendNotificationEmail(toAddress string, data NotificationData) error
+
+	// SendDigestEmail sends a digest email to the given address, summarizing
+	// notifications and other events since they were last sent a digest.
+	SendDigestEmail(toAddress string, data DigestData) error
+
+	// SendExportEmail sends an email to the given address, letting them know
+	// that an export of their account data is ready to be downloaded.
+	SendExportEmail(toAddress string, data ExportData) error
 }
 
 // NewSender returns a new email Sender interface with the given configuration, or an error if something goes wrong.
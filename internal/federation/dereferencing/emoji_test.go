@@ -67,7 +67,7 @@ func (suite *EmojiTestSuite) TestDereferenceEmojiBlocking() {
 	suite.Equal(emojiImageStaticRemoteURL, emoji.ImageStaticRemoteURL)
 	suite.Contains(emoji.ImageURL, "/emoji/original/01GCBMGNZBKMEE1KTZ6PMJEW5D.gif")
 	suite.Contains(emoji.ImageStaticURL, "emoji/static/01GCBMGNZBKMEE1KTZ6PMJEW5D.png")
-	suite.Contains(emoji.ImagePath, "/emoji/original/01GCBMGNZBKMEE1KTZ6PMJEW5D.gif")
+	suite.Regexp(`^blobs/sha256/[0-9a-f]{2}/[0-9a-f]{64}\.gif$`, emoji.ImagePath)
 	suite.Contains(emoji.ImageStaticPath, "/emoji/static/01GCBMGNZBKMEE1KTZ6PMJEW5D.png")
 	suite.Equal("image/gif", emoji.ImageContentType)
 	suite.Equal("image/png", emoji.ImageStaticContentType)
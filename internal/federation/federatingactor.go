@@ -33,6 +33,7 @@ import (
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
@@ -139,6 +140,17 @@ func (f *federatingActor) PostInboxScheme(ctx context.Context, w http.ResponseWr
 		return false, gtserror.NewErrorUnauthorized(err)
 	}
 
+	// Now that we know which domain really sent the request,
+	// charge it against that domain's rate limit budget instead
+	// of the budget of the IP address it came from, if any.
+	if rateLimit := gtscontext.RateLimit(ctx); rateLimit != nil {
+		requester := gtscontext.RequestingAccount(ctx)
+		if requester != nil && !rateLimit(requester.Domain) {
+			err := fmt.Errorf("rate limit reached for %s", requester.Domain)
+			return false, gtserror.NewErrorTooManyRequests(err)
+		}
+	}
+
 	/*
 		Begin processing the request, but note that we
 		have not yet applied authorization (ie., blocks).
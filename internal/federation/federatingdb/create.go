@@ -139,16 +139,16 @@ func (f *federatingDB) activityCreate(ctx context.Context, asType vocab.Type, re
 			continue
 		}
 
-		// we have a type -- what is it?
-		asObjectTypeName := asObjectType.GetTypeName()
-		switch asObjectTypeName {
-		case ap.ObjectNote:
-			// CREATE A NOTE
-			if err := f.createNote(ctx, objectIter.GetActivityStreamsNote(), receivingAccount, requestingAccount); err != nil {
-				errs = append(errs, err.Error())
-			}
-		default:
+		// we have a type -- is it something we can turn into a status?
+		statusable, ok := ap.ToStatusable(asObjectType)
+		if !ok {
 			errs = append(errs, fmt.Sprintf("received an object on a Create that we couldn't handle: %s", asObjectType.GetTypeName()))
+			continue
+		}
+
+		// CREATE A STATUS
+		if err := f.createStatus(ctx, statusable, receivingAccount, requestingAccount); err != nil {
+			errs = append(errs, err.Error())
 		}
 	}
 
@@ -159,8 +159,10 @@ func (f *federatingDB) activityCreate(ctx context.Context, asType vocab.Type, re
 	return nil
 }
 
-// createNote handles a Create activity with a Note type.
-func (f *federatingDB) createNote(ctx context.Context, note vocab.ActivityStreamsNote, receivingAccount *gtsmodel.Account, requestingAccount *gtsmodel.Account) error {
+// createStatus handles a Create activity with a Statusable type, eg., a
+// Note, or an Article, Page, Question, Event, Video, Audio or Image.
+// Whatever the type, it's passed on to the processor as a Note.
+func (f *federatingDB) createStatus(ctx context.Context, statusable ap.Statusable, receivingAccount *gtsmodel.Account, requestingAccount *gtsmodel.Account) error {
 	l := log.WithContext(ctx).
 		WithFields(kv.Fields{
 			{"receivingAccount", receivingAccount.URI},
@@ -168,36 +170,36 @@ func (f *federatingDB) createNote(ctx context.Context, note vocab.ActivityStream
 		}...)
 
 	// Check if we have a forward.
-	// In other words, was the note posted to our inbox by at least one actor who actually created the note, or are they just forwarding it?
+	// In other words, was the status posted to our inbox by at least one actor who actually created the status, or are they just forwarding it?
 	forward := true
 
-	// note should have an attributedTo
-	noteAttributedTo := note.GetActivityStreamsAttributedTo()
-	if noteAttributedTo == nil {
-		return errors.New("createNote: note had no attributedTo")
+	// status should have an attributedTo
+	statusAttributedTo := statusable.GetActivityStreamsAttributedTo()
+	if statusAttributedTo == nil {
+		return errors.New("createStatus: status had no attributedTo")
 	}
 
 	// compare the attributedTo(s) with the actor who posted this to our inbox
-	for attributedToIter := noteAttributedTo.Begin(); attributedToIter != noteAttributedTo.End(); attributedToIter = attributedToIter.Next() {
+	for attributedToIter := statusAttributedTo.Begin(); attributedToIter != statusAttributedTo.End(); attributedToIter = attributedToIter.Next() {
 		if !attributedToIter.IsIRI() {
 			continue
 		}
 		iri := attributedToIter.GetIRI()
 		if requestingAccount.URI == iri.String() {
-			// at least one creator of the note, and the actor who posted the note to our inbox, are the same, so it's not a forward
+			// at least one creator of the status, and the actor who posted the status to our inbox, are the same, so it's not a forward
 			forward = false
 		}
 	}
 
-	// If we do have a forward, we should ignore the content for now and just dereference based on the URL/ID of the note instead, to get the note straight from the horse's mouth
+	// If we do have a forward, we should ignore the content for now and just dereference based on the URL/ID of the status instead, to get the status straight from the horse's mouth
 	if forward {
-		l.Trace("note is a forward")
-		id := note.GetJSONLDId()
+		l.Trace("status is a forward")
+		id := statusable.GetJSONLDId()
 		if !id.IsIRI() {
-			// if the note id isn't an IRI, there's nothing we can do here
+			// if the status id isn't an IRI, there's nothing we can do here
 			return nil
 		}
-		// pass the note iri into the processor and have it do the dereferencing instead of doing it here
+		// pass the status iri into the processor and have it do the dereferencing instead of doing it here
 		f.state.Workers.EnqueueFederator(ctx, messages.FromFederator{
 			APObjectType:     ap.ObjectNote,
 			APActivityType:   ap.ActivityCreate,
@@ -211,9 +213,9 @@ func (f *federatingDB) createNote(ctx context.Context, note vocab.ActivityStream
 
 	// if we reach this point, we know it's not a forwarded status, so proceed with processing it as normal
 
-	status, err := f.typeConverter.ASStatusToStatus(ctx, note)
+	status, err := f.typeConverter.ASStatusToStatus(ctx, statusable)
 	if err != nil {
-		return fmt.Errorf("createNote: error converting note to status: %s", err)
+		return fmt.Errorf("createStatus: error converting statusable to status: %s", err)
 	}
 
 	// id the status based on the time it was created
@@ -230,13 +232,13 @@ func (f *federatingDB) createNote(ctx context.Context, note vocab.ActivityStream
 			return nil
 		}
 		// an actual error has happened
-		return fmt.Errorf("createNote: database error inserting status: %s", err)
+		return fmt.Errorf("createStatus: database error inserting status: %s", err)
 	}
 
 	f.state.Workers.EnqueueFederator(ctx, messages.FromFederator{
 		APObjectType:     ap.ObjectNote,
 		APActivityType:   ap.ActivityCreate,
-		APObjectModel:    note,
+		APObjectModel:    statusable,
 		GTSModel:         status,
 		ReceivingAccount: receivingAccount,
 	})
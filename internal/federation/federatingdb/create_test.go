@@ -84,6 +84,69 @@ func (suite *CreateTestSuite) TestCreateNoteForward() {
 	suite.Equal("http://example.org/users/Some_User/statuses/afaba698-5740-4e32-a702-af61aa543bc1", msg.APIri.String())
 }
 
+func (suite *CreateTestSuite) TestCreateVideo() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_1"]
+
+	raw := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://fossbros-anonymous.io/videos/watch/5e1c8d62/activity",
+  "type": "Create",
+  "actor": "` + requestingAccount.URI + `",
+  "to": [
+    "https://www.w3.org/ns/activitystreams#Public"
+  ],
+  "object": {
+    "id": "http://fossbros-anonymous.io/videos/watch/5e1c8d62",
+    "type": "Video",
+    "name": "Installing GoToSocial",
+    "published": "2023-07-12T09:21:04Z",
+    "attributedTo": "` + requestingAccount.URI + `",
+    "to": [
+      "https://www.w3.org/ns/activitystreams#Public"
+    ],
+    "content": "<p>A quick walkthrough.</p>",
+    "url": [
+      {
+        "type": "Link",
+        "mediaType": "video/mp4",
+        "href": "http://fossbros-anonymous.io/static/web-videos/5e1c8d62-720.mp4"
+      }
+    ]
+  }
+}`
+
+	m := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(raw), &m); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	t, err := streams.ToType(context.Background(), m)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ctx := createTestContext(receivingAccount, requestingAccount)
+	if err := suite.federatingDB.Create(ctx, t); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// should be a message heading to the processor now, which we can intercept here
+	msg := <-suite.fromFederator
+	suite.Equal(ap.ObjectNote, msg.APObjectType)
+	suite.Equal(ap.ActivityCreate, msg.APActivityType)
+
+	// status should keep the original type
+	status := msg.GTSModel.(*gtsmodel.Status)
+	suite.Equal(ap.ObjectVideo, status.ActivityStreamsType)
+	suite.Equal("<p><strong>Installing GoToSocial</strong></p><p>A quick walkthrough.</p>", status.Content)
+
+	// status should be in the database
+	if _, err := suite.db.GetStatusByID(context.Background(), status.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
 func (suite *CreateTestSuite) TestCreateFlag1() {
 	reportedAccount := suite.testAccounts["local_account_1"]
 	reportingAccount := suite.testAccounts["remote_account_1"]
@@ -42,6 +42,7 @@ const (
 	httpSigKey
 	httpSigPubKeyIDKey
 	dryRunKey
+	rateLimitKey
 )
 
 // DryRun returns whether the "dryrun" context key has been set. This can be
@@ -166,6 +167,22 @@ func SetHTTPSignaturePubKeyID(ctx context.Context, pubKeyID *url.URL) context.Co
 	return context.WithValue(ctx, httpSigPubKeyIDKey, pubKeyID)
 }
 
+// RateLimit returns the function to charge the current request against the
+// rate limit budget of the (verified) domain it came from, if set, instead of
+// the budget of the IP address it came from. The function
+// returns false if the budget is used up, in which case the request should be
+// refused with 429 Too Many Requests.
+func RateLimit(ctx context.Context) func(domain string) bool {
+	fn, _ := ctx.Value(rateLimitKey).(func(domain string) bool)
+	return fn
+}
+
+// SetRateLimit stores the given domain rate limit function and returns the
+// wrapped context. See RateLimit() for further information on the value.
+func SetRateLimit(ctx context.Context, fn func(domain string) bool) context.Context {
+	return context.WithValue(ctx, rateLimitKey, fn)
+}
+
 // IsFastFail returns whether the "fastfail" context key has been set. This
 // can be used to indicate to an http client, for example, that the result
 // of an outgoing request is time sensitive and so not to bother with retries.
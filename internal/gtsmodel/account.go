@@ -108,6 +108,18 @@ func (a *Account) IsInstance() bool {
 		a.Username == "instance.actor" // <- misskey
 }
 
+// IsSilenced returns whether account has been silenced by an admin,
+// ie., its statuses are only visible to the account's followers.
+func (a *Account) IsSilenced() bool {
+	return !a.SilencedAt.IsZero()
+}
+
+// IsSensitized returns whether account has been sensitized by an admin,
+// ie., all of its media is to be shown as sensitive.
+func (a *Account) IsSensitized() bool {
+	return !a.SensitizedAt.IsZero()
+}
+
 // EmojisPopulated returns whether emojis are populated according to current EmojiIDs.
 func (a *Account) EmojisPopulated() bool {
 	if len(a.EmojiIDs) != len(a.Emojis) {
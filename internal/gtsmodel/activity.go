@@ -0,0 +1,60 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// DailyActivity is a rollup of activity on this instance over one (UTC) day,
+// along with a snapshot of instance size taken at the end of that day.
+// Rollups are collected by a scheduled job, and used to serve admin measures.
+// Rollups backfilled for earlier days have no snapshot, as it can't be known.
+type DailyActivity struct {
+	ID               string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt        time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	Day              time.Time `validate:"required" bun:"type:timestamptz,nullzero,notnull,unique"`             // Start of the (UTC) day this rollup covers.
+	NewUsers         int       `validate:"min=0" bun:",notnull,default:0"`                                      // Number of local users that signed up on this day.
+	ActiveAccountIDs []string  `validate:"dive,ulid" bun:"active_accounts,array"`                               // IDs of local accounts that signed in, posted or faved on this day.
+	Statuses         int       `validate:"min=0" bun:",notnull,default:0"`                                      // Number of statuses posted by local accounts on this day.
+	Interactions     int       `validate:"min=0" bun:",notnull,default:0"`                                      // Number of replies, boosts and faves of local statuses on this day.
+	OpenedReports    int       `validate:"min=0" bun:",notnull,default:0"`                                      // Number of reports opened on this day.
+	ResolvedReports  int       `validate:"min=0" bun:",notnull,default:0"`                                      // Number of reports resolved on this day.
+	KnownInstances   int       `validate:"min=0" bun:",notnull,default:0"`                                      // Number of (unsuspended) instances known at the end of this day.
+	LocalMediaBytes  int64     `validate:"min=0" bun:",notnull,default:0"`                                      // Storage used by local media at the end of this day.
+	RemoteMediaBytes int64     `validate:"min=0" bun:",notnull,default:0"`                                      // Storage used by cached remote media at the end of this day.
+	EmojiBytes       int64     `validate:"min=0" bun:",notnull,default:0"`                                      // Storage used by emojis at the end of this day.
+}
+
+// ActiveUsers returns the number of local accounts active on this day.
+func (d *DailyActivity) ActiveUsers() int {
+	return len(d.ActiveAccountIDs)
+}
+
+// InstanceActivity is a count of activity originating from
+// one instance over a period of time. It is not stored.
+type InstanceActivity struct {
+	Accounts         int   // Number of accounts first seen in this period.
+	Statuses         int   // Number of statuses posted in this period.
+	Reports          int   // Number of reports targeting accounts of the instance opened in this period.
+	MediaAttachments int64 // Storage used by cached media that was first seen in this period.
+}
+
+// ActivityCount is a count of activity for one key, such as a domain or language.
+type ActivityCount struct {
+	Key   string `bun:"key"`
+	Count int    `bun:"count"`
+}
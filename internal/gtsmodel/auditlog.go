@@ -0,0 +1,84 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// AuditLogEntry models one action taken by an admin or moderator, as recorded
+// in the append-only moderation audit log. Entries are never updated or deleted.
+type AuditLogEntry struct {
+	ID         string             `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt  time.Time          `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	AccountID  string             `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // Who performed this action.
+	Account    *Account           `validate:"-" bun:"-"`                                                           // Account corresponding to accountID
+	Action     string             `validate:"required" bun:",nullzero,notnull"`                                    // What action was taken, eg., create, update, delete, or an AdminActionType.
+	TargetType AuditLogTargetType `validate:"required" bun:",nullzero,notnull"`                                    // What type of entity the action was taken on.
+	TargetID   string             `validate:"-" bun:",nullzero"`                                                   // ID of the entity the action was taken on, if any.
+	Diff       string             `validate:"-" bun:""`                                                            // JSON object describing the fields changed by this action.
+}
+
+// AuditLogTargetType describes the type of entity an audited action was taken on.
+type AuditLogTargetType string
+
+const (
+	// AuditLogTargetAccount -- action was taken on an account.
+	AuditLogTargetAccount AuditLogTargetType = "account"
+	// AuditLogTargetDomainBlock -- action was taken on a domain block.
+	AuditLogTargetDomainBlock AuditLogTargetType = "domain_block"
+	// AuditLogTargetEmailDomainBlock -- action was taken on an email domain block.
+	AuditLogTargetEmailDomainBlock AuditLogTargetType = "email_domain_block"
+	// AuditLogTargetEmoji -- action was taken on a custom emoji.
+	AuditLogTargetEmoji AuditLogTargetType = "emoji"
+	// AuditLogTargetInstance -- action was taken on the instance settings.
+	AuditLogTargetInstance AuditLogTargetType = "instance"
+	// AuditLogTargetIPBlock -- action was taken on an IP block.
+	AuditLogTargetIPBlock AuditLogTargetType = "ip_block"
+	// AuditLogTargetMedia -- action was taken on stored media.
+	AuditLogTargetMedia AuditLogTargetType = "media"
+	// AuditLogTargetReport -- action was taken on a report.
+	AuditLogTargetReport AuditLogTargetType = "report"
+	// AuditLogTargetRule -- action was taken on an instance rule.
+	AuditLogTargetRule AuditLogTargetType = "rule"
+)
+
+const (
+	// AuditLogActionCreate -- the target was created.
+	AuditLogActionCreate = "create"
+	// AuditLogActionUpdate -- the target was updated.
+	AuditLogActionUpdate = "update"
+	// AuditLogActionDelete -- the target was deleted.
+	AuditLogActionDelete = "delete"
+	// AuditLogActionImport -- targets were created in bulk from an imported list.
+	AuditLogActionImport = "import"
+	// AuditLogActionResolve -- the target report was resolved.
+	AuditLogActionResolve = "resolve"
+	// AuditLogActionReopen -- the target report was reopened.
+	AuditLogActionReopen = "reopen"
+	// AuditLogActionAssign -- the target report was assigned to a moderator.
+	AuditLogActionAssign = "assign"
+	// AuditLogActionUnassign -- the target report was unassigned.
+	AuditLogActionUnassign = "unassign"
+	// AuditLogActionNote -- a note was left on the target report.
+	AuditLogActionNote = "note"
+	// AuditLogActionDeleteNote -- a note was deleted from the target report.
+	AuditLogActionDeleteNote = "delete_note"
+	// AuditLogActionPrune -- old remote media was pruned.
+	AuditLogActionPrune = "prune"
+	// AuditLogActionRefetch -- missing remote emojis were refetched.
+	AuditLogActionRefetch = "refetch"
+)
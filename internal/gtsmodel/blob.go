@@ -0,0 +1,31 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Blob represents a content-addressed file in storage, which may be shared
+// between several media attachments and / or emojis with identical contents.
+// The stored file is only removed once the last reference to it is released.
+type Blob struct {
+	Key       string    `validate:"required" bun:",pk,nullzero,notnull,unique"`                          // storage key of the blob, derived from the SHA-256 hash of its contents
+	CreatedAt time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Size      int64     `validate:"-" bun:",notnull"`                                                    // size of the blob in bytes
+	Refs      int       `validate:"-" bun:",notnull"`                                                    // number of database entries referencing this blob
+}
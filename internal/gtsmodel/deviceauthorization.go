@@ -0,0 +1,50 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// DeviceAuthorization models a pending OAuth device authorization
+// grant, as per RFC 8628. It's created when a client requests a device
+// code, approved or denied when the user enters the corresponding user
+// code on the instance, and deleted when the client exchanges it for a
+// token, or when it expires.
+type DeviceAuthorization struct {
+	ID           string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt    time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt    time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	ClientID     string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // ID of the client that requested this authorization
+	DeviceCode   string    `validate:"required" bun:",nullzero,notnull,unique"`                             // Secret code used by the client to poll for a token
+	UserCode     string    `validate:"required" bun:",nullzero,notnull,unique"`                             // Short code entered by the user to approve the client
+	Scope        string    `validate:"required" bun:",notnull"`                                             // Oauth scope requested by the client
+	Interval     int       `validate:"min=1" bun:",notnull"`                                                // Minimum number of seconds the client must wait between polls
+	ExpiresAt    time.Time `validate:"required" bun:"type:timestamptz,nullzero,notnull"`                    // When does this authorization expire?
+	LastPolledAt time.Time `validate:"-" bun:"type:timestamptz,nullzero"`                                   // When did the client last poll for a token?
+	UserID       string    `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                         // ID of the user who approved the authorization, if approved
+	Denied       *bool     `validate:"-" bun:",nullzero,notnull,default:false"`                             // Did the user deny the authorization?
+}
+
+// Expired returns whether this authorization has expired as of the given time.
+func (d *DeviceAuthorization) Expired(now time.Time) bool {
+	return !now.Before(d.ExpiresAt)
+}
+
+// Approved returns whether a user has approved this authorization.
+func (d *DeviceAuthorization) Approved() bool {
+	return d.UserID != ""
+}
@@ -0,0 +1,48 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Export models an archive of an account's data, built in the background
+// at the request of its user, which can be downloaded until it expires.
+type Export struct {
+	ID          string      `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt   time.Time   `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt   time.Time   `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID   string      `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // ID of the account whose data is exported
+	Account     *Account    `validate:"-" bun:"rel:belongs-to"`                                              // Account corresponding to accountID
+	State       ExportState `validate:"oneof=processing complete failed" bun:",nullzero,notnull"`            // Whether the export is still being built, or done.
+	Path        string      `validate:"-" bun:",nullzero"`                                                   // Storage key of the finished archive.
+	Size        int64       `validate:"-" bun:",nullzero"`                                                   // Size in bytes of the finished archive.
+	Error       string      `validate:"-" bun:",nullzero"`                                                   // Why the export failed, if it did.
+	CompletedAt time.Time   `validate:"-" bun:"type:timestamptz,nullzero"`                                   // When the archive was finished (or failed).
+	ExpiresAt   time.Time   `validate:"-" bun:"type:timestamptz,nullzero"`                                   // When the archive will be removed.
+}
+
+// ExportState describes how far along an export is.
+type ExportState string
+
+const (
+	// ExportProcessing -- the archive is being built.
+	ExportProcessing ExportState = "processing"
+	// ExportComplete -- the archive is ready to download.
+	ExportComplete ExportState = "complete"
+	// ExportFailed -- the archive couldn't be built.
+	ExportFailed ExportState = "failed"
+)
@@ -0,0 +1,86 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Import models the import of a CSV file of follows, blocks
+// etc, or an archive of statuses, eg. from a Mastodon export,
+// into an account. Imports are processed in the background,
+// recording their progress.
+type Import struct {
+	ID          string      `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`           // id of this item in the database
+	CreatedAt   time.Time   `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`    // when was item created
+	UpdatedAt   time.Time   `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`    // when was item last updated
+	AccountID   string      `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                     // ID of the account data is imported into
+	Account     *Account    `validate:"-" bun:"rel:belongs-to"`                                                 // Account corresponding to accountID
+	Type        ImportType  `validate:"oneof=following blocks bookmarks lists archive" bun:",nullzero,notnull"` // What kind of data is imported.
+	Mode        ImportMode  `validate:"oneof=merge overwrite" bun:",nullzero,notnull"`                          // Whether existing data not in the import is kept.
+	State       ImportState `validate:"oneof=processing complete failed" bun:",nullzero,notnull"`               // Whether the import is still being processed, or done.
+	Total       int         `validate:"-" bun:",notnull"`                                                       // Number of rows (or statuses) in the imported file.
+	Processed   int         `validate:"-" bun:",notnull"`                                                       // Number of rows processed so far.
+	Failed      int         `validate:"-" bun:",notnull"`                                                       // Number of processed rows which couldn't be imported.
+	Failures    []string    `validate:"-" bun:"failures,array"`                                                 // Why rows couldn't be imported, up to a limit.
+	Error       string      `validate:"-" bun:",nullzero"`                                                      // Why the import as a whole failed, if it did.
+	CompletedAt time.Time   `validate:"-" bun:"type:timestamptz,nullzero"`                                      // When the import was finished (or failed).
+}
+
+// ImportType describes what kind of data is imported.
+type ImportType string
+
+const (
+	// ImportFollowing -- accounts to follow.
+	ImportFollowing ImportType = "following"
+	// ImportBlocks -- accounts to block.
+	ImportBlocks ImportType = "blocks"
+	// ImportMutes -- accounts to mute.
+	ImportMutes ImportType = "muting"
+	// ImportBookmarks -- statuses to bookmark.
+	ImportBookmarks ImportType = "bookmarks"
+	// ImportLists -- lists, and the accounts in them.
+	ImportLists ImportType = "lists"
+	// ImportArchive -- statuses, from the outbox
+	// and media of an archive of another account.
+	ImportArchive ImportType = "archive"
+)
+
+// ImportMode describes how an import
+// treats data which isn't in the import.
+type ImportMode string
+
+const (
+	// ImportMerge -- existing data is kept.
+	ImportMerge ImportMode = "merge"
+	// ImportOverwrite -- existing data not in
+	// the import is removed, so that afterwards
+	// the account's data matches the import.
+	ImportOverwrite ImportMode = "overwrite"
+)
+
+// ImportState describes how far along an import is.
+type ImportState string
+
+const (
+	// ImportProcessing -- the import is in progress.
+	ImportProcessing ImportState = "processing"
+	// ImportComplete -- all rows have been processed,
+	// though some of them may have failed.
+	ImportComplete ImportState = "complete"
+	// ImportFailed -- the import was stopped by an error.
+	ImportFailed ImportState = "failed"
+)
@@ -0,0 +1,70 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// IPBlock models a block on requests from an IP address or range of
+// IP addresses, at one of several severities. It may expire.
+type IPBlock struct {
+	ID                 string          `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                             // id of this item in the database
+	CreatedAt          time.Time       `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                      // when was item created
+	UpdatedAt          time.Time       `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                      // when was item last updated
+	IP                 string          `validate:"required,cidr" bun:",nullzero,notnull,unique"`                                             // Blocked address range in CIDR notation, eg. '192.0.2.0/24' or '2001:db8::/32'.
+	Severity           IPBlockSeverity `validate:"required,oneof=sign_up_requires_approval sign_up_block no_access" bun:",nullzero,notnull"` // What requests from the range are subject to.
+	Comment            string          `validate:"-" bun:",nullzero"`                                                                        // Private comment for admins on why the range was blocked.
+	ExpiresAt          time.Time       `validate:"-" bun:"type:timestamptz,nullzero"`                                                        // When does this block stop applying? Zero means never.
+	CreatedByAccountID string          `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                                       // Account ID of the creator of this block
+	CreatedByAccount   *Account        `validate:"-" bun:"rel:belongs-to"`                                                                   // Account corresponding to createdByAccountID
+}
+
+// Expired returns whether this block has expired as of the given time.
+func (b *IPBlock) Expired(now time.Time) bool {
+	return !b.ExpiresAt.IsZero() && !now.Before(b.ExpiresAt)
+}
+
+// IPBlockSeverity describes what requests
+// from a blocked IP range are subject to.
+type IPBlockSeverity string
+
+const (
+	// IPBlockSignUpRequiresApproval -- sign-ups from the range
+	// always need approval by an admin, whatever the instance
+	// setting for sign-up approval is.
+	IPBlockSignUpRequiresApproval IPBlockSeverity = "sign_up_requires_approval"
+	// IPBlockSignUpBlock -- sign-ups from the range are refused.
+	IPBlockSignUpBlock IPBlockSeverity = "sign_up_block"
+	// IPBlockNoAccess -- all requests from the range are refused.
+	IPBlockNoAccess IPBlockSeverity = "no_access"
+)
+
+// Level returns the ordering of this severity,
+// with zero meaning no severity, ie., not blocked.
+// Higher levels include the effects of lower ones.
+func (s IPBlockSeverity) Level() int {
+	switch s {
+	case IPBlockSignUpRequiresApproval:
+		return 1
+	case IPBlockSignUpBlock:
+		return 2
+	case IPBlockNoAccess:
+		return 3
+	default:
+		return 0
+	}
+}
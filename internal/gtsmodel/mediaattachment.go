@@ -50,6 +50,14 @@ type File struct {
 	ContentType string    `validate:"required" bun:",nullzero,notnull"`                                    // MIME content type of the file.
 	FileSize    int       `validate:"required" bun:",notnull"`                                             // File size in bytes
 	UpdatedAt   time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // When was the file last updated.
+
+	// Where the file is a web-safe derivative converted from an original
+	// in a format that browsers don't widely support (e.g. HEIC / AVIF),
+	// the following describe that original. It is kept in storage but
+	// never served, as it may contain metadata stripped from the derivative.
+	SourcePath        string `validate:"-" bun:",nullzero"` // Path of the original source file in storage.
+	SourceContentType string `validate:"-" bun:",nullzero"` // MIME content type of the original source file.
+	SourceFileSize    int    `validate:"-" bun:",nullzero"` // Original source file size in bytes.
 }
 
 // Thumbnail refers to a small image thumbnail derived from a larger image, video, or audio file.
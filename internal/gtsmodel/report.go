@@ -35,6 +35,9 @@ type Report struct {
 	TargetAccountID        string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // which account is targeted by this report
 	TargetAccount          *Account  `validate:"-" bun:"-"`                                                           // account corresponding to TargetAccountID
 	Comment                string    `validate:"-" bun:",nullzero"`                                                   // comment / explanation for this report, by the reporter
+	Category               string    `validate:"-" bun:",nullzero"`                                                   // category of this report, one of the ReportCategory constants
+	RuleIDs                []string  `validate:"dive,ulid" bun:"rules,array"`                                         // database IDs of any instance rules broken according to this report
+	Rules                  []*Rule   `validate:"-" bun:"-"`                                                           // rules corresponding to RuleIDs
 	StatusIDs              []string  `validate:"dive,ulid" bun:"statuses,array"`                                      // database IDs of any statuses referenced by this report
 	Statuses               []*Status `validate:"-" bun:"-"`                                                           // statuses corresponding to StatusIDs
 	Forwarded              *bool     `validate:"-" bun:",nullzero,notnull,default:false"`                             // flag to indicate report should be forwarded to remote instance
@@ -42,4 +45,17 @@ type Report struct {
 	ActionTakenAt          time.Time `validate:"-" bun:"type:timestamptz,nullzero"`                                   // time at which action was taken, if any
 	ActionTakenByAccountID string    `validate:",omitempty,ulid" bun:"type:CHAR(26),nullzero"`                        // database ID of account which took action, if any
 	ActionTakenByAccount   *Account  `validate:"-" bun:"-"`                                                           // account corresponding to ActionTakenByID, if any
+	AssignedAccountID      string    `validate:",omitempty,ulid" bun:"type:CHAR(26),nullzero"`                        // database ID of the moderator account assigned to handle this report, if any
+	AssignedAccount        *Account  `validate:"-" bun:"-"`                                                           // account corresponding to AssignedAccountID, if any
 }
+
+const (
+	// ReportCategorySpam -- the reported account is sending spam.
+	ReportCategorySpam = "spam"
+	// ReportCategoryLegal -- the reported content is illegal.
+	ReportCategoryLegal = "legal"
+	// ReportCategoryViolation -- the reported account broke one or more instance rules.
+	ReportCategoryViolation = "violation"
+	// ReportCategoryOther -- some other reason, the default.
+	ReportCategoryOther = "other"
+)
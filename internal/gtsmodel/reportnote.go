@@ -0,0 +1,36 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// ReportNote models a comment left on a report by an instance admin or moderator.
+// Notes are only ever shown to other admins and moderators, never to the reporter.
+//
+// Notes can be threaded, by replying to an earlier note on the same report.
+type ReportNote struct {
+	ID          string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt   time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt   time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	ReportID    string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // which report does this note belong to
+	Report      *Report   `validate:"-" bun:"-"`                                                           // report corresponding to ReportID
+	AccountID   string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // which account created this note
+	Account     *Account  `validate:"-" bun:"-"`                                                           // account corresponding to AccountID
+	InReplyToID string    `validate:",omitempty,ulid" bun:"type:CHAR(26),nullzero"`                        // id of the note on the same report that this note replies to, if any
+	Content     string    `validate:"required" bun:",nullzero,notnull"`                                    // text content of the note
+}
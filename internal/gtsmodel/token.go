@@ -39,4 +39,6 @@ type Token struct {
 	Refresh             string    `validate:"-" bun:",pk,nullzero,notnull,default:''"`                             // Refresh token, if present
 	RefreshCreateAt     time.Time `validate:"required_with=Refresh" bun:"type:timestamptz,nullzero"`               // Refresh created at, if refresh present
 	RefreshExpiresAt    time.Time `validate:"-" bun:"type:timestamptz,nullzero"`                                   // Refresh expires at -- null means the refresh token never expires
+	LastUsed            time.Time `validate:"-" bun:"type:timestamptz,nullzero"`                                   // Approximate time this token was last used to access the API
+	Name                string    `validate:"-" bun:",nullzero"`                                                   // Name given to this token by its owner, if it's a personal access token
 }
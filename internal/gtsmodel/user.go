@@ -56,4 +56,114 @@ type User struct {
 	ResetPasswordToken     string       `validate:"required_with=ResetPasswordSentAt" bun:",nullzero"`                   // The generated token that the user can use to reset their password
 	ResetPasswordSentAt    time.Time    `validate:"required_with=ResetPasswordToken" bun:"type:timestamptz,nullzero"`    // When did we email the user their reset-password email?
 	ExternalID             string       `validate:"-" bun:",nullzero,unique"`                                            // If the login for the user is managed externally (e.g OIDC), we need to keep a stable reference to the external object (e.g OIDC sub claim)
+	MediaQuota             *int64       `validate:"omitempty,min=0" bun:",nullzero"`                                     // Per-account override of the media storage quota in bytes; nil to use the instance default, 0 for no limit.
+	EmailMentions          string       `validate:"omitempty,oneof=off immediate digest" bun:",nullzero"`                // How should this user be emailed about mentions? Empty means the default for this type.
+	EmailDirect            string       `validate:"omitempty,oneof=off immediate digest" bun:",nullzero"`                // How should this user be emailed about direct messages? Empty means the default for this type.
+	EmailFollows           string       `validate:"omitempty,oneof=off immediate digest" bun:",nullzero"`                // How should this user be emailed about new followers? Empty means the default for this type.
+	EmailFollowRequests    string       `validate:"omitempty,oneof=off immediate digest" bun:",nullzero"`                // How should this user be emailed about follow requests? Empty means the default for this type.
+	EmailReportsClosed     string       `validate:"omitempty,oneof=off immediate digest" bun:",nullzero"`                // How should this user be emailed about their reports being closed? Empty means the default for this type.
+	EmailDigestFrequency   string       `validate:"omitempty,oneof=daily weekly" bun:",nullzero"`                        // How often should digest emails be sent to this user? Empty means daily.
+	LastDigestAt           time.Time    `validate:"-" bun:"type:timestamptz,nullzero"`                                   // When was this user last sent a digest email? Digests cover everything since then.
+	UnsubscribeToken       string       `validate:"-" bun:",nullzero,unique"`                                            // Token used in one-click unsubscribe links in emails sent to this user.
+}
+
+// EmailType is a kind of event that
+// a user can be notified of by email.
+type EmailType string
+
+const (
+	EmailTypeMentions       EmailType = "mentions"        // someone mentioned the user in a non-direct post
+	EmailTypeDirect         EmailType = "direct"          // someone sent the user a direct message
+	EmailTypeFollows        EmailType = "follows"         // someone followed the user
+	EmailTypeFollowRequests EmailType = "follow_requests" // someone requested to follow the user
+	EmailTypeReportsClosed  EmailType = "reports_closed"  // a report created by the user was closed
+)
+
+// EmailTypes contains all email types, in
+// the order they should be presented to users.
+var EmailTypes = []EmailType{
+	EmailTypeMentions,
+	EmailTypeDirect,
+	EmailTypeFollows,
+	EmailTypeFollowRequests,
+	EmailTypeReportsClosed,
+}
+
+// EmailPreference describes how a user
+// wants to be emailed about an EmailType.
+type EmailPreference string
+
+const (
+	EmailPreferenceOff       EmailPreference = "off"       // don't email about this at all
+	EmailPreferenceImmediate EmailPreference = "immediate" // email about this as soon as it happens
+	EmailPreferenceDigest    EmailPreference = "digest"    // include this in the periodic digest email
+)
+
+// EmailDigestFrequency describes how
+// often a user receives digest emails.
+type EmailDigestFrequency string
+
+const (
+	EmailDigestDaily  EmailDigestFrequency = "daily"
+	EmailDigestWeekly EmailDigestFrequency = "weekly"
+)
+
+// EmailPreference returns the user's preference for
+// being emailed about the given type, falling back to
+// the default preference if none has been set.
+//
+// By default, users are emailed immediately when their
+// reports are closed, and not emailed about anything else.
+func (u *User) EmailPreference(t EmailType) EmailPreference {
+	pref, def := u.emailPreferenceField(t)
+	if pref == nil || *pref == "" {
+		return def
+	}
+	return EmailPreference(*pref)
+}
+
+// SetEmailPreference sets the user's preference for being emailed
+// about the given type, returning the database column to update.
+func (u *User) SetEmailPreference(t EmailType, pref EmailPreference) string {
+	field, _ := u.emailPreferenceField(t)
+	if field == nil {
+		return ""
+	}
+	*field = string(pref)
+	return "email_" + string(t)
+}
+
+func (u *User) emailPreferenceField(t EmailType) (*string, EmailPreference) {
+	switch t {
+	case EmailTypeMentions:
+		return &u.EmailMentions, EmailPreferenceOff
+	case EmailTypeDirect:
+		return &u.EmailDirect, EmailPreferenceOff
+	case EmailTypeFollows:
+		return &u.EmailFollows, EmailPreferenceOff
+	case EmailTypeFollowRequests:
+		return &u.EmailFollowRequests, EmailPreferenceOff
+	case EmailTypeReportsClosed:
+		return &u.EmailReportsClosed, EmailPreferenceImmediate
+	default:
+		return nil, EmailPreferenceOff
+	}
+}
+
+// DigestFrequency returns how often the user should
+// be sent digest emails, defaulting to daily.
+func (u *User) DigestFrequency() EmailDigestFrequency {
+	if u.EmailDigestFrequency == "" {
+		return EmailDigestDaily
+	}
+	return EmailDigestFrequency(u.EmailDigestFrequency)
+}
+
+// CanBeEmailed returns true if the user has a confirmed
+// email address, and is approved and not disabled.
+func (u *User) CanBeEmailed() bool {
+	return u.Email != "" &&
+		!u.ConfirmedAt.IsZero() &&
+		(u.Approved != nil && *u.Approved) &&
+		(u.Disabled == nil || !*u.Disabled)
 }
@@ -0,0 +1,34 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// UserMute refers to one account muting another, hiding
+// the target's posts (and optionally notifications) from
+// the account doing the muting. Mutes are never federated.
+type UserMute struct {
+	ID              string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`            // id of this item in the database
+	CreatedAt       time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`     // when was item created
+	UpdatedAt       time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`     // when was item last updated
+	AccountID       string    `validate:"required,ulid" bun:"type:CHAR(26),unique:mutesrctarget,notnull,nullzero"` // Who does this mute originate from?
+	Account         *Account  `validate:"-" bun:"rel:belongs-to"`                                                  // Account corresponding to accountID
+	TargetAccountID string    `validate:"required,ulid" bun:"type:CHAR(26),unique:mutesrctarget,notnull,nullzero"` // Who is the target of this mute?
+	TargetAccount   *Account  `validate:"-" bun:"rel:belongs-to"`                                                  // Account corresponding to targetAccountID
+	Notifications   *bool     `validate:"-" bun:",nullzero,notnull,default:false"`                                 // Whether notifications from the target are muted too.
+}
@@ -164,6 +164,11 @@ type tempFileSeeker struct {
 	tmp *os.File
 }
 
+// Name returns the path of the underlying temporary file.
+func (tfs *tempFileSeeker) Name() string {
+	return tfs.tmp.Name()
+}
+
 func (tfs *tempFileSeeker) Close() error {
 	tfs.tmp.Close()
 	return os.Remove(tfs.tmp.Name())
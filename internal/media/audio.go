@@ -0,0 +1,867 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"io"
+	"math"
+
+	"github.com/abema/go-mp4"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/iotools"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// waveform thumbnail dimensions.
+	waveformWidth  = 512
+	waveformHeight = 256
+	waveformBars   = 64
+
+	// waveformSampleRate is the sample rate we ask
+	// ffmpeg to resample audio to when decoding PCM
+	// for a waveform. We only need the amplitude
+	// envelope, so this can be very low.
+	waveformSampleRate = 1000
+)
+
+type gtsAudio struct {
+	cover    *gtsImage // cover art, or generated waveform
+	duration float32   // in seconds
+	bitrate  uint64
+}
+
+// decodeAudio probes the given audio stream of given MIME type for metadata, and returns
+// either attached cover art, or a generated waveform image to be used as a thumbnail. Both
+// metadata probing and cover art extraction are attempted natively first, falling back to
+// the (optional) ffmpeg binary for cover art and waveform generation of compressed formats.
+func decodeAudio(ctx context.Context, r io.Reader, contentType string, ff *ffmpeg) (*gtsAudio, error) {
+	// we need a readseeker to probe the audio...
+	tfs, err := iotools.TempFileSeeker(r)
+	if err != nil {
+		return nil, fmt.Errorf("error creating temp file seeker: %w", err)
+	}
+	defer func() {
+		if err := tfs.Close(); err != nil {
+			log.Errorf(nil, "error closing temp file seeker: %s", err)
+		}
+	}()
+
+	// Determine total file size.
+	size, err := tfs.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, fmt.Errorf("error seeking audio: %w", err)
+	}
+
+	if _, err := tfs.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("error seeking audio: %w", err)
+	}
+
+	var (
+		audio audioInfo
+		wave  *waveform
+	)
+
+	switch contentType {
+	case mimeAudioMpeg:
+		audio, err = probeMP3(tfs, size)
+	case mimeAudioOgg:
+		audio, err = probeOgg(tfs, size)
+	case mimeAudioFlac:
+		audio, err = probeFLAC(tfs)
+	case mimeAudioMp4:
+		audio, err = probeM4A(tfs)
+	case mimeAudioWav:
+		audio, wave, err = probeWAV(tfs, size)
+	default:
+		err = fmt.Errorf("unsupported audio type: %s", contentType)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error probing audio: %w", err)
+	}
+
+	if audio.duration <= 0 {
+		return nil, errors.New("error determining audio metadata: [duration]")
+	}
+
+	if audio.bitrate == 0 {
+		// Calculate average bitrate from file size.
+		audio.bitrate = uint64(float64(size*8) / float64(audio.duration))
+	}
+
+	result := gtsAudio{
+		duration: audio.duration,
+		bitrate:  audio.bitrate,
+	}
+
+	if audio.cover != nil {
+		// Try decode natively extracted cover art.
+		img, err := decodeImage(bytes.NewReader(audio.cover))
+		if err != nil {
+			log.Warnf(ctx, "error decoding audio cover art: %v", err)
+		} else {
+			result.cover = img
+		}
+	}
+
+	var path string
+
+	if f, ok := tfs.(interface{ Name() string }); ok {
+		// ffmpeg needs the file path.
+		path = f.Name()
+	}
+
+	if result.cover == nil && ff != nil && path != "" {
+		// Attempt to extract attached picture (ffmpeg
+		// presents these as a single frame video stream).
+		if img, err := ff.extractFrame(ctx, path, 0); err == nil {
+			result.cover = img
+		}
+	}
+
+	if result.cover == nil && wave == nil && ff != nil && path != "" {
+		// Decode compressed audio to PCM for a waveform.
+		wave, err = ff.decodeWaveform(ctx, path, audio.duration)
+		if err != nil {
+			log.Warnf(ctx, "error decoding audio waveform: %v", err)
+		}
+	}
+
+	if result.cover == nil && wave != nil {
+		// Draw waveform from PCM samples.
+		result.cover = wave.image()
+	}
+
+	if result.cover == nil {
+		// Nothing else to go on.
+		result.cover = blankImage(waveformWidth, waveformHeight)
+	}
+
+	return &result, nil
+}
+
+// audioInfo is the metadata returned by native audio format probes.
+type audioInfo struct {
+	duration float32 // in seconds
+	bitrate  uint64  // may be 0 if unknown
+	cover    []byte  // encoded cover art image, if any
+}
+
+// probeWAV reads metadata from a RIFF WAVE stream of given total size.
+// For PCM encoded audio, a waveform is also generated from the sample data.
+func probeWAV(r io.ReadSeeker, size int64) (audioInfo, *waveform, error) {
+	var info audioInfo
+
+	hdr := make([]byte, 12)
+	if _, err := io.ReadFull(r, hdr); err != nil {
+		return info, nil, err
+	}
+
+	if string(hdr[0:4]) != "RIFF" || string(hdr[8:12]) != "WAVE" {
+		return info, nil, errors.New("not a wave file")
+	}
+
+	var (
+		format     uint16
+		channels   uint16
+		byteRate   uint32
+		blockAlign uint16
+		bits       uint16
+	)
+
+	for {
+		var chunk [8]byte
+		if _, err := io.ReadFull(r, chunk[:]); err != nil {
+			return info, nil, errors.New("no data chunk found")
+		}
+
+		id := string(chunk[0:4])
+		sz := int64(binary.LittleEndian.Uint32(chunk[4:8]))
+
+		// Chunk sizes are untrusted, so
+		// check against what's left in file.
+		pos, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return info, nil, err
+		}
+
+		if sz > size-pos {
+			return info, nil, fmt.Errorf("%q chunk size %d larger than remaining file", id, sz)
+		}
+
+		switch id {
+		case "fmt ":
+			if sz < 16 {
+				return info, nil, errors.New("invalid fmt chunk")
+			}
+
+			// Only read the fields we
+			// need, skip any remainder.
+			n := int64(16)
+			if sz >= 26 {
+				n = 26
+			}
+
+			fmtb := make([]byte, n)
+			if _, err := io.ReadFull(r, fmtb); err != nil {
+				return info, nil, err
+			}
+
+			if _, err := r.Seek(sz-n, io.SeekCurrent); err != nil {
+				return info, nil, err
+			}
+
+			format = binary.LittleEndian.Uint16(fmtb[0:2])
+			channels = binary.LittleEndian.Uint16(fmtb[2:4])
+			byteRate = binary.LittleEndian.Uint32(fmtb[8:12])
+			blockAlign = binary.LittleEndian.Uint16(fmtb[12:14])
+			bits = binary.LittleEndian.Uint16(fmtb[14:16])
+
+			if format == 0xFFFE && n >= 26 {
+				// WAVE_FORMAT_EXTENSIBLE, real format
+				// is in the first 2 bytes of subformat.
+				format = binary.LittleEndian.Uint16(fmtb[24:26])
+			}
+
+		case "data":
+			if byteRate == 0 {
+				return info, nil, errors.New("data chunk before fmt chunk")
+			}
+
+			info.duration = float32(float64(sz) / float64(byteRate))
+			info.bitrate = uint64(byteRate) * 8
+
+			if (format != 1 && format != 3) || channels == 0 || blockAlign == 0 {
+				// Non-PCM, can't draw waveform.
+				return info, nil, nil
+			}
+
+			wave, err := readPCMWaveform(io.LimitReader(r, sz), format, channels, bits, int(sz)/int(blockAlign))
+			if err != nil {
+				// Metadata is still fine.
+				return info, nil, nil
+			}
+
+			return info, wave, nil
+
+		default:
+			// Skip unneeded chunk.
+			if _, err := r.Seek(sz, io.SeekCurrent); err != nil {
+				return info, nil, err
+			}
+		}
+
+		if sz%2 == 1 {
+			// Chunks are word aligned.
+			if _, err := r.Seek(1, io.SeekCurrent); err != nil {
+				return info, nil, err
+			}
+		}
+	}
+}
+
+// readPCMWaveform reads interleaved PCM frames (integer or float) from r into a waveform.
+func readPCMWaveform(r io.Reader, format, channels, bits uint16, frames int) (*waveform, error) {
+	width := int(bits / 8)
+	if width == 0 || width > 4 || (format == 3 && width != 4) {
+		return nil, fmt.Errorf("unsupported pcm sample size: %d", bits)
+	}
+
+	var (
+		wave  = newWaveform(frames)
+		frame = make([]byte, width*int(channels))
+		br    = bufio.NewReader(r)
+	)
+
+	for i := 0; i < frames; i++ {
+		if _, err := io.ReadFull(br, frame); err != nil {
+			break
+		}
+
+		// Only use first channel.
+		s := frame[:width]
+
+		var sample float64
+
+		switch {
+		case format == 3:
+			sample = float64(math.Float32frombits(binary.LittleEndian.Uint32(s)))
+		case width == 1:
+			// 8-bit PCM is unsigned.
+			sample = (float64(s[0]) - 128) / 128
+		case width == 2:
+			sample = float64(int16(binary.LittleEndian.Uint16(s))) / (1 << 15)
+		case width == 3:
+			v := int32(uint32(s[0])<<8|uint32(s[1])<<16|uint32(s[2])<<24) >> 8
+			sample = float64(v) / (1 << 23)
+		case width == 4:
+			sample = float64(int32(binary.LittleEndian.Uint32(s))) / (1 << 31)
+		}
+
+		wave.add(sample)
+	}
+
+	return wave, nil
+}
+
+// mp3 bitrates (kbps) for MPEG-1 and MPEG-2/2.5 layer III, by bitrate index.
+var (
+	mp3BitratesV1 = [16]int{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 0}
+	mp3BitratesV2 = [16]int{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0}
+)
+
+// mp3 sample rates for MPEG-1, MPEG-2, MPEG-2.5, by sample rate index.
+var (
+	mp3SampleRatesV1  = [4]int{44100, 48000, 32000, 0}
+	mp3SampleRatesV2  = [4]int{22050, 24000, 16000, 0}
+	mp3SampleRatesV25 = [4]int{11025, 12000, 8000, 0}
+)
+
+// probeMP3 reads metadata from an MPEG layer III stream, including
+// cover art from any leading ID3v2 tag. VBR duration is taken from a
+// Xing / Info / VBRI header, else duration is estimated from bitrate.
+func probeMP3(r io.ReadSeeker, size int64) (audioInfo, error) {
+	var (
+		info   audioInfo
+		offset int64
+	)
+
+	hdr := make([]byte, 10)
+	if _, err := io.ReadFull(r, hdr); err != nil {
+		return info, err
+	}
+
+	if string(hdr[0:3]) == "ID3" {
+		bodysz := int64(syncsafe(hdr[6:10]))
+
+		tagsz := bodysz
+		if hdr[5]&0x10 != 0 {
+			// Footer present.
+			tagsz += 10
+		}
+
+		if tagsz > size-10 {
+			return info, fmt.Errorf("id3 tag size %d larger than remaining file", tagsz)
+		}
+
+		cover, err := id3v2Picture(r, hdr[3], bodysz)
+		if err != nil {
+			return info, err
+		}
+
+		info.cover = cover
+		offset = 10 + tagsz
+	}
+
+	// Read enough to find the first frame and its VBR header.
+	if _, err := r.Seek(offset, io.SeekStart); err != nil {
+		return info, err
+	}
+
+	buf := make([]byte, 8192)
+	n, _ := io.ReadFull(r, buf)
+	buf = buf[:n]
+
+	// Scan for first valid frame sync.
+	for i := 0; i+4 <= len(buf); i++ {
+		if buf[i] != 0xFF || buf[i+1]&0xE0 != 0xE0 {
+			continue
+		}
+
+		var (
+			version  = (buf[i+1] >> 3) & 0x3
+			layer    = (buf[i+1] >> 1) & 0x3
+			brIdx    = buf[i+2] >> 4
+			srIdx    = (buf[i+2] >> 2) & 0x3
+			mono     = buf[i+3]>>6 == 0x3
+			bitrates = mp3BitratesV1
+			rates    = mp3SampleRatesV1
+			spf      = 1152 // samples per frame
+			sideinfo = 32
+		)
+
+		if layer != 0x1 || version == 0x1 {
+			// Not layer III, or reserved version.
+			continue
+		}
+
+		switch version {
+		case 0x0: // MPEG-2.5
+			bitrates, rates = mp3BitratesV2, mp3SampleRatesV25
+		case 0x2: // MPEG-2
+			bitrates, rates = mp3BitratesV2, mp3SampleRatesV2
+		}
+
+		if version != 0x3 {
+			spf = 576
+			sideinfo = 17
+			if mono {
+				sideinfo = 9
+			}
+		} else if mono {
+			sideinfo = 17
+		}
+
+		bitrate := bitrates[brIdx] * 1000
+		rate := rates[srIdx]
+		if bitrate == 0 || rate == 0 {
+			// Free-format or bad frame.
+			continue
+		}
+
+		// Check for Xing / Info VBR header after side info.
+		if x := i + 4 + sideinfo; x+12 <= len(buf) {
+			tag := string(buf[x : x+4])
+			flags := binary.BigEndian.Uint32(buf[x+4 : x+8])
+			if (tag == "Xing" || tag == "Info") && flags&0x1 != 0 {
+				frames := binary.BigEndian.Uint32(buf[x+8 : x+12])
+				info.duration = float32(float64(frames) * float64(spf) / float64(rate))
+				return info, nil
+			}
+		}
+
+		// Check for VBRI header at fixed offset.
+		if x := i + 4 + 32; x+18 <= len(buf) && string(buf[x:x+4]) == "VBRI" {
+			frames := binary.BigEndian.Uint32(buf[x+14 : x+18])
+			info.duration = float32(float64(frames) * float64(spf) / float64(rate))
+			return info, nil
+		}
+
+		// Assume constant bitrate.
+		audiosz := size - offset - int64(i)
+		info.bitrate = uint64(bitrate)
+		info.duration = float32(float64(audiosz*8) / float64(bitrate))
+		return info, nil
+	}
+
+	return info, errors.New("no mpeg audio frame found")
+}
+
+// syncsafe decodes a 4 byte ID3v2 "syncsafe" integer.
+func syncsafe(b []byte) uint32 {
+	return uint32(b[0]&0x7F)<<21 |
+		uint32(b[1]&0x7F)<<14 |
+		uint32(b[2]&0x7F)<<7 |
+		uint32(b[3]&0x7F)
+}
+
+// id3v2Picture returns the image data of the first attached picture
+// (preferring the front cover) in the ID3v2 tag body of given size
+// at the current position of r, or nil. Frames are skipped over
+// rather than read, only picture frames small enough to be used as
+// an image attachment are read into memory.
+func id3v2Picture(r io.ReadSeeker, version byte, tagsz int64) ([]byte, error) {
+	var (
+		idsz   = 4
+		hdrsz  = 10
+		maxsz  = int64(config.GetMediaImageMaxSize())
+		found  []byte
+		header []byte
+	)
+
+	if version == 2 {
+		// ID3v2.2 uses shorter frame headers.
+		idsz, hdrsz = 3, 6
+	}
+
+	header = make([]byte, hdrsz)
+
+	for tagsz >= int64(hdrsz) {
+		if _, err := io.ReadFull(r, header); err != nil {
+			return nil, err
+		}
+		tagsz -= int64(hdrsz)
+
+		id := string(header[:idsz])
+		if id[0] == 0 {
+			// Reached padding.
+			break
+		}
+
+		var sz int64
+		switch version {
+		case 2:
+			sz = int64(header[3])<<16 | int64(header[4])<<8 | int64(header[5])
+		case 3:
+			sz = int64(binary.BigEndian.Uint32(header[4:8]))
+		default:
+			sz = int64(syncsafe(header[4:8]))
+		}
+
+		if sz > tagsz {
+			// Frame overruns tag.
+			break
+		}
+		tagsz -= sz
+
+		if id != "APIC" && id != "PIC" || sz < 4 || sz > maxsz {
+			// Skip unneeded frame.
+			if _, err := r.Seek(sz, io.SeekCurrent); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		body := make([]byte, sz)
+		if _, err := io.ReadFull(r, body); err != nil {
+			return nil, err
+		}
+
+		enc := body[0]
+		body = body[1:]
+
+		if id == "PIC" {
+			// 3 byte image format.
+			body = body[3:]
+		} else {
+			// Null-terminated MIME type.
+			i := bytes.IndexByte(body, 0)
+			if i < 0 {
+				continue
+			}
+			body = body[i+1:]
+		}
+
+		if len(body) < 1 {
+			continue
+		}
+
+		ptype := body[0]
+		body = skipID3String(body[1:], enc)
+
+		if ptype == 3 {
+			// Front cover.
+			return body, nil
+		}
+
+		if found == nil {
+			found = body
+		}
+	}
+
+	return found, nil
+}
+
+// skipID3String skips a null terminated string of given ID3 text encoding.
+func skipID3String(b []byte, enc byte) []byte {
+	if enc == 1 || enc == 2 {
+		// UTF-16, terminated by a 2 byte null.
+		for i := 0; i+1 < len(b); i += 2 {
+			if b[i] == 0 && b[i+1] == 0 {
+				return b[i+2:]
+			}
+		}
+		return nil
+	}
+
+	if i := bytes.IndexByte(b, 0); i >= 0 {
+		return b[i+1:]
+	}
+
+	return nil
+}
+
+// probeFLAC reads metadata from a native FLAC stream,
+// including any attached PICTURE metadata block.
+func probeFLAC(r io.Reader) (audioInfo, error) {
+	var info audioInfo
+
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return info, err
+	}
+
+	if string(magic) != "fLaC" {
+		return info, errors.New("not a flac file")
+	}
+
+	for {
+		var hdr [4]byte
+		if _, err := io.ReadFull(r, hdr[:]); err != nil {
+			return info, err
+		}
+
+		var (
+			last  = hdr[0]&0x80 != 0
+			btype = hdr[0] & 0x7F
+			sz    = int(hdr[1])<<16 | int(hdr[2])<<8 | int(hdr[3])
+		)
+
+		block := make([]byte, sz)
+		if _, err := io.ReadFull(r, block); err != nil {
+			return info, err
+		}
+
+		switch btype {
+		case 0: // STREAMINFO
+			if sz < 18 {
+				return info, errors.New("invalid streaminfo block")
+			}
+
+			v := binary.BigEndian.Uint64(block[10:18])
+			rate := v >> 44
+			samples := v & 0xFFFFFFFFF
+			if rate != 0 {
+				info.duration = float32(float64(samples) / float64(rate))
+			}
+
+		case 6: // PICTURE
+			if pic, ptype := flacPicture(block); pic != nil {
+				if ptype == 3 || info.cover == nil {
+					info.cover = pic
+				}
+			}
+		}
+
+		if last {
+			return info, nil
+		}
+	}
+}
+
+// flacPicture parses a FLAC PICTURE metadata block, returning image data and picture type.
+func flacPicture(b []byte) ([]byte, uint32) {
+	if len(b) < 8 {
+		return nil, 0
+	}
+
+	ptype := binary.BigEndian.Uint32(b[0:4])
+	b = b[4:]
+
+	// Skip MIME type and description.
+	for i := 0; i < 2; i++ {
+		if len(b) < 4 {
+			return nil, 0
+		}
+		n := int(binary.BigEndian.Uint32(b[0:4]))
+		if n < 0 || 4+n > len(b) {
+			return nil, 0
+		}
+		b = b[4+n:]
+	}
+
+	// Skip width, height, depth, colors.
+	if len(b) < 20 {
+		return nil, 0
+	}
+
+	n := int(binary.BigEndian.Uint32(b[16:20]))
+	b = b[20:]
+	if n < 0 || n > len(b) {
+		return nil, 0
+	}
+
+	return b[:n], ptype
+}
+
+// probeOgg reads metadata from an Ogg stream containing Vorbis
+// or Opus audio. Duration is calculated from the granule position
+// of the final page in the stream.
+func probeOgg(r io.ReadSeeker, size int64) (audioInfo, error) {
+	var info audioInfo
+
+	// Read the first page, containing the codec identification header.
+	first := make([]byte, 27+255)
+	n, err := io.ReadFull(r, first)
+	if err != nil && n < 28 {
+		return info, err
+	}
+	first = first[:n]
+
+	if string(first[0:4]) != "OggS" {
+		return info, errors.New("not an ogg file")
+	}
+
+	var (
+		serial  = binary.LittleEndian.Uint32(first[14:18])
+		nsegs   = int(first[26])
+		rate    uint32
+		preskip uint64
+	)
+
+	if 27+nsegs > len(first) {
+		return info, errors.New("invalid ogg page")
+	}
+
+	// Read start of first packet.
+	if _, err := r.Seek(int64(27+nsegs), io.SeekStart); err != nil {
+		return info, err
+	}
+
+	pkt := make([]byte, 19)
+	if _, err := io.ReadFull(r, pkt); err != nil {
+		return info, err
+	}
+
+	switch {
+	case string(pkt[0:7]) == "\x01vorbis":
+		rate = binary.LittleEndian.Uint32(pkt[12:16])
+	case string(pkt[0:8]) == "OpusHead":
+		// Opus granule positions are always at 48kHz.
+		rate = 48000
+		preskip = uint64(binary.LittleEndian.Uint16(pkt[10:12]))
+	default:
+		return info, errors.New("unsupported ogg codec")
+	}
+
+	if rate == 0 {
+		return info, errors.New("invalid sample rate")
+	}
+
+	// Search backwards from end of
+	// stream to find the final page.
+	tailsz := int64(65536)
+	if tailsz > size {
+		tailsz = size
+	}
+
+	if _, err := r.Seek(size-tailsz, io.SeekStart); err != nil {
+		return info, err
+	}
+
+	tail := make([]byte, tailsz)
+	if _, err := io.ReadFull(r, tail); err != nil {
+		return info, err
+	}
+
+	for i := len(tail) - 27; i >= 0; i-- {
+		if string(tail[i:i+4]) != "OggS" ||
+			binary.LittleEndian.Uint32(tail[i+14:i+18]) != serial {
+			continue
+		}
+
+		granule := binary.LittleEndian.Uint64(tail[i+6 : i+14])
+		if granule == math.MaxUint64 {
+			// No packets finish on this page.
+			continue
+		}
+
+		if granule > preskip {
+			granule -= preskip
+		}
+
+		info.duration = float32(float64(granule) / float64(rate))
+		return info, nil
+	}
+
+	return info, errors.New("no final ogg page found")
+}
+
+// probeM4A reads metadata from an MPEG-4 audio stream.
+func probeM4A(r io.ReadSeeker) (audioInfo, error) {
+	var info audioInfo
+
+	probe, err := mp4.Probe(r)
+	if err != nil {
+		return info, err
+	}
+
+	for _, tr := range probe.Tracks {
+		if tr.Timescale == 0 {
+			continue
+		}
+
+		if d := float32(float64(tr.Duration) / float64(tr.Timescale)); d > info.duration {
+			info.duration = d
+		}
+
+		if br := tr.Samples.GetBitrate(tr.Timescale); br > info.bitrate {
+			info.bitrate = br
+		} else if br := probe.Segments.GetBitrate(tr.TrackID, tr.Timescale); br > info.bitrate {
+			info.bitrate = br
+		}
+	}
+
+	return info, nil
+}
+
+// waveform accumulates the peak amplitudes of a
+// stream of audio samples into a fixed number of bars.
+type waveform struct {
+	peaks  []float64
+	perBar int // no. samples per bar
+	n      int // no. samples seen
+}
+
+// newWaveform returns a new waveform for given total number of samples.
+func newWaveform(samples int) *waveform {
+	perBar := samples / waveformBars
+	if perBar < 1 {
+		perBar = 1
+	}
+	return &waveform{
+		peaks:  make([]float64, waveformBars),
+		perBar: perBar,
+	}
+}
+
+// add adds a single sample, in the range [-1, 1], to the waveform.
+func (w *waveform) add(sample float64) {
+	bar := w.n / w.perBar
+	w.n++
+
+	if bar >= len(w.peaks) {
+		// Past expected end, e.g.
+		// inaccurate duration.
+		return
+	}
+
+	if sample = math.Abs(sample); sample > w.peaks[bar] {
+		w.peaks[bar] = math.Min(sample, 1)
+	}
+}
+
+// image draws the waveform as a bar graph.
+func (w *waveform) image() *gtsImage {
+	img := blankImage(waveformWidth, waveformHeight).image.(*image.RGBA)
+
+	var (
+		fg    = &image.Uniform{color.RGBA{255, 255, 255, 255}}
+		barW  = waveformWidth / waveformBars
+		mid   = waveformHeight / 2
+		scale = 0.0
+	)
+
+	// Normalize against loudest peak.
+	for _, peak := range w.peaks {
+		scale = math.Max(scale, peak)
+	}
+
+	for i, peak := range w.peaks {
+		h := 1 // always draw a line
+		if scale > 0 {
+			h = int(math.Max(1, peak/scale*float64(mid-8)))
+		}
+
+		x := i * barW
+		rect := image.Rect(x+1, mid-h, x+barW-1, mid+h)
+		draw.Draw(img, rect, fg, image.Point{}, draw.Src)
+	}
+
+	return &gtsImage{image: img}
+}
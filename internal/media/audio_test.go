@@ -0,0 +1,216 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+type AudioTestSuite struct {
+	suite.Suite
+}
+
+func (suite *AudioTestSuite) SetupTest() {
+	// Bounds the size of extracted cover art.
+	config.SetMediaImageMaxSize(10 * 1024 * 1024)
+}
+
+func (suite *AudioTestSuite) TestProbeWAV() {
+	f, err := os.Open("./test/test-wav-original.wav")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	info, wave, err := probeWAV(f, stat.Size())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.EqualValues(1, info.duration)
+	suite.EqualValues(128000, info.bitrate)
+	if !suite.NotNil(wave) {
+		suite.FailNow("wanted waveform for pcm audio")
+	}
+
+	// The test file fades in and out,
+	// so peak should be in the middle.
+	suite.Less(wave.peaks[0], wave.peaks[waveformBars/2])
+}
+
+func (suite *AudioTestSuite) TestProbeWAVOversizedChunk() {
+	var buf bytes.Buffer
+	buf.WriteString("RIFF")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(0))
+	buf.WriteString("WAVE")
+
+	// A fmt chunk claiming to be 4GiB.
+	buf.WriteString("fmt ")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(0xFFFFFFFF))
+	buf.Write(make([]byte, 16))
+
+	_, _, err := probeWAV(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	suite.ErrorContains(err, "larger than remaining file")
+}
+
+func (suite *AudioTestSuite) TestProbeMP3CBR() {
+	// Frame header: MPEG-1 layer III,
+	// 128kbps, 44.1kHz, joint stereo.
+	const frameLen = 417
+	header := []byte{0xFF, 0xFB, 0x90, 0x64}
+
+	var buf bytes.Buffer
+	for i := 0; i < 100; i++ {
+		frame := make([]byte, frameLen)
+		copy(frame, header)
+		buf.Write(frame)
+	}
+
+	info, err := probeMP3(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.EqualValues(128000, info.bitrate)
+
+	// 100 frames * 1152 samples / 44100Hz.
+	suite.InDelta(2.612, info.duration, 0.01)
+}
+
+func (suite *AudioTestSuite) TestProbeMP3XingCover() {
+	cover := []byte("not really a png")
+
+	// Build an ID3v2.3 APIC frame.
+	var apic bytes.Buffer
+	apic.WriteByte(0) // latin1
+	apic.WriteString("image/png\x00")
+	apic.WriteByte(3) // front cover
+	apic.WriteString("cover\x00")
+	apic.Write(cover)
+
+	var frames bytes.Buffer
+	frames.WriteString("APIC")
+	_ = binary.Write(&frames, binary.BigEndian, uint32(apic.Len()))
+	frames.Write([]byte{0, 0})
+	frames.Write(apic.Bytes())
+
+	var buf bytes.Buffer
+	buf.WriteString("ID3")
+	buf.Write([]byte{3, 0, 0})
+	sz := frames.Len()
+	buf.Write([]byte{byte(sz >> 21 & 0x7F), byte(sz >> 14 & 0x7F), byte(sz >> 7 & 0x7F), byte(sz & 0x7F)})
+	buf.Write(frames.Bytes())
+
+	// First frame: MPEG-1 layer III, 128kbps, 44.1kHz,
+	// joint stereo, with Xing header claiming 1000 frames.
+	frame := make([]byte, 417)
+	copy(frame, []byte{0xFF, 0xFB, 0x90, 0x64})
+	copy(frame[4+32:], "Xing")
+	binary.BigEndian.PutUint32(frame[4+32+4:], 0x1)
+	binary.BigEndian.PutUint32(frame[4+32+8:], 1000)
+	buf.Write(frame)
+
+	info, err := probeMP3(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(cover, info.cover)
+
+	// 1000 frames * 1152 samples / 44100Hz.
+	suite.InDelta(26.122, info.duration, 0.01)
+}
+
+func (suite *AudioTestSuite) TestProbeMP3OversizedTag() {
+	var buf bytes.Buffer
+	buf.WriteString("ID3")
+	buf.Write([]byte{3, 0, 0})
+
+	// Largest possible syncsafe size, ~256MB.
+	buf.Write([]byte{0x7F, 0x7F, 0x7F, 0x7F})
+	buf.Write(make([]byte, 417))
+
+	_, err := probeMP3(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	suite.ErrorContains(err, "larger than remaining file")
+}
+
+func (suite *AudioTestSuite) TestProbeFLAC() {
+	var buf bytes.Buffer
+	buf.WriteString("fLaC")
+
+	// Last STREAMINFO block: 44.1kHz,
+	// stereo, 16-bit, 88200 samples.
+	buf.Write([]byte{0x80, 0, 0, 34})
+	streaminfo := make([]byte, 34)
+	v := uint64(44100)<<44 | uint64(1)<<41 | uint64(15)<<36 | 88200
+	binary.BigEndian.PutUint64(streaminfo[10:18], v)
+	buf.Write(streaminfo)
+
+	info, err := probeFLAC(&buf)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.EqualValues(2, info.duration)
+}
+
+func (suite *AudioTestSuite) TestProbeOggOpus() {
+	page := func(granule uint64, packet []byte) []byte {
+		b := make([]byte, 27, 27+1+len(packet))
+		copy(b, "OggS")
+		binary.LittleEndian.PutUint64(b[6:14], granule)
+		binary.LittleEndian.PutUint32(b[14:18], 1234) // serial
+		b[26] = 1                                     // 1 segment
+		b = append(b, byte(len(packet)))
+		return append(b, packet...)
+	}
+
+	head := make([]byte, 19)
+	copy(head, "OpusHead")
+	head[8] = 1                                      // version
+	head[9] = 2                                      // channels
+	binary.LittleEndian.PutUint16(head[10:12], 312)  // pre-skip
+	binary.LittleEndian.PutUint32(head[12:16], 4800) // input rate
+
+	var buf bytes.Buffer
+	buf.Write(page(0, head))
+	buf.Write(page(0, []byte("OpusTags")))
+	buf.Write(page(48000*3+312, []byte("audio")))
+
+	info, err := probeOgg(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.EqualValues(3, info.duration)
+}
+
+func TestAudioTestSuite(t *testing.T) {
+	suite.Run(t, &AudioTestSuite{})
+}
@@ -0,0 +1,161 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"image/png"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ffmpegTimeout is the maximum time we allow
+// a single ffmpeg invocation to run before it's
+// killed, to prevent malicious or broken media
+// from tying up the media worker pool forever.
+const ffmpegTimeout = 30 * time.Second
+
+// ffmpeg wraps an (optional) external ffmpeg binary,
+// used to decode media that we're not able to decode
+// natively in Go, e.g. H.264 video frames.
+type ffmpeg struct{ path string }
+
+// newFFmpeg attempts to locate the ffmpeg binary at
+// given path (or name, to be searched for in $PATH),
+// returning nil if not configured or not found.
+func newFFmpeg(path string) *ffmpeg {
+	if path == "" {
+		// Explicitly disabled.
+		return nil
+	}
+
+	// Resolve the ffmpeg binary location.
+	bin, err := exec.LookPath(path)
+	if err != nil {
+		return nil
+	}
+
+	return &ffmpeg{path: bin}
+}
+
+// extractFrame decodes a single frame at given timestamp
+// (in seconds) from the video file at given file path.
+func (f *ffmpeg) extractFrame(ctx context.Context, filepath string, at float32) (*gtsImage, error) {
+	ctx, cncl := context.WithTimeout(ctx, ffmpegTimeout)
+	defer cncl()
+
+	var stdout, stderr bytes.Buffer
+
+	// Seek to position BEFORE opening input, so ffmpeg
+	// uses fast keyframe seeking, then output a single
+	// video frame to stdout in PNG format.
+	cmd := exec.CommandContext(ctx, f.path,
+		"-hide_banner",
+		"-loglevel", "error",
+		"-nostdin",
+		"-ss", strconv.FormatFloat(float64(at), 'f', 3, 32),
+		"-i", filepath,
+		"-an", "-sn", "-dn",
+		"-frames:v", "1",
+		"-f", "image2pipe",
+		"-c:v", "png",
+		"pipe:1",
+	)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, errors.New(msg)
+		}
+		return nil, err
+	}
+
+	if stdout.Len() == 0 {
+		// Can happen when seeking past final frame.
+		return nil, errors.New("no frame decoded")
+	}
+
+	img, err := png.Decode(&stdout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gtsImage{image: img}, nil
+}
+
+// decodeWaveform decodes the audio file at given file path to mono 16-bit PCM
+// at a low sample rate, accumulating the samples into a waveform on the fly.
+func (f *ffmpeg) decodeWaveform(ctx context.Context, filepath string, duration float32) (*waveform, error) {
+	ctx, cncl := context.WithTimeout(ctx, ffmpegTimeout)
+	defer cncl()
+
+	var stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, f.path,
+		"-hide_banner",
+		"-loglevel", "error",
+		"-nostdin",
+		"-i", filepath,
+		"-vn", "-sn", "-dn",
+		"-ac", "1",
+		"-ar", strconv.Itoa(waveformSampleRate),
+		"-f", "s16le",
+		"pipe:1",
+	)
+	cmd.Stderr = &stderr
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	var (
+		wave = newWaveform(int(duration * waveformSampleRate))
+		br   = bufio.NewReader(stdout)
+		b    [2]byte
+	)
+
+	for {
+		if _, err := io.ReadFull(br, b[:]); err != nil {
+			break
+		}
+		sample := int16(binary.LittleEndian.Uint16(b[:]))
+		wave.add(float64(sample) / (1 << 15))
+	}
+
+	if err := cmd.Wait(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, errors.New(msg)
+		}
+		return nil, err
+	}
+
+	return wave, nil
+}
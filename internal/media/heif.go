@@ -0,0 +1,114 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/h2non/filetype"
+	"github.com/h2non/filetype/matchers/isobmff"
+	"github.com/superseriousbusiness/gotosocial/internal/iotools"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+func init() {
+	// filetype has no AVIF support, and its HEIF and MOV matchers
+	// are respectively too strict and too loose (the latter matching
+	// many regular MP4s). Registering a matcher for an existing type
+	// replaces the built-in one, so swap in our own ftyp brand checks.
+	filetype.AddMatcher(filetype.GetType("mov"), isQuickTime)
+	filetype.AddMatcher(filetype.GetType("heif"), isHEIF)
+	filetype.AddMatcher(filetype.AddType("avif", mimeImageAvif), isAVIF)
+}
+
+// isQuickTime returns whether the file header
+// has the ftyp brand of an Apple QuickTime movie.
+func isQuickTime(buf []byte) bool {
+	return hasFtypBrand(buf, "qt  ")
+}
+
+// isHEIF returns whether the file header has
+// the ftyp brand of an HEVC-encoded HEIF image.
+func isHEIF(buf []byte) bool {
+	return !isAVIF(buf) && hasFtypBrand(buf, "heic", "heix")
+}
+
+// isAVIF returns whether the file header has the ftyp
+// brand of an AV1-encoded (still or animated) image.
+func isAVIF(buf []byte) bool {
+	return hasFtypBrand(buf, "avif", "avis")
+}
+
+// hasFtypBrand returns whether the ISO base media file header
+// has any of given brands as its major or compatible brand.
+func hasFtypBrand(buf []byte, brands ...string) bool {
+	if !isobmff.IsISOBMFF(buf) {
+		return false
+	}
+
+	major, _, compatible := isobmff.GetFtyp(buf)
+
+	for _, brand := range brands {
+		if major == brand {
+			return true
+		}
+
+		for _, c := range compatible {
+			if c == brand {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// decodeHEIF decodes and returns the primary image from an HEIF / AVIF file.
+// Neither format can be decoded natively, so this requires ffmpeg.
+func decodeHEIF(ctx context.Context, r io.Reader, ff *ffmpeg) (*gtsImage, error) {
+	if ff == nil {
+		return nil, errors.New("ffmpeg is required to decode heif / avif images")
+	}
+
+	// ffmpeg requires a file on disk.
+	tfs, err := iotools.TempFileSeeker(r)
+	if err != nil {
+		return nil, fmt.Errorf("error creating temp file seeker: %w", err)
+	}
+	defer func() {
+		if err := tfs.Close(); err != nil {
+			log.Errorf(nil, "error closing temp file seeker: %s", err)
+		}
+	}()
+
+	f, ok := tfs.(interface{ Name() string })
+	if !ok {
+		return nil, errors.New("temp file seeker has no file path")
+	}
+
+	// Decode the first (i.e. primary) frame.
+	img, err := ff.extractFrame(ctx, f.Name(), 0)
+	if err != nil {
+		return nil, fmt.Errorf("error extracting image: %w", err)
+	}
+
+	return img, nil
+}
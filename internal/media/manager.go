@@ -26,6 +26,7 @@ import (
 
 	"codeberg.org/gruf/go-iotools"
 	"codeberg.org/gruf/go-store/v2/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
@@ -39,7 +40,17 @@ var SupportedMIMETypes = []string{
 	mimeImageGif,
 	mimeImagePng,
 	mimeImageWebp,
+	mimeImageHeif,
+	mimeImageAvif,
 	mimeVideoMp4,
+	mimeVideoWebm,
+	mimeVideoMatroska,
+	mimeVideoQuicktime,
+	mimeAudioMpeg,
+	mimeAudioOgg,
+	mimeAudioFlac,
+	mimeAudioMp4,
+	mimeAudioWav,
 }
 
 var SupportedEmojiMIMETypes = []string{
@@ -48,7 +59,8 @@ var SupportedEmojiMIMETypes = []string{
 }
 
 type Manager struct {
-	state *state.State
+	state  *state.State
+	ffmpeg *ffmpeg // may be nil
 }
 
 // NewManager returns a media manager with the given db and underlying storage.
@@ -57,8 +69,21 @@ type Manager struct {
 // a limited number of media will be processed in parallel. The numbers of workers
 // is determined from the $GOMAXPROCS environment variable (usually no. CPU cores).
 // See internal/concurrency.NewWorkerPool() documentation for further information.
+//
+// If configured, an external ffmpeg binary will also be looked up, to be used for
+// decoding video frames. If not found, videos will be given blank thumbnails.
 func NewManager(state *state.State) *Manager {
 	m := &Manager{state: state}
+
+	if path := config.GetMediaFFmpegPath(); path != "" {
+		m.ffmpeg = newFFmpeg(path)
+		if m.ffmpeg == nil {
+			log.Warnf(nil, "ffmpeg not found at %s, video thumbnails will be blank", path)
+		} else {
+			log.Infof(nil, "using ffmpeg at %s for video thumbnails", m.ffmpeg.path)
+		}
+	}
+
 	return m
 }
 
@@ -242,7 +267,7 @@ func (m *Manager) PreProcessEmoji(ctx context.Context, data DataFunc, shortcode
 
 			// Wrap closer to cleanup old data.
 			c := iotools.CloserCallback(rc, func() {
-				if err := m.state.Storage.Delete(ctx, originalImagePath); err != nil && !errors.Is(err, storage.ErrNotFound) {
+				if err := m.state.Storage.Release(ctx, m.state.DB, originalImagePath); err != nil {
 					log.Errorf(ctx, "error removing old emoji %s@%s from storage: %v", emoji.Shortcode, emoji.Domain, err)
 				}
 
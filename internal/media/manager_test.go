@@ -27,8 +27,10 @@ import (
 	"testing"
 	"time"
 
+	"codeberg.org/gruf/go-bytesize"
 	"codeberg.org/gruf/go-store/v2/storage"
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
@@ -452,6 +454,70 @@ func (suite *ManagerTestSuite) TestSlothVineProcessBlocking() {
 	suite.Equal(processedThumbnailBytesExpected, processedThumbnailBytes)
 }
 
+func (suite *ManagerTestSuite) TestWavProcessBlocking() {
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test audio file
+		b, err := os.ReadFile("./test/test-wav-original.wav")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processingMedia, err := suite.manager.ProcessMedia(ctx, data, accountID, nil)
+	suite.NoError(err)
+	// fetch the attachment id from the processing media
+	attachmentID := processingMedia.AttachmentID()
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// make sure it's got the stuff set on it that we expect
+	// the attachment ID and accountID we expect
+	suite.Equal(attachmentID, attachment.ID)
+	suite.Equal(accountID, attachment.AccountID)
+
+	// file meta should be correctly derived from the audio,
+	// with the generated waveform used as the thumbnail
+	suite.Equal(gtsmodel.FileTypeAudio, attachment.Type)
+	suite.Zero(attachment.FileMeta.Original.Width)
+	suite.Zero(attachment.FileMeta.Original.Height)
+	suite.EqualValues(1, *attachment.FileMeta.Original.Duration)
+	suite.EqualValues(128000, *attachment.FileMeta.Original.Bitrate)
+	suite.EqualValues(gtsmodel.Small{
+		Width: 512, Height: 256, Size: 131072, Aspect: 2,
+	}, attachment.FileMeta.Small)
+	suite.Equal("audio/wav", attachment.File.ContentType)
+	suite.Equal("image/jpeg", attachment.Thumbnail.ContentType)
+	suite.Equal(16044, attachment.File.FileSize)
+	suite.NotEmpty(attachment.Blurhash)
+
+	// now make sure the attachment is in the database
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachmentID)
+	suite.NoError(err)
+	suite.NotNil(dbAttachment)
+
+	// the stored audio should be the original, untouched
+	processedFullBytes, err := suite.storage.Get(ctx, attachment.File.Path)
+	suite.NoError(err)
+
+	originalBytes, err := os.ReadFile("./test/test-wav-original.wav")
+	suite.NoError(err)
+	suite.Equal(originalBytes, processedFullBytes)
+
+	// the waveform thumbnail should be in storage
+	processedThumbnailBytes, err := suite.storage.Get(ctx, attachment.Thumbnail.Path)
+	suite.NoError(err)
+	suite.NotEmpty(processedThumbnailBytes)
+}
+
 func (suite *ManagerTestSuite) TestLongerMp4ProcessBlocking() {
 	ctx := context.Background()
 
@@ -604,8 +670,9 @@ func (suite *ManagerTestSuite) TestBirdnestMp4ProcessBlocking() {
 	suite.Equal(processedThumbnailBytesExpected, processedThumbnailBytes)
 }
 
-func (suite *ManagerTestSuite) TestNotAnMp4ProcessBlocking() {
-	// try to load an 'mp4' that's actually an mkv in disguise
+func (suite *ManagerTestSuite) TestHEVCMp4ProcessBlocking() {
+	// load an mp4 with an HEVC video track, the codec of which
+	// isn't understood by go-mp4, so the track headers are used
 
 	ctx := context.Background()
 
@@ -620,14 +687,70 @@ func (suite *ManagerTestSuite) TestNotAnMp4ProcessBlocking() {
 
 	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
 
-	// pre processing should go fine but...
+	// process the media with no additional info provided
 	processingMedia, err := suite.manager.ProcessMedia(ctx, data, accountID, nil)
 	suite.NoError(err)
 
-	// we should get an error while loading
+	// do a blocking call to fetch the attachment
 	attachment, err := processingMedia.LoadAttachment(ctx)
-	suite.EqualError(err, "finish: error decoding video: error determining video metadata: [width height framerate]")
-	suite.Nil(attachment)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// file meta should be correctly derived from the video
+	suite.Equal(gtsmodel.FileTypeVideo, attachment.Type)
+	suite.Equal(1920, attachment.FileMeta.Original.Width)
+	suite.Equal(1080, attachment.FileMeta.Original.Height)
+	suite.Equal(2073600, attachment.FileMeta.Original.Size)
+	suite.EqualValues(1.7777778, attachment.FileMeta.Original.Aspect)
+	suite.EqualValues(3.878875, *attachment.FileMeta.Original.Duration)
+	suite.EqualValues(23.976025, *attachment.FileMeta.Original.Framerate)
+	suite.EqualValues(0x3920ab, *attachment.FileMeta.Original.Bitrate)
+	suite.EqualValues(gtsmodel.Small{
+		Width: 512, Height: 288, Size: 147456, Aspect: 1.7777778,
+	}, attachment.FileMeta.Small)
+	suite.Equal("video/mp4", attachment.File.ContentType)
+	suite.Equal("image/jpeg", attachment.Thumbnail.ContentType)
+	suite.Equal(1819035, attachment.File.FileSize)
+	suite.Equal("L00000fQfQfQfQfQfQfQfQfQfQfQ", attachment.Blurhash)
+}
+
+func (suite *ManagerTestSuite) TestImageTooLargeProcessBlocking() {
+	ctx := context.Background()
+
+	// Video limit is large enough, but image limit isn't.
+	config.SetMediaImageMaxSize(100 * bytesize.KiB)
+
+	for _, size := range []int64{
+		269739, // size given up front
+		-1,     // size only known once stored
+	} {
+		size := size
+		data := func(_ context.Context) (io.ReadCloser, int64, error) {
+			// load bytes from a test image
+			b, err := os.ReadFile("./test/test-jpeg.jpg")
+			if err != nil {
+				panic(err)
+			}
+			return io.NopCloser(bytes.NewBuffer(b)), size, nil
+		}
+
+		accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+		// pre processing should go fine but...
+		processingMedia, err := suite.manager.ProcessMedia(ctx, data, accountID, nil)
+		suite.NoError(err)
+
+		// we should get an error while loading
+		attachment, err := processingMedia.LoadAttachment(ctx)
+		suite.ErrorContains(err, "greater than max allowed 100kiB")
+		suite.Nil(attachment)
+
+		// and the file shouldn't be left behind in storage
+		path := fmt.Sprintf("%s/attachment/original/%s.jpeg", accountID, processingMedia.AttachmentID())
+		have, err := suite.storage.Has(ctx, path)
+		suite.NoError(err)
+		suite.False(have)
+	}
 }
 
 func (suite *ManagerTestSuite) TestSimpleJpegProcessBlockingNoContentLengthGiven() {
@@ -1248,6 +1371,51 @@ func (suite *ManagerTestSuite) TestSimpleJpegProcessBlockingWithDiskStorage() {
 	suite.Equal(processedThumbnailBytesExpected, processedThumbnailBytes)
 }
 
+func (suite *ManagerTestSuite) TestDuplicateJpegProcessBlocking() {
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test image
+		b, err := os.ReadFile("./test/test-jpeg.jpg")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	var attachments []*gtsmodel.MediaAttachment
+
+	// process the same media for two different accounts
+	for _, accountID := range []string{
+		"01FS1X72SK9ZPW0J1QQ68BD264",
+		"01F8MH1H7YV1Z7D2C8K2730QBF",
+	} {
+		processingMedia, err := suite.manager.ProcessMedia(ctx, data, accountID, nil)
+		suite.NoError(err)
+
+		attachment, err := processingMedia.LoadAttachment(ctx)
+		suite.NoError(err)
+		suite.NotNil(attachment)
+
+		attachments = append(attachments, attachment)
+	}
+
+	// both originals should be stored once under their content hash
+	suite.True(gtsstorage.IsBlobKey(attachments[0].File.Path))
+	suite.Equal(attachments[0].File.Path, attachments[1].File.Path)
+	suite.NotEqual(attachments[0].Thumbnail.Path, attachments[1].Thumbnail.Path)
+	suite.NotEqual(attachments[0].URL, attachments[1].URL)
+
+	refs, err := suite.db.GetBlobRefs(ctx, attachments[0].File.Path)
+	suite.NoError(err)
+	suite.Equal(2, refs)
+
+	// the stored file should be the processed (exif stripped) image
+	stored, err := suite.storage.Get(ctx, attachments[0].File.Path)
+	suite.NoError(err)
+	suite.Len(stored, attachments[0].File.FileSize)
+}
+
 func TestManagerTestSuite(t *testing.T) {
 	suite.Run(t, &ManagerTestSuite{})
 }
@@ -0,0 +1,457 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Matroska / WebM EBML element IDs that we're interested
+// in. Element IDs are unique across the whole spec, so we
+// can get away with matching these regardless of depth.
+//
+// See: https://www.matroska.org/technical/elements.html
+const (
+	ebmlIDHeader          = 0x1A45DFA3
+	ebmlIDSegment         = 0x18538067
+	ebmlIDInfo            = 0x1549A966
+	ebmlIDTimecodeScale   = 0x2AD7B1
+	ebmlIDDuration        = 0x4489
+	ebmlIDTracks          = 0x1654AE6B
+	ebmlIDTrackEntry      = 0xAE
+	ebmlIDTrackNumber     = 0xD7
+	ebmlIDTrackType       = 0x83
+	ebmlIDDefaultDuration = 0x23E383
+	ebmlIDVideo           = 0xE0
+	ebmlIDPixelWidth      = 0xB0
+	ebmlIDPixelHeight     = 0xBA
+	ebmlIDCluster         = 0x1F43B675
+	ebmlIDTimecode        = 0xE7
+	ebmlIDBlockGroup      = 0xA0
+	ebmlIDBlock           = 0xA1
+	ebmlIDSimpleBlock     = 0xA3
+)
+
+// matroskaTrackVideo is the
+// Matroska video track type.
+const matroskaTrackVideo = 1
+
+// ebmlUnknownSize is returned as element size
+// for "unknown-sized" elements, i.e. those
+// written by live streaming muxers.
+const ebmlUnknownSize = math.MaxUint64
+
+// maxEBMLValueSize is the largest (non-master, non-block)
+// element body that we're willing to read into memory.
+const maxEBMLValueSize = 64
+
+// matroskaTrack contains the track details we care about.
+type matroskaTrack struct {
+	number          uint64
+	typ             uint64
+	width           uint64
+	height          uint64
+	defaultDuration uint64 // in nanoseconds
+	frames          uint64 // no. blocks counted while scanning clusters
+}
+
+// probeMatroska reads container and track metadata from a Matroska or WebM file. Where
+// the file doesn't declare a duration (common for files written by browser MediaRecorder)
+// or video frame rate, the clusters are scanned for block timestamps to calculate these.
+func probeMatroska(rs io.ReadSeeker) (*videoInfo, error) {
+	size, err := rs.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := rs.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	r := &ebmlReader{rs: rs, size: size}
+
+	// Check we're actually dealing with an EBML document.
+	if id, sz, err := r.readElementHeader(); err != nil {
+		return nil, err
+	} else if id != ebmlIDHeader {
+		return nil, fmt.Errorf("invalid ebml header %x", id)
+	} else if err := r.skip(sz); err != nil {
+		return nil, err
+	}
+
+	var (
+		scale    uint64  = 1000000 // default timecode scale (1ms)
+		duration float64           // in timecode scale units
+		tracks   []*matroskaTrack
+		track    *matroskaTrack
+
+		clusterTime uint64 // current cluster timecode
+		lastBlock   uint64 // latest block timecode seen
+		scanning    bool   // whether we're scanning clusters
+	)
+
+	// needScan returns whether we
+	// need to scan cluster blocks
+	// to determine missing info.
+	needScan := func() bool {
+		if duration == 0 {
+			return true
+		}
+		for _, t := range tracks {
+			if t.typ == matroskaTrackVideo &&
+				t.defaultDuration == 0 {
+				return true
+			}
+		}
+		return false
+	}
+
+	// trackByNumber returns the track
+	// with given number, if it exists.
+	trackByNumber := func(n uint64) *matroskaTrack {
+		for _, t := range tracks {
+			if t.number == n {
+				return t
+			}
+		}
+		return nil
+	}
+
+loop:
+	for {
+		id, sz, err := r.readElementHeader()
+		if err != nil {
+			if errors.Is(err, io.EOF) ||
+				errors.Is(err, io.ErrUnexpectedEOF) {
+				// End of (possibly
+				// truncated) file.
+				break
+			}
+			return nil, err
+		}
+
+		switch id {
+		// Master elements we want to
+		// descend into; we do this by
+		// simply not skipping the body.
+		case ebmlIDSegment,
+			ebmlIDInfo,
+			ebmlIDTracks,
+			ebmlIDVideo,
+			ebmlIDBlockGroup:
+
+		case ebmlIDTrackEntry:
+			track = new(matroskaTrack)
+			tracks = append(tracks, track)
+
+		case ebmlIDCluster:
+			if !scanning {
+				if !needScan() {
+					// All metadata found.
+					break loop
+				}
+				scanning = true
+			}
+
+		case ebmlIDTimecodeScale:
+			if scale, err = r.readUint(sz); err != nil {
+				return nil, err
+			}
+
+		case ebmlIDDuration:
+			if duration, err = r.readFloat(sz); err != nil {
+				return nil, err
+			}
+
+		case ebmlIDTrackNumber, ebmlIDTrackType,
+			ebmlIDDefaultDuration, ebmlIDPixelWidth,
+			ebmlIDPixelHeight:
+			v, err := r.readUint(sz)
+			if err != nil {
+				return nil, err
+			}
+
+			if track == nil {
+				// Malformed, track details
+				// outside of a track entry.
+				continue
+			}
+
+			switch id {
+			case ebmlIDTrackNumber:
+				track.number = v
+			case ebmlIDTrackType:
+				track.typ = v
+			case ebmlIDDefaultDuration:
+				track.defaultDuration = v
+			case ebmlIDPixelWidth:
+				track.width = v
+			case ebmlIDPixelHeight:
+				track.height = v
+			}
+
+		case ebmlIDTimecode:
+			if clusterTime, err = r.readUint(sz); err != nil {
+				return nil, err
+			}
+
+		case ebmlIDSimpleBlock, ebmlIDBlock:
+			n, rel, err := r.readBlockHeader(sz)
+			if err != nil {
+				if errors.Is(err, io.EOF) ||
+					errors.Is(err, io.ErrUnexpectedEOF) {
+					// Truncated file, work
+					// with what we've got.
+					break loop
+				}
+				return nil, err
+			}
+
+			// Calculate absolute block time, ignoring
+			// negative values (i.e. pre-roll) that may
+			// be present at the start of a stream.
+			if abs := int64(clusterTime) + int64(rel); abs > int64(lastBlock) {
+				lastBlock = uint64(abs)
+			}
+
+			if t := trackByNumber(n); t != nil {
+				t.frames++
+			}
+
+		default:
+			if sz == ebmlUnknownSize {
+				return nil, fmt.Errorf("unknown size for element %x", id)
+			}
+
+			// Skip over the element body.
+			if err := r.skip(sz); err != nil {
+				if errors.Is(err, io.ErrUnexpectedEOF) {
+					// Truncated file, work
+					// with what we've got.
+					break loop
+				}
+				return nil, err
+			}
+		}
+	}
+
+	var info videoInfo
+
+	if duration > 0 {
+		info.duration = float32(duration * float64(scale) / 1e9)
+	} else {
+		info.duration = float32(float64(lastBlock) * float64(scale) / 1e9)
+	}
+
+	for _, t := range tracks {
+		if t.typ != matroskaTrackVideo {
+			continue
+		}
+
+		if w := int(t.width); w > info.width {
+			info.width = w
+		}
+
+		if h := int(t.height); h > info.height {
+			info.height = h
+		}
+
+		if info.framerate != 0 {
+			continue
+		}
+
+		if t.defaultDuration > 0 {
+			info.framerate = float32(1e9 / float64(t.defaultDuration))
+		} else if t.frames > 0 && info.duration > 0 {
+			info.framerate = float32(t.frames) / info.duration
+		}
+	}
+
+	if info.duration > 0 {
+		// Matroska has no per-track bitrate info, so
+		// the best we can do is average the whole file.
+		info.bitrate = uint64(float64(size*8) / float64(info.duration))
+	}
+
+	return &info, nil
+}
+
+// ebmlReader wraps an io.ReadSeeker
+// to provide EBML decoding utilities.
+type ebmlReader struct {
+	rs   io.ReadSeeker
+	size int64
+	buf  [maxEBMLValueSize]byte
+}
+
+// readElementHeader reads the ID and body size of the next element.
+func (r *ebmlReader) readElementHeader() (id uint64, sz uint64, err error) {
+	// IDs keep their length marker bits.
+	if id, _, err = r.readVint(4, true); err != nil {
+		return 0, 0, err
+	}
+
+	// Sizes have length marker bits removed.
+	if sz, _, err = r.readVint(8, false); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, 0, err
+	}
+
+	return id, sz, nil
+}
+
+// readVint reads an EBML variable length integer of up to
+// max bytes, returning the value and its length in bytes.
+func (r *ebmlReader) readVint(max int, keepMarker bool) (uint64, int, error) {
+	if _, err := io.ReadFull(r.rs, r.buf[:1]); err != nil {
+		return 0, 0, err
+	}
+
+	// Length is determined by the
+	// position of first set bit.
+	first := r.buf[0]
+	n := 1
+	for mask := byte(0x80); n <= 8 && first&mask == 0; mask >>= 1 {
+		n++
+	}
+
+	if n > max {
+		return 0, 0, fmt.Errorf("invalid ebml vint length %d", n)
+	}
+
+	if _, err := io.ReadFull(r.rs, r.buf[1:n]); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, 0, err
+	}
+
+	var (
+		v       uint64
+		allOnes = true
+	)
+
+	for i := 0; i < n; i++ {
+		b := r.buf[i]
+		if i == 0 && !keepMarker {
+			// Drop the marker bit.
+			b &= 0xFF >> n
+			allOnes = b == 0xFF>>n
+		} else if !keepMarker {
+			allOnes = allOnes && b == 0xFF
+		}
+		v = v<<8 | uint64(b)
+	}
+
+	if !keepMarker && allOnes {
+		// Reserved "unknown" value.
+		return ebmlUnknownSize, n, nil
+	}
+
+	return v, n, nil
+}
+
+// readUint reads an unsigned integer element body of given size.
+func (r *ebmlReader) readUint(sz uint64) (uint64, error) {
+	if sz > 8 {
+		return 0, fmt.Errorf("invalid ebml uint size %d", sz)
+	}
+
+	if _, err := io.ReadFull(r.rs, r.buf[:sz]); err != nil {
+		return 0, err
+	}
+
+	var v uint64
+	for _, b := range r.buf[:sz] {
+		v = v<<8 | uint64(b)
+	}
+
+	return v, nil
+}
+
+// readFloat reads a float element body of given size.
+func (r *ebmlReader) readFloat(sz uint64) (float64, error) {
+	switch sz {
+	case 0:
+		return 0, nil
+	case 4, 8:
+	default:
+		return 0, fmt.Errorf("invalid ebml float size %d", sz)
+	}
+
+	if _, err := io.ReadFull(r.rs, r.buf[:sz]); err != nil {
+		return 0, err
+	}
+
+	if sz == 4 {
+		bits := binary.BigEndian.Uint32(r.buf[:4])
+		return float64(math.Float32frombits(bits)), nil
+	}
+
+	bits := binary.BigEndian.Uint64(r.buf[:8])
+	return math.Float64frombits(bits), nil
+}
+
+// readBlockHeader reads the track number and relative timecode from the
+// header of a (Simple)Block of given size, skipping over the frame data.
+func (r *ebmlReader) readBlockHeader(sz uint64) (uint64, int16, error) {
+	track, n, err := r.readVint(8, false)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Relative timecode (int16), then flags byte.
+	if _, err := io.ReadFull(r.rs, r.buf[:3]); err != nil {
+		return 0, 0, err
+	}
+
+	rel := int16(binary.BigEndian.Uint16(r.buf[:2]))
+
+	if hdr := uint64(n) + 3; sz > hdr {
+		if err := r.skip(sz - hdr); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return track, rel, nil
+}
+
+// skip skips over sz bytes of element body.
+func (r *ebmlReader) skip(sz uint64) error {
+	if sz > math.MaxInt64 {
+		return fmt.Errorf("invalid ebml element size %d", sz)
+	}
+
+	cur, err := r.rs.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+
+	if int64(sz) > r.size-cur {
+		return io.ErrUnexpectedEOF
+	}
+
+	_, err = r.rs.Seek(int64(sz), io.SeekCurrent)
+	return err
+}
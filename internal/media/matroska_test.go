@@ -0,0 +1,218 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/h2non/filetype"
+	"github.com/stretchr/testify/suite"
+)
+
+type MatroskaTestSuite struct {
+	suite.Suite
+}
+
+// ebmlElement encodes an EBML element with given ID and body.
+func ebmlElement(id uint32, body ...[]byte) []byte {
+	var buf bytes.Buffer
+
+	// Write ID bytes (marker bits included).
+	idb := make([]byte, 4)
+	binary.BigEndian.PutUint32(idb, id)
+	buf.Write(bytes.TrimLeft(idb, "\x00"))
+
+	// Write 8-byte size vint.
+	data := bytes.Join(body, nil)
+	szb := make([]byte, 8)
+	binary.BigEndian.PutUint64(szb, uint64(len(data)))
+	szb[0] = 0x01
+	buf.Write(szb)
+
+	buf.Write(data)
+	return buf.Bytes()
+}
+
+// ebmlUnknownSizeElement encodes the header of an unknown-size EBML master element.
+func ebmlUnknownSizeElement(id uint32) []byte {
+	idb := make([]byte, 4)
+	binary.BigEndian.PutUint32(idb, id)
+	return append(idb, 0x01, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF)
+}
+
+func ebmlUint(id uint32, v uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return ebmlElement(id, b)
+}
+
+func ebmlFloat(id uint32, v float64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, math.Float64bits(v))
+	return ebmlElement(id, b)
+}
+
+func ebmlHeader(docType string) []byte {
+	// DocType with a 1-byte size, as muxers write it.
+	doc := append([]byte{0x42, 0x82, 0x80 | byte(len(docType))}, docType...)
+	return ebmlElement(ebmlIDHeader, doc)
+}
+
+func ebmlSimpleBlock(track byte, rel int16) []byte {
+	b := []byte{0x80 | track, 0, 0, 0x80, 'f', 'r', 'a', 'm', 'e'}
+	binary.BigEndian.PutUint16(b[1:3], uint16(rel))
+	return ebmlElement(ebmlIDSimpleBlock, b)
+}
+
+func (suite *MatroskaTestSuite) TestProbeMatroska() {
+	var buf bytes.Buffer
+	buf.Write(ebmlHeader("webm"))
+	buf.Write(ebmlElement(ebmlIDSegment,
+		ebmlElement(ebmlIDInfo,
+			ebmlUint(ebmlIDTimecodeScale, 1000000),
+			ebmlFloat(ebmlIDDuration, 4500), // ms
+		),
+		ebmlElement(ebmlIDTracks,
+			ebmlElement(ebmlIDTrackEntry,
+				ebmlUint(ebmlIDTrackNumber, 1),
+				ebmlUint(ebmlIDTrackType, 1),
+				ebmlUint(ebmlIDDefaultDuration, 40000000), // 25fps
+				ebmlElement(ebmlIDVideo,
+					ebmlUint(ebmlIDPixelWidth, 640),
+					ebmlUint(ebmlIDPixelHeight, 360),
+				),
+			),
+			ebmlElement(ebmlIDTrackEntry,
+				ebmlUint(ebmlIDTrackNumber, 2),
+				ebmlUint(ebmlIDTrackType, 2),
+			),
+		),
+		ebmlElement(ebmlIDCluster,
+			ebmlUint(ebmlIDTimecode, 0),
+			ebmlSimpleBlock(1, 0),
+		),
+	))
+
+	// Check we'd detect the right type.
+	kind, err := filetype.Match(buf.Bytes())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(mimeVideoWebm, kind.MIME.Value)
+
+	info, err := probeMatroska(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.EqualValues(640, info.width)
+	suite.EqualValues(360, info.height)
+	suite.EqualValues(4.5, info.duration)
+	suite.EqualValues(25, info.framerate)
+	suite.Equal(uint64(float64(buf.Len()*8)/4.5), info.bitrate)
+}
+
+func (suite *MatroskaTestSuite) TestProbeMatroskaLiveStream() {
+	// As written by browser MediaRecorder: unknown-size
+	// segment and clusters, no duration or frame rate.
+	var buf bytes.Buffer
+	buf.Write(ebmlHeader("webm"))
+	buf.Write(ebmlUnknownSizeElement(ebmlIDSegment))
+	buf.Write(ebmlElement(ebmlIDInfo,
+		ebmlUint(ebmlIDTimecodeScale, 1000000),
+	))
+	buf.Write(ebmlElement(ebmlIDTracks,
+		ebmlElement(ebmlIDTrackEntry,
+			ebmlUint(ebmlIDTrackNumber, 1),
+			ebmlUint(ebmlIDTrackType, 1),
+			ebmlElement(ebmlIDVideo,
+				ebmlUint(ebmlIDPixelWidth, 320),
+				ebmlUint(ebmlIDPixelHeight, 240),
+			),
+		),
+	))
+
+	// 2 clusters of 1s each, containing 10 frames.
+	for c := 0; c < 2; c++ {
+		buf.Write(ebmlUnknownSizeElement(ebmlIDCluster))
+		buf.Write(ebmlUint(ebmlIDTimecode, uint64(c*1000)))
+		for f := 0; f < 10; f++ {
+			buf.Write(ebmlSimpleBlock(1, int16(f*100)))
+		}
+	}
+
+	info, err := probeMatroska(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.EqualValues(320, info.width)
+	suite.EqualValues(240, info.height)
+
+	// Last block is at 1.9s.
+	suite.InDelta(1.9, info.duration, 0.001)
+
+	// 20 frames over 1.9s.
+	suite.InDelta(20/1.9, info.framerate, 0.001)
+}
+
+func (suite *MatroskaTestSuite) TestProbeMatroskaNotEBML() {
+	_, err := probeMatroska(bytes.NewReader([]byte("definitely not a webm")))
+	suite.Error(err)
+}
+
+func (suite *MatroskaTestSuite) TestISOBMFFMatchers() {
+	ftyp := func(major string, compatible ...string) []byte {
+		b := make([]byte, 16, 261)
+		binary.BigEndian.PutUint32(b[0:4], uint32(16+4*len(compatible)))
+		copy(b[4:8], "ftyp")
+		copy(b[8:12], major)
+		for _, c := range compatible {
+			b = append(b, c...)
+		}
+		return append(b, make([]byte, 261-len(b))...)
+	}
+
+	for _, test := range []struct {
+		hdr  []byte
+		mime string
+	}{
+		{hdr: ftyp("qt  ", "qt  "), mime: mimeVideoQuicktime},
+		{hdr: ftyp("heic", "mif1", "heic"), mime: mimeImageHeif},
+		{hdr: ftyp("mif1", "mif1", "heic"), mime: mimeImageHeif},
+		{hdr: ftyp("avif", "avif", "mif1", "miaf"), mime: mimeImageAvif},
+		{hdr: ftyp("mif1", "avif", "mif1", "miaf"), mime: mimeImageAvif},
+
+		// 20-byte ftyp regular mp4, previously matched as quicktime.
+		{hdr: ftyp("isom", "isom"), mime: mimeVideoMp4},
+	} {
+		kind, err := filetype.Match(test.hdr)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		suite.Equal(test.mime, kind.MIME.Value, "ftyp %q", test.hdr[8:12])
+	}
+}
+
+func TestMatroskaTestSuite(t *testing.T) {
+	suite.Run(t, &MatroskaTestSuite{})
+}
@@ -20,8 +20,8 @@ package media
 import (
 	"bytes"
 	"context"
-	"fmt"
 	"io"
+	"time"
 
 	"codeberg.org/gruf/go-bytesize"
 	"codeberg.org/gruf/go-errors/v2"
@@ -31,6 +31,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/metrics"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
@@ -93,6 +94,8 @@ func (p *ProcessingEmoji) load(ctx context.Context) (*gtsmodel.Emoji, bool, erro
 			return p.err
 		}
 
+		start := time.Now()
+
 		defer func() {
 			// This is only done when ctx NOT cancelled.
 			done = err == nil || !errors.Comparable(err,
@@ -107,6 +110,9 @@ func (p *ProcessingEmoji) load(ctx context.Context) (*gtsmodel.Emoji, bool, erro
 			// Store final values.
 			p.done = true
 			p.err = err
+
+			// Record processing duration.
+			metrics.ObserveMediaProcessing("emoji", err, time.Since(start))
 		}()
 
 		// Attempt to store media and calculate
@@ -115,6 +121,15 @@ func (p *ProcessingEmoji) load(ctx context.Context) (*gtsmodel.Emoji, bool, erro
 			return err
 		}
 
+		defer func() {
+			if err != nil {
+				// Release stored emoji so it isn't
+				// left referenced, the emoji will
+				// be re-stored if reprocessed.
+				p.release()
+			}
+		}()
+
 		// Finish processing by reloading media into
 		// memory to get dimension and generate a thumb.
 		if err = p.finish(ctx); err != nil {
@@ -154,6 +169,19 @@ func (p *ProcessingEmoji) load(ctx context.Context) (*gtsmodel.Emoji, bool, erro
 	return p.emoji, done, nil
 }
 
+// release releases the storage reference held by p after a failure to finish processing.
+func (p *ProcessingEmoji) release() {
+	// Processing may have failed due to a
+	// cancelled context, so use a fresh one.
+	ctx := context.Background()
+
+	if err := p.mgr.state.Storage.Release(ctx, p.mgr.state.DB, p.emoji.ImagePath); err != nil {
+		log.Errorf(ctx, "error releasing emoji from storage: %v", err)
+	}
+
+	p.emoji.ImagePath = ""
+}
+
 // store calls the data function attached to p if it hasn't been called yet,
 // and updates the underlying attachment fields as necessary. It will then stream
 // bytes from p's reader directly into storage so that it can be retrieved later.
@@ -226,41 +254,24 @@ func (p *ProcessingEmoji) store(ctx context.Context) error {
 		pathID = p.emoji.ID
 	}
 
-	// Calculate emoji file path.
-	p.emoji.ImagePath = fmt.Sprintf(
-		"%s/%s/%s/%s.%s",
-		p.instAccID,
-		TypeEmoji,
-		SizeOriginal,
-		pathID,
-		info.Extension,
-	)
-
-	// This shouldn't already exist, but we do a check as it's worth logging.
-	if have, _ := p.mgr.state.Storage.Has(ctx, p.emoji.ImagePath); have {
-		log.Warnf(ctx, "emoji already exists at storage path: %s", p.emoji.ImagePath)
-
-		// Attempt to remove existing emoji at storage path (might be broken / out-of-date)
-		if err := p.mgr.state.Storage.Delete(ctx, p.emoji.ImagePath); err != nil {
-			return gtserror.Newf("error removing emoji from storage: %v", err)
-		}
-	}
-
 	// Write the final image reader stream to our storage.
-	sz, err = p.mgr.state.Storage.PutStream(ctx, p.emoji.ImagePath, r)
+	path, sz, err := p.mgr.state.Storage.PutBlob(ctx, p.mgr.state.DB, r, info.Extension)
 	if err != nil {
 		return gtserror.Newf("error writing emoji to storage: %w", err)
 	}
 
 	// Once again check size in case none was provided previously.
 	if size := bytesize.Size(sz); size > maxSize {
-
-		if err := p.mgr.state.Storage.Delete(ctx, p.emoji.ImagePath); err != nil {
+		if err := p.mgr.state.Storage.Release(ctx, p.mgr.state.DB, path); err != nil {
 			log.Errorf(ctx, "error removing too-large-emoji from storage: %v", err)
 		}
 		return gtserror.Newf("calculated emoji size %s greater than max allowed %s", size, maxSize)
 	}
 
+	// Emoji is stored by its content
+	// hash, shared between duplicates.
+	p.emoji.ImagePath = path
+
 	// Fill in remaining attachment data now it's stored.
 	p.emoji.ImageURL = uris.GenerateURIForAttachment(
 		p.instAccID,
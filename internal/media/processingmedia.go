@@ -25,14 +25,17 @@ import (
 	"io"
 	"time"
 
+	"codeberg.org/gruf/go-bytesize"
 	"codeberg.org/gruf/go-errors/v2"
 	"codeberg.org/gruf/go-runners"
 	"github.com/disintegration/imaging"
 	"github.com/h2non/filetype"
 	terminator "github.com/superseriousbusiness/exif-terminator"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/metrics"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
@@ -93,6 +96,8 @@ func (p *ProcessingMedia) load(ctx context.Context) (*gtsmodel.MediaAttachment,
 			return p.err
 		}
 
+		start := time.Now()
+
 		defer func() {
 			// This is only done when ctx NOT cancelled.
 			done = err == nil || !errors.Comparable(err,
@@ -107,6 +112,9 @@ func (p *ProcessingMedia) load(ctx context.Context) (*gtsmodel.MediaAttachment,
 			// Store final values.
 			p.done = true
 			p.err = err
+
+			// Record processing duration.
+			metrics.ObserveMediaProcessing("attachment", err, time.Since(start))
 		}()
 
 		// Attempt to store media and calculate
@@ -115,6 +123,15 @@ func (p *ProcessingMedia) load(ctx context.Context) (*gtsmodel.MediaAttachment,
 			return err
 		}
 
+		defer func() {
+			if err != nil {
+				// Release stored media so it isn't
+				// left referenced, the attachment
+				// will be re-stored if reprocessed.
+				p.release()
+			}
+		}()
+
 		// Finish processing by reloading media into
 		// memory to get dimension and generate a thumb.
 		if err = p.finish(ctx); err != nil {
@@ -139,6 +156,26 @@ func (p *ProcessingMedia) load(ctx context.Context) (*gtsmodel.MediaAttachment,
 	return p.media, done, nil
 }
 
+// release releases the storage references held by p after a failure to finish processing.
+func (p *ProcessingMedia) release() {
+	// Processing may have failed due to a
+	// cancelled context, so use a fresh one.
+	ctx := context.Background()
+
+	for _, path := range []string{p.media.File.Path, p.media.File.SourcePath} {
+		if path == "" {
+			continue
+		}
+
+		if err := p.mgr.state.Storage.Release(ctx, p.mgr.state.DB, path); err != nil {
+			log.Errorf(ctx, "error releasing media from storage: %v", err)
+		}
+	}
+
+	p.media.File.Path = ""
+	p.media.File.SourcePath = ""
+}
+
 // store calls the data function attached to p if it hasn't been called yet,
 // and updates the underlying attachment fields as necessary. It will then stream
 // bytes from p's reader directly into storage so that it can be retrieved later.
@@ -175,11 +212,35 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 	// Recombine header bytes with remaining stream
 	r := io.MultiReader(bytes.NewReader(hdrBuf), rc)
 
+	// Content-type to store; the detected
+	// MIME type, unless normalized below.
+	contentType := info.MIME.Value
+
 	switch info.Extension {
-	case "mp4":
+	case "mp4", "webm", "mkv", "mov":
 		p.media.Type = gtsmodel.FileTypeVideo
 
-	case "gif":
+	case "mp3":
+		p.media.Type = gtsmodel.FileTypeAudio
+		contentType = mimeAudioMpeg
+
+	case "ogg":
+		p.media.Type = gtsmodel.FileTypeAudio
+		contentType = mimeAudioOgg
+
+	case "flac":
+		p.media.Type = gtsmodel.FileTypeAudio
+		contentType = mimeAudioFlac
+
+	case "m4a":
+		p.media.Type = gtsmodel.FileTypeAudio
+		contentType = mimeAudioMp4
+
+	case "wav":
+		p.media.Type = gtsmodel.FileTypeAudio
+		contentType = mimeAudioWav
+
+	case "gif", "heif", "avif":
 		p.media.Type = gtsmodel.FileTypeImage
 
 	case "jpg", "jpeg", "png", "webp":
@@ -196,35 +257,49 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 		return gtserror.Newf("unsupported file type: %s", info.Extension)
 	}
 
-	// Calculate attachment file path.
-	p.media.File.Path = fmt.Sprintf(
-		"%s/%s/%s/%s.%s",
-		p.media.AccountID,
-		TypeAttachment,
-		SizeOriginal,
-		p.media.ID,
-		info.Extension,
-	)
+	var maxSize bytesize.Size
 
-	// This shouldn't already exist, but we do a check as it's worth logging.
-	if have, _ := p.mgr.state.Storage.Has(ctx, p.media.File.Path); have {
-		log.Warnf(ctx, "media already exists at storage path: %s", p.media.File.Path)
+	if p.media.Type == gtsmodel.FileTypeImage {
+		maxSize = config.GetMediaImageMaxSize()
+	} else {
+		// Video and audio.
+		maxSize = config.GetMediaVideoMaxSize()
+	}
 
-		// Attempt to remove existing media at storage path (might be broken / out-of-date)
-		if err := p.mgr.state.Storage.Delete(ctx, p.media.File.Path); err != nil {
-			return gtserror.Newf("error removing media from storage: %v", err)
-		}
+	// Check that provided size isn't beyond max. We check beforehand
+	// so that we don't attempt to stream the media into storage if not needed.
+	if size := bytesize.Size(sz); sz > 0 && size > maxSize {
+		return gtserror.Newf("given media size %s greater than max allowed %s", size, maxSize)
 	}
 
 	// Write the final image reader stream to our storage.
-	sz, err = p.mgr.state.Storage.PutStream(ctx, p.media.File.Path, r)
+	path, sz, err := p.mgr.state.Storage.PutBlob(ctx, p.mgr.state.DB, r, info.Extension)
 	if err != nil {
 		return gtserror.Newf("error writing media to storage: %w", err)
 	}
 
+	// Once again check size in case none was provided previously.
+	if size := bytesize.Size(sz); size > maxSize {
+		if err := p.mgr.state.Storage.Release(ctx, p.mgr.state.DB, path); err != nil {
+			log.Errorf(ctx, "error removing too-large-media from storage: %v", err)
+		}
+		return gtserror.Newf("calculated media size %s greater than max allowed %s", size, maxSize)
+	}
+
+	// Media is stored by its content
+	// hash, shared between duplicates.
+	p.media.File.Path = path
+
 	// Set written image size.
 	p.media.File.FileSize = int(sz)
 
+	// Clear any previous original source file
+	// details, these get set if we need to
+	// convert the media in finish().
+	p.media.File.SourcePath = ""
+	p.media.File.SourceContentType = ""
+	p.media.File.SourceFileSize = 0
+
 	// Fill in remaining attachment data now it's stored.
 	p.media.URL = uris.GenerateURIForAttachment(
 		p.media.AccountID,
@@ -233,7 +308,7 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 		p.media.ID,
 		info.Extension,
 	)
-	p.media.File.ContentType = info.MIME.Value
+	p.media.File.ContentType = contentType
 	p.media.Cached = func() *bool {
 		ok := true
 		return &ok
@@ -269,9 +344,21 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 			return gtserror.Newf("error decoding image: %w", err)
 		}
 
-	// .mp4 video type
-	case mimeVideoMp4:
-		video, err := decodeVideoFrame(rc)
+	// .heif, .avif image (requires conversion)
+	case mimeImageHeif, mimeImageAvif:
+		fullImg, err = decodeHEIF(ctx, rc, p.mgr.ffmpeg)
+		if err != nil {
+			return gtserror.Newf("error decoding image: %w", err)
+		}
+
+		// Store a web-safe version of the image.
+		if err := p.storeDerivative(ctx, fullImg); err != nil {
+			return err
+		}
+
+	// .mp4, .webm, .mkv, .mov video type
+	case mimeVideoMp4, mimeVideoWebm, mimeVideoMatroska, mimeVideoQuicktime:
+		video, err := decodeVideoFrame(ctx, rc, p.media.File.ContentType, p.mgr.ffmpeg)
 		if err != nil {
 			return gtserror.Newf("error decoding video: %w", err)
 		}
@@ -283,6 +370,20 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 		p.media.FileMeta.Original.Duration = &video.duration
 		p.media.FileMeta.Original.Framerate = &video.framerate
 		p.media.FileMeta.Original.Bitrate = &video.bitrate
+
+	// .mp3, .ogg, .flac, .m4a, .wav audio type
+	case mimeAudioMpeg, mimeAudioOgg, mimeAudioFlac, mimeAudioMp4, mimeAudioWav:
+		audio, err := decodeAudio(ctx, rc, p.media.File.ContentType, p.mgr.ffmpeg)
+		if err != nil {
+			return gtserror.Newf("error decoding audio: %w", err)
+		}
+
+		// Set cover art (or waveform) as image.
+		fullImg = audio.cover
+
+		// Set audio metadata in attachment info.
+		p.media.FileMeta.Original.Duration = &audio.duration
+		p.media.FileMeta.Original.Bitrate = &audio.bitrate
 	}
 
 	// The image should be in-memory by now.
@@ -290,11 +391,14 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 		return gtserror.Newf("error closing file: %w", err)
 	}
 
-	// Set full-size dimensions in attachment info.
-	p.media.FileMeta.Original.Width = int(fullImg.Width())
-	p.media.FileMeta.Original.Height = int(fullImg.Height())
-	p.media.FileMeta.Original.Size = int(fullImg.Size())
-	p.media.FileMeta.Original.Aspect = fullImg.AspectRatio()
+	if p.media.Type != gtsmodel.FileTypeAudio {
+		// Set full-size dimensions in attachment info
+		// (audio has no dimensions, only the thumbnail).
+		p.media.FileMeta.Original.Width = int(fullImg.Width())
+		p.media.FileMeta.Original.Height = int(fullImg.Height())
+		p.media.FileMeta.Original.Size = int(fullImg.Size())
+		p.media.FileMeta.Original.Aspect = fullImg.AspectRatio()
+	}
 
 	// Calculate attachment thumbnail file path
 	p.media.Thumbnail.Path = fmt.Sprintf(
@@ -369,3 +473,56 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 
 	return nil
 }
+
+// storeDerivative stores given decoded image as a web-safe JPEG in
+// place of the original file, which is kept as the attachment's source.
+func (p *ProcessingMedia) storeDerivative(ctx context.Context, img *gtsImage) error {
+	// Calculate derivative file path.
+	path := fmt.Sprintf(
+		"%s/%s/%s/%s.jpg",
+		p.media.AccountID,
+		TypeAttachment,
+		SizeOriginal,
+		p.media.ID,
+	)
+
+	// This shouldn't already exist, but we do a check as it's worth logging.
+	if have, _ := p.mgr.state.Storage.Has(ctx, path); have {
+		log.Warnf(ctx, "media already exists at storage path: %s", path)
+
+		// Attempt to remove existing media at storage path (might be broken / out-of-date)
+		if err := p.mgr.state.Storage.Delete(ctx, path); err != nil {
+			return gtserror.Newf("error removing media from storage: %v", err)
+		}
+	}
+
+	// Create a full-size JPEG encoder stream.
+	enc := img.ToJPEG(&jpeg.Options{
+		Quality: 90, // keep it looking good.
+	})
+
+	// Stream-encode the JPEG image into storage.
+	sz, err := p.mgr.state.Storage.PutStream(ctx, path, enc)
+	if err != nil {
+		return gtserror.Newf("error stream-encoding derivative to storage: %w", err)
+	}
+
+	// Keep track of the original.
+	p.media.File.SourcePath = p.media.File.Path
+	p.media.File.SourceContentType = p.media.File.ContentType
+	p.media.File.SourceFileSize = p.media.File.FileSize
+
+	// Serve the derivative in its place.
+	p.media.File.Path = path
+	p.media.File.ContentType = mimeImageJpeg
+	p.media.File.FileSize = int(sz)
+	p.media.URL = uris.GenerateURIForAttachment(
+		p.media.AccountID,
+		string(TypeAttachment),
+		string(SizeOriginal),
+		p.media.ID,
+		"jpg",
+	)
+
+	return nil
+}
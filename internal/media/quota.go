@@ -0,0 +1,55 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"fmt"
+	"strings"
+
+	"codeberg.org/gruf/go-bytesize"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// AccountQuota returns the max total size in bytes of media that may be stored
+// for the given local user, taking into account any admin-set override of the
+// instance default. Zero means the user may store an unlimited amount of media.
+func AccountQuota(user *gtsmodel.User) int64 {
+	if user.MediaQuota != nil {
+		return *user.MediaQuota
+	}
+	return int64(config.GetMediaAccountQuota())
+}
+
+// ParseAccountQuota parses an admin-set account media quota, given as a size
+// such as "500MiB" or "0" for no limit. The value "default" returns nil, i.e.
+// removes any override so that the instance default quota applies.
+func ParseAccountQuota(s string) (*int64, error) {
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "default") {
+		return nil, nil
+	}
+
+	size, err := bytesize.ParseSize(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quota %q: %w", s, err)
+	}
+
+	quota := int64(size)
+	return &quota, nil
+}
@@ -27,6 +27,7 @@ import (
 const (
 	mimeImage = "image"
 	mimeVideo = "video"
+	mimeAudio = "audio"
 
 	mimeJpeg      = "jpeg"
 	mimeImageJpeg = mimeImage + "/" + mimeJpeg
@@ -40,8 +41,37 @@ const (
 	mimeWebp      = "webp"
 	mimeImageWebp = mimeImage + "/" + mimeWebp
 
+	mimeHeif      = "heif"
+	mimeImageHeif = mimeImage + "/" + mimeHeif
+
+	mimeAvif      = "avif"
+	mimeImageAvif = mimeImage + "/" + mimeAvif
+
 	mimeMp4      = "mp4"
 	mimeVideoMp4 = mimeVideo + "/" + mimeMp4
+
+	mimeWebm      = "webm"
+	mimeVideoWebm = mimeVideo + "/" + mimeWebm
+
+	mimeMatroska      = "x-matroska"
+	mimeVideoMatroska = mimeVideo + "/" + mimeMatroska
+
+	mimeQuicktime      = "quicktime"
+	mimeVideoQuicktime = mimeVideo + "/" + mimeQuicktime
+
+	mimeMpeg      = "mpeg"
+	mimeAudioMpeg = mimeAudio + "/" + mimeMpeg
+
+	mimeOgg      = "ogg"
+	mimeAudioOgg = mimeAudio + "/" + mimeOgg
+
+	mimeFlac      = "flac"
+	mimeAudioFlac = mimeAudio + "/" + mimeFlac
+
+	mimeAudioMp4 = mimeAudio + "/" + mimeMp4
+
+	mimeWav      = "wav"
+	mimeAudioWav = mimeAudio + "/" + mimeWav
 )
 
 // EmojiMaxBytes is the maximum permitted bytes of an emoji upload (50kb)
@@ -18,10 +18,14 @@
 package media
 
 import (
+	"context"
 	"fmt"
+	"image/color"
 	"io"
+	"math"
 
 	"github.com/abema/go-mp4"
+	"github.com/disintegration/imaging"
 	"github.com/superseriousbusiness/gotosocial/internal/iotools"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
@@ -33,9 +37,25 @@ type gtsVideo struct {
 	framerate float32
 }
 
+// videoFrameOffsets are the positions, as fractions of
+// the total video duration, at which candidate thumbnail
+// frames are extracted. Avoiding the very start of the
+// video skips most fade-ins and black title cards.
+var videoFrameOffsets = []float32{0.1, 0.25, 0.5}
+
+// videoInfo contains the video metadata
+// probed from a supported container format.
+type videoInfo struct {
+	width     int
+	height    int
+	duration  float32 // in seconds
+	framerate float32
+	bitrate   uint64
+}
+
 // decodeVideoFrame decodes and returns an image from a single frame in the given video stream.
-// (note: currently this only returns a blank image resized to fit video dimensions).
-func decodeVideoFrame(r io.Reader) (*gtsVideo, error) {
+// If ffmpeg is nil (i.e. not available), this returns a blank image resized to fit video dimensions.
+func decodeVideoFrame(ctx context.Context, r io.Reader, contentType string, ff *ffmpeg) (*gtsVideo, error) {
 	// we need a readseeker to decode the video...
 	tfs, err := iotools.TempFileSeeker(r)
 	if err != nil {
@@ -47,85 +67,265 @@ func decodeVideoFrame(r io.Reader) (*gtsVideo, error) {
 		}
 	}()
 
+	var info *videoInfo
+
+	switch contentType {
+	case mimeVideoWebm, mimeVideoMatroska:
+		info, err = probeMatroska(tfs)
+		if err != nil {
+			return nil, fmt.Errorf("error during matroska probe: %w", err)
+		}
+
+	default: // mp4, quicktime
+		info, err = probeMP4(tfs)
+		if err != nil {
+			return nil, fmt.Errorf("error during mp4 probe: %w", err)
+		}
+	}
+
+	// Check for empty video metadata.
+	var empty []string
+	if info.width == 0 {
+		empty = append(empty, "width")
+	}
+	if info.height == 0 {
+		empty = append(empty, "height")
+	}
+	if info.duration == 0 {
+		empty = append(empty, "duration")
+	}
+	if info.framerate == 0 {
+		empty = append(empty, "framerate")
+	}
+	if info.bitrate == 0 {
+		empty = append(empty, "bitrate")
+	}
+	if len(empty) > 0 {
+		return nil, fmt.Errorf("error determining video metadata: %v", empty)
+	}
+
+	video := gtsVideo{
+		duration:  info.duration,
+		bitrate:   info.bitrate,
+		framerate: info.framerate,
+	}
+
+	if ff != nil {
+		// We have an ffmpeg binary available, we can use it to decode
+		// a real video frame. This requires a file path, so we can only
+		// do this when our seeker is backed by a file on disk.
+		if f, ok := tfs.(interface{ Name() string }); ok {
+			video.frame = bestVideoFrame(ctx, ff, f.Name(), video.duration)
+		}
+	}
+
+	if video.frame == nil {
+		// Fallback to an empty "frame" image.
+		video.frame = blankImage(info.width, info.height)
+	}
+
+	return &video, nil
+}
+
+// probeMP4 reads container and track metadata from an ISO base media file
+// (i.e. MP4 or QuickTime). Dimensions of video tracks in codecs not natively
+// understood by go-mp4 (e.g. HEVC, as recorded by iOS devices) are read from
+// the track header box instead.
+func probeMP4(rs io.ReadSeeker) (*videoInfo, error) {
 	// probe the video file to extract useful metadata from it; for methodology, see:
 	// https://github.com/abema/go-mp4/blob/7d8e5a7c5e644e0394261b0cf72fef79ce246d31/mp4tool/probe/probe.go#L85-L154
-	info, err := mp4.Probe(tfs)
+	probe, err := mp4.Probe(rs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Gather track headers and handler types
+	// so we can distinguish video tracks with
+	// codecs that aren't understood by Probe().
+	headers, err := mp4TrackHeaders(rs)
 	if err != nil {
-		return nil, fmt.Errorf("error during mp4 probe: %w", err)
+		return nil, err
 	}
 
 	var (
-		width        int
-		height       int
+		info         videoInfo
 		videoBitrate uint64
 		audioBitrate uint64
-		video        gtsVideo
 	)
 
-	for _, tr := range info.Tracks {
-		if tr.AVC == nil {
+	for _, tr := range probe.Tracks {
+		var width, height int
+
+		if tr.AVC != nil {
+			width = int(tr.AVC.Width)
+			height = int(tr.AVC.Height)
+		} else if hdr, ok := headers[tr.TrackID]; ok && hdr.video {
+			width = hdr.width
+			height = hdr.height
+		}
+
+		if width == 0 || height == 0 {
 			// audio track
 			if br := tr.Samples.GetBitrate(tr.Timescale); br > audioBitrate {
 				audioBitrate = br
-			} else if br := info.Segments.GetBitrate(tr.TrackID, tr.Timescale); br > audioBitrate {
+			} else if br := probe.Segments.GetBitrate(tr.TrackID, tr.Timescale); br > audioBitrate {
 				audioBitrate = br
 			}
 
-			if d := float64(tr.Duration) / float64(tr.Timescale); d > float64(video.duration) {
-				video.duration = float32(d)
+			if d := float64(tr.Duration) / float64(tr.Timescale); d > float64(info.duration) {
+				info.duration = float32(d)
 			}
 			continue
 		}
 
 		// video track
-		if w := int(tr.AVC.Width); w > width {
-			width = w
+		if width > info.width {
+			info.width = width
 		}
 
-		if h := int(tr.AVC.Height); h > height {
-			height = h
+		if height > info.height {
+			info.height = height
 		}
 
 		if br := tr.Samples.GetBitrate(tr.Timescale); br > videoBitrate {
 			videoBitrate = br
-		} else if br := info.Segments.GetBitrate(tr.TrackID, tr.Timescale); br > videoBitrate {
+		} else if br := probe.Segments.GetBitrate(tr.TrackID, tr.Timescale); br > videoBitrate {
 			videoBitrate = br
 		}
 
-		if d := float64(tr.Duration) / float64(tr.Timescale); d > float64(video.duration) {
-			video.framerate = float32(len(tr.Samples)) / float32(d)
-			video.duration = float32(d)
+		if d := float64(tr.Duration) / float64(tr.Timescale); d > float64(info.duration) {
+			info.framerate = float32(len(tr.Samples)) / float32(d)
+			info.duration = float32(d)
 		}
 	}
 
 	// overall bitrate should be audio + video combined
 	// (since they're both playing at the same time)
-	video.bitrate = audioBitrate + videoBitrate
+	info.bitrate = audioBitrate + videoBitrate
 
-	// Check for empty video metadata.
-	var empty []string
-	if width == 0 {
-		empty = append(empty, "width")
+	return &info, nil
+}
+
+// mp4TrackHeader contains details
+// from an mp4 track's header boxes.
+type mp4TrackHeader struct {
+	video  bool
+	width  int
+	height int
+}
+
+// mp4TrackHeaders reads the track header and media handler boxes
+// of each track in given mp4 file, returning them keyed by track ID.
+func mp4TrackHeaders(rs io.ReadSeeker) (map[uint32]mp4TrackHeader, error) {
+	if _, err := rs.Seek(0, io.SeekStart); err != nil {
+		return nil, err
 	}
-	if height == 0 {
-		empty = append(empty, "height")
+
+	boxes, err := mp4.ExtractBoxesWithPayload(rs, nil, []mp4.BoxPath{
+		{mp4.BoxTypeMoov(), mp4.BoxTypeTrak(), mp4.BoxTypeTkhd()},
+		{mp4.BoxTypeMoov(), mp4.BoxTypeTrak(), mp4.BoxTypeMdia(), mp4.BoxTypeHdlr()},
+	})
+	if err != nil {
+		return nil, err
 	}
-	if video.duration == 0 {
-		empty = append(empty, "duration")
+
+	var (
+		headers = make(map[uint32]mp4TrackHeader)
+		trackID uint32
+	)
+
+	// Boxes are returned in file order, and each
+	// trak contains exactly one tkhd, which comes
+	// before its mdia, so we can pair them up.
+	for _, box := range boxes {
+		switch payload := box.Payload.(type) {
+		case *mp4.Tkhd:
+			trackID = payload.TrackID
+			headers[trackID] = mp4TrackHeader{
+				width:  int(payload.GetWidth()),
+				height: int(payload.GetHeight()),
+			}
+
+		case *mp4.Hdlr:
+			hdr := headers[trackID]
+			hdr.video = payload.HandlerType == [4]byte{'v', 'i', 'd', 'e'}
+			headers[trackID] = hdr
+		}
 	}
-	if video.framerate == 0 {
-		empty = append(empty, "framerate")
+
+	return headers, nil
+}
+
+// bestVideoFrame extracts candidate frames from the video at given file path at each of
+// videoFrameOffsets, returning the frame deemed most "interesting" by frameScore(). This
+// returns nil if no frames could be decoded.
+func bestVideoFrame(ctx context.Context, ff *ffmpeg, filepath string, duration float32) *gtsImage {
+	var (
+		best      *gtsImage
+		bestScore = -1.0
+	)
+
+	for _, offset := range videoFrameOffsets {
+		frame, err := ff.extractFrame(ctx, filepath, duration*offset)
+		if err != nil {
+			log.Warnf(ctx, "error extracting video frame at %.2fs: %v", duration*offset, err)
+			continue
+		}
+
+		if score := frameScore(frame); score > bestScore {
+			best, bestScore = frame, score
+		}
+
+		if bestScore >= goodFrameScore {
+			// Good enough, no need
+			// to decode any further.
+			break
+		}
 	}
-	if video.bitrate == 0 {
-		empty = append(empty, "bitrate")
+
+	return best
+}
+
+// goodFrameScore is the frameScore() above which
+// we consider a video frame good enough to use as
+// a thumbnail without checking any further frames.
+const goodFrameScore = 48
+
+// frameScore returns a heuristic score of how well the given frame would serve as a
+// video thumbnail. This is the standard deviation of luminance across the (downscaled)
+// image, penalizing near-black and near-white frames, so that flat frames such as fades,
+// title cards and end slates score lower than frames with visible detail.
+func frameScore(frame *gtsImage) float64 {
+	// We don't need detail for this, so work on a tiny version.
+	tiny := imaging.Resize(frame.image, 32, 0, imaging.NearestNeighbor)
+	bounds := tiny.Bounds()
+
+	var (
+		sum   float64
+		sumSq float64
+		n     float64
+	)
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			l := float64(color.GrayModel.Convert(tiny.At(x, y)).(color.Gray).Y)
+			sum += l
+			sumSq += l * l
+			n++
+		}
 	}
-	if len(empty) > 0 {
-		return nil, fmt.Errorf("error determining video metadata: %v", empty)
+
+	if n == 0 {
+		return 0
 	}
 
-	// Create new empty "frame" image.
-	// TODO: decode frame from video file.
-	video.frame = blankImage(width, height)
+	mean := sum / n
+	score := math.Sqrt(math.Max(0, sumSq/n-mean*mean))
 
-	return &video, nil
+	if mean < 16 || mean > 240 {
+		// Mostly black or white.
+		score /= 4
+	}
+
+	return score
 }
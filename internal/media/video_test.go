@@ -0,0 +1,90 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type VideoTestSuite struct {
+	suite.Suite
+}
+
+func (suite *VideoTestSuite) TestFrameScoreBlank() {
+	suite.Zero(frameScore(blankImage(640, 480)))
+}
+
+func (suite *VideoTestSuite) TestFrameScoreDetailed() {
+	f, err := os.Open("./test/test-jpeg.jpg")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	defer f.Close()
+
+	img, err := decodeImage(f)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	blank := frameScore(blankImage(int(img.Width()), int(img.Height())))
+	suite.Greater(frameScore(img), blank)
+}
+
+func (suite *VideoTestSuite) TestDecodeVideoFrameNoFFmpeg() {
+	f, err := os.Open("./test/test-mp4-original.mp4")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	defer f.Close()
+
+	video, err := decodeVideoFrame(context.Background(), f, mimeVideoMp4, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Without ffmpeg, fall back to a blank frame.
+	suite.Zero(frameScore(video.frame))
+}
+
+func (suite *VideoTestSuite) TestDecodeVideoFrameFFmpeg() {
+	ff := newFFmpeg("ffmpeg")
+	if ff == nil {
+		suite.T().Skip("ffmpeg not found in $PATH")
+	}
+
+	f, err := os.Open("./test/test-mp4-original.mp4")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	defer f.Close()
+
+	video, err := decodeVideoFrame(context.Background(), f, mimeVideoMp4, ff)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.NotZero(frameScore(video.frame))
+}
+
+func TestVideoTestSuite(t *testing.T) {
+	suite.Run(t, &VideoTestSuite{})
+}
@@ -0,0 +1,230 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package metrics
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/cache"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+)
+
+const (
+	// namespace prefixes all metric names.
+	namespace = "gotosocial_"
+
+	// contentType is the Prometheus text exposition format content-type.
+	contentType = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+var (
+	// std is the registry of all gotosocial metrics.
+	std registry
+
+	// enabled is set when metrics are enabled, so
+	// that nothing is recorded when they're not.
+	enabled atomic.Bool
+
+	// current is the state that worker
+	// and cache metrics are read from.
+	current atomic.Pointer[state.State]
+)
+
+var (
+	// durationBuckets are the histogram buckets used for request and query durations, in seconds.
+	durationBuckets = []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+	// mediaBuckets are the histogram buckets used for media processing durations, in seconds.
+	mediaBuckets = []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60, 120}
+
+	httpRequestDuration = newHistogramVec(&std,
+		namespace+"http_request_duration_seconds",
+		"Duration of HTTP requests, by method, matched route and response status.",
+		durationBuckets, "method", "route", "status",
+	)
+
+	_ = newFuncVec(&std,
+		namespace+"worker_queue_depth",
+		"Number of tasks queued for each worker pool.",
+		"gauge", collectWorkers, "worker",
+	)
+
+	_ = newFuncVec(&std,
+		namespace+"cache_hits_total",
+		"Number of loads served from each cache.",
+		"counter", collectCaches(func(s cache.Stats) float64 { return float64(s.Hits) }), "cache",
+	)
+
+	_ = newFuncVec(&std,
+		namespace+"cache_misses_total",
+		"Number of loads from each cache that fell through to the database.",
+		"counter", collectCaches(func(s cache.Stats) float64 { return float64(s.Misses) }), "cache",
+	)
+
+	_ = newFuncVec(&std,
+		namespace+"cache_size",
+		"Number of entries currently in each cache.",
+		"gauge", collectCaches(func(s cache.Stats) float64 { return float64(s.Size) }), "cache",
+	)
+
+	dbQueryDuration = newHistogramVec(&std,
+		namespace+"db_query_duration_seconds",
+		"Duration of database queries, by operation.",
+		durationBuckets, "operation",
+	)
+
+	federationDeliveries = newCounterVec(&std,
+		namespace+"federation_deliveries_total",
+		"Number of attempted deliveries of activities to remote inboxes, by result and domain.",
+		"result", "domain",
+	)
+
+	mediaProcessingDuration = newHistogramVec(&std,
+		namespace+"media_processing_duration_seconds",
+		"Duration of processing media attachments and emojis, by type and result.",
+		mediaBuckets, "type", "result",
+	)
+)
+
+// Initialize enables metrics if configured, reading worker and cache metrics from the given state.
+func Initialize(state *state.State) {
+	if !config.GetMetricsEnabled() {
+		return
+	}
+
+	current.Store(state)
+	enabled.Store(true)
+}
+
+// Enabled returns whether metrics are enabled.
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// Handler returns a handler serving all metrics in Prometheus text format. If
+// a metrics auth token is configured, it must be given as a bearer token.
+func Handler() gin.HandlerFunc {
+	token := config.GetMetricsAuthToken()
+
+	return func(c *gin.Context) {
+		if token != "" {
+			given := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+			if subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+				c.Header("WWW-Authenticate", `Bearer realm="metrics"`)
+				c.String(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+				return
+			}
+		}
+
+		var buf bytes.Buffer
+		std.collect(&buf)
+		c.Data(http.StatusOK, contentType, buf.Bytes())
+	}
+}
+
+// InstrumentGin returns a middleware recording the duration of each
+// request, by method, matched route pattern and response status.
+func InstrumentGin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			// Don't record arbitrary
+			// paths of unmatched requests.
+			route = "unmatched"
+		}
+
+		httpRequestDuration.Observe(
+			time.Since(start).Seconds(),
+			c.Request.Method,
+			route,
+			strconv.Itoa(c.Writer.Status()),
+		)
+	}
+}
+
+// ObserveDBQuery records the duration of a database query with given operation, eg. SELECT.
+func ObserveDBQuery(operation string, dur time.Duration) {
+	if !enabled.Load() {
+		return
+	}
+	dbQueryDuration.Observe(dur.Seconds(), operation)
+}
+
+// CountDelivery records an attempted delivery to
+// an inbox at the given domain, which failed if err is set.
+func CountDelivery(domain string, err error) {
+	if !enabled.Load() {
+		return
+	}
+	federationDeliveries.Inc(result(err), domain)
+}
+
+// ObserveMediaProcessing records the duration of processing media of given
+// type (attachment or emoji), which failed if err is set.
+func ObserveMediaProcessing(mediaType string, err error, dur time.Duration) {
+	if !enabled.Load() {
+		return
+	}
+	mediaProcessingDuration.Observe(dur.Seconds(), mediaType, result(err))
+}
+
+// result returns the result label value for err.
+func result(err error) string {
+	if err != nil {
+		return "failure"
+	}
+	return "success"
+}
+
+// collectWorkers emits the queue depth of each worker pool.
+func collectWorkers(emit func(v float64, values ...string)) {
+	state := current.Load()
+	if state == nil {
+		return
+	}
+
+	emit(float64(state.Workers.ClientAPI.Queue()), "client_api")
+	emit(float64(state.Workers.Federator.Queue()), "federator")
+	emit(float64(state.Workers.Media.Queue()), "media")
+	emit(float64(state.Workers.Jobs.Queue()), "jobs")
+}
+
+// collectCaches returns a collect func emitting the value of each cache's stats.
+func collectCaches(value func(cache.Stats) float64) func(emit func(v float64, values ...string)) {
+	return func(emit func(v float64, values ...string)) {
+		state := current.Load()
+		if state == nil {
+			return
+		}
+
+		for _, stats := range state.Caches.Stats() {
+			emit(value(stats), stats.Name)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package metrics_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/metrics"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type MetricsTestSuite struct {
+	suite.Suite
+	state state.State
+}
+
+func (suite *MetricsTestSuite) SetupSuite() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+
+	config.SetMetricsEnabled(true)
+	config.SetMetricsAuthToken("secret")
+
+	suite.state.Caches.Init()
+	metrics.Initialize(&suite.state)
+}
+
+func (suite *MetricsTestSuite) TearDownSuite() {
+	testrig.InitTestConfig()
+}
+
+func (suite *MetricsTestSuite) scrape(token string) (int, string) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "http://localhost:8080/metrics", nil)
+	if token != "" {
+		ctx.Request.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	metrics.Handler()(ctx)
+	return recorder.Code, recorder.Body.String()
+}
+
+func (suite *MetricsTestSuite) TestScrapeUnauthorized() {
+	code, _ := suite.scrape("")
+	suite.Equal(http.StatusUnauthorized, code)
+
+	code, _ = suite.scrape("not the secret")
+	suite.Equal(http.StatusUnauthorized, code)
+}
+
+func (suite *MetricsTestSuite) TestScrape() {
+	suite.True(metrics.Enabled())
+
+	metrics.ObserveDBQuery("SELECT", 3*time.Millisecond)
+	metrics.CountDelivery("example.org", nil)
+	metrics.CountDelivery("example.org", errors.New("oh no"))
+	metrics.ObserveMediaProcessing("emoji", nil, 200*time.Millisecond)
+
+	// Record a request through the gin middleware.
+	engine := gin.New()
+	engine.Use(metrics.InstrumentGin())
+	engine.GET("/api/v1/statuses/:id", func(c *gin.Context) { c.Status(http.StatusNotFound) })
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/statuses/01F8MH75CBF9JFX4ZAD54N0W0R", nil))
+
+	code, body := suite.scrape("secret")
+	suite.Equal(http.StatusOK, code)
+
+	for _, expect := range []string{
+		"# TYPE gotosocial_http_request_duration_seconds histogram\n",
+		`gotosocial_http_request_duration_seconds_count{method="GET",route="/api/v1/statuses/:id",status="404"} 1` + "\n",
+		`gotosocial_worker_queue_depth{worker="client_api"} 0` + "\n",
+		`gotosocial_cache_size{cache="account"} 0` + "\n",
+		"# TYPE gotosocial_cache_hits_total counter\n",
+		`gotosocial_db_query_duration_seconds_bucket{operation="SELECT",le="0.005"} 1` + "\n",
+		`gotosocial_db_query_duration_seconds_bucket{operation="SELECT",le="0.001"} 0` + "\n",
+		`gotosocial_federation_deliveries_total{result="success",domain="example.org"} 1` + "\n",
+		`gotosocial_federation_deliveries_total{result="failure",domain="example.org"} 1` + "\n",
+		`gotosocial_media_processing_duration_seconds_bucket{type="emoji",result="success",le="+Inf"} 1` + "\n",
+	} {
+		suite.Contains(body, expect)
+	}
+}
+
+func TestMetricsTestSuite(t *testing.T) {
+	suite.Run(t, new(MetricsTestSuite))
+}
@@ -0,0 +1,284 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package metrics
+
+import (
+	"bytes"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// collector is implemented by all metric types, to
+// write their current values in Prometheus text format.
+type collector interface {
+	collect(buf *bytes.Buffer)
+}
+
+// registry holds all registered collectors, in order of registration.
+type registry struct {
+	mu         sync.Mutex
+	collectors []collector
+}
+
+// register adds the given collector to the registry.
+func (r *registry) register(c collector) {
+	r.mu.Lock()
+	r.collectors = append(r.collectors, c)
+	r.mu.Unlock()
+}
+
+// collect writes all registered collectors to the given buffer.
+func (r *registry) collect(buf *bytes.Buffer) {
+	r.mu.Lock()
+	collectors := r.collectors
+	r.mu.Unlock()
+
+	for _, c := range collectors {
+		c.collect(buf)
+	}
+}
+
+// desc describes a metric family.
+type desc struct {
+	name   string
+	help   string
+	kind   string
+	labels []string
+}
+
+// header writes the HELP and TYPE lines of the metric family.
+func (d *desc) header(buf *bytes.Buffer) {
+	buf.WriteString("# HELP ")
+	buf.WriteString(d.name)
+	buf.WriteByte(' ')
+	buf.WriteString(strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(d.help))
+	buf.WriteString("\n# TYPE ")
+	buf.WriteString(d.name)
+	buf.WriteByte(' ')
+	buf.WriteString(d.kind)
+	buf.WriteByte('\n')
+}
+
+// sample writes one sample line of the metric family, with
+// given name suffix, label values, any extra label and value.
+func (d *desc) sample(buf *bytes.Buffer, suffix string, values []string, extra string, value float64) {
+	buf.WriteString(d.name)
+	buf.WriteString(suffix)
+
+	if len(values) > 0 || extra != "" {
+		buf.WriteByte('{')
+		for i, label := range d.labels {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(label)
+			buf.WriteString(`="`)
+			buf.WriteString(labelReplacer.Replace(values[i]))
+			buf.WriteByte('"')
+		}
+		if extra != "" {
+			if len(values) > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(extra)
+		}
+		buf.WriteByte('}')
+	}
+
+	buf.WriteByte(' ')
+	buf.WriteString(formatFloat(value))
+	buf.WriteByte('\n')
+}
+
+// labelReplacer escapes label values.
+var labelReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// formatFloat formats a sample value.
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, +1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+}
+
+// key joins label values into a map key.
+func key(values []string) string {
+	return strings.Join(values, "\xff")
+}
+
+// CounterVec is a set of counters, partitioned by label values.
+type CounterVec struct {
+	desc
+	mu     sync.Mutex
+	values map[string]*counter
+}
+
+type counter struct {
+	labels []string
+	value  float64
+}
+
+// newCounterVec returns a new registered CounterVec.
+func newCounterVec(r *registry, name string, help string, labels ...string) *CounterVec {
+	c := &CounterVec{
+		desc:   desc{name: name, help: help, kind: "counter", labels: labels},
+		values: make(map[string]*counter),
+	}
+	r.register(c)
+	return c
+}
+
+// Add adds v to the counter with the given label values.
+func (c *CounterVec) Add(v float64, values ...string) {
+	k := key(values)
+
+	c.mu.Lock()
+	ctr, ok := c.values[k]
+	if !ok {
+		ctr = &counter{labels: values}
+		c.values[k] = ctr
+	}
+	ctr.value += v
+	c.mu.Unlock()
+}
+
+// Inc increments the counter with the given label values.
+func (c *CounterVec) Inc(values ...string) {
+	c.Add(1, values...)
+}
+
+func (c *CounterVec) collect(buf *bytes.Buffer) {
+	c.header(buf)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, k := range sortedKeys(c.values) {
+		ctr := c.values[k]
+		c.sample(buf, "", ctr.labels, "", ctr.value)
+	}
+}
+
+// HistogramVec is a set of histograms, partitioned by label values.
+type HistogramVec struct {
+	desc
+	buckets []float64
+	mu      sync.Mutex
+	values  map[string]*histogram
+}
+
+type histogram struct {
+	labels []string
+	counts []uint64 // per bucket, non-cumulative
+	count  uint64
+	sum    float64
+}
+
+// newHistogramVec returns a new registered HistogramVec with given (sorted) bucket upper bounds.
+func newHistogramVec(r *registry, name string, help string, buckets []float64, labels ...string) *HistogramVec {
+	h := &HistogramVec{
+		desc:    desc{name: name, help: help, kind: "histogram", labels: labels},
+		buckets: buckets,
+		values:  make(map[string]*histogram),
+	}
+	r.register(h)
+	return h
+}
+
+// Observe adds an observation of v to the histogram with the given label values.
+func (h *HistogramVec) Observe(v float64, values ...string) {
+	k := key(values)
+
+	// Find first bucket that v fits in.
+	i := sort.SearchFloat64s(h.buckets, v)
+
+	h.mu.Lock()
+	hst, ok := h.values[k]
+	if !ok {
+		hst = &histogram{labels: values, counts: make([]uint64, len(h.buckets))}
+		h.values[k] = hst
+	}
+	if i < len(h.buckets) {
+		hst.counts[i]++
+	}
+	hst.count++
+	hst.sum += v
+	h.mu.Unlock()
+}
+
+func (h *HistogramVec) collect(buf *bytes.Buffer) {
+	h.header(buf)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, k := range sortedKeys(h.values) {
+		hst := h.values[k]
+
+		var cumulative uint64
+		for i, upper := range h.buckets {
+			cumulative += hst.counts[i]
+			h.sample(buf, "_bucket", hst.labels, `le="`+formatFloat(upper)+`"`, float64(cumulative))
+		}
+		h.sample(buf, "_bucket", hst.labels, `le="+Inf"`, float64(hst.count))
+		h.sample(buf, "_sum", hst.labels, "", hst.sum)
+		h.sample(buf, "_count", hst.labels, "", float64(hst.count))
+	}
+}
+
+// FuncVec is a set of gauges or counters, partitioned by label
+// values, whose values are read by a callback on each collection.
+type FuncVec struct {
+	desc
+	fn func(emit func(v float64, values ...string))
+}
+
+// newFuncVec returns a new registered FuncVec of given kind (gauge or counter). Each
+// time metrics are collected, fn is called, and should call emit once for each value.
+func newFuncVec(r *registry, name string, help string, kind string, fn func(emit func(v float64, values ...string)), labels ...string) *FuncVec {
+	f := &FuncVec{
+		desc: desc{name: name, help: help, kind: kind, labels: labels},
+		fn:   fn,
+	}
+	r.register(f)
+	return f
+}
+
+func (f *FuncVec) collect(buf *bytes.Buffer) {
+	f.header(buf)
+	f.fn(func(v float64, values ...string) {
+		f.sample(buf, "", values, "", v)
+	})
+}
+
+// sortedKeys returns the keys of the given map, sorted, for stable output.
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -76,6 +76,11 @@ func CORS() gin.HandlerFunc {
 			"X-RateLimit-Reset",
 			"X-RateLimit-Limit",
 			"X-RateLimit-Remaining",
+			"RateLimit-Limit",
+			"RateLimit-Remaining",
+			"RateLimit-Reset",
+			"RateLimit-Policy",
+			"Retry-After",
 			"X-Request-Id",
 
 			// websocket stuff
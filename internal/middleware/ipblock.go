@@ -0,0 +1,65 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/netip"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// IPBlock returns a gin middleware which aborts requests from
+// IP addresses in ranges blocked with severity no_access,
+// returning code 403 - Forbidden.
+//
+// The client IP is determined by gin, so X-Forwarded-For and
+// similar headers are only taken into account for requests
+// which come through one of the configured trusted proxies.
+//
+// Lesser severities only apply to sign-ups, so they're
+// left to the account creation logic to enforce.
+//
+// If the block can't be checked due to a database
+// error, the request is logged and let through.
+func IPBlock(dbConn db.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		addr, err := netip.ParseAddr(c.ClientIP())
+		if err != nil {
+			// Gin couldn't work out a client IP,
+			// so there's nothing to check against.
+			return
+		}
+
+		severity, err := dbConn.GetIPBlockSeverity(c.Request.Context(), addr)
+		if err != nil {
+			log.Errorf(c.Request.Context(), "error checking ip block for %s: %v", addr, err)
+			return
+		}
+
+		if severity == gtsmodel.IPBlockNoAccess {
+			code := http.StatusForbidden
+			err := errors.New(http.StatusText(code) + ": requests from your ip address are blocked")
+			c.AbortWithStatusJSON(code, gin.H{"error": err.Error()})
+		}
+	}
+}
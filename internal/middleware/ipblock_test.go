@@ -0,0 +1,105 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type IPBlockTestSuite struct {
+	suite.Suite
+	state  state.State
+	db     db.DB
+	engine *gin.Engine
+}
+
+func (suite *IPBlockTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+
+	suite.state.Caches.Init()
+	suite.db = testrig.NewTestDB(&suite.state)
+	suite.state.DB = suite.db
+	testrig.StandardDBSetup(suite.db, nil)
+
+	suite.engine = gin.New()
+	if err := suite.engine.SetTrustedProxies([]string{"127.0.0.1/32"}); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.engine.Use(middleware.IPBlock(suite.db))
+	suite.engine.GET("/", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+}
+
+func (suite *IPBlockTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+}
+
+func (suite *IPBlockTestSuite) request(remoteAddr string, forwardedFor string) int {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = remoteAddr
+	if forwardedFor != "" {
+		r.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+
+	recorder := httptest.NewRecorder()
+	suite.engine.ServeHTTP(recorder, r)
+	return recorder.Code
+}
+
+func (suite *IPBlockTestSuite) TestNotBlocked() {
+	suite.Equal(http.StatusOK, suite.request("192.0.2.1:1234", ""))
+}
+
+func (suite *IPBlockTestSuite) TestSignUpSeverityNotBlocked() {
+	// Sign-up blocks shouldn't stop any other requests.
+	suite.Equal(http.StatusOK, suite.request("198.51.100.70:1234", ""))
+}
+
+func (suite *IPBlockTestSuite) TestNoAccessBlocked() {
+	suite.Equal(http.StatusForbidden, suite.request("[2001:db8::1]:1234", ""))
+}
+
+func (suite *IPBlockTestSuite) TestExpiredNotBlocked() {
+	suite.Equal(http.StatusOK, suite.request("203.0.113.5:1234", ""))
+}
+
+func (suite *IPBlockTestSuite) TestTrustedProxy() {
+	// Forwarded for a blocked address by a trusted proxy.
+	suite.Equal(http.StatusForbidden, suite.request("127.0.0.1:1234", "2001:db8::1"))
+}
+
+func (suite *IPBlockTestSuite) TestUntrustedProxy() {
+	// Forwarded for a blocked address by an untrusted
+	// proxy, so the header should be ignored.
+	suite.Equal(http.StatusOK, suite.request("192.0.2.1:1234", "2001:db8::1"))
+}
+
+func TestIPBlockTestSuite(t *testing.T) {
+	suite.Run(t, &IPBlockTestSuite{})
+}
@@ -18,49 +18,248 @@
 package middleware
 
 import (
-	"net"
 	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/ulule/limiter/v3"
-	limitergin "github.com/ulule/limiter/v3/drivers/middleware/gin"
 	"github.com/ulule/limiter/v3/drivers/store/memory"
 )
 
 const rateLimitPeriod = 5 * time.Minute
 
-// RateLimit returns a gin middleware that will automatically rate limit caller (by IP address),
-// and enrich the response header with the following headers:
+// RateLimitClass is a class of routes which get a rate limit
+// budget of their own, separate from other routes. Requests
+// to routes in a class only count against that class' budget.
+type RateLimitClass struct {
+	// Name of the class, given in the RateLimit-Policy header.
+	Name string
+
+	// Limit is the amount of requests to permit within a
+	// 5 minute window. 0 or less means no rate limiting for
+	// routes in this class.
+	Limit int
+
+	// Routes in this class, as "METHOD /full/route/path",
+	// eg., "POST /api/v1/statuses", with paths as they were
+	// registered on the router. A trailing "*" matches any
+	// route starting with what comes before it.
+	Routes []string
+
+	// Verified, if true, means that requests in this class
+	// are charged against the budget of the domain they come
+	// from instead, once the handler has verified that domain
+	// (eg., by checking the request's http signature). See
+	// gtscontext.RateLimit(). Until then, requests are rate
+	// limited by IP address, so that unverified requests
+	// can't spend the budget of a domain they don't control;
+	// once verified, the request is refunded to the IP.
+	Verified bool
+}
+
+// RateLimit returns a gin middleware that will automatically rate limit callers
+// by IP address (and, for some classes, by verified domain), and enrich the
+// response with the following headers:
 //
 //   - `x-ratelimit-limit`     - maximum number of requests allowed per time period (fixed).
 //   - `x-ratelimit-remaining` - number of remaining requests that can still be performed.
 //   - `x-ratelimit-reset`     - unix timestamp when the rate limit will reset.
+//   - `ratelimit-limit`, `ratelimit-remaining`, `ratelimit-reset` and `ratelimit-policy`,
+//     as in the IETF draft for rate limit headers, with reset in seconds from now.
 //
-// If `x-ratelimit-limit` is exceeded, the request is aborted and an HTTP 429 TooManyRequests
-// status is returned.
+// If the limit is exceeded, the request is aborted and an HTTP 429 TooManyRequests
+// status is returned, with a `retry-after` header.
 //
-// If the config AdvancedRateLimitRequests value is <= 0, then a noop handler will be returned,
-// which performs no rate limiting.
-func RateLimit(limit int) gin.HandlerFunc {
-	if limit <= 0 {
+// Requests to routes in one of the given classes count against the budget of
+// that class only; all other requests count against the shared budget of limit
+// requests. A limit <= 0 turns rate limiting off for requests counting against
+// that budget. Requests from IP addresses within one of the except prefixes are
+// never rate limited.
+func RateLimit(limit int, except []netip.Prefix, classes ...RateLimitClass) gin.HandlerFunc {
+	// Check whether any rate limiting is enabled at all.
+	enabled := limit > 0
+	for _, class := range classes {
+		enabled = enabled || class.Limit > 0
+	}
+
+	if !enabled {
 		// use noop middleware if ratelimiting is disabled
 		return func(ctx *gin.Context) {}
 	}
 
-	limiter := limiter.New(
-		memory.NewStore(),
-		limiter.Rate{Period: rateLimitPeriod, Limit: int64(limit)},
-		limiter.WithIPv6Mask(net.CIDRMask(64, 128)), // apply /64 mask to IPv6 addresses
-	)
+	defaultBucket := newRateLimitBucket("default", limit, false)
+
+	// Index classes by route for quick lookup,
+	// keeping prefix routes aside to check in turn.
+	exact := make(map[string]*rateLimitBucket)
+	type prefixRoute struct {
+		prefix string
+		bucket *rateLimitBucket
+	}
+	var prefixes []prefixRoute
+
+	for _, class := range classes {
+		bucket := newRateLimitBucket(class.Name, class.Limit, class.Verified)
+		for _, route := range class.Routes {
+			if prefix, ok := strings.CutSuffix(route, "*"); ok {
+				prefixes = append(prefixes, prefixRoute{prefix, bucket})
+			} else {
+				exact[route] = bucket
+			}
+		}
+	}
+
+	bucketFor := func(c *gin.Context) *rateLimitBucket {
+		route := c.Request.Method + " " + c.FullPath()
+		if bucket, ok := exact[route]; ok {
+			return bucket
+		}
+		for _, p := range prefixes {
+			if strings.HasPrefix(route, p.prefix) {
+				return p.bucket
+			}
+		}
+		return defaultBucket
+	}
+
+	return func(c *gin.Context) {
+		bucket := bucketFor(c)
+		if bucket.limiter == nil {
+			// Rate limiting disabled for this bucket.
+			return
+		}
+
+		addr, err := netip.ParseAddr(c.ClientIP())
+		if err != nil {
+			// Without an IP we can't tell
+			// who to rate limit, so allow.
+			return
+		}
+		addr = addr.Unmap()
+
+		for _, prefix := range except {
+			if prefix.Contains(addr) {
+				return
+			}
+		}
+
+		key := ipKey(addr)
+		if !bucket.charge(c, key) {
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "rate limit reached"})
+			return
+		}
+
+		if bucket.verified {
+			// Let the handler move the request over to
+			// its domain's budget, once that's verified,
+			// so that many domains behind one IP (eg., a
+			// shared host) don't use up each other's budget.
+			ctx := gtscontext.SetRateLimit(c.Request.Context(), func(domain string) bool {
+				bucket.refund(c, key)
+				return bucket.charge(c, "domain:"+domain)
+			})
+			c.Request = c.Request.WithContext(ctx)
+		}
+	}
+}
+
+// rateLimitBucket wraps a limiter for one
+// budget of requests, along with whether it
+// charges verified domains, and its
+// RateLimit-Policy header value.
+type rateLimitBucket struct {
+	limiter  *limiter.Limiter
+	verified bool
+	policy   string
+}
+
+func newRateLimitBucket(name string, limit int, verified bool) *rateLimitBucket {
+	if limit <= 0 {
+		return &rateLimitBucket{}
+	}
+
+	return &rateLimitBucket{
+		limiter: limiter.New(
+			memory.NewStore(),
+			limiter.Rate{Period: rateLimitPeriod, Limit: int64(limit)},
+		),
+		verified: verified,
+		policy: strconv.Itoa(limit) +
+			";w=" + strconv.Itoa(int(rateLimitPeriod/time.Second)) +
+			";comment=\"" + name + "\"",
+	}
+}
+
+// charge counts one request against the budget of the given
+// key, setting rate limit headers on the response. It returns
+// false, having set the Retry-After header, if the budget of
+// the key is used up and the request should be refused.
+func (b *rateLimitBucket) charge(c *gin.Context, key string) bool {
+	ctx := c.Request.Context()
+	lctx, err := b.limiter.Get(ctx, key)
+	if err != nil {
+		// The in-memory store doesn't return errors,
+		// but if it ever does, don't lock people out.
+		log.Errorf(ctx, "error getting rate limit for %s: %v", key, err)
+		return true
+	}
+
+	resetIn := lctx.Reset - time.Now().Unix()
+	if resetIn < 0 {
+		resetIn = 0
+	}
+	resetSecs := strconv.FormatInt(resetIn, 10)
+
+	h := c.Writer.Header()
+	h.Set("X-RateLimit-Limit", strconv.FormatInt(lctx.Limit, 10))
+	h.Set("X-RateLimit-Remaining", strconv.FormatInt(lctx.Remaining, 10))
+	h.Set("X-RateLimit-Reset", strconv.FormatInt(lctx.Reset, 10))
+	h.Set("RateLimit-Limit", strconv.FormatInt(lctx.Limit, 10))
+	h.Set("RateLimit-Remaining", strconv.FormatInt(lctx.Remaining, 10))
+	h.Set("RateLimit-Reset", resetSecs)
+	h.Set("RateLimit-Policy", b.policy)
+
+	if lctx.Reached {
+		h.Set("Retry-After", resetSecs)
+		return false
+	}
+
+	return true
+}
 
-	// use custom rate limit reached error
-	handler := func(c *gin.Context) {
-		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "rate limit reached"})
+// refund gives back one request previously
+// charged against the budget of the given key.
+func (b *rateLimitBucket) refund(c *gin.Context, key string) {
+	ctx := c.Request.Context()
+	lctx, err := b.limiter.Peek(ctx, key)
+	if err != nil {
+		log.Errorf(ctx, "error peeking rate limit for %s: %v", key, err)
+		return
 	}
 
-	return limitergin.NewMiddleware(
-		limiter,
-		limitergin.WithLimitReachedHandler(handler),
-	)
+	if lctx.Remaining >= lctx.Limit {
+		// Nothing left to refund, eg., the
+		// window reset since we charged it.
+		return
+	}
+
+	if _, err := b.limiter.Increment(ctx, key, -1); err != nil {
+		log.Errorf(ctx, "error refunding rate limit for %s: %v", key, err)
+	}
+}
+
+// ipKey returns the rate limit key for the
+// given address. IPv6 addresses are masked
+// to /64, since that's what most people get.
+func ipKey(addr netip.Addr) string {
+	if addr.Is6() {
+		prefix, _ := addr.Prefix(64)
+		return prefix.String()
+	}
+	return addr.String()
 }
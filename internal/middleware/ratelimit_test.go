@@ -0,0 +1,242 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+)
+
+type RateLimitTestSuite struct {
+	suite.Suite
+}
+
+func (suite *RateLimitTestSuite) engine(limit int, except []netip.Prefix, classes ...middleware.RateLimitClass) *gin.Engine {
+	engine := gin.New()
+	engine.Use(middleware.RateLimit(limit, except, classes...))
+
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	engine.GET("/api/v1/accounts/:id", ok)
+	engine.POST("/api/v1/statuses", ok)
+	engine.GET("/api/v1/timelines/home", ok)
+	engine.GET("/api/v1/timelines/public", ok)
+
+	// Fake signature verification by taking the
+	// verified domain straight from a header.
+	engine.POST("/users/:username/inbox", func(c *gin.Context) {
+		domain := c.GetHeader("Test-Verified-Domain")
+		if domain == "" {
+			c.Status(http.StatusUnauthorized)
+			return
+		}
+
+		if rateLimit := gtscontext.RateLimit(c.Request.Context()); rateLimit != nil && !rateLimit(domain) {
+			c.Status(http.StatusTooManyRequests)
+			return
+		}
+
+		c.Status(http.StatusOK)
+	})
+
+	return engine
+}
+
+func (suite *RateLimitTestSuite) request(engine *gin.Engine, method string, path string, remoteAddr string, domain string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, path, nil)
+	r.RemoteAddr = remoteAddr
+	if domain != "" {
+		r.Header.Set("Test-Verified-Domain", domain)
+	}
+
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, r)
+	return recorder
+}
+
+func (suite *RateLimitTestSuite) TestRateLimit() {
+	engine := suite.engine(2, nil)
+
+	rec := suite.request(engine, http.MethodGet, "/api/v1/accounts/1", "192.0.2.1:1234", "")
+	suite.Equal(http.StatusOK, rec.Code)
+	suite.Equal("2", rec.Header().Get("RateLimit-Limit"))
+	suite.Equal("1", rec.Header().Get("RateLimit-Remaining"))
+	suite.Equal("300", rec.Header().Get("RateLimit-Reset"))
+	suite.Equal(`2;w=300;comment="default"`, rec.Header().Get("RateLimit-Policy"))
+	suite.Equal("2", rec.Header().Get("X-RateLimit-Limit"))
+	suite.Equal("1", rec.Header().Get("X-RateLimit-Remaining"))
+	suite.NotEmpty(rec.Header().Get("X-RateLimit-Reset"))
+
+	rec = suite.request(engine, http.MethodGet, "/api/v1/accounts/1", "192.0.2.1:1234", "")
+	suite.Equal(http.StatusOK, rec.Code)
+	suite.Equal("0", rec.Header().Get("RateLimit-Remaining"))
+
+	rec = suite.request(engine, http.MethodGet, "/api/v1/accounts/1", "192.0.2.1:1234", "")
+	suite.Equal(http.StatusTooManyRequests, rec.Code)
+	suite.Equal("300", rec.Header().Get("Retry-After"))
+	suite.Equal(`{"error":"rate limit reached"}`, rec.Body.String())
+
+	// Someone else shouldn't be affected.
+	rec = suite.request(engine, http.MethodGet, "/api/v1/accounts/1", "192.0.2.2:1234", "")
+	suite.Equal(http.StatusOK, rec.Code)
+}
+
+func (suite *RateLimitTestSuite) TestRateLimitIPv6Mask() {
+	engine := suite.engine(1, nil)
+
+	rec := suite.request(engine, http.MethodGet, "/api/v1/accounts/1", "[2001:db8::1]:1234", "")
+	suite.Equal(http.StatusOK, rec.Code)
+
+	// Same /64, so should share the budget.
+	rec = suite.request(engine, http.MethodGet, "/api/v1/accounts/1", "[2001:db8::2]:1234", "")
+	suite.Equal(http.StatusTooManyRequests, rec.Code)
+}
+
+func (suite *RateLimitTestSuite) TestRateLimitExcept() {
+	engine := suite.engine(1, []netip.Prefix{netip.MustParsePrefix("192.0.2.0/24")})
+
+	for i := 0; i < 3; i++ {
+		rec := suite.request(engine, http.MethodGet, "/api/v1/accounts/1", "192.0.2.1:1234", "")
+		suite.Equal(http.StatusOK, rec.Code)
+		suite.Empty(rec.Header().Get("RateLimit-Limit"))
+	}
+
+	rec := suite.request(engine, http.MethodGet, "/api/v1/accounts/1", "198.51.100.1:1234", "")
+	suite.Equal(http.StatusOK, rec.Code)
+	suite.Equal("1", rec.Header().Get("RateLimit-Limit"))
+}
+
+func (suite *RateLimitTestSuite) TestRateLimitClasses() {
+	engine := suite.engine(1, nil,
+		middleware.RateLimitClass{
+			Name:   "status_post",
+			Limit:  2,
+			Routes: []string{"POST /api/v1/statuses"},
+		},
+		middleware.RateLimitClass{
+			Name:   "timeline",
+			Limit:  3,
+			Routes: []string{"GET /api/v1/timelines/*"},
+		},
+	)
+
+	// Use up the timeline budget across
+	// different routes in the class.
+	for _, path := range []string{
+		"/api/v1/timelines/home",
+		"/api/v1/timelines/public",
+		"/api/v1/timelines/home",
+	} {
+		rec := suite.request(engine, http.MethodGet, path, "192.0.2.1:1234", "")
+		suite.Equal(http.StatusOK, rec.Code)
+		suite.Equal(`3;w=300;comment="timeline"`, rec.Header().Get("RateLimit-Policy"))
+	}
+	rec := suite.request(engine, http.MethodGet, "/api/v1/timelines/public", "192.0.2.1:1234", "")
+	suite.Equal(http.StatusTooManyRequests, rec.Code)
+
+	// Posting and other requests should be unaffected.
+	rec = suite.request(engine, http.MethodPost, "/api/v1/statuses", "192.0.2.1:1234", "")
+	suite.Equal(http.StatusOK, rec.Code)
+	suite.Equal("1", rec.Header().Get("RateLimit-Remaining"))
+
+	rec = suite.request(engine, http.MethodGet, "/api/v1/accounts/1", "192.0.2.1:1234", "")
+	suite.Equal(http.StatusOK, rec.Code)
+	suite.Equal("0", rec.Header().Get("RateLimit-Remaining"))
+}
+
+func (suite *RateLimitTestSuite) TestRateLimitClassDisabled() {
+	engine := suite.engine(1, nil, middleware.RateLimitClass{
+		Name:   "status_post",
+		Limit:  0,
+		Routes: []string{"POST /api/v1/statuses"},
+	})
+
+	for i := 0; i < 3; i++ {
+		rec := suite.request(engine, http.MethodPost, "/api/v1/statuses", "192.0.2.1:1234", "")
+		suite.Equal(http.StatusOK, rec.Code)
+	}
+}
+
+func (suite *RateLimitTestSuite) TestRateLimitVerifiedDomain() {
+	engine := suite.engine(0, nil, middleware.RateLimitClass{
+		Name:     "inbox",
+		Limit:    2,
+		Routes:   []string{"POST /users/:username/inbox"},
+		Verified: true,
+	})
+
+	// Unverified requests (eg., claiming to be from
+	// example.org) are only charged to their IP, so
+	// they can't use up example.org's budget.
+	for i := 0; i < 2; i++ {
+		rec := suite.request(engine, http.MethodPost, "/users/the_mighty_zork/inbox", "192.0.2.9:1234", "")
+		suite.Equal(http.StatusUnauthorized, rec.Code)
+	}
+	rec := suite.request(engine, http.MethodPost, "/users/the_mighty_zork/inbox", "192.0.2.9:1234", "")
+	suite.Equal(http.StatusTooManyRequests, rec.Code)
+
+	// Different IPs verified as the same
+	// domain share that domain's budget.
+	rec = suite.request(engine, http.MethodPost, "/users/the_mighty_zork/inbox", "192.0.2.1:1234", "example.org")
+	suite.Equal(http.StatusOK, rec.Code)
+	rec = suite.request(engine, http.MethodPost, "/users/the_mighty_zork/inbox", "192.0.2.2:1234", "example.org")
+	suite.Equal(http.StatusOK, rec.Code)
+	suite.Equal("0", rec.Header().Get("RateLimit-Remaining"))
+	rec = suite.request(engine, http.MethodPost, "/users/the_mighty_zork/inbox", "192.0.2.3:1234", "example.org")
+	suite.Equal(http.StatusTooManyRequests, rec.Code)
+	suite.NotEmpty(rec.Header().Get("Retry-After"))
+
+	// Another domain isn't affected.
+	rec = suite.request(engine, http.MethodPost, "/users/the_mighty_zork/inbox", "192.0.2.3:1234", "fossbros-anonymous.io")
+	suite.Equal(http.StatusOK, rec.Code)
+}
+
+func (suite *RateLimitTestSuite) TestRateLimitVerifiedRefundsIP() {
+	engine := suite.engine(0, nil, middleware.RateLimitClass{
+		Name:     "inbox",
+		Limit:    2,
+		Routes:   []string{"POST /users/:username/inbox"},
+		Verified: true,
+	})
+
+	// Several domains behind one IP each get their
+	// own budget, without using up the IP's budget.
+	for _, domain := range []string{"example.org", "fossbros-anonymous.io", "example.org", "fossbros-anonymous.io"} {
+		rec := suite.request(engine, http.MethodPost, "/users/the_mighty_zork/inbox", "192.0.2.5:1234", domain)
+		suite.Equal(http.StatusOK, rec.Code)
+	}
+
+	// So the IP still has its full
+	// budget for unverified requests.
+	for i := 0; i < 2; i++ {
+		rec := suite.request(engine, http.MethodPost, "/users/the_mighty_zork/inbox", "192.0.2.5:1234", "")
+		suite.Equal(http.StatusUnauthorized, rec.Code)
+	}
+	rec := suite.request(engine, http.MethodPost, "/users/the_mighty_zork/inbox", "192.0.2.5:1234", "")
+	suite.Equal(http.StatusTooManyRequests, rec.Code)
+}
+
+func TestRateLimitTestSuite(t *testing.T) {
+	suite.Run(t, &RateLimitTestSuite{})
+}
@@ -0,0 +1,52 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/oauth2/v4"
+)
+
+// RequireScope returns a new gin middleware which aborts requests
+// with 403 Forbidden if the oauth token set on the gin context by
+// TokenCheck was not granted the given scope.
+//
+// Requests without a token are passed through, since handlers
+// themselves decide whether a token is required for the route.
+func RequireScope(scope oauth.Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		i, ok := c.Get(oauth.SessionAuthorizedToken)
+		if !ok {
+			return
+		}
+
+		ti, ok := i.(oauth2.TokenInfo)
+		if !ok {
+			return
+		}
+
+		if !oauth.ScopesPermit(ti.GetScope(), scope) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": http.StatusText(http.StatusForbidden) + ": token is missing scope " + string(scope),
+			})
+		}
+	}
+}
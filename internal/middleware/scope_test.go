@@ -0,0 +1,72 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/oauth2/v4/models"
+)
+
+type ScopeTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ScopeTestSuite) request(scope string, setToken bool) *httptest.ResponseRecorder {
+	engine := gin.New()
+	engine.GET("/",
+		func(c *gin.Context) {
+			if setToken {
+				c.Set(oauth.SessionAuthorizedToken, &models.Token{Scope: scope})
+			}
+		},
+		middleware.RequireScope(oauth.ScopeWriteStatuses),
+		func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		},
+	)
+
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	return recorder
+}
+
+func (suite *ScopeTestSuite) TestScopePermitted() {
+	suite.Equal(http.StatusOK, suite.request("read write", true).Code)
+	suite.Equal(http.StatusOK, suite.request("write:statuses", true).Code)
+}
+
+func (suite *ScopeTestSuite) TestScopeMissing() {
+	recorder := suite.request("read write:media", true)
+	suite.Equal(http.StatusForbidden, recorder.Code)
+	suite.Equal(`{"error":"Forbidden: token is missing scope write:statuses"}`, recorder.Body.String())
+}
+
+func (suite *ScopeTestSuite) TestNoToken() {
+	suite.Equal(http.StatusOK, suite.request("", false).Code)
+}
+
+func TestScopeTestSuite(t *testing.T) {
+	suite.Run(t, &ScopeTestSuite{})
+}
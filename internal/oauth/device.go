@@ -0,0 +1,352 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/oauth2/v4"
+)
+
+const (
+	// DeviceCodeGrantType is the grant type used by
+	// clients to exchange a device code for a token.
+	DeviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+	// DeviceVerificationPath is the path of the page where
+	// users enter the user code shown on their device.
+	DeviceVerificationPath = "/oauth/device"
+
+	deviceCodeExpiry   = 10 * time.Minute // how long a device authorization is valid for
+	deviceCodeInterval = 5                // default polling interval in seconds
+	deviceCodeSlowDown = 5                // seconds added to the interval on each slow_down
+
+	// Characters used in user codes. These are uppercase
+	// consonants only, which avoids both ambiguous chars
+	// like 0/O and 1/I, and accidentally spelling words.
+	userCodeChars = "BCDFGHJKLMNPQRSTVWXZ"
+	userCodeLen   = 8
+)
+
+// DeviceAuthorize handles a device authorization request from
+// the given client, as per RFC 8628 section 3.1, returning
+// the device authorization response to serve to the client.
+//
+// The scope is validated against the scopes the application was
+// registered with, and defaults to 'read', as in the authorize flow.
+func (s *s) DeviceAuthorize(ctx context.Context, clientID string, clientSecret string, scope string) (map[string]interface{}, gtserror.WithCode) {
+	if clientID == "" {
+		return nil, gtserror.NewErrorBadRequest(InvalidRequest(), "client_id was not set in the device authorization request form")
+	}
+
+	client, errWithCode := s.deviceClient(ctx, clientID, clientSecret)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	app := &gtsmodel.Application{}
+	if err := s.db.GetWhere(ctx, []db.Where{{Key: "client_id", Value: client.GetID()}}, app); err != nil {
+		err := fmt.Errorf("error getting application for client %s: %w", clientID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if scope == "" {
+		scope = "read"
+	}
+
+	scope, err := ValidateScopes(scope, app.Scopes)
+	if err != nil {
+		return nil, gtserror.NewErrorBadRequest(InvalidScope(), err.Error())
+	}
+
+	deviceCode, err := newDeviceCode()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	userCode, err := newUserCode()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	da := &gtsmodel.DeviceAuthorization{
+		ID:         id.NewULID(),
+		ClientID:   client.GetID(),
+		DeviceCode: deviceCode,
+		UserCode:   userCode,
+		Scope:      scope,
+		Interval:   deviceCodeInterval,
+		ExpiresAt:  time.Now().Add(deviceCodeExpiry),
+		Denied:     func() *bool { b := false; return &b }(),
+	}
+
+	if err := s.db.Put(ctx, da); err != nil {
+		err := fmt.Errorf("error storing device authorization: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	verificationURI := config.GetProtocol() + "://" + config.GetHost() + DeviceVerificationPath
+
+	return map[string]interface{}{
+		"device_code":               da.DeviceCode,
+		"user_code":                 da.UserCode,
+		"verification_uri":          verificationURI,
+		"verification_uri_complete": verificationURI + "?user_code=" + url.QueryEscape(da.UserCode),
+		"expires_in":                int64(deviceCodeExpiry / time.Second),
+		"interval":                  da.Interval,
+	}, nil
+}
+
+// GetDeviceAuthorization returns the pending device authorization
+// with the given user code, as entered by a user. Codes which have
+// expired, or which have already been approved or denied, are
+// treated as not found.
+func (s *s) GetDeviceAuthorization(ctx context.Context, userCode string) (*gtsmodel.DeviceAuthorization, gtserror.WithCode) {
+	userCode = normalizeUserCode(userCode)
+	if userCode == "" {
+		err := errors.New("no code provided")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	da := &gtsmodel.DeviceAuthorization{}
+	if err := s.db.GetWhere(ctx, []db.Where{{Key: "user_code", Value: userCode}}, da); err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			err := fmt.Errorf("error getting device authorization: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		da = nil
+	}
+
+	if da == nil || da.Expired(time.Now()) || da.Approved() || *da.Denied {
+		err := fmt.Errorf("code %s not recognised, or it has expired", userCode)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	return da, nil
+}
+
+// ResolveDeviceAuthorization approves or denies the pending device
+// authorization with the given user code, on behalf of the given user.
+// Once approved, the next poll by the client will yield a token for
+// the user.
+func (s *s) ResolveDeviceAuthorization(ctx context.Context, userCode string, userID string, approve bool) gtserror.WithCode {
+	da, errWithCode := s.GetDeviceAuthorization(ctx, userCode)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	var column string
+	if approve {
+		da.UserID = userID
+		column = "user_id"
+	} else {
+		da.Denied = func() *bool { b := true; return &b }()
+		column = "denied"
+	}
+
+	da.UpdatedAt = time.Now()
+	if err := s.db.UpdateByID(ctx, da, da.ID, column, "updated_at"); err != nil {
+		err := fmt.Errorf("error updating device authorization: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// HandleDeviceTokenRequest handles a token request from a client
+// polling with a device code, as per RFC 8628 section 3.4, returning
+// the token response if the user has approved the authorization.
+//
+// Until then, it returns an 'authorization_pending' error, or a
+// 'slow_down' error if the client polls faster than its interval,
+// in which case the interval is also increased for future polls.
+func (s *s) HandleDeviceTokenRequest(ctx context.Context, clientID string, clientSecret string, deviceCode string) (map[string]interface{}, gtserror.WithCode) {
+	if clientID == "" {
+		return nil, gtserror.NewErrorBadRequest(InvalidRequest(), "client_id was not set in the token request form")
+	}
+
+	if deviceCode == "" {
+		return nil, gtserror.NewErrorBadRequest(InvalidRequest(), "device_code was not set in the token request form, but must be set since grant_type is "+DeviceCodeGrantType)
+	}
+
+	client, errWithCode := s.deviceClient(ctx, clientID, clientSecret)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	da := &gtsmodel.DeviceAuthorization{}
+	if err := s.db.GetWhere(ctx, []db.Where{{Key: "device_code", Value: deviceCode}}, da); err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			err := fmt.Errorf("error getting device authorization: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		return nil, gtserror.NewErrorBadRequest(InvalidGrant(), "device_code not recognised")
+	}
+
+	if da.ClientID != client.GetID() {
+		return nil, gtserror.NewErrorBadRequest(InvalidGrant(), "device_code was not issued to client "+clientID)
+	}
+
+	now := time.Now()
+
+	switch {
+	case da.Expired(now):
+		s.deleteDeviceAuthorization(ctx, da)
+		return nil, gtserror.NewErrorBadRequest(ExpiredToken(), "device_code has expired; please start a new device authorization request")
+
+	case *da.Denied:
+		s.deleteDeviceAuthorization(ctx, da)
+		return nil, gtserror.NewErrorBadRequest(AccessDenied(), "the user denied the authorization request")
+
+	case !da.Approved():
+		polledTooSoon := !da.LastPolledAt.IsZero() &&
+			now.Sub(da.LastPolledAt) < time.Duration(da.Interval)*time.Second
+
+		columns := []string{"last_polled_at", "updated_at"}
+		if polledTooSoon {
+			da.Interval += deviceCodeSlowDown
+			columns = append(columns, "interval")
+		}
+
+		da.LastPolledAt = now
+		da.UpdatedAt = now
+		if err := s.db.UpdateByID(ctx, da, da.ID, columns...); err != nil {
+			err := fmt.Errorf("error updating device authorization: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if polledTooSoon {
+			help := fmt.Sprintf("polling too frequently; please wait at least %d seconds between requests", da.Interval)
+			return nil, gtserror.NewErrorBadRequest(SlowDown(), help)
+		}
+
+		return nil, gtserror.NewErrorBadRequest(AuthorizationPending(), "the user has not yet approved the authorization request")
+	}
+
+	// The user approved, so this device code is now
+	// spent; remove it before issuing the token so
+	// it can't be used to obtain another one. Only
+	// the request that actually deleted it gets a
+	// token, in case the device polled concurrently.
+	deleted, err := s.db.DeleteApprovedDeviceAuthorization(ctx, da.ID)
+	if err != nil {
+		err := fmt.Errorf("error deleting device authorization: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !deleted {
+		return nil, gtserror.NewErrorBadRequest(InvalidGrant(), "device_code has already been used")
+	}
+
+	ti, err := s.generateUserAccessToken(ctx, &oauth2.TokenGenerateRequest{
+		ClientID:     client.GetID(),
+		ClientSecret: client.GetSecret(),
+		UserID:       da.UserID,
+		RedirectURI:  client.GetDomain(),
+		Scope:        da.Scope,
+	})
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return s.tokenData(ti)
+}
+
+// deviceClient gets the client with the given ID. Clients using the
+// device flow may not be able to keep a secret, so it's optional,
+// but if a secret is provided then it must match.
+func (s *s) deviceClient(ctx context.Context, clientID string, clientSecret string) (oauth2.ClientInfo, gtserror.WithCode) {
+	client, err := s.server.Manager.GetClient(ctx, clientID)
+	if err == nil && clientSecret != "" && subtle.ConstantTimeCompare([]byte(client.GetSecret()), []byte(clientSecret)) != 1 {
+		err = errors.New("client secret did not match")
+	}
+
+	if err != nil {
+		return nil, gtserror.NewErrorUnauthorized(InvalidClient(), fmt.Sprintf("could not authenticate client %s: %s", clientID, err))
+	}
+
+	return client, nil
+}
+
+// deleteDeviceAuthorization deletes the given spent device
+// authorization; failure is only logged, since the sweeper
+// will get to it eventually anyway.
+func (s *s) deleteDeviceAuthorization(ctx context.Context, da *gtsmodel.DeviceAuthorization) {
+	if err := s.db.DeleteByID(ctx, da.ID, da); err != nil {
+		log.Errorf(ctx, "error deleting device authorization: %v", err)
+	}
+}
+
+// newDeviceCode returns a new random device code.
+func newDeviceCode() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating device code: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// newUserCode returns a new random user
+// code, formatted like 'BCDF-GHJK'.
+func newUserCode() (string, error) {
+	var sb strings.Builder
+	max := big.NewInt(int64(len(userCodeChars)))
+	for i := 0; i < userCodeLen; i++ {
+		if i == userCodeLen/2 {
+			sb.WriteByte('-')
+		}
+
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("error generating user code: %w", err)
+		}
+		sb.WriteByte(userCodeChars[n.Int64()])
+	}
+	return sb.String(), nil
+}
+
+// normalizeUserCode normalizes a user code as typed by a user
+// into the stored format, ignoring case and any separators.
+func normalizeUserCode(userCode string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToUpper(userCode) {
+		if r >= 'A' && r <= 'Z' {
+			sb.WriteRune(r)
+		}
+	}
+
+	code := sb.String()
+	if len(code) != userCodeLen {
+		return code
+	}
+	return code[:userCodeLen/2] + "-" + code[userCodeLen/2:]
+}
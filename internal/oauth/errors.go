@@ -23,3 +23,52 @@ import "github.com/superseriousbusiness/oauth2/v4/errors"
 func InvalidRequest() error {
 	return errors.New("invalid_request")
 }
+
+// InvalidClient returns an oauth spec compliant 'invalid_client' error.
+func InvalidClient() error {
+	return errors.New("invalid_client")
+}
+
+// UnauthorizedClient returns an oauth spec compliant 'unauthorized_client' error.
+func UnauthorizedClient() error {
+	return errors.New("unauthorized_client")
+}
+
+// UnsupportedTokenType returns an oauth spec compliant 'unsupported_token_type' error.
+func UnsupportedTokenType() error {
+	return errors.New("unsupported_token_type")
+}
+
+// InvalidGrant returns an oauth spec compliant 'invalid_grant' error.
+func InvalidGrant() error {
+	return errors.New("invalid_grant")
+}
+
+// InvalidScope returns an oauth spec compliant 'invalid_scope' error.
+func InvalidScope() error {
+	return errors.New("invalid_scope")
+}
+
+// AuthorizationPending returns a device flow 'authorization_pending'
+// error, indicating the user hasn't yet approved or denied the request.
+func AuthorizationPending() error {
+	return errors.New("authorization_pending")
+}
+
+// SlowDown returns a device flow 'slow_down' error, indicating
+// the client is polling the token endpoint too frequently.
+func SlowDown() error {
+	return errors.New("slow_down")
+}
+
+// AccessDenied returns an oauth spec compliant 'access_denied' error.
+func AccessDenied() error {
+	return errors.New("access_denied")
+}
+
+// ExpiredToken returns a device flow 'expired_token' error,
+// indicating the device code has expired and the client
+// should start a new device authorization request.
+func ExpiredToken() error {
+	return errors.New("expired_token")
+}
@@ -0,0 +1,248 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oauth
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Scope is an OAuth scope, following the scopes used by Mastodon,
+// which can be granted to applications and tokens. Top-level scopes
+// like "read" include all granular scopes beneath them, like
+// "read:statuses"; see Permits.
+type Scope string
+
+const (
+	ScopeRead              Scope = "read"
+	ScopeReadAccounts      Scope = "read:accounts"
+	ScopeReadBlocks        Scope = "read:blocks"
+	ScopeReadBookmarks     Scope = "read:bookmarks"
+	ScopeReadFavourites    Scope = "read:favourites"
+	ScopeReadFilters       Scope = "read:filters"
+	ScopeReadFollows       Scope = "read:follows"
+	ScopeReadLists         Scope = "read:lists"
+	ScopeReadMutes         Scope = "read:mutes"
+	ScopeReadNotifications Scope = "read:notifications"
+	ScopeReadSearch        Scope = "read:search"
+	ScopeReadStatuses      Scope = "read:statuses"
+
+	ScopeWrite              Scope = "write"
+	ScopeWriteAccounts      Scope = "write:accounts"
+	ScopeWriteBlocks        Scope = "write:blocks"
+	ScopeWriteBookmarks     Scope = "write:bookmarks"
+	ScopeWriteConversations Scope = "write:conversations"
+	ScopeWriteFavourites    Scope = "write:favourites"
+	ScopeWriteFilters       Scope = "write:filters"
+	ScopeWriteFollows       Scope = "write:follows"
+	ScopeWriteLists         Scope = "write:lists"
+	ScopeWriteMedia         Scope = "write:media"
+	ScopeWriteMutes         Scope = "write:mutes"
+	ScopeWriteNotifications Scope = "write:notifications"
+	ScopeWriteReports       Scope = "write:reports"
+	ScopeWriteStatuses      Scope = "write:statuses"
+
+	// ScopeFollow is deprecated in favour of
+	// the granular blocks, follows and mutes
+	// scopes, but still granted by many apps.
+	ScopeFollow Scope = "follow"
+	ScopePush   Scope = "push"
+
+	ScopeAdminRead                     Scope = "admin:read"
+	ScopeAdminReadAccounts             Scope = "admin:read:accounts"
+	ScopeAdminReadReports              Scope = "admin:read:reports"
+	ScopeAdminReadDomainAllows         Scope = "admin:read:domain_allows"
+	ScopeAdminReadDomainBlocks         Scope = "admin:read:domain_blocks"
+	ScopeAdminReadIPBlocks             Scope = "admin:read:ip_blocks"
+	ScopeAdminReadEmailDomainBlocks    Scope = "admin:read:email_domain_blocks"
+	ScopeAdminReadCanonicalEmailBlocks Scope = "admin:read:canonical_email_blocks"
+
+	ScopeAdminWrite                     Scope = "admin:write"
+	ScopeAdminWriteAccounts             Scope = "admin:write:accounts"
+	ScopeAdminWriteReports              Scope = "admin:write:reports"
+	ScopeAdminWriteDomainAllows         Scope = "admin:write:domain_allows"
+	ScopeAdminWriteDomainBlocks         Scope = "admin:write:domain_blocks"
+	ScopeAdminWriteIPBlocks             Scope = "admin:write:ip_blocks"
+	ScopeAdminWriteEmailDomainBlocks    Scope = "admin:write:email_domain_blocks"
+	ScopeAdminWriteCanonicalEmailBlocks Scope = "admin:write:canonical_email_blocks"
+
+	// ScopeUser is a legacy GoToSocial scope,
+	// which is treated as read, write, follow
+	// and push together.
+	ScopeUser Scope = "user"
+	// ScopeAdmin is a legacy GoToSocial scope,
+	// which is treated as admin:read and
+	// admin:write together.
+	ScopeAdmin Scope = "admin"
+
+	// ScopeDefault is the scope requested
+	// when none is given by an application.
+	ScopeDefault = ScopeRead
+)
+
+// knownScopes contains all scopes which may be granted.
+var knownScopes = func() map[Scope]struct{} {
+	m := make(map[Scope]struct{})
+	for _, s := range []Scope{
+		ScopeRead, ScopeReadAccounts, ScopeReadBlocks, ScopeReadBookmarks,
+		ScopeReadFavourites, ScopeReadFilters, ScopeReadFollows, ScopeReadLists,
+		ScopeReadMutes, ScopeReadNotifications, ScopeReadSearch, ScopeReadStatuses,
+		ScopeWrite, ScopeWriteAccounts, ScopeWriteBlocks, ScopeWriteBookmarks,
+		ScopeWriteConversations, ScopeWriteFavourites, ScopeWriteFilters,
+		ScopeWriteFollows, ScopeWriteLists, ScopeWriteMedia, ScopeWriteMutes,
+		ScopeWriteNotifications, ScopeWriteReports, ScopeWriteStatuses,
+		ScopeFollow, ScopePush,
+		ScopeAdminRead, ScopeAdminReadAccounts, ScopeAdminReadReports,
+		ScopeAdminReadDomainAllows, ScopeAdminReadDomainBlocks, ScopeAdminReadIPBlocks,
+		ScopeAdminReadEmailDomainBlocks, ScopeAdminReadCanonicalEmailBlocks,
+		ScopeAdminWrite, ScopeAdminWriteAccounts, ScopeAdminWriteReports,
+		ScopeAdminWriteDomainAllows, ScopeAdminWriteDomainBlocks, ScopeAdminWriteIPBlocks,
+		ScopeAdminWriteEmailDomainBlocks, ScopeAdminWriteCanonicalEmailBlocks,
+		ScopeUser, ScopeAdmin,
+	} {
+		m[s] = struct{}{}
+	}
+	return m
+}()
+
+// Permits returns whether a token or application
+// granted scope s may do what requires scope required.
+func (s Scope) Permits(required Scope) bool {
+	switch {
+	case s == required:
+		return true
+
+	// Top-level scopes permit the granular scopes beneath them,
+	// eg., "read" permits "read:statuses", and "admin:read"
+	// permits "admin:read:accounts".
+	case strings.HasPrefix(string(required), string(s)+":"):
+		return true
+
+	case s == ScopeFollow:
+		switch required {
+		case ScopeReadBlocks, ScopeWriteBlocks,
+			ScopeReadFollows, ScopeWriteFollows,
+			ScopeReadMutes, ScopeWriteMutes:
+			return true
+		}
+
+	case s == ScopeUser:
+		return ScopeRead.Permits(required) ||
+			ScopeWrite.Permits(required) ||
+			ScopeFollow.Permits(required) ||
+			ScopePush.Permits(required)
+
+	case s == ScopeAdmin:
+		return ScopeAdminRead.Permits(required) ||
+			ScopeAdminWrite.Permits(required)
+	}
+
+	return false
+}
+
+// ParseScopes parses the given space-separated scopes,
+// returning an error if any of them are not known. The
+// default scope is returned if scopes is empty.
+func ParseScopes(scopes string) ([]Scope, error) {
+	fields := strings.Fields(scopes)
+	if len(fields) == 0 {
+		return []Scope{ScopeDefault}, nil
+	}
+
+	parsed := make([]Scope, 0, len(fields))
+	seen := make(map[Scope]struct{}, len(fields))
+
+	for _, field := range fields {
+		scope := Scope(field)
+		if _, ok := knownScopes[scope]; !ok {
+			return nil, fmt.Errorf("scope %s is not recognized", field)
+		}
+
+		if _, ok := seen[scope]; ok {
+			continue
+		}
+		seen[scope] = struct{}{}
+
+		parsed = append(parsed, scope)
+	}
+
+	return parsed, nil
+}
+
+// ScopesPermit returns whether any of the given space-separated
+// granted scopes permit the required scope. Unknown scopes are
+// ignored. Empty granted scopes are treated as the default scope.
+func ScopesPermit(granted string, required Scope) bool {
+	fields := strings.Fields(granted)
+	if len(fields) == 0 {
+		return ScopeDefault.Permits(required)
+	}
+
+	for _, field := range fields {
+		if Scope(field).Permits(required) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidateScopes checks that each of the space-separated requested
+// scopes is known, and permitted by the space-separated allowed scopes
+// of an application. It returns the requested scopes normalized, ie.,
+// deduplicated and with the default scope if none were requested.
+func ValidateScopes(requested string, allowed string) (string, error) {
+	scopes, err := ParseScopes(requested)
+	if err != nil {
+		return "", err
+	}
+
+	strs := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !scopesPermitAll(allowed, scope) {
+			return "", fmt.Errorf("scope %s is not permitted for this application", scope)
+		}
+		strs = append(strs, string(scope))
+	}
+
+	return strings.Join(strs, " "), nil
+}
+
+// scopesPermitAll is like ScopesPermit, but also
+// checks each scope covered by the legacy user and
+// admin scopes, so that these may be requested by
+// applications granted their modern equivalents.
+func scopesPermitAll(granted string, scope Scope) bool {
+	var required []Scope
+	switch scope {
+	case ScopeUser:
+		required = []Scope{ScopeRead, ScopeWrite, ScopeFollow, ScopePush}
+	case ScopeAdmin:
+		required = []Scope{ScopeAdminRead, ScopeAdminWrite}
+	default:
+		required = []Scope{scope}
+	}
+
+	for _, r := range required {
+		if !ScopesPermit(granted, r) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,102 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oauth_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type ScopeTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ScopeTestSuite) TestScopesPermit() {
+	for _, test := range []struct {
+		granted  string
+		required oauth.Scope
+		permit   bool
+	}{
+		{"read", oauth.ScopeRead, true},
+		{"read", oauth.ScopeReadStatuses, true},
+		{"read", oauth.ScopeWriteStatuses, false},
+		{"read:statuses", oauth.ScopeRead, false},
+		{"read:statuses", oauth.ScopeReadStatuses, true},
+		{"read:statuses", oauth.ScopeReadAccounts, false},
+		{"write:statuses write:media", oauth.ScopeWriteMedia, true},
+		{"follow", oauth.ScopeWriteFollows, true},
+		{"follow", oauth.ScopeReadBlocks, true},
+		{"follow", oauth.ScopeWriteStatuses, false},
+		{"user", oauth.ScopeWriteAccounts, true},
+		{"user", oauth.ScopeAdminRead, false},
+		{"admin", oauth.ScopeAdminWriteIPBlocks, true},
+		{"admin:read", oauth.ScopeAdminReadReports, true},
+		{"admin:read", oauth.ScopeAdminWriteReports, false},
+		{"read write follow push", oauth.ScopeAdminRead, false},
+		{"", oauth.ScopeReadStatuses, true},
+		{"", oauth.ScopeWriteStatuses, false},
+	} {
+		suite.Equal(test.permit, oauth.ScopesPermit(test.granted, test.required), "%q permits %s", test.granted, test.required)
+	}
+}
+
+func (suite *ScopeTestSuite) TestParseScopes() {
+	scopes, err := oauth.ParseScopes("read write:statuses read")
+	suite.NoError(err)
+	suite.Equal([]oauth.Scope{oauth.ScopeRead, oauth.ScopeWriteStatuses}, scopes)
+
+	scopes, err = oauth.ParseScopes("")
+	suite.NoError(err)
+	suite.Equal([]oauth.Scope{oauth.ScopeDefault}, scopes)
+
+	scopes, err = oauth.ParseScopes("read everything")
+	suite.EqualError(err, "scope everything is not recognized")
+	suite.Nil(scopes)
+}
+
+func (suite *ScopeTestSuite) TestValidateScopes() {
+	for _, test := range []struct {
+		requested string
+		allowed   string
+		expected  string
+		err       string
+	}{
+		{"read:statuses write:statuses", "read write", "read:statuses write:statuses", ""},
+		{"", "read write", "read", ""},
+		{"user admin", "user admin", "user admin", ""},
+		{"user", "read write follow push", "user", ""},
+		{"user", "read write", "", "scope user is not permitted for this application"},
+		{"write", "read", "", "scope write is not permitted for this application"},
+		{"admin:read", "read write follow push", "", "scope admin:read is not permitted for this application"},
+		{"read:everything", "read", "", "scope read:everything is not recognized"},
+	} {
+		scope, err := oauth.ValidateScopes(test.requested, test.allowed)
+		if test.err != "" {
+			suite.EqualError(err, test.err)
+		} else {
+			suite.NoError(err)
+		}
+		suite.Equal(test.expected, scope)
+	}
+}
+
+func TestScopeTestSuite(t *testing.T) {
+	suite.Run(t, &ScopeTestSuite{})
+}
@@ -19,13 +19,16 @@ package oauth
 
 import (
 	"context"
+	"crypto/subtle"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/oauth2/v4"
 	oautherr "github.com/superseriousbusiness/oauth2/v4/errors"
@@ -57,7 +60,7 @@ const (
 	// HelpfulAdvice is a handy hint to users;
 	// particularly important during the login flow
 	HelpfulAdvice      = "If you arrived at this error during a login/oauth flow, please try clearing your session cookies and logging in again; if problems persist, make sure you're using the correct credentials"
-	HelpfulAdviceGrant = "If you arrived at this error during a login/oauth flow, your client is trying to use an unsupported OAuth grant type. Supported grant types are: authorization_code, client_credentials; please reach out to developer of your client"
+	HelpfulAdviceGrant = "If you arrived at this error during a login/oauth flow, your client is trying to use an unsupported OAuth grant type. Supported grant types are: authorization_code, client_credentials, refresh_token, " + DeviceCodeGrantType + "; please reach out to developer of your client"
 )
 
 // Server wraps some oauth2 server functions in an interface, exposing only what is needed
@@ -67,24 +70,48 @@ type Server interface {
 	ValidationBearerToken(r *http.Request) (oauth2.TokenInfo, error)
 	GenerateUserAccessToken(ctx context.Context, ti oauth2.TokenInfo, clientSecret string, userID string) (accessToken oauth2.TokenInfo, err error)
 	LoadAccessToken(ctx context.Context, access string) (accessToken oauth2.TokenInfo, err error)
+	RevokeToken(ctx context.Context, clientID string, clientSecret string, token string, tokenTypeHint string) gtserror.WithCode
+	DeviceAuthorize(ctx context.Context, clientID string, clientSecret string, scope string) (map[string]interface{}, gtserror.WithCode)
+	GetDeviceAuthorization(ctx context.Context, userCode string) (*gtsmodel.DeviceAuthorization, gtserror.WithCode)
+	ResolveDeviceAuthorization(ctx context.Context, userCode string, userID string, approve bool) gtserror.WithCode
+	HandleDeviceTokenRequest(ctx context.Context, clientID string, clientSecret string, deviceCode string) (map[string]interface{}, gtserror.WithCode)
+	CreatePersonalToken(ctx context.Context, token *gtsmodel.Token) (string, error)
 }
 
 // s fulfils the Server interface using the underlying oauth2 server
 type s struct {
 	server *server.Server
+	store  *tokenStore
+	db     db.DB
 }
 
 // New returns a new oauth server that implements the Server interface
-func New(ctx context.Context, database db.Basic) Server {
+func New(ctx context.Context, database db.DB) Server {
 	ts := newTokenStore(ctx, database)
 	cs := NewClientStore(database)
 
 	manager := manage.NewDefaultManager()
 	manager.MapTokenStorage(ts)
 	manager.MapClientStorage(cs)
+
+	// Access tokens only expire if configured to do so,
+	// otherwise they must be revoked. When they do expire,
+	// they're issued along with a refresh token which can
+	// be exchanged for a new access + refresh token pair.
+	accessExp := config.GetOAuthAccessTokenExpiry()
+	refreshExp := config.GetOAuthRefreshTokenExpiry()
 	manager.SetAuthorizeCodeTokenCfg(&manage.Config{
-		AccessTokenExp:    0,     // access tokens don't expire -- they must be revoked
-		IsGenerateRefresh: false, // don't use refresh tokens
+		AccessTokenExp:    accessExp,
+		RefreshTokenExp:   refreshExp,
+		IsGenerateRefresh: accessExp != 0,
+	})
+	manager.SetRefreshTokenCfg(&manage.RefreshingConfig{
+		AccessTokenExp:     accessExp,
+		RefreshTokenExp:    refreshExp,
+		IsGenerateRefresh:  true,
+		IsResetRefreshTime: true,
+		IsRemoveAccess:     true,
+		IsRemoveRefreshing: true,
 	})
 	sc := &server.Config{
 		TokenType: "Bearer",
@@ -95,9 +122,11 @@ func New(ctx context.Context, database db.Basic) Server {
 		// Allow:
 		// - Authorization Code (for first & third parties)
 		// - Client Credentials (for applications)
+		// - Refreshing (for expired access tokens)
 		AllowedGrantTypes: []oauth2.GrantType{
 			oauth2.AuthorizationCode,
 			oauth2.ClientCredentials,
+			oauth2.Refreshing,
 		},
 		AllowedCodeChallengeMethods: []oauth2.CodeChallengeMethod{oauth2.CodeChallengePlain},
 	}
@@ -120,8 +149,46 @@ func New(ctx context.Context, database db.Basic) Server {
 		return userID, nil
 	})
 	srv.SetClientInfoHandler(server.ClientFormHandler)
+
+	// Only allow scopes which the application was registered
+	// with, and store them normalized on the token.
+	srv.SetClientScopeHandler(func(tgr *oauth2.TokenGenerateRequest) (bool, error) {
+		reqCtx := ctx
+		if tgr.Request != nil {
+			reqCtx = tgr.Request.Context()
+		}
+
+		app := &gtsmodel.Application{}
+		if err := database.GetWhere(reqCtx, []db.Where{{Key: "client_id", Value: tgr.ClientID}}, app); err != nil {
+			return false, fmt.Errorf("error getting application for client %s: %w", tgr.ClientID, err)
+		}
+
+		scope, err := ValidateScopes(tgr.Scope, app.Scopes)
+		if err != nil {
+			log.Debugf(reqCtx, "invalid scope requested for client %s: %v", tgr.ClientID, err)
+			return false, nil
+		}
+
+		tgr.Scope = scope
+		return true, nil
+	})
+
+	// Refreshed tokens may narrow their scope, but not widen it.
+	srv.SetRefreshingScopeHandler(func(tgr *oauth2.TokenGenerateRequest, oldScope string) (bool, error) {
+		scope, err := ValidateScopes(tgr.Scope, oldScope)
+		if err != nil {
+			log.Debugf(nil, "invalid scope requested when refreshing token for client %s: %v", tgr.ClientID, err)
+			return false, nil
+		}
+
+		tgr.Scope = scope
+		return true, nil
+	})
+
 	return &s{
 		server: srv,
+		store:  ts,
+		db:     database,
 	}
 }
 
@@ -145,6 +212,11 @@ func (s *s) HandleTokenRequest(r *http.Request) (map[string]interface{}, gtserro
 		return nil, gtserror.NewErrorBadRequest(err, help, HelpfulAdvice)
 	}
 
+	return s.tokenData(ti)
+}
+
+// tokenData returns the token endpoint response for the given token.
+func (s *s) tokenData(ti oauth2.TokenInfo) (map[string]interface{}, gtserror.WithCode) {
 	data := s.server.GetTokenData(ti)
 
 	if expiresInI, ok := data["expires_in"]; ok {
@@ -264,13 +336,20 @@ func (s *s) ValidationBearerToken(r *http.Request) (oauth2.TokenInfo, error) {
 // The ti parameter refers to an existing Application token that was used to make the upstream
 // request. This token needs to be validated and exist in database in order to create a new token.
 func (s *s) GenerateUserAccessToken(ctx context.Context, ti oauth2.TokenInfo, clientSecret string, userID string) (oauth2.TokenInfo, error) {
-	authToken, err := s.server.Manager.GenerateAuthToken(ctx, oauth2.Code, &oauth2.TokenGenerateRequest{
+	return s.generateUserAccessToken(ctx, &oauth2.TokenGenerateRequest{
 		ClientID:     ti.GetClientID(),
 		ClientSecret: clientSecret,
 		UserID:       userID,
 		RedirectURI:  ti.GetRedirectURI(),
 		Scope:        ti.GetScope(),
 	})
+}
+
+// generateUserAccessToken generates a user-level access token for the
+// client, user, redirect URI and scope of the given request, by generating
+// an auth code and immediately exchanging it for an access token.
+func (s *s) generateUserAccessToken(ctx context.Context, tgr *oauth2.TokenGenerateRequest) (oauth2.TokenInfo, error) {
+	authToken, err := s.server.Manager.GenerateAuthToken(ctx, oauth2.Code, tgr)
 	if err != nil {
 		return nil, fmt.Errorf("error generating auth token: %s", err)
 	}
@@ -281,7 +360,7 @@ func (s *s) GenerateUserAccessToken(ctx context.Context, ti oauth2.TokenInfo, cl
 
 	accessToken, err := s.server.Manager.GenerateAccessToken(ctx, oauth2.AuthorizationCode, &oauth2.TokenGenerateRequest{
 		ClientID:     authToken.GetClientID(),
-		ClientSecret: clientSecret,
+		ClientSecret: tgr.ClientSecret,
 		RedirectURI:  authToken.GetRedirectURI(),
 		Scope:        authToken.GetScope(),
 		Code:         authToken.GetCode(),
@@ -299,3 +378,87 @@ func (s *s) GenerateUserAccessToken(ctx context.Context, ti oauth2.TokenInfo, cl
 func (s *s) LoadAccessToken(ctx context.Context, access string) (accessToken oauth2.TokenInfo, err error) {
 	return s.server.Manager.LoadAccessToken(ctx, access)
 }
+
+// RevokeToken revokes the given access or refresh token on behalf
+// of the client it was issued to, following RFC 7009. Revoking
+// either token of an access + refresh pair revokes both.
+//
+// As per the RFC, no error is returned if the token does not exist,
+// or has already been revoked or expired.
+func (s *s) RevokeToken(ctx context.Context, clientID string, clientSecret string, token string, tokenTypeHint string) gtserror.WithCode {
+	client, err := s.server.Manager.GetClient(ctx, clientID)
+	if err != nil || clientSecret == "" || subtle.ConstantTimeCompare([]byte(client.GetSecret()), []byte(clientSecret)) != 1 {
+		if err == nil {
+			err = errors.New("client secret did not match")
+		}
+		return gtserror.NewErrorUnauthorized(InvalidClient(), fmt.Sprintf("could not authenticate client %s: %s", clientID, err))
+	}
+
+	if token == "" {
+		return gtserror.NewErrorBadRequest(InvalidRequest(), "token was not set in the revocation request form")
+	}
+
+	// Check the type of token we were
+	// given that the client thinks we
+	// were given first, then the other.
+	var (
+		getByAccess  = s.store.GetByAccess
+		getByRefresh = s.store.GetByRefresh
+		getters      []func(context.Context, string) (oauth2.TokenInfo, error)
+	)
+
+	switch tokenTypeHint {
+	case "", "access_token":
+		getters = append(getters, getByAccess, getByRefresh)
+	case "refresh_token":
+		getters = append(getters, getByRefresh, getByAccess)
+	default:
+		return gtserror.NewErrorBadRequest(UnsupportedTokenType(), fmt.Sprintf("token_type_hint %s is not supported", tokenTypeHint))
+	}
+
+	var ti oauth2.TokenInfo
+	for _, get := range getters {
+		ti, err = get(ctx, token)
+		if err == nil {
+			break
+		}
+
+		if !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	if ti == nil {
+		// Nothing to revoke.
+		return nil
+	}
+
+	if ti.GetClientID() != clientID {
+		err := fmt.Errorf("token was not issued to client %s", clientID)
+		return gtserror.NewErrorForbidden(UnauthorizedClient(), err.Error())
+	}
+
+	if access := ti.GetAccess(); access != "" {
+		if err := s.store.RemoveByAccess(ctx, access); err != nil {
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	if refresh := ti.GetRefresh(); refresh != "" {
+		if err := s.store.RemoveByRefresh(ctx, refresh); err != nil {
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return nil
+}
+
+// CreatePersonalToken generates a new personal access token
+// and stores it, hashed, using the given token model, which
+// should already have all other fields set as appropriate.
+//
+// The personal access token is returned; since only its hash
+// is stored, it can't be retrieved again later.
+func (s *s) CreatePersonalToken(ctx context.Context, token *gtsmodel.Token) (string, error) {
+	return s.store.createPersonal(ctx, token)
+}
@@ -19,10 +19,16 @@ package oauth
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
@@ -31,6 +37,17 @@ import (
 	"github.com/superseriousbusiness/oauth2/v4/models"
 )
 
+// lastUsedInterval is how often the last used
+// time of a token is updated when it's used. It
+// is approximate, to avoid a db write per request.
+const lastUsedInterval = 5 * time.Minute
+
+// PersonalTokenPrefix is the prefix of all personal access
+// tokens. Unlike tokens issued through the oauth flow, these
+// are stored hashed, so the prefix tells us to hash a token
+// before looking it up.
+const PersonalTokenPrefix = "gtspat_" // #nosec G101 else we get a hardcoded credentials warning
+
 // tokenStore is an implementation of oauth2.TokenStore, which uses our db interface as a storage backend.
 type tokenStore struct {
 	oauth2.TokenStore
@@ -41,7 +58,7 @@ type tokenStore struct {
 //
 // In order to allow tokens to 'expire', it will also set off a goroutine that iterates through
 // the tokens in the DB once per minute and deletes any that have expired.
-func newTokenStore(ctx context.Context, db db.Basic) oauth2.TokenStore {
+func newTokenStore(ctx context.Context, db db.Basic) *tokenStore {
 	ts := &tokenStore{
 		db: db,
 	}
@@ -65,7 +82,7 @@ func newTokenStore(ctx context.Context, db db.Basic) oauth2.TokenStore {
 	return ts
 }
 
-// sweep clears out old tokens that have expired; it should be run on a loop about once per minute or so.
+// sweep clears out old tokens and device authorizations that have expired; it should be run on a loop about once per minute or so.
 func (ts *tokenStore) sweep(ctx context.Context) error {
 	// select *all* tokens from the db
 	// todo: if this becomes expensive (ie., there are fucking LOADS of tokens) then figure out a better way.
@@ -76,17 +93,39 @@ func (ts *tokenStore) sweep(ctx context.Context) error {
 
 	// iterate through and remove expired tokens
 	now := time.Now()
-	for _, dbt := range *tokens {
+	expired := func(expiresAt time.Time) bool {
 		// The zero value of a time.Time is 00:00 january 1 1970, which will always be before now. So:
 		// we only want to check if a token expired before now if the expiry time is *not zero*;
 		// ie., if it's been explicity set.
-		if !dbt.CodeExpiresAt.IsZero() && dbt.CodeExpiresAt.Before(now) || !dbt.RefreshExpiresAt.IsZero() && dbt.RefreshExpiresAt.Before(now) || !dbt.AccessExpiresAt.IsZero() && dbt.AccessExpiresAt.Before(now) {
+		return !expiresAt.IsZero() && expiresAt.Before(now)
+	}
+
+	for _, dbt := range *tokens {
+		// Tokens with an expired access token are kept
+		// around if they have a refresh token, since the
+		// refresh token can still be used to get a new one.
+		if expired(dbt.CodeExpiresAt) || expired(dbt.RefreshExpiresAt) || (expired(dbt.AccessExpiresAt) && dbt.Refresh == "") {
 			if err := ts.db.DeleteByID(ctx, dbt.ID, dbt); err != nil {
 				return err
 			}
 		}
 	}
 
+	// also remove device authorizations which
+	// were never exchanged for a token in time
+	das := new([]*gtsmodel.DeviceAuthorization)
+	if err := ts.db.GetAll(ctx, das); err != nil {
+		return err
+	}
+
+	for _, da := range *das {
+		if da.Expired(now) {
+			if err := ts.db.DeleteByID(ctx, da.ID, da); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -120,6 +159,9 @@ func (ts *tokenStore) RemoveByCode(ctx context.Context, code string) error {
 
 // RemoveByAccess deletes a token from the DB based on the Access field
 func (ts *tokenStore) RemoveByAccess(ctx context.Context, access string) error {
+	if strings.HasPrefix(access, PersonalTokenPrefix) {
+		access = hashPersonalToken(access)
+	}
 	return ts.db.DeleteWhere(ctx, []db.Where{{Key: "access", Value: access}}, &gtsmodel.Token{})
 }
 
@@ -142,18 +184,54 @@ func (ts *tokenStore) GetByCode(ctx context.Context, code string) (oauth2.TokenI
 	return DBTokenToToken(dbt), nil
 }
 
-// GetByAccess selects a token from the DB based on the Access field
+// GetByAccess selects a token from the DB based on the Access field.
+//
+// Since this is called whenever a token is used to access the API,
+// it also updates the token's last used time if it's out of date.
 func (ts *tokenStore) GetByAccess(ctx context.Context, access string) (oauth2.TokenInfo, error) {
 	if access == "" {
 		return nil, nil
 	}
+
+	key := access
+	personal := strings.HasPrefix(access, PersonalTokenPrefix)
+	if personal {
+		if !config.GetOAuthPersonalTokensEnabled() {
+			// Treat personal access tokens as
+			// not existing if they're disabled.
+			return nil, db.ErrNoEntries
+		}
+		key = hashPersonalToken(access)
+	}
+
 	dbt := &gtsmodel.Token{
-		Access: access,
+		Access: key,
 	}
-	if err := ts.db.GetWhere(ctx, []db.Where{{Key: "access", Value: access}}, dbt); err != nil {
+	if err := ts.db.GetWhere(ctx, []db.Where{{Key: "access", Value: key}}, dbt); err != nil {
 		return nil, err
 	}
-	return DBTokenToToken(dbt), nil
+
+	if !personal && dbt.Name != "" {
+		// Stored hash of a personal access
+		// token presented as a token itself.
+		return nil, db.ErrNoEntries
+	}
+
+	if now := time.Now(); now.Sub(dbt.LastUsed) > lastUsedInterval {
+		dbt.LastUsed = now
+		if err := ts.db.UpdateByID(ctx, dbt, dbt.ID, "last_used"); err != nil {
+			log.Errorf(ctx, "error updating last used time of token %s: %v", dbt.ID, err)
+		}
+	}
+
+	ti := DBTokenToToken(dbt)
+	if personal {
+		// Give back the token as presented rather than
+		// its hash, as the oauth2 library compares them.
+		ti.Access = access
+	}
+
+	return ti, nil
 }
 
 // GetByRefresh selects a token from the DB based on the Refresh field
@@ -170,31 +248,64 @@ func (ts *tokenStore) GetByRefresh(ctx context.Context, refresh string) (oauth2.
 	return DBTokenToToken(dbt), nil
 }
 
+// createPersonal generates a new personal access token, and stores it
+// hashed on the given token model in the DB, returning the token itself.
+func (ts *tokenStore) createPersonal(ctx context.Context, dbt *gtsmodel.Token) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating personal access token: %w", err)
+	}
+
+	access := PersonalTokenPrefix + base64.RawURLEncoding.EncodeToString(b)
+	dbt.Access = hashPersonalToken(access)
+	if dbt.AccessCreateAt.IsZero() {
+		dbt.AccessCreateAt = time.Now()
+	}
+
+	if dbt.ID == "" {
+		dbtID, err := id.NewRandomULID()
+		if err != nil {
+			return "", err
+		}
+		dbt.ID = dbtID
+	}
+
+	if err := ts.db.Put(ctx, dbt); err != nil {
+		return "", fmt.Errorf("error in tokenstore createPersonal: %s", err)
+	}
+	return access, nil
+}
+
+// hashPersonalToken returns the hash of a personal
+// access token, which is what's stored in the DB.
+func hashPersonalToken(access string) string {
+	sum := sha256.Sum256([]byte(access))
+	return hex.EncodeToString(sum[:])
+}
+
 /*
 	The following models are basically helpers for the token store implementation, they should only be used internally.
 */
 
 // TokenToDBToken is a lil util function that takes a gotosocial token and gives back a token for inserting into a database.
 func TokenToDBToken(tkn *models.Token) *gtsmodel.Token {
-	now := time.Now()
-
-	// For the following, we want to make sure we're not adding a time.Now() to an *empty* ExpiresIn, otherwise that's
+	// For the following, we want to make sure we're not adding a creation time to an *empty* ExpiresIn, otherwise that's
 	// going to cause all sorts of interesting problems. So check first to make sure that the ExpiresIn is not equal
 	// to the zero value of a time.Duration, which is 0s. If it *is* empty/nil, just leave the ExpiresAt at nil as well.
 
 	cea := time.Time{}
 	if tkn.CodeExpiresIn != 0*time.Second {
-		cea = now.Add(tkn.CodeExpiresIn)
+		cea = expiresAt(tkn.CodeCreateAt, tkn.CodeExpiresIn)
 	}
 
 	aea := time.Time{}
 	if tkn.AccessExpiresIn != 0*time.Second {
-		aea = now.Add(tkn.AccessExpiresIn)
+		aea = expiresAt(tkn.AccessCreateAt, tkn.AccessExpiresIn)
 	}
 
 	rea := time.Time{}
 	if tkn.RefreshExpiresIn != 0*time.Second {
-		rea = now.Add(tkn.RefreshExpiresIn)
+		rea = expiresAt(tkn.RefreshCreateAt, tkn.RefreshExpiresIn)
 	}
 
 	return &gtsmodel.Token{
@@ -218,21 +329,23 @@ func TokenToDBToken(tkn *models.Token) *gtsmodel.Token {
 
 // DBTokenToToken is a lil util function that takes a database token and gives back a gotosocial token
 func DBTokenToToken(dbt *gtsmodel.Token) *models.Token {
-	now := time.Now()
+	// ExpiresIn durations are relative to the
+	// matching CreateAt time, which is how the
+	// oauth2 library checks token expiry.
 
 	var codeExpiresIn time.Duration
 	if !dbt.CodeExpiresAt.IsZero() {
-		codeExpiresIn = dbt.CodeExpiresAt.Sub(now)
+		codeExpiresIn = expiresIn(dbt.CodeCreateAt, dbt.CodeExpiresAt)
 	}
 
 	var accessExpiresIn time.Duration
 	if !dbt.AccessExpiresAt.IsZero() {
-		accessExpiresIn = dbt.AccessExpiresAt.Sub(now)
+		accessExpiresIn = expiresIn(dbt.AccessCreateAt, dbt.AccessExpiresAt)
 	}
 
 	var refreshExpiresIn time.Duration
 	if !dbt.RefreshExpiresAt.IsZero() {
-		refreshExpiresIn = dbt.RefreshExpiresAt.Sub(now)
+		refreshExpiresIn = expiresIn(dbt.RefreshCreateAt, dbt.RefreshExpiresAt)
 	}
 
 	return &models.Token{
@@ -253,3 +366,22 @@ func DBTokenToToken(dbt *gtsmodel.Token) *models.Token {
 		RefreshExpiresIn:    refreshExpiresIn,
 	}
 }
+
+// expiresAt returns the time at which something created
+// at createAt expires, given that it expires after the
+// given duration. If createAt isn't set, now is used.
+func expiresAt(createAt time.Time, expiresIn time.Duration) time.Time {
+	if createAt.IsZero() {
+		createAt = time.Now()
+	}
+	return createAt.Add(expiresIn)
+}
+
+// expiresIn is the inverse of expiresAt, returning the
+// duration after createAt at which something expires.
+func expiresIn(createAt time.Time, expiresAt time.Time) time.Duration {
+	if createAt.IsZero() {
+		createAt = time.Now()
+	}
+	return expiresAt.Sub(createAt)
+}
@@ -18,7 +18,11 @@
 package account
 
 import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
@@ -40,6 +44,8 @@ type Processor struct {
 	formatter    text.Formatter
 	federator    federation.Federator
 	parseMention gtsmodel.ParseMentionFunc
+	emailAllowed func(ctx context.Context, address string) gtserror.WithCode
+	emailSender  email.Sender
 }
 
 // New returns a new account processor.
@@ -51,8 +57,10 @@ func New(
 	federator federation.Federator,
 	filter *visibility.Filter,
 	parseMention gtsmodel.ParseMentionFunc,
+	emailAllowed func(ctx context.Context, address string) gtserror.WithCode,
+	emailSender email.Sender,
 ) Processor {
-	return Processor{
+	p := Processor{
 		state:        state,
 		tc:           tc,
 		mediaManager: mediaManager,
@@ -61,5 +69,9 @@ func New(
 		formatter:    text.NewFormatter(state.DB),
 		federator:    federator,
 		parseMention: parseMention,
+		emailAllowed: emailAllowed,
+		emailSender:  emailSender,
 	}
+	p.scheduleExportCleanup()
+	return p
 }
@@ -19,7 +19,9 @@ package account
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/netip"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
@@ -42,6 +44,10 @@ func (p *Processor) Create(ctx context.Context, applicationToken oauth2.TokenInf
 		return nil, gtserror.NewErrorConflict(fmt.Errorf("email address %s is not available", form.Email))
 	}
 
+	if errWithCode := p.emailAllowed(ctx, form.Email); errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	usernameAvailable, err := p.state.DB.IsUsernameAvailable(ctx, form.Username)
 	if err != nil {
 		return nil, gtserror.NewErrorBadRequest(err)
@@ -53,6 +59,23 @@ func (p *Processor) Create(ctx context.Context, applicationToken oauth2.TokenInf
 	reasonRequired := config.GetAccountsReasonRequired()
 	approvalRequired := config.GetAccountsApprovalRequired()
 
+	// Sign-ups from blocked IP ranges are either
+	// refused outright, or always need approval.
+	if addr, ok := netip.AddrFromSlice(form.IP); ok {
+		severity, err := p.state.DB.GetIPBlockSeverity(ctx, addr.Unmap())
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error checking ip block: %w", err))
+		}
+
+		switch severity {
+		case gtsmodel.IPBlockSignUpBlock, gtsmodel.IPBlockNoAccess:
+			err := errors.New("sign-ups from your ip address are not allowed")
+			return nil, gtserror.NewErrorForbidden(err, err.Error())
+		case gtsmodel.IPBlockSignUpRequiresApproval:
+			approvalRequired = true
+		}
+	}
+
 	// don't store a reason if we don't require one
 	reason := form.Reason
 	if !reasonRequired {
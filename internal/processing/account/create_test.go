@@ -0,0 +1,114 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type CreateTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *CreateTestSuite) create(ip string) gtserror.WithCode {
+	form := &apimodel.AccountCreateRequest{
+		Username:  "new_user",
+		Email:     "new_user@example.org",
+		Password:  "a very long and very secure password",
+		Agreement: true,
+		Locale:    "en",
+		IP:        net.ParseIP(ip),
+	}
+
+	_, errWithCode := suite.accountProcessor.Create(
+		context.Background(),
+		oauth.DBTokenToToken(suite.testTokens["local_account_1"]),
+		suite.testApplications["application_1"],
+		form,
+	)
+	return errWithCode
+}
+
+func (suite *CreateTestSuite) getUser() *gtsmodel.User {
+	account, err := suite.db.GetAccountByUsernameDomain(context.Background(), "new_user", "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	user, err := suite.db.GetUserByAccountID(context.Background(), account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return user
+}
+
+func (suite *CreateTestSuite) TestCreate() {
+	config.SetAccountsApprovalRequired(false)
+
+	if err := suite.create("192.0.2.1"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	user := suite.getUser()
+	suite.True(*user.Approved)
+}
+
+func (suite *CreateTestSuite) TestCreateIPRequiresApproval() {
+	config.SetAccountsApprovalRequired(false)
+
+	// In the sign_up_requires_approval range,
+	// but not the narrower sign_up_block range.
+	if err := suite.create("198.51.100.5"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	user := suite.getUser()
+	suite.False(*user.Approved)
+}
+
+func (suite *CreateTestSuite) TestCreateIPBlocked() {
+	err := suite.create("198.51.100.70")
+	suite.EqualError(err, "sign-ups from your ip address are not allowed")
+	suite.Equal(http.StatusForbidden, err.Code())
+}
+
+func (suite *CreateTestSuite) TestCreateIPNoAccess() {
+	err := suite.create("2001:db8::1")
+	suite.EqualError(err, "sign-ups from your ip address are not allowed")
+}
+
+func (suite *CreateTestSuite) TestCreateIPBlockExpired() {
+	if err := suite.create("203.0.113.5"); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func TestCreateTestSuite(t *testing.T) {
+	suite.Run(t, new(CreateTestSuite))
+}
@@ -50,6 +50,14 @@ func (p *Processor) Delete(ctx context.Context, account *gtsmodel.Account, origi
 		if err := p.deleteUserAndTokensForAccount(ctx, account); err != nil {
 			return gtserror.NewErrorInternalError(err)
 		}
+
+		if err := p.deleteAccountExports(ctx, account); err != nil {
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		if err := p.state.DB.DeleteAccountImports(ctx, account.ID); err != nil {
+			return gtserror.NewErrorInternalError(err)
+		}
 	}
 
 	if err := p.deleteAccountFollows(ctx, account); err != nil {
@@ -60,6 +68,10 @@ func (p *Processor) Delete(ctx context.Context, account *gtsmodel.Account, origi
 		return gtserror.NewErrorInternalError(err)
 	}
 
+	if err := p.deleteAccountMutes(ctx, account); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
 	if err := p.deleteAccountStatuses(ctx, account); err != nil {
 		return gtserror.NewErrorInternalError(err)
 	}
@@ -151,6 +163,23 @@ func (p *Processor) deleteUserAndTokensForAccount(ctx context.Context, account *
 	return nil
 }
 
+// deleteAccountExports deletes any exports
+// of the account's data, and their archives.
+func (p *Processor) deleteAccountExports(ctx context.Context, account *gtsmodel.Account) error {
+	exports, err := p.state.DB.GetAccountExports(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return fmt.Errorf("deleteAccountExports: db error getting exports: %w", err)
+	}
+
+	for _, export := range exports {
+		if err := p.deleteExport(ctx, export); err != nil {
+			return fmt.Errorf("deleteAccountExports: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // deleteAccountFollows deletes:
 //   - Follows targeting account.
 //   - Follow requests targeting account.
@@ -284,6 +313,13 @@ func (p *Processor) deleteAccountBlocks(ctx context.Context, account *gtsmodel.A
 	return nil
 }
 
+func (p *Processor) deleteAccountMutes(ctx context.Context, account *gtsmodel.Account) error {
+	if err := p.state.DB.DeleteAccountMutes(ctx, account.ID); err != nil {
+		return fmt.Errorf("deleteAccountMutes: db error deleting account mutes for %s: %w", account.ID, err)
+	}
+	return nil
+}
+
 // deleteAccountStatuses iterates through all statuses owned by
 // the given account, passing each discovered status (and boosts
 // thereof) to the processor workers for further async processing.
@@ -517,6 +553,7 @@ func stubbifyUser(user *gtsmodel.User) ([]string, error) {
 	user.Locale = ""
 	user.CreatedByApplicationID = ""
 	user.LastEmailedAt = never
+	user.LastDigestAt = never
 	user.ConfirmationToken = ""
 	user.ConfirmationSentAt = never
 	user.ResetPasswordToken = ""
@@ -533,6 +570,7 @@ func stubbifyUser(user *gtsmodel.User) ([]string, error) {
 		"locale",
 		"created_by_application_id",
 		"last_emailed_at",
+		"last_digest_at",
 		"confirmation_token",
 		"confirmation_sent_at",
 		"reset_password_token",
@@ -91,6 +91,7 @@ func (suite *AccountDeleteTestSuite) TestAccountDeleteLocal() {
 	suite.Zero(updatedUser.Locale)
 	suite.Zero(updatedUser.CreatedByApplicationID)
 	suite.Zero(updatedUser.LastEmailedAt)
+	suite.Zero(updatedUser.LastDigestAt)
 	suite.Zero(updatedUser.ConfirmationToken)
 	suite.Zero(updatedUser.ConfirmationSentAt)
 	suite.Zero(updatedUser.ResetPasswordToken)
@@ -0,0 +1,777 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"time"
+
+	"codeberg.org/gruf/go-runners"
+	"codeberg.org/gruf/go-sched"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+)
+
+const (
+	// exportInterval is the minimum time
+	// between requesting two exports.
+	exportInterval = 24 * time.Hour
+
+	// exportPageSize is the number of statuses,
+	// blocks etc to fetch from the db at a time
+	// when writing an export.
+	exportPageSize = 100
+
+	// exportContentType is the content
+	// type of finished export archives.
+	exportContentType = "application/zip"
+
+	// exportMediaDir is the directory in export archives
+	// containing the media attachments of exported statuses,
+	// as in Mastodon exports.
+	exportMediaDir = "media_attachments/files/"
+)
+
+// ExportCreate starts building an archive of the given
+// account's data in the background, returning the new export.
+// The user is sent an email once the archive is ready.
+func (p *Processor) ExportCreate(ctx context.Context, account *gtsmodel.Account) (*apimodel.Export, gtserror.WithCode) {
+	exports, err := p.state.DB.GetAccountExports(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting exports: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if len(exports) != 0 && time.Since(exports[0].CreatedAt) < exportInterval {
+		const text = "you can only request one export per day"
+		return nil, gtserror.NewErrorConflict(errors.New(text), text)
+	}
+
+	now := time.Now()
+	export := &gtsmodel.Export{
+		ID:        id.NewULID(),
+		CreatedAt: now,
+		UpdatedAt: now,
+		AccountID: account.ID,
+		State:     gtsmodel.ExportProcessing,
+		// Clean up after the usual expiry even
+		// if building the export is interrupted.
+		ExpiresAt: now.Add(config.GetAccountsExportExpiry()),
+	}
+
+	if err := p.state.DB.PutExport(ctx, export); err != nil {
+		err = gtserror.Newf("db error putting export: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.state.Workers.Jobs.Enqueue(func(ctx context.Context) {
+		p.export(ctx, export)
+	})
+
+	return p.tc.ExportToAPIExport(export), nil
+}
+
+// ExportsGet returns all exports of the given account, newest first.
+func (p *Processor) ExportsGet(ctx context.Context, account *gtsmodel.Account) ([]*apimodel.Export, gtserror.WithCode) {
+	exports, err := p.state.DB.GetAccountExports(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting exports: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiExports := make([]*apimodel.Export, 0, len(exports))
+	for _, export := range exports {
+		apiExports = append(apiExports, p.tc.ExportToAPIExport(export))
+	}
+
+	return apiExports, nil
+}
+
+// ExportGet returns one export of the given account.
+func (p *Processor) ExportGet(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.Export, gtserror.WithCode) {
+	export, errWithCode := p.getExport(ctx, account, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.tc.ExportToAPIExport(export), nil
+}
+
+// ExportFileGet returns the archive of one
+// completed export of the given account.
+func (p *Processor) ExportFileGet(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.Content, gtserror.WithCode) {
+	export, errWithCode := p.getExport(ctx, account, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if export.State != gtsmodel.ExportComplete {
+		err := gtserror.Newf("export %s is %s", id, export.State)
+		return nil, gtserror.NewErrorNotFound(err, "export not ready for download")
+	}
+
+	content := &apimodel.Content{
+		ContentType:    exportContentType,
+		ContentLength:  export.Size,
+		ContentUpdated: export.CompletedAt,
+	}
+
+	rc, err := p.state.Storage.GetStream(ctx, export.Path)
+	if err != nil {
+		err = gtserror.Newf("error opening export %s: %w", export.Path, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	content.Content = rc
+
+	return content, nil
+}
+
+// getExport gets the given export, or a 404 if
+// it doesn't exist or doesn't belong to account.
+func (p *Processor) getExport(ctx context.Context, account *gtsmodel.Account, id string) (*gtsmodel.Export, gtserror.WithCode) {
+	export, err := p.state.DB.GetExportByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting export %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if export == nil || export.AccountID != account.ID {
+		err := gtserror.Newf("export %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return export, nil
+}
+
+// export builds the archive for the given export,
+// updating it once done and letting the user know.
+func (p *Processor) export(ctx context.Context, export *gtsmodel.Export) {
+	l := log.WithContext(ctx).WithField("export", export.ID)
+
+	if err := p.writeExport(ctx, export); err != nil {
+		if ctx.Err() != nil {
+			// Shutting down; the export is left
+			// processing and cleaned up on expiry.
+			l.Warnf("export interrupted: %v", err)
+			return
+		}
+
+		l.Errorf("error writing export: %v", err)
+		export.State = gtsmodel.ExportFailed
+		export.Error = err.Error()
+	} else {
+		export.State = gtsmodel.ExportComplete
+	}
+
+	export.CompletedAt = time.Now()
+	export.ExpiresAt = export.CompletedAt.Add(config.GetAccountsExportExpiry())
+
+	if err := p.state.DB.UpdateExport(ctx, export,
+		"state",
+		"path",
+		"size",
+		"error",
+		"completed_at",
+		"expires_at",
+	); err != nil {
+		l.Errorf("db error updating export: %v", err)
+		return
+	}
+
+	if export.State != gtsmodel.ExportComplete {
+		return
+	}
+
+	if err := p.emailExport(ctx, export); err != nil {
+		l.Errorf("error emailing user: %v", err)
+	}
+}
+
+// emailExport lets the owner of the given
+// export know that it's ready to download.
+func (p *Processor) emailExport(ctx context.Context, export *gtsmodel.Export) error {
+	user, err := p.state.DB.GetUserByAccountID(ctx, export.AccountID)
+	if err != nil {
+		return gtserror.Newf("db error getting user: %w", err)
+	}
+
+	if !user.CanBeEmailed() {
+		return nil
+	}
+
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return gtserror.Newf("db error getting instance: %w", err)
+	}
+
+	exportData := email.ExportData{
+		Username:     user.Account.Username,
+		InstanceURL:  instance.URI,
+		InstanceName: instance.Title,
+		ExportURL:    instance.URI + "/settings/user/export",
+		ExpiresAt:    export.ExpiresAt.UTC().Format(time.RFC1123),
+	}
+
+	return p.emailSender.SendExportEmail(user.Email, exportData)
+}
+
+// writeExport writes the archive of the given export to a temporary
+// file, and then moves it to storage, setting the path and size.
+func (p *Processor) writeExport(ctx context.Context, export *gtsmodel.Export) error {
+	account, err := p.state.DB.GetAccountByID(ctx, export.AccountID)
+	if err != nil {
+		return gtserror.Newf("db error getting account: %w", err)
+	}
+
+	tmp, err := os.CreateTemp("", "gotosocial-export-*.zip")
+	if err != nil {
+		return gtserror.Newf("error creating temporary file: %w", err)
+	}
+	defer func() {
+		tmp.Close()
+		os.Remove(tmp.Name())
+	}()
+
+	w := &exportWriter{
+		Processor: p,
+		account:   account,
+		zip:       zip.NewWriter(tmp),
+	}
+
+	for _, write := range []func(context.Context) error{
+		w.writeActor,
+		w.writeOutbox,
+		w.writeMedia,
+		w.writeFollowing,
+		w.writeFollowers,
+		w.writeBlocks,
+		w.writeMutes,
+		w.writeLists,
+		w.writeBookmarks,
+	} {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := write(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := w.zip.Close(); err != nil {
+		return gtserror.Newf("error finishing archive: %w", err)
+	}
+
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return gtserror.Newf("error rewinding archive: %w", err)
+	}
+
+	key := exportPath(export)
+	size, err := p.state.Storage.PutStream(ctx, key, tmp)
+	if err != nil {
+		return gtserror.Newf("error storing archive: %w", err)
+	}
+
+	export.Path = key
+	export.Size = size
+	return nil
+}
+
+// exportPath returns the storage key for the archive of
+// the given export. It deliberately doesn't fit the form
+// of media paths, so orphaned media pruning skips it.
+func exportPath(export *gtsmodel.Export) string {
+	return "exports/" + export.AccountID + "/" + export.ID + ".zip"
+}
+
+// exportWriter writes the files of one export archive.
+type exportWriter struct {
+	*Processor
+	account *gtsmodel.Account
+	zip     *zip.Writer
+
+	// media contains the media attachments
+	// of statuses written to the outbox, to
+	// be copied into the archive afterwards.
+	media []*gtsmodel.MediaAttachment
+}
+
+// writeActor writes actor.json, the ActivityStreams
+// representation of the account, and its avatar + header.
+func (w *exportWriter) writeActor(ctx context.Context) error {
+	person, err := w.tc.AccountToAS(ctx, w.account)
+	if err != nil {
+		return gtserror.Newf("error converting account: %w", err)
+	}
+
+	actor, err := ap.Serialize(person)
+	if err != nil {
+		return gtserror.Newf("error serializing account: %w", err)
+	}
+
+	// Point the avatar + header at their
+	// copies in the archive, as Mastodon does.
+	for _, image := range []struct {
+		prop       string
+		name       string
+		attachment *gtsmodel.MediaAttachment
+	}{
+		{"icon", "avatar", w.account.AvatarMediaAttachment},
+		{"image", "header", w.account.HeaderMediaAttachment},
+	} {
+		if image.attachment == nil {
+			continue
+		}
+
+		name := image.name + path.Ext(image.attachment.File.Path)
+		if err := w.copyFile(ctx, name, image.attachment.File.Path); err != nil {
+			return err
+		}
+
+		if obj, ok := actor[image.prop].(map[string]interface{}); ok {
+			obj["url"] = name
+		}
+	}
+
+	return w.writeJSON("actor.json", actor)
+}
+
+// writeOutbox writes outbox.json, an ActivityStreams collection
+// of Create activities for each of the account's statuses.
+//
+// The outbox is streamed into the archive a page of statuses at
+// a time, so it doesn't need to be held in memory. Attachment URLs
+// are rewritten to point to their file in the archive.
+func (w *exportWriter) writeOutbox(ctx context.Context) error {
+	f, err := w.zip.Create("outbox.json")
+	if err != nil {
+		return gtserror.Newf("error creating outbox.json: %w", err)
+	}
+
+	if _, err := io.WriteString(f, `{"@context":"https://www.w3.org/ns/activitystreams","id":"outbox.json","type":"OrderedCollection","orderedItems":[`); err != nil {
+		return gtserror.Newf("error writing outbox.json: %w", err)
+	}
+
+	var (
+		total int
+		maxID string
+	)
+
+	for {
+		statuses, err := w.state.DB.GetAccountStatuses(ctx, w.account.ID, exportPageSize, false, true, maxID, "", false, false)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting statuses: %w", err)
+		}
+
+		if len(statuses) == 0 {
+			break
+		}
+		maxID = statuses[len(statuses)-1].ID
+
+		for _, status := range statuses {
+			item, err := w.outboxItem(ctx, status)
+			if err != nil {
+				// Better to export as much
+				// as possible than nothing.
+				log.Warnf(ctx, "skipping status %s: %v", status.ID, err)
+				continue
+			}
+
+			b, err := json.Marshal(item)
+			if err != nil {
+				return gtserror.Newf("error marshaling status %s: %w", status.ID, err)
+			}
+
+			if total != 0 {
+				b = append([]byte{','}, b...)
+			}
+
+			if _, err := f.Write(b); err != nil {
+				return gtserror.Newf("error writing outbox.json: %w", err)
+			}
+			total++
+		}
+	}
+
+	if _, err := io.WriteString(f, `],"totalItems":`+strconv.Itoa(total)+`}`); err != nil {
+		return gtserror.Newf("error writing outbox.json: %w", err)
+	}
+
+	return nil
+}
+
+// outboxItem returns the serialized Create activity for the
+// given status, noting its media attachments for writeMedia.
+func (w *exportWriter) outboxItem(ctx context.Context, status *gtsmodel.Status) (map[string]interface{}, error) {
+	statusable, err := w.tc.StatusToAS(ctx, status)
+	if err != nil {
+		return nil, gtserror.Newf("error converting status: %w", err)
+	}
+
+	create, err := w.tc.WrapStatusableInCreate(statusable, false)
+	if err != nil {
+		return nil, gtserror.Newf("error wrapping status: %w", err)
+	}
+
+	item, err := ap.Serialize(create)
+	if err != nil {
+		return nil, gtserror.Newf("error serializing status: %w", err)
+	}
+
+	// The collection carries the context.
+	delete(item, "@context")
+
+	names := make(map[string]string, len(status.Attachments))
+	for _, attachment := range status.Attachments {
+		name := exportMediaDir + attachment.ID + path.Ext(attachment.File.Path)
+		names[attachment.URL] = name
+		w.media = append(w.media, attachment)
+	}
+
+	if object, ok := item["object"].(map[string]interface{}); ok {
+		var attachments []interface{}
+		switch a := object["attachment"].(type) {
+		case []interface{}:
+			attachments = a
+		case map[string]interface{}:
+			// Always give an array,
+			// as Mastodon does.
+			attachments = []interface{}{a}
+			object["attachment"] = attachments
+		}
+
+		for _, a := range attachments {
+			attachment, ok := a.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			if url, ok := attachment["url"].(string); ok && names[url] != "" {
+				attachment["url"] = names[url]
+			}
+		}
+	}
+
+	return item, nil
+}
+
+// writeMedia copies the original files of attachments
+// noted while writing the outbox into the archive.
+func (w *exportWriter) writeMedia(ctx context.Context) error {
+	for _, attachment := range w.media {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		name := exportMediaDir + attachment.ID + path.Ext(attachment.File.Path)
+		if err := w.copyFile(ctx, name, attachment.File.Path); err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				log.Warnf(ctx, "skipping missing media %s", attachment.ID)
+				continue
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeFollowing writes following_accounts.csv.
+func (w *exportWriter) writeFollowing(ctx context.Context) error {
+	follows, err := w.state.DB.GetAccountFollows(ctx, w.account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting follows: %w", err)
+	}
+
+	records := [][]string{{"Account address", "Show boosts", "Notify on new posts", "Languages"}}
+	for _, follow := range follows {
+		records = append(records, []string{
+			exportAcct(follow.TargetAccount),
+			strconv.FormatBool(*follow.ShowReblogs),
+			strconv.FormatBool(*follow.Notify),
+			"",
+		})
+	}
+
+	return w.writeCSV("following_accounts.csv", records)
+}
+
+// writeFollowers writes followers.csv.
+func (w *exportWriter) writeFollowers(ctx context.Context) error {
+	follows, err := w.state.DB.GetAccountFollowers(ctx, w.account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting followers: %w", err)
+	}
+
+	records := [][]string{{"Account address"}}
+	for _, follow := range follows {
+		records = append(records, []string{exportAcct(follow.Account)})
+	}
+
+	return w.writeCSV("followers.csv", records)
+}
+
+// writeBlocks writes blocked_accounts.csv,
+// which has no header in Mastodon exports.
+func (w *exportWriter) writeBlocks(ctx context.Context) error {
+	var (
+		records [][]string
+		maxID   string
+	)
+
+	for {
+		accounts, nextMaxID, _, err := w.state.DB.GetAccountBlocks(ctx, w.account.ID, maxID, "", exportPageSize)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting blocks: %w", err)
+		}
+
+		if len(accounts) == 0 {
+			break
+		}
+		maxID = nextMaxID
+
+		for _, account := range accounts {
+			records = append(records, []string{exportAcct(account)})
+		}
+	}
+
+	return w.writeCSV("blocked_accounts.csv", records)
+}
+
+// writeMutes writes muted_accounts.csv.
+func (w *exportWriter) writeMutes(ctx context.Context) error {
+	mutes, err := w.state.DB.GetAccountMutes(ctx, w.account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting mutes: %w", err)
+	}
+
+	records := [][]string{{"Account address", "Hide notifications"}}
+	for _, mute := range mutes {
+		records = append(records, []string{
+			exportAcct(mute.TargetAccount),
+			strconv.FormatBool(*mute.Notifications),
+		})
+	}
+
+	return w.writeCSV("muted_accounts.csv", records)
+}
+
+// writeLists writes lists.csv, which
+// has no header in Mastodon exports.
+func (w *exportWriter) writeLists(ctx context.Context) error {
+	lists, err := w.state.DB.GetListsForAccountID(ctx, w.account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting lists: %w", err)
+	}
+
+	var records [][]string
+	for _, list := range lists {
+		entries, err := w.state.DB.GetListEntries(ctx, list.ID, "", "", "", 0)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting list entries: %w", err)
+		}
+
+		for _, entry := range entries {
+			account, err := w.state.DB.GetAccountByID(
+				gtscontext.SetBarebones(ctx),
+				entry.Follow.TargetAccountID,
+			)
+			if err != nil {
+				log.Errorf(ctx, "db error getting account for list entry %s: %v", entry.ID, err)
+				continue
+			}
+
+			records = append(records, []string{list.Title, exportAcct(account)})
+		}
+	}
+
+	return w.writeCSV("lists.csv", records)
+}
+
+// writeBookmarks writes bookmarks.csv, which
+// has no header in Mastodon exports.
+func (w *exportWriter) writeBookmarks(ctx context.Context) error {
+	var (
+		records [][]string
+		maxID   string
+	)
+
+	for {
+		bookmarks, err := w.state.DB.GetStatusBookmarks(ctx, w.account.ID, exportPageSize, maxID, "")
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting bookmarks: %w", err)
+		}
+
+		if len(bookmarks) == 0 {
+			break
+		}
+		maxID = bookmarks[len(bookmarks)-1].ID
+
+		for _, bookmark := range bookmarks {
+			status, err := w.state.DB.GetStatusByID(
+				gtscontext.SetBarebones(ctx),
+				bookmark.StatusID,
+			)
+			if err != nil {
+				log.Errorf(ctx, "db error getting status for bookmark %s: %v", bookmark.ID, err)
+				continue
+			}
+
+			records = append(records, []string{status.URI})
+		}
+	}
+
+	return w.writeCSV("bookmarks.csv", records)
+}
+
+// writeJSON writes v to the archive as the given file.
+func (w *exportWriter) writeJSON(name string, v any) error {
+	f, err := w.zip.Create(name)
+	if err != nil {
+		return gtserror.Newf("error creating %s: %w", name, err)
+	}
+
+	if err := json.NewEncoder(f).Encode(v); err != nil {
+		return gtserror.Newf("error writing %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// writeCSV writes records to the archive as the given file.
+func (w *exportWriter) writeCSV(name string, records [][]string) error {
+	f, err := w.zip.Create(name)
+	if err != nil {
+		return gtserror.Newf("error creating %s: %w", name, err)
+	}
+
+	if err := csv.NewWriter(f).WriteAll(records); err != nil {
+		return gtserror.Newf("error writing %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// copyFile copies the file at key in storage
+// to the archive, as the file with given name.
+func (w *exportWriter) copyFile(ctx context.Context, name string, key string) error {
+	rc, err := w.state.Storage.GetStream(ctx, key)
+	if err != nil {
+		return gtserror.Newf("error opening %s: %w", key, err)
+	}
+	defer rc.Close()
+
+	// Media is already compressed,
+	// so store it as-is.
+	f, err := w.zip.CreateHeader(&zip.FileHeader{
+		Name:   name,
+		Method: zip.Store,
+	})
+	if err != nil {
+		return gtserror.Newf("error creating %s: %w", name, err)
+	}
+
+	if _, err := io.Copy(f, rc); err != nil {
+		return gtserror.Newf("error copying %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// exportAcct returns the username@domain address of the
+// given account, as used in Mastodon-compatible CSV files.
+func exportAcct(account *gtsmodel.Account) string {
+	domain := account.Domain
+	if domain == "" {
+		domain = config.GetAccountDomain()
+	}
+	return account.Username + "@" + domain
+}
+
+// scheduleExportCleanup schedules hourly removal of expired
+// exports, deleting their archives from storage.
+func (p *Processor) scheduleExportCleanup() {
+	// Get ctx associated with scheduler run state.
+	done := p.state.Workers.Scheduler.Done()
+	doneCtx := runners.CancelCtx(done)
+
+	next := time.Now().Truncate(time.Hour).Add(time.Hour)
+
+	p.state.Workers.Scheduler.Schedule(sched.NewJob(func(start time.Time) {
+		deleted, err := p.ExportsExpire(doneCtx, start)
+		if err != nil {
+			log.Errorf(nil, "error deleting expired exports: %v", err)
+			return
+		}
+		if deleted > 0 {
+			log.Infof(nil, "deleted %d expired exports", deleted)
+		}
+	}).EveryAt(next, time.Hour))
+}
+
+// ExportsExpire deletes exports which expired before
+// the given time, returning the number deleted.
+func (p *Processor) ExportsExpire(ctx context.Context, now time.Time) (int, error) {
+	exports, err := p.state.DB.GetExpiredExports(ctx, now)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return 0, gtserror.Newf("db error getting expired exports: %w", err)
+	}
+
+	var deleted int
+	for _, export := range exports {
+		if err := p.deleteExport(ctx, export); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+
+	return deleted, nil
+}
+
+// deleteExport deletes the given export and its archive.
+func (p *Processor) deleteExport(ctx context.Context, export *gtsmodel.Export) error {
+	if export.Path != "" {
+		err := p.state.Storage.Delete(ctx, export.Path)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return gtserror.Newf("error deleting archive %s: %w", export.Path, err)
+		}
+	}
+
+	if err := p.state.DB.DeleteExportByID(ctx, export.ID); err != nil {
+		return gtserror.Newf("db error deleting export %s: %w", export.ID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,247 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type ExportTestSuite struct {
+	AccountStandardTestSuite
+}
+
+// waitExport waits for the given export to stop processing.
+func (suite *ExportTestSuite) waitExport(id string) *gtsmodel.Export {
+	var export *gtsmodel.Export
+	if !suite.Eventually(func() bool {
+		var err error
+		export, err = suite.db.GetExportByID(context.Background(), id)
+		return err == nil && export.State != gtsmodel.ExportProcessing
+	}, 10*time.Second, 50*time.Millisecond) {
+		suite.FailNow("timed out waiting for export")
+	}
+	return export
+}
+
+// readArchive returns the files in the archive
+// of the given export, keyed by their name.
+func (suite *ExportTestSuite) readArchive(account *gtsmodel.Account, id string) map[string][]byte {
+	content, errWithCode := suite.accountProcessor.ExportFileGet(context.Background(), account, id)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	defer content.Content.Close()
+
+	b, err := io.ReadAll(content.Content)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.EqualValues(content.ContentLength, len(b))
+	suite.Equal("application/zip", content.ContentType)
+
+	r, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	files := make(map[string][]byte)
+	for _, f := range r.File {
+		rc, err := f.Open()
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		files[f.Name], err = io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+	return files
+}
+
+func (suite *ExportTestSuite) TestExport() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["admin_account"]
+
+	// Mute an account so there's something in muted_accounts.csv.
+	if _, errWithCode := suite.accountProcessor.MuteCreate(ctx, testAccount, suite.testAccounts["local_account_2"].ID, nil); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	apiExport, errWithCode := suite.accountProcessor.ExportCreate(ctx, testAccount)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("processing", apiExport.State)
+
+	export := suite.waitExport(apiExport.ID)
+	suite.Equal(gtsmodel.ExportComplete, export.State)
+	suite.Empty(export.Error)
+	suite.NotZero(export.Size)
+	suite.WithinDuration(export.CompletedAt.Add(7*24*time.Hour), export.ExpiresAt, time.Second)
+
+	files := suite.readArchive(testAccount, export.ID)
+	for _, name := range []string{
+		"actor.json",
+		"outbox.json",
+		"following_accounts.csv",
+		"followers.csv",
+		"blocked_accounts.csv",
+		"muted_accounts.csv",
+		"lists.csv",
+		"bookmarks.csv",
+	} {
+		suite.Contains(files, name)
+	}
+
+	actor := make(map[string]interface{})
+	if err := json.Unmarshal(files["actor.json"], &actor); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(testAccount.URI, actor["id"])
+
+	outbox := struct {
+		Type         string
+		TotalItems   int
+		OrderedItems []struct {
+			Type   string
+			Object struct {
+				ID         string
+				Attachment []struct {
+					URL string
+				}
+			}
+		}
+	}{}
+	if err := json.Unmarshal(files["outbox.json"], &outbox); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("OrderedCollection", outbox.Type)
+	suite.NotZero(outbox.TotalItems)
+	suite.Len(outbox.OrderedItems, outbox.TotalItems)
+
+	var attachments int
+	for _, item := range outbox.OrderedItems {
+		suite.Equal("Create", item.Type)
+		for _, attachment := range item.Object.Attachment {
+			suite.True(strings.HasPrefix(attachment.URL, "media_attachments/files/"), attachment.URL)
+			suite.Contains(files, attachment.URL)
+			attachments++
+		}
+	}
+	suite.NotZero(attachments)
+
+	suite.Equal("Account address,Show boosts,Notify on new posts,Languages\n"+
+		"the_mighty_zork@localhost:8080,true,false,\n", string(files["following_accounts.csv"]))
+	suite.Equal("Account address,Hide notifications\n"+
+		"1happyturtle@localhost:8080,true\n", string(files["muted_accounts.csv"]))
+
+	// The user should have been told
+	// the export is ready to download.
+	suite.Eventually(func() bool {
+		_, ok := suite.sentEmails["admin@example.org"]
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+	suite.Contains(suite.sentEmails["admin@example.org"], "http://localhost:8080/settings/user/export")
+
+	// Another export can't be requested straight away.
+	_, errWithCode = suite.accountProcessor.ExportCreate(ctx, testAccount)
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	// It's not visible to other accounts.
+	_, errWithCode = suite.accountProcessor.ExportGet(ctx, suite.testAccounts["local_account_1"], export.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// Once expired, it's deleted along with its archive.
+	deleted, err := suite.accountProcessor.ExportsExpire(ctx, export.ExpiresAt)
+	suite.NoError(err)
+	suite.Equal(1, deleted)
+
+	has, err := suite.storage.Has(ctx, export.Path)
+	suite.NoError(err)
+	suite.False(has)
+
+	_, errWithCode = suite.accountProcessor.ExportGet(ctx, testAccount, export.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *ExportTestSuite) TestExportFileGetNotReady() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	export := &gtsmodel.Export{
+		ID:        "01HA9Q2Z3W4X5Y6Z7A8B9C0D1E",
+		AccountID: testAccount.ID,
+		State:     gtsmodel.ExportProcessing,
+	}
+	if err := suite.db.PutExport(ctx, export); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, errWithCode := suite.accountProcessor.ExportFileGet(ctx, testAccount, export.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+	suite.Equal("Not Found: export not ready for download", errWithCode.Safe())
+}
+
+func (suite *ExportTestSuite) TestExportInterrupted() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// Keep the only jobs worker busy until the
+	// pool is stopped, so the export is still
+	// queued when shutdown begins.
+	suite.state.Workers.Jobs.Stop()
+	suite.state.Workers.Jobs.Start(1, 10)
+	suite.state.Workers.Jobs.Enqueue(func(ctx context.Context) {
+		<-ctx.Done()
+	})
+
+	apiExport, errWithCode := suite.accountProcessor.ExportCreate(ctx, testAccount)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Stopping runs the queued export
+	// with a cancelled context.
+	suite.True(suite.state.Workers.Jobs.Stop())
+
+	// The export is left processing, with
+	// nothing written, until it expires.
+	export, err := suite.db.GetExportByID(ctx, apiExport.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.ExportProcessing, export.State)
+	suite.Empty(export.Path)
+	suite.Empty(export.Error)
+}
+
+func TestExportTestSuite(t *testing.T) {
+	suite.Run(t, new(ExportTestSuite))
+}
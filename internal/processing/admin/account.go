@@ -19,46 +19,87 @@ package admin
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
-	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
-	"github.com/superseriousbusiness/gotosocial/internal/id"
-	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
 )
 
-func (p *Processor) AccountAction(ctx context.Context, account *gtsmodel.Account, form *apimodel.AdminAccountActionRequest) gtserror.WithCode {
-	targetAccount, err := p.state.DB.GetAccountByID(ctx, form.TargetAccountID)
+// AccountQuotaGet returns the media storage usage and quota of the local account with given ID.
+func (p *Processor) AccountQuotaGet(ctx context.Context, accountID string) (*apimodel.MediaQuota, gtserror.WithCode) {
+	user, errWithCode := p.accountUser(ctx, accountID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.accountQuota(ctx, user)
+}
+
+// AccountQuotaSet sets the media storage quota of the local account with given ID,
+// overriding the instance default, or removing the override for a "default" quota.
+func (p *Processor) AccountQuotaSet(ctx context.Context, account *gtsmodel.Account, accountID string, form *apimodel.AdminAccountQuotaRequest) (*apimodel.MediaQuota, gtserror.WithCode) {
+	quota, err := media.ParseAccountQuota(form.Quota)
 	if err != nil {
-		return gtserror.NewErrorInternalError(err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
 	}
 
-	adminAction := &gtsmodel.AdminAccountAction{
-		ID:              id.NewULID(),
-		AccountID:       account.ID,
-		TargetAccountID: targetAccount.ID,
-		Text:            form.Text,
+	user, errWithCode := p.accountUser(ctx, accountID)
+	if errWithCode != nil {
+		return nil, errWithCode
 	}
 
-	switch form.Type {
-	case string(gtsmodel.AdminActionSuspend):
-		adminAction.Type = gtsmodel.AdminActionSuspend
-		// pass the account delete through the client api channel for processing
-		p.state.Workers.EnqueueClientAPI(ctx, messages.FromClientAPI{
-			APObjectType:   ap.ActorPerson,
-			APActivityType: ap.ActivityDelete,
-			OriginAccount:  account,
-			TargetAccount:  targetAccount,
-		})
-	default:
-		return gtserror.NewErrorBadRequest(fmt.Errorf("admin action type %s is not supported for this endpoint", form.Type))
+	// Keep the current state for the audit log.
+	before, errWithCode := p.accountQuota(ctx, user)
+	if errWithCode != nil {
+		return nil, errWithCode
 	}
 
-	if err := p.state.DB.Put(ctx, adminAction); err != nil {
-		return gtserror.NewErrorInternalError(err)
+	user.MediaQuota = quota
+	if err := p.state.DB.UpdateUser(ctx, user, "media_quota"); err != nil {
+		err := fmt.Errorf("AccountQuotaSet: db error updating user: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	after, errWithCode := p.accountQuota(ctx, user)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	p.Audit(ctx, account, gtsmodel.AuditLogActionUpdate, gtsmodel.AuditLogTargetAccount, accountID, before, after)
+
+	return after, nil
+}
+
+// accountUser fetches the user of the local account with given ID.
+func (p *Processor) accountUser(ctx context.Context, accountID string) (*gtsmodel.User, gtserror.WithCode) {
+	user, err := p.state.DB.GetUserByAccountID(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err := fmt.Errorf("no local user found for account %s", accountID)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+		err := fmt.Errorf("db error getting user: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return user, nil
+}
+
+// accountQuota returns the media storage usage and quota of given user.
+func (p *Processor) accountQuota(ctx context.Context, user *gtsmodel.User) (*apimodel.MediaQuota, gtserror.WithCode) {
+	usage, err := p.state.DB.GetAccountMediaUsage(ctx, user.AccountID)
+	if err != nil {
+		err := fmt.Errorf("db error getting media usage: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	return nil
+	return &apimodel.MediaQuota{
+		Usage:    usage,
+		Limit:    media.AccountQuota(user),
+		Override: user.MediaQuota != nil,
+	}, nil
 }
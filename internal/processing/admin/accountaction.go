@@ -0,0 +1,264 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+// accountActionType describes an admin action
+// that can be taken on an account.
+type accountActionType struct {
+	// Type of the action to store.
+	Type gtsmodel.AdminActionType
+	// Whether this action undoes a previous action.
+	Undo bool
+	// Comment to leave on a resolved report.
+	Resolved string
+	// Description of the action to email the account owner.
+	Email string
+}
+
+// accountActionTypes maps form action types to account action types,
+// including Mastodon's "sensitive" and "unsensitive" alternative names.
+var accountActionTypes = func() map[string]accountActionType {
+	disable := accountActionType{
+		Type:     gtsmodel.AdminActionDisable,
+		Resolved: "The reported account has been disabled.",
+		Email:    "Your account has been disabled: you will no longer be able to log in.",
+	}
+	enable := accountActionType{
+		Type:  gtsmodel.AdminActionEnable,
+		Undo:  true,
+		Email: "Your account has been re-enabled: you can log in again.",
+	}
+	silence := accountActionType{
+		Type:     gtsmodel.AdminActionSilence,
+		Resolved: "The reported account has been silenced.",
+		Email:    "Your account has been silenced: from now on, your posts will only be shown to your followers.",
+	}
+	unsilence := accountActionType{
+		Type:  gtsmodel.AdminActionUnsilence,
+		Undo:  true,
+		Email: "Your account is no longer silenced: your posts will be shown as normal again.",
+	}
+	sensitize := accountActionType{
+		Type:     gtsmodel.AdminActionSensitize,
+		Resolved: "The media of the reported account has been marked as sensitive.",
+		Email:    "Your account has been marked as sensitive: from now on, all media you post will be hidden behind a sensitive media warning.",
+	}
+	unsensitize := accountActionType{
+		Type:  gtsmodel.AdminActionUnsensitize,
+		Undo:  true,
+		Email: "Your account is no longer marked as sensitive: media you post will be shown as normal again.",
+	}
+	suspend := accountActionType{
+		Type:     gtsmodel.AdminActionSuspend,
+		Resolved: "The reported account has been suspended.",
+		Email:    "Your account has been suspended: it will be removed from this instance, along with all of your posts.",
+	}
+
+	return map[string]accountActionType{
+		string(gtsmodel.AdminActionDisable):     disable,
+		string(gtsmodel.AdminActionEnable):      enable,
+		string(gtsmodel.AdminActionSilence):     silence,
+		string(gtsmodel.AdminActionUnsilence):   unsilence,
+		string(gtsmodel.AdminActionSensitize):   sensitize,
+		"sensitive":                             sensitize,
+		string(gtsmodel.AdminActionUnsensitize): unsensitize,
+		"unsensitive":                           unsensitize,
+		string(gtsmodel.AdminActionSuspend):     suspend,
+	}
+}()
+
+// AccountAction performs the admin action described by form on the target account.
+//
+// If a report ID is given, the action is linked to that report, which will be
+// resolved if not already. If requested, the target account owner is emailed.
+func (p *Processor) AccountAction(ctx context.Context, account *gtsmodel.Account, form *apimodel.AdminAccountActionRequest) gtserror.WithCode {
+	action, ok := accountActionTypes[form.Type]
+	if !ok {
+		err := fmt.Errorf("admin action type %s is not supported for this endpoint", form.Type)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	targetAccount, err := p.state.DB.GetAccountByID(ctx, form.TargetAccountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err := fmt.Errorf("account %s not found", form.TargetAccountID)
+			return gtserror.NewErrorNotFound(err, err.Error())
+		}
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Keep the current state for the audit log.
+	before, err := p.tc.AccountToAdminAPIAccount(ctx, targetAccount)
+	if err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	var report *gtsmodel.Report
+	if form.ReportID != "" {
+		report, err = p.state.DB.GetReportByID(ctx, form.ReportID)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				err := fmt.Errorf("report %s not found", form.ReportID)
+				return gtserror.NewErrorBadRequest(err, err.Error())
+			}
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		if report.TargetAccountID != targetAccount.ID {
+			err := fmt.Errorf("report %s does not target account %s", report.ID, targetAccount.ID)
+			return gtserror.NewErrorBadRequest(err, err.Error())
+		}
+	}
+
+	// Fetch the user (if any) now, as
+	// a suspension will go on to delete it.
+	var user *gtsmodel.User
+	if targetAccount.IsLocal() {
+		user, err = p.state.DB.GetUserByAccountID(ctx, targetAccount.ID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	switch action.Type {
+	case gtsmodel.AdminActionDisable, gtsmodel.AdminActionEnable:
+		if user == nil {
+			err := fmt.Errorf("account %s is not a local user account, cannot %s", targetAccount.ID, action.Type)
+			return gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		disabled := action.Type == gtsmodel.AdminActionDisable
+		user.Disabled = &disabled
+		if err := p.state.DB.UpdateUser(ctx, user, "disabled"); err != nil {
+			return gtserror.NewErrorInternalError(err)
+		}
+
+	case gtsmodel.AdminActionSilence, gtsmodel.AdminActionUnsilence:
+		targetAccount.SilencedAt = time.Time{}
+		if action.Type == gtsmodel.AdminActionSilence {
+			targetAccount.SilencedAt = time.Now()
+		}
+
+		if err := p.state.DB.UpdateAccount(ctx, targetAccount, "silenced_at"); err != nil {
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		// Silencing changes the visibility of all statuses
+		// of the account (and boosts of them), which we can't
+		// invalidate individually, so drop all cached visibility.
+		p.state.Caches.Visibility.Clear()
+
+	case gtsmodel.AdminActionSensitize, gtsmodel.AdminActionUnsensitize:
+		targetAccount.SensitizedAt = time.Time{}
+		if action.Type == gtsmodel.AdminActionSensitize {
+			targetAccount.SensitizedAt = time.Now()
+		}
+
+		if err := p.state.DB.UpdateAccount(ctx, targetAccount, "sensitized_at"); err != nil {
+			return gtserror.NewErrorInternalError(err)
+		}
+
+	case gtsmodel.AdminActionSuspend:
+		// pass the account delete through the client api channel for processing
+		p.state.Workers.EnqueueClientAPI(ctx, messages.FromClientAPI{
+			APObjectType:   ap.ActorPerson,
+			APActivityType: ap.ActivityDelete,
+			OriginAccount:  account,
+			TargetAccount:  targetAccount,
+		})
+	}
+
+	adminAction := &gtsmodel.AdminAccountAction{
+		ID:              id.NewULID(),
+		AccountID:       account.ID,
+		TargetAccountID: targetAccount.ID,
+		Text:            form.Text,
+		Type:            action.Type,
+		SendEmail:       form.SendEmail,
+	}
+
+	if report != nil {
+		adminAction.ReportID = report.ID
+	}
+
+	if err := p.state.DB.Put(ctx, adminAction); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	after, err := p.tc.AccountToAdminAPIAccount(ctx, targetAccount)
+	if err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	p.Audit(ctx, account, string(action.Type), gtsmodel.AuditLogTargetAccount, targetAccount.ID, before, after)
+
+	if report != nil && report.ActionTakenAt.IsZero() && !action.Undo {
+		// Resolve the linked report with a comment describing
+		// the action, not the admin's text, since the comment
+		// is shown to the account that created the report.
+		if _, errWithCode := p.ReportResolve(ctx, account, report.ID, &action.Resolved); errWithCode != nil {
+			return errWithCode
+		}
+	}
+
+	if form.SendEmail && user != nil && user.Email != "" {
+		go func() {
+			if err := p.emailAccountAction(context.Background(), user, targetAccount, action, form.Text); err != nil {
+				log.Errorf(ctx, "error emailing account action: %v", err)
+			}
+		}()
+	}
+
+	return nil
+}
+
+// emailAccountAction emails the owner of the target account to let them know about the action taken.
+func (p *Processor) emailAccountAction(ctx context.Context, user *gtsmodel.User, targetAccount *gtsmodel.Account, action accountActionType, text string) error {
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return fmt.Errorf("emailAccountAction: db error getting instance: %w", err)
+	}
+
+	accountActionData := email.AccountActionData{
+		Username:     targetAccount.Username,
+		InstanceURL:  instance.URI,
+		InstanceName: instance.Title,
+		Action:       action.Email,
+		Text:         text,
+	}
+
+	return p.emailSender.SendAccountActionEmail(user.Email, accountActionData)
+}
@@ -0,0 +1,162 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"codeberg.org/gruf/go-runners"
+	"codeberg.org/gruf/go-sched"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// day is the period covered by one activity rollup.
+	day = 24 * time.Hour
+
+	// rollupBackfillDays is the number of past days for
+	// which missing rollups are collected on each run, to
+	// catch up with days on which the instance was down.
+	rollupBackfillDays = 7
+)
+
+// RollupActivity collects and stores daily activity rollups for
+// any of the last few (UTC) days before now that don't have one yet.
+func (p *Processor) RollupActivity(ctx context.Context, now time.Time) error {
+	today := now.UTC().Truncate(day)
+	from := today.Add(-rollupBackfillDays * day)
+
+	stored, err := p.state.DB.GetDailyActivities(ctx, from, today)
+	if err != nil {
+		return gtserror.Newf("error getting stored rollups: %w", err)
+	}
+
+	have := make(map[int64]bool, len(stored))
+	for _, activity := range stored {
+		have[activity.Day.Unix()] = true
+	}
+
+	for d := from; d.Before(today); d = d.Add(day) {
+		if have[d.Unix()] {
+			// Already collected.
+			continue
+		}
+
+		activity, err := p.state.DB.CountActivity(ctx, d, d.Add(day))
+		if err != nil {
+			return gtserror.Newf("error counting activity for %s: %w", d, err)
+		}
+
+		if d.Add(day).Before(today) {
+			// Not yesterday, so the
+			// snapshot isn't of that day.
+			clearSnapshot(activity)
+		}
+
+		activity.ID = id.NewULID()
+		if err := p.state.DB.PutDailyActivity(ctx, activity); err != nil {
+			return gtserror.Newf("error storing activity for %s: %w", d, err)
+		}
+	}
+
+	return nil
+}
+
+// activities returns one DailyActivity for each (UTC) day from start
+// until end, using stored rollups where possible. Rollups for any past
+// days that are missing (eg., from before rollups were introduced) are
+// counted and stored, so that they only need to be counted once. Today
+// is counted live and not stored, as it isn't over yet. The size of the
+// instance at the end of past days can't be counted after the fact, so
+// the snapshot fields of backfilled rollups are left at zero.
+func (p *Processor) activities(ctx context.Context, start time.Time, end time.Time) ([]*gtsmodel.DailyActivity, error) {
+	stored, err := p.state.DB.GetDailyActivities(ctx, start, end)
+	if err != nil {
+		return nil, gtserror.Newf("error getting stored rollups: %w", err)
+	}
+
+	byDay := make(map[int64]*gtsmodel.DailyActivity, len(stored))
+	for _, activity := range stored {
+		byDay[activity.Day.Unix()] = activity
+	}
+
+	today := time.Now().UTC().Truncate(day)
+
+	activities := make([]*gtsmodel.DailyActivity, 0, int(end.Sub(start)/day))
+	for d := start; d.Before(end); d = d.Add(day) {
+		activity, ok := byDay[d.Unix()]
+		if !ok {
+			activity, err = p.state.DB.CountActivity(ctx, d, d.Add(day))
+			if err != nil {
+				return nil, gtserror.Newf("error counting activity for %s: %w", d, err)
+			}
+
+			if d.Before(today) {
+				// Counting only gives the *current*
+				// size of the instance, not that of
+				// this day, so don't store it as such.
+				clearSnapshot(activity)
+
+				// Store rollups for past days, so they
+				// don't need counting on every request.
+				activity.ID = id.NewULID()
+				if err := p.state.DB.PutDailyActivity(ctx, activity); err != nil &&
+					!errors.Is(err, db.ErrAlreadyExists) {
+					return nil, gtserror.Newf("error storing activity for %s: %w", d, err)
+				}
+			}
+		}
+		activities = append(activities, activity)
+	}
+
+	return activities, nil
+}
+
+// clearSnapshot zeroes the instance size snapshot of given
+// activity rollup, for rollups counted after the day is over.
+func clearSnapshot(activity *gtsmodel.DailyActivity) {
+	activity.KnownInstances = 0
+	activity.LocalMediaBytes = 0
+	activity.RemoteMediaBytes = 0
+	activity.EmojiBytes = 0
+}
+
+// scheduleRollups schedules daily activity rollups to
+// be collected every day, just after midnight (UTC).
+func (p *Processor) scheduleRollups() {
+	// Get ctx associated with scheduler run state.
+	done := p.state.Workers.Scheduler.Done()
+	doneCtx := runners.CancelCtx(done)
+
+	next := time.Now().UTC().Truncate(day).Add(day + 5*time.Minute)
+
+	p.state.Workers.Scheduler.Schedule(sched.NewJob(func(start time.Time) {
+		log.Info(nil, "starting activity rollup")
+		if err := p.RollupActivity(doneCtx, start); err != nil {
+			log.Errorf(nil, "error collecting activity rollup: %v", err)
+			return
+		}
+		log.Infof(nil, "finished activity rollup after %s", time.Since(start))
+	}).EveryAt(next, day))
+}
@@ -37,7 +37,7 @@ type Processor struct {
 
 // New returns a new admin processor.
 func New(state *state.State, tc typeutils.TypeConverter, mediaManager *media.Manager, transportController transport.Controller, emailSender email.Sender) Processor {
-	return Processor{
+	p := Processor{
 		state:               state,
 		cleaner:             cleaner.New(state),
 		tc:                  tc,
@@ -45,4 +45,7 @@ func New(state *state.State, tc typeutils.TypeConverter, mediaManager *media.Man
 		transportController: transportController,
 		emailSender:         emailSender,
 	}
+	p.scheduleRollups()
+	p.scheduleIPBlockCleanup()
+	return p
 }
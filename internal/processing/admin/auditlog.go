@@ -0,0 +1,222 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// Audit appends an entry to the moderation audit log, recording that account took
+// action on the target of given type and ID. The diff of the entry is made up of the
+// fields that differ between the JSON representations of before and after, either of
+// which may be nil for creations / deletions.
+//
+// Audit is called once the action has already been taken,
+// so errors are logged rather than returned to the caller.
+func (p *Processor) Audit(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	action string,
+	targetType gtsmodel.AuditLogTargetType,
+	targetID string,
+	before any,
+	after any,
+) {
+	diff, err := auditDiff(before, after)
+	if err != nil {
+		log.Errorf(ctx, "error calculating audit log diff for %s %s: %v", action, targetType, err)
+		diff = "{}"
+	}
+
+	entry := &gtsmodel.AuditLogEntry{
+		ID:         id.NewULID(),
+		AccountID:  account.ID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Diff:       diff,
+	}
+
+	if err := p.state.DB.PutAuditLogEntry(ctx, entry); err != nil {
+		log.Errorf(ctx, "error putting audit log entry for %s %s: %v", action, targetType, err)
+	}
+}
+
+// auditDiff returns a JSON object of the top-level fields that differ
+// between the JSON representations of before and after, in the form
+// {"field":{"old":...,"new":...}}.
+func auditDiff(before any, after any) (string, error) {
+	oldFields, err := jsonFields(before)
+	if err != nil {
+		return "", err
+	}
+
+	newFields, err := jsonFields(after)
+	if err != nil {
+		return "", err
+	}
+
+	type change struct {
+		Old json.RawMessage `json:"old"`
+		New json.RawMessage `json:"new"`
+	}
+
+	diff := make(map[string]change)
+	for k, v := range oldFields {
+		if !bytes.Equal(v, newFields[k]) {
+			diff[k] = change{Old: v, New: newFields[k]}
+		}
+	}
+	for k, v := range newFields {
+		if _, ok := oldFields[k]; !ok {
+			diff[k] = change{New: v}
+		}
+	}
+
+	b, err := json.Marshal(diff)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// jsonFields returns the top-level fields of the JSON representation of v.
+func jsonFields(v any) (map[string]json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+// AuditLogGet returns entries from the moderation audit log, with the given parameters.
+func (p *Processor) AuditLogGet(
+	ctx context.Context,
+	accountID string,
+	action string,
+	targetType string,
+	targetID string,
+	maxID string,
+	sinceID string,
+	minID string,
+	limit int,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	extraQueryParams := []string{}
+	if accountID != "" {
+		extraQueryParams = append(extraQueryParams, "account_id="+accountID)
+	}
+	if action != "" {
+		extraQueryParams = append(extraQueryParams, "action="+action)
+	}
+	if targetType != "" {
+		extraQueryParams = append(extraQueryParams, "target_type="+targetType)
+	}
+	if targetID != "" {
+		extraQueryParams = append(extraQueryParams, "target_id="+targetID)
+	}
+
+	return p.auditLogGet(
+		ctx,
+		"/api/v1/admin/audit_log",
+		extraQueryParams,
+		accountID,
+		action,
+		targetType,
+		targetID,
+		maxID,
+		sinceID,
+		minID,
+		limit,
+	)
+}
+
+// auditLogGet returns a page of audit log entries with the given
+// parameters, with paging links pointing to the given path.
+func (p *Processor) auditLogGet(
+	ctx context.Context,
+	path string,
+	extraQueryParams []string,
+	accountID string,
+	action string,
+	targetType string,
+	targetID string,
+	maxID string,
+	sinceID string,
+	minID string,
+	limit int,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	entries, err := p.state.DB.GetAuditLogEntries(ctx, accountID, action, targetType, targetID, maxID, sinceID, minID, limit)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return util.EmptyPageableResponse(), nil
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(entries)
+	items := make([]interface{}, 0, count)
+	nextMaxIDValue := ""
+	prevMinIDValue := ""
+	for i, e := range entries {
+		item, err := p.tc.AuditLogEntryToAdminAPIAuditLogEntry(ctx, e)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting audit log entry to api: %s", err))
+		}
+
+		if i == count-1 {
+			nextMaxIDValue = item.ID
+		}
+
+		if i == 0 {
+			prevMinIDValue = item.ID
+		}
+
+		items = append(items, item)
+	}
+
+	return util.PackagePageableResponse(util.PageableResponseParams{
+		Items:            items,
+		Path:             path,
+		NextMaxIDValue:   nextMaxIDValue,
+		PrevMinIDValue:   prevMinIDValue,
+		Limit:            limit,
+		ExtraQueryParams: extraQueryParams,
+	})
+}
@@ -45,6 +45,7 @@ func (p *Processor) DomainBlockCreate(ctx context.Context, account *gtsmodel.Acc
 	domain = strings.ToLower(domain)
 
 	// first check if we already have a block -- if err == nil we already had a block so we can skip a whole lot of work
+	var created bool
 	block, err := p.state.DB.GetDomainBlock(ctx, domain)
 	if err != nil {
 		if !errors.Is(err, db.ErrNoEntries) {
@@ -70,6 +71,7 @@ func (p *Processor) DomainBlockCreate(ctx context.Context, account *gtsmodel.Acc
 
 		// Set the newly created block
 		block = newBlock
+		created = true
 
 		// Process the side effects of the domain block asynchronously since it might take a while
 		go func() {
@@ -83,6 +85,10 @@ func (p *Processor) DomainBlockCreate(ctx context.Context, account *gtsmodel.Acc
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting domain block to frontend/api representation %s: %s", domain, err))
 	}
 
+	if created {
+		p.Audit(ctx, account, gtsmodel.AuditLogActionCreate, gtsmodel.AuditLogTargetDomainBlock, block.ID, nil, apiDomainBlock)
+	}
+
 	return apiDomainBlock, nil
 }
 
@@ -307,5 +313,7 @@ func (p *Processor) DomainBlockDelete(ctx context.Context, account *gtsmodel.Acc
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("database error removing suspension_origin from accounts: %s", err))
 	}
 
+	p.Audit(ctx, account, gtsmodel.AuditLogActionDelete, gtsmodel.AuditLogTargetDomainBlock, domainBlock.ID, apiDomainBlock, nil)
+
 	return apiDomainBlock, nil
 }
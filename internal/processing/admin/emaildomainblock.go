@@ -0,0 +1,257 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// EmailDomainBlocksGet returns all email domain blocks, sorted by domain.
+func (p *Processor) EmailDomainBlocksGet(ctx context.Context) ([]*apimodel.EmailDomainBlock, gtserror.WithCode) {
+	blocks, err := p.state.DB.GetEmailDomainBlocks(ctx)
+	if err != nil {
+		err := fmt.Errorf("EmailDomainBlocksGet: db error getting email domain blocks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiBlocks := make([]*apimodel.EmailDomainBlock, 0, len(blocks))
+	for _, block := range blocks {
+		apiBlock, err := p.tc.EmailDomainBlockToAPIEmailDomainBlock(ctx, block)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiBlocks = append(apiBlocks, apiBlock)
+	}
+
+	return apiBlocks, nil
+}
+
+// EmailDomainBlockGet returns the email domain block with the given ID.
+func (p *Processor) EmailDomainBlockGet(ctx context.Context, id string) (*apimodel.EmailDomainBlock, gtserror.WithCode) {
+	block, errWithCode := p.getEmailDomainBlock(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiBlock, err := p.tc.EmailDomainBlockToAPIEmailDomainBlock(ctx, block)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiBlock, nil
+}
+
+// EmailDomainBlockCreate blocks sign-ups and email changes to addresses at
+// the given domain, its subdomains, or domains whose mail is handled by it.
+// If the domain is already blocked, the existing block is returned.
+func (p *Processor) EmailDomainBlockCreate(ctx context.Context, account *gtsmodel.Account, domain string) (*apimodel.EmailDomainBlock, gtserror.WithCode) {
+	domain, errWithCode := normalizeEmailDomain(domain)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	block, err := p.state.DB.GetEmailDomainBlock(ctx, domain)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := fmt.Errorf("EmailDomainBlockCreate: db error checking for existing email domain block %s: %w", domain, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	created := false
+	if block == nil {
+		block = &gtsmodel.EmailDomainBlock{
+			ID:                 id.NewULID(),
+			Domain:             domain,
+			CreatedByAccountID: account.ID,
+		}
+
+		if err := p.state.DB.PutEmailDomainBlock(ctx, block); err != nil {
+			err := fmt.Errorf("EmailDomainBlockCreate: db error putting email domain block %s: %w", domain, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		created = true
+	}
+
+	apiBlock, err := p.tc.EmailDomainBlockToAPIEmailDomainBlock(ctx, block)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if created {
+		p.Audit(ctx, account, gtsmodel.AuditLogActionCreate, gtsmodel.AuditLogTargetEmailDomainBlock, block.ID, nil, apiBlock)
+	}
+
+	return apiBlock, nil
+}
+
+// EmailDomainBlocksImport blocks all the domains in the given plaintext
+// list, which should contain one domain per line, as used by the common
+// lists of disposable email providers. Blank lines and lines starting
+// with '#' are ignored, as are domains which are invalid or already
+// blocked. The newly created blocks are returned.
+func (p *Processor) EmailDomainBlocksImport(ctx context.Context, account *gtsmodel.Account, domains *multipart.FileHeader) ([]*apimodel.EmailDomainBlock, gtserror.WithCode) {
+	f, err := domains.Open()
+	if err != nil {
+		err := fmt.Errorf("EmailDomainBlocksImport: error opening attachment: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	defer f.Close()
+
+	// Load existing blocks so we can skip them.
+	existing, err := p.state.DB.GetEmailDomainBlocks(ctx)
+	if err != nil {
+		err := fmt.Errorf("EmailDomainBlocksImport: db error getting email domain blocks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	seen := make(map[string]struct{}, len(existing))
+	for _, block := range existing {
+		seen[block.Domain] = struct{}{}
+	}
+
+	var (
+		blocks  []*gtsmodel.EmailDomainBlock
+		invalid int
+	)
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		domain, errWithCode := normalizeEmailDomain(line)
+		if errWithCode != nil {
+			invalid++
+			continue
+		}
+
+		if _, ok := seen[domain]; ok {
+			continue
+		}
+		seen[domain] = struct{}{}
+
+		blocks = append(blocks, &gtsmodel.EmailDomainBlock{
+			ID:                 id.NewULID(),
+			Domain:             domain,
+			CreatedByAccountID: account.ID,
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		err := fmt.Errorf("EmailDomainBlocksImport: error reading attachment: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if len(blocks) == 0 && invalid > 0 {
+		err := fmt.Errorf("EmailDomainBlocksImport: attachment contained no valid domains (%d invalid)", invalid)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if err := p.state.DB.PutEmailDomainBlocks(ctx, blocks); err != nil {
+		err := fmt.Errorf("EmailDomainBlocksImport: db error putting email domain blocks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiBlocks := make([]*apimodel.EmailDomainBlock, 0, len(blocks))
+	for _, block := range blocks {
+		apiBlock, err := p.tc.EmailDomainBlockToAPIEmailDomainBlock(ctx, block)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiBlocks = append(apiBlocks, apiBlock)
+	}
+
+	if len(blocks) > 0 {
+		p.Audit(ctx, account, gtsmodel.AuditLogActionImport, gtsmodel.AuditLogTargetEmailDomainBlock, "", nil, map[string]int{
+			"imported": len(blocks),
+			"invalid":  invalid,
+		})
+	}
+
+	return apiBlocks, nil
+}
+
+// EmailDomainBlockDelete removes the email domain block with the given ID.
+func (p *Processor) EmailDomainBlockDelete(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.EmailDomainBlock, gtserror.WithCode) {
+	block, errWithCode := p.getEmailDomainBlock(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiBlock, err := p.tc.EmailDomainBlockToAPIEmailDomainBlock(ctx, block)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.DeleteEmailDomainBlockByID(ctx, block.ID); err != nil {
+		err := fmt.Errorf("EmailDomainBlockDelete: db error deleting email domain block %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.Audit(ctx, account, gtsmodel.AuditLogActionDelete, gtsmodel.AuditLogTargetEmailDomainBlock, block.ID, apiBlock, nil)
+
+	return apiBlock, nil
+}
+
+func (p *Processor) getEmailDomainBlock(ctx context.Context, id string) (*gtsmodel.EmailDomainBlock, gtserror.WithCode) {
+	block, err := p.state.DB.GetEmailDomainBlockByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err := fmt.Errorf("no email domain block with id %s", id)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		err := fmt.Errorf("db error getting email domain block %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return block, nil
+}
+
+// normalizeEmailDomain lowercases and punifies the given domain,
+// trimming any leading '@' or '.', and validates the result.
+func normalizeEmailDomain(domain string) (string, gtserror.WithCode) {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	domain = strings.TrimLeft(domain, "@.")
+
+	domain, err := util.Punify(domain)
+	if err != nil {
+		err := fmt.Errorf("error punifying email domain %s: %w", domain, err)
+		return "", gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if err := validate.EmailDomain(domain); err != nil {
+		return "", gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	return domain, nil
+}
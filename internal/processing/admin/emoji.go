@@ -30,6 +30,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
@@ -89,6 +90,8 @@ func (p *Processor) EmojiCreate(ctx context.Context, account *gtsmodel.Account,
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting emoji: %s", err), "error converting emoji to api representation")
 	}
 
+	p.Audit(ctx, account, gtsmodel.AuditLogActionCreate, gtsmodel.AuditLogTargetEmoji, emoji.ID, nil, apiEmoji)
+
 	return &apiEmoji, nil
 }
 
@@ -195,7 +198,7 @@ func (p *Processor) EmojiGet(ctx context.Context, account *gtsmodel.Account, use
 }
 
 // EmojiDelete deletes one emoji from the database, with the given id.
-func (p *Processor) EmojiDelete(ctx context.Context, id string) (*apimodel.AdminEmoji, gtserror.WithCode) {
+func (p *Processor) EmojiDelete(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminEmoji, gtserror.WithCode) {
 	emoji, err := p.state.DB.GetEmojiByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, db.ErrNoEntries) {
@@ -222,11 +225,21 @@ func (p *Processor) EmojiDelete(ctx context.Context, id string) (*apimodel.Admin
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	// Release the emoji files from storage; the original
+	// image may be shared with other identical emojis.
+	for _, path := range []string{emoji.ImagePath, emoji.ImageStaticPath} {
+		if err := p.state.Storage.Release(ctx, p.state.DB, path); err != nil {
+			log.Errorf(ctx, "error removing emoji file %s from storage: %v", path, err)
+		}
+	}
+
+	p.Audit(ctx, account, gtsmodel.AuditLogActionDelete, gtsmodel.AuditLogTargetEmoji, emoji.ID, adminEmoji, nil)
+
 	return adminEmoji, nil
 }
 
 // EmojiUpdate updates one emoji with the given id, using the provided form parameters.
-func (p *Processor) EmojiUpdate(ctx context.Context, id string, form *apimodel.EmojiUpdateRequest) (*apimodel.AdminEmoji, gtserror.WithCode) {
+func (p *Processor) EmojiUpdate(ctx context.Context, account *gtsmodel.Account, id string, form *apimodel.EmojiUpdateRequest) (*apimodel.AdminEmoji, gtserror.WithCode) {
 	emoji, err := p.state.DB.GetEmojiByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, db.ErrNoEntries) {
@@ -237,17 +250,42 @@ func (p *Processor) EmojiUpdate(ctx context.Context, id string, form *apimodel.E
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	// Keep the current state for the audit log.
+	before, err := p.tc.EmojiToAdminAPIEmoji(ctx, emoji)
+	if err != nil {
+		err = fmt.Errorf("EmojiUpdate: error converting emoji to admin api emoji: %s", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	var (
+		after       *apimodel.AdminEmoji
+		errWithCode gtserror.WithCode
+	)
+
 	switch form.Type {
 	case apimodel.EmojiUpdateCopy:
-		return p.emojiUpdateCopy(ctx, emoji, form.Shortcode, form.CategoryName)
+		after, errWithCode = p.emojiUpdateCopy(ctx, emoji, form.Shortcode, form.CategoryName)
 	case apimodel.EmojiUpdateDisable:
-		return p.emojiUpdateDisable(ctx, emoji)
+		after, errWithCode = p.emojiUpdateDisable(ctx, emoji)
 	case apimodel.EmojiUpdateModify:
-		return p.emojiUpdateModify(ctx, emoji, form.Image, form.CategoryName)
+		after, errWithCode = p.emojiUpdateModify(ctx, emoji, form.Image, form.CategoryName)
 	default:
 		err := errors.New("unrecognized emoji action type")
 		return nil, gtserror.NewErrorBadRequest(err, err.Error())
 	}
+
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if form.Type == apimodel.EmojiUpdateCopy {
+		// Copying creates a new local emoji.
+		p.Audit(ctx, account, gtsmodel.AuditLogActionCreate, gtsmodel.AuditLogTargetEmoji, after.ID, nil, after)
+	} else {
+		p.Audit(ctx, account, gtsmodel.AuditLogActionUpdate, gtsmodel.AuditLogTargetEmoji, emoji.ID, before, after)
+	}
+
+	return after, nil
 }
 
 // EmojiCategoriesGet returns all custom emoji categories that exist on this instance.
@@ -0,0 +1,245 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"codeberg.org/gruf/go-runners"
+	"codeberg.org/gruf/go-sched"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// IPBlocksGet returns all IP blocks, including expired ones
+// which haven't yet been cleaned up.
+func (p *Processor) IPBlocksGet(ctx context.Context) ([]*apimodel.AdminIPBlock, gtserror.WithCode) {
+	blocks, err := p.state.DB.GetIPBlocks(ctx)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiBlocks := make([]*apimodel.AdminIPBlock, 0, len(blocks))
+	for _, block := range blocks {
+		apiBlocks = append(apiBlocks, p.tc.IPBlockToAdminAPIIPBlock(block))
+	}
+
+	return apiBlocks, nil
+}
+
+// IPBlockGet returns the IP block with the given ID.
+func (p *Processor) IPBlockGet(ctx context.Context, id string) (*apimodel.AdminIPBlock, gtserror.WithCode) {
+	block, errWithCode := p.getIPBlock(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.tc.IPBlockToAdminAPIIPBlock(block), nil
+}
+
+// IPBlockCreate blocks the given IP address or range at the given severity.
+func (p *Processor) IPBlockCreate(ctx context.Context, account *gtsmodel.Account, form *apimodel.AdminIPBlockCreateRequest) (*apimodel.AdminIPBlock, gtserror.WithCode) {
+	ip, err := util.NormalizeIPRange(form.IP)
+	if err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	severity := gtsmodel.IPBlockSeverity(form.Severity)
+	if err := validate.IPBlockSeverity(severity); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	expiresAt, errWithCode := ipBlockExpiry(form.ExpiresIn)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if _, err := p.state.DB.GetIPBlockByIP(ctx, ip); err == nil {
+		err := fmt.Errorf("ip range %s is already blocked", ip)
+		return nil, gtserror.NewErrorConflict(err, err.Error())
+	} else if !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	block := &gtsmodel.IPBlock{
+		ID:                 id.NewULID(),
+		IP:                 ip,
+		Severity:           severity,
+		Comment:            text.SanitizePlaintext(form.Comment),
+		ExpiresAt:          expiresAt,
+		CreatedByAccountID: account.ID,
+	}
+
+	if err := p.state.DB.PutIPBlock(ctx, block); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiBlock := p.tc.IPBlockToAdminAPIIPBlock(block)
+	p.Audit(ctx, account, gtsmodel.AuditLogActionCreate, gtsmodel.AuditLogTargetIPBlock, block.ID, nil, apiBlock)
+
+	return apiBlock, nil
+}
+
+// IPBlockUpdate updates the range, severity, comment and / or
+// expiry of the IP block with the given ID.
+func (p *Processor) IPBlockUpdate(ctx context.Context, account *gtsmodel.Account, id string, form *apimodel.AdminIPBlockUpdateRequest) (*apimodel.AdminIPBlock, gtserror.WithCode) {
+	block, errWithCode := p.getIPBlock(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	before := p.tc.IPBlockToAdminAPIIPBlock(block)
+	columns := []string{}
+
+	if form.IP != nil {
+		ip, err := util.NormalizeIPRange(*form.IP)
+		if err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		if ip != block.IP {
+			if _, err := p.state.DB.GetIPBlockByIP(ctx, ip); err == nil {
+				err := fmt.Errorf("ip range %s is already blocked", ip)
+				return nil, gtserror.NewErrorConflict(err, err.Error())
+			} else if !errors.Is(err, db.ErrNoEntries) {
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+		}
+
+		block.IP = ip
+		columns = append(columns, "ip")
+	}
+
+	if form.Severity != nil {
+		severity := gtsmodel.IPBlockSeverity(*form.Severity)
+		if err := validate.IPBlockSeverity(severity); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		block.Severity = severity
+		columns = append(columns, "severity")
+	}
+
+	if form.Comment != nil {
+		block.Comment = text.SanitizePlaintext(*form.Comment)
+		columns = append(columns, "comment")
+	}
+
+	if form.ExpiresIn != nil {
+		expiresAt, errWithCode := ipBlockExpiry(*form.ExpiresIn)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		block.ExpiresAt = expiresAt
+		columns = append(columns, "expires_at")
+	}
+
+	if len(columns) == 0 {
+		return before, nil
+	}
+
+	if err := p.state.DB.UpdateIPBlock(ctx, block, columns...); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiBlock := p.tc.IPBlockToAdminAPIIPBlock(block)
+	p.Audit(ctx, account, gtsmodel.AuditLogActionUpdate, gtsmodel.AuditLogTargetIPBlock, block.ID, before, apiBlock)
+
+	return apiBlock, nil
+}
+
+// IPBlockDelete deletes the IP block with the given ID.
+func (p *Processor) IPBlockDelete(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminIPBlock, gtserror.WithCode) {
+	block, errWithCode := p.getIPBlock(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteIPBlockByID(ctx, block.ID); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiBlock := p.tc.IPBlockToAdminAPIIPBlock(block)
+	p.Audit(ctx, account, gtsmodel.AuditLogActionDelete, gtsmodel.AuditLogTargetIPBlock, block.ID, apiBlock, nil)
+
+	return apiBlock, nil
+}
+
+// getIPBlock gets the IP block with the
+// given ID, returning not found if it
+// doesn't exist.
+func (p *Processor) getIPBlock(ctx context.Context, id string) (*gtsmodel.IPBlock, gtserror.WithCode) {
+	block, err := p.state.DB.GetIPBlockByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err := fmt.Errorf("ip block %s not found", id)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return block, nil
+}
+
+// ipBlockExpiry returns the expiry time of an IP block
+// expiring in the given number of seconds from now,
+// or the zero time if it should never expire.
+func ipBlockExpiry(expiresIn int) (time.Time, gtserror.WithCode) {
+	switch {
+	case expiresIn < 0:
+		err := errors.New("expires_in must not be negative")
+		return time.Time{}, gtserror.NewErrorBadRequest(err, err.Error())
+	case expiresIn == 0:
+		return time.Time{}, nil
+	default:
+		return time.Now().Add(time.Duration(expiresIn) * time.Second), nil
+	}
+}
+
+// scheduleIPBlockCleanup schedules hourly removal of expired IP blocks.
+// Expired blocks stop applying as soon as they expire, so this is only
+// to keep them from accumulating in the list of blocks.
+func (p *Processor) scheduleIPBlockCleanup() {
+	// Get ctx associated with scheduler run state.
+	done := p.state.Workers.Scheduler.Done()
+	doneCtx := runners.CancelCtx(done)
+
+	next := time.Now().Truncate(time.Hour).Add(time.Hour)
+
+	p.state.Workers.Scheduler.Schedule(sched.NewJob(func(start time.Time) {
+		deleted, err := p.state.DB.DeleteExpiredIPBlocks(doneCtx, start)
+		if err != nil {
+			log.Errorf(nil, "error deleting expired ip blocks: %v", err)
+			return
+		}
+		if deleted > 0 {
+			log.Infof(nil, "deleted %d expired ip blocks", deleted)
+		}
+	}).EveryAt(next, time.Hour))
+}
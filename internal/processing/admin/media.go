@@ -44,11 +44,15 @@ func (p *Processor) MediaRefetch(ctx context.Context, requestingAccount *gtsmode
 		}
 	}()
 
+	p.Audit(ctx, requestingAccount, gtsmodel.AuditLogActionRefetch, gtsmodel.AuditLogTargetMedia, "", nil, map[string]string{
+		"domain": domain,
+	})
+
 	return nil
 }
 
 // MediaPrune triggers a non-blocking prune of unused media, orphaned, uncaching remote and fixing cache states.
-func (p *Processor) MediaPrune(ctx context.Context, mediaRemoteCacheDays int) gtserror.WithCode {
+func (p *Processor) MediaPrune(ctx context.Context, account *gtsmodel.Account, mediaRemoteCacheDays int) gtserror.WithCode {
 	if mediaRemoteCacheDays < 0 {
 		err := fmt.Errorf("MediaPrune: invalid value for mediaRemoteCacheDays prune: value was %d, cannot be less than 0", mediaRemoteCacheDays)
 		return gtserror.NewErrorBadRequest(err, err.Error())
@@ -61,5 +65,9 @@ func (p *Processor) MediaPrune(ctx context.Context, mediaRemoteCacheDays int) gt
 		p.cleaner.Emoji().All(ctx)
 	}()
 
+	p.Audit(ctx, account, gtsmodel.AuditLogActionPrune, gtsmodel.AuditLogTargetMedia, "", nil, map[string]int{
+		"remote_cache_days": mediaRemoteCacheDays,
+	})
+
 	return nil
 }
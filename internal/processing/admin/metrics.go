@@ -0,0 +1,517 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strconv"
+	"time"
+
+	"codeberg.org/gruf/go-bytesize"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	// defaultMetricsDays is the length of
+	// the period measured if none is given.
+	defaultMetricsDays = 30
+
+	// maxMetricsDays is the longest period that can be measured at once.
+	maxMetricsDays = 366
+
+	// unitBytes is the unit of measures and dimensions of storage size.
+	unitBytes = "bytes"
+)
+
+// parseMetricsPeriod parses the given start and end dates, returning the
+// start of the first day, and the end of the last day of the period (UTC).
+func parseMetricsPeriod(startAt string, endAt string) (time.Time, time.Time, gtserror.WithCode) {
+	parse := func(key string, value string) (time.Time, gtserror.WithCode) {
+		if t, err := time.Parse("2006-01-02", value); err == nil {
+			return t, nil
+		}
+
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			err := fmt.Errorf("%s %s is not a valid date", key, value)
+			return time.Time{}, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		return t.UTC().Truncate(day), nil
+	}
+
+	var (
+		start, end  time.Time
+		errWithCode gtserror.WithCode
+	)
+
+	if endAt == "" {
+		end = time.Now().UTC().Truncate(day)
+	} else if end, errWithCode = parse("end_at", endAt); errWithCode != nil {
+		return start, end, errWithCode
+	}
+
+	// End date is inclusive.
+	end = end.Add(day)
+
+	if startAt == "" {
+		start = end.Add(-defaultMetricsDays * day)
+	} else if start, errWithCode = parse("start_at", startAt); errWithCode != nil {
+		return start, end, errWithCode
+	}
+
+	if !start.Before(end) {
+		err := errors.New("start_at must not be after end_at")
+		return start, end, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if end.Sub(start) > maxMetricsDays*day {
+		err := fmt.Errorf("period between start_at and end_at must not be longer than %d days", maxMetricsDays)
+		return start, end, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	return start, end, nil
+}
+
+// MeasuresGet returns the requested measures of instance
+// activity, for each day of the requested period.
+func (p *Processor) MeasuresGet(ctx context.Context, form *apimodel.AdminMeasuresRequest) ([]*apimodel.AdminMeasure, gtserror.WithCode) {
+	start, end, errWithCode := parseMetricsPeriod(form.StartAt, form.EndAt)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Previous period of the same length.
+	prevStart := start.Add(-end.Sub(start))
+
+	var (
+		activities []*gtsmodel.DailyActivity
+		previous   *gtsmodel.DailyActivity
+		err        error
+	)
+
+	measures := make([]*apimodel.AdminMeasure, 0, len(form.Keys))
+	for _, key := range form.Keys {
+		var measure *apimodel.AdminMeasure
+
+		switch key {
+		case "new_users", "active_users", "interactions", "opened_reports",
+			"resolved_reports", "statuses", "known_instances", "media_storage":
+			if activities == nil {
+				// Load local activity on first use.
+				activities, err = p.activities(ctx, start, end)
+				if err != nil {
+					return nil, gtserror.NewErrorInternalError(err)
+				}
+
+				previous, err = p.state.DB.CountActivity(ctx, prevStart, start)
+				if err != nil {
+					err := gtserror.Newf("error counting previous activity: %w", err)
+					return nil, gtserror.NewErrorInternalError(err)
+				}
+			}
+
+			measure, err = p.localMeasure(ctx, key, activities, previous, prevStart, start)
+			if err != nil {
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+
+		case "instance_accounts", "instance_statuses",
+			"instance_reports", "instance_media_attachments":
+			params := map[string]*apimodel.AdminMeasureParams{
+				"instance_accounts":          form.InstanceAccounts,
+				"instance_statuses":          form.InstanceStatuses,
+				"instance_reports":           form.InstanceReports,
+				"instance_media_attachments": form.InstanceMediaAttachments,
+			}[key]
+
+			if params == nil || params.Domain == "" {
+				err := fmt.Errorf("no domain given for %s", key)
+				return nil, gtserror.NewErrorBadRequest(err, err.Error())
+			}
+
+			measure, err = p.instanceMeasure(ctx, key, params.Domain, prevStart, start, end)
+			if err != nil {
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+
+		default:
+			// Unknown measures are
+			// ignored, like Mastodon.
+			continue
+		}
+
+		measures = append(measures, measure)
+	}
+
+	return measures, nil
+}
+
+// localMeasure returns the measure with the given key from the given
+// daily activities, and previous activity starting at prevStart.
+func (p *Processor) localMeasure(
+	ctx context.Context,
+	key string,
+	activities []*gtsmodel.DailyActivity,
+	previous *gtsmodel.DailyActivity,
+	prevStart time.Time,
+	start time.Time,
+) (*apimodel.AdminMeasure, error) {
+	var value func(*gtsmodel.DailyActivity) int64
+
+	switch key {
+	case "new_users":
+		value = func(a *gtsmodel.DailyActivity) int64 { return int64(a.NewUsers) }
+	case "active_users":
+		value = func(a *gtsmodel.DailyActivity) int64 { return int64(a.ActiveUsers()) }
+	case "interactions":
+		value = func(a *gtsmodel.DailyActivity) int64 { return int64(a.Interactions) }
+	case "opened_reports":
+		value = func(a *gtsmodel.DailyActivity) int64 { return int64(a.OpenedReports) }
+	case "resolved_reports":
+		value = func(a *gtsmodel.DailyActivity) int64 { return int64(a.ResolvedReports) }
+	case "statuses":
+		value = func(a *gtsmodel.DailyActivity) int64 { return int64(a.Statuses) }
+	case "known_instances":
+		value = func(a *gtsmodel.DailyActivity) int64 { return int64(a.KnownInstances) }
+	case "media_storage":
+		value = func(a *gtsmodel.DailyActivity) int64 { return a.LocalMediaBytes + a.RemoteMediaBytes + a.EmojiBytes }
+	}
+
+	measure := &apimodel.AdminMeasure{
+		Key:  key,
+		Data: make([]apimodel.AdminMeasureData, 0, len(activities)),
+	}
+
+	var total int64
+	for _, activity := range activities {
+		v := value(activity)
+		total += v
+		measure.Data = append(measure.Data, apimodel.AdminMeasureData{
+			Date:  util.FormatISO8601(activity.Day),
+			Value: strconv.FormatInt(v, 10),
+		})
+	}
+
+	switch key {
+	case "active_users":
+		// Users active on multiple days should only be counted once.
+		total = int64(countDistinct(activities))
+
+		// Previous live count only includes users that posted or
+		// faved, so combine it with any stored rollups of the period.
+		stored, err := p.state.DB.GetDailyActivities(ctx, prevStart, start)
+		if err != nil {
+			return nil, gtserror.Newf("error getting stored rollups: %w", err)
+		}
+		measure.PreviousTotal = strconv.Itoa(countDistinct(append(stored, previous)))
+
+	case "known_instances", "media_storage":
+		// Snapshots; total is the value at the end of the period.
+		total = 0
+		if len(activities) != 0 {
+			total = value(activities[len(activities)-1])
+		}
+
+		// Previous total is the value at the end of the previous
+		// period, which is only known if a rollup was stored.
+		stored, err := p.state.DB.GetDailyActivities(ctx, start.Add(-day), start)
+		if err != nil {
+			return nil, gtserror.Newf("error getting stored rollups: %w", err)
+		}
+		if len(stored) != 0 {
+			measure.PreviousTotal = strconv.FormatInt(value(stored[0]), 10)
+		}
+
+	default:
+		measure.PreviousTotal = strconv.FormatInt(value(previous), 10)
+	}
+
+	measure.Total = strconv.FormatInt(total, 10)
+
+	if key == "media_storage" {
+		measure.Unit = unitBytes
+		measure.HumanValue = bytesize.Size(total).StringIEC()
+	}
+
+	return measure, nil
+}
+
+// instanceMeasure returns the measure with the given key for activity
+// originating from the given domain, for each day from start until end.
+func (p *Processor) instanceMeasure(
+	ctx context.Context,
+	key string,
+	domain string,
+	prevStart time.Time,
+	start time.Time,
+	end time.Time,
+) (*apimodel.AdminMeasure, error) {
+	var value func(*gtsmodel.InstanceActivity) int64
+
+	switch key {
+	case "instance_accounts":
+		value = func(a *gtsmodel.InstanceActivity) int64 { return int64(a.Accounts) }
+	case "instance_statuses":
+		value = func(a *gtsmodel.InstanceActivity) int64 { return int64(a.Statuses) }
+	case "instance_reports":
+		value = func(a *gtsmodel.InstanceActivity) int64 { return int64(a.Reports) }
+	case "instance_media_attachments":
+		value = func(a *gtsmodel.InstanceActivity) int64 { return a.MediaAttachments }
+	}
+
+	measure := &apimodel.AdminMeasure{
+		Key:  key,
+		Data: make([]apimodel.AdminMeasureData, 0, int(end.Sub(start)/day)),
+	}
+
+	var total int64
+	for d := start; d.Before(end); d = d.Add(day) {
+		activity, err := p.state.DB.CountInstanceActivity(ctx, domain, d, d.Add(day))
+		if err != nil {
+			return nil, gtserror.Newf("error counting activity of %s: %w", domain, err)
+		}
+
+		v := value(activity)
+		total += v
+		measure.Data = append(measure.Data, apimodel.AdminMeasureData{
+			Date:  util.FormatISO8601(d),
+			Value: strconv.FormatInt(v, 10),
+		})
+	}
+
+	previous, err := p.state.DB.CountInstanceActivity(ctx, domain, prevStart, start)
+	if err != nil {
+		return nil, gtserror.Newf("error counting previous activity of %s: %w", domain, err)
+	}
+
+	measure.Total = strconv.FormatInt(total, 10)
+	measure.PreviousTotal = strconv.FormatInt(value(previous), 10)
+
+	if key == "instance_media_attachments" {
+		measure.Unit = unitBytes
+		measure.HumanValue = bytesize.Size(total).StringIEC()
+	}
+
+	return measure, nil
+}
+
+// countDistinct returns the number of distinct
+// accounts active in any of the given activities.
+func countDistinct(activities []*gtsmodel.DailyActivity) int {
+	seen := make(map[string]struct{})
+	for _, activity := range activities {
+		for _, id := range activity.ActiveAccountIDs {
+			seen[id] = struct{}{}
+		}
+	}
+	return len(seen)
+}
+
+// DimensionsGet returns the requested qualitative
+// breakdowns of instance activity in the requested period.
+func (p *Processor) DimensionsGet(ctx context.Context, form *apimodel.AdminDimensionsRequest) ([]*apimodel.AdminDimension, gtserror.WithCode) {
+	start, end, errWithCode := parseMetricsPeriod(form.StartAt, form.EndAt)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	limit := form.Limit
+	if limit <= 0 {
+		limit = 10
+	} else if limit > 100 {
+		limit = 100
+	}
+
+	// counted converts the given activity counts to dimension data.
+	counted := func(counts []*gtsmodel.ActivityCount) []apimodel.AdminDimensionData {
+		data := make([]apimodel.AdminDimensionData, 0, len(counts))
+		for _, count := range counts {
+			data = append(data, apimodel.AdminDimensionData{
+				Key:      count.Key,
+				HumanKey: count.Key,
+				Value:    strconv.Itoa(count.Count),
+			})
+		}
+		return data
+	}
+
+	// sized returns dimension data of a storage size.
+	sized := func(key string, humanKey string, size int64) apimodel.AdminDimensionData {
+		return apimodel.AdminDimensionData{
+			Key:        key,
+			HumanKey:   humanKey,
+			Value:      strconv.FormatInt(size, 10),
+			Unit:       unitBytes,
+			HumanValue: bytesize.Size(size).StringIEC(),
+		}
+	}
+
+	dimensions := make([]*apimodel.AdminDimension, 0, len(form.Keys))
+	for _, key := range form.Keys {
+		dimension := &apimodel.AdminDimension{Key: key}
+
+		switch key {
+		case "languages":
+			counts, err := p.state.DB.CountStatusLanguages(ctx, start, end, limit)
+			if err != nil {
+				err := gtserror.Newf("error counting languages: %w", err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+			dimension.Data = counted(counts)
+
+		case "servers":
+			counts, err := p.state.DB.GetTopInstances(ctx, start, end, limit)
+			if err != nil {
+				err := gtserror.Newf("error counting servers: %w", err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+			dimension.Data = counted(counts)
+
+		case "space_usage":
+			// Only the current size of the instance is
+			// needed, so count over an empty period.
+			now := time.Now()
+			activity, err := p.state.DB.CountActivity(ctx, now, now)
+			if err != nil {
+				err := gtserror.Newf("error counting space usage: %w", err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+			dimension.Data = []apimodel.AdminDimensionData{
+				sized("media", "Local media", activity.LocalMediaBytes),
+				sized("cache", "Cached remote media", activity.RemoteMediaBytes),
+				sized("emoji", "Custom emoji", activity.EmojiBytes),
+			}
+
+		case "software_versions":
+			dimension.Data = []apimodel.AdminDimensionData{
+				{Key: "gotosocial", HumanKey: "GoToSocial", Value: config.GetSoftwareVersion()},
+				{Key: "go", HumanKey: "Go", Value: runtime.Version()},
+				{Key: "database", HumanKey: "Database", Value: config.GetDbType()},
+			}
+
+		default:
+			// Unknown dimensions are
+			// ignored, like Mastodon.
+			continue
+		}
+
+		dimensions = append(dimensions, dimension)
+	}
+
+	return dimensions, nil
+}
+
+// RetentionGet returns, for each cohort of users who signed up in a day or month
+// of the requested period, how many of them were active in each following period.
+func (p *Processor) RetentionGet(ctx context.Context, form *apimodel.AdminRetentionRequest) ([]*apimodel.AdminCohort, gtserror.WithCode) {
+	start, end, errWithCode := parseMetricsPeriod(form.StartAt, form.EndAt)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// next returns the start of the period after the one starting at t.
+	var next func(t time.Time) time.Time
+
+	switch form.Frequency {
+	case "", "day":
+		form.Frequency = "day"
+		next = func(t time.Time) time.Time { return t.Add(day) }
+	case "month":
+		start = time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC)
+		next = func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }
+	default:
+		err := fmt.Errorf("frequency %s not recognized, must be day or month", form.Frequency)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	activities, err := p.activities(ctx, start, end)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Gather periods, and the accounts active in each.
+	var (
+		periods []time.Time
+		active  []map[string]struct{}
+	)
+
+	for t := start; t.Before(end); t = next(t) {
+		accounts := make(map[string]struct{})
+		for _, activity := range activities {
+			if !activity.Day.Before(t) && activity.Day.Before(next(t)) {
+				for _, id := range activity.ActiveAccountIDs {
+					accounts[id] = struct{}{}
+				}
+			}
+		}
+		periods = append(periods, t)
+		active = append(active, accounts)
+	}
+
+	cohorts := make([]*apimodel.AdminCohort, 0, len(periods))
+	for i, period := range periods {
+		accountIDs, err := p.state.DB.GetNewAccountIDs(ctx, period, next(period))
+		if err != nil {
+			err := gtserror.Newf("error getting new accounts: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		cohort := &apimodel.AdminCohort{
+			Period:    util.FormatISO8601(period),
+			Frequency: form.Frequency,
+			Data:      make([]apimodel.AdminCohortData, 0, len(periods)-i),
+		}
+
+		for j := i; j < len(periods); j++ {
+			value := len(accountIDs)
+			if j != i {
+				// Signing up counts as activity in the first
+				// period, after that only count those active.
+				value = 0
+				for _, id := range accountIDs {
+					if _, ok := active[j][id]; ok {
+						value++
+					}
+				}
+			}
+
+			var rate float64
+			if len(accountIDs) != 0 {
+				rate = float64(value) / float64(len(accountIDs))
+			}
+
+			cohort.Data = append(cohort.Data, apimodel.AdminCohortData{
+				Date:  util.FormatISO8601(periods[j]),
+				Rate:  rate,
+				Value: strconv.Itoa(value),
+			})
+		}
+
+		cohorts = append(cohorts, cohort)
+	}
+
+	return cohorts, nil
+}
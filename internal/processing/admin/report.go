@@ -19,6 +19,7 @@ package admin
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
@@ -28,8 +29,10 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
 
 // ReportsGet returns all reports stored on this instance, with the given parameters.
@@ -125,6 +128,12 @@ func (p *Processor) ReportResolve(ctx context.Context, account *gtsmodel.Account
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	// Keep the current state for the audit log.
+	before, err := p.tc.ReportToAdminAPIReport(ctx, report, account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
 	columns := []string{
 		"action_taken_at",
 		"action_taken_by_account_id",
@@ -133,6 +142,14 @@ func (p *Processor) ReportResolve(ctx context.Context, account *gtsmodel.Account
 	report.ActionTakenAt = time.Now()
 	report.ActionTakenByAccountID = account.ID
 
+	if report.AssignedAccountID == "" {
+		// Whoever resolves an unassigned
+		// report takes it on themselves.
+		report.AssignedAccountID = account.ID
+		report.AssignedAccount = account
+		columns = append(columns, "assigned_account_id")
+	}
+
 	if actionTakenComment != nil {
 		report.ActionTaken = *actionTakenComment
 		columns = append(columns, "action_taken")
@@ -157,5 +174,282 @@ func (p *Processor) ReportResolve(ctx context.Context, account *gtsmodel.Account
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	p.Audit(ctx, account, gtsmodel.AuditLogActionResolve, gtsmodel.AuditLogTargetReport, report.ID, before, apimodelReport)
+
+	return apimodelReport, nil
+}
+
+// ReportReopen marks a resolved report with the given id as unresolved again,
+// clearing the action taken on it, so that it shows up as open to moderators.
+func (p *Processor) ReportReopen(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminReport, gtserror.WithCode) {
+	report, errWithCode := p.getReport(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	before, err := p.tc.ReportToAdminAPIReport(ctx, report, account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	report.ActionTaken = ""
+	report.ActionTakenAt = time.Time{}
+	report.ActionTakenByAccountID = ""
+	report.ActionTakenByAccount = nil
+
+	return p.updateReport(ctx, account, report, before, gtsmodel.AuditLogActionReopen,
+		"action_taken",
+		"action_taken_at",
+		"action_taken_by_account_id",
+	)
+}
+
+// ReportAssign assigns the report with the given id to the moderator
+// with the given account id, or to account if assigneeID is empty.
+func (p *Processor) ReportAssign(ctx context.Context, account *gtsmodel.Account, id string, assigneeID string) (*apimodel.AdminReport, gtserror.WithCode) {
+	report, errWithCode := p.getReport(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	assignee := account
+	if assigneeID != "" && assigneeID != account.ID {
+		assignee, errWithCode = p.getModeratorAccount(ctx, assigneeID)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	before, err := p.tc.ReportToAdminAPIReport(ctx, report, account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	report.AssignedAccountID = assignee.ID
+	report.AssignedAccount = assignee
+
+	return p.updateReport(ctx, account, report, before, gtsmodel.AuditLogActionAssign, "assigned_account_id")
+}
+
+// ReportUnassign removes the assigned moderator from the report with the given id.
+func (p *Processor) ReportUnassign(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminReport, gtserror.WithCode) {
+	report, errWithCode := p.getReport(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	before, err := p.tc.ReportToAdminAPIReport(ctx, report, account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	report.AssignedAccountID = ""
+	report.AssignedAccount = nil
+
+	return p.updateReport(ctx, account, report, before, gtsmodel.AuditLogActionUnassign, "assigned_account_id")
+}
+
+// ReportNotesGet returns all notes left on the report with the given id, oldest first.
+func (p *Processor) ReportNotesGet(ctx context.Context, id string) ([]*apimodel.AdminReportNote, gtserror.WithCode) {
+	if _, errWithCode := p.getReport(ctx, id); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	notes, err := p.state.DB.GetReportNotes(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiNotes := make([]*apimodel.AdminReportNote, 0, len(notes))
+	for _, note := range notes {
+		apiNote, err := p.tc.ReportNoteToAdminAPIReportNote(ctx, note)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiNotes = append(apiNotes, apiNote)
+	}
+
+	return apiNotes, nil
+}
+
+// ReportNoteCreate leaves a note from account on the report with the given reportID.
+// Other moderators of the instance will be notified of the new note.
+func (p *Processor) ReportNoteCreate(ctx context.Context, account *gtsmodel.Account, reportID string, form *apimodel.AdminReportNoteCreateRequest) (*apimodel.AdminReportNote, gtserror.WithCode) {
+	report, errWithCode := p.getReport(ctx, reportID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := validate.ReportNote(form.Content); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if form.InReplyToID != "" {
+		inReplyTo, err := p.state.DB.GetReportNoteByID(ctx, form.InReplyToID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if inReplyTo == nil || inReplyTo.ReportID != report.ID {
+			err := fmt.Errorf("in_reply_to_id %s is not a note on report %s", form.InReplyToID, report.ID)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+	}
+
+	note := &gtsmodel.ReportNote{
+		ID:          id.NewULID(),
+		ReportID:    report.ID,
+		Report:      report,
+		AccountID:   account.ID,
+		Account:     account,
+		InReplyToID: form.InReplyToID,
+		Content:     form.Content,
+	}
+
+	if err := p.state.DB.PutReportNote(ctx, note); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Process side effects of the new note.
+	p.state.Workers.EnqueueClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ActivityFlag,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       note,
+		OriginAccount:  account,
+	})
+
+	apiNote, err := p.tc.ReportNoteToAdminAPIReportNote(ctx, note)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.Audit(ctx, account, gtsmodel.AuditLogActionNote, gtsmodel.AuditLogTargetReport, report.ID, nil, apiNote)
+
+	return apiNote, nil
+}
+
+// ReportNoteDelete deletes the note with the given noteID from the report with
+// the given id. Notes can only be deleted by the account that created them.
+func (p *Processor) ReportNoteDelete(ctx context.Context, account *gtsmodel.Account, id string, noteID string) (*apimodel.AdminReportNote, gtserror.WithCode) {
+	report, errWithCode := p.getReport(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	note, err := p.state.DB.GetReportNoteByID(ctx, noteID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if note == nil || note.ReportID != report.ID {
+		err := fmt.Errorf("note %s not found on report %s", noteID, report.ID)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	if note.AccountID != account.ID {
+		err := fmt.Errorf("note %s was not created by account %s", noteID, account.ID)
+		return nil, gtserror.NewErrorForbidden(err, "notes can only be deleted by the account that created them")
+	}
+
+	apiNote, err := p.tc.ReportNoteToAdminAPIReportNote(ctx, note)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.DeleteReportNoteByID(ctx, note.ID); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.Audit(ctx, account, gtsmodel.AuditLogActionDeleteNote, gtsmodel.AuditLogTargetReport, report.ID, apiNote, nil)
+
+	return apiNote, nil
+}
+
+// ReportHistoryGet returns the activity history of the report with the
+// given id, as recorded in the moderation audit log, newest first.
+func (p *Processor) ReportHistoryGet(
+	ctx context.Context,
+	id string,
+	maxID string,
+	sinceID string,
+	minID string,
+	limit int,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	if _, errWithCode := p.getReport(ctx, id); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.auditLogGet(
+		ctx,
+		"/api/v1/admin/reports/"+id+"/history",
+		nil,
+		"",
+		"",
+		string(gtsmodel.AuditLogTargetReport),
+		id,
+		maxID,
+		sinceID,
+		minID,
+		limit,
+	)
+}
+
+// getReport gets the report with the given ID,
+// returning not found if it doesn't exist.
+func (p *Processor) getReport(ctx context.Context, id string) (*gtsmodel.Report, gtserror.WithCode) {
+	report, err := p.state.DB.GetReportByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return report, nil
+}
+
+// getModeratorAccount gets the local account with the given ID, returning
+// bad request if it doesn't exist or doesn't belong to an admin or moderator.
+func (p *Processor) getModeratorAccount(ctx context.Context, accountID string) (*gtsmodel.Account, gtserror.WithCode) {
+	user, err := p.state.DB.GetUserByAccountID(ctx, accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if user == nil || !(*user.Moderator || *user.Admin) {
+		err := fmt.Errorf("account %s is not a moderator", accountID)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	account, err := p.state.DB.GetAccountByID(ctx, accountID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return account, nil
+}
+
+// updateReport updates the given columns of the given report, and records the
+// update in the audit log as the given action, with before as the previous state.
+func (p *Processor) updateReport(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	report *gtsmodel.Report,
+	before *apimodel.AdminReport,
+	action string,
+	columns ...string,
+) (*apimodel.AdminReport, gtserror.WithCode) {
+	updatedReport, err := p.state.DB.UpdateReport(ctx, report, columns...)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apimodelReport, err := p.tc.ReportToAdminAPIReport(ctx, updatedReport, account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.Audit(ctx, account, action, gtsmodel.AuditLogTargetReport, report.ID, before, apimodelReport)
+
 	return apimodelReport, nil
 }
@@ -0,0 +1,207 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// RulesGet returns all active rules of this instance, in order.
+func (p *Processor) RulesGet(ctx context.Context) ([]*apimodel.AdminInstanceRule, gtserror.WithCode) {
+	rules, err := p.state.DB.GetActiveRules(ctx)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiRules := make([]*apimodel.AdminInstanceRule, 0, len(rules))
+	for _, rule := range rules {
+		apiRules = append(apiRules, p.tc.RuleToAdminAPIRule(rule))
+	}
+
+	return apiRules, nil
+}
+
+// RuleGet returns one active rule, with the given ID.
+func (p *Processor) RuleGet(ctx context.Context, id string) (*apimodel.AdminInstanceRule, gtserror.WithCode) {
+	rule, errWithCode := p.getActiveRule(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.tc.RuleToAdminAPIRule(rule), nil
+}
+
+// RuleCreate creates a new rule with the given text, after any existing rules.
+func (p *Processor) RuleCreate(ctx context.Context, account *gtsmodel.Account, form *apimodel.InstanceRuleCreateRequest) (*apimodel.AdminInstanceRule, gtserror.WithCode) {
+	if err := validate.InstanceRule(form.Text); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	rules, err := p.state.DB.GetActiveRules(ctx)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// New rules go at the end.
+	order := 0
+	if len(rules) > 0 {
+		order = rules[len(rules)-1].Order + 1
+	}
+
+	rule := &gtsmodel.Rule{
+		ID:    id.NewULID(),
+		Text:  form.Text,
+		Order: order,
+	}
+
+	if err := p.state.DB.PutRule(ctx, rule); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiRule := p.tc.RuleToAdminAPIRule(rule)
+	p.Audit(ctx, account, gtsmodel.AuditLogActionCreate, gtsmodel.AuditLogTargetRule, rule.ID, nil, apiRule)
+
+	return apiRule, nil
+}
+
+// RuleUpdate updates the text and / or position of the rule with the given ID.
+// When a rule is moved, the other rules are renumbered to make room for it.
+func (p *Processor) RuleUpdate(ctx context.Context, account *gtsmodel.Account, id string, form *apimodel.InstanceRuleUpdateRequest) (*apimodel.AdminInstanceRule, gtserror.WithCode) {
+	rule, errWithCode := p.getActiveRule(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	before := p.tc.RuleToAdminAPIRule(rule)
+
+	if form.Text != nil {
+		if err := validate.InstanceRule(*form.Text); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		rule.Text = *form.Text
+		if err := p.state.DB.UpdateRule(ctx, rule, "text"); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	if form.Order != nil {
+		if errWithCode := p.moveRule(ctx, rule, *form.Order); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	apiRule := p.tc.RuleToAdminAPIRule(rule)
+	p.Audit(ctx, account, gtsmodel.AuditLogActionUpdate, gtsmodel.AuditLogTargetRule, rule.ID, before, apiRule)
+
+	return apiRule, nil
+}
+
+// RuleDelete deletes the rule with the given ID. The rule is
+// kept in the database so that reports citing it can still
+// show it, but it's no longer shown as a rule of the instance.
+func (p *Processor) RuleDelete(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminInstanceRule, gtserror.WithCode) {
+	rule, errWithCode := p.getActiveRule(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiRule := p.tc.RuleToAdminAPIRule(rule)
+
+	deleted := true
+	rule.Deleted = &deleted
+	if err := p.state.DB.UpdateRule(ctx, rule, "deleted"); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.Audit(ctx, account, gtsmodel.AuditLogActionDelete, gtsmodel.AuditLogTargetRule, rule.ID, apiRule, nil)
+
+	return apiRule, nil
+}
+
+// getActiveRule gets the rule with the given ID,
+// returning not found if it doesn't exist or has
+// been deleted.
+func (p *Processor) getActiveRule(ctx context.Context, id string) (*gtsmodel.Rule, gtserror.WithCode) {
+	rule, err := p.state.DB.GetRuleByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("rule %s not found", id)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if *rule.Deleted {
+		err := fmt.Errorf("rule %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	return rule, nil
+}
+
+// moveRule moves the given rule to the given index in the
+// list of active rules, renumbering all active rules so that
+// their order is contiguous from 0.
+func (p *Processor) moveRule(ctx context.Context, rule *gtsmodel.Rule, index int) gtserror.WithCode {
+	rules, err := p.state.DB.GetActiveRules(ctx)
+	if err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if index < 0 || index >= len(rules) {
+		err := fmt.Errorf("rule order must be between 0 and %d", len(rules)-1)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Take the rule out of the list...
+	others := make([]*gtsmodel.Rule, 0, len(rules))
+	for _, r := range rules {
+		if r.ID != rule.ID {
+			others = append(others, r)
+		}
+	}
+
+	// ...and put it back in at the new index.
+	ordered := make([]*gtsmodel.Rule, 0, len(rules))
+	ordered = append(ordered, others[:index]...)
+	ordered = append(ordered, rule)
+	ordered = append(ordered, others[index:]...)
+
+	for i, r := range ordered {
+		if r.Order == i {
+			continue
+		}
+
+		r.Order = i
+		if err := p.state.DB.UpdateRule(ctx, r, "order"); err != nil {
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return nil
+}
@@ -19,6 +19,7 @@ package processing
 
 import (
 	"context"
+	"strings"
 
 	"github.com/google/uuid"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
@@ -29,13 +30,18 @@ import (
 )
 
 func (p *Processor) AppCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.ApplicationCreateRequest) (*apimodel.Application, gtserror.WithCode) {
-	// set default 'read' for scopes if it's not set
-	var scopes string
-	if form.Scopes == "" {
-		scopes = "read"
-	} else {
-		scopes = form.Scopes
+	// parse + normalize scopes, using
+	// default 'read' if they're not set
+	parsed, err := oauth.ParseScopes(form.Scopes)
+	if err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	scopeStrs := make([]string, 0, len(parsed))
+	for _, scope := range parsed {
+		scopeStrs = append(scopeStrs, string(scope))
 	}
+	scopes := strings.Join(scopeStrs, " ")
 
 	// generate new IDs for this application and its associated client
 	clientID, err := id.NewRandomULID()
@@ -28,6 +28,7 @@ import (
 	"github.com/superseriousbusiness/activity/pub"
 	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -75,6 +76,9 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, clientMsg messages
 		case ap.ActivityBlock:
 			// CREATE BLOCK
 			return p.processCreateBlockFromClientAPI(ctx, clientMsg)
+		case ap.ActivityFlag:
+			// CREATE NOTE ON A FLAG/REPORT
+			return p.processCreateReportNoteFromClientAPI(ctx, clientMsg)
 		}
 	case ap.ActivityUpdate:
 		// UPDATE
@@ -430,6 +434,45 @@ func (p *Processor) processReportAccountFromClientAPI(ctx context.Context, clien
 	return nil
 }
 
+func (p *Processor) processCreateReportNoteFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
+	note, ok := clientMsg.GTSModel.(*gtsmodel.ReportNote)
+	if !ok {
+		return errors.New("note was not parseable as *gtsmodel.ReportNote")
+	}
+
+	moderators, err := p.state.DB.GetInstanceModerators(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// No moderators to notify.
+			return nil
+		}
+		return fmt.Errorf("processCreateReportNoteFromClientAPI: error getting instance moderators: %w", err)
+	}
+
+	// Only notify moderators
+	// other than the note author.
+	others := make([]*gtsmodel.User, 0, len(moderators))
+	for _, user := range moderators {
+		if user.AccountID != note.AccountID {
+			others = append(others, user)
+		}
+	}
+
+	if len(others) == 0 {
+		return nil
+	}
+
+	if err := p.notifyReportNote(ctx, note, others); err != nil {
+		return fmt.Errorf("processCreateReportNoteFromClientAPI: error notifying report note: %w", err)
+	}
+
+	if err := p.emailReportNote(ctx, note, others); err != nil {
+		return fmt.Errorf("processCreateReportNoteFromClientAPI: error emailing report note: %w", err)
+	}
+
+	return nil
+}
+
 // TODO: move all the below functions into federation.Federator
 
 func (p *Processor) federateAccountDelete(ctx context.Context, account *gtsmodel.Account) error {
@@ -509,7 +552,7 @@ func (p *Processor) federateStatus(ctx context.Context, status *gtsmodel.Status)
 		return fmt.Errorf("federateStatus: error converting status to as format: %s", err)
 	}
 
-	create, err := p.tc.WrapNoteInCreate(asStatus, false)
+	create, err := p.tc.WrapStatusableInCreate(asStatus, false)
 	if err != nil {
 		return fmt.Errorf("federateStatus: error wrapping status in create: %s", err)
 	}
@@ -327,6 +327,22 @@ func (p *Processor) notify(
 		return nil
 	}
 
+	// Don't notify of anything from an account
+	// the target has muted notifications from.
+	mute, err := p.state.DB.GetMute(
+		gtscontext.SetBarebones(ctx),
+		targetAccountID,
+		originAccountID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return fmt.Errorf("notify: error checking mute: %w", err)
+	}
+
+	if mute != nil && *mute.Notifications {
+		// Notifications muted.
+		return nil
+	}
+
 	// Make sure a notification doesn't
 	// already exist with these params.
 	if _, err := p.state.DB.GetNotification(
@@ -367,9 +383,59 @@ func (p *Processor) notify(
 		return fmt.Errorf("notify: error streaming notification to account: %w", err)
 	}
 
+	// Email the notification too, if the user wants to be
+	// emailed immediately. The notification has already been
+	// stored + streamed by now, so an email failure (eg., SMTP
+	// being down) is logged rather than failing the notify.
+	if err := p.user.EmailNotification(ctx, notif); err != nil {
+		log.Errorf(ctx, "error emailing notification %s to account %s: %v", notif.ID, targetAccountID, err)
+	}
+
 	return nil
 }
 
+// notifyReportNote notifies the given moderators of a new note left on a report.
+// Unlike other notifications, each note results in a new notification, since a
+// moderator can leave many notes on the same report.
+func (p *Processor) notifyReportNote(ctx context.Context, note *gtsmodel.ReportNote, moderators []*gtsmodel.User) error {
+	errs := make(gtserror.MultiError, 0, len(moderators))
+
+	for _, user := range moderators {
+		targetAccount, err := p.state.DB.GetAccountByID(ctx, user.AccountID)
+		if err != nil {
+			errs.Appendf("error getting moderator account %s: %v", user.AccountID, err)
+			continue
+		}
+
+		notif := &gtsmodel.Notification{
+			ID:               id.NewULID(),
+			NotificationType: gtsmodel.NotificationReportNote,
+			TargetAccountID:  targetAccount.ID,
+			TargetAccount:    targetAccount,
+			OriginAccountID:  note.AccountID,
+			ReportID:         note.ReportID,
+		}
+
+		if err := p.state.DB.PutNotification(ctx, notif); err != nil {
+			errs.Appendf("error putting notification in database: %v", err)
+			continue
+		}
+
+		// Stream notification to the moderator.
+		apiNotif, err := p.tc.NotificationToAPINotification(ctx, notif)
+		if err != nil {
+			errs.Appendf("error converting notification to api representation: %v", err)
+			continue
+		}
+
+		if err := p.stream.Notify(apiNotif, targetAccount); err != nil {
+			errs.Appendf("error streaming notification to account: %v", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
 // wipeStatus contains common logic used to totally delete a status
 // + all its attachments, notifications, boosts, and timeline entries.
 func (p *Processor) wipeStatus(ctx context.Context, statusToDelete *gtsmodel.Status, deleteAttachments bool) error {
@@ -526,7 +592,7 @@ func (p *Processor) emailReportClosed(ctx context.Context, report *gtsmodel.Repo
 		return fmt.Errorf("emailReportClosed: db error getting user: %w", err)
 	}
 
-	if user.ConfirmedAt.IsZero() || !*user.Approved || *user.Disabled || user.Email == "" {
+	if !user.CanBeEmailed() {
 		// Only email users who:
 		// - are confirmed
 		// - are approved
@@ -535,6 +601,12 @@ func (p *Processor) emailReportClosed(ctx context.Context, report *gtsmodel.Repo
 		return nil
 	}
 
+	if user.EmailPreference(gtsmodel.EmailTypeReportsClosed) != gtsmodel.EmailPreferenceImmediate {
+		// User has turned these emails off,
+		// or will get them in their digest.
+		return nil
+	}
+
 	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
 	if err != nil {
 		return fmt.Errorf("emailReportClosed: db error getting instance: %w", err)
@@ -554,6 +626,23 @@ func (p *Processor) emailReportClosed(ctx context.Context, report *gtsmodel.Repo
 		}
 	}
 
+	if len(report.RuleIDs) != 0 && len(report.Rules) == 0 {
+		report.Rules, err = p.state.DB.GetRulesByIDs(ctx, report.RuleIDs)
+		if err != nil {
+			return fmt.Errorf("emailReportClosed: error getting report rules: %w", err)
+		}
+	}
+
+	rules := make([]string, 0, len(report.Rules))
+	for _, rule := range report.Rules {
+		rules = append(rules, rule.Text)
+	}
+
+	unsubscribeURL, err := p.user.EmailUnsubscribeURL(ctx, user, string(gtsmodel.EmailTypeReportsClosed))
+	if err != nil {
+		return fmt.Errorf("emailReportClosed: %w", err)
+	}
+
 	reportClosedData := email.ReportClosedData{
 		Username:             report.Account.Username,
 		InstanceURL:          instance.URI,
@@ -561,7 +650,49 @@ func (p *Processor) emailReportClosed(ctx context.Context, report *gtsmodel.Repo
 		ReportTargetUsername: report.TargetAccount.Username,
 		ReportTargetDomain:   report.TargetAccount.Domain,
 		ActionTakenComment:   report.ActionTaken,
+		ReportRules:          rules,
+		UnsubscribeURL:       unsubscribeURL,
 	}
 
 	return p.emailSender.SendReportClosedEmail(user.Email, reportClosedData)
 }
+
+func (p *Processor) emailReportNote(ctx context.Context, note *gtsmodel.ReportNote, moderators []*gtsmodel.User) error {
+	toAddresses := make([]string, 0, len(moderators))
+	for _, user := range moderators {
+		if user.Email != "" {
+			toAddresses = append(toAddresses, user.Email)
+		}
+	}
+
+	if len(toAddresses) == 0 {
+		// No registered moderator addresses.
+		return nil
+	}
+
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return fmt.Errorf("emailReportNote: error getting instance: %w", err)
+	}
+
+	if note.Account == nil {
+		note.Account, err = p.state.DB.GetAccountByID(ctx, note.AccountID)
+		if err != nil {
+			return fmt.Errorf("emailReportNote: error getting note account: %w", err)
+		}
+	}
+
+	reportNoteData := email.ReportNoteData{
+		InstanceURL:        instance.URI,
+		InstanceName:       instance.Title,
+		ReportURL:          instance.URI + "/settings/admin/reports/" + note.ReportID,
+		NoteAuthorUsername: note.Account.Username,
+		NoteContent:        note.Content,
+	}
+
+	if err := p.emailSender.SendReportNoteEmail(toAddresses, reportNoteData); err != nil {
+		return fmt.Errorf("emailReportNote: error emailing instance moderators: %w", err)
+	}
+
+	return nil
+}
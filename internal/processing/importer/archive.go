@@ -0,0 +1,681 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package importer
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"codeberg.org/gruf/go-bytesize"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+// errStopWalk is returned from a walk
+// function to stop walking an archive.
+var errStopWalk = errors.New("stop walk")
+
+// importArchive is an uploaded archive of an account on
+// another instance, as exported by Mastodon (a gzipped tar)
+// or GoToSocial (a zip), which is stored in a temporary file
+// while it's imported.
+type importArchive struct {
+	file   *os.File
+	size   int64
+	zipped bool
+}
+
+// newImportArchive copies the given uploaded archive to
+// a temporary file, checking that it's a zip or gzipped tar.
+func newImportArchive(fh *multipart.FileHeader) (*importArchive, gtserror.WithCode) {
+	maxSize := int64(config.GetAccountsImportMaxSize())
+	if fh.Size > maxSize {
+		err := fmt.Errorf("archive size %s exceeds the maximum of %s", bytesize.Size(fh.Size), bytesize.Size(maxSize))
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		err = gtserror.Newf("error opening archive: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	defer f.Close()
+
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(f, magic); err != nil {
+		const text = "archive is not a zip or tar.gz file"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	a := &importArchive{size: fh.Size}
+	switch {
+	case bytes.Equal(magic, []byte("PK\x03\x04")):
+		a.zipped = true
+	case bytes.Equal(magic[:2], []byte{0x1f, 0x8b}):
+		a.zipped = false
+	default:
+		const text = "archive is not a zip or tar.gz file"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		err = gtserror.Newf("error seeking archive: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// The upload is removed once the request is
+	// done, so it has to be copied to be imported
+	// in the background.
+	a.file, err = os.CreateTemp("", "gotosocial-import-*")
+	if err != nil {
+		err = gtserror.Newf("error creating temporary file: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if _, err := io.Copy(a.file, f); err != nil {
+		a.close()
+		err = gtserror.Newf("error copying archive: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return a, nil
+}
+
+// close closes and removes the temporary file.
+func (a *importArchive) close() {
+	a.file.Close()
+	if err := os.Remove(a.file.Name()); err != nil {
+		log.Errorf(nil, "error removing %s: %v", a.file.Name(), err)
+	}
+}
+
+// walk calls fn for each regular file in the archive, with
+// its name and contents, in the order they're stored in.
+// Walking stops at the first error; errStopWalk just stops.
+func (a *importArchive) walk(fn func(name string, r io.Reader, size int64) error) error {
+	err := a.walkFiles(fn)
+	if errors.Is(err, errStopWalk) {
+		return nil
+	}
+	return err
+}
+
+func (a *importArchive) walkFiles(fn func(name string, r io.Reader, size int64) error) error {
+	if a.zipped {
+		zr, err := zip.NewReader(a.file, a.size)
+		if err != nil {
+			return fmt.Errorf("error reading zip: %w", err)
+		}
+
+		for _, f := range zr.File {
+			if !f.Mode().IsRegular() {
+				continue
+			}
+
+			rc, err := f.Open()
+			if err != nil {
+				return fmt.Errorf("error opening %s: %w", f.Name, err)
+			}
+
+			err = fn(f.Name, rc, int64(f.UncompressedSize64))
+			rc.Close()
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if _, err := a.file.Seek(0, io.SeekStart); err != nil {
+		return gtserror.Newf("error seeking archive: %w", err)
+	}
+
+	gr, err := gzip.NewReader(a.file)
+	if err != nil {
+		return fmt.Errorf("error reading gzip: %w", err)
+	}
+	defer gr.Close()
+
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("error reading tar: %w", err)
+		}
+
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if err := fn(hdr.Name, tr, hdr.Size); err != nil {
+			return err
+		}
+	}
+}
+
+// oneOrMany unmarshals JSON which may be either
+// one value or an array of them, as is common in
+// ActivityStreams, into a slice.
+type oneOrMany[T any] []T
+
+func (o *oneOrMany[T]) UnmarshalJSON(b []byte) error {
+	if len(b) != 0 && b[0] == '[' {
+		return json.Unmarshal(b, (*[]T)(o))
+	}
+
+	if string(b) == "null" {
+		*o = nil
+		return nil
+	}
+
+	var one T
+	if err := json.Unmarshal(b, &one); err != nil {
+		return err
+	}
+	*o = oneOrMany[T]{one}
+	return nil
+}
+
+// archiveOutbox is the outbox.json of an archive,
+// containing only what's used to import statuses.
+type archiveOutbox struct {
+	OrderedItems []struct {
+		Type   string          `json:"type"`
+		Object json.RawMessage `json:"object"`
+	} `json:"orderedItems"`
+}
+
+// archiveNote is the object of a Create activity in an
+// outbox, containing only what's used to import statuses.
+type archiveNote struct {
+	ID         string                       `json:"id"`
+	Type       string                       `json:"type"`
+	Published  time.Time                    `json:"published"`
+	InReplyTo  string                       `json:"inReplyTo"`
+	Summary    string                       `json:"summary"`
+	Content    string                       `json:"content"`
+	ContentMap map[string]string            `json:"contentMap"`
+	Sensitive  bool                         `json:"sensitive"`
+	To         oneOrMany[string]            `json:"to"`
+	Cc         oneOrMany[string]            `json:"cc"`
+	Tag        oneOrMany[archiveTag]        `json:"tag"`
+	Attachment oneOrMany[archiveAttachment] `json:"attachment"`
+
+	// Set while importing: why it won't be imported, or
+	// the status it was imported as by a previous import.
+	skip     string
+	existing *gtsmodel.Status
+}
+
+type archiveTag struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type archiveAttachment struct {
+	URL  string `json:"url"`
+	Name string `json:"name"`
+
+	// Set while importing: the uploaded attachment.
+	uploaded *gtsmodel.MediaAttachment
+}
+
+// path returns the path of the attachment's file in the archive,
+// relative to the directory containing outbox.json.
+func (a *archiveAttachment) path() string {
+	p := a.URL
+	if u, err := url.Parse(a.URL); err == nil {
+		p = u.Path
+	}
+	return strings.TrimPrefix(path.Clean("/"+p), "/")
+}
+
+// visibility returns the visibility of the note based on
+// who it's addressed to, as Mastodon and GoToSocial do.
+func (n *archiveNote) visibility() gtsmodel.Visibility {
+	isPublic := func(iris []string) bool {
+		for _, iri := range iris {
+			switch iri {
+			case "https://www.w3.org/ns/activitystreams#Public", "as:Public", "Public":
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case isPublic(n.To):
+		return gtsmodel.VisibilityPublic
+	case isPublic(n.Cc):
+		return gtsmodel.VisibilityUnlocked
+	}
+
+	for _, iri := range n.To {
+		if strings.HasSuffix(iri, "/followers") {
+			return gtsmodel.VisibilityFollowersOnly
+		}
+	}
+
+	return gtsmodel.VisibilityDirect
+}
+
+// language returns the language of the note,
+// if it's given as the only key of contentMap.
+func (n *archiveNote) language() string {
+	if len(n.ContentMap) != 1 {
+		return ""
+	}
+	for lang := range n.ContentMap {
+		return lang
+	}
+	return ""
+}
+
+// importArchive recreates the public, unlisted and followers-only
+// statuses in the archive's outbox as local statuses of the account,
+// with their original published dates, uploading their media. Replies
+// to other statuses in the archive are threaded. Statuses imported
+// by a previous import of the same archive are skipped.
+func (i *importer) importArchive(ctx context.Context) error {
+	notes, dir, err := i.archiveNotes(ctx)
+	if err != nil {
+		return err
+	}
+
+	i.imp.Total = len(notes)
+	if err := i.state.DB.UpdateImport(ctx, i.imp, "total"); err != nil {
+		return gtserror.Newf("db error updating import: %w", err)
+	}
+
+	// Uploaded media is only attached to statuses
+	// once they're all created, so a failure before
+	// then leaves it to be cleaned up as unattached.
+	if err := i.archiveMedia(ctx, notes, dir); err != nil {
+		return err
+	}
+
+	// Imported statuses by their URI in the archive,
+	// to thread replies to them. Notes are sorted by
+	// published date, so replies come after parents.
+	imported := make(map[string]*gtsmodel.Status, len(notes))
+
+	for _, note := range notes {
+		if note.skip != "" {
+			i.done(ctx, note.ID, note.skip)
+			continue
+		}
+
+		if note.existing != nil {
+			imported[note.ID] = note.existing
+			i.done(ctx, note.ID, "")
+			continue
+		}
+
+		status, err := i.archiveStatus(ctx, note, imported)
+		if err != nil {
+			return err
+		}
+		imported[note.ID] = status
+
+		if i.federate {
+			i.state.Workers.EnqueueClientAPI(ctx, messages.FromClientAPI{
+				APObjectType:   ap.ObjectNote,
+				APActivityType: ap.ActivityCreate,
+				GTSModel:       status,
+				OriginAccount:  i.owner,
+			})
+		}
+
+		i.done(ctx, note.ID, "")
+	}
+
+	return nil
+}
+
+// archiveNotes reads the notes of Create activities from the archive's
+// outbox, sorted by published date, noting which won't be imported and
+// which have been already. It also returns the directory containing the
+// outbox, which media paths are relative to.
+func (i *importer) archiveNotes(ctx context.Context) ([]*archiveNote, string, error) {
+	var (
+		outbox  archiveOutbox
+		dir     string
+		found   bool
+		maxSize = int64(config.GetAccountsImportMaxOutboxSize())
+	)
+
+	if err := i.archive.walk(func(name string, r io.Reader, size int64) error {
+		if path.Base(name) != "outbox.json" {
+			return nil
+		}
+
+		// The outbox is decoded in memory, so refuse
+		// any larger than allowed, whether the archive
+		// says how large it is or not.
+		tooLarge := fmt.Errorf("outbox.json exceeds the maximum size of %s", bytesize.Size(maxSize))
+		if size > maxSize {
+			return tooLarge
+		}
+
+		lr := &io.LimitedReader{R: r, N: maxSize + 1}
+		err := json.NewDecoder(lr).Decode(&outbox)
+		if lr.N == 0 {
+			return tooLarge
+		}
+
+		if err != nil {
+			return fmt.Errorf("error parsing outbox.json: %w", err)
+		}
+
+		dir, found = path.Dir(name), true
+		return errStopWalk
+	}); err != nil {
+		return nil, "", err
+	}
+
+	if !found {
+		return nil, "", errors.New("archive doesn't contain an outbox.json")
+	}
+
+	notes := make([]*archiveNote, 0, len(outbox.OrderedItems))
+	for n, item := range outbox.OrderedItems {
+		// Boosts etc aren't imported.
+		if item.Type != "Create" {
+			continue
+		}
+
+		note := &archiveNote{}
+		if err := json.Unmarshal(item.Object, note); err != nil || note.ID == "" {
+			note.ID = fmt.Sprintf("item %d", n+1)
+			note.skip = "couldn't parse post"
+		}
+		notes = append(notes, note)
+	}
+
+	sort.SliceStable(notes, func(a, b int) bool {
+		return notes[a].Published.Before(notes[b].Published)
+	})
+
+	for _, note := range notes {
+		if note.skip != "" {
+			continue
+		}
+
+		switch {
+		case note.Type != ap.ObjectNote:
+			note.skip = "only notes can be imported, not " + note.Type
+			continue
+		case note.Published.IsZero() || note.Published.After(time.Now()):
+			note.skip = "invalid published date"
+			continue
+		case note.visibility() == gtsmodel.VisibilityDirect:
+			note.skip = "direct messages aren't imported"
+			continue
+		}
+
+		existing, err := i.archiveExisting(ctx, note.Published)
+		if err != nil {
+			return nil, "", err
+		}
+		note.existing = existing
+	}
+
+	return notes, dir, nil
+}
+
+// archiveExisting returns the status of the account
+// posted at the given time, if it's already been imported.
+func (i *importer) archiveExisting(ctx context.Context, published time.Time) (*gtsmodel.Status, error) {
+	statusID, err := id.NewULIDFromTime(published)
+	if err != nil {
+		return nil, gtserror.Newf("error generating id: %w", err)
+	}
+
+	// The first 10 characters of a ULID are its timestamp,
+	// so this selects statuses with the same timestamp.
+	var (
+		minID = statusID[:10] + strings.Repeat("0", 16)
+		maxID = statusID[:10] + strings.Repeat("Z", 16)
+	)
+
+	statuses, err := i.state.DB.GetAccountStatuses(ctx, i.owner.ID, 1, false, true, maxID, minID, false, false)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting statuses: %w", err)
+	}
+
+	if len(statuses) == 0 {
+		return nil, nil
+	}
+
+	return statuses[0], nil
+}
+
+// archiveMedia uploads the media attached to the notes
+// which will be imported, as it's found in the archive.
+func (i *importer) archiveMedia(ctx context.Context, notes []*archiveNote, dir string) error {
+	wanted := make(map[string]*archiveAttachment)
+	for _, note := range notes {
+		if note.skip != "" || note.existing != nil {
+			continue
+		}
+
+		for n := range note.Attachment {
+			attachment := &note.Attachment[n]
+			wanted[path.Join(dir, attachment.path())] = attachment
+		}
+	}
+
+	if len(wanted) == 0 {
+		return nil
+	}
+
+	user, err := i.state.DB.GetUserByAccountID(ctx, i.owner.ID)
+	if err != nil {
+		return gtserror.Newf("db error getting user: %w", err)
+	}
+	quota := media.AccountQuota(user)
+
+	if err := i.archive.walk(func(name string, r io.Reader, size int64) error {
+		attachment := wanted[path.Clean(name)]
+		if attachment == nil {
+			return nil
+		}
+		delete(wanted, path.Clean(name))
+
+		if quota != 0 {
+			usage, err := i.state.DB.GetAccountMediaUsage(ctx, i.owner.ID)
+			if err != nil {
+				return gtserror.Newf("db error getting media usage: %w", err)
+			}
+
+			if usage+size > quota {
+				i.fail(attachment.URL, "media storage quota exceeded")
+				return nil
+			}
+		}
+
+		description := text.SanitizePlaintext(attachment.Name)
+		processing, err := i.mediaManager.PreProcessMedia(ctx, func(context.Context) (io.ReadCloser, int64, error) {
+			return io.NopCloser(r), size, nil
+		}, i.owner.ID, &media.AdditionalMediaInfo{
+			Description: &description,
+		})
+		if err != nil {
+			return gtserror.Newf("error processing media: %w", err)
+		}
+
+		attachment.uploaded, err = processing.LoadAttachment(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			log.Debugf(ctx, "error loading media %s: %v", name, err)
+			i.fail(attachment.URL, "couldn't process media")
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, attachment := range wanted {
+		i.fail(attachment.URL, "media not found in archive")
+	}
+
+	return nil
+}
+
+// archiveStatus creates a local status of the account
+// from the given note, with a ULID from its published
+// date so that it sorts among the account's statuses
+// as it was originally posted.
+func (i *importer) archiveStatus(ctx context.Context, note *archiveNote, imported map[string]*gtsmodel.Status) (*gtsmodel.Status, error) {
+	statusID, err := id.NewULIDFromTime(note.Published)
+	if err != nil {
+		return nil, gtserror.Newf("error generating id: %w", err)
+	}
+
+	// Statuses which aren't sent out to followers are
+	// local-only, so they can't be fetched piecemeal
+	// by remote instances either.
+	var (
+		accountURIs = uris.GenerateURIsForAccount(i.owner.Username)
+		visibility  = note.visibility()
+		local       = true
+		federated   = i.federate
+		boostable   = visibility != gtsmodel.VisibilityFollowersOnly
+		replyable   = true
+		likeable    = true
+	)
+
+	status := &gtsmodel.Status{
+		ID:                  statusID,
+		URI:                 accountURIs.StatusesURI + "/" + statusID,
+		URL:                 accountURIs.StatusesURL + "/" + statusID,
+		CreatedAt:           note.Published,
+		UpdatedAt:           note.Published,
+		Local:               &local,
+		AccountID:           i.owner.ID,
+		AccountURI:          i.owner.URI,
+		Content:             text.SanitizeHTML(note.Content),
+		ContentWarning:      text.SanitizePlaintext(note.Summary),
+		Visibility:          visibility,
+		Sensitive:           &note.Sensitive,
+		Language:            note.language(),
+		ActivityStreamsType: ap.ObjectNote,
+		Federated:           &federated,
+		Boostable:           &boostable,
+		Replyable:           &replyable,
+		Likeable:            &likeable,
+	}
+
+	if status.Language == "" {
+		status.Language = i.owner.Language
+	}
+
+	// Thread replies to imported statuses; replies
+	// to others are imported as top-level statuses.
+	if parent := imported[note.InReplyTo]; parent != nil {
+		status.InReplyToID = parent.ID
+		status.InReplyToURI = parent.URI
+		status.InReplyToAccountID = parent.AccountID
+	}
+
+	for _, attachment := range note.Attachment {
+		if attachment.uploaded != nil {
+			status.AttachmentIDs = append(status.AttachmentIDs, attachment.uploaded.ID)
+			status.Attachments = append(status.Attachments, attachment.uploaded)
+		}
+	}
+
+	for _, t := range note.Tag {
+		if t.Type != "Hashtag" {
+			continue
+		}
+
+		tag, err := i.archiveTag(ctx, strings.TrimPrefix(t.Name, "#"))
+		if err != nil {
+			return nil, err
+		}
+
+		if tag != nil && !containsTag(status.Tags, tag.ID) {
+			status.TagIDs = append(status.TagIDs, tag.ID)
+			status.Tags = append(status.Tags, tag)
+		}
+	}
+
+	if err := i.state.DB.PutStatus(ctx, status); err != nil {
+		return nil, gtserror.Newf("db error putting status: %w", err)
+	}
+
+	return status, nil
+}
+
+// archiveTag returns the tag with the given name,
+// creating it if necessary, or nil if it's invalid.
+func (i *importer) archiveTag(ctx context.Context, name string) (*gtsmodel.Tag, error) {
+	if name == "" || strings.ContainsAny(name, " #") {
+		return nil, nil
+	}
+
+	tag, err := i.state.DB.TagStringToTag(ctx, name, i.owner.ID)
+	if err != nil {
+		return nil, gtserror.Newf("db error getting tag %s: %w", name, err)
+	}
+
+	if err := i.state.DB.Put(ctx, tag); err != nil && !errors.Is(err, db.ErrAlreadyExists) {
+		return nil, gtserror.Newf("db error putting tag %s: %w", name, err)
+	}
+
+	return tag, nil
+}
+
+func containsTag(tags []*gtsmodel.Tag, id string) bool {
+	for _, tag := range tags {
+		if tag.ID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,318 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package importer_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+const archiveOutbox = `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "outbox.json",
+  "type": "OrderedCollection",
+  "totalItems": 5,
+  "orderedItems": [
+    {
+      "id": "https://old.example.org/users/zork/statuses/2/activity",
+      "type": "Create",
+      "object": {
+        "id": "https://old.example.org/users/zork/statuses/2",
+        "type": "Note",
+        "published": "2020-01-02T10:00:00Z",
+        "inReplyTo": "https://old.example.org/users/zork/statuses/1",
+        "content": "<p>replying to myself</p><script>alert(1)</script>",
+        "to": ["https://old.example.org/users/zork/followers"],
+        "cc": [],
+        "attachment": {
+          "type": "Document",
+          "mediaType": "image/png",
+          "url": "/media_attachments/files/missing.png"
+        }
+      }
+    },
+    {
+      "id": "https://old.example.org/users/zork/statuses/1/activity",
+      "type": "Create",
+      "object": {
+        "id": "https://old.example.org/users/zork/statuses/1",
+        "type": "Note",
+        "published": "2020-01-01T10:00:00Z",
+        "inReplyTo": null,
+        "summary": "old post",
+        "content": "<p>hello from my old instance <a href=\"https://old.example.org/tags/oldposts\" class=\"mention hashtag\" rel=\"tag\">#<span>oldposts</span></a></p>",
+        "contentMap": {"de": "<p>hello from my old instance</p>"},
+        "sensitive": true,
+        "to": ["https://www.w3.org/ns/activitystreams#Public"],
+        "cc": ["https://old.example.org/users/zork/followers"],
+        "tag": [{"type": "Hashtag", "name": "#oldposts", "href": "https://old.example.org/tags/oldposts"}],
+        "attachment": [
+          {
+            "type": "Document",
+            "mediaType": "image/jpeg",
+            "url": "/media_attachments/files/ohyou.jpg",
+            "name": "oh you"
+          }
+        ]
+      }
+    },
+    {
+      "id": "https://old.example.org/users/zork/statuses/3/activity",
+      "type": "Create",
+      "object": {
+        "id": "https://old.example.org/users/zork/statuses/3",
+        "type": "Note",
+        "published": "2020-01-03T10:00:00Z",
+        "content": "<p>psst</p>",
+        "to": ["https://example.org/users/someone"]
+      }
+    },
+    {
+      "id": "https://old.example.org/users/zork/statuses/4/activity",
+      "type": "Create",
+      "object": {
+        "id": "https://old.example.org/users/zork/statuses/4",
+        "type": "Question",
+        "published": "2020-01-04T10:00:00Z",
+        "content": "<p>poll</p>",
+        "to": ["https://www.w3.org/ns/activitystreams#Public"]
+      }
+    },
+    {
+      "id": "https://old.example.org/users/zork/statuses/5/activity",
+      "type": "Announce",
+      "published": "2020-01-05T10:00:00Z",
+      "object": "https://example.org/users/someone/statuses/1"
+    }
+  ]
+}`
+
+// archiveFiles returns the files of a test archive.
+func (suite *ImportTestSuite) archiveFiles() map[string][]byte {
+	image, err := os.ReadFile("../../../testrig/media/ohyou-original.jpg")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return map[string][]byte{
+		"outbox.json":                       []byte(archiveOutbox),
+		"media_attachments/files/ohyou.jpg": image,
+	}
+}
+
+// zipArchive returns the test archive as a zip, as GoToSocial exports.
+func (suite *ImportTestSuite) zipArchive() string {
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	for name, b := range suite.archiveFiles() {
+		w, err := zw.Create(name)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		if _, err := w.Write(b); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+	if err := zw.Close(); err != nil {
+		suite.FailNow(err.Error())
+	}
+	return buf.String()
+}
+
+// tarArchive returns the test archive as a gzipped tar, as Mastodon exports.
+func (suite *ImportTestSuite) tarArchive() string {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for name, b := range suite.archiveFiles() {
+		if err := tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0o644,
+			Size:     int64(len(b)),
+			Typeflag: tar.TypeReg,
+		}); err != nil {
+			suite.FailNow(err.Error())
+		}
+		if _, err := tw.Write(b); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+	if err := tw.Close(); err != nil {
+		suite.FailNow(err.Error())
+	}
+	if err := gw.Close(); err != nil {
+		suite.FailNow(err.Error())
+	}
+	return buf.String()
+}
+
+// importedStatuses returns the statuses of the
+// given account posted in 2020, oldest first.
+func (suite *ImportTestSuite) importedStatuses(account *gtsmodel.Account) []*gtsmodel.Status {
+	maxID, err := id.NewULIDFromTime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statuses, err := suite.db.GetAccountStatuses(context.Background(), account.ID, 0, false, false, maxID, id.Lowest, false, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Reverse to oldest first.
+	for l, r := 0, len(statuses)-1; l < r; l, r = l+1, r-1 {
+		statuses[l], statuses[r] = statuses[r], statuses[l]
+	}
+	return statuses
+}
+
+func (suite *ImportTestSuite) TestImportArchive() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	imp := suite.runImport(testAccount, suite.importForm(suite.zipArchive(), "archive", ""))
+	suite.Equal(4, imp.Total)
+	suite.Equal(2, imp.Failed)
+	suite.ElementsMatch([]string{
+		"/media_attachments/files/missing.png: media not found in archive",
+		"https://old.example.org/users/zork/statuses/3: direct messages aren't imported",
+		"https://old.example.org/users/zork/statuses/4: only notes can be imported, not Question",
+	}, imp.Failures)
+
+	statuses := suite.importedStatuses(testAccount)
+	if !suite.Len(statuses, 2) {
+		suite.FailNow("")
+	}
+	first, reply := statuses[0], statuses[1]
+
+	// The original published date is kept, and used for the ULID.
+	published := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+	suite.True(first.CreatedAt.Equal(published))
+	publishedID, err := id.NewULIDFromTime(published)
+	suite.NoError(err)
+	suite.Equal(publishedID[:10], first.ID[:10])
+
+	suite.True(*first.Local)
+	suite.False(*first.Federated)
+	suite.Equal("http://localhost:8080/users/the_mighty_zork/statuses/"+first.ID, first.URI)
+	suite.Equal(gtsmodel.VisibilityPublic, first.Visibility)
+	suite.Equal("old post", first.ContentWarning)
+	suite.True(*first.Sensitive)
+	suite.Equal("de", first.Language)
+	suite.Contains(first.Content, "hello from my old instance")
+
+	if suite.Len(first.Attachments, 1) {
+		suite.Equal("oh you", first.Attachments[0].Description)
+		suite.Equal(gtsmodel.FileTypeImage, first.Attachments[0].Type)
+		suite.Equal(first.ID, first.Attachments[0].StatusID)
+	}
+
+	if suite.Len(first.Tags, 1) {
+		suite.Equal("oldposts", first.Tags[0].Name)
+	}
+
+	// The reply is threaded to the imported status.
+	suite.Equal(first.ID, reply.InReplyToID)
+	suite.Equal(first.URI, reply.InReplyToURI)
+	suite.Equal(testAccount.ID, reply.InReplyToAccountID)
+	suite.Equal(gtsmodel.VisibilityFollowersOnly, reply.Visibility)
+	suite.False(*reply.Boostable)
+	suite.Equal("<p>replying to myself</p>", reply.Content)
+	suite.Equal(testAccount.Language, reply.Language)
+	suite.Empty(reply.Attachments)
+
+	// Importing the same archive again doesn't duplicate statuses.
+	imp = suite.runImport(testAccount, suite.importForm(suite.tarArchive(), "archive", ""))
+	suite.Equal(4, imp.Total)
+	suite.Equal(2, imp.Failed)
+	suite.Len(suite.importedStatuses(testAccount), 2)
+
+	// Nothing was federated.
+	suite.Never(func() bool {
+		var sent bool
+		suite.httpClient.SentMessages.Range(func(key any, value any) bool {
+			sent = true
+			return false
+		})
+		return sent
+	}, 1*time.Second, 100*time.Millisecond)
+}
+
+func (suite *ImportTestSuite) TestImportArchiveTar() {
+	testAccount := suite.testAccounts["admin_account"]
+
+	imp := suite.runImport(testAccount, suite.importForm(suite.tarArchive(), "archive", ""))
+	suite.Equal(4, imp.Total)
+
+	statuses := suite.importedStatuses(testAccount)
+	if suite.Len(statuses, 2) {
+		suite.Len(statuses[0].Attachments, 1)
+		suite.Equal(statuses[0].ID, statuses[1].InReplyToID)
+	}
+}
+
+func (suite *ImportTestSuite) TestImportArchiveInvalid() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	_, errWithCode := suite.importer.ImportCreate(ctx, testAccount, suite.importForm("not an archive", "archive", ""))
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	_, errWithCode = suite.importer.ImportCreate(ctx, testAccount, suite.importForm(suite.zipArchive(), "archive", "overwrite"))
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	// An archive without an outbox fails to import.
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	if _, err := zw.Create("actor.json"); err != nil {
+		suite.FailNow(err.Error())
+	}
+	if err := zw.Close(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	apiImport, errWithCode := suite.importer.ImportCreate(ctx, testAccount, suite.importForm(buf.String(), "archive", ""))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	imp := suite.waitImport(apiImport.ID)
+	suite.Equal(gtsmodel.ImportFailed, imp.State)
+	suite.Equal("archive doesn't contain an outbox.json", imp.Error)
+
+	// As does one with an outbox too large to decode.
+	config.SetAccountsImportMaxOutboxSize(64)
+
+	apiImport, errWithCode = suite.importer.ImportCreate(ctx, testAccount, suite.importForm(suite.tarArchive(), "archive", ""))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	imp = suite.waitImport(apiImport.ID)
+	suite.Equal(gtsmodel.ImportFailed, imp.State)
+	suite.Equal("outbox.json exceeds the maximum size of 64B", imp.Error)
+}
@@ -0,0 +1,838 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package importer
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	// importMaxSize is the maximum size
+	// in bytes of an uploaded CSV file.
+	importMaxSize = 10 * 1024 * 1024
+
+	// importMaxRows is the maximum number
+	// of rows in an uploaded CSV file.
+	importMaxRows = 20000
+
+	// importMaxFailures is the maximum number of
+	// failed rows recorded with the reason why.
+	importMaxFailures = 100
+
+	// importProgressInterval is how many rows
+	// are processed between progress updates.
+	importProgressInterval = 25
+
+	// importFetchInterval is the minimum time between
+	// fetching accounts or statuses not yet known to
+	// this instance, so that importing a large file
+	// doesn't hammer remote instances.
+	importFetchInterval = time.Second
+
+	// importStaleAfter is how long an import can go without
+	// progress before it's assumed to have been interrupted,
+	// eg. by the instance restarting, and marked as failed.
+	importStaleAfter = time.Hour
+)
+
+// importRow is one row of an uploaded CSV file.
+type importRow struct {
+	// Account address, or status URI for bookmarks.
+	target string
+	// List title, for lists.
+	list string
+	// Whether to show boosts + notify
+	// of posts, for following, if set.
+	showReblogs *bool
+	notify      *bool
+	// Whether to mute notifications,
+	// for muting, if set.
+	hideNotifications *bool
+}
+
+// ImportCreate parses the uploaded CSV file, or stores the
+// uploaded archive, and starts importing it into the given
+// account in the background, returning the new import.
+func (p *Processor) ImportCreate(ctx context.Context, account *gtsmodel.Account, form *apimodel.ImportRequest) (*apimodel.Import, gtserror.WithCode) {
+	importType := gtsmodel.ImportType(form.Type)
+	switch importType {
+	case gtsmodel.ImportFollowing, gtsmodel.ImportBlocks, gtsmodel.ImportMutes, gtsmodel.ImportBookmarks, gtsmodel.ImportLists, gtsmodel.ImportArchive:
+	case "domain_blocking":
+		err := fmt.Errorf("importing %s is not supported, as GoToSocial doesn't support it yet", form.Type)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	default:
+		err := fmt.Errorf("type %s not recognised, valid types are following, blocks, muting, bookmarks, lists and archive", form.Type)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	importMode := gtsmodel.ImportMode(form.Mode)
+	switch importMode {
+	case "":
+		importMode = gtsmodel.ImportMerge
+	case gtsmodel.ImportMerge, gtsmodel.ImportOverwrite:
+	default:
+		err := fmt.Errorf("mode %s not recognised, valid modes are merge and overwrite", form.Mode)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if importType == gtsmodel.ImportArchive && importMode != gtsmodel.ImportMerge {
+		const text = "archives can only be imported in merge mode"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if errWithCode := p.importCheckInProgress(ctx, account); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	now := time.Now()
+	imp := &gtsmodel.Import{
+		ID:        id.NewULID(),
+		CreatedAt: now,
+		UpdatedAt: now,
+		AccountID: account.ID,
+		Type:      importType,
+		Mode:      importMode,
+		State:     gtsmodel.ImportProcessing,
+	}
+
+	i := &importer{
+		Processor: p,
+		imp:       imp,
+		owner:     account,
+		federate:  form.Federate,
+	}
+
+	if importType == gtsmodel.ImportArchive {
+		// The number of statuses in
+		// the archive isn't known until
+		// it's read in the background.
+		archive, errWithCode := newImportArchive(form.Data)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		i.archive = archive
+	} else {
+		rows, errWithCode := parseImportFile(form.Data, importType)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		i.rows = rows
+		imp.Total = len(rows)
+	}
+
+	if err := p.state.DB.PutImport(ctx, imp); err != nil {
+		if i.archive != nil {
+			i.archive.close()
+		}
+		err = gtserror.Newf("db error putting import: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.state.Workers.Jobs.Enqueue(i.run)
+
+	return p.tc.ImportToAPIImport(imp), nil
+}
+
+// importCheckInProgress returns a conflict error if an import into
+// the given account is still in progress. Imports which have gone
+// without progress for too long are marked as failed instead.
+func (p *Processor) importCheckInProgress(ctx context.Context, account *gtsmodel.Account) gtserror.WithCode {
+	imports, err := p.state.DB.GetAccountImports(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting imports: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	for _, imp := range imports {
+		if imp.State != gtsmodel.ImportProcessing {
+			continue
+		}
+
+		if time.Since(imp.UpdatedAt) < importStaleAfter {
+			const text = "an import is already in progress, wait for it to finish"
+			return gtserror.NewErrorConflict(errors.New(text), text)
+		}
+
+		imp.State = gtsmodel.ImportFailed
+		imp.Error = "interrupted"
+		imp.CompletedAt = time.Now()
+		if err := p.state.DB.UpdateImport(ctx, imp, "state", "error", "completed_at"); err != nil {
+			err = gtserror.Newf("db error updating import: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return nil
+}
+
+// ImportsGet returns all imports into the given account, newest first.
+func (p *Processor) ImportsGet(ctx context.Context, account *gtsmodel.Account) ([]*apimodel.Import, gtserror.WithCode) {
+	imports, err := p.state.DB.GetAccountImports(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting imports: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiImports := make([]*apimodel.Import, 0, len(imports))
+	for _, imp := range imports {
+		apiImports = append(apiImports, p.tc.ImportToAPIImport(imp))
+	}
+
+	return apiImports, nil
+}
+
+// ImportGet returns one import into the given account.
+func (p *Processor) ImportGet(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.Import, gtserror.WithCode) {
+	imp, err := p.state.DB.GetImportByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting import %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if imp == nil || imp.AccountID != account.ID {
+		err := gtserror.Newf("import %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return p.tc.ImportToAPIImport(imp), nil
+}
+
+// parseImportFile opens and parses the given uploaded CSV file.
+func parseImportFile(fh *multipart.FileHeader, importType gtsmodel.ImportType) ([]importRow, gtserror.WithCode) {
+	if fh.Size > importMaxSize {
+		err := fmt.Errorf("file size %d bytes exceeds the maximum of %d bytes", fh.Size, importMaxSize)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		err = gtserror.Newf("error opening file: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	defer f.Close()
+
+	rows, err := parseImport(f, importType)
+	if err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	return rows, nil
+}
+
+// parseImport parses the rows of a CSV file in the format of
+// the corresponding Mastodon export for the given import type:
+//
+//   - following: "Account address,Show boosts,Notify on new posts,Languages",
+//     with that header; files with only the account address column are accepted.
+//   - blocks: account address, without a header.
+//   - muting: "Account address,Hide notifications", with that header;
+//     files with only the account address column are accepted.
+//   - bookmarks: status URI, without a header.
+//   - lists: "list title,account address", without a header.
+func parseImport(r io.Reader, importType gtsmodel.ImportType) ([]importRow, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing CSV: %w", err)
+	}
+
+	if len(records) != 0 && strings.EqualFold(records[0][0], "Account address") {
+		// Skip header.
+		records = records[1:]
+	}
+
+	if len(records) == 0 {
+		return nil, errors.New("file contains no rows to import")
+	}
+
+	if len(records) > importMaxRows {
+		return nil, fmt.Errorf("file contains %d rows, more than the maximum of %d", len(records), importMaxRows)
+	}
+
+	rows := make([]importRow, 0, len(records))
+	for i, record := range records {
+		var row importRow
+
+		switch importType {
+		case gtsmodel.ImportLists:
+			if len(record) < 2 {
+				return nil, fmt.Errorf("row %d: expected list title and account address", i+1)
+			}
+			row.list = strings.TrimSpace(record[0])
+			row.target = strings.TrimSpace(record[1])
+
+		case gtsmodel.ImportFollowing:
+			row.target = strings.TrimSpace(record[0])
+			if len(record) > 1 && record[1] != "" {
+				showReblogs, err := strconv.ParseBool(record[1])
+				if err != nil {
+					return nil, fmt.Errorf("row %d: invalid value for show boosts: %w", i+1, err)
+				}
+				row.showReblogs = &showReblogs
+			}
+			if len(record) > 2 && record[2] != "" {
+				notify, err := strconv.ParseBool(record[2])
+				if err != nil {
+					return nil, fmt.Errorf("row %d: invalid value for notify on new posts: %w", i+1, err)
+				}
+				row.notify = &notify
+			}
+
+		case gtsmodel.ImportMutes:
+			row.target = strings.TrimSpace(record[0])
+			if len(record) > 1 && record[1] != "" {
+				hideNotifications, err := strconv.ParseBool(record[1])
+				if err != nil {
+					return nil, fmt.Errorf("row %d: invalid value for hide notifications: %w", i+1, err)
+				}
+				row.hideNotifications = &hideNotifications
+			}
+
+		default:
+			row.target = strings.TrimSpace(record[0])
+		}
+
+		if row.target == "" {
+			return nil, fmt.Errorf("row %d: empty", i+1)
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// importer processes the rows of one import.
+type importer struct {
+	*Processor
+	imp   *gtsmodel.Import
+	owner *gtsmodel.Account
+
+	// Rows of an uploaded CSV file, or
+	// an uploaded archive of statuses.
+	rows    []importRow
+	archive *importArchive
+
+	// Whether to federate imported statuses.
+	federate bool
+
+	// lastFetch is when a remote account or
+	// status was last fetched for this import.
+	lastFetch time.Time
+}
+
+// run imports the rows or archive, recording progress as it goes.
+func (i *importer) run(ctx context.Context) {
+	l := log.WithContext(ctx).WithField("import", i.imp.ID)
+
+	var err error
+	switch i.imp.Type {
+	case gtsmodel.ImportFollowing:
+		err = i.importFollowing(ctx, i.rows)
+	case gtsmodel.ImportBlocks:
+		err = i.importBlocks(ctx, i.rows)
+	case gtsmodel.ImportMutes:
+		err = i.importMutes(ctx, i.rows)
+	case gtsmodel.ImportBookmarks:
+		err = i.importBookmarks(ctx, i.rows)
+	case gtsmodel.ImportLists:
+		err = i.importLists(ctx, i.rows)
+	case gtsmodel.ImportArchive:
+		err = i.importArchive(ctx)
+		i.archive.close()
+	}
+
+	if err != nil {
+		l.Errorf("error importing: %v", err)
+		i.imp.State = gtsmodel.ImportFailed
+		i.imp.Error = err.Error()
+	} else {
+		i.imp.State = gtsmodel.ImportComplete
+	}
+	i.imp.CompletedAt = time.Now()
+
+	// The import may have been stopped by ctx
+	// being cancelled, so use a fresh one here.
+	if err := i.state.DB.UpdateImport(context.Background(), i.imp,
+		"state",
+		"total",
+		"processed",
+		"failed",
+		"failures",
+		"error",
+		"completed_at",
+	); err != nil {
+		l.Errorf("db error updating import: %v", err)
+	}
+}
+
+// done records the row with the given target as processed, noting
+// why it failed if it did, and periodically updates progress in the db.
+func (i *importer) done(ctx context.Context, target string, failure string) {
+	i.imp.Processed++
+
+	if failure != "" {
+		i.imp.Failed++
+		i.fail(target, failure)
+	}
+
+	if i.imp.Processed%importProgressInterval != 0 {
+		return
+	}
+
+	if err := i.state.DB.UpdateImport(ctx, i.imp, "processed", "failed", "failures"); err != nil {
+		log.Errorf(ctx, "db error updating import %s: %v", i.imp.ID, err)
+	}
+}
+
+// fail notes why the given target couldn't be imported,
+// up to a limit, without counting it as a failed row.
+func (i *importer) fail(target string, failure string) {
+	if len(i.imp.Failures) < importMaxFailures {
+		i.imp.Failures = append(i.imp.Failures, target+": "+failure)
+	}
+}
+
+// wait blocks until at least importFetchInterval has
+// passed since the last remote fetch for this import.
+func (i *importer) wait(ctx context.Context) error {
+	if d := time.Until(i.lastFetch.Add(importFetchInterval)); d > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(d):
+		}
+	}
+	i.lastFetch = time.Now()
+	return nil
+}
+
+// resolveAccount returns the account with the given address,
+// fetching it if it isn't already known to this instance.
+// The returned string describes why it couldn't be found,
+// if it couldn't; a returned error means the import must stop.
+func (i *importer) resolveAccount(ctx context.Context, address string) (*gtsmodel.Account, string, error) {
+	username, domain, err := util.ExtractWebfingerParts(address)
+	if err != nil {
+		return nil, "not a valid account address", nil
+	}
+
+	if domain == config.GetHost() || domain == config.GetAccountDomain() {
+		domain = ""
+	}
+
+	account, err := i.state.DB.GetAccountByUsernameDomain(ctx, username, domain)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, "", gtserror.Newf("db error getting account %s: %w", address, err)
+	}
+
+	if account != nil {
+		return account, "", nil
+	}
+
+	if domain == "" {
+		return nil, "account not found", nil
+	}
+
+	if err := i.wait(ctx); err != nil {
+		return nil, "", err
+	}
+
+	account, _, err = i.federator.GetAccountByUsernameDomain(ctx, i.owner.Username, username, domain)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, "", ctx.Err()
+		}
+		log.Debugf(ctx, "error fetching account %s: %v", address, err)
+		return nil, "account not found", nil
+	}
+
+	return account, "", nil
+}
+
+// resolveStatus returns the status with the given URI,
+// fetching it if it isn't already known to this instance.
+// Returns as resolveAccount.
+func (i *importer) resolveStatus(ctx context.Context, uri string) (*gtsmodel.Status, string, error) {
+	u, err := url.Parse(uri)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+		return nil, "not a valid status URI", nil
+	}
+
+	status, err := i.state.DB.GetStatusByURI(ctx, uri)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, "", gtserror.Newf("db error getting status %s: %w", uri, err)
+	}
+
+	if status != nil {
+		return status, "", nil
+	}
+
+	if u.Host == config.GetHost() || u.Host == config.GetAccountDomain() {
+		return nil, "status not found", nil
+	}
+
+	if err := i.wait(ctx); err != nil {
+		return nil, "", err
+	}
+
+	status, _, err = i.federator.GetStatusByURI(ctx, i.owner.Username, u)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, "", ctx.Err()
+		}
+		log.Debugf(ctx, "error fetching status %s: %v", uri, err)
+		return nil, "status not found", nil
+	}
+
+	return status, "", nil
+}
+
+// importFollowing follows the account of each row, or updates the
+// existing follow. If overwriting, any other accounts are unfollowed.
+func (i *importer) importFollowing(ctx context.Context, rows []importRow) error {
+	keep := make(map[string]bool, len(rows))
+
+	for _, row := range rows {
+		target, failure, err := i.resolveAccount(ctx, row.target)
+		if err != nil {
+			return err
+		}
+
+		if target != nil {
+			keep[target.ID] = true
+
+			if _, errWithCode := i.account.FollowCreate(ctx, i.owner, &apimodel.AccountFollowRequest{
+				ID:      target.ID,
+				Reblogs: row.showReblogs,
+				Notify:  row.notify,
+			}); errWithCode != nil {
+				failure = errWithCode.Safe()
+			}
+		}
+
+		i.done(ctx, row.target, failure)
+	}
+
+	if i.imp.Mode != gtsmodel.ImportOverwrite {
+		return nil
+	}
+
+	follows, err := i.state.DB.GetAccountFollows(ctx, i.owner.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting follows: %w", err)
+	}
+
+	for _, follow := range follows {
+		if keep[follow.TargetAccountID] {
+			continue
+		}
+
+		if _, errWithCode := i.account.FollowRemove(ctx, i.owner, follow.TargetAccountID); errWithCode != nil {
+			log.Errorf(ctx, "error unfollowing %s: %v", follow.TargetAccountID, errWithCode)
+		}
+	}
+
+	return nil
+}
+
+// importBlocks blocks the account of each row. If
+// overwriting, any other accounts are unblocked.
+func (i *importer) importBlocks(ctx context.Context, rows []importRow) error {
+	keep := make(map[string]bool, len(rows))
+
+	for _, row := range rows {
+		target, failure, err := i.resolveAccount(ctx, row.target)
+		if err != nil {
+			return err
+		}
+
+		if target != nil {
+			keep[target.ID] = true
+
+			if _, errWithCode := i.account.BlockCreate(ctx, i.owner, target.ID); errWithCode != nil {
+				failure = errWithCode.Safe()
+			}
+		}
+
+		i.done(ctx, row.target, failure)
+	}
+
+	if i.imp.Mode != gtsmodel.ImportOverwrite {
+		return nil
+	}
+
+	blocked, _, _, err := i.state.DB.GetAccountBlocks(ctx, i.owner.ID, "", "", 0)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting blocks: %w", err)
+	}
+
+	for _, target := range blocked {
+		if keep[target.ID] {
+			continue
+		}
+
+		if _, errWithCode := i.account.BlockRemove(ctx, i.owner, target.ID); errWithCode != nil {
+			log.Errorf(ctx, "error unblocking %s: %v", target.ID, errWithCode)
+		}
+	}
+
+	return nil
+}
+
+// importMutes mutes the account of each row, or updates the
+// existing mute. If overwriting, any other accounts are unmuted.
+func (i *importer) importMutes(ctx context.Context, rows []importRow) error {
+	keep := make(map[string]bool, len(rows))
+
+	for _, row := range rows {
+		target, failure, err := i.resolveAccount(ctx, row.target)
+		if err != nil {
+			return err
+		}
+
+		if target != nil {
+			keep[target.ID] = true
+
+			if _, errWithCode := i.account.MuteCreate(ctx, i.owner, target.ID, row.hideNotifications); errWithCode != nil {
+				failure = errWithCode.Safe()
+			}
+		}
+
+		i.done(ctx, row.target, failure)
+	}
+
+	if i.imp.Mode != gtsmodel.ImportOverwrite {
+		return nil
+	}
+
+	mutes, err := i.state.DB.GetAccountMutes(ctx, i.owner.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting mutes: %w", err)
+	}
+
+	for _, mute := range mutes {
+		if keep[mute.TargetAccountID] {
+			continue
+		}
+
+		if _, errWithCode := i.account.MuteRemove(ctx, i.owner, mute.TargetAccountID); errWithCode != nil {
+			log.Errorf(ctx, "error unmuting %s: %v", mute.TargetAccountID, errWithCode)
+		}
+	}
+
+	return nil
+}
+
+// importBookmarks bookmarks the status of each row. If
+// overwriting, any other bookmarks are removed.
+func (i *importer) importBookmarks(ctx context.Context, rows []importRow) error {
+	keep := make(map[string]bool, len(rows))
+
+	for _, row := range rows {
+		status, failure, err := i.resolveStatus(ctx, row.target)
+		if err != nil {
+			return err
+		}
+
+		if status != nil {
+			keep[status.ID] = true
+
+			if _, errWithCode := i.status.BookmarkCreate(ctx, i.owner, status.ID); errWithCode != nil {
+				failure = errWithCode.Safe()
+			}
+		}
+
+		i.done(ctx, row.target, failure)
+	}
+
+	if i.imp.Mode != gtsmodel.ImportOverwrite {
+		return nil
+	}
+
+	bookmarks, err := i.state.DB.GetStatusBookmarks(ctx, i.owner.ID, 0, "", "")
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting bookmarks: %w", err)
+	}
+
+	for _, bookmark := range bookmarks {
+		if keep[bookmark.StatusID] {
+			continue
+		}
+
+		if _, errWithCode := i.status.BookmarkRemove(ctx, i.owner, bookmark.StatusID); errWithCode != nil {
+			log.Errorf(ctx, "error removing bookmark of %s: %v", bookmark.StatusID, errWithCode)
+		}
+	}
+
+	return nil
+}
+
+// importLists adds the account of each row to the list with
+// the given title, creating it if necessary. Accounts must
+// already be followed to be added to a list. If overwriting,
+// any other lists are deleted, and any other accounts are
+// removed from the imported lists.
+func (i *importer) importLists(ctx context.Context, rows []importRow) error {
+	existing, err := i.state.DB.GetListsForAccountID(ctx, i.owner.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting lists: %w", err)
+	}
+
+	// Lists by title, and which accounts
+	// should be in each by the end.
+	lists := make(map[string]*gtsmodel.List, len(existing))
+	keep := make(map[string]map[string]bool)
+	for _, list := range existing {
+		lists[list.Title] = list
+	}
+
+	for _, row := range rows {
+		list, err := i.importList(ctx, lists, row.list)
+		if err != nil {
+			return err
+		}
+
+		target, failure, err := i.resolveAccount(ctx, row.target)
+		if err != nil {
+			return err
+		}
+
+		if target != nil {
+			if keep[list.ID] == nil {
+				keep[list.ID] = make(map[string]bool)
+			}
+			keep[list.ID][target.ID] = true
+
+			failure, err = i.addToList(ctx, list, target)
+			if err != nil {
+				return err
+			}
+		}
+
+		i.done(ctx, row.target, failure)
+	}
+
+	if i.imp.Mode != gtsmodel.ImportOverwrite {
+		return nil
+	}
+
+	for _, list := range lists {
+		if keep[list.ID] == nil {
+			if errWithCode := i.list.Delete(ctx, i.owner, list.ID); errWithCode != nil {
+				log.Errorf(ctx, "error deleting list %s: %v", list.ID, errWithCode)
+			}
+			continue
+		}
+
+		entries, err := i.state.DB.GetListEntries(ctx, list.ID, "", "", "", 0)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting list entries: %w", err)
+		}
+
+		var remove []string
+		for _, entry := range entries {
+			if !keep[list.ID][entry.Follow.TargetAccountID] {
+				remove = append(remove, entry.Follow.TargetAccountID)
+			}
+		}
+
+		if len(remove) == 0 {
+			continue
+		}
+
+		if errWithCode := i.list.RemoveFromList(ctx, i.owner, list.ID, remove); errWithCode != nil {
+			log.Errorf(ctx, "error removing accounts from list %s: %v", list.ID, errWithCode)
+		}
+	}
+
+	return nil
+}
+
+// importList returns the list with the given title,
+// creating it if there isn't one yet.
+func (i *importer) importList(ctx context.Context, lists map[string]*gtsmodel.List, title string) (*gtsmodel.List, error) {
+	if list := lists[title]; list != nil {
+		return list, nil
+	}
+
+	apiList, errWithCode := i.list.Create(ctx, i.owner, title, gtsmodel.RepliesPolicyFollowed)
+	if errWithCode != nil {
+		return nil, gtserror.Newf("error creating list %s: %w", title, errWithCode)
+	}
+
+	list, err := i.state.DB.GetListByID(ctx, apiList.ID)
+	if err != nil {
+		return nil, gtserror.Newf("db error getting list %s: %w", apiList.ID, err)
+	}
+
+	lists[title] = list
+	return list, nil
+}
+
+// addToList adds target to the given list if it isn't in it
+// already. Returns as resolveAccount, without the account.
+func (i *importer) addToList(ctx context.Context, list *gtsmodel.List, target *gtsmodel.Account) (string, error) {
+	follow, err := i.state.DB.GetFollow(ctx, i.owner.ID, target.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return "", gtserror.Newf("db error getting follow: %w", err)
+	}
+
+	if follow == nil {
+		return "you must follow an account to add it to a list", nil
+	}
+
+	entries, err := i.state.DB.GetListEntriesForFollowID(ctx, follow.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return "", gtserror.Newf("db error getting list entries: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.ListID == list.ID {
+			// Already in list.
+			return "", nil
+		}
+	}
+
+	if errWithCode := i.list.AddToList(ctx, i.owner, list.ID, []string{target.ID}); errWithCode != nil {
+		return errWithCode.Safe(), nil
+	}
+
+	return "", nil
+}
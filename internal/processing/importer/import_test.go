@@ -0,0 +1,320 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package importer_test
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type ImportTestSuite struct {
+	ImporterStandardTestSuite
+}
+
+// importForm returns an import request
+// for the given CSV data, type and mode.
+func (suite *ImportTestSuite) importForm(data string, importType string, mode string) *apimodel.ImportRequest {
+	buf := &bytes.Buffer{}
+	w := multipart.NewWriter(buf)
+	fw, err := w.CreateFormFile("data", "import.csv")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if _, err := fw.Write([]byte(data)); err != nil {
+		suite.FailNow(err.Error())
+	}
+	if err := w.Close(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	form, err := multipart.NewReader(buf, w.Boundary()).ReadForm(1024 * 1024)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return &apimodel.ImportRequest{
+		Data: form.File["data"][0],
+		Type: importType,
+		Mode: mode,
+	}
+}
+
+// waitImport waits for the given import to stop processing.
+func (suite *ImportTestSuite) waitImport(id string) *gtsmodel.Import {
+	var imp *gtsmodel.Import
+	if !suite.Eventually(func() bool {
+		var err error
+		imp, err = suite.db.GetImportByID(context.Background(), id)
+		return err == nil && imp.State != gtsmodel.ImportProcessing
+	}, 10*time.Second, 50*time.Millisecond) {
+		suite.FailNow("timed out waiting for import")
+	}
+	return imp
+}
+
+// runImport creates an import into the given account,
+// and waits for it to finish processing.
+func (suite *ImportTestSuite) runImport(account *gtsmodel.Account, form *apimodel.ImportRequest) *gtsmodel.Import {
+	apiImport, errWithCode := suite.importer.ImportCreate(context.Background(), account, form)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("processing", apiImport.State)
+
+	imp := suite.waitImport(apiImport.ID)
+	suite.Equal(gtsmodel.ImportComplete, imp.State)
+	suite.Empty(imp.Error)
+	suite.Equal(imp.Total, imp.Processed)
+	return imp
+}
+
+func (suite *ImportTestSuite) TestImportFollowingOverwrite() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	imp := suite.runImport(testAccount, suite.importForm(
+		"Account address,Show boosts,Notify on new posts,Languages\n"+
+			"admin@localhost:8080,false,true,\n"+
+			"nobody@localhost:8080,true,false,\n",
+		"following",
+		"overwrite",
+	))
+	suite.Equal(2, imp.Total)
+	suite.Equal(1, imp.Failed)
+	suite.Equal([]string{"nobody@localhost:8080: account not found"}, imp.Failures)
+
+	// The existing follow was updated.
+	follow, err := suite.db.GetFollow(ctx, testAccount.ID, suite.testAccounts["admin_account"].ID)
+	suite.NoError(err)
+	suite.False(*follow.ShowReblogs)
+	suite.True(*follow.Notify)
+
+	// Follows not in the file were removed.
+	follows, err := suite.db.GetAccountFollows(ctx, testAccount.ID)
+	suite.NoError(err)
+	suite.Len(follows, 1)
+}
+
+func (suite *ImportTestSuite) TestImportBlocksMerge() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_2"]
+	targetAccount := suite.testAccounts["local_account_1"]
+
+	imp := suite.runImport(testAccount, suite.importForm(
+		"the_mighty_zork@localhost:8080\n",
+		"blocks",
+		"",
+	))
+	suite.Equal(gtsmodel.ImportMerge, imp.Mode)
+	suite.Zero(imp.Failed)
+
+	blocked, err := suite.db.IsBlocked(ctx, testAccount.ID, targetAccount.ID)
+	suite.NoError(err)
+	suite.True(blocked)
+
+	// The existing block was kept.
+	blocked, err = suite.db.IsBlocked(ctx, testAccount.ID, suite.testAccounts["remote_account_1"].ID)
+	suite.NoError(err)
+	suite.True(blocked)
+}
+
+func (suite *ImportTestSuite) TestImportMutesOverwrite() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+	targetAccount := suite.testAccounts["local_account_2"]
+	otherAccount := suite.testAccounts["remote_account_1"]
+
+	// Mute an account which isn't in the file.
+	if _, errWithCode := suite.processor.Account().MuteCreate(ctx, testAccount, otherAccount.ID, nil); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	imp := suite.runImport(testAccount, suite.importForm(
+		"Account address,Hide notifications\n1happyturtle@localhost:8080,false\n",
+		"muting",
+		"overwrite",
+	))
+	suite.Equal(1, imp.Total)
+	suite.Zero(imp.Failed)
+
+	mute, err := suite.db.GetMute(ctx, testAccount.ID, targetAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(*mute.Notifications)
+
+	// The other mute was removed.
+	muted, err := suite.db.IsMuted(ctx, testAccount.ID, otherAccount.ID)
+	suite.NoError(err)
+	suite.False(muted)
+}
+
+func (suite *ImportTestSuite) TestImportBookmarksOverwrite() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+	testStatus := suite.testStatuses["local_account_2_status_1"]
+
+	imp := suite.runImport(testAccount, suite.importForm(
+		testStatus.URI+"\nnot a status\n",
+		"bookmarks",
+		"overwrite",
+	))
+	suite.Equal(1, imp.Failed)
+	suite.Equal([]string{"not a status: not a valid status URI"}, imp.Failures)
+
+	bookmarks, err := suite.db.GetStatusBookmarks(ctx, testAccount.ID, 0, "", "")
+	suite.NoError(err)
+	if suite.Len(bookmarks, 1) {
+		suite.Equal(testStatus.ID, bookmarks[0].StatusID)
+	}
+}
+
+func (suite *ImportTestSuite) TestImportListsMerge() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	imp := suite.runImport(testAccount, suite.importForm(
+		"Cool Ass Posters From This Instance,admin@localhost:8080\n"+
+			"Turtles,1happyturtle@localhost:8080\n"+
+			"Turtles,foss_satan@fossbros-anonymous.io\n",
+		"lists",
+		"merge",
+	))
+	suite.Equal(1, imp.Failed)
+	suite.Equal([]string{"foss_satan@fossbros-anonymous.io: you must follow an account to add it to a list"}, imp.Failures)
+
+	lists, err := suite.db.GetListsForAccountID(ctx, testAccount.ID)
+	suite.NoError(err)
+	suite.Len(lists, 2)
+
+	for _, list := range lists {
+		entries, err := suite.db.GetListEntries(ctx, list.ID, "", "", "", 0)
+		suite.NoError(err)
+
+		switch list.Title {
+		case "Cool Ass Posters From This Instance":
+			// Existing entries were kept.
+			suite.Len(entries, 2)
+		case "Turtles":
+			suite.Len(entries, 1)
+			suite.Equal(gtsmodel.RepliesPolicyFollowed, list.RepliesPolicy)
+		default:
+			suite.Fail("unexpected list " + list.Title)
+		}
+	}
+}
+
+func (suite *ImportTestSuite) TestImportInvalid() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	for _, test := range []struct {
+		data       string
+		importType string
+		mode       string
+		code       int
+	}{
+		{"someone@example.org,sometimes\n", "muting", "", http.StatusBadRequest},
+		{"example.org\n", "domain_blocking", "", http.StatusUnprocessableEntity},
+		{"someone@example.org\n", "fish", "", http.StatusBadRequest},
+		{"someone@example.org\n", "blocks", "replace", http.StatusBadRequest},
+		{"Account address,Show boosts,Notify on new posts,Languages\n", "following", "", http.StatusBadRequest},
+		{"someone@example.org,maybe\n", "following", "", http.StatusBadRequest},
+		{"Some list\n", "lists", "", http.StatusBadRequest},
+	} {
+		_, errWithCode := suite.importer.ImportCreate(ctx, testAccount, suite.importForm(test.data, test.importType, test.mode))
+		if suite.NotNil(errWithCode, test.importType) {
+			suite.Equal(test.code, errWithCode.Code(), errWithCode.Error())
+		}
+	}
+
+	imports, errWithCode := suite.importer.ImportsGet(ctx, testAccount)
+	suite.Nil(errWithCode)
+	suite.Empty(imports)
+}
+
+func (suite *ImportTestSuite) TestImportConflict() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	imp := &gtsmodel.Import{
+		ID:        "01HB8R3Y4Z5A6B7C8D9E0F1G2H",
+		UpdatedAt: time.Now(),
+		AccountID: testAccount.ID,
+		Type:      gtsmodel.ImportBlocks,
+		Mode:      gtsmodel.ImportMerge,
+		State:     gtsmodel.ImportProcessing,
+		Total:     1,
+	}
+	if err := suite.db.PutImport(ctx, imp); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, errWithCode := suite.importer.ImportCreate(ctx, testAccount, suite.importForm("someone@example.org\n", "blocks", ""))
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	// It's not visible to other accounts.
+	_, errWithCode = suite.importer.ImportGet(ctx, suite.testAccounts["local_account_2"], imp.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	apiImport, errWithCode := suite.importer.ImportGet(ctx, testAccount, imp.ID)
+	suite.Nil(errWithCode)
+	suite.Equal("processing", apiImport.State)
+	suite.Equal([]string{}, apiImport.Failures)
+	suite.Nil(apiImport.CompletedAt)
+}
+
+func (suite *ImportTestSuite) TestImportInterrupted() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_2"]
+
+	// An import which hasn't made progress for a long
+	// time is assumed to have been interrupted, and
+	// doesn't stop another from being started.
+	stale := &gtsmodel.Import{
+		ID:        "01HB8R3Y4Z5A6B7C8D9E0F1G2H",
+		UpdatedAt: time.Now().Add(-2 * time.Hour),
+		AccountID: testAccount.ID,
+		Type:      gtsmodel.ImportBlocks,
+		Mode:      gtsmodel.ImportMerge,
+		State:     gtsmodel.ImportProcessing,
+		Total:     1,
+	}
+	if err := suite.db.PutImport(ctx, stale); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.runImport(testAccount, suite.importForm("the_mighty_zork@localhost:8080\n", "blocks", ""))
+
+	stale, err := suite.db.GetImportByID(ctx, stale.ID)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.ImportFailed, stale.State)
+	suite.Equal("interrupted", stale.Error)
+}
+
+func TestImportTestSuite(t *testing.T) {
+	suite.Run(t, &ImportTestSuite{})
+}
@@ -0,0 +1,64 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package importer
+
+import (
+	"github.com/superseriousbusiness/gotosocial/internal/federation"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/list"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/status"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+)
+
+// Processor imports data exported from other instances
+// into accounts, going through the account, list and status
+// processors so that imports have the same side effects as
+// following, blocking, bookmarking etc. by hand.
+type Processor struct {
+	state        *state.State
+	tc           typeutils.TypeConverter
+	federator    federation.Federator
+	mediaManager *media.Manager
+
+	account *account.Processor
+	list    *list.Processor
+	status  *status.Processor
+}
+
+// New returns a new importer processor.
+func New(
+	state *state.State,
+	tc typeutils.TypeConverter,
+	federator federation.Federator,
+	mediaManager *media.Manager,
+	account *account.Processor,
+	list *list.Processor,
+	status *status.Processor,
+) Processor {
+	return Processor{
+		state:        state,
+		tc:           tc,
+		federator:    federator,
+		mediaManager: mediaManager,
+		account:      account,
+		list:         list,
+		status:       status,
+	}
+}
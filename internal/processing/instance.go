@@ -69,6 +69,20 @@ func (p *Processor) InstanceGetV2(ctx context.Context) (*apimodel.InstanceV2, gt
 	return ai, nil
 }
 
+func (p *Processor) InstanceGetRules(ctx context.Context) ([]apimodel.InstanceRule, gtserror.WithCode) {
+	rules, err := p.state.DB.GetActiveRules(ctx)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error fetching rules: %s", err))
+	}
+
+	apiRules := make([]apimodel.InstanceRule, 0, len(rules))
+	for _, rule := range rules {
+		apiRules = append(apiRules, p.tc.RuleToAPIRule(rule))
+	}
+
+	return apiRules, nil
+}
+
 func (p *Processor) InstancePeersGet(ctx context.Context, includeSuspended bool, includeOpen bool, flat bool) (interface{}, gtserror.WithCode) {
 	domains := []*apimodel.Domain{}
 
@@ -135,7 +149,7 @@ func (p *Processor) InstancePeersGet(ctx context.Context, includeSuspended bool,
 	return domains, nil
 }
 
-func (p *Processor) InstancePatch(ctx context.Context, form *apimodel.InstanceSettingsUpdateRequest) (*apimodel.InstanceV1, gtserror.WithCode) {
+func (p *Processor) InstancePatch(ctx context.Context, account *gtsmodel.Account, form *apimodel.InstanceSettingsUpdateRequest) (*apimodel.InstanceV1, gtserror.WithCode) {
 	// fetch the instance entry from the db for processing
 	i := &gtsmodel.Instance{}
 	host := config.GetHost()
@@ -149,6 +163,12 @@ func (p *Processor) InstancePatch(ctx context.Context, form *apimodel.InstanceSe
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error fetching instance account %s: %s", host, err))
 	}
 
+	// keep the current settings for the audit log
+	before, err := p.tc.InstanceToAPIV1Instance(ctx, i)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting instance to api representation: %s", err))
+	}
+
 	updatingColumns := []string{}
 
 	// validate & update site title if it's set on the form
@@ -193,6 +213,7 @@ func (p *Processor) InstancePatch(ctx context.Context, form *apimodel.InstanceSe
 		}
 		updatingColumns = append(updatingColumns, "contact_account_id")
 		i.ContactAccountID = contactAccount.ID
+		i.ContactAccount = contactAccount
 	}
 
 	// validate & update site contact email if it's set on the form
@@ -283,6 +304,8 @@ func (p *Processor) InstancePatch(ctx context.Context, form *apimodel.InstanceSe
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting instance to api representation: %s", err))
 	}
 
+	p.admin.Audit(ctx, account, gtsmodel.AuditLogActionUpdate, gtsmodel.AuditLogTargetInstance, i.ID, before, ai)
+
 	return ai, nil
 }
 
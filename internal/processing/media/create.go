@@ -19,9 +19,11 @@ package media
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 
+	"codeberg.org/gruf/go-bytesize"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -35,6 +37,11 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form
 		return f, form.File.Size, err
 	}
 
+	// Ensure the upload fits in account's storage quota.
+	if errWithCode := p.checkQuota(ctx, account, form.File.Size); errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	focusX, focusY, err := parseFocus(form.Focus)
 	if err != nil {
 		err := fmt.Errorf("could not parse focus value %s: %s", form.Focus, err)
@@ -64,3 +71,35 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form
 
 	return &apiAttachment, nil
 }
+
+// checkQuota checks whether storing media of given size in bytes would take the account
+// over its media storage quota, returning a 422 error with an explanation if so.
+func (p *Processor) checkQuota(ctx context.Context, account *gtsmodel.Account, size int64) gtserror.WithCode {
+	user, err := p.state.DB.GetUserByAccountID(ctx, account.ID)
+	if err != nil {
+		err := fmt.Errorf("error getting user for account %s: %w", account.ID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	quota := media.AccountQuota(user)
+	if quota == 0 {
+		// No limit.
+		return nil
+	}
+
+	usage, err := p.state.DB.GetAccountMediaUsage(ctx, account.ID)
+	if err != nil {
+		err := fmt.Errorf("error getting media usage for account %s: %w", account.ID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if usage+size > quota {
+		text := fmt.Sprintf(
+			"media storage quota exceeded: this upload of %s would take you over your quota of %s, of which you are currently using %s; delete some media or ask an admin to raise your quota",
+			bytesize.Size(size), bytesize.Size(quota), bytesize.Size(usage),
+		)
+		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media_test
+
+import (
+	"context"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+type CreateTestSuite struct {
+	MediaStandardTestSuite
+}
+
+func (suite *CreateTestSuite) TestCreateOverInstanceQuota() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// Zork already stores more than this.
+	config.SetMediaAccountQuota(1024 * 1024)
+
+	attachment, errWithCode := suite.mediaProcessor.Create(ctx, testAccount, &apimodel.AttachmentRequest{
+		File: &multipart.FileHeader{Filename: "test.jpg", Size: 1024},
+	})
+	suite.Nil(attachment)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+	suite.Equal("Unprocessable Entity: media storage quota exceeded: this upload of 1.00kiB would take you over your quota of 1.00MiB, of which you are currently using 4.26MiB; delete some media or ask an admin to raise your quota", errWithCode.Safe())
+}
+
+func (suite *CreateTestSuite) TestCreateOverUserQuota() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// Instance default is unlimited,
+	// but zork has an override.
+	user, err := suite.db.GetUserByAccountID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	quota := int64(4463269 + 512)
+	user.MediaQuota = &quota
+	if err := suite.db.UpdateUser(ctx, user, "media_quota"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	attachment, errWithCode := suite.mediaProcessor.Create(ctx, testAccount, &apimodel.AttachmentRequest{
+		File: &multipart.FileHeader{Filename: "test.jpg", Size: 1024},
+	})
+	suite.Nil(attachment)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func TestCreateTestSuite(t *testing.T) {
+	suite.Run(t, &CreateTestSuite{})
+}
@@ -23,7 +23,6 @@ import (
 	"fmt"
 	"strings"
 
-	"codeberg.org/gruf/go-store/v2/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 )
@@ -42,20 +41,35 @@ func (p *Processor) Delete(ctx context.Context, mediaAttachmentID string) gtserr
 
 	errs := []string{}
 
+	if !*attachment.Cached {
+		// Files were already released
+		// from storage when uncached.
+		attachment.Thumbnail.Path = ""
+		attachment.File.Path = ""
+		attachment.File.SourcePath = ""
+	}
+
 	// delete the thumbnail from storage
 	if attachment.Thumbnail.Path != "" {
-		if err := p.state.Storage.Delete(ctx, attachment.Thumbnail.Path); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		if err := p.state.Storage.Release(ctx, p.state.DB, attachment.Thumbnail.Path); err != nil {
 			errs = append(errs, fmt.Sprintf("remove thumbnail at path %s: %s", attachment.Thumbnail.Path, err))
 		}
 	}
 
 	// delete the file from storage
 	if attachment.File.Path != "" {
-		if err := p.state.Storage.Delete(ctx, attachment.File.Path); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		if err := p.state.Storage.Release(ctx, p.state.DB, attachment.File.Path); err != nil {
 			errs = append(errs, fmt.Sprintf("remove file at path %s: %s", attachment.File.Path, err))
 		}
 	}
 
+	// delete the original source file from storage
+	if attachment.File.SourcePath != "" {
+		if err := p.state.Storage.Release(ctx, p.state.DB, attachment.File.SourcePath); err != nil {
+			errs = append(errs, fmt.Sprintf("remove source file at path %s: %s", attachment.File.SourcePath, err))
+		}
+	}
+
 	// delete the attachment
 	if err := p.state.DB.DeleteAttachment(ctx, mediaAttachmentID); err != nil && !errors.Is(err, db.ErrNoEntries) {
 		errs = append(errs, fmt.Sprintf("remove attachment: %s", err))
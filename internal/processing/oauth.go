@@ -18,9 +18,11 @@
 package processing
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/oauth2/v4"
 )
 
@@ -38,3 +40,23 @@ func (p *Processor) OAuthValidateBearerToken(r *http.Request) (oauth2.TokenInfo,
 	// todo: some kind of metrics stuff here
 	return p.oauthServer.ValidationBearerToken(r)
 }
+
+func (p *Processor) OAuthRevokeToken(ctx context.Context, clientID string, clientSecret string, token string, tokenTypeHint string) gtserror.WithCode {
+	return p.oauthServer.RevokeToken(ctx, clientID, clientSecret, token, tokenTypeHint)
+}
+
+func (p *Processor) OAuthDeviceAuthorize(ctx context.Context, clientID string, clientSecret string, scope string) (map[string]interface{}, gtserror.WithCode) {
+	return p.oauthServer.DeviceAuthorize(ctx, clientID, clientSecret, scope)
+}
+
+func (p *Processor) OAuthGetDeviceAuthorization(ctx context.Context, userCode string) (*gtsmodel.DeviceAuthorization, gtserror.WithCode) {
+	return p.oauthServer.GetDeviceAuthorization(ctx, userCode)
+}
+
+func (p *Processor) OAuthResolveDeviceAuthorization(ctx context.Context, userCode string, userID string, approve bool) gtserror.WithCode {
+	return p.oauthServer.ResolveDeviceAuthorization(ctx, userCode, userID, approve)
+}
+
+func (p *Processor) OAuthHandleDeviceTokenRequest(ctx context.Context, clientID string, clientSecret string, deviceCode string) (map[string]interface{}, gtserror.WithCode) {
+	return p.oauthServer.HandleDeviceTokenRequest(ctx, clientID, clientSecret, deviceCode)
+}
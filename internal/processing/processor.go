@@ -29,6 +29,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/admin"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/fedi"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/importer"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/list"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/media"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/report"
@@ -58,6 +59,7 @@ type Processor struct {
 	account  account.Processor
 	admin    admin.Processor
 	fedi     fedi.Processor
+	importer importer.Processor
 	list     list.Processor
 	media    media.Processor
 	report   report.Processor
@@ -80,6 +82,10 @@ func (p *Processor) Fedi() *fedi.Processor {
 	return &p.fedi
 }
 
+func (p *Processor) Importer() *importer.Processor {
+	return &p.importer
+}
+
 func (p *Processor) List() *list.Processor {
 	return &p.list
 }
@@ -120,6 +126,7 @@ func NewProcessor(
 	mediaManager *mm.Manager,
 	state *state.State,
 	emailSender email.Sender,
+	mxResolver user.MXResolver,
 ) *Processor {
 	parseMentionFunc := GetParseMentionFunc(state.DB, federator)
 
@@ -136,7 +143,11 @@ func NewProcessor(
 	}
 
 	// Instantiate sub processors.
-	processor.account = account.New(state, tc, mediaManager, oauthServer, federator, filter, parseMentionFunc)
+	//
+	// The user processor goes first, as the account
+	// processor checks email addresses against it.
+	processor.user = user.New(state, oauthServer, emailSender, mxResolver)
+	processor.account = account.New(state, tc, mediaManager, oauthServer, federator, filter, parseMentionFunc, processor.user.EmailAllowed, emailSender)
 	processor.admin = admin.New(state, tc, mediaManager, federator.TransportController(), emailSender)
 	processor.fedi = fedi.New(state, tc, federator, filter)
 	processor.list = list.New(state, tc)
@@ -146,7 +157,10 @@ func NewProcessor(
 	processor.search = search.New(state, federator, tc, filter)
 	processor.status = status.New(state, federator, tc, filter, parseMentionFunc)
 	processor.stream = stream.New(state, oauthServer)
-	processor.user = user.New(state, emailSender)
+
+	// The importer goes last, as it
+	// uses the other sub processors.
+	processor.importer = importer.New(state, tc, federator, mediaManager, &processor.account, &processor.list, &processor.status)
 
 	return processor
 }
@@ -122,7 +122,7 @@ func (suite *ProcessingStandardTestSuite) SetupTest() {
 	suite.oauthServer = testrig.NewTestOauthServer(suite.db)
 	suite.emailSender = testrig.NewEmailSender("../../web/template/", nil)
 
-	suite.processor = processing.NewProcessor(suite.typeconverter, suite.federator, suite.oauthServer, suite.mediaManager, &suite.state, suite.emailSender)
+	suite.processor = processing.NewProcessor(suite.typeconverter, suite.federator, suite.oauthServer, suite.mediaManager, &suite.state, suite.emailSender, testrig.NewMockMXResolver(nil))
 	suite.state.Workers.EnqueueClientAPI = suite.processor.EnqueueClientAPI
 	suite.state.Workers.EnqueueFederator = suite.processor.EnqueueFederator
 
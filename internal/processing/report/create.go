@@ -30,6 +30,8 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
 
 // Create creates one user report / flag, using the provided form parameters.
@@ -64,6 +66,45 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form
 		}
 	}
 
+	// validate category + fetch any rules cited by the report
+	category := form.Category
+	if category == "" {
+		category = gtsmodel.ReportCategoryOther
+		if len(form.RuleIDs) > 0 {
+			category = gtsmodel.ReportCategoryViolation
+		}
+	}
+
+	if err := validate.ReportCategory(category); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if len(form.RuleIDs) > 0 && category != gtsmodel.ReportCategoryViolation {
+		err := fmt.Errorf("rule_ids can only be given with category %s", gtsmodel.ReportCategoryViolation)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// the same rule may be cited more than once
+	form.RuleIDs = util.UniqueStrings(form.RuleIDs)
+
+	rules, err := p.state.DB.GetRulesByIDs(ctx, form.RuleIDs)
+	if err != nil {
+		err = fmt.Errorf("db error fetching report rules: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if len(rules) != len(form.RuleIDs) {
+		err := errors.New("one or more rule_ids do not exist")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	for _, r := range rules {
+		if r.Deleted != nil && *r.Deleted {
+			err := fmt.Errorf("rule with ID %s has been deleted", r.ID)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+	}
+
 	reportID := id.NewULID()
 	report := &gtsmodel.Report{
 		ID:              reportID,
@@ -73,8 +114,11 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form
 		TargetAccountID: form.AccountID,
 		TargetAccount:   targetAccount,
 		Comment:         form.Comment,
+		Category:        category,
 		StatusIDs:       form.StatusIDs,
 		Statuses:        statuses,
+		RuleIDs:         form.RuleIDs,
+		Rules:           rules,
 		Forwarded:       &form.Forward,
 	}
 
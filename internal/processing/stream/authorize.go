@@ -24,6 +24,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
 // Authorize returns an oauth2 token info in response to an access token query from the streaming API
@@ -34,6 +35,12 @@ func (p *Processor) Authorize(ctx context.Context, accessToken string) (*gtsmode
 		return nil, gtserror.NewErrorUnauthorized(err)
 	}
 
+	if !oauth.ScopesPermit(ti.GetScope(), oauth.ScopeReadStatuses) &&
+		!oauth.ScopesPermit(ti.GetScope(), oauth.ScopeReadNotifications) {
+		err := fmt.Errorf("token is missing scope %s", oauth.ScopeReadStatuses)
+		return nil, gtserror.NewErrorForbidden(err, err.Error())
+	}
+
 	uid := ti.GetUserID()
 	if uid == "" {
 		err := fmt.Errorf("no userid in token")
@@ -42,6 +42,18 @@ func (suite *AuthorizeTestSuite) TestAuthorize() {
 	suite.Nil(noAccount)
 }
 
+func (suite *AuthorizeTestSuite) TestAuthorizeMissingScope() {
+	token := suite.testTokens["local_account_1"]
+	token.Scope = "write"
+	if err := suite.db.UpdateByID(context.Background(), token, token.ID, "scope"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	account, err := suite.streamProcessor.Authorize(context.Background(), token.Access)
+	suite.EqualError(err, "token is missing scope read:statuses")
+	suite.Nil(account)
+}
+
 func TestAuthorizeTestSuite(t *testing.T) {
 	suite.Run(t, &AuthorizeTestSuite{})
 }
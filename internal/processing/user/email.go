@@ -21,6 +21,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
+	"net/mail"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -29,11 +32,138 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+	"golang.org/x/crypto/bcrypt"
 )
 
 var oneWeek = 168 * time.Hour
 
+// mxLookupTimeout is the maximum time to wait
+// for an MX lookup when checking email domains.
+const mxLookupTimeout = 5 * time.Second
+
+// MXResolver looks up the mail exchangers of a domain.
+// It is satisfied by *net.Resolver, and exists so
+// that tests can substitute a local stand-in.
+type MXResolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+}
+
+// EmailAllowed checks the domain of the given email address against the
+// email domain blocks of this instance, returning a 400 error if it is
+// blocked. The domain's MX hostnames are checked as well, so that blocks
+// on a mail provider also catch any vanity domains which it handles.
+//
+// Failure to look up MX records is not treated as an error.
+func (p *Processor) EmailAllowed(ctx context.Context, address string) gtserror.WithCode {
+	m, err := mail.ParseAddress(address)
+	if err != nil {
+		err := fmt.Errorf("error parsing email address %s: %w", address, err)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Domain will always be the part after the final @.
+	domain := m.Address[strings.LastIndex(m.Address, "@")+1:]
+
+	blocked, err := p.state.DB.IsEmailDomainBlocked(ctx, domain)
+	if err != nil {
+		err := fmt.Errorf("error checking email domain %s: %w", domain, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if blocked {
+		err := fmt.Errorf("email domain %s is blocked", domain)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if p.mxResolver == nil {
+		return nil
+	}
+
+	mxCtx, cancel := context.WithTimeout(ctx, mxLookupTimeout)
+	defer cancel()
+
+	mxs, err := p.mxResolver.LookupMX(mxCtx, domain)
+	if err != nil {
+		log.Debugf(ctx, "error looking up mx records for %s: %v", domain, err)
+		return nil
+	}
+
+	for _, mx := range mxs {
+		host := strings.TrimSuffix(mx.Host, ".")
+		if host == "" {
+			continue
+		}
+
+		blocked, err := p.state.DB.IsEmailDomainBlocked(ctx, host)
+		if err != nil {
+			err := fmt.Errorf("error checking mx host %s: %w", host, err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		if blocked {
+			err := fmt.Errorf("email domain %s is blocked", domain)
+			return gtserror.NewErrorBadRequest(err, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// EmailChange processes a request from the given user to change their
+// email address. The new address is stored as unconfirmed, and only
+// replaces the current address once confirmed by the emailed link.
+func (p *Processor) EmailChange(ctx context.Context, user *gtsmodel.User, password string, newEmail string) gtserror.WithCode {
+	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(password)); err != nil {
+		return gtserror.NewErrorUnauthorized(err, "password was incorrect")
+	}
+
+	if err := validate.Email(newEmail); err != nil {
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if newEmail == user.Email {
+		err := errors.New("new email address is the same as the current one")
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	available, err := p.state.DB.IsEmailAvailable(ctx, newEmail)
+	if err != nil {
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if !available {
+		err := fmt.Errorf("email address %s is not available", newEmail)
+		return gtserror.NewErrorConflict(err, err.Error())
+	}
+
+	if errWithCode := p.EmailAllowed(ctx, newEmail); errWithCode != nil {
+		return errWithCode
+	}
+
+	if user.Account == nil {
+		account, err := p.state.DB.GetAccountByID(ctx, user.AccountID)
+		if err != nil {
+			err := fmt.Errorf("error getting account for user %s: %w", user.ID, err)
+			return gtserror.NewErrorInternalError(err)
+		}
+		user.Account = account
+	}
+
+	user.UnconfirmedEmail = newEmail
+	if err := p.state.DB.UpdateUser(ctx, user, "unconfirmed_email"); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.EmailSendConfirmation(ctx, user, user.Account.Username); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
 // EmailSendConfirmation sends an email address confirmation request email to the given user.
 func (p *Processor) EmailSendConfirmation(ctx context.Context, user *gtsmodel.User, username string) error {
 	if user.UnconfirmedEmail == "" || user.UnconfirmedEmail == user.Email {
@@ -71,13 +201,12 @@ func (p *Processor) EmailSendConfirmation(ctx context.Context, user *gtsmodel.Us
 	}
 
 	// email sent, now we need to update the user entry with the token we just sent them
-	updatingColumns := []string{"confirmation_sent_at", "confirmation_token", "last_emailed_at", "updated_at"}
+	updatingColumns := []string{"confirmation_sent_at", "confirmation_token", "last_emailed_at"}
 	user.ConfirmationSentAt = time.Now()
 	user.ConfirmationToken = confirmationToken
 	user.LastEmailedAt = time.Now()
-	user.UpdatedAt = time.Now()
 
-	if err := p.state.DB.UpdateByID(ctx, user, user.ID, updatingColumns...); err != nil {
+	if err := p.state.DB.UpdateUser(ctx, user, updatingColumns...); err != nil {
 		return fmt.Errorf("SendConfirmEmail: error updating user entry after email sent: %s", err)
 	}
 
@@ -110,6 +110,74 @@ func (suite *EmailConfirmTestSuite) TestConfirmEmailOldToken() {
 	suite.EqualError(errWithCode, "ConfirmEmail: confirmation token expired")
 }
 
+func (suite *EmailConfirmTestSuite) TestEmailAllowed() {
+	ctx := context.Background()
+
+	for address, expectedErr := range map[string]string{
+		// Not blocked.
+		"someone@example.org": "",
+		// No MX records, not blocked.
+		"someone@unknown.example.net": "",
+		// Blocked directly.
+		"someone@disposable.example.org": "email domain disposable.example.org is blocked",
+		// Blocked via parent domain.
+		"someone@mail.disposable.example.org": "email domain mail.disposable.example.org is blocked",
+		// Blocked via mail exchanger.
+		"someone@vanity.example.com": "email domain vanity.example.com is blocked",
+	} {
+		errWithCode := suite.user.EmailAllowed(ctx, address)
+		if expectedErr == "" {
+			suite.Nil(errWithCode, address)
+			continue
+		}
+		suite.EqualError(errWithCode, expectedErr, address)
+	}
+}
+
+func (suite *EmailConfirmTestSuite) TestEmailChange() {
+	ctx := context.Background()
+
+	user := suite.testUsers["local_account_1"]
+
+	errWithCode := suite.user.EmailChange(ctx, user, "password", "new.email@example.org")
+	suite.NoError(errWithCode)
+
+	// Address shouldn't change until confirmed.
+	dbUser, err := suite.db.GetUserByID(ctx, user.ID)
+	suite.NoError(err)
+	suite.Equal("zork@example.org", dbUser.Email)
+	suite.Equal("new.email@example.org", dbUser.UnconfirmedEmail)
+	suite.NotEmpty(dbUser.ConfirmationToken)
+
+	// Confirmation should have gone to the new address.
+	suite.Len(suite.sentEmails, 1)
+	suite.Contains(suite.sentEmails["new.email@example.org"], dbUser.ConfirmationToken)
+}
+
+func (suite *EmailConfirmTestSuite) TestEmailChangeWrongPassword() {
+	user := suite.testUsers["local_account_1"]
+
+	errWithCode := suite.user.EmailChange(context.Background(), user, "wrong password", "new.email@example.org")
+	suite.EqualError(errWithCode, "crypto/bcrypt: hashedPassword is not the hash of the given password")
+	suite.Empty(suite.sentEmails)
+}
+
+func (suite *EmailConfirmTestSuite) TestEmailChangeBlockedDomain() {
+	user := suite.testUsers["local_account_1"]
+
+	errWithCode := suite.user.EmailChange(context.Background(), user, "password", "new.email@vanity.example.com")
+	suite.EqualError(errWithCode, "email domain vanity.example.com is blocked")
+	suite.Empty(suite.sentEmails)
+}
+
+func (suite *EmailConfirmTestSuite) TestEmailChangeInUse() {
+	user := suite.testUsers["local_account_1"]
+
+	errWithCode := suite.user.EmailChange(context.Background(), user, "password", suite.testUsers["local_account_2"].Email)
+	suite.EqualError(errWithCode, "email address tortle.dude@example.org is not available")
+	suite.Empty(suite.sentEmails)
+}
+
 func TestEmailConfirmTestSuite(t *testing.T) {
 	suite.Run(t, &EmailConfirmTestSuite{})
 }
@@ -0,0 +1,428 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"codeberg.org/gruf/go-runners"
+	"codeberg.org/gruf/go-sched"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+const (
+	// digestCheckInterval is how often we check
+	// whether any users are due a digest email.
+	digestCheckInterval = time.Hour
+
+	// digestNotificationsLimit is the maximum number
+	// of notifications to include in one digest email.
+	// Any further notifications are only counted.
+	digestNotificationsLimit = 100
+
+	// digestContentLength is the maximum length in runes of
+	// status content included in notification + digest emails.
+	digestContentLength = 500
+)
+
+// digestSectionTitles contains the title of
+// the digest section for each email type.
+var digestSectionTitles = map[gtsmodel.EmailType]string{
+	gtsmodel.EmailTypeMentions:       "Mentions",
+	gtsmodel.EmailTypeDirect:         "Direct messages",
+	gtsmodel.EmailTypeFollows:        "New followers",
+	gtsmodel.EmailTypeFollowRequests: "Follow requests",
+	gtsmodel.EmailTypeReportsClosed:  "Closed reports",
+}
+
+// scheduleDigests schedules a check for users who are
+// due a digest email to be run every hour, on the hour.
+func (p *Processor) scheduleDigests() {
+	// Get ctx associated with scheduler run state.
+	done := p.state.Workers.Scheduler.Done()
+	doneCtx := runners.CancelCtx(done)
+
+	next := time.Now().Truncate(digestCheckInterval).Add(digestCheckInterval)
+
+	p.state.Workers.Scheduler.Schedule(sched.NewJob(func(start time.Time) {
+		log.Info(nil, "starting email digests")
+		if err := p.EmailDigests(doneCtx, start); err != nil {
+			log.Errorf(nil, "error sending email digests: %v", err)
+			return
+		}
+		log.Infof(nil, "finished email digests after %s", time.Since(start))
+	}).EveryAt(next, digestCheckInterval))
+}
+
+// EmailDigests sends a digest email to each user who has opted in to
+// digests for at least one email type, and who hasn't been sent a
+// digest within their chosen digest period as of the given time.
+//
+// The given time is truncated to the hour, so that the small
+// delays in running the scheduled job don't push back digests.
+func (p *Processor) EmailDigests(ctx context.Context, now time.Time) error {
+	users, err := p.state.DB.GetUsersForEmailDigest(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting users: %w", err)
+	}
+
+	now = now.Truncate(digestCheckInterval)
+
+	for _, user := range users {
+		if !user.CanBeEmailed() {
+			continue
+		}
+
+		period := 24 * time.Hour
+		if user.DigestFrequency() == gtsmodel.EmailDigestWeekly {
+			period *= 7
+		}
+
+		since := user.LastDigestAt
+		if since.IsZero() {
+			// Never sent a digest, cover
+			// just the last period.
+			since = now.Add(-period)
+		}
+
+		if now.Sub(since) < period {
+			// Not due yet.
+			continue
+		}
+
+		if err := p.emailDigest(ctx, user, since, now); err != nil {
+			log.Errorf(ctx, "error sending email digest to user %s: %v", user.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// emailDigest sends one digest email to the given user, containing
+// digested notifications + closed reports between since and until.
+func (p *Processor) emailDigest(ctx context.Context, user *gtsmodel.User, since time.Time, until time.Time) error {
+	items := make(map[gtsmodel.EmailType][]email.DigestItem)
+
+	digested := func(t gtsmodel.EmailType) bool {
+		return user.EmailPreference(t) == gtsmodel.EmailPreferenceDigest
+	}
+
+	// Only fetch the notification types we may actually digest.
+	excludeTypes := []string{
+		string(gtsmodel.NotificationReblog),
+		string(gtsmodel.NotificationFave),
+		string(gtsmodel.NotificationPoll),
+		string(gtsmodel.NotificationStatus),
+		string(gtsmodel.NotificationReportNote),
+	}
+	if !digested(gtsmodel.EmailTypeMentions) && !digested(gtsmodel.EmailTypeDirect) {
+		excludeTypes = append(excludeTypes, string(gtsmodel.NotificationMention))
+	}
+	if !digested(gtsmodel.EmailTypeFollows) {
+		excludeTypes = append(excludeTypes, string(gtsmodel.NotificationFollow))
+	}
+	if !digested(gtsmodel.EmailTypeFollowRequests) {
+		excludeTypes = append(excludeTypes, string(gtsmodel.NotificationFollowRequest))
+	}
+
+	var (
+		included int
+		more     = make(map[gtsmodel.EmailType]int)
+		maxID    = timeBoundID(until, id.Lowest)
+	)
+
+	// Page through all notifications in the period,
+	// so that those past the limit can still be counted.
+	for {
+		notifs, err := p.state.DB.GetAccountNotifications(
+			ctx,
+			user.AccountID,
+			maxID,
+			timeBoundID(since, id.Lowest),
+			"",
+			digestNotificationsLimit,
+			excludeTypes,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting notifications: %w", err)
+		}
+
+		if len(notifs) == 0 {
+			break
+		}
+		maxID = notifs[len(notifs)-1].ID
+
+		for _, notif := range notifs {
+			emailType, item, err := p.notificationItem(ctx, notif)
+			if err != nil {
+				log.Debugf(ctx, "skipping notification %s: %v", notif.ID, err)
+				continue
+			}
+
+			if emailType == "" || !digested(emailType) {
+				continue
+			}
+
+			if included >= digestNotificationsLimit {
+				// Too many to list.
+				more[emailType]++
+				continue
+			}
+
+			items[emailType] = append(items[emailType], item)
+			included++
+		}
+	}
+
+	if digested(gtsmodel.EmailTypeReportsClosed) {
+		resolved := true
+		reports, err := p.state.DB.GetReports(ctx, &resolved, user.AccountID, "", "", "", "", 0)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting reports: %w", err)
+		}
+
+		for _, report := range reports {
+			if !report.ActionTakenAt.After(since) || report.ActionTakenAt.After(until) {
+				continue
+			}
+
+			items[gtsmodel.EmailTypeReportsClosed] = append(
+				items[gtsmodel.EmailTypeReportsClosed],
+				reportClosedItem(report),
+			)
+		}
+	}
+
+	if len(items) == 0 && len(more) == 0 {
+		// Nothing to tell
+		// the user about.
+		return nil
+	}
+
+	sections := make([]email.DigestSection, 0, len(items))
+	for _, t := range gtsmodel.EmailTypes {
+		if len(items[t]) == 0 && more[t] == 0 {
+			continue
+		}
+
+		sections = append(sections, email.DigestSection{
+			Title: digestSectionTitles[t],
+			Items: items[t],
+			More:  more[t],
+		})
+	}
+
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return gtserror.Newf("db error getting instance: %w", err)
+	}
+
+	unsubscribeURL, err := p.EmailUnsubscribeURL(ctx, user, UnsubscribeDigest)
+	if err != nil {
+		return err
+	}
+
+	digestData := email.DigestData{
+		Username:       user.Account.Username,
+		InstanceURL:    instance.URI,
+		InstanceName:   instance.Title,
+		Frequency:      string(user.DigestFrequency()),
+		Sections:       sections,
+		UnsubscribeURL: unsubscribeURL,
+	}
+
+	if err := p.emailSender.SendDigestEmail(user.Email, digestData); err != nil {
+		return gtserror.Newf("error sending email: %w", err)
+	}
+
+	user.LastDigestAt = until
+	user.LastEmailedAt = time.Now()
+	if err := p.state.DB.UpdateUser(ctx, user, "last_digest_at", "last_emailed_at"); err != nil {
+		return gtserror.Newf("db error updating user: %w", err)
+	}
+
+	return nil
+}
+
+// EmailNotification emails the target of the given notification
+// about it straight away, if they've chosen to be emailed immediately
+// about notifications of its type. Otherwise, it does nothing.
+func (p *Processor) EmailNotification(ctx context.Context, notif *gtsmodel.Notification) error {
+	user, err := p.state.DB.GetUserByAccountID(ctx, notif.TargetAccountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// Not a local user.
+			return nil
+		}
+		return gtserror.Newf("db error getting user: %w", err)
+	}
+
+	if !user.CanBeEmailed() {
+		return nil
+	}
+
+	if user.Account == nil {
+		user.Account, err = p.state.DB.GetAccountByID(ctx, user.AccountID)
+		if err != nil {
+			return gtserror.Newf("db error getting account: %w", err)
+		}
+	}
+
+	emailType, item, err := p.notificationItem(ctx, notif)
+	if err != nil {
+		return err
+	}
+
+	if emailType == "" || user.EmailPreference(emailType) != gtsmodel.EmailPreferenceImmediate {
+		return nil
+	}
+
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return gtserror.Newf("db error getting instance: %w", err)
+	}
+
+	unsubscribeURL, err := p.EmailUnsubscribeURL(ctx, user, string(emailType))
+	if err != nil {
+		return err
+	}
+
+	notificationData := email.NotificationData{
+		Username:       user.Account.Username,
+		InstanceURL:    instance.URI,
+		InstanceName:   instance.Title,
+		Item:           item,
+		UnsubscribeURL: unsubscribeURL,
+	}
+
+	if err := p.emailSender.SendNotificationEmail(user.Email, notificationData); err != nil {
+		return gtserror.Newf("error sending email: %w", err)
+	}
+
+	return nil
+}
+
+// notificationItem returns the email type of the given notification,
+// along with an item describing it for use in emails. If the
+// notification isn't of a type that can be emailed, the returned
+// email type will be empty.
+func (p *Processor) notificationItem(ctx context.Context, notif *gtsmodel.Notification) (gtsmodel.EmailType, email.DigestItem, error) {
+	var (
+		emailType gtsmodel.EmailType
+		item      email.DigestItem
+	)
+
+	switch notif.NotificationType {
+	case gtsmodel.NotificationMention, gtsmodel.NotificationFollow, gtsmodel.NotificationFollowRequest:
+		// Can be emailed.
+	default:
+		return "", item, nil
+	}
+
+	origin, err := p.state.DB.GetAccountByID(ctx, notif.OriginAccountID)
+	if err != nil {
+		return "", item, gtserror.Newf("db error getting origin account: %w", err)
+	}
+	handle := "@" + origin.Username
+	if origin.Domain != "" {
+		handle += "@" + origin.Domain
+	}
+
+	switch notif.NotificationType {
+	case gtsmodel.NotificationMention:
+		status, err := p.state.DB.GetStatusByID(ctx, notif.StatusID)
+		if err != nil {
+			return "", item, gtserror.Newf("db error getting status: %w", err)
+		}
+
+		if status.Visibility == gtsmodel.VisibilityDirect {
+			emailType = gtsmodel.EmailTypeDirect
+			item.Summary = handle + " sent you a direct message"
+		} else {
+			emailType = gtsmodel.EmailTypeMentions
+			item.Summary = handle + " mentioned you"
+		}
+		item.URL = status.URL
+
+		if status.ContentWarning != "" {
+			// Don't put content behind a
+			// content warning into emails.
+			item.Content = "Content warning: " + text.SanitizePlaintext(status.ContentWarning)
+		} else {
+			item.Content = truncate(text.SanitizePlaintext(status.Content), digestContentLength)
+		}
+
+	case gtsmodel.NotificationFollow:
+		emailType = gtsmodel.EmailTypeFollows
+		item.Summary = handle + " followed you"
+		item.URL = origin.URL
+
+	case gtsmodel.NotificationFollowRequest:
+		emailType = gtsmodel.EmailTypeFollowRequests
+		item.Summary = handle + " requested to follow you"
+		item.URL = origin.URL
+	}
+
+	return emailType, item, nil
+}
+
+// reportClosedItem returns an item describing the
+// given closed report, for use in digest emails.
+// The report's target account must be populated.
+func reportClosedItem(report *gtsmodel.Report) email.DigestItem {
+	handle := "@" + report.TargetAccount.Username
+	if report.TargetAccount.Domain != "" {
+		handle += "@" + report.TargetAccount.Domain
+	}
+
+	return email.DigestItem{
+		Summary: "Your report of " + handle + " was closed",
+		Content: report.ActionTaken,
+		URL:     report.TargetAccount.URL,
+	}
+}
+
+// timeBoundID returns an ID with the timestamp part of the given
+// time, and the random part taken from the given bound ID, to use
+// as a boundary when paging through IDs created around that time.
+func timeBoundID(t time.Time, bound string) string {
+	ulid, err := id.NewULIDFromTime(t)
+	if err != nil {
+		// Should never happen.
+		panic(err)
+	}
+	return ulid[:10] + bound[10:]
+}
+
+// truncate returns s cut down to at most n runes.
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
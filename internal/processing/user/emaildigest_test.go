@@ -0,0 +1,222 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type EmailDigestTestSuite struct {
+	UserStandardTestSuite
+}
+
+// putNotification stores a notification of the given type for
+// zork, created at the given time, and returns it.
+func (suite *EmailDigestTestSuite) putNotification(notificationType gtsmodel.NotificationType, originAccountID string, statusID string, createdAt time.Time) *gtsmodel.Notification {
+	notifID, err := id.NewULIDFromTime(createdAt)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	notif := &gtsmodel.Notification{
+		ID:               notifID,
+		NotificationType: notificationType,
+		TargetAccountID:  suite.testUsers["local_account_1"].AccountID,
+		OriginAccountID:  originAccountID,
+		StatusID:         statusID,
+	}
+
+	if err := suite.db.PutNotification(context.Background(), notif); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return notif
+}
+
+func (suite *EmailDigestTestSuite) TestEmailDigest() {
+	ctx := context.Background()
+	now := time.Now()
+
+	user := suite.testUsers["local_account_1"]
+	user.EmailMentions = string(gtsmodel.EmailPreferenceDigest)
+	user.EmailFollows = string(gtsmodel.EmailPreferenceDigest)
+	user.LastDigestAt = now.Add(-25 * time.Hour)
+
+	// Zork was sent some other email recently,
+	// which shouldn't hold back their digest.
+	user.LastEmailedAt = now.Add(-time.Hour)
+	if err := suite.db.UpdateUser(ctx, user, "email_mentions", "email_follows", "last_digest_at", "last_emailed_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Admin mentions zork + then
+	// a turtle follows zork.
+	suite.putNotification(gtsmodel.NotificationMention, "01F8MH17FWEB39HZJ76B6VXSKF", "01F8MH75CBF9JFX4ZAD54N0W0R", now.Add(-3*time.Hour))
+	suite.putNotification(gtsmodel.NotificationFollow, "01F8MH5NBDF2MV7CTC4Q5128HF", "", now.Add(-2*time.Hour))
+
+	// Turtle also DMs zork, but
+	// zork doesn't digest DMs.
+	suite.putNotification(gtsmodel.NotificationMention, "01F8MH5NBDF2MV7CTC4Q5128HF", "01FN3VJGFH10KR7S2PB0GFJZYG", now.Add(-2*time.Hour))
+
+	if err := suite.user.EmailDigests(ctx, now); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbUser, err := suite.db.GetUserByID(ctx, user.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Zork should have been given an unsubscribe token,
+	// and the digest should be recorded as sent.
+	suite.NotEmpty(dbUser.UnsubscribeToken)
+	suite.Equal(now.Truncate(time.Hour).Unix(), dbUser.LastDigestAt.Unix())
+	suite.True(dbUser.LastEmailedAt.After(user.LastEmailedAt))
+
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal(fmt.Sprintf("To: zork@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Digest\r\nList-Unsubscribe: <http://localhost:8080/unsubscribe?token=%[1]s&type=digest>\r\nList-Unsubscribe-Post: List-Unsubscribe=One-Click\r\n\r\nHello the_mighty_zork!\r\n\r\nHere's your daily digest from GoToSocial Testrig Instance (http://localhost:8080).\r\n\r\nMentions\r\n\r\n- @admin mentioned you\r\n  hello world! #welcome ! first post on the instance :rainbow: !\r\n  http://localhost:8080/@admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R\r\n\r\nNew followers\r\n\r\n- @1happyturtle followed you\r\n  http://localhost:8080/@1happyturtle\r\n\r\nIf you no longer want to receive digest emails, visit: http://localhost:8080/unsubscribe?token=%[1]s&type=digest\r\n\r\n", dbUser.UnsubscribeToken), suite.sentEmails["zork@example.org"])
+}
+
+func (suite *EmailDigestTestSuite) TestEmailDigestMore() {
+	ctx := context.Background()
+	now := time.Now()
+
+	user := suite.testUsers["local_account_1"]
+	user.EmailFollows = string(gtsmodel.EmailPreferenceDigest)
+	user.LastDigestAt = now.Add(-25 * time.Hour)
+	if err := suite.db.UpdateUser(ctx, user, "email_follows", "last_digest_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// More follows than fit in one digest.
+	for i := 0; i < 103; i++ {
+		suite.putNotification(gtsmodel.NotificationFollow, "01F8MH5NBDF2MV7CTC4Q5128HF", "", now.Truncate(time.Hour).Add(-time.Duration(i+1)*time.Minute))
+	}
+
+	if err := suite.user.EmailDigests(ctx, now); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// The first 100 should be listed, and
+	// the rest summed up rather than dropped.
+	sent := suite.sentEmails["zork@example.org"]
+	suite.Equal(100, strings.Count(sent, "- @1happyturtle followed you"))
+	suite.Contains(sent, "- ...and 3 more\r\n")
+}
+
+func (suite *EmailDigestTestSuite) TestEmailDigestNotDue() {
+	ctx := context.Background()
+	now := time.Now()
+
+	user := suite.testUsers["local_account_1"]
+	user.EmailMentions = string(gtsmodel.EmailPreferenceDigest)
+	user.EmailDigestFrequency = string(gtsmodel.EmailDigestWeekly)
+	user.LastDigestAt = now.Add(-72 * time.Hour)
+	if err := suite.db.UpdateUser(ctx, user, "email_mentions", "email_digest_frequency", "last_digest_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.putNotification(gtsmodel.NotificationMention, "01F8MH17FWEB39HZJ76B6VXSKF", "01F8MH75CBF9JFX4ZAD54N0W0R", now.Add(-3*time.Hour))
+
+	if err := suite.user.EmailDigests(ctx, now); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Zork was emailed 3 days ago, and
+	// only wants weekly digests, so no
+	// email should have been sent yet.
+	suite.Empty(suite.sentEmails)
+}
+
+func (suite *EmailDigestTestSuite) TestEmailNotificationImmediate() {
+	ctx := context.Background()
+
+	user := suite.testUsers["local_account_1"]
+	user.EmailDirect = string(gtsmodel.EmailPreferenceImmediate)
+	if err := suite.db.UpdateUser(ctx, user, "email_direct"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	notif := suite.putNotification(gtsmodel.NotificationMention, "01F8MH5NBDF2MV7CTC4Q5128HF", "01FN3VJGFH10KR7S2PB0GFJZYG", time.Now())
+	if err := suite.user.EmailNotification(ctx, notif); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbUser, err := suite.db.GetUserByID(ctx, user.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal(fmt.Sprintf("To: zork@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Notification\r\nList-Unsubscribe: <http://localhost:8080/unsubscribe?token=%[1]s&type=direct>\r\nList-Unsubscribe-Post: List-Unsubscribe=One-Click\r\n\r\nHello the_mighty_zork!\r\n\r\n@1happyturtle sent you a direct message on GoToSocial Testrig Instance (http://localhost:8080).\r\n\r\n🐢 @the_mighty_zork hi zork, this is a direct message, shhhhhh! 🐢\r\n\r\nTo see it, visit: http://localhost:8080/@1happyturtle/statuses/01FN3VJGFH10KR7S2PB0GFJZYG\r\n\r\nIf you no longer want to receive emails like this, visit: http://localhost:8080/unsubscribe?token=%[1]s&type=direct\r\n\r\n", dbUser.UnsubscribeToken), suite.sentEmails["zork@example.org"])
+}
+
+func (suite *EmailDigestTestSuite) TestEmailNotificationOff() {
+	// Zork hasn't opted in to emails
+	// about follows, so nothing is sent.
+	notif := suite.putNotification(gtsmodel.NotificationFollow, "01F8MH5NBDF2MV7CTC4Q5128HF", "", time.Now())
+	if err := suite.user.EmailNotification(context.Background(), notif); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Empty(suite.sentEmails)
+}
+
+func (suite *EmailDigestTestSuite) TestEmailUnsubscribe() {
+	ctx := context.Background()
+
+	user := suite.testUsers["local_account_1"]
+	user.EmailMentions = string(gtsmodel.EmailPreferenceDigest)
+	user.EmailFollows = string(gtsmodel.EmailPreferenceDigest)
+	user.EmailDirect = string(gtsmodel.EmailPreferenceImmediate)
+	user.UnsubscribeToken = "d2fa3cbb-7dce-4b77-9b45-4c3be0c7a9b3"
+	if err := suite.db.UpdateUser(ctx, user, "email_mentions", "email_follows", "email_direct", "unsubscribe_token"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Unsubscribing from digests should
+	// leave immediate emails alone.
+	unsubscribed, errWithCode := suite.user.EmailUnsubscribe(ctx, user.UnsubscribeToken, "digest")
+	suite.NoError(errWithCode)
+	suite.Equal(user.ID, unsubscribed.ID)
+
+	prefs := suite.user.EmailPreferencesGet(unsubscribed)
+	suite.Equal("off", prefs.Mentions)
+	suite.Equal("off", prefs.Follows)
+	suite.Equal("immediate", prefs.Direct)
+	suite.Equal("immediate", prefs.ReportsClosed)
+
+	unsubscribed, errWithCode = suite.user.EmailUnsubscribe(ctx, user.UnsubscribeToken, "reports_closed")
+	suite.NoError(errWithCode)
+	suite.Equal("off", suite.user.EmailPreferencesGet(unsubscribed).ReportsClosed)
+
+	_, errWithCode = suite.user.EmailUnsubscribe(ctx, "not-a-real-token", "digest")
+	suite.EqualError(errWithCode, "no entries")
+}
+
+func TestEmailDigestTestSuite(t *testing.T) {
+	suite.Run(t, &EmailDigestTestSuite{})
+}
@@ -0,0 +1,163 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// UnsubscribeDigest is the unsubscribe type used in links in digest
+// emails. Unsubscribing with it turns off all digested email types.
+const UnsubscribeDigest = "digest"
+
+// EmailPreferencesGet returns the email preferences of the given user.
+func (p *Processor) EmailPreferencesGet(user *gtsmodel.User) *apimodel.EmailPreferences {
+	return &apimodel.EmailPreferences{
+		Mentions:        string(user.EmailPreference(gtsmodel.EmailTypeMentions)),
+		Direct:          string(user.EmailPreference(gtsmodel.EmailTypeDirect)),
+		Follows:         string(user.EmailPreference(gtsmodel.EmailTypeFollows)),
+		FollowRequests:  string(user.EmailPreference(gtsmodel.EmailTypeFollowRequests)),
+		ReportsClosed:   string(user.EmailPreference(gtsmodel.EmailTypeReportsClosed)),
+		DigestFrequency: string(user.DigestFrequency()),
+	}
+}
+
+// EmailPreferencesUpdate updates the email preferences of the given user
+// with any values set in the given form, and returns the new preferences.
+func (p *Processor) EmailPreferencesUpdate(
+	ctx context.Context,
+	user *gtsmodel.User,
+	form *apimodel.EmailPreferencesUpdateRequest,
+) (*apimodel.EmailPreferences, gtserror.WithCode) {
+	var columns []string
+
+	for _, update := range []struct {
+		emailType gtsmodel.EmailType
+		pref      *string
+	}{
+		{gtsmodel.EmailTypeMentions, form.Mentions},
+		{gtsmodel.EmailTypeDirect, form.Direct},
+		{gtsmodel.EmailTypeFollows, form.Follows},
+		{gtsmodel.EmailTypeFollowRequests, form.FollowRequests},
+		{gtsmodel.EmailTypeReportsClosed, form.ReportsClosed},
+	} {
+		emailType, pref := update.emailType, update.pref
+		if pref == nil {
+			continue
+		}
+
+		if err := validate.EmailPreference(gtsmodel.EmailPreference(*pref)); err != nil {
+			err = fmt.Errorf("%s: %w", emailType, err)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		columns = append(columns, user.SetEmailPreference(emailType, gtsmodel.EmailPreference(*pref)))
+	}
+
+	if form.DigestFrequency != nil {
+		if err := validate.EmailDigestFrequency(gtsmodel.EmailDigestFrequency(*form.DigestFrequency)); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		user.EmailDigestFrequency = *form.DigestFrequency
+		columns = append(columns, "email_digest_frequency")
+	}
+
+	if len(columns) == 0 {
+		err := errors.New("no email preferences provided")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if err := p.state.DB.UpdateUser(ctx, user, columns...); err != nil {
+		err = gtserror.Newf("db error updating user: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.EmailPreferencesGet(user), nil
+}
+
+// EmailUnsubscribe processes a request to stop receiving the given type of
+// email, usually initiated as a result of clicking on an unsubscribe link.
+func (p *Processor) EmailUnsubscribe(ctx context.Context, token string, emailType string) (*gtsmodel.User, gtserror.WithCode) {
+	if token == "" {
+		return nil, gtserror.NewErrorNotFound(errors.New("no token provided"))
+	}
+
+	user, err := p.state.DB.GetUserByUnsubscribeToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	var columns []string
+	if emailType == UnsubscribeDigest {
+		// Turn off everything
+		// that was being digested.
+		for _, t := range gtsmodel.EmailTypes {
+			if user.EmailPreference(t) == gtsmodel.EmailPreferenceDigest {
+				columns = append(columns, user.SetEmailPreference(t, gtsmodel.EmailPreferenceOff))
+			}
+		}
+	} else {
+		column := user.SetEmailPreference(gtsmodel.EmailType(emailType), gtsmodel.EmailPreferenceOff)
+		if column == "" {
+			err := fmt.Errorf("email type '%s' was not recognized", emailType)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		columns = append(columns, column)
+	}
+
+	if len(columns) == 0 {
+		// Nothing to do.
+		return user, nil
+	}
+
+	if err := p.state.DB.UpdateUser(ctx, user, columns...); err != nil {
+		err = gtserror.Newf("db error updating user: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return user, nil
+}
+
+// EmailUnsubscribeURL returns a link the given user can use to stop receiving
+// the given type of email, generating them an unsubscribe token if necessary.
+func (p *Processor) EmailUnsubscribeURL(ctx context.Context, user *gtsmodel.User, emailType string) (string, error) {
+	if user.UnsubscribeToken == "" {
+		// Like confirmation tokens, use a uuid
+		// since it's basically impossible to guess.
+		user.UnsubscribeToken = uuid.NewString()
+		if err := p.state.DB.UpdateUser(ctx, user, "unsubscribe_token"); err != nil {
+			return "", gtserror.Newf("db error updating user: %w", err)
+		}
+	}
+
+	return uris.GenerateURIForEmailUnsubscribe(user.UnsubscribeToken, emailType), nil
+}
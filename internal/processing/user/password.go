@@ -27,6 +27,7 @@ import (
 )
 
 // PasswordChange processes a password change request for the given user.
+// On success, all OAuth tokens belonging to the user are revoked.
 func (p *Processor) PasswordChange(ctx context.Context, user *gtsmodel.User, oldPassword string, newPassword string) gtserror.WithCode {
 	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(oldPassword)); err != nil {
 		return gtserror.NewErrorUnauthorized(err, "old password was incorrect")
@@ -47,5 +48,13 @@ func (p *Processor) PasswordChange(ctx context.Context, user *gtsmodel.User, old
 		return gtserror.NewErrorInternalError(err)
 	}
 
+	// Revoke all tokens for this user,
+	// so that anyone who may have got
+	// hold of the old password is also
+	// signed out of any apps they used.
+	if err := p.state.DB.DeleteTokensByUserID(ctx, user.ID); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
 	return nil
 }
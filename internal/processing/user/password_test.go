@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -48,6 +49,13 @@ func (suite *ChangePasswordTestSuite) TestChangePasswordOK() {
 	// check the password has changed
 	err = bcrypt.CompareHashAndPassword([]byte(dbUser.EncryptedPassword), []byte("verygoodnewpassword"))
 	suite.NoError(err)
+
+	// check the user's tokens have been revoked
+	tokens, err := suite.db.GetAccessTokensByUserID(context.Background(), user.ID)
+	suite.NoError(err)
+	suite.Empty(tokens)
+	_, err = suite.db.GetTokenByID(context.Background(), suite.testTokens["local_account_1"].ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
 }
 
 func (suite *ChangePasswordTestSuite) TestChangePasswordIncorrectOld() {
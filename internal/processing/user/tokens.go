@@ -0,0 +1,247 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// maxPersonalTokenNameChars is the maximum
+// length of the name of a personal access token.
+const maxPersonalTokenNameChars = 64
+
+// TokensGet returns the OAuth access tokens that the given user has
+// authorized applications to use, and the personal access tokens
+// they've created, newest first.
+func (p *Processor) TokensGet(ctx context.Context, user *gtsmodel.User) ([]*apimodel.TokenInfo, gtserror.WithCode) {
+	tokens, err := p.state.DB.GetAccessTokensByUserID(ctx, user.ID)
+	if err != nil {
+		err := fmt.Errorf("TokensGet: db error getting tokens: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiTokens := make([]*apimodel.TokenInfo, 0, len(tokens))
+	for _, token := range tokens {
+		apiToken, err := p.apiTokenInfo(ctx, token)
+		if err != nil {
+			// Don't fail the whole
+			// list for one bad token.
+			log.Errorf(ctx, "error converting token %s: %v", token.ID, err)
+			continue
+		}
+		apiTokens = append(apiTokens, apiToken)
+	}
+
+	return apiTokens, nil
+}
+
+// TokenInvalidate revokes the OAuth access token with the given
+// ID, if it belongs to the given user, returning the revoked token.
+func (p *Processor) TokenInvalidate(ctx context.Context, user *gtsmodel.User, id string) (*apimodel.TokenInfo, gtserror.WithCode) {
+	token, err := p.state.DB.GetTokenByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := fmt.Errorf("TokenInvalidate: db error getting token %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if token == nil || token.UserID != user.ID {
+		err := fmt.Errorf("token %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	apiToken, err := p.apiTokenInfo(ctx, token)
+	if err != nil {
+		err := fmt.Errorf("TokenInvalidate: error converting token %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.DeleteTokenByID(ctx, id); err != nil {
+		err := fmt.Errorf("TokenInvalidate: db error deleting token %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if token.Name != "" {
+		// Personal access tokens each have their own client
+		// and application, which are of no use without it.
+		if err := p.state.DB.DeleteByID(ctx, token.ClientID, &gtsmodel.Client{}); err != nil {
+			log.Errorf(ctx, "error deleting client of personal access token %s: %v", id, err)
+		}
+
+		if err := p.state.DB.DeleteWhere(ctx, []db.Where{{Key: "client_id", Value: token.ClientID}}, &gtsmodel.Application{}); err != nil {
+			log.Errorf(ctx, "error deleting application of personal access token %s: %v", id, err)
+		}
+	}
+
+	return apiToken, nil
+}
+
+// PersonalTokenCreate creates a personal access token for the given user,
+// with the name, scope and expiry from the given form. The scope may not
+// exceed callerScope, the scope of the token making the request. The token
+// itself is included in the returned TokenInfo, and can't be retrieved again.
+func (p *Processor) PersonalTokenCreate(ctx context.Context, user *gtsmodel.User, callerScope string, form *apimodel.PersonalTokenCreateRequest) (*apimodel.TokenInfo, gtserror.WithCode) {
+	if !config.GetOAuthPersonalTokensEnabled() {
+		err := errors.New("personal access tokens are not enabled on this instance")
+		return nil, gtserror.NewErrorForbidden(err, err.Error())
+	}
+
+	name := strings.TrimSpace(form.Name)
+	if name == "" {
+		err := errors.New("name must be provided")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if length := len([]rune(name)); length > maxPersonalTokenNameChars {
+		err := fmt.Errorf("name must be %d characters or less, provided name was %d characters", maxPersonalTokenNameChars, length)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if form.ExpiresIn < 0 {
+		err := errors.New("expires_in must be 0 (never) or a positive number of seconds")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	scope := form.Scope
+	if scope == "" {
+		scope = "read"
+	}
+
+	scope, err := oauth.ValidateScopes(scope, strings.Join(config.GetOAuthPersonalTokensScopes(), " "))
+	if err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Don't let a token create a token more powerful than itself,
+	// else any app with write:accounts could give itself every scope
+	// (including admin scopes), in a token outliving its own revocation.
+	if _, err := oauth.ValidateScopes(scope, callerScope); err != nil {
+		err := fmt.Errorf("requested scope exceeds the scope of the token making this request: %w", err)
+		return nil, gtserror.NewErrorForbidden(err, err.Error())
+	}
+
+	// Each personal access token gets a client and application of
+	// its own, named after it, so the token can be used anywhere
+	// that tokens issued to applications can be.
+	clientID := id.NewULID()
+	clientSecret := uuid.NewString()
+
+	client := &gtsmodel.Client{
+		ID:     clientID,
+		Secret: clientSecret,
+		Domain: oauth.OOBURI,
+		UserID: user.ID,
+	}
+
+	if err := p.state.DB.Put(ctx, client); err != nil {
+		err := fmt.Errorf("PersonalTokenCreate: db error putting client: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	app := &gtsmodel.Application{
+		ID:           id.NewULID(),
+		Name:         name,
+		RedirectURI:  oauth.OOBURI,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Scopes:       scope,
+	}
+
+	if err := p.state.DB.Put(ctx, app); err != nil {
+		err := fmt.Errorf("PersonalTokenCreate: db error putting application: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	now := time.Now()
+	token := &gtsmodel.Token{
+		ID:             id.NewULID(),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		ClientID:       clientID,
+		UserID:         user.ID,
+		RedirectURI:    oauth.OOBURI,
+		Scope:          scope,
+		AccessCreateAt: now,
+		Name:           name,
+	}
+
+	if form.ExpiresIn > 0 {
+		token.AccessExpiresAt = now.Add(time.Duration(form.ExpiresIn) * time.Second)
+	}
+
+	access, err := p.oauthServer.CreatePersonalToken(ctx, token)
+	if err != nil {
+		err := fmt.Errorf("PersonalTokenCreate: error creating token: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiToken, err := p.apiTokenInfo(ctx, token)
+	if err != nil {
+		err := fmt.Errorf("PersonalTokenCreate: error converting token %s: %w", token.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	apiToken.AccessToken = access
+
+	return apiToken, nil
+}
+
+func (p *Processor) apiTokenInfo(ctx context.Context, token *gtsmodel.Token) (*apimodel.TokenInfo, error) {
+	app, err := p.state.DB.GetApplicationByClientID(ctx, token.ClientID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting application for client %s: %w", token.ClientID, err)
+	}
+
+	var lastUsed *string
+	if !token.LastUsed.IsZero() {
+		t := util.FormatISO8601(token.LastUsed)
+		lastUsed = &t
+	}
+
+	var expiresAt *string
+	if !token.AccessExpiresAt.IsZero() {
+		t := util.FormatISO8601(token.AccessExpiresAt)
+		expiresAt = &t
+	}
+
+	return &apimodel.TokenInfo{
+		ID:        token.ID,
+		CreatedAt: util.FormatISO8601(token.CreatedAt),
+		LastUsed:  lastUsed,
+		Scope:     token.Scope,
+		Application: &apimodel.Application{
+			Name:    app.Name,
+			Website: app.Website,
+		},
+		Name:      token.Name,
+		ExpiresAt: expiresAt,
+	}, nil
+}
@@ -19,18 +19,27 @@ package user
 
 import (
 	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 )
 
 type Processor struct {
 	state       *state.State
+	oauthServer oauth.Server
 	emailSender email.Sender
+	mxResolver  MXResolver
 }
 
-// New returns a new user processor
-func New(state *state.State, emailSender email.Sender) Processor {
-	return Processor{
+// New returns a new user processor. The given
+// resolver is used to look up the MX hosts of
+// email domains; it may be nil to skip this.
+func New(state *state.State, oauthServer oauth.Server, emailSender email.Sender, mxResolver MXResolver) Processor {
+	p := Processor{
 		state:       state,
+		oauthServer: oauthServer,
 		emailSender: emailSender,
+		mxResolver:  mxResolver,
 	}
+	p.scheduleDigests()
+	return p
 }
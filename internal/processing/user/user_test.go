@@ -22,6 +22,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/user"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/testrig"
@@ -32,8 +33,10 @@ type UserStandardTestSuite struct {
 	emailSender email.Sender
 	db          db.DB
 	state       state.State
+	oauthServer oauth.Server
 
-	testUsers map[string]*gtsmodel.User
+	testUsers  map[string]*gtsmodel.User
+	testTokens map[string]*gtsmodel.Token
 
 	sentEmails map[string]string
 
@@ -41,7 +44,12 @@ type UserStandardTestSuite struct {
 }
 
 func (suite *UserStandardTestSuite) SetupTest() {
+	// Use fresh state for each test, so the previous
+	// test's scheduler shutting down in the background
+	// can't stop the one the user processor relies on.
+	suite.state = state.State{}
 	suite.state.Caches.Init()
+	testrig.StartWorkers(&suite.state)
 
 	testrig.InitTestConfig()
 	testrig.InitTestLog()
@@ -52,12 +60,15 @@ func (suite *UserStandardTestSuite) SetupTest() {
 	suite.sentEmails = make(map[string]string)
 	suite.emailSender = testrig.NewEmailSender("../../../web/template/", suite.sentEmails)
 	suite.testUsers = testrig.NewTestUsers()
+	suite.testTokens = testrig.NewTestTokens()
 
-	suite.user = user.New(&suite.state, suite.emailSender)
+	suite.oauthServer = testrig.NewTestOauthServer(suite.db)
+	suite.user = user.New(&suite.state, suite.oauthServer, suite.emailSender, testrig.NewMockMXResolver(nil))
 
 	testrig.StandardDBSetup(suite.db, nil)
 }
 
 func (suite *UserStandardTestSuite) TearDownTest() {
 	testrig.StandardDBTeardown(suite.db)
+	testrig.StopWorkers(&suite.state)
 }
@@ -0,0 +1,187 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/iotools"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// blobPrefix is the storage key prefix under
+// which all content-addressed blobs are stored.
+const blobPrefix = "blobs/sha256/"
+
+// BlobRefs tracks the number of references held to
+// content-addressed blobs in storage, see db.Blob.
+type BlobRefs interface {
+	GetBlobRefs(ctx context.Context, key string) (int, error)
+	IncrementBlobRefs(ctx context.Context, key string, size int64) (int, error)
+	DecrementBlobRefs(ctx context.Context, key string) (int, error)
+	DeleteBlob(ctx context.Context, key string) error
+}
+
+// BlobKey returns the content-addressed storage key for
+// a file with given SHA-256 sum and file extension.
+func BlobKey(sum []byte, ext string) string {
+	hash := hex.EncodeToString(sum)
+	return blobPrefix + hash[:2] + "/" + hash + "." + ext
+}
+
+// IsBlobKey returns whether key is a content-addressed blob storage key.
+func IsBlobKey(key string) bool {
+	return strings.HasPrefix(key, blobPrefix)
+}
+
+// PutBlob writes the bytes from supplied reader into content-addressed storage,
+// keyed by the SHA-256 sum of its contents, and adds a reference to the blob in
+// refs. If a blob with identical contents is already stored, nothing is written.
+// Each successful call must be paired with a later call to Release for the key.
+func (d *Driver) PutBlob(ctx context.Context, refs BlobRefs, r io.Reader, ext string) (string, int64, error) {
+	hash := sha256.New()
+
+	// Spool the data to disk while hashing,
+	// as the key is only known once read.
+	tfs, err := iotools.TempFileSeeker(io.TeeReader(r, hash))
+	if err != nil {
+		return "", 0, fmt.Errorf("error spooling blob: %w", err)
+	}
+
+	defer func() {
+		if err := tfs.Close(); err != nil {
+			log.Errorf(ctx, "error closing temp file seeker: %v", err)
+		}
+	}()
+
+	size, err := tfs.Seek(0, io.SeekEnd)
+	if err != nil {
+		return "", 0, fmt.Errorf("error seeking blob: %w", err)
+	}
+
+	if _, err := tfs.Seek(0, io.SeekStart); err != nil {
+		return "", 0, fmt.Errorf("error seeking blob: %w", err)
+	}
+
+	key := BlobKey(hash.Sum(nil), ext)
+
+	unlock := d.lockBlob(key)
+	defer unlock()
+
+	have, err := d.Has(ctx, key)
+	if err != nil {
+		return "", 0, fmt.Errorf("error checking for blob: %w", err)
+	}
+
+	if !have {
+		if _, err := d.PutStream(ctx, key, tfs); err != nil {
+			return "", 0, fmt.Errorf("error writing blob: %w", err)
+		}
+	}
+
+	if _, err := refs.IncrementBlobRefs(ctx, key, size); err != nil {
+		if !have {
+			// Don't leave an unreferenced blob behind.
+			if err := d.Delete(ctx, key); err != nil {
+				log.Errorf(ctx, "error removing unreferenced blob %s: %v", key, err)
+			}
+		}
+		return "", 0, fmt.Errorf("error referencing blob: %w", err)
+	}
+
+	return key, size, nil
+}
+
+// Release releases a reference to the file at key in storage, removing the
+// file once its last reference is released. Files that are not content-addressed
+// blobs are simply removed. Releasing an already removed file is not an error.
+func (d *Driver) Release(ctx context.Context, refs BlobRefs, key string) error {
+	if !IsBlobKey(key) {
+		err := d.Delete(ctx, key)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+		return nil
+	}
+
+	unlock := d.lockBlob(key)
+	defer unlock()
+
+	n, err := refs.DecrementBlobRefs(ctx, key)
+	if err != nil {
+		return fmt.Errorf("error dereferencing blob: %w", err)
+	}
+
+	if n > 0 {
+		// Still in use.
+		return nil
+	}
+
+	return d.removeBlob(ctx, refs, key)
+}
+
+// PruneBlob removes the blob at key in storage if nothing references it,
+// returning whether it was removed. Blobs currently being written are
+// never considered unreferenced.
+func (d *Driver) PruneBlob(ctx context.Context, refs BlobRefs, key string) (bool, error) {
+	unlock := d.lockBlob(key)
+	defer unlock()
+
+	n, err := refs.GetBlobRefs(ctx, key)
+	if err != nil {
+		return false, fmt.Errorf("error getting blob refs: %w", err)
+	}
+
+	if n > 0 {
+		// Still in use.
+		return false, nil
+	}
+
+	return true, d.removeBlob(ctx, refs, key)
+}
+
+// removeBlob removes the unreferenced blob at key
+// from storage, along with its entry in refs.
+func (d *Driver) removeBlob(ctx context.Context, refs BlobRefs, key string) error {
+	if err := d.Delete(ctx, key); err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	if err := refs.DeleteBlob(ctx, key); err != nil {
+		return fmt.Errorf("error deleting blob entry: %w", err)
+	}
+
+	return nil
+}
+
+// lockBlob acquires the lock stripe for the blob at
+// key, returning a function to release the lock.
+func (d *Driver) lockBlob(key string) func() {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	mu := &d.blobMus[h.Sum32()%uint32(len(d.blobMus))]
+	mu.Lock()
+	return mu.Unlock
+}
@@ -0,0 +1,155 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+// memRefs is an in-memory implementation of storage.BlobRefs.
+type memRefs map[string]int
+
+func (m memRefs) GetBlobRefs(ctx context.Context, key string) (int, error) {
+	return m[key], nil
+}
+
+func (m memRefs) IncrementBlobRefs(ctx context.Context, key string, size int64) (int, error) {
+	m[key]++
+	return m[key], nil
+}
+
+func (m memRefs) DecrementBlobRefs(ctx context.Context, key string) (int, error) {
+	if m[key] > 0 {
+		m[key]--
+	}
+	return m[key], nil
+}
+
+func (m memRefs) DeleteBlob(ctx context.Context, key string) error {
+	delete(m, key)
+	return nil
+}
+
+type BlobTestSuite struct {
+	suite.Suite
+	storage *storage.Driver
+	refs    memRefs
+}
+
+func (suite *BlobTestSuite) SetupTest() {
+	suite.storage = testrig.NewInMemoryStorage()
+	suite.refs = make(memRefs)
+}
+
+func (suite *BlobTestSuite) putBlob(data string) string {
+	key, size, err := suite.storage.PutBlob(context.Background(), suite.refs, strings.NewReader(data), "txt")
+	suite.NoError(err)
+	suite.EqualValues(len(data), size)
+	return key
+}
+
+func (suite *BlobTestSuite) has(key string) bool {
+	have, err := suite.storage.Has(context.Background(), key)
+	suite.NoError(err)
+	return have
+}
+
+func (suite *BlobTestSuite) TestPutBlobDeduplicates() {
+	ctx := context.Background()
+
+	key1 := suite.putBlob("hello world")
+	key2 := suite.putBlob("hello world")
+	key3 := suite.putBlob("goodbye world")
+
+	// sha256("hello world")
+	suite.Equal("blobs/sha256/b9/b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9.txt", key1)
+	suite.True(storage.IsBlobKey(key1))
+	suite.Equal(key1, key2)
+	suite.NotEqual(key1, key3)
+	suite.Equal(2, suite.refs[key1])
+	suite.Equal(1, suite.refs[key3])
+
+	b, err := suite.storage.Get(ctx, key1)
+	suite.NoError(err)
+	suite.Equal([]byte("hello world"), b)
+}
+
+func (suite *BlobTestSuite) TestReleaseLastReference() {
+	ctx := context.Background()
+
+	key := suite.putBlob("hello world")
+	suite.putBlob("hello world")
+
+	// First release leaves the shared blob in place.
+	suite.NoError(suite.storage.Release(ctx, suite.refs, key))
+	suite.True(suite.has(key))
+	suite.Equal(1, suite.refs[key])
+
+	// Last release removes it.
+	suite.NoError(suite.storage.Release(ctx, suite.refs, key))
+	suite.False(suite.has(key))
+	suite.NotContains(suite.refs, key)
+
+	// Releasing again is a no-op.
+	suite.NoError(suite.storage.Release(ctx, suite.refs, key))
+}
+
+func (suite *BlobTestSuite) TestReleaseNonBlob() {
+	ctx := context.Background()
+
+	key := "01F8MH1H7YV1Z7D2C8K2730QBF/attachment/original/01F8MH6NEM8D7527KZAECTCR76.txt"
+	_, err := suite.storage.PutStream(ctx, key, bytes.NewReader([]byte("hello world")))
+	suite.NoError(err)
+
+	suite.NoError(suite.storage.Release(ctx, suite.refs, key))
+	suite.False(suite.has(key))
+
+	// Already removed files are fine.
+	suite.NoError(suite.storage.Release(ctx, suite.refs, key))
+}
+
+func (suite *BlobTestSuite) TestPruneBlob() {
+	ctx := context.Background()
+
+	key := suite.putBlob("hello world")
+
+	// Referenced blob is kept.
+	pruned, err := suite.storage.PruneBlob(ctx, suite.refs, key)
+	suite.NoError(err)
+	suite.False(pruned)
+	suite.True(suite.has(key))
+
+	// Simulate a reference that was never recorded.
+	delete(suite.refs, key)
+
+	pruned, err = suite.storage.PruneBlob(ctx, suite.refs, key)
+	suite.NoError(err)
+	suite.True(pruned)
+	suite.False(suite.has(key))
+}
+
+func TestBlobTestSuite(t *testing.T) {
+	suite.Run(t, new(BlobTestSuite))
+}
@@ -24,6 +24,8 @@ import (
 	"mime"
 	"net/url"
 	"path"
+	"strconv"
+	"sync"
 	"time"
 
 	"codeberg.org/gruf/go-bytesize"
@@ -61,6 +63,10 @@ type Driver struct {
 	Proxy          bool
 	Bucket         string
 	PresignedCache *ttl.Cache[string, PresignedURL]
+
+	// Lock stripes serializing reference
+	// changes to content-addressed blobs.
+	blobMus [64]sync.Mutex
 }
 
 // Get returns the byte value for key in storage.
@@ -102,6 +108,33 @@ func (d *Driver) WalkKeys(ctx context.Context, walk func(context.Context, string
 	})
 }
 
+// IsHealthy checks that the storage can be written to, read
+// from and deleted from, by round-tripping a small probe file.
+func (d *Driver) IsHealthy(ctx context.Context) error {
+	key := "healthcheck/" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	value := []byte("ok")
+
+	if _, err := d.Put(ctx, key, value); err != nil {
+		return fmt.Errorf("error writing probe: %w", err)
+	}
+
+	got, err := d.Get(ctx, key)
+	if err != nil {
+		_ = d.Delete(ctx, key)
+		return fmt.Errorf("error reading probe: %w", err)
+	}
+
+	if err := d.Delete(ctx, key); err != nil {
+		return fmt.Errorf("error deleting probe: %w", err)
+	}
+
+	if string(got) != string(value) {
+		return fmt.Errorf("probe content mismatch: got %q", got)
+	}
+
+	return nil
+}
+
 // Close will close the storage, releasing any file locks.
 func (d *Driver) Close() error {
 	return d.Storage.Close()
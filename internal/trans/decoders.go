@@ -19,6 +19,7 @@ package trans
 
 import (
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/pem"
 	"errors"
 	"fmt"
@@ -123,6 +124,21 @@ func (i *importer) userDecode(e transmodel.Entry) (*transmodel.User, error) {
 	return u, nil
 }
 
+func (i *importer) fileDecode(e transmodel.Entry) (*transmodel.File, error) {
+	f := &transmodel.File{}
+	if err := i.simpleDecode(e, f); err != nil {
+		return nil, err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(f.DataString)
+	if err != nil {
+		return nil, fmt.Errorf("fileDecode: error decoding file data: %s", err)
+	}
+	f.Data = data
+
+	return f, nil
+}
+
 func (i *importer) simpleDecode(entry transmodel.Entry, target interface{}) error {
 	decoder, err := newDecoder(target)
 	if err != nil {
@@ -19,18 +19,23 @@ package trans
 
 import (
 	"context"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"encoding/pem"
 	"errors"
 	"fmt"
-	"os"
+	"io"
 
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	transmodel "github.com/superseriousbusiness/gotosocial/internal/trans/model"
 )
 
 // accountEncode handles special fields like private + public keys on accounts
-func (e *exporter) accountEncode(ctx context.Context, f *os.File, a *transmodel.Account) error {
+func (e *exporter) accountEncode(ctx context.Context, f io.Writer, a *transmodel.Account) error {
 	a.Type = transmodel.TransAccount
 
 	// marshal public key
@@ -65,7 +70,7 @@ func (e *exporter) accountEncode(ctx context.Context, f *os.File, a *transmodel.
 //
 // Beware, the 'type' key on the passed interface should already have been set, since simpleEncode won't know
 // what type it is! If you try to decode stuff you've encoded with a missing type key, you're going to have a bad time.
-func (e *exporter) simpleEncode(ctx context.Context, file *os.File, i interface{}, id string) error {
+func (e *exporter) simpleEncode(ctx context.Context, file io.Writer, i interface{}, id string) error {
 	_, alreadyWritten := e.writtenIDs[id]
 	if alreadyWritten {
 		// this exporter has already exported an entry with this ID, no need to do it twice
@@ -80,3 +85,73 @@ func (e *exporter) simpleEncode(ctx context.Context, file *os.File, i interface{
 	e.writtenIDs[id] = true
 	return nil
 }
+
+// filesEncode writes each of the stored files at the given paths to the
+// export as file entries, skipping empty paths and files not in storage.
+func (e *exporter) filesEncode(ctx context.Context, w io.Writer, paths ...string) error {
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+
+		if err := e.fileEncode(ctx, w, path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fileEncode writes the stored file at the given path to the export
+// as a series of file chunk entries, the last of which contains the
+// size and sha256 checksum of the whole file.
+func (e *exporter) fileEncode(ctx context.Context, w io.Writer, path string) error {
+	r, err := e.storage.GetStream(ctx, path)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			// Most likely remote media that isn't cached.
+			log.Debugf(ctx, "file %s not in storage, skipping it", path)
+			return nil
+		}
+		return fmt.Errorf("fileEncode: error opening file %s: %w", path, err)
+	}
+	defer r.Close()
+
+	var (
+		hash   = sha256.New()
+		buf    = make([]byte, fileChunkSize)
+		offset int64
+	)
+
+	for {
+		n, err := io.ReadFull(r, buf)
+		last := errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+		if err != nil && !last {
+			return fmt.Errorf("fileEncode: error reading file %s: %w", path, err)
+		}
+
+		hash.Write(buf[:n])
+
+		chunk := &transmodel.File{
+			Type:       transmodel.TransFile,
+			Path:       path,
+			Offset:     offset,
+			DataString: base64.StdEncoding.EncodeToString(buf[:n]),
+		}
+
+		offset += int64(n)
+
+		if last {
+			chunk.Size = offset
+			chunk.SHA256 = hex.EncodeToString(hash.Sum(nil))
+		}
+
+		if err := json.NewEncoder(w).Encode(chunk); err != nil {
+			return fmt.Errorf("fileEncode: error encoding chunk of file %s: %w", path, err)
+		}
+
+		if last {
+			return nil
+		}
+	}
+}
@@ -34,6 +34,12 @@ func (e *exporter) exportAccounts(ctx context.Context, where []db.Where, file *o
 
 	// write any accounts found to file
 	for _, a := range accounts {
+		// media and emojis aren't part of minimal
+		// exports, so don't leave dangling references
+		a.AvatarMediaAttachmentID = ""
+		a.HeaderMediaAttachmentID = ""
+		a.EmojiIDs = nil
+
 		if err := e.accountEncode(ctx, file, a); err != nil {
 			return nil, fmt.Errorf("exportAccounts: error encoding account: %s", err)
 		}
@@ -21,22 +21,34 @@ import (
 	"context"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 )
 
 // Exporter wraps functionality for exporting entries from the database to a file.
 type Exporter interface {
+	// ExportMinimal exports local accounts, and the
+	// blocks, follows, users etc. that relate to them.
 	ExportMinimal(ctx context.Context, path string) error
+
+	// ExportFull exports every entry of every type supported
+	// by trans, wrapped in a header and checksum so that the
+	// file can be verified before it's imported. If media is
+	// true, stored media files are bundled into the file too.
+	ExportFull(ctx context.Context, path string, media bool) error
 }
 
 type exporter struct {
 	db         db.DB
+	storage    *storage.Driver
 	writtenIDs map[string]bool
 }
 
-// NewExporter returns a new Exporter that will use the given db.
-func NewExporter(db db.DB) Exporter {
+// NewExporter returns a new Exporter that will use the given db,
+// and the given storage for bundling media files into full exports.
+func NewExporter(db db.DB, storage *storage.Driver) Exporter {
 	return &exporter{
 		db:         db,
+		storage:    storage,
 		writtenIDs: make(map[string]bool),
 	}
 }
@@ -0,0 +1,302 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	transmodel "github.com/superseriousbusiness/gotosocial/internal/trans/model"
+)
+
+const (
+	// exportVersion is the version of the full export
+	// format written by this package; importers refuse
+	// files written with a newer version than this.
+	exportVersion = 1
+
+	// exportPageSize is the number of entries selected
+	// from the database at once when exporting a table.
+	exportPageSize = 500
+
+	// fileChunkSize is the maximum number of bytes of
+	// a stored file written into a single file entry.
+	fileChunkSize = 1024 * 1024
+)
+
+// exportWriter writes entries to a full export file, keeping a
+// running count and sha256 checksum of all the entries written.
+type exportWriter struct {
+	buf     *bufio.Writer
+	hash    hash.Hash
+	entries int
+}
+
+func (w *exportWriter) Write(p []byte) (int, error) {
+	n, err := w.buf.Write(p)
+	w.hash.Write(p[:n])
+
+	// Entries are newline-delimited, and
+	// there are no newlines *within* them.
+	w.entries += bytes.Count(p[:n], []byte{'\n'})
+
+	return n, err
+}
+
+func (w *exportWriter) encode(i interface{}) error {
+	return json.NewEncoder(w).Encode(i)
+}
+
+func (e *exporter) ExportFull(ctx context.Context, path string, media bool) error {
+	if path == "" {
+		return errors.New("ExportFull: path empty")
+	}
+
+	if media && e.storage == nil {
+		return errors.New("ExportFull: media requested but no storage set")
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("ExportFull: couldn't export to %s: %s", path, err)
+	}
+
+	w := &exportWriter{
+		buf:  bufio.NewWriter(file),
+		hash: sha256.New(),
+	}
+
+	now := time.Now()
+	header := &transmodel.Header{
+		Type:      transmodel.TransHeader,
+		Version:   exportVersion,
+		CreatedAt: &now,
+		Host:      config.GetHost(),
+		Media:     media,
+	}
+
+	if err := w.encode(header); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("ExportFull: error encoding header: %s", err)
+	}
+
+	if err := e.exportAll(ctx, w, media); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("ExportFull: %w", err)
+	}
+
+	// The checksum covers everything written before it,
+	// so write it straight to the buffer, bypassing hash.
+	checksum := &transmodel.Checksum{
+		Type:    transmodel.TransChecksum,
+		Entries: w.entries,
+		SHA256:  hex.EncodeToString(w.hash.Sum(nil)),
+	}
+
+	if err := json.NewEncoder(w.buf).Encode(checksum); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("ExportFull: error encoding checksum: %s", err)
+	}
+
+	if err := w.buf.Flush(); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("ExportFull: error flushing %s: %s", path, err)
+	}
+
+	return neatClose(file)
+}
+
+// exportAll writes every entry of every supported type to w, one type
+// at a time, with stored files following the entries that use them.
+func (e *exporter) exportAll(ctx context.Context, w *exportWriter, media bool) error {
+	encodeAccount := func(i interface{}) error {
+		return e.accountEncode(ctx, w, i.(*transmodel.Account))
+	}
+
+	encodeEmoji := func(i interface{}) error {
+		emoji := i.(*transmodel.Emoji)
+		if err := w.encode(emoji); err != nil {
+			return err
+		}
+
+		if !media {
+			return nil
+		}
+
+		return e.filesEncode(ctx, w,
+			emoji.ImagePath,
+			emoji.ImageStaticPath,
+		)
+	}
+
+	encodeAttachment := func(i interface{}) error {
+		attachment := i.(*transmodel.MediaAttachment)
+		if err := w.encode(attachment); err != nil {
+			return err
+		}
+
+		if !media {
+			return nil
+		}
+
+		return e.filesEncode(ctx, w,
+			attachment.File.Path,
+			attachment.File.SourcePath,
+			attachment.Thumbnail.Path,
+		)
+	}
+
+	if err := exportPaged[transmodel.Instance](ctx, e, transmodel.TransInstance, w.encode); err != nil {
+		return err
+	}
+
+	if err := exportPaged[transmodel.DomainBlock](ctx, e, transmodel.TransDomainBlock, w.encode); err != nil {
+		return err
+	}
+
+	if err := exportPaged[transmodel.Account](ctx, e, transmodel.TransAccount, encodeAccount); err != nil {
+		return err
+	}
+
+	if err := exportPaged[transmodel.User](ctx, e, transmodel.TransUser, w.encode); err != nil {
+		return err
+	}
+
+	if err := exportPaged[transmodel.Follow](ctx, e, transmodel.TransFollow, w.encode); err != nil {
+		return err
+	}
+
+	if err := exportPaged[transmodel.FollowRequest](ctx, e, transmodel.TransFollowRequest, w.encode); err != nil {
+		return err
+	}
+
+	if err := exportPaged[transmodel.Block](ctx, e, transmodel.TransBlock, w.encode); err != nil {
+		return err
+	}
+
+	if err := exportPaged[transmodel.UserMute](ctx, e, transmodel.TransUserMute, w.encode); err != nil {
+		return err
+	}
+
+	if err := exportPaged[transmodel.EmojiCategory](ctx, e, transmodel.TransEmojiCategory, w.encode); err != nil {
+		return err
+	}
+
+	if err := exportPaged[transmodel.Emoji](ctx, e, transmodel.TransEmoji, encodeEmoji); err != nil {
+		return err
+	}
+
+	if err := exportPaged[transmodel.Tag](ctx, e, transmodel.TransTag, w.encode); err != nil {
+		return err
+	}
+
+	if err := exportPaged[transmodel.MediaAttachment](ctx, e, transmodel.TransMediaAttachment, encodeAttachment); err != nil {
+		return err
+	}
+
+	if err := exportPaged[transmodel.Status](ctx, e, transmodel.TransStatus, w.encode); err != nil {
+		return err
+	}
+
+	if err := exportPaged[transmodel.Mention](ctx, e, transmodel.TransMention, w.encode); err != nil {
+		return err
+	}
+
+	if err := exportPaged[transmodel.List](ctx, e, transmodel.TransList, w.encode); err != nil {
+		return err
+	}
+
+	if err := exportPaged[transmodel.ListEntry](ctx, e, transmodel.TransListEntry, w.encode); err != nil {
+		return err
+	}
+
+	if err := exportPaged[transmodel.StatusBookmark](ctx, e, transmodel.TransStatusBookmark, w.encode); err != nil {
+		return err
+	}
+
+	if err := exportPaged[transmodel.StatusFave](ctx, e, transmodel.TransStatusFave, w.encode); err != nil {
+		return err
+	}
+
+	if err := exportPaged[transmodel.Notification](ctx, e, transmodel.TransNotification, w.encode); err != nil {
+		return err
+	}
+
+	if err := exportPaged[transmodel.Report](ctx, e, transmodel.TransReport, w.encode); err != nil {
+		return err
+	}
+
+	if err := exportPaged[transmodel.Tombstone](ctx, e, transmodel.TransTombstone, w.encode); err != nil {
+		return err
+	}
+
+	if err := exportPaged[transmodel.Application](ctx, e, transmodel.TransApplication, w.encode); err != nil {
+		return err
+	}
+
+	if err := exportPaged[transmodel.Client](ctx, e, transmodel.TransClient, w.encode); err != nil {
+		return err
+	}
+
+	if err := exportPaged[transmodel.Token](ctx, e, transmodel.TransToken, w.encode); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// exportPaged pages through every entry of trans model T in the database
+// in order of ID, setting the given trans type on each entry before passing
+// it to encode. T must be a trans model struct with 'Type' and 'ID' fields.
+func exportPaged[T any](ctx context.Context, e *exporter, t transmodel.Type, encode func(interface{}) error) error {
+	var minID string
+
+	for {
+		page := []*T{}
+		if err := e.db.GetAllPage(ctx, minID, exportPageSize, &page); err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return fmt.Errorf("exportPaged: error selecting %s entries: %w", t, err)
+		}
+
+		for _, entry := range page {
+			v := reflect.ValueOf(entry).Elem()
+			v.FieldByName("Type").SetString(string(t))
+			minID = v.FieldByName("ID").String()
+
+			if err := encode(entry); err != nil {
+				return fmt.Errorf("exportPaged: error encoding %s %s: %w", t, minID, err)
+			}
+		}
+
+		if len(page) < exportPageSize {
+			// Last page.
+			return nil
+		}
+	}
+}
@@ -0,0 +1,121 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/trans"
+	transmodel "github.com/superseriousbusiness/gotosocial/internal/trans/model"
+)
+
+type ExportFullTestSuite struct {
+	TransTestSuite
+}
+
+// exportFull exports the test database to a temporary file, and
+// returns the type of every entry in that file, in order.
+func (suite *ExportFullTestSuite) exportFull(media bool) []transmodel.Type {
+	tempFilePath := fmt.Sprintf("%s/%s", suite.T().TempDir(), uuid.NewString())
+
+	exporter := trans.NewExporter(suite.db, suite.storage)
+	if err := exporter.ExportFull(context.Background(), tempFilePath, media); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	file, err := os.Open(tempFilePath)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	defer file.Close()
+
+	types := []transmodel.Type{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 4*1024*1024)
+	for scanner.Scan() {
+		entry := transmodel.Entry{}
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			suite.FailNow(err.Error())
+		}
+		types = append(types, transmodel.Type(entry[transmodel.TypeKey].(string)))
+	}
+	suite.NoError(scanner.Err())
+
+	return types
+}
+
+func (suite *ExportFullTestSuite) TestExportFull() {
+	types := suite.exportFull(false)
+
+	// header first, checksum last
+	suite.Equal(transmodel.TransHeader, types[0])
+	suite.Equal(transmodel.TransChecksum, types[len(types)-1])
+
+	for _, t := range []transmodel.Type{
+		transmodel.TransAccount,
+		transmodel.TransUser,
+		transmodel.TransStatus,
+		transmodel.TransMention,
+		transmodel.TransTag,
+		transmodel.TransMediaAttachment,
+		transmodel.TransEmoji,
+		transmodel.TransEmojiCategory,
+		transmodel.TransList,
+		transmodel.TransListEntry,
+		transmodel.TransNotification,
+		transmodel.TransStatusBookmark,
+		transmodel.TransStatusFave,
+		transmodel.TransReport,
+		transmodel.TransTombstone,
+		transmodel.TransApplication,
+		transmodel.TransClient,
+		transmodel.TransToken,
+	} {
+		suite.Contains(types, t)
+	}
+
+	// no media asked for
+	suite.NotContains(types, transmodel.TransFile)
+}
+
+func (suite *ExportFullTestSuite) TestExportFullMedia() {
+	types := suite.exportFull(true)
+	suite.Contains(types, transmodel.TransFile)
+
+	// files follow the entries they belong to
+	for i, t := range types {
+		if t == transmodel.TransFile {
+			suite.Contains([]transmodel.Type{
+				transmodel.TransFile,
+				transmodel.TransMediaAttachment,
+				transmodel.TransEmoji,
+			}, types[i-1])
+		}
+	}
+}
+
+func TestExportFullTestSuite(t *testing.T) {
+	suite.Run(t, &ExportFullTestSuite{})
+}
@@ -19,27 +19,83 @@ package trans
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"os"
+	"reflect"
 
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	transmodel "github.com/superseriousbusiness/gotosocial/internal/trans/model"
 )
 
+// progressInterval is the number of entries imported
+// between each save of the import progress file.
+const progressInterval = 1000
+
 func (i *importer) Import(ctx context.Context, path string) error {
 	if path == "" {
-		return errors.New("Export: path empty")
+		return errors.New("Import: path empty")
 	}
 
 	file, err := os.Open(path)
 	if err != nil {
-		return fmt.Errorf("Import: couldn't export to %s: %s", path, err)
+		return fmt.Errorf("Import: couldn't import from %s: %s", path, err)
+	}
+	defer i.discardFile()
+
+	// Verify the whole file before
+	// putting anything in the database.
+	checksum, err := verify(file)
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("Import: error verifying %s: %w", path, err)
+	}
+
+	if checksum == nil {
+		log.Warnf(ctx, "%s has no header, so it can't be verified or resumed", path)
+	}
+
+	// Check for progress left behind by an earlier,
+	// interrupted import of this same file, and skip
+	// past the entries that import already took care of.
+	progress := &importProgress{}
+	i.resumed = false
+
+	if checksum != nil {
+		previous, err := loadProgress(path)
+		if err != nil {
+			_ = file.Close()
+			return fmt.Errorf("Import: %w", err)
+		}
+
+		if previous != nil && previous.SHA256 == checksum.SHA256 {
+			progress = previous
+			i.resumed = true
+			log.Infof(ctx, "resuming import from entry %d of %d", progress.Entries, checksum.Entries)
+		}
+
+		progress.SHA256 = checksum.SHA256
 	}
 
-	decoder := json.NewDecoder(file)
+	if _, err := file.Seek(progress.Offset, io.SeekStart); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("Import: error seeking in %s: %s", path, err)
+	}
+
+	var (
+		offset  = progress.Offset
+		entries = progress.Entries
+		saved   = progress.Entries
+		decoder = json.NewDecoder(file)
+	)
 	decoder.UseNumber()
 
 	for {
@@ -48,13 +104,52 @@ func (i *importer) Import(ctx context.Context, path string) error {
 		if err != nil {
 			if err == io.EOF {
 				log.Infof(ctx, "reached end of file")
+				if err := i.recountBlobRefs(ctx); err != nil {
+					_ = file.Close()
+					return fmt.Errorf("Import: %w", err)
+				}
+				if checksum != nil {
+					if err := removeProgress(path); err != nil {
+						log.Warnf(ctx, "error removing progress file: %s", err)
+					}
+				}
 				return neatClose(file)
 			}
+			_ = file.Close()
 			return fmt.Errorf("Import: error decoding in readLoop: %s", err)
 		}
+
 		if err := i.inputEntry(ctx, entry); err != nil {
+			// Save where we got to, so that the
+			// import can be resumed after a fix.
+			if checksum != nil && saved != progress.Entries {
+				if err := saveProgress(path, progress); err != nil {
+					log.Warnf(ctx, "error saving progress file: %s", err)
+				}
+			}
+			_ = file.Close()
 			return fmt.Errorf("Import: error inputting entry: %s", err)
 		}
+
+		entries++
+
+		if i.file != nil {
+			// Only track progress between files;
+			// resuming halfway through one won't work.
+			continue
+		}
+
+		progress.Offset = offset + decoder.InputOffset()
+		progress.Entries = entries
+
+		if checksum != nil && progress.Entries-saved >= progressInterval {
+			if err := saveProgress(path, progress); err != nil {
+				_ = file.Close()
+				return fmt.Errorf("Import: error saving progress file: %w", err)
+			}
+			saved = progress.Entries
+			log.Infof(ctx, "imported %d of %d entries", progress.Entries, checksum.Entries)
+		}
 	}
 }
 
@@ -65,6 +160,9 @@ func (i *importer) inputEntry(ctx context.Context, entry transmodel.Entry) error
 	}
 
 	switch transmodel.Type(t) {
+	case transmodel.TransHeader, transmodel.TransChecksum:
+		// Already handled by verify.
+		return nil
 	case transmodel.TransAccount:
 		account, err := i.accountDecode(entry)
 		if err != nil {
@@ -73,6 +171,14 @@ func (i *importer) inputEntry(ctx context.Context, entry transmodel.Entry) error
 		if err := i.putInDB(ctx, account); err != nil {
 			return fmt.Errorf("inputEntry: error adding account to database: %s", err)
 		}
+		for _, emojiID := range account.EmojiIDs {
+			if err := i.putInDB(ctx, &gtsmodel.AccountToEmoji{
+				AccountID: account.ID,
+				EmojiID:   emojiID,
+			}); err != nil {
+				return fmt.Errorf("inputEntry: error adding account emoji to database: %s", err)
+			}
+		}
 		log.Infof(ctx, "added account with id %s", account.ID)
 		return nil
 	case transmodel.TransBlock:
@@ -135,12 +241,254 @@ func (i *importer) inputEntry(ctx context.Context, entry transmodel.Entry) error
 		}
 		log.Infof(ctx, "added user with id %s", user.ID)
 		return nil
+	case transmodel.TransStatus:
+		status := &transmodel.Status{}
+		if err := i.inputSimple(ctx, entry, status); err != nil {
+			return err
+		}
+		for _, tagID := range status.TagIDs {
+			if err := i.putInDB(ctx, &gtsmodel.StatusToTag{
+				StatusID: status.ID,
+				TagID:    tagID,
+			}); err != nil {
+				return fmt.Errorf("inputEntry: error adding status tag to database: %s", err)
+			}
+		}
+		for _, emojiID := range status.EmojiIDs {
+			if err := i.putInDB(ctx, &gtsmodel.StatusToEmoji{
+				StatusID: status.ID,
+				EmojiID:  emojiID,
+			}); err != nil {
+				return fmt.Errorf("inputEntry: error adding status emoji to database: %s", err)
+			}
+		}
+		return nil
+	case transmodel.TransMention:
+		return i.inputSimple(ctx, entry, &transmodel.Mention{})
+	case transmodel.TransTag:
+		return i.inputSimple(ctx, entry, &transmodel.Tag{})
+	case transmodel.TransMediaAttachment:
+		return i.inputSimple(ctx, entry, &transmodel.MediaAttachment{})
+	case transmodel.TransEmoji:
+		return i.inputSimple(ctx, entry, &transmodel.Emoji{})
+	case transmodel.TransEmojiCategory:
+		return i.inputSimple(ctx, entry, &transmodel.EmojiCategory{})
+	case transmodel.TransList:
+		return i.inputSimple(ctx, entry, &transmodel.List{})
+	case transmodel.TransListEntry:
+		return i.inputSimple(ctx, entry, &transmodel.ListEntry{})
+	case transmodel.TransNotification:
+		return i.inputSimple(ctx, entry, &transmodel.Notification{})
+	case transmodel.TransStatusBookmark:
+		return i.inputSimple(ctx, entry, &transmodel.StatusBookmark{})
+	case transmodel.TransStatusFave:
+		return i.inputSimple(ctx, entry, &transmodel.StatusFave{})
+	case transmodel.TransReport:
+		return i.inputSimple(ctx, entry, &transmodel.Report{})
+	case transmodel.TransTombstone:
+		return i.inputSimple(ctx, entry, &transmodel.Tombstone{})
+	case transmodel.TransApplication:
+		return i.inputSimple(ctx, entry, &transmodel.Application{})
+	case transmodel.TransClient:
+		return i.inputSimple(ctx, entry, &transmodel.Client{})
+	case transmodel.TransToken:
+		return i.inputSimple(ctx, entry, &transmodel.Token{})
+	case transmodel.TransUserMute:
+		return i.inputSimple(ctx, entry, &transmodel.UserMute{})
+	case transmodel.TransFile:
+		file, err := i.fileDecode(entry)
+		if err != nil {
+			return fmt.Errorf("inputEntry: error decoding entry into file: %s", err)
+		}
+		return i.inputFile(ctx, file)
 	}
 
 	log.Errorf(ctx, "didn't recognize transtype '%s', skipping it", t)
 	return nil
 }
 
+// inputSimple decodes the given entry into target, and puts
+// it in the database, for types that need no special handling.
+func (i *importer) inputSimple(ctx context.Context, entry transmodel.Entry, target interface{}) error {
+	t := entry[transmodel.TypeKey]
+
+	if err := i.simpleDecode(entry, target); err != nil {
+		return fmt.Errorf("inputEntry: error decoding entry into %s: %s", t, err)
+	}
+
+	if err := i.putInDB(ctx, target); err != nil {
+		return fmt.Errorf("inputEntry: error adding %s to database: %s", t, err)
+	}
+
+	log.Debugf(ctx, "added %s with id %s", t, entry["id"])
+	return nil
+}
+
 func (i *importer) putInDB(ctx context.Context, entry interface{}) error {
-	return i.db.Put(ctx, entry)
+	err := i.db.Put(ctx, entry)
+	if errors.Is(err, db.ErrAlreadyExists) && i.resumed {
+		// Put by the interrupted import being
+		// resumed, after its last saved progress.
+		return nil
+	}
+	return err
+}
+
+// fileImport is a stored file being read back in from
+// file entries, buffered to a temporary file until its
+// last chunk is read and it can be verified and stored.
+type fileImport struct {
+	path    string
+	tmp     *os.File
+	hash    hash.Hash
+	written int64
+}
+
+func (i *importer) inputFile(ctx context.Context, chunk *transmodel.File) error {
+	if i.storage == nil {
+		return errors.New("inputFile: file found in import, but no storage set")
+	}
+
+	if chunk.Offset == 0 {
+		// First chunk of a new file.
+		if i.file != nil {
+			return fmt.Errorf("inputFile: file %s is incomplete", i.file.path)
+		}
+
+		tmp, err := os.CreateTemp("", "gotosocial-trans-*")
+		if err != nil {
+			return fmt.Errorf("inputFile: error creating temporary file: %w", err)
+		}
+
+		i.file = &fileImport{
+			path: chunk.Path,
+			tmp:  tmp,
+			hash: sha256.New(),
+		}
+	}
+
+	f := i.file
+	if f == nil || f.path != chunk.Path || f.written != chunk.Offset {
+		return fmt.Errorf("inputFile: unexpected chunk of file %s at offset %d", chunk.Path, chunk.Offset)
+	}
+
+	n, err := io.MultiWriter(f.tmp, f.hash).Write(chunk.Data)
+	f.written += int64(n)
+	if err != nil {
+		return fmt.Errorf("inputFile: error buffering file %s: %w", f.path, err)
+	}
+
+	if chunk.SHA256 == "" {
+		// More chunks to come.
+		return nil
+	}
+
+	defer i.discardFile()
+
+	if f.written != chunk.Size {
+		return fmt.Errorf("inputFile: file %s should be %d bytes, but was %d", f.path, chunk.Size, f.written)
+	}
+
+	if sum := hex.EncodeToString(f.hash.Sum(nil)); sum != chunk.SHA256 {
+		return fmt.Errorf("inputFile: checksum mismatch for file %s", f.path)
+	}
+
+	if _, err := f.tmp.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("inputFile: error seeking in temporary file: %w", err)
+	}
+
+	if _, err := i.storage.PutStream(ctx, f.path, f.tmp); err != nil {
+		if errors.Is(err, storage.ErrAlreadyExists) {
+			log.Debugf(ctx, "file %s already in storage, skipping it", f.path)
+			return nil
+		}
+		return fmt.Errorf("inputFile: error storing file %s: %w", f.path, err)
+	}
+
+	log.Debugf(ctx, "stored file %s", f.path)
+	return nil
+}
+
+// discardFile removes any partially read file.
+func (i *importer) discardFile() {
+	if i.file == nil {
+		return
+	}
+
+	_ = i.file.tmp.Close()
+	_ = os.Remove(i.file.tmp.Name())
+	i.file = nil
+}
+
+// recountBlobRefs rebuilds the reference counts of content-addressed
+// blobs in storage from the imported media attachments and emojis. Counts
+// aren't part of the export, and without them shared blobs would be
+// treated as unreferenced, and removed by the next prune.
+func (i *importer) recountBlobRefs(ctx context.Context) error {
+	blobs := make(map[string]*gtsmodel.Blob)
+
+	addRef := func(path string, size int) {
+		if !storage.IsBlobKey(path) {
+			return
+		}
+
+		blob, ok := blobs[path]
+		if !ok {
+			blob = &gtsmodel.Blob{Key: path, Size: int64(size)}
+			blobs[path] = blob
+		}
+
+		blob.Refs++
+	}
+
+	if err := recountPaged(ctx, i, func(attachment *transmodel.MediaAttachment) {
+		addRef(attachment.File.Path, attachment.File.FileSize)
+		addRef(attachment.File.SourcePath, attachment.File.SourceFileSize)
+		addRef(attachment.Thumbnail.Path, attachment.Thumbnail.FileSize)
+	}); err != nil {
+		return err
+	}
+
+	if err := recountPaged(ctx, i, func(emoji *transmodel.Emoji) {
+		addRef(emoji.ImagePath, emoji.ImageFileSize)
+		addRef(emoji.ImageStaticPath, emoji.ImageStaticFileSize)
+	}); err != nil {
+		return err
+	}
+
+	refs := make([]*gtsmodel.Blob, 0, len(blobs))
+	for _, blob := range blobs {
+		refs = append(refs, blob)
+	}
+
+	if err := i.db.ResetBlobRefs(ctx, refs); err != nil {
+		return fmt.Errorf("recountBlobRefs: error storing blob refs: %w", err)
+	}
+
+	log.Infof(ctx, "recounted references to %d blobs", len(refs))
+	return nil
+}
+
+// recountPaged pages through every entry of trans model T in
+// the database in order of ID, passing each entry to count. T
+// must be a trans model struct with an 'ID' field.
+func recountPaged[T any](ctx context.Context, i *importer, count func(*T)) error {
+	var minID string
+
+	for {
+		page := []*T{}
+		if err := i.db.GetAllPage(ctx, minID, exportPageSize, &page); err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return fmt.Errorf("recountPaged: error selecting entries: %w", err)
+		}
+
+		for _, entry := range page {
+			count(entry)
+			minID = reflect.ValueOf(entry).Elem().FieldByName("ID").String()
+		}
+
+		if len(page) < exportPageSize {
+			// Last page.
+			return nil
+		}
+	}
 }
@@ -18,13 +18,17 @@
 package trans_test
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"os"
+	"reflect"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/cleaner"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/trans"
@@ -47,7 +51,7 @@ func (suite *ImportMinimalTestSuite) TestImportMinimalOK() {
 	tempFilePath := fmt.Sprintf("%s/%s", suite.T().TempDir(), uuid.NewString())
 
 	// export to the tempFilePath
-	exporter := trans.NewExporter(suite.db)
+	exporter := trans.NewExporter(suite.db, nil)
 	err = exporter.ExportMinimal(ctx, tempFilePath)
 	suite.NoError(err)
 
@@ -63,7 +67,7 @@ func (suite *ImportMinimalTestSuite) TestImportMinimalOK() {
 	// create a new database with just the tables created, no entries
 	newDB := testrig.NewTestDB(&state)
 
-	importer := trans.NewImporter(newDB)
+	importer := trans.NewImporter(newDB, nil)
 	err = importer.Import(ctx, tempFilePath)
 	suite.NoError(err)
 
@@ -130,3 +134,278 @@ func (suite *ImportMinimalTestSuite) TestImportMinimalOK() {
 func TestImportMinimalTestSuite(t *testing.T) {
 	suite.Run(t, &ImportMinimalTestSuite{})
 }
+
+type ImportFullTestSuite struct {
+	TransTestSuite
+}
+
+// exportFull exports the test database to a temporary file, returning its path.
+func (suite *ImportFullTestSuite) exportFull(media bool) string {
+	tempFilePath := fmt.Sprintf("%s/%s", suite.T().TempDir(), uuid.NewString())
+
+	exporter := trans.NewExporter(suite.db, suite.storage)
+	if err := exporter.ExportFull(context.Background(), tempFilePath, media); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return tempFilePath
+}
+
+// newDB returns a new database with just the tables created, no entries.
+func (suite *ImportFullTestSuite) newDB() db.DB {
+	var state state.State
+	state.Caches.Init()
+
+	return testrig.NewTestDB(&state)
+}
+
+// rewrite replaces the contents of the file at path with the result of edit.
+func (suite *ImportFullTestSuite) rewrite(path string, edit func([]byte) []byte) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := os.WriteFile(path, edit(b), 0o600); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+// sameEntries checks that newDB has as many entries
+// of each exported type as the test database has.
+func (suite *ImportFullTestSuite) sameEntries(newDB db.DB) {
+	ctx := context.Background()
+
+	for _, newSlice := range []func() interface{}{
+		func() interface{} { return &[]*gtsmodel.Account{} },
+		func() interface{} { return &[]*gtsmodel.AccountToEmoji{} },
+		func() interface{} { return &[]*gtsmodel.User{} },
+		func() interface{} { return &[]*gtsmodel.Follow{} },
+		func() interface{} { return &[]*gtsmodel.Block{} },
+		func() interface{} { return &[]*gtsmodel.UserMute{} },
+		func() interface{} { return &[]*gtsmodel.Instance{} },
+		func() interface{} { return &[]*gtsmodel.Status{} },
+		func() interface{} { return &[]*gtsmodel.StatusToTag{} },
+		func() interface{} { return &[]*gtsmodel.StatusToEmoji{} },
+		func() interface{} { return &[]*gtsmodel.Mention{} },
+		func() interface{} { return &[]*gtsmodel.Tag{} },
+		func() interface{} { return &[]*gtsmodel.MediaAttachment{} },
+		func() interface{} { return &[]*gtsmodel.Emoji{} },
+		func() interface{} { return &[]*gtsmodel.EmojiCategory{} },
+		func() interface{} { return &[]*gtsmodel.List{} },
+		func() interface{} { return &[]*gtsmodel.ListEntry{} },
+		func() interface{} { return &[]*gtsmodel.Notification{} },
+		func() interface{} { return &[]*gtsmodel.StatusBookmark{} },
+		func() interface{} { return &[]*gtsmodel.StatusFave{} },
+		func() interface{} { return &[]*gtsmodel.Report{} },
+		func() interface{} { return &[]*gtsmodel.Tombstone{} },
+		func() interface{} { return &[]*gtsmodel.Application{} },
+		func() interface{} { return &[]*gtsmodel.Client{} },
+		func() interface{} { return &[]*gtsmodel.Token{} },
+	} {
+		before, after := newSlice(), newSlice()
+		suite.NoError(suite.db.GetAll(ctx, before))
+		suite.NoError(newDB.GetAll(ctx, after))
+		suite.Equal(
+			reflect.ValueOf(before).Elem().Len(),
+			reflect.ValueOf(after).Elem().Len(),
+			"%T", before,
+		)
+	}
+}
+
+func (suite *ImportFullTestSuite) TestImportFullOK() {
+	ctx := context.Background()
+
+	// There are no mutes in the test
+	// data, so make one to carry over.
+	if err := suite.db.PutMute(ctx, &gtsmodel.UserMute{
+		ID:              "01HBHQ4K7JQWV1M9QZ2DPGV2C3",
+		AccountID:       suite.testAccounts["local_account_1"].ID,
+		TargetAccountID: suite.testAccounts["local_account_2"].ID,
+		Notifications:   func() *bool { b := true; return &b }(),
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	tempFilePath := suite.exportFull(true)
+
+	newDB := suite.newDB()
+	newStorage := testrig.NewInMemoryStorage()
+
+	importer := trans.NewImporter(newDB, newStorage)
+	err := importer.Import(ctx, tempFilePath)
+	suite.NoError(err)
+
+	suite.sameEntries(newDB)
+
+	// compare a status with tags, emojis and media before + after
+	statusBefore := &gtsmodel.Status{}
+	err = suite.db.GetByID(ctx, "01F8MH75CBF9JFX4ZAD54N0W0R", statusBefore)
+	suite.NoError(err)
+
+	statusAfter := &gtsmodel.Status{}
+	err = newDB.GetByID(ctx, statusBefore.ID, statusAfter)
+	suite.NoError(err)
+
+	suite.Equal(statusBefore.URI, statusAfter.URI)
+	suite.Equal(statusBefore.Content, statusAfter.Content)
+	suite.Equal(statusBefore.AttachmentIDs, statusAfter.AttachmentIDs)
+	suite.Equal(statusBefore.TagIDs, statusAfter.TagIDs)
+	suite.Equal(statusBefore.EmojiIDs, statusAfter.EmojiIDs)
+	suite.Equal(statusBefore.Visibility, statusAfter.Visibility)
+	suite.Equal(statusBefore.Federated, statusAfter.Federated)
+	suite.Equal(statusBefore.CreatedAt, statusAfter.CreatedAt)
+
+	// media files should have come along too
+	attachment := &gtsmodel.MediaAttachment{}
+	err = suite.db.GetByID(ctx, statusBefore.AttachmentIDs[0], attachment)
+	suite.NoError(err)
+
+	for _, path := range []string{attachment.File.Path, attachment.Thumbnail.Path} {
+		before, err := suite.storage.Get(ctx, path)
+		suite.NoError(err)
+		after, err := newStorage.Get(ctx, path)
+		suite.NoError(err)
+		suite.Equal(before, after)
+	}
+
+	// nothing left to resume
+	_, err = os.Stat(tempFilePath + ".progress")
+	suite.ErrorIs(err, os.ErrNotExist)
+}
+
+func (suite *ImportFullTestSuite) TestImportFullCorrupted() {
+	ctx := context.Background()
+	tempFilePath := suite.exportFull(false)
+
+	suite.rewrite(tempFilePath, func(b []byte) []byte {
+		return bytes.Replace(b, []byte("the_mighty_zork"), []byte("the_mighty_zorq"), 1)
+	})
+
+	newDB := suite.newDB()
+	importer := trans.NewImporter(newDB, nil)
+	err := importer.Import(ctx, tempFilePath)
+	suite.ErrorContains(err, "checksum mismatch")
+
+	// nothing should have been imported
+	accounts := []*gtsmodel.Account{}
+	err = newDB.GetAll(ctx, &accounts)
+	suite.NoError(err)
+	suite.Empty(accounts)
+}
+
+func (suite *ImportFullTestSuite) TestImportFullTruncated() {
+	ctx := context.Background()
+	tempFilePath := suite.exportFull(false)
+
+	suite.rewrite(tempFilePath, func(b []byte) []byte {
+		// drop the checksum line, and part of the line before
+		b = b[:bytes.LastIndexByte(b[:len(b)-1], '\n')]
+		return b[:len(b)-10]
+	})
+
+	importer := trans.NewImporter(suite.newDB(), nil)
+	err := importer.Import(ctx, tempFilePath)
+	suite.ErrorContains(err, "checksum entry missing")
+}
+
+func (suite *ImportFullTestSuite) TestImportFullResume() {
+	ctx := context.Background()
+	tempFilePath := suite.exportFull(true)
+
+	newDB := suite.newDB()
+	newStorage := testrig.NewInMemoryStorage()
+
+	// put one of the tokens in the new db already, so
+	// the import is interrupted towards the end of the file
+	token := testrig.NewTestTokens()["local_account_1"]
+	err := newDB.Put(ctx, token)
+	suite.NoError(err)
+
+	importer := trans.NewImporter(newDB, newStorage)
+	err = importer.Import(ctx, tempFilePath)
+	suite.ErrorContains(err, "already exists")
+
+	// progress should have been saved
+	_, err = os.Stat(tempFilePath + ".progress")
+	suite.NoError(err)
+
+	// clear the conflict and go again; this only works if the import
+	// resumes where it left off, as everything before would conflict
+	err = newDB.DeleteByID(ctx, token.ID, &gtsmodel.Token{})
+	suite.NoError(err)
+
+	err = importer.Import(ctx, tempFilePath)
+	suite.NoError(err)
+
+	suite.sameEntries(newDB)
+
+	_, err = os.Stat(tempFilePath + ".progress")
+	suite.ErrorIs(err, os.ErrNotExist)
+}
+
+func (suite *ImportFullTestSuite) TestImportFullBlobRefs() {
+	ctx := context.Background()
+
+	// Deduplicate the originals of two attachments
+	// into a single blob, with a reference from each.
+	attachments := testrig.NewTestAttachments()
+	first := attachments["local_account_1_status_4_attachment_1"]
+	second := attachments["local_account_1_status_4_attachment_2"]
+
+	b, err := suite.storage.Get(ctx, first.File.Path)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var key string
+	for _, attachment := range []*gtsmodel.MediaAttachment{first, second} {
+		key, _, err = suite.storage.PutBlob(ctx, suite.db, bytes.NewReader(b), "jpg")
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		attachment.File.Path = key
+		attachment.File.FileSize = len(b)
+		if err := suite.db.UpdateByID(ctx, attachment, attachment.ID, "file_path", "file_file_size"); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	tempFilePath := suite.exportFull(true)
+
+	var newState state.State
+	newState.Caches.Init()
+	newState.DB = testrig.NewTestDB(&newState)
+	newState.Storage = testrig.NewInMemoryStorage()
+
+	importer := trans.NewImporter(newState.DB, newState.Storage)
+	err = importer.Import(ctx, tempFilePath)
+	suite.NoError(err)
+
+	refs, err := newState.DB.GetBlobRefs(ctx, key)
+	suite.NoError(err)
+	suite.Equal(2, refs)
+
+	testrig.StartWorkers(&newState)
+	defer testrig.StopWorkers(&newState)
+
+	// The shared blob must survive a prune...
+	_, err = cleaner.New(&newState).Media().PruneOrphaned(ctx)
+	suite.NoError(err)
+
+	_, err = newState.Storage.Get(ctx, key)
+	suite.NoError(err)
+
+	// ...and releasing one of its references.
+	err = newState.Storage.Release(ctx, newState.DB, key)
+	suite.NoError(err)
+
+	_, err = newState.Storage.Get(ctx, key)
+	suite.NoError(err)
+}
+
+func TestImportFullTestSuite(t *testing.T) {
+	suite.Run(t, &ImportFullTestSuite{})
+}
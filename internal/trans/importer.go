@@ -21,6 +21,7 @@ import (
 	"context"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 )
 
 // Importer wraps functionality for importing entries from a file into the database.
@@ -29,12 +30,23 @@ type Importer interface {
 }
 
 type importer struct {
-	db db.DB
+	db      db.DB
+	storage *storage.Driver
+
+	// resumed is set when an import picks up
+	// where a previous, interrupted one left off.
+	resumed bool
+
+	// file is the stored file currently
+	// being read in from file entries.
+	file *fileImport
 }
 
-// NewImporter returns a new Importer interface that uses the given db.
-func NewImporter(db db.DB) Importer {
+// NewImporter returns a new Importer interface that uses the given db,
+// and the given storage for any media files bundled in the import file.
+func NewImporter(db db.DB, storage *storage.Driver) Importer {
 	return &importer{
-		db: db,
+		db:      db,
+		storage: storage,
 	}
 }
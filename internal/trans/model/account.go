@@ -24,41 +24,58 @@ import (
 
 // Account represents the minimum viable representation of an account for export/import.
 type Account struct {
-	Type                  Type            `json:"type" bun:"-"`
-	ID                    string          `json:"id" bun:",nullzero"`
-	CreatedAt             *time.Time      `json:"createdAt" bun:",nullzero"`
-	Username              string          `json:"username" bun:",nullzero"`
-	Domain                string          `json:"domain,omitempty" bun:",nullzero"`
-	HeaderRemoteURL       string          `json:"headerRemoteURL,omitempty" bun:",nullzero"`
-	AvatarRemoteURL       string          `json:"avatarRemoteURL,omitempty" bun:",nullzero"`
-	DisplayName           string          `json:"displayName,omitempty" bun:",nullzero"`
-	Note                  string          `json:"note,omitempty" bun:",nullzero"`
-	NoteRaw               string          `json:"noteRaw,omitempty" bun:",nullzero"`
-	Memorial              *bool           `json:"memorial"`
-	Bot                   *bool           `json:"bot"`
-	Reason                string          `json:"reason,omitempty" bun:",nullzero"`
-	Locked                *bool           `json:"locked"`
-	Discoverable          *bool           `json:"discoverable"`
-	Privacy               string          `json:"privacy,omitempty" bun:",nullzero"`
-	Sensitive             *bool           `json:"sensitive"`
-	Language              string          `json:"language,omitempty" bun:",nullzero"`
-	StatusContentType     string          `json:"statusContentType,omitempty" bun:",nullzero"`
-	URI                   string          `json:"uri" bun:",nullzero"`
-	URL                   string          `json:"url" bun:",nullzero"`
-	InboxURI              string          `json:"inboxURI" bun:",nullzero"`
-	OutboxURI             string          `json:"outboxURI" bun:",nullzero"`
-	FollowingURI          string          `json:"followingUri" bun:",nullzero"`
-	FollowersURI          string          `json:"followersUri" bun:",nullzero"`
-	FeaturedCollectionURI string          `json:"featuredCollectionUri" bun:",nullzero"`
-	ActorType             string          `json:"actorType" bun:",nullzero"`
-	PrivateKey            *rsa.PrivateKey `json:"-" mapstructure:"-"`
-	PrivateKeyString      string          `json:"privateKey,omitempty" mapstructure:"privateKey" bun:"-"`
-	PublicKey             *rsa.PublicKey  `json:"-" mapstructure:"-"`
-	PublicKeyString       string          `json:"publicKey,omitempty" mapstructure:"publicKey" bun:"-"`
-	PublicKeyURI          string          `json:"publicKeyUri" bun:",nullzero"`
-	SensitizedAt          *time.Time      `json:"sensitizedAt,omitempty" bun:",nullzero"`
-	SilencedAt            *time.Time      `json:"silencedAt,omitempty" bun:",nullzero"`
-	SuspendedAt           *time.Time      `json:"suspendedAt,omitempty" bun:",nullzero"`
-	HideCollections       *bool           `json:"hideCollections"`
-	SuspensionOrigin      string          `json:"suspensionOrigin,omitempty" bun:",nullzero"`
+	Type                    Type            `json:"type" bun:"-"`
+	ID                      string          `json:"id" bun:",nullzero"`
+	CreatedAt               *time.Time      `json:"createdAt" bun:",nullzero"`
+	Username                string          `json:"username" bun:",nullzero"`
+	Domain                  string          `json:"domain,omitempty" bun:",nullzero"`
+	HeaderMediaAttachmentID string          `json:"headerMediaAttachmentID,omitempty" bun:",nullzero"`
+	HeaderRemoteURL         string          `json:"headerRemoteURL,omitempty" bun:",nullzero"`
+	AvatarMediaAttachmentID string          `json:"avatarMediaAttachmentID,omitempty" bun:",nullzero"`
+	AvatarRemoteURL         string          `json:"avatarRemoteURL,omitempty" bun:",nullzero"`
+	DisplayName             string          `json:"displayName,omitempty" bun:",nullzero"`
+	EmojiIDs                []string        `json:"emojiIDs,omitempty" bun:"emojis,array"`
+	Fields                  []*Field        `json:"fields,omitempty"`
+	FieldsRaw               []*Field        `json:"fieldsRaw,omitempty"`
+	Note                    string          `json:"note,omitempty" bun:",nullzero"`
+	NoteRaw                 string          `json:"noteRaw,omitempty" bun:",nullzero"`
+	Memorial                *bool           `json:"memorial"`
+	AlsoKnownAs             string          `json:"alsoKnownAs,omitempty" bun:",nullzero"`
+	MovedToAccountID        string          `json:"movedToAccountID,omitempty" bun:",nullzero"`
+	Bot                     *bool           `json:"bot"`
+	Reason                  string          `json:"reason,omitempty" bun:",nullzero"`
+	Locked                  *bool           `json:"locked"`
+	Discoverable            *bool           `json:"discoverable"`
+	Privacy                 string          `json:"privacy,omitempty" bun:",nullzero"`
+	Sensitive               *bool           `json:"sensitive"`
+	Language                string          `json:"language,omitempty" bun:",nullzero"`
+	StatusContentType       string          `json:"statusContentType,omitempty" bun:",nullzero"`
+	CustomCSS               string          `json:"customCSS,omitempty" bun:",nullzero"`
+	URI                     string          `json:"uri" bun:",nullzero"`
+	URL                     string          `json:"url" bun:",nullzero"`
+	InboxURI                string          `json:"inboxURI" bun:",nullzero"`
+	SharedInboxURI          *string         `json:"sharedInboxURI,omitempty"`
+	OutboxURI               string          `json:"outboxURI" bun:",nullzero"`
+	FollowingURI            string          `json:"followingUri" bun:",nullzero"`
+	FollowersURI            string          `json:"followersUri" bun:",nullzero"`
+	FeaturedCollectionURI   string          `json:"featuredCollectionUri" bun:",nullzero"`
+	ActorType               string          `json:"actorType" bun:",nullzero"`
+	PrivateKey              *rsa.PrivateKey `json:"-" mapstructure:"-"`
+	PrivateKeyString        string          `json:"privateKey,omitempty" mapstructure:"privateKey" bun:"-"`
+	PublicKey               *rsa.PublicKey  `json:"-" mapstructure:"-"`
+	PublicKeyString         string          `json:"publicKey,omitempty" mapstructure:"publicKey" bun:"-"`
+	PublicKeyURI            string          `json:"publicKeyUri" bun:",nullzero"`
+	SensitizedAt            *time.Time      `json:"sensitizedAt,omitempty" bun:",nullzero"`
+	SilencedAt              *time.Time      `json:"silencedAt,omitempty" bun:",nullzero"`
+	SuspendedAt             *time.Time      `json:"suspendedAt,omitempty" bun:",nullzero"`
+	HideCollections         *bool           `json:"hideCollections"`
+	SuspensionOrigin        string          `json:"suspensionOrigin,omitempty" bun:",nullzero"`
+	EnableRSS               *bool           `json:"enableRSS,omitempty"`
+}
+
+// Field represents a key value field on an account profile.
+type Field struct {
+	Name       string     `json:"name"`
+	Value      string     `json:"value"`
+	VerifiedAt *time.Time `json:"verifiedAt,omitempty"`
 }
@@ -0,0 +1,54 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import "time"
+
+// Emoji represents a custom emoji as serialized in an export file.
+type Emoji struct {
+	Type                   Type       `json:"type" bun:"-"`
+	ID                     string     `json:"id" bun:",nullzero"`
+	CreatedAt              *time.Time `json:"createdAt" bun:",nullzero"`
+	UpdatedAt              *time.Time `json:"updatedAt" bun:",nullzero"`
+	Shortcode              string     `json:"shortcode" bun:",nullzero"`
+	Domain                 string     `json:"domain,omitempty" bun:",nullzero"`
+	ImageRemoteURL         string     `json:"imageRemoteURL,omitempty" bun:",nullzero"`
+	ImageStaticRemoteURL   string     `json:"imageStaticRemoteURL,omitempty" bun:",nullzero"`
+	ImageURL               string     `json:"imageURL,omitempty" bun:",nullzero"`
+	ImageStaticURL         string     `json:"imageStaticURL,omitempty" bun:",nullzero"`
+	ImagePath              string     `json:"imagePath" bun:",nullzero"`
+	ImageStaticPath        string     `json:"imageStaticPath" bun:",nullzero"`
+	ImageContentType       string     `json:"imageContentType" bun:",nullzero"`
+	ImageStaticContentType string     `json:"imageStaticContentType" bun:",nullzero"`
+	ImageFileSize          int        `json:"imageFileSize"`
+	ImageStaticFileSize    int        `json:"imageStaticFileSize"`
+	ImageUpdatedAt         *time.Time `json:"imageUpdatedAt" bun:",nullzero"`
+	Disabled               *bool      `json:"disabled"`
+	URI                    string     `json:"uri" bun:",nullzero"`
+	VisibleInPicker        *bool      `json:"visibleInPicker"`
+	CategoryID             string     `json:"categoryID,omitempty" bun:",nullzero"`
+}
+
+// EmojiCategory represents a custom emoji category as serialized in an export file.
+type EmojiCategory struct {
+	Type      Type       `json:"type" bun:"-"`
+	ID        string     `json:"id" bun:",nullzero"`
+	CreatedAt *time.Time `json:"createdAt" bun:",nullzero"`
+	UpdatedAt *time.Time `json:"updatedAt" bun:",nullzero"`
+	Name      string     `json:"name" bun:",nullzero"`
+}
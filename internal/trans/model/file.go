@@ -0,0 +1,35 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+// File represents one chunk of a file from
+// media storage, as serialized in an export file.
+//
+// Large files are split across several consecutive
+// chunks, the last of which carries the total size
+// and checksum of the file, so it can be verified
+// once all chunks have been read back in.
+type File struct {
+	Type       Type   `json:"type"`
+	Path       string `json:"path"`
+	Offset     int64  `json:"offset"`
+	Data       []byte `json:"-" mapstructure:"-"`
+	DataString string `json:"data" mapstructure:"data"`
+	Size       int64  `json:"size,omitempty"`
+	SHA256     string `json:"sha256,omitempty"`
+}
@@ -0,0 +1,41 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import "time"
+
+// Header is the first entry of a full export file,
+// describing what the rest of the file contains.
+type Header struct {
+	Type      Type       `json:"type"`
+	Version   int        `json:"version"`
+	CreatedAt *time.Time `json:"createdAt"`
+	Host      string     `json:"host"`
+	Media     bool       `json:"media"`
+}
+
+// Checksum is the last entry of a full export file.
+// It contains the number of entries preceding it,
+// and the sha256 checksum of all preceding bytes,
+// so that truncated or corrupted files can be
+// detected before anything is imported from them.
+type Checksum struct {
+	Type    Type   `json:"type"`
+	Entries int    `json:"entries"`
+	SHA256  string `json:"sha256"`
+}
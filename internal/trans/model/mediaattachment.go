@@ -0,0 +1,91 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import "time"
+
+// MediaAttachment represents a media attachment as serialized in an export file.
+type MediaAttachment struct {
+	Type              Type           `json:"type" bun:"-"`
+	ID                string         `json:"id" bun:",nullzero"`
+	CreatedAt         *time.Time     `json:"createdAt" bun:",nullzero"`
+	UpdatedAt         *time.Time     `json:"updatedAt" bun:",nullzero"`
+	StatusID          string         `json:"statusID,omitempty" bun:",nullzero"`
+	URL               string         `json:"url,omitempty" bun:",nullzero"`
+	RemoteURL         string         `json:"remoteURL,omitempty" bun:",nullzero"`
+	FileType          string         `json:"fileType" bun:"type,nullzero"`
+	Original          MediaOriginal  `json:"original" bun:",embed:original_"`
+	Small             MediaSmall     `json:"small" bun:",embed:small_"`
+	Focus             MediaFocus     `json:"focus" bun:",embed:focus_"`
+	AccountID         string         `json:"accountID" bun:",nullzero"`
+	Description       string         `json:"description,omitempty"`
+	ScheduledStatusID string         `json:"scheduledStatusID,omitempty" bun:",nullzero"`
+	Blurhash          string         `json:"blurhash,omitempty" bun:",nullzero"`
+	Processing        int            `json:"processing"`
+	File              MediaFile      `json:"file" bun:",embed:file_"`
+	Thumbnail         MediaThumbnail `json:"thumbnail" bun:",embed:thumbnail_"`
+	Avatar            *bool          `json:"avatar"`
+	Header            *bool          `json:"header"`
+	Cached            *bool          `json:"cached"`
+}
+
+// MediaOriginal represents metadata about the original size of a media attachment.
+type MediaOriginal struct {
+	Width     int      `json:"width"`
+	Height    int      `json:"height"`
+	Size      int      `json:"size"`
+	Aspect    float32  `json:"aspect"`
+	Duration  *float32 `json:"duration,omitempty"`
+	Framerate *float32 `json:"framerate,omitempty"`
+	Bitrate   *uint64  `json:"bitrate,omitempty"`
+}
+
+// MediaSmall represents metadata about the small (thumbnail) size of a media attachment.
+type MediaSmall struct {
+	Width  int     `json:"width"`
+	Height int     `json:"height"`
+	Size   int     `json:"size"`
+	Aspect float32 `json:"aspect"`
+}
+
+// MediaFocus represents the focus point of a media attachment.
+type MediaFocus struct {
+	X float32 `json:"x"`
+	Y float32 `json:"y"`
+}
+
+// MediaFile represents the stored file of a media attachment.
+type MediaFile struct {
+	Path              string     `json:"path" bun:",nullzero"`
+	ContentType       string     `json:"contentType" bun:",nullzero"`
+	FileSize          int        `json:"fileSize"`
+	UpdatedAt         *time.Time `json:"updatedAt" bun:",nullzero"`
+	SourcePath        string     `json:"sourcePath,omitempty" bun:",nullzero"`
+	SourceContentType string     `json:"sourceContentType,omitempty" bun:",nullzero"`
+	SourceFileSize    int        `json:"sourceFileSize,omitempty" bun:",nullzero"`
+}
+
+// MediaThumbnail represents the stored thumbnail of a media attachment.
+type MediaThumbnail struct {
+	Path        string     `json:"path" bun:",nullzero"`
+	ContentType string     `json:"contentType" bun:",nullzero"`
+	FileSize    int        `json:"fileSize"`
+	UpdatedAt   *time.Time `json:"updatedAt" bun:",nullzero"`
+	URL         string     `json:"url,omitempty" bun:",nullzero"`
+	RemoteURL   string     `json:"remoteURL,omitempty" bun:",nullzero"`
+}
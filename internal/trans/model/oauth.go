@@ -0,0 +1,70 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import "time"
+
+// Application represents an OAuth application as serialized in an export file.
+type Application struct {
+	Type         Type       `json:"type" bun:"-"`
+	ID           string     `json:"id" bun:",nullzero"`
+	CreatedAt    *time.Time `json:"createdAt" bun:",nullzero"`
+	UpdatedAt    *time.Time `json:"updatedAt" bun:",nullzero"`
+	Name         string     `json:"name"`
+	Website      string     `json:"website,omitempty" bun:",nullzero"`
+	RedirectURI  string     `json:"redirectURI" bun:",nullzero"`
+	ClientID     string     `json:"clientID" bun:",nullzero"`
+	ClientSecret string     `json:"clientSecret" bun:",nullzero"`
+	Scopes       string     `json:"scopes"`
+}
+
+// Client represents an OAuth client as serialized in an export file.
+type Client struct {
+	Type      Type       `json:"type" bun:"-"`
+	ID        string     `json:"id" bun:",nullzero"`
+	CreatedAt *time.Time `json:"createdAt" bun:",nullzero"`
+	UpdatedAt *time.Time `json:"updatedAt" bun:",nullzero"`
+	Secret    string     `json:"secret" bun:",nullzero"`
+	Domain    string     `json:"domain" bun:",nullzero"`
+	UserID    string     `json:"userID,omitempty" bun:",nullzero"`
+}
+
+// Token represents an OAuth token as serialized in an export file.
+type Token struct {
+	Type                Type       `json:"type" bun:"-"`
+	ID                  string     `json:"id" bun:",nullzero"`
+	CreatedAt           *time.Time `json:"createdAt" bun:",nullzero"`
+	UpdatedAt           *time.Time `json:"updatedAt" bun:",nullzero"`
+	ClientID            string     `json:"clientID" bun:",nullzero"`
+	UserID              string     `json:"userID,omitempty" bun:",nullzero"`
+	RedirectURI         string     `json:"redirectURI" bun:",nullzero"`
+	Scope               string     `json:"scope"`
+	Code                string     `json:"code,omitempty"`
+	CodeChallenge       string     `json:"codeChallenge,omitempty" bun:",nullzero"`
+	CodeChallengeMethod string     `json:"codeChallengeMethod,omitempty" bun:",nullzero"`
+	CodeCreateAt        *time.Time `json:"codeCreateAt,omitempty" bun:",nullzero"`
+	CodeExpiresAt       *time.Time `json:"codeExpiresAt,omitempty" bun:",nullzero"`
+	Access              string     `json:"access,omitempty"`
+	AccessCreateAt      *time.Time `json:"accessCreateAt,omitempty" bun:",nullzero"`
+	AccessExpiresAt     *time.Time `json:"accessExpiresAt,omitempty" bun:",nullzero"`
+	Refresh             string     `json:"refresh,omitempty"`
+	RefreshCreateAt     *time.Time `json:"refreshCreateAt,omitempty" bun:",nullzero"`
+	RefreshExpiresAt    *time.Time `json:"refreshExpiresAt,omitempty" bun:",nullzero"`
+	LastUsed            *time.Time `json:"lastUsed,omitempty" bun:",nullzero"`
+	Name                string     `json:"name,omitempty" bun:",nullzero"`
+}
@@ -26,13 +26,33 @@ type Type string
 // Type of the trans entry. Describes how it should be read from file.
 const (
 	TransAccount          Type = "account"
+	TransApplication      Type = "application"
 	TransBlock            Type = "block"
+	TransChecksum         Type = "checksum"
+	TransClient           Type = "client"
 	TransDomainBlock      Type = "domainBlock"
 	TransEmailDomainBlock Type = "emailDomainBlock"
+	TransEmoji            Type = "emoji"
+	TransEmojiCategory    Type = "emojiCategory"
+	TransFile             Type = "file"
 	TransFollow           Type = "follow"
 	TransFollowRequest    Type = "followRequest"
+	TransHeader           Type = "header"
 	TransInstance         Type = "instance"
+	TransList             Type = "list"
+	TransListEntry        Type = "listEntry"
+	TransMediaAttachment  Type = "mediaAttachment"
+	TransMention          Type = "mention"
+	TransNotification     Type = "notification"
+	TransReport           Type = "report"
+	TransStatus           Type = "status"
+	TransStatusBookmark   Type = "statusBookmark"
+	TransStatusFave       Type = "statusFave"
+	TransTag              Type = "tag"
+	TransToken            Type = "token"
+	TransTombstone        Type = "tombstone"
 	TransUser             Type = "user"
+	TransUserMute         Type = "userMute"
 )
 
 // Entry is used for deserializing trans entries into a rough interface so that
@@ -23,27 +23,39 @@ import (
 
 // User represents a local instance user as serialized to an export file.
 type User struct {
-	Type                Type       `json:"type" bun:"-"`
-	ID                  string     `json:"id" bun:",nullzero"`
-	CreatedAt           *time.Time `json:"createdAt" bun:",nullzero"`
-	Email               string     `json:"email,omitempty" bun:",nullzero"`
-	AccountID           string     `json:"accountID" bun:",nullzero"`
-	EncryptedPassword   string     `json:"encryptedPassword" bun:",nullzero"`
-	CurrentSignInAt     *time.Time `json:"currentSignInAt,omitempty" bun:",nullzero"`
-	LastSignInAt        *time.Time `json:"lastSignInAt,omitempty" bun:",nullzero"`
-	InviteID            string     `json:"inviteID,omitempty" bun:",nullzero"`
-	ChosenLanguages     []string   `json:"chosenLanguages,omitempty" bun:",nullzero"`
-	FilteredLanguages   []string   `json:"filteredLanguage,omitempty" bun:",nullzero"`
-	Locale              string     `json:"locale" bun:",nullzero"`
-	LastEmailedAt       time.Time  `json:"lastEmailedAt,omitempty" bun:",nullzero"`
-	ConfirmationToken   string     `json:"confirmationToken,omitempty" bun:",nullzero"`
-	ConfirmationSentAt  *time.Time `json:"confirmationTokenSentAt,omitempty" bun:",nullzero"`
-	ConfirmedAt         *time.Time `json:"confirmedAt,omitempty" bun:",nullzero"`
-	UnconfirmedEmail    string     `json:"unconfirmedEmail,omitempty" bun:",nullzero"`
-	Moderator           *bool      `json:"moderator" bun:",nullzero,notnull,default:false"`
-	Admin               *bool      `json:"admin" bun:",nullzero,notnull,default:false"`
-	Disabled            *bool      `json:"disabled" bun:",nullzero,notnull,default:false"`
-	Approved            *bool      `json:"approved" bun:",nullzero,notnull,default:false"`
-	ResetPasswordToken  string     `json:"resetPasswordToken,omitempty" bun:",nullzero"`
-	ResetPasswordSentAt *time.Time `json:"resetPasswordSentAt,omitempty" bun:",nullzero"`
+	Type                   Type       `json:"type" bun:"-"`
+	ID                     string     `json:"id" bun:",nullzero"`
+	CreatedAt              *time.Time `json:"createdAt" bun:",nullzero"`
+	Email                  string     `json:"email,omitempty" bun:",nullzero"`
+	AccountID              string     `json:"accountID" bun:",nullzero"`
+	EncryptedPassword      string     `json:"encryptedPassword" bun:",nullzero"`
+	CurrentSignInAt        *time.Time `json:"currentSignInAt,omitempty" bun:",nullzero"`
+	LastSignInAt           *time.Time `json:"lastSignInAt,omitempty" bun:",nullzero"`
+	InviteID               string     `json:"inviteID,omitempty" bun:",nullzero"`
+	ChosenLanguages        []string   `json:"chosenLanguages,omitempty" bun:",nullzero"`
+	FilteredLanguages      []string   `json:"filteredLanguage,omitempty" bun:",nullzero"`
+	Locale                 string     `json:"locale" bun:",nullzero"`
+	LastEmailedAt          time.Time  `json:"lastEmailedAt,omitempty" bun:",nullzero"`
+	ConfirmationToken      string     `json:"confirmationToken,omitempty" bun:",nullzero"`
+	ConfirmationSentAt     *time.Time `json:"confirmationTokenSentAt,omitempty" bun:",nullzero"`
+	ConfirmedAt            *time.Time `json:"confirmedAt,omitempty" bun:",nullzero"`
+	UnconfirmedEmail       string     `json:"unconfirmedEmail,omitempty" bun:",nullzero"`
+	Moderator              *bool      `json:"moderator" bun:",nullzero,notnull,default:false"`
+	Admin                  *bool      `json:"admin" bun:",nullzero,notnull,default:false"`
+	Disabled               *bool      `json:"disabled" bun:",nullzero,notnull,default:false"`
+	Approved               *bool      `json:"approved" bun:",nullzero,notnull,default:false"`
+	ResetPasswordToken     string     `json:"resetPasswordToken,omitempty" bun:",nullzero"`
+	ResetPasswordSentAt    *time.Time `json:"resetPasswordSentAt,omitempty" bun:",nullzero"`
+	SignInCount            int        `json:"signInCount"`
+	CreatedByApplicationID string     `json:"createdByApplicationID,omitempty" bun:",nullzero"`
+	ExternalID             string     `json:"externalID,omitempty" bun:",nullzero"`
+	MediaQuota             *int64     `json:"mediaQuota,omitempty" bun:",nullzero"`
+	EmailMentions          string     `json:"emailMentions,omitempty" bun:",nullzero"`
+	EmailDirect            string     `json:"emailDirect,omitempty" bun:",nullzero"`
+	EmailFollows           string     `json:"emailFollows,omitempty" bun:",nullzero"`
+	EmailFollowRequests    string     `json:"emailFollowRequests,omitempty" bun:",nullzero"`
+	EmailReportsClosed     string     `json:"emailReportsClosed,omitempty" bun:",nullzero"`
+	EmailDigestFrequency   string     `json:"emailDigestFrequency,omitempty" bun:",nullzero"`
+	LastDigestAt           *time.Time `json:"lastDigestAt,omitempty" bun:",nullzero"`
+	UnsubscribeToken       string     `json:"unsubscribeToken,omitempty" bun:",nullzero"`
 }
@@ -0,0 +1,95 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// importProgress records how far an import of a full export
+// file got, so that an interrupted import can be resumed.
+// It's kept in a file next to the file being imported.
+type importProgress struct {
+	// SHA256 is the checksum of the file being imported,
+	// so that progress of one file isn't used for another.
+	SHA256 string `json:"sha256"`
+
+	// Offset is the byte offset in the
+	// file just after the last entry imported.
+	Offset int64 `json:"offset"`
+
+	// Entries is the number of entries imported.
+	Entries int `json:"entries"`
+}
+
+func progressPath(path string) string {
+	return path + ".progress"
+}
+
+// loadProgress loads the progress of an earlier import
+// of the file at the given path, if there is any.
+func loadProgress(path string) (*importProgress, error) {
+	b, err := os.ReadFile(progressPath(path))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("loadProgress: error reading progress file: %w", err)
+	}
+
+	progress := &importProgress{}
+	if err := json.Unmarshal(b, progress); err != nil {
+		return nil, fmt.Errorf("loadProgress: error parsing progress file: %w", err)
+	}
+
+	return progress, nil
+}
+
+// saveProgress saves the progress of an import of the file at the
+// given path, writing it to a temporary file first and renaming
+// that over the old progress file so it's never left half written.
+func saveProgress(path string, progress *importProgress) error {
+	b, err := json.Marshal(progress)
+	if err != nil {
+		return fmt.Errorf("saveProgress: error encoding progress: %w", err)
+	}
+
+	tmp := progressPath(path) + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return fmt.Errorf("saveProgress: error writing progress file: %w", err)
+	}
+
+	if err := os.Rename(tmp, progressPath(path)); err != nil {
+		return fmt.Errorf("saveProgress: error renaming progress file: %w", err)
+	}
+
+	return nil
+}
+
+// removeProgress removes the progress file for
+// the file at the given path, once it's imported.
+func removeProgress(path string) error {
+	if err := os.Remove(progressPath(path)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removeProgress: error removing progress file: %w", err)
+	}
+
+	return nil
+}
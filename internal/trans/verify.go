@@ -0,0 +1,99 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	transmodel "github.com/superseriousbusiness/gotosocial/internal/trans/model"
+)
+
+// verify checks the integrity of a full export file by comparing the
+// checksum entry at its end against all the entries that precede it,
+// returning the checksum entry if everything matches.
+//
+// Files that don't start with a header were written by ExportMinimal
+// (or an older version of GoToSocial) and can't be verified, in which
+// case verify returns nil and no error.
+func verify(file *os.File) (*transmodel.Checksum, error) {
+	var (
+		reader  = bufio.NewReader(file)
+		hash    = sha256.New()
+		entries int
+		last    []byte
+	)
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("verify: error reading file: %w", err)
+		}
+
+		if len(line) != 0 {
+			if last == nil {
+				// First line, check for a header.
+				header := &transmodel.Header{}
+				if err := json.Unmarshal(line, header); err != nil || header.Type != transmodel.TransHeader {
+					return nil, nil
+				}
+
+				if header.Version > exportVersion {
+					return nil, fmt.Errorf("verify: file has version %d, newer than supported version %d", header.Version, exportVersion)
+				}
+			} else {
+				// Only hash a line once we know
+				// it's not the final checksum line.
+				hash.Write(last)
+				entries++
+			}
+
+			last = line
+		}
+
+		if err == io.EOF {
+			break
+		}
+	}
+
+	if last == nil {
+		// Empty file.
+		return nil, nil
+	}
+
+	checksum := &transmodel.Checksum{}
+	if err := json.Unmarshal(last, checksum); err != nil || checksum.Type != transmodel.TransChecksum {
+		return nil, errors.New("verify: checksum entry missing, the file may be truncated")
+	}
+
+	if checksum.Entries != entries {
+		return nil, fmt.Errorf("verify: expected %d entries, but found %d; the file may be truncated", checksum.Entries, entries)
+	}
+
+	if sum := hex.EncodeToString(hash.Sum(nil)); sum != checksum.SHA256 {
+		return nil, errors.New("verify: checksum mismatch, the file may be corrupted")
+	}
+
+	return checksum, nil
+}
@@ -27,6 +27,7 @@ import (
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/metrics"
 )
 
 func (t *transport) BatchDeliver(ctx context.Context, b []byte, recipients []*url.URL) error {
@@ -106,7 +107,10 @@ func (t *transport) Deliver(ctx context.Context, b []byte, to *url.URL) error {
 	return t.deliver(ctx, b, to)
 }
 
-func (t *transport) deliver(ctx context.Context, b []byte, to *url.URL) error {
+func (t *transport) deliver(ctx context.Context, b []byte, to *url.URL) (err error) {
+	// Record delivery result metrics.
+	defer func() { metrics.CountDelivery(to.Host, err) }()
+
 	url := to.String()
 
 	// Use rewindable bytes reader for body.
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/miekg/dns"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
@@ -244,6 +245,36 @@ func (c *converter) extractAttachments(i ap.WithAttachment) []*gtsmodel.MediaAtt
 	return attachments
 }
 
+// extractObjectAttachment extracts a minimal gtsmodel.MediaAttachment
+// from a Statusable that is itself a media file, ie., a Video, Audio
+// or Image, or returns nil if none could be extracted. Such objects
+// tend to link to the file(s) in their url property with a mediaType
+// matching the object type, but Images may also just link straight
+// to the file with a plain url IRI.
+func (c *converter) extractObjectAttachment(statusable ap.Statusable) *gtsmodel.MediaAttachment {
+	attachmentable, ok := statusable.(ap.Attachmentable)
+	if !ok {
+		return nil
+	}
+
+	typeName := statusable.GetTypeName()
+	remoteURL := ap.ExtractMediaURL(attachmentable, strings.ToLower(typeName)+"/")
+	if remoteURL == nil && typeName == ap.ObjectImage {
+		remoteURL, _ = ap.ExtractURL(attachmentable)
+	}
+
+	if remoteURL == nil {
+		return nil
+	}
+
+	return &gtsmodel.MediaAttachment{
+		RemoteURL:   remoteURL.String(),
+		Description: ap.ExtractName(attachmentable),
+		Blurhash:    ap.ExtractBlurhash(attachmentable),
+		Processing:  gtsmodel.ProcessingStatusReceived,
+	}
+}
+
 func (c *converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusable) (*gtsmodel.Status, error) {
 	status := new(gtsmodel.Status)
 
@@ -307,12 +338,46 @@ func (c *converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 
 	// status.ContentWarning
 	//
-	// Topic or content warning for this status;
-	// prefer Summary, fall back to Name.
-	if summary := ap.ExtractSummary(statusable); summary != "" {
-		status.ContentWarning = summary
-	} else {
-		status.ContentWarning = ap.ExtractName(statusable)
+	// Topic or content warning for this status.
+	// Notes prefer Summary, and fall back to Name,
+	// but other types of object use these fields
+	// differently, so they need a bit of massaging.
+	switch statusable.GetTypeName() {
+	case ap.ObjectArticle:
+		// Articles are long-form posts, eg., from
+		// a blog, so show just the title and summary
+		// with a link to the original for the rest.
+		status.Content = articleContent(
+			ap.ExtractName(statusable),
+			ap.ExtractSummary(statusable),
+			status.URL,
+		)
+
+	case ap.ObjectPage, ap.ObjectEvent, ap.ActivityQuestion,
+		ap.ObjectVideo, ap.ObjectAudio, ap.ObjectImage:
+		// Name is a title for these, not a content warning.
+		status.ContentWarning = ap.ExtractSummary(statusable)
+		status.Content = titledContent(ap.ExtractName(statusable), status.Content)
+
+		if pollable, ok := statusable.(ap.Pollable); ok {
+			// Show choices of a poll (Question).
+			status.Content += pollContent(ap.ExtractPollOptions(pollable))
+		}
+
+		if attachment := c.extractObjectAttachment(statusable); attachment != nil {
+			// Object is itself the media for this status.
+			status.Attachments = append([]*gtsmodel.MediaAttachment{attachment}, status.Attachments...)
+			if status.URL == attachment.RemoteURL {
+				status.URL = status.URI
+			}
+		}
+
+	default:
+		if summary := ap.ExtractSummary(statusable); summary != "" {
+			status.ContentWarning = summary
+		} else {
+			status.ContentWarning = ap.ExtractName(statusable)
+		}
 	}
 
 	// status.Published
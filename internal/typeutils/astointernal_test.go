@@ -264,10 +264,190 @@ func (suite *ASToInternalTestSuite) TestParseBookwyrmStatus() {
 		suite.FailNow(err.Error())
 	}
 
-	suite.Equal("Review of \"Dracula\" (5 stars): A great read, not just for codifying vampire lore, but the way it's built from letters and diaries.", status.ContentWarning)
+	suite.Empty(status.ContentWarning)
+	suite.Equal(`<p><strong>Review of &#34;Dracula&#34; (5 stars): A great read, not just for codifying vampire lore, but the way it&#39;s built from letters and diaries.</strong></p><p><a href="`+authorAccount.URI+`/review/445260">Read more</a></p>`, status.Content)
+	suite.Equal(ap.ObjectArticle, status.ActivityStreamsType)
 	suite.Len(status.Attachments, 1)
 }
 
+func (suite *ASToInternalTestSuite) TestParseWriteFreelyArticle() {
+	authorAccount := suite.testAccounts["remote_account_1"]
+
+	raw := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "` + authorAccount.URI + `/posts/3kcfm5e8zd",
+  "type": "Article",
+  "published": "2023-07-12T09:21:04Z",
+  "attributedTo": "` + authorAccount.URI + `",
+  "to": [
+    "https://www.w3.org/ns/activitystreams#Public"
+  ],
+  "cc": [
+    "` + authorAccount.FollowersURI + `"
+  ],
+  "url": "http://fossbros-anonymous.io/blog/on-federated-blogging",
+  "name": "On federated blogging",
+  "summary": "<p>Some thoughts on writing long posts for the fediverse.</p>",
+  "content": "<p>A very long article which we don't want to show in full.</p>",
+  "tag": []
+}`
+
+	t := suite.jsonToType(raw)
+	statusable, ok := ap.ToStatusable(t)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	status, err := suite.typeconverter.ASStatusToStatus(context.Background(), statusable)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Empty(status.ContentWarning)
+	suite.Equal(`<p><strong>On federated blogging</strong></p><p>Some thoughts on writing long posts for the fediverse.</p><p><a href="http://fossbros-anonymous.io/blog/on-federated-blogging">Read more</a></p>`, status.Content)
+	suite.Equal(ap.ObjectArticle, status.ActivityStreamsType)
+}
+
+func (suite *ASToInternalTestSuite) TestParsePeertubeVideo() {
+	authorAccount := suite.testAccounts["remote_account_1"]
+
+	raw := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://fossbros-anonymous.io/videos/watch/5e1c8d62-6f1f-4f5e-8f54-5e8b0f0c4e38",
+  "type": "Video",
+  "name": "Installing GoToSocial",
+  "published": "2023-07-12T09:21:04Z",
+  "attributedTo": "` + authorAccount.URI + `",
+  "to": [
+    "https://www.w3.org/ns/activitystreams#Public"
+  ],
+  "cc": [
+    "` + authorAccount.FollowersURI + `"
+  ],
+  "sensitive": false,
+  "content": "<p>A quick walkthrough.</p>",
+  "url": [
+    {
+      "type": "Link",
+      "mediaType": "text/html",
+      "href": "http://fossbros-anonymous.io/w/5e1c8d62"
+    },
+    {
+      "type": "Link",
+      "mediaType": "video/mp4",
+      "href": "http://fossbros-anonymous.io/static/web-videos/5e1c8d62-720.mp4",
+      "height": 720
+    }
+  ]
+}`
+
+	t := suite.jsonToType(raw)
+	statusable, ok := ap.ToStatusable(t)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	status, err := suite.typeconverter.ASStatusToStatus(context.Background(), statusable)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Empty(status.ContentWarning)
+	suite.Equal("<p><strong>Installing GoToSocial</strong></p><p>A quick walkthrough.</p>", status.Content)
+	suite.Equal(ap.ObjectVideo, status.ActivityStreamsType)
+	suite.Len(status.Attachments, 1)
+	suite.Equal("http://fossbros-anonymous.io/static/web-videos/5e1c8d62-720.mp4", status.Attachments[0].RemoteURL)
+	suite.Equal("Installing GoToSocial", status.Attachments[0].Description)
+}
+
+func (suite *ASToInternalTestSuite) TestParseImage() {
+	authorAccount := suite.testAccounts["remote_account_1"]
+
+	raw := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://fossbros-anonymous.io/photos/1234",
+  "type": "Image",
+  "name": "Sunset over the harbour",
+  "published": "2023-07-12T09:21:04Z",
+  "attributedTo": "` + authorAccount.URI + `",
+  "to": [
+    "https://www.w3.org/ns/activitystreams#Public"
+  ],
+  "url": "http://fossbros-anonymous.io/photos/1234/original.jpg"
+}`
+
+	t := suite.jsonToType(raw)
+	statusable, ok := ap.ToStatusable(t)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	status, err := suite.typeconverter.ASStatusToStatus(context.Background(), statusable)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal("<p><strong>Sunset over the harbour</strong></p>", status.Content)
+	suite.Equal(ap.ObjectImage, status.ActivityStreamsType)
+	suite.Len(status.Attachments, 1)
+	suite.Equal("http://fossbros-anonymous.io/photos/1234/original.jpg", status.Attachments[0].RemoteURL)
+
+	// URL of the status shouldn't be the image file itself.
+	suite.Equal("http://fossbros-anonymous.io/photos/1234", status.URL)
+}
+
+func (suite *ASToInternalTestSuite) TestParseQuestion() {
+	authorAccount := suite.testAccounts["remote_account_1"]
+
+	raw := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://fossbros-anonymous.io/users/foss_satan/statuses/110705287812364470",
+  "type": "Question",
+  "summary": "poll about food",
+  "published": "2023-07-12T09:21:04Z",
+  "attributedTo": "` + authorAccount.URI + `",
+  "to": [
+    "https://www.w3.org/ns/activitystreams#Public"
+  ],
+  "content": "<p>What should I have for dinner?</p>",
+  "endTime": "2023-07-13T09:21:04Z",
+  "oneOf": [
+    {
+      "type": "Note",
+      "name": "pizza",
+      "replies": {
+        "type": "Collection",
+        "totalItems": 3
+      }
+    },
+    {
+      "type": "Note",
+      "name": "fish & chips",
+      "replies": {
+        "type": "Collection",
+        "totalItems": 1
+      }
+    }
+  ]
+}`
+
+	t := suite.jsonToType(raw)
+	statusable, ok := ap.ToStatusable(t)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	status, err := suite.typeconverter.ASStatusToStatus(context.Background(), statusable)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal("poll about food", status.ContentWarning)
+	suite.Equal("<p>What should I have for dinner?</p><ul><li>pizza</li><li>fish &amp; chips</li></ul>", status.Content)
+	suite.Equal(ap.ActivityQuestion, status.ActivityStreamsType)
+	suite.Empty(status.Attachments)
+}
+
 func (suite *ASToInternalTestSuite) TestParseFlag1() {
 	reportedAccount := suite.testAccounts["local_account_1"]
 	reportingAccount := suite.testAccounts["remote_account_1"]
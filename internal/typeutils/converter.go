@@ -52,6 +52,9 @@ type TypeConverter interface {
 	// something goes wrong. The returned account will be a bare minimum representation of the account. This function should be used
 	// when someone wants to view an account they've blocked.
 	AccountToAPIAccountBlocked(ctx context.Context, account *gtsmodel.Account) (*apimodel.Account, error)
+	// AccountToAdminAPIAccount converts a gts model account into an admin view account, including
+	// moderation details such as whether the account is disabled, silenced or suspended.
+	AccountToAdminAPIAccount(ctx context.Context, account *gtsmodel.Account) (*apimodel.AdminAccountInfo, error)
 	// AppToAPIAppSensitive takes a db model application as a param, and returns a populated apitype application, or an error
 	// if something goes wrong. The returned application should be ready to serialize on an API level, and may have sensitive fields
 	// (such as client id and client secret), so serve it only to an authorized user who should have permission to see it.
@@ -88,10 +91,26 @@ type TypeConverter interface {
 	NotificationToAPINotification(ctx context.Context, n *gtsmodel.Notification) (*apimodel.Notification, error)
 	// DomainBlockToAPIDomainBlock converts a gts model domin block into a api domain block, for serving at /api/v1/admin/domain_blocks
 	DomainBlockToAPIDomainBlock(ctx context.Context, b *gtsmodel.DomainBlock, export bool) (*apimodel.DomainBlock, error)
+	// EmailDomainBlockToAPIEmailDomainBlock converts a gts model email domain block into an api email domain block, for serving at /api/v1/admin/email_domain_blocks
+	EmailDomainBlockToAPIEmailDomainBlock(ctx context.Context, b *gtsmodel.EmailDomainBlock) (*apimodel.EmailDomainBlock, error)
 	// ReportToAPIReport converts a gts model report into an api model report, for serving at /api/v1/reports
 	ReportToAPIReport(ctx context.Context, r *gtsmodel.Report) (*apimodel.Report, error)
 	// ReportToAdminAPIReport converts a gts model report into an admin view report, for serving at /api/v1/admin/reports
 	ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Report, requestingAccount *gtsmodel.Account) (*apimodel.AdminReport, error)
+	// ReportNoteToAdminAPIReportNote converts a gts model report note into an admin view report note, for serving at /api/v1/admin/reports/{id}/notes
+	ReportNoteToAdminAPIReportNote(ctx context.Context, n *gtsmodel.ReportNote) (*apimodel.AdminReportNote, error)
+	// RuleToAPIRule converts a gts model rule into an api model instance rule, for serving at /api/v1/instance/rules
+	RuleToAPIRule(r *gtsmodel.Rule) apimodel.InstanceRule
+	// RuleToAdminAPIRule converts a gts model rule into an admin view rule, for serving at /api/v1/admin/instance/rules
+	RuleToAdminAPIRule(r *gtsmodel.Rule) *apimodel.AdminInstanceRule
+	// IPBlockToAdminAPIIPBlock converts a gts model IP block into an admin view IP block, for serving at /api/v1/admin/ip_blocks
+	IPBlockToAdminAPIIPBlock(b *gtsmodel.IPBlock) *apimodel.AdminIPBlock
+	// ExportToAPIExport converts a gts model export into an api model export, for serving at /api/v1/exports
+	ExportToAPIExport(e *gtsmodel.Export) *apimodel.Export
+	// ImportToAPIImport converts a gts model import into an api model import, for serving at /api/v1/import
+	ImportToAPIImport(i *gtsmodel.Import) *apimodel.Import
+	// AuditLogEntryToAdminAPIAuditLogEntry converts a gts model audit log entry into an admin view entry, for serving at /api/v1/admin/audit_log
+	AuditLogEntryToAdminAPIAuditLogEntry(ctx context.Context, e *gtsmodel.AuditLogEntry) (*apimodel.AdminAuditLogEntry, error)
 	// ListToAPIList converts one gts model list into an api model list, for serving at /api/v1/lists/{id}
 	ListToAPIList(ctx context.Context, l *gtsmodel.List) (*apimodel.List, error)
 
@@ -147,8 +166,9 @@ type TypeConverter interface {
 	// suitable for serving to requesters to whom we want to give as little information as possible because
 	// we don't trust them (yet).
 	AccountToASMinimal(ctx context.Context, a *gtsmodel.Account) (vocab.ActivityStreamsPerson, error)
-	// StatusToAS converts a gts model status into an activity streams note, suitable for federation
-	StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.ActivityStreamsNote, error)
+	// StatusToAS converts a gts model status into an activity streams statusable, suitable for federation.
+	// This will be a Note, unless the status originally came from a remote object of another type, eg., an Article.
+	StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Statusable, error)
 	// StatusToASDelete converts a gts model status into a Delete of that status, using just the
 	// URI of the status as object, and addressing the Delete appropriately.
 	StatusToASDelete(ctx context.Context, status *gtsmodel.Status) (vocab.ActivityStreamsDelete, error)
@@ -203,12 +223,12 @@ type TypeConverter interface {
 
 	// WrapPersonInUpdate
 	WrapPersonInUpdate(person vocab.ActivityStreamsPerson, originAccount *gtsmodel.Account) (vocab.ActivityStreamsUpdate, error)
-	// WrapNoteInCreate wraps a Note with a Create activity.
+	// WrapStatusableInCreate wraps a Statusable (eg., a Note) with a Create activity.
 	//
-	// If objectIRIOnly is set to true, then the function won't put the *entire* note in the Object field of the Create,
-	// but just the AP URI of the note. This is useful in cases where you want to give a remote server something to dereference,
+	// If objectIRIOnly is set to true, then the function won't put the *entire* status in the Object field of the Create,
+	// but just the AP URI of the status. This is useful in cases where you want to give a remote server something to dereference,
 	// and still have control over whether or not they're allowed to actually see the contents.
-	WrapNoteInCreate(note vocab.ActivityStreamsNote, objectIRIOnly bool) (vocab.ActivityStreamsCreate, error)
+	WrapStatusableInCreate(status ap.Statusable, objectIRIOnly bool) (vocab.ActivityStreamsCreate, error)
 }
 
 type converter struct {
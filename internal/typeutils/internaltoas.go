@@ -28,6 +28,7 @@ import (
 	"github.com/superseriousbusiness/activity/pub"
 	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -399,7 +400,52 @@ func (c *converter) AccountToASMinimal(ctx context.Context, a *gtsmodel.Account)
 	return person, nil
 }
 
-func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.ActivityStreamsNote, error) {
+// settableStatusable is a Statusable whose properties
+// can be set, as returned from newASStatusable.
+type settableStatusable interface {
+	ap.Statusable
+
+	SetJSONLDId(vocab.JSONLDIdProperty)
+	SetActivityStreamsInReplyTo(vocab.ActivityStreamsInReplyToProperty)
+	SetActivityStreamsPublished(vocab.ActivityStreamsPublishedProperty)
+	SetActivityStreamsUrl(vocab.ActivityStreamsUrlProperty)
+	SetActivityStreamsAttributedTo(vocab.ActivityStreamsAttributedToProperty)
+	SetActivityStreamsTag(vocab.ActivityStreamsTagProperty)
+	SetActivityStreamsTo(vocab.ActivityStreamsToProperty)
+	SetActivityStreamsCc(vocab.ActivityStreamsCcProperty)
+	SetActivityStreamsAttachment(vocab.ActivityStreamsAttachmentProperty)
+	SetActivityStreamsReplies(vocab.ActivityStreamsRepliesProperty)
+	SetActivityStreamsSensitive(vocab.ActivityStreamsSensitiveProperty)
+}
+
+// newASStatusable returns a new, empty activity streams object of
+// the given type, for serializing a status. Statuses created here
+// are always Notes, but remote ones may be of some other type that
+// we should preserve when we serve them, eg., an Article.
+func newASStatusable(typeName string) settableStatusable {
+	switch typeName {
+	case ap.ObjectArticle:
+		return streams.NewActivityStreamsArticle()
+	case ap.ObjectAudio:
+		return streams.NewActivityStreamsAudio()
+	case ap.ObjectDocument:
+		return streams.NewActivityStreamsDocument()
+	case ap.ObjectEvent:
+		return streams.NewActivityStreamsEvent()
+	case ap.ObjectImage:
+		return streams.NewActivityStreamsImage()
+	case ap.ObjectPage:
+		return streams.NewActivityStreamsPage()
+	case ap.ObjectVideo:
+		return streams.NewActivityStreamsVideo()
+	case ap.ActivityQuestion:
+		return streams.NewActivityStreamsQuestion()
+	default:
+		return streams.NewActivityStreamsNote()
+	}
+}
+
+func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Statusable, error) {
 	// ensure prerequisites here before we get stuck in
 
 	// check if author account is already attached to status and attach it if not
@@ -412,8 +458,8 @@ func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.A
 		s.Account = a
 	}
 
-	// create the Note!
-	status := streams.NewActivityStreamsNote()
+	// create the Note (or whatever type it originally was)!
+	status := newASStatusable(s.ActivityStreamsType)
 
 	// id
 	statusURI, err := url.Parse(s.URI)
@@ -618,7 +664,7 @@ func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.A
 
 	// sensitive
 	sensitiveProp := streams.NewActivityStreamsSensitiveProperty()
-	sensitiveProp.AppendXMLSchemaBoolean(*s.Sensitive)
+	sensitiveProp.AppendXMLSchemaBoolean(statusSensitive(s))
 	status.SetActivityStreamsSensitive(sensitiveProp)
 
 	return status, nil
@@ -1357,12 +1403,12 @@ func (c *converter) StatusesToASOutboxPage(ctx context.Context, outboxID string,
 	var highest string
 	var lowest string
 	for _, s := range statuses {
-		note, err := c.StatusToAS(ctx, s)
+		statusable, err := c.StatusToAS(ctx, s)
 		if err != nil {
 			return nil, err
 		}
 
-		create, err := c.WrapNoteInCreate(note, true)
+		create, err := c.WrapStatusableInCreate(statusable, true)
 		if err != nil {
 			return nil, err
 		}
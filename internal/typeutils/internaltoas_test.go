@@ -361,6 +361,24 @@ func (suite *InternalToASTestSuite) TestStatusToAS() {
 }`, string(bytes))
 }
 
+func (suite *InternalToASTestSuite) TestStatusToASKeepsType() {
+	// copy a remote status, and pretend it
+	// was originally federated as an Article
+	testStatus := &gtsmodel.Status{}
+	*testStatus = *suite.testStatuses["remote_account_1_status_1"]
+	testStatus.ActivityStreamsType = ap.ObjectArticle
+	ctx := context.Background()
+
+	asStatus, err := suite.typeconverter.StatusToAS(ctx, testStatus)
+	suite.NoError(err)
+	suite.Equal(ap.ObjectArticle, asStatus.GetTypeName())
+
+	ser, err := ap.Serialize(asStatus)
+	suite.NoError(err)
+	suite.Equal(ap.ObjectArticle, ser["type"])
+	suite.Equal(testStatus.URI, ser["id"])
+}
+
 func (suite *InternalToASTestSuite) TestStatusWithTagsToASWithIDs() {
 	// use the status with just IDs of attachments and emojis pinned on it
 	testStatus := suite.testStatuses["admin_account_status_1"]
@@ -19,6 +19,7 @@ package typeutils
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
@@ -82,9 +83,39 @@ func (c *converter) AccountToAPIAccountSensitive(ctx context.Context, a *gtsmode
 		FollowRequestsCount: frc,
 	}
 
+	if a.IsLocal() {
+		// Include media storage usage for local accounts.
+		apiAccount.Source.MediaQuota, err = c.mediaQuota(ctx, a)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return apiAccount, nil
 }
 
+// mediaQuota returns the media storage usage and quota of given local account.
+func (c *converter) mediaQuota(ctx context.Context, a *gtsmodel.Account) (*apimodel.MediaQuota, error) {
+	user, err := c.db.GetUserByAccountID(ctx, a.ID)
+	if errors.Is(err, db.ErrNoEntries) {
+		// No user, e.g. the instance account.
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error getting user: %w", err)
+	}
+
+	usage, err := c.db.GetAccountMediaUsage(ctx, a.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting media usage: %w", err)
+	}
+
+	return &apimodel.MediaQuota{
+		Usage:    usage,
+		Limit:    media.AccountQuota(user),
+		Override: user.MediaQuota != nil,
+	}, nil
+}
+
 func (c *converter) AccountToAPIAccountPublic(ctx context.Context, a *gtsmodel.Account) (*apimodel.Account, error) {
 	if err := c.db.PopulateAccount(ctx, a); err != nil {
 		log.Errorf(ctx, "error(s) populating account, will continue: %s", err)
@@ -379,7 +410,8 @@ func (c *converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		Confirmed:              confirmed,
 		Approved:               approved,
 		Disabled:               disabled,
-		Silenced:               !a.SilencedAt.IsZero(),
+		Sensitized:             a.IsSensitized(),
+		Silenced:               a.IsSilenced(),
 		Suspended:              !a.SuspendedAt.IsZero(),
 		Account:                apiAccount,
 		CreatedByApplicationID: createdByApplicationID,
@@ -465,6 +497,14 @@ func (c *converter) AttachmentToAPIAttachment(ctx context.Context, a *gtsmodel.M
 			apiAttachment.Meta.Original.FrameRate = fr + "/1"
 		}
 
+		if i := a.FileMeta.Original.Bitrate; i != nil {
+			apiAttachment.Meta.Original.Bitrate = int(*i)
+		}
+	case gtsmodel.FileTypeAudio:
+		if i := a.FileMeta.Original.Duration; i != nil {
+			apiAttachment.Meta.Original.Duration = *i
+		}
+
 		if i := a.FileMeta.Original.Bitrate; i != nil {
 			apiAttachment.Meta.Original.Bitrate = int(*i)
 		}
@@ -634,7 +674,7 @@ func (c *converter) StatusToAPIStatus(ctx context.Context, s *gtsmodel.Status, r
 		CreatedAt:          util.FormatISO8601(s.CreatedAt),
 		InReplyToID:        nil,
 		InReplyToAccountID: nil,
-		Sensitive:          *s.Sensitive,
+		Sensitive:          statusSensitive(s),
 		SpoilerText:        s.ContentWarning,
 		Visibility:         c.VisToAPIVis(ctx, s.Visibility),
 		Language:           nil,
@@ -763,6 +803,13 @@ func (c *converter) InstanceToAPIV1Instance(ctx context.Context, i *gtsmodel.Ins
 	// URLs
 	instance.URLs.StreamingAPI = "wss://" + i.Domain
 
+	// rules
+	rules, err := c.instanceRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("InstanceToAPIV1Instance: db error getting instance rules: %w", err)
+	}
+	instance.Rules = rules
+
 	// statistics
 	stats := make(map[string]int, 3)
 	userCount, err := c.db.CountInstanceUsers(ctx, i.Domain)
@@ -836,7 +883,6 @@ func (c *converter) InstanceToAPIV2Instance(ctx context.Context, i *gtsmodel.Ins
 		Description:   i.Description,
 		Usage:         apimodel.InstanceV2Usage{}, // todo: not implemented
 		Languages:     []string{},                 // todo: not implemented
-		Rules:         []interface{}{},            // todo: not implemented
 	}
 
 	// thumbnail
@@ -866,6 +912,13 @@ func (c *converter) InstanceToAPIV2Instance(ctx context.Context, i *gtsmodel.Ins
 
 	instance.Thumbnail = thumbnail
 
+	// rules
+	rules, err := c.instanceRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("InstanceToAPIV2Instance: db error getting instance rules: %w", err)
+	}
+	instance.Rules = rules
+
 	// configuration
 	instance.Configuration.URLs.Streaming = "wss://" + i.Domain
 	instance.Configuration.Statuses.MaxCharacters = config.GetStatusesMaxChars()
@@ -983,12 +1036,30 @@ func (c *converter) NotificationToAPINotification(ctx context.Context, n *gtsmod
 		apiStatus = apiStatus.Reblog.Status
 	}
 
+	var apiReport *apimodel.Report
+	if n.ReportID != "" {
+		if n.Report == nil {
+			report, err := c.db.GetReportByID(ctx, n.ReportID)
+			if err != nil {
+				return nil, fmt.Errorf("NotificationToapi: error getting report with id %s from the db: %s", n.ReportID, err)
+			}
+			n.Report = report
+		}
+
+		var err error
+		apiReport, err = c.ReportToAPIReport(ctx, n.Report)
+		if err != nil {
+			return nil, fmt.Errorf("NotificationToapi: error converting report to api: %s", err)
+		}
+	}
+
 	return &apimodel.Notification{
 		ID:        n.ID,
 		Type:      string(n.NotificationType),
 		CreatedAt: util.FormatISO8601(n.CreatedAt),
 		Account:   apiAccount,
 		Status:    apiStatus,
+		Report:    apiReport,
 	}, nil
 }
 
@@ -1020,16 +1091,36 @@ func (c *converter) DomainBlockToAPIDomainBlock(ctx context.Context, b *gtsmodel
 	return domainBlock, nil
 }
 
+func (c *converter) EmailDomainBlockToAPIEmailDomainBlock(ctx context.Context, b *gtsmodel.EmailDomainBlock) (*apimodel.EmailDomainBlock, error) {
+	// Domain may be in Punycode,
+	// de-punify it just in case.
+	d, err := util.DePunify(b.Domain)
+	if err != nil {
+		return nil, fmt.Errorf("EmailDomainBlockToAPIEmailDomainBlock: error de-punifying domain %s: %w", b.Domain, err)
+	}
+
+	return &apimodel.EmailDomainBlock{
+		ID:        b.ID,
+		Domain:    d,
+		CreatedBy: b.CreatedByAccountID,
+		CreatedAt: util.FormatISO8601(b.CreatedAt),
+	}, nil
+}
+
 func (c *converter) ReportToAPIReport(ctx context.Context, r *gtsmodel.Report) (*apimodel.Report, error) {
 	report := &apimodel.Report{
 		ID:          r.ID,
 		CreatedAt:   util.FormatISO8601(r.CreatedAt),
 		ActionTaken: !r.ActionTakenAt.IsZero(),
-		Category:    "other", // todo: only support default 'other' category right now
+		Category:    reportCategory(r),
 		Comment:     r.Comment,
 		Forwarded:   *r.Forwarded,
 		StatusIDs:   r.StatusIDs,
-		RuleIDs:     []int{}, // todo: not supported yet
+		RuleIDs:     r.RuleIDs,
+	}
+
+	if report.RuleIDs == nil {
+		report.RuleIDs = []string{}
 	}
 
 	if !r.ActionTakenAt.IsZero() {
@@ -1064,6 +1155,7 @@ func (c *converter) ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Repo
 		actionTakenAt        *string
 		actionTakenComment   *string
 		actionTakenByAccount *apimodel.AdminAccountInfo
+		assignedAccount      *apimodel.AdminAccountInfo
 	)
 
 	if !r.ActionTakenAt.IsZero() {
@@ -1107,6 +1199,20 @@ func (c *converter) ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Repo
 		}
 	}
 
+	if r.AssignedAccountID != "" {
+		if r.AssignedAccount == nil {
+			r.AssignedAccount, err = c.db.GetAccountByID(ctx, r.AssignedAccountID)
+			if err != nil {
+				return nil, fmt.Errorf("ReportToAdminAPIReport: error getting assigned account with id %s from the db: %w", r.AssignedAccountID, err)
+			}
+		}
+
+		assignedAccount, err = c.AccountToAdminAPIAccount(ctx, r.AssignedAccount)
+		if err != nil {
+			return nil, fmt.Errorf("ReportToAdminAPIReport: error converting assigned account with id %s to adminAPIAccount: %w", r.AssignedAccountID, err)
+		}
+	}
+
 	statuses := make([]*apimodel.Status, 0, len(r.StatusIDs))
 	if len(r.StatusIDs) != 0 && len(r.Statuses) == 0 {
 		r.Statuses, err = c.db.GetStatuses(ctx, r.StatusIDs)
@@ -1122,6 +1228,18 @@ func (c *converter) ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Repo
 		statuses = append(statuses, status)
 	}
 
+	rules := make([]*apimodel.InstanceRule, 0, len(r.RuleIDs))
+	if len(r.RuleIDs) != 0 && len(r.Rules) == 0 {
+		r.Rules, err = c.db.GetRulesByIDs(ctx, r.RuleIDs)
+		if err != nil {
+			return nil, fmt.Errorf("ReportToAdminAPIReport: error getting rules from the db: %w", err)
+		}
+	}
+	for _, rule := range r.Rules {
+		apiRule := c.RuleToAPIRule(rule)
+		rules = append(rules, &apiRule)
+	}
+
 	if ac := r.ActionTaken; ac != "" {
 		actionTakenComment = &ac
 	}
@@ -1130,18 +1248,182 @@ func (c *converter) ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Repo
 		ID:                   r.ID,
 		ActionTaken:          !r.ActionTakenAt.IsZero(),
 		ActionTakenAt:        actionTakenAt,
-		Category:             "other", // todo: only support default 'other' category right now
+		Category:             reportCategory(r),
 		Comment:              r.Comment,
 		Forwarded:            *r.Forwarded,
 		CreatedAt:            util.FormatISO8601(r.CreatedAt),
 		UpdatedAt:            util.FormatISO8601(r.UpdatedAt),
 		Account:              account,
 		TargetAccount:        targetAccount,
-		AssignedAccount:      actionTakenByAccount,
+		AssignedAccount:      assignedAccount,
 		ActionTakenByAccount: actionTakenByAccount,
 		ActionTakenComment:   actionTakenComment,
 		Statuses:             statuses,
-		Rules:                []interface{}{}, // not implemented
+		Rules:                rules,
+	}, nil
+}
+
+func (c *converter) ReportNoteToAdminAPIReportNote(ctx context.Context, n *gtsmodel.ReportNote) (*apimodel.AdminReportNote, error) {
+	var (
+		err         error
+		inReplyToID *string
+	)
+
+	if n.Account == nil {
+		n.Account, err = c.db.GetAccountByID(ctx, n.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("ReportNoteToAdminAPIReportNote: error getting account with id %s from the db: %w", n.AccountID, err)
+		}
+	}
+	account, err := c.AccountToAdminAPIAccount(ctx, n.Account)
+	if err != nil {
+		return nil, fmt.Errorf("ReportNoteToAdminAPIReportNote: error converting account with id %s to adminAPIAccount: %w", n.AccountID, err)
+	}
+
+	if irt := n.InReplyToID; irt != "" {
+		inReplyToID = &irt
+	}
+
+	return &apimodel.AdminReportNote{
+		ID:          n.ID,
+		CreatedAt:   util.FormatISO8601(n.CreatedAt),
+		ReportID:    n.ReportID,
+		InReplyToID: inReplyToID,
+		Account:     account,
+		Content:     n.Content,
+	}, nil
+}
+
+// reportCategory returns the category of the given
+// report, defaulting to 'other' if none was stored.
+func reportCategory(r *gtsmodel.Report) string {
+	if r.Category == "" {
+		return gtsmodel.ReportCategoryOther
+	}
+	return r.Category
+}
+
+func (c *converter) RuleToAPIRule(r *gtsmodel.Rule) apimodel.InstanceRule {
+	return apimodel.InstanceRule{
+		ID:   r.ID,
+		Text: r.Text,
+	}
+}
+
+func (c *converter) RuleToAdminAPIRule(r *gtsmodel.Rule) *apimodel.AdminInstanceRule {
+	return &apimodel.AdminInstanceRule{
+		ID:        r.ID,
+		CreatedAt: util.FormatISO8601(r.CreatedAt),
+		UpdatedAt: util.FormatISO8601(r.UpdatedAt),
+		Text:      r.Text,
+		Order:     r.Order,
+	}
+}
+
+func (c *converter) IPBlockToAdminAPIIPBlock(b *gtsmodel.IPBlock) *apimodel.AdminIPBlock {
+	apiBlock := &apimodel.AdminIPBlock{
+		ID:        b.ID,
+		IP:        b.IP,
+		Severity:  string(b.Severity),
+		Comment:   b.Comment,
+		CreatedAt: util.FormatISO8601(b.CreatedAt),
+	}
+
+	if !b.ExpiresAt.IsZero() {
+		expiresAt := util.FormatISO8601(b.ExpiresAt)
+		apiBlock.ExpiresAt = &expiresAt
+	}
+
+	return apiBlock
+}
+
+func (c *converter) ExportToAPIExport(e *gtsmodel.Export) *apimodel.Export {
+	apiExport := &apimodel.Export{
+		ID:        e.ID,
+		CreatedAt: util.FormatISO8601(e.CreatedAt),
+		State:     string(e.State),
+		Size:      e.Size,
+		Error:     e.Error,
+	}
+
+	if !e.CompletedAt.IsZero() {
+		completedAt := util.FormatISO8601(e.CompletedAt)
+		apiExport.CompletedAt = &completedAt
+	}
+
+	if !e.ExpiresAt.IsZero() {
+		expiresAt := util.FormatISO8601(e.ExpiresAt)
+		apiExport.ExpiresAt = &expiresAt
+	}
+
+	return apiExport
+}
+
+func (c *converter) ImportToAPIImport(i *gtsmodel.Import) *apimodel.Import {
+	apiImport := &apimodel.Import{
+		ID:        i.ID,
+		CreatedAt: util.FormatISO8601(i.CreatedAt),
+		Type:      string(i.Type),
+		Mode:      string(i.Mode),
+		State:     string(i.State),
+		Total:     i.Total,
+		Processed: i.Processed,
+		Failed:    i.Failed,
+		Failures:  i.Failures,
+		Error:     i.Error,
+	}
+
+	if apiImport.Failures == nil {
+		apiImport.Failures = []string{}
+	}
+
+	if !i.CompletedAt.IsZero() {
+		completedAt := util.FormatISO8601(i.CompletedAt)
+		apiImport.CompletedAt = &completedAt
+	}
+
+	return apiImport
+}
+
+// instanceRules returns the active rules of this instance, converted to api models.
+func (c *converter) instanceRules(ctx context.Context) ([]apimodel.InstanceRule, error) {
+	rules, err := c.db.GetActiveRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	apiRules := make([]apimodel.InstanceRule, 0, len(rules))
+	for _, rule := range rules {
+		apiRules = append(apiRules, c.RuleToAPIRule(rule))
+	}
+
+	return apiRules, nil
+}
+
+func (c *converter) AuditLogEntryToAdminAPIAuditLogEntry(ctx context.Context, e *gtsmodel.AuditLogEntry) (*apimodel.AdminAuditLogEntry, error) {
+	var account *apimodel.AdminAccountInfo
+	if e.Account != nil {
+		var err error
+		account, err = c.AccountToAdminAPIAccount(ctx, e.Account)
+		if err != nil {
+			return nil, fmt.Errorf("AuditLogEntryToAdminAPIAuditLogEntry: error converting account to api for %s: %w", e.AccountID, err)
+		}
+	}
+
+	diff := json.RawMessage(e.Diff)
+	if len(diff) == 0 {
+		diff = json.RawMessage("{}")
+	}
+
+	return &apimodel.AdminAuditLogEntry{
+		ID:         e.ID,
+		CreatedAt:  util.FormatISO8601(e.CreatedAt),
+		AccountID:  e.AccountID,
+		Account:    account,
+		Action:     e.Action,
+		TargetType: string(e.TargetType),
+		TargetID:   e.TargetID,
+		Diff:       diff,
 	}, nil
 }
 
@@ -21,8 +21,10 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -203,7 +205,12 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendSensitive() {
     "status_content_type": "text/plain",
     "note": "hey yo this is my profile!",
     "fields": [],
-    "follow_requests_count": 0
+    "follow_requests_count": 0,
+    "media_quota": {
+      "usage": 4463269,
+      "limit": 0,
+      "override": false
+    }
   },
   "enable_rss": true,
   "role": {
@@ -437,6 +444,29 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontend() {
 }`, string(b))
 }
 
+func (suite *InternalToFrontendTestSuite) TestStatusToFrontendSensitizedAccount() {
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["admin_account"]
+	testAccount.SensitizedAt = time.Now()
+
+	testStatus := &gtsmodel.Status{}
+	*testStatus = *suite.testStatuses["admin_account_status_1"]
+	testStatus.Account = testAccount
+	requestingAccount := suite.testAccounts["local_account_1"]
+
+	// Status has media, so should be marked sensitive.
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(context.Background(), testStatus, requestingAccount)
+	suite.NoError(err)
+	suite.True(apiStatus.Sensitive)
+
+	// Without media the status is left alone.
+	testStatus.AttachmentIDs = nil
+	testStatus.Attachments = nil
+	apiStatus, err = suite.typeconverter.StatusToAPIStatus(context.Background(), testStatus, requestingAccount)
+	suite.NoError(err)
+	suite.False(apiStatus.Sensitive)
+}
+
 func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownLanguage() {
 	testStatus := &gtsmodel.Status{}
 	*testStatus = *suite.testStatuses["admin_account_status_1"]
@@ -631,7 +661,17 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV1ToFrontend() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "image/heif",
+        "image/avif",
+        "video/mp4",
+        "video/webm",
+        "video/x-matroska",
+        "video/quicktime",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/flac",
+        "audio/mp4",
+        "audio/wav"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -689,6 +729,16 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV1ToFrontend() {
       "name": "admin"
     }
   },
+  "rules": [
+    {
+      "id": "01H6Y8HD6R6C0N5M3MHW3X4PZ1",
+      "text": "Be nice to each other."
+    },
+    {
+      "id": "01H6Y8J2ZK0QEB9G5XG6Q7VCA2",
+      "text": "No advertising or spam."
+    }
+  ],
   "max_toot_chars": 5000
 }`, string(b))
 }
@@ -749,7 +799,17 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV2ToFrontend() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "image/heif",
+        "image/avif",
+        "video/mp4",
+        "video/webm",
+        "video/x-matroska",
+        "video/quicktime",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/flac",
+        "audio/mp4",
+        "audio/wav"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -804,7 +864,16 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV2ToFrontend() {
       }
     }
   },
-  "rules": []
+  "rules": [
+    {
+      "id": "01H6Y8HD6R6C0N5M3MHW3X4PZ1",
+      "text": "Be nice to each other."
+    },
+    {
+      "id": "01H6Y8J2ZK0QEB9G5XG6Q7VCA2",
+      "text": "No advertising or spam."
+    }
+  ]
 }`, string(b))
 }
 
@@ -1003,6 +1072,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
     "confirmed": false,
     "approved": false,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -1044,6 +1114,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -1100,6 +1171,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -1146,6 +1218,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -1177,11 +1250,31 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
     "created_by_application_id": "01F8MGXQRHYF5QPMTMXP78QC2F"
   },
   "statuses": [],
-  "rule_ids": [],
+  "rules": [],
   "action_taken_comment": "user was warned not to be a turtle anymore"
 }`, string(b))
 }
 
+func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendWithRules() {
+	requestingAccount := suite.testAccounts["admin_account"]
+	rules := testrig.NewTestRules()
+
+	// Rules cited by a report are still shown
+	// on the report even once they're deleted.
+	report := suite.testReports["remote_account_1_report_local_account_2"]
+	report.Category = gtsmodel.ReportCategoryViolation
+	report.RuleIDs = []string{rules["rule1"].ID, rules["deleted_rule"].ID}
+
+	adminReport, err := suite.typeconverter.ReportToAdminAPIReport(context.Background(), report, requestingAccount)
+	suite.NoError(err)
+
+	suite.Equal("violation", adminReport.Category)
+	suite.Equal([]*apimodel.InstanceRule{
+		{ID: "01H6Y8HD6R6C0N5M3MHW3X4PZ1", Text: "Be nice to each other."},
+		{ID: "01H6Y8JF3V7RSW2T4N9K0XB8D3", Text: "No posting about cheese."},
+	}, adminReport.Rules)
+}
+
 func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
 	requestingAccount := suite.testAccounts["admin_account"]
 	adminReport, err := suite.typeconverter.ReportToAdminAPIReport(context.Background(), suite.testReports["local_account_2_report_remote_account_1"], requestingAccount)
@@ -1215,6 +1308,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -1271,6 +1365,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
     "confirmed": false,
     "approved": false,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -1380,7 +1475,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
       "poll": null
     }
   ],
-  "rule_ids": [],
+  "rules": [],
   "action_taken_comment": null
 }`, string(b))
 }
@@ -1440,6 +1535,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
     "confirmed": false,
     "approved": false,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -1481,6 +1577,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": true,
     "account": {
@@ -1526,6 +1623,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -1572,6 +1670,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -1603,7 +1702,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
     "created_by_application_id": "01F8MGXQRHYF5QPMTMXP78QC2F"
   },
   "statuses": [],
-  "rule_ids": [],
+  "rules": [],
   "action_taken_comment": "user was warned not to be a turtle anymore"
 }`, string(b))
 }
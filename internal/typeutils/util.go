@@ -21,7 +21,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"html"
 	"net/url"
+	"strings"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -73,6 +75,20 @@ func (c *converter) interactionsWithStatusForAccount(ctx context.Context, s *gts
 	return si, nil
 }
 
+// statusSensitive returns whether the given status should be
+// shown as sensitive: either because its author marked it as
+// such, or because it has media attached and its author has
+// been sensitized by an admin.
+func statusSensitive(s *gtsmodel.Status) bool {
+	if *s.Sensitive {
+		return true
+	}
+
+	return s.Account != nil &&
+		s.Account.IsSensitized() &&
+		len(s.AttachmentIDs) != 0
+}
+
 func misskeyReportInlineURLs(content string) []*url.URL {
 	m := regexes.MisskeyReportNotes.FindAllStringSubmatch(content, -1)
 	urls := make([]*url.URL, 0, len(m))
@@ -85,6 +101,52 @@ func misskeyReportInlineURLs(content string) []*url.URL {
 	return urls
 }
 
+// articleContent renders the title and (html) summary
+// of a remote Article as status content, followed by a
+// "read more" link to the full article at the given URL.
+func articleContent(title string, summary string, link string) string {
+	var b strings.Builder
+
+	if title != "" {
+		b.WriteString("<p><strong>" + html.EscapeString(title) + "</strong></p>")
+	}
+
+	if summary != "" {
+		if !strings.HasPrefix(summary, "<") {
+			summary = "<p>" + summary + "</p>"
+		}
+		b.WriteString(summary)
+	}
+
+	b.WriteString(`<p><a href="` + html.EscapeString(link) + `">Read more</a></p>`)
+	return b.String()
+}
+
+// titledContent prepends the given plaintext
+// title to the given html content, if set.
+func titledContent(title string, content string) string {
+	if title == "" {
+		return content
+	}
+	return "<p><strong>" + html.EscapeString(title) + "</strong></p>" + content
+}
+
+// pollContent renders the given plaintext poll options
+// as an html list, or returns an empty string if none.
+func pollContent(options []string) string {
+	if len(options) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("<ul>")
+	for _, option := range options {
+		b.WriteString("<li>" + html.EscapeString(option) + "</li>")
+	}
+	b.WriteString("</ul>")
+	return b.String()
+}
+
 // getURI is a shortcut/util function for extracting
 // the JSONLDId URI of an Activity or Object.
 func getURI(withID ap.WithJSONLDId) (*url.URL, string, error) {
@@ -41,6 +41,7 @@ const (
 	BlocksPath       = "blocks"        // BlocksPath is used to generate the URI for a block
 	ReportsPath      = "reports"       // ReportsPath is used to generate the URI for a report/flag
 	ConfirmEmailPath = "confirm_email" // ConfirmEmailPath is used to generate the URI for an email confirmation link
+	UnsubscribePath  = "unsubscribe"   // UnsubscribePath is used to generate the URI for an email unsubscribe link
 	FileserverPath   = "fileserver"    // FileserverPath is a path component for serving attachments + media
 	EmojiPath        = "emoji"         // EmojiPath represents the activitypub emoji location
 )
@@ -125,6 +126,14 @@ func GenerateURIForEmailConfirm(token string) string {
 	return fmt.Sprintf("%s://%s/%s?token=%s", protocol, host, ConfirmEmailPath, token)
 }
 
+// GenerateURIForEmailUnsubscribe returns a link for unsubscribing from the given type of email -- something like:
+// https://example.org/unsubscribe?token=490e337c-0162-454f-ac48-4b22bb92a205&type=mentions
+func GenerateURIForEmailUnsubscribe(token string, emailType string) string {
+	protocol := config.GetProtocol()
+	host := config.GetHost()
+	return fmt.Sprintf("%s://%s/%s?token=%s&type=%s", protocol, host, UnsubscribePath, token, emailType)
+}
+
 // GenerateURIsForAccount throws together a bunch of URIs for the given username, with the given protocol and host.
 func GenerateURIsForAccount(username string) *UserURIs {
 	protocol := config.GetProtocol()
@@ -0,0 +1,57 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package util
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// NormalizeIPRange parses the given IP address or range in CIDR notation,
+// and returns it as a range in CIDR notation with any host bits cleared,
+// eg., `192.0.2.1` becomes `192.0.2.1/32`, `192.0.2.1/24` becomes
+// `192.0.2.0/24`, and IPv4-mapped IPv6 addresses become IPv4.
+func NormalizeIPRange(ip string) (string, error) {
+	ip = strings.TrimSpace(ip)
+
+	if !strings.Contains(ip, "/") {
+		addr, err := netip.ParseAddr(ip)
+		if err != nil {
+			return "", fmt.Errorf("error parsing ip %s: %w", ip, err)
+		}
+		addr = addr.Unmap()
+		return netip.PrefixFrom(addr, addr.BitLen()).String(), nil
+	}
+
+	prefix, err := netip.ParsePrefix(ip)
+	if err != nil {
+		return "", fmt.Errorf("error parsing ip range %s: %w", ip, err)
+	}
+
+	if addr := prefix.Addr(); addr.Is4In6() {
+		// Convert mapped IPv4 ranges to plain IPv4,
+		// provided they only cover mapped addresses.
+		if prefix.Bits() < 96 {
+			return "", fmt.Errorf("error parsing ip range %s: mixes ipv4-mapped and ipv6 addresses", ip)
+		}
+		prefix = netip.PrefixFrom(addr.Unmap(), prefix.Bits()-96)
+	}
+
+	return prefix.Masked().String(), nil
+}
@@ -0,0 +1,59 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type IPSuite struct {
+	suite.Suite
+}
+
+func (suite *IPSuite) TestNormalizeIPRange() {
+	for in, expected := range map[string]string{
+		"192.0.2.1":             "192.0.2.1/32",
+		" 192.0.2.1/24 ":        "192.0.2.0/24",
+		"2001:db8::1":           "2001:db8::1/128",
+		"2001:DB8:1::/32":       "2001:db8::/32",
+		"::ffff:192.0.2.1":      "192.0.2.1/32",
+		"::ffff:192.0.2.77/120": "192.0.2.0/24",
+	} {
+		out, err := util.NormalizeIPRange(in)
+		suite.NoError(err, in)
+		suite.Equal(expected, out, in)
+	}
+
+	for _, in := range []string{
+		"",
+		"example.org",
+		"192.0.2.1/33",
+		"192.0.2.1/",
+		"::ffff:192.0.2.1/64",
+	} {
+		_, err := util.NormalizeIPRange(in)
+		suite.Error(err, in)
+	}
+}
+
+func TestIPSuite(t *testing.T) {
+	suite.Run(t, &IPSuite{})
+}
@@ -45,6 +45,10 @@ const (
 	maximumProfileFieldLength     = 255
 	maximumProfileFields          = 6
 	maximumListTitleLength        = 200
+	maximumInstanceRuleLength     = 1000
+	maximumReportNoteLength       = 5000
+	maximumDomainLength           = 253
+	maximumDomainLabelLength      = 63
 )
 
 // NewPassword returns an error if the given password is not sufficiently strong, or nil if it's ok.
@@ -284,3 +288,110 @@ func ListRepliesPolicy(repliesPolicy gtsmodel.RepliesPolicy) error {
 		return fmt.Errorf("list replies_policy must be either empty or one of 'followed', 'list', 'none'")
 	}
 }
+
+// InstanceRule validates the text of a new or updated instance rule.
+func InstanceRule(text string) error {
+	if text == "" {
+		return fmt.Errorf("rule text must be provided, and must be no more than %d chars", maximumInstanceRuleLength)
+	}
+
+	if length := len([]rune(text)); length > maximumInstanceRuleLength {
+		return fmt.Errorf("rule text length must be no more than %d chars, provided text was %d chars", maximumInstanceRuleLength, length)
+	}
+
+	return nil
+}
+
+// ReportNote validates the content of a new report note.
+func ReportNote(content string) error {
+	if content == "" {
+		return fmt.Errorf("note content must be provided, and must be no more than %d chars", maximumReportNoteLength)
+	}
+
+	if length := len([]rune(content)); length > maximumReportNoteLength {
+		return fmt.Errorf("note content length must be no more than %d chars, provided content was %d chars", maximumReportNoteLength, length)
+	}
+
+	return nil
+}
+
+// ReportCategory validates the category of a new report.
+func ReportCategory(category string) error {
+	switch category {
+	case gtsmodel.ReportCategorySpam, gtsmodel.ReportCategoryLegal, gtsmodel.ReportCategoryViolation, gtsmodel.ReportCategoryOther:
+		return nil
+	default:
+		return fmt.Errorf("report category must be one of 'spam', 'legal', 'violation', 'other'")
+	}
+}
+
+// EmailPreference validates a user's choice of how to be emailed about something.
+func EmailPreference(pref gtsmodel.EmailPreference) error {
+	switch pref {
+	case gtsmodel.EmailPreferenceOff, gtsmodel.EmailPreferenceImmediate, gtsmodel.EmailPreferenceDigest:
+		return nil
+	default:
+		return fmt.Errorf("email preference '%s' was not recognized, valid options are 'off', 'immediate', 'digest'", pref)
+	}
+}
+
+// EmailDigestFrequency validates a user's choice of how often to receive digest emails.
+func EmailDigestFrequency(frequency gtsmodel.EmailDigestFrequency) error {
+	switch frequency {
+	case gtsmodel.EmailDigestDaily, gtsmodel.EmailDigestWeekly:
+		return nil
+	default:
+		return fmt.Errorf("email digest frequency '%s' was not recognized, valid options are 'daily', 'weekly'", frequency)
+	}
+}
+
+// EmailDomain validates a domain to block email addresses from.
+// The domain should already be lowercased and in punycode.
+func EmailDomain(domain string) error {
+	if domain == "" {
+		return errors.New("no email domain provided")
+	}
+
+	labels := strings.Split(domain, ".")
+	if len(labels) < 2 || len(domain) > maximumDomainLength {
+		return fmt.Errorf("email domain '%s' is not a valid domain", domain)
+	}
+
+	for _, label := range labels {
+		if !domainLabel(label) {
+			return fmt.Errorf("email domain '%s' is not a valid domain", domain)
+		}
+	}
+
+	return nil
+}
+
+// domainLabel returns whether the given string is a valid
+// lowercase ASCII (ie., punycode) label of a domain name.
+func domainLabel(label string) bool {
+	if len(label) == 0 || len(label) > maximumDomainLabelLength {
+		return false
+	}
+
+	if label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+
+	for _, r := range label {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IPBlockSeverity validates the severity of an IP block.
+func IPBlockSeverity(severity gtsmodel.IPBlockSeverity) error {
+	switch severity {
+	case gtsmodel.IPBlockSignUpRequiresApproval, gtsmodel.IPBlockSignUpBlock, gtsmodel.IPBlockNoAccess:
+		return nil
+	default:
+		return fmt.Errorf("ip block severity '%s' was not recognized, valid options are 'sign_up_requires_approval', 'sign_up_block', 'no_access'", severity)
+	}
+}
@@ -342,6 +342,14 @@ func (suite *ValidationTestSuite) TestValidateCustomCSSTooLongUnicode() {
 	suite.EqualError(err, "custom_css must be less than 5 characters, but submitted custom_css was 10 characters")
 }
 
+func (suite *ValidationTestSuite) TestValidateEmailDomain() {
+	suite.NoError(validate.EmailDomain("example.org"))
+	suite.NoError(validate.EmailDomain("mail.xn--fiqs8s"))
+	suite.EqualError(validate.EmailDomain(""), "no email domain provided")
+	suite.EqualError(validate.EmailDomain("not a domain"), "email domain 'not a domain' is not a valid domain")
+	suite.EqualError(validate.EmailDomain("@example.org"), "email domain '@example.org' is not a valid domain")
+}
+
 func TestValidationTestSuite(t *testing.T) {
 	suite.Run(t, new(ValidationTestSuite))
 }
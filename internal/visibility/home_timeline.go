@@ -91,6 +91,25 @@ func (f *Filter) isStatusHomeTimelineable(ctx context.Context, owner *gtsmodel.A
 		return true, nil
 	}
 
+	// Don't show statuses by, or boosts
+	// of, accounts the owner has muted.
+	muted, err := f.state.DB.IsMuted(ctx, owner.ID, status.AccountID)
+	if err != nil {
+		return false, fmt.Errorf("isStatusHomeTimelineable: error checking mute: %w", err)
+	}
+
+	if !muted && status.BoostOfAccountID != "" {
+		muted, err = f.state.DB.IsMuted(ctx, owner.ID, status.BoostOfAccountID)
+		if err != nil {
+			return false, fmt.Errorf("isStatusHomeTimelineable: error checking mute: %w", err)
+		}
+	}
+
+	if muted {
+		log.Trace(ctx, "status author muted by timeline owner")
+		return false, nil
+	}
+
 	if status.MentionsAccount(owner.ID) {
 		// Can always see when you are mentioned.
 		return true, nil
@@ -80,6 +80,26 @@ func (f *Filter) isStatusPublicTimelineable(ctx context.Context, requester *gtsm
 		return false, nil
 	}
 
+	if status.Account == nil {
+		// Status author needed for silence check below.
+		account, err := f.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			status.AccountID,
+		)
+		if err != nil {
+			return false, fmt.Errorf("isStatusPublicTimelineable: error getting status author %s: %w", status.AccountID, err)
+		}
+		status.Account = account
+	}
+
+	// Don't show silenced accounts on timeline,
+	// even to requesters who would otherwise be
+	// able to see their statuses.
+	if status.Account.IsSilenced() {
+		log.Trace(ctx, "status author silenced")
+		return false, nil
+	}
+
 	// Check whether status is visible to requesting account.
 	visible, err := f.StatusVisible(ctx, requester, status)
 	if err != nil {
@@ -96,6 +96,14 @@ func (f *Filter) isStatusVisible(ctx context.Context, requester *gtsmodel.Accoun
 		return false, nil
 	}
 
+	// Check whether status accounts are silenced to the requester.
+	visible, err = f.areStatusAccountsUnsilenced(ctx, requester, status)
+	if err != nil {
+		return false, fmt.Errorf("isStatusVisible: error checking status %s account silencing: %w", status.ID, err)
+	} else if !visible {
+		return false, nil
+	}
+
 	if status.Visibility == gtsmodel.VisibilityPublic {
 		// This status will be visible to all.
 		return true, nil
@@ -217,3 +225,53 @@ func (f *Filter) areStatusAccountsVisible(ctx context.Context, requester *gtsmod
 
 	return true, nil
 }
+
+// areStatusAccountsUnsilenced checks whether status author and the status boost-of (if set) author
+// have been silenced by an admin, in which case the status is only visible to the silenced author
+// themselves and their followers.
+func (f *Filter) areStatusAccountsUnsilenced(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status) (bool, error) {
+	visible, err := f.isSilencedAccountVisible(ctx, requester, status.Account)
+	if err != nil {
+		return false, fmt.Errorf("error checking status author silencing: %w", err)
+	}
+
+	if !visible {
+		log.Trace(ctx, "status author silenced to requester")
+		return false, nil
+	}
+
+	if status.BoostOfID != "" && status.BoostOfAccount != nil {
+		visible, err := f.isSilencedAccountVisible(ctx, requester, status.BoostOfAccount)
+		if err != nil {
+			return false, fmt.Errorf("error checking boosted author silencing: %w", err)
+		}
+
+		if !visible {
+			log.Trace(ctx, "boosted status author silenced to requester")
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// isSilencedAccountVisible returns false if account is silenced and requester is neither the account itself nor one of its followers.
+func (f *Filter) isSilencedAccountVisible(ctx context.Context, requester *gtsmodel.Account, account *gtsmodel.Account) (bool, error) {
+	if !account.IsSilenced() {
+		// Nothing to check.
+		return true, nil
+	}
+
+	if requester == nil {
+		// Silenced accounts are never visible without auth.
+		return false, nil
+	}
+
+	if requester.ID == account.ID {
+		// Silenced accounts can always see themselves.
+		return true, nil
+	}
+
+	// Only followers can see silenced accounts.
+	return f.state.DB.IsFollowing(ctx, requester.ID, account.ID)
+}
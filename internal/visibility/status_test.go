@@ -20,6 +20,7 @@ package visibility_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -156,6 +157,45 @@ func (suite *StatusVisibleTestSuite) TestStatusNotVisibleIfNotFollowingCached()
 	suite.False(visible)
 }
 
+func (suite *StatusVisibleTestSuite) TestSilencedStatusVisibleOnlyToFollowers() {
+	ctx := context.Background()
+
+	// Silence the author of a public status.
+	author := suite.testAccounts["local_account_2"]
+	author.SilencedAt = time.Now()
+	if err := suite.db.UpdateAccount(ctx, author, "silenced_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	testStatus, err := suite.db.GetStatusByID(ctx, suite.testStatuses["local_account_2_status_1"].ID)
+	suite.NoError(err)
+
+	// Author can still see their own status.
+	visible, err := suite.filter.StatusVisible(ctx, author, testStatus)
+	suite.NoError(err)
+	suite.True(visible)
+
+	// local_account_1 follows the author.
+	visible, err = suite.filter.StatusVisible(ctx, suite.testAccounts["local_account_1"], testStatus)
+	suite.NoError(err)
+	suite.True(visible)
+
+	// admin_account does not follow the author.
+	visible, err = suite.filter.StatusVisible(ctx, suite.testAccounts["admin_account"], testStatus)
+	suite.NoError(err)
+	suite.False(visible)
+
+	// No auth means no follow.
+	visible, err = suite.filter.StatusVisible(ctx, nil, testStatus)
+	suite.NoError(err)
+	suite.False(visible)
+
+	// Not on the public timeline, even for followers.
+	timelineable, err := suite.filter.StatusPublicTimelineable(ctx, suite.testAccounts["local_account_1"], testStatus)
+	suite.NoError(err)
+	suite.False(timelineable)
+}
+
 func TestStatusVisibleTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusVisibleTestSuite))
 }
@@ -0,0 +1,100 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package web
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/user"
+)
+
+// unsubscribeDescriptions describes what the user
+// is unsubscribing from, for each unsubscribe type.
+var unsubscribeDescriptions = map[string]string{
+	string(gtsmodel.EmailTypeMentions):       "emails about mentions",
+	string(gtsmodel.EmailTypeDirect):         "emails about direct messages",
+	string(gtsmodel.EmailTypeFollows):        "emails about new followers",
+	string(gtsmodel.EmailTypeFollowRequests): "emails about follow requests",
+	string(gtsmodel.EmailTypeReportsClosed):  "emails about your reports being closed",
+	user.UnsubscribeDigest:                   "digest emails",
+}
+
+// unsubscribeGETHandler serves a page asking the user to confirm
+// that they want to unsubscribe. We don't unsubscribe on GET, since
+// mail scanners may follow links in emails without the user knowing.
+func (m *Module) unsubscribeGETHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	token := c.Query(tokenParam)
+	description, ok := unsubscribeDescriptions[c.Query(typeParam)]
+	if token == "" || !ok {
+		apiutil.WebErrorHandler(c, gtserror.NewErrorNotFound(errors.New(http.StatusText(http.StatusNotFound))), m.processor.InstanceGetV1)
+		return
+	}
+
+	instance, err := m.processor.InstanceGetV1(ctx)
+	if err != nil {
+		apiutil.WebErrorHandler(c, gtserror.NewErrorInternalError(err), m.processor.InstanceGetV1)
+		return
+	}
+
+	c.HTML(http.StatusOK, "unsubscribe.tmpl", gin.H{
+		"instance":    instance,
+		"description": description,
+		"action":      c.Request.URL.RequestURI(),
+	})
+}
+
+// unsubscribePOSTHandler unsubscribes the user. This is
+// used both by the confirmation form, and by mail clients
+// performing a one-click unsubscribe (RFC 8058).
+func (m *Module) unsubscribePOSTHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	token := c.Query(tokenParam)
+	unsubscribeType := c.Query(typeParam)
+	description, ok := unsubscribeDescriptions[unsubscribeType]
+	if token == "" || !ok {
+		apiutil.WebErrorHandler(c, gtserror.NewErrorNotFound(errors.New(http.StatusText(http.StatusNotFound))), m.processor.InstanceGetV1)
+		return
+	}
+
+	u, errWithCode := m.processor.User().EmailUnsubscribe(ctx, token, unsubscribeType)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	instance, err := m.processor.InstanceGetV1(ctx)
+	if err != nil {
+		apiutil.WebErrorHandler(c, gtserror.NewErrorInternalError(err), m.processor.InstanceGetV1)
+		return
+	}
+
+	c.HTML(http.StatusOK, "unsubscribed.tmpl", gin.H{
+		"instance":    instance,
+		"description": description,
+		"email":       u.Email,
+		"username":    u.Account.Username,
+	})
+}
@@ -36,6 +36,7 @@ import (
 
 const (
 	confirmEmailPath   = "/" + uris.ConfirmEmailPath
+	unsubscribePath    = "/" + uris.UnsubscribePath
 	profileGroupPath   = "/@:" + usernameKey
 	statusPath         = "/statuses/:" + statusIDKey // leave out the '/@:username' prefix as this will be served within the profile group
 	customCSSPath      = profileGroupPath + "/custom.css"
@@ -48,6 +49,7 @@ const (
 	adminPanelPath     = settingsPathPrefix + "/admin"
 
 	tokenParam  = "token"
+	typeParam   = "type"
 	usernameKey = "username"
 	statusIDKey = "status"
 
@@ -102,6 +104,8 @@ func (m *Module) Route(r router.Router, mi ...gin.HandlerFunc) {
 	r.AttachHandler(http.MethodGet, customCSSPath, m.customCSSGETHandler)
 	r.AttachHandler(http.MethodGet, rssFeedPath, m.rssFeedGETHandler)
 	r.AttachHandler(http.MethodGet, confirmEmailPath, m.confirmEmailGETHandler)
+	r.AttachHandler(http.MethodGet, unsubscribePath, m.unsubscribeGETHandler)
+	r.AttachHandler(http.MethodPost, unsubscribePath, m.unsubscribePOSTHandler)
 	r.AttachHandler(http.MethodGet, robotsPath, m.robotsGETHandler)
 	r.AttachHandler(http.MethodGet, aboutPath, m.aboutGETHandler)
 	r.AttachHandler(http.MethodGet, domainBlockListPath, m.domainBlockListGETHandler)
@@ -48,6 +48,12 @@ type Workers struct {
 	// Media manager worker pools.
 	Media runners.WorkerPool
 
+	// Jobs provides a small worker pool for long-running
+	// background jobs, such as building account exports and
+	// running imports, which would otherwise tie up the
+	// client API workers for minutes or hours at a time.
+	Jobs runners.WorkerPool
+
 	// prevent pass-by-value.
 	_ nocopy
 }
@@ -72,6 +78,10 @@ func (w *Workers) Start() {
 	tryUntil("starting media workerpool", 5, func() bool {
 		return w.Media.Start(8*maxprocs, 80*maxprocs)
 	})
+
+	tryUntil("starting jobs workerpool", 5, func() bool {
+		return w.Jobs.Start(maxprocs, 100*maxprocs)
+	})
 }
 
 // Stop will stop all of the contained worker pools (and global scheduler).
@@ -80,6 +90,7 @@ func (w *Workers) Stop() {
 	tryUntil("stopping client API workerpool", 5, w.ClientAPI.Stop)
 	tryUntil("stopping federator workerpool", 5, w.Federator.Stop)
 	tryUntil("stopping media workerpool", 5, w.Media.Stop)
+	tryUntil("stopping jobs workerpool", 5, w.Jobs.Stop)
 }
 
 // nocopy when embedded will signal linter to
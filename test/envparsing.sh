@@ -8,10 +8,23 @@ EXPECT=$(cat <<"EOF"
     "accounts-allow-custom-css": true,
     "accounts-approval-required": false,
     "accounts-custom-css-length": 5000,
+    "accounts-export-expiry": 86400000000000,
+    "accounts-import-max-outbox-size": 69,
+    "accounts-import-max-size": 420,
     "accounts-reason-required": false,
     "accounts-registration-open": true,
     "advanced-cookies-samesite": "strict",
+    "advanced-rate-limit-exceptions": [
+        "192.0.2.0/24",
+        "127.0.0.1/32"
+    ],
+    "advanced-rate-limit-fileserver-requests": 1000,
+    "advanced-rate-limit-inbox-requests": 1000,
+    "advanced-rate-limit-media-upload-requests": 60,
     "advanced-rate-limit-requests": 6969,
+    "advanced-rate-limit-sign-in-requests": 10,
+    "advanced-rate-limit-status-post-requests": 300,
+    "advanced-rate-limit-timeline-requests": 300,
     "advanced-sender-multiplier": -1,
     "advanced-throttling-multiplier": -1,
     "advanced-throttling-retry-after": 10000000000,
@@ -68,6 +81,9 @@ EXPECT=$(cat <<"EOF"
             "tombstone-sweep-freq": 60000000000,
             "tombstone-ttl": 1800000000000,
             "user-max-size": 500,
+            "user-mute-max-size": 1000,
+            "user-mute-sweep-freq": 60000000000,
+            "user-mute-ttl": 1800000000000,
             "user-sweep-freq": 60000000000,
             "user-ttl": 1800000000000,
             "webfinger-max-size": 250,
@@ -78,6 +94,7 @@ EXPECT=$(cat <<"EOF"
         "visibility-sweep-freq": 60000000000,
         "visibility-ttl": 1800000000000
     },
+    "comment": "",
     "config-path": "internal/config/testdata/test.yaml",
     "db-address": ":memory:",
     "db-database": "gotosocial_prod",
@@ -94,12 +111,15 @@ EXPECT=$(cat <<"EOF"
     "db-user": "sex-haver",
     "dry-run": true,
     "email": "",
+    "expires-in": 0,
+    "health-auth-token": "",
     "host": "example.com",
     "instance-deliver-to-shared-inboxes": false,
     "instance-expose-peers": true,
     "instance-expose-public-timeline": true,
     "instance-expose-suspended": true,
     "instance-expose-suspended-web": true,
+    "ip": "",
     "landing-page-user": "admin",
     "letsencrypt-cert-dir": "/gotosocial/storage/certs",
     "letsencrypt-email-address": "",
@@ -108,13 +128,25 @@ EXPECT=$(cat <<"EOF"
     "log-client-ip": false,
     "log-db-queries": true,
     "log-level": "info",
+    "media": false,
+    "media-account-quota": 1048576,
     "media-description-max-chars": 5000,
     "media-description-min-chars": 69,
     "media-emoji-local-max-size": 420,
     "media-emoji-remote-max-size": 420,
+    "media-ffmpeg-path": "/usr/bin/ffmpeg",
     "media-image-max-size": 420,
     "media-remote-cache-days": 30,
     "media-video-max-size": 420,
+    "metrics-auth-token": "",
+    "metrics-enabled": false,
+    "oauth-access-token-expiry": 3600000000000,
+    "oauth-personal-tokens-enabled": false,
+    "oauth-personal-tokens-scopes": [
+        "read",
+        "write:statuses"
+    ],
+    "oauth-refresh-token-expiry": 1209600000000000,
     "oidc-admin-groups": [
         "steamy"
     ],
@@ -133,7 +165,9 @@ EXPECT=$(cat <<"EOF"
     "path": "",
     "port": 6969,
     "protocol": "http",
+    "quota": "",
     "request-id-header": "X-Trace-Id",
+    "severity": "",
     "smtp-disclose-recipients": true,
     "smtp-from": "queen.rip.in.piss@terfisland.org",
     "smtp-host": "example.com",
@@ -209,6 +243,9 @@ GTS_INSTANCE_EXPOSE_PUBLIC_TIMELINE=true \
 GTS_INSTANCE_DELIVER_TO_SHARED_INBOXES=false \
 GTS_ACCOUNTS_ALLOW_CUSTOM_CSS=true \
 GTS_ACCOUNTS_CUSTOM_CSS_LENGTH=5000 \
+GTS_ACCOUNTS_EXPORT_EXPIRY=24h \
+GTS_ACCOUNTS_IMPORT_MAX_SIZE=420 \
+GTS_ACCOUNTS_IMPORT_MAX_OUTBOX_SIZE=69 \
 GTS_ACCOUNTS_REGISTRATION_OPEN=true \
 GTS_ACCOUNTS_APPROVAL_REQUIRED=false \
 GTS_ACCOUNTS_REASON_REQUIRED=false \
@@ -219,6 +256,8 @@ GTS_MEDIA_DESCRIPTION_MAX_CHARS=5000 \
 GTS_MEDIA_REMOTE_CACHE_DAYS=30 \
 GTS_MEDIA_EMOJI_LOCAL_MAX_SIZE=420 \
 GTS_MEDIA_EMOJI_REMOTE_MAX_SIZE=420 \
+GTS_MEDIA_ACCOUNT_QUOTA=1048576 \
+GTS_MEDIA_FFMPEG_PATH='/usr/bin/ffmpeg' \
 GTS_STORAGE_BACKEND='local' \
 GTS_STORAGE_LOCAL_BASE_PATH='/root/store' \
 GTS_STORAGE_S3_ACCESS_KEY='minio' \
@@ -245,6 +284,10 @@ GTS_OIDC_CLIENT_SECRET='shhhh its a secret' \
 GTS_OIDC_SCOPES='read,write' \
 GTS_OIDC_LINK_EXISTING=true \
 GTS_OIDC_ADMIN_GROUPS='steamy' \
+GTS_OAUTH_ACCESS_TOKEN_EXPIRY='1h' \
+GTS_OAUTH_PERSONAL_TOKENS_ENABLED=false \
+GTS_OAUTH_PERSONAL_TOKENS_SCOPES='read,write:statuses' \
+GTS_OAUTH_REFRESH_TOKEN_EXPIRY='336h' \
 GTS_SMTP_HOST='example.com' \
 GTS_SMTP_PORT=4269 \
 GTS_SMTP_USERNAME='sex-haver' \
@@ -256,7 +299,9 @@ GTS_SYSLOG_PROTOCOL='udp' \
 GTS_SYSLOG_ADDRESS='127.0.0.1:6969' \
 GTS_TRACING_ENDPOINT='localhost:4317' \
 GTS_ADVANCED_COOKIES_SAMESITE='strict' \
+GTS_ADVANCED_RATE_LIMIT_EXCEPTIONS='192.0.2.0/24,127.0.0.1/32' \
 GTS_ADVANCED_RATE_LIMIT_REQUESTS=6969 \
+GTS_ADVANCED_RATE_LIMIT_SIGN_IN_REQUESTS=10 \
 GTS_ADVANCED_SENDER_MULTIPLIER=-1 \
 GTS_ADVANCED_THROTTLING_MULTIPLIER=-1 \
 GTS_ADVANCED_THROTTLING_RETRY_AFTER='10s' \
@@ -67,11 +67,14 @@ var testDefaults = config.Configuration{
 	InstanceExposeSuspendedWeb:     true,
 	InstanceDeliverToSharedInboxes: true,
 
-	AccountsRegistrationOpen: true,
-	AccountsApprovalRequired: true,
-	AccountsReasonRequired:   true,
-	AccountsAllowCustomCSS:   true,
-	AccountsCustomCSSLength:  10000,
+	AccountsRegistrationOpen:    true,
+	AccountsApprovalRequired:    true,
+	AccountsReasonRequired:      true,
+	AccountsAllowCustomCSS:      true,
+	AccountsCustomCSSLength:     10000,
+	AccountsExportExpiry:        7 * 24 * time.Hour,
+	AccountsImportMaxSize:       104857600, // 100mb
+	AccountsImportMaxOutboxSize: 10485760,  // 10mb
 
 	MediaImageMaxSize:        10485760, // 10mb
 	MediaVideoMaxSize:        41943040, // 40mb
@@ -80,6 +83,8 @@ var testDefaults = config.Configuration{
 	MediaRemoteCacheDays:     30,
 	MediaEmojiLocalMaxSize:   51200,  // 50kb
 	MediaEmojiRemoteMaxSize:  102400, // 100kb
+	MediaAccountQuota:        0,      // no limit
+	MediaFFmpegPath:          "",     // disabled, for deterministic thumbnails
 
 	// the testrig only uses in-memory storage, so we can
 	// safely set this value to 'test' to avoid running storage
@@ -107,6 +112,11 @@ var testDefaults = config.Configuration{
 	OIDCScopes:           []string{oidc.ScopeOpenID, "profile", "email", "groups"},
 	OIDCLinkExisting:     false,
 
+	OAuthAccessTokenExpiry:     0,
+	OAuthRefreshTokenExpiry:    720 * time.Hour, // 30 days
+	OAuthPersonalTokensEnabled: true,
+	OAuthPersonalTokensScopes:  []string{"read", "write", "follow", "push", "admin:read", "admin:write"},
+
 	SMTPHost:               "",
 	SMTPPort:               0,
 	SMTPUsername:           "",
@@ -119,14 +129,26 @@ var testDefaults = config.Configuration{
 	TracingTransport:         "grpc",
 	TracingInsecureTransport: true,
 
+	MetricsEnabled:   false,
+	MetricsAuthToken: "",
+
+	HealthAuthToken: "",
+
 	SyslogEnabled:  false,
 	SyslogProtocol: "udp",
 	SyslogAddress:  "localhost:514",
 
-	AdvancedCookiesSamesite:      "lax",
-	AdvancedRateLimitRequests:    0, // disabled
-	AdvancedThrottlingMultiplier: 0, // disabled
-	AdvancedSenderMultiplier:     0, // 1 sender only, regardless of CPU
+	AdvancedCookiesSamesite:              "lax",
+	AdvancedRateLimitRequests:            0, // disabled
+	AdvancedRateLimitExceptions:          []string{},
+	AdvancedRateLimitSignInRequests:      0, // disabled
+	AdvancedRateLimitMediaUploadRequests: 0, // disabled
+	AdvancedRateLimitStatusPostRequests:  0, // disabled
+	AdvancedRateLimitTimelineRequests:    0, // disabled
+	AdvancedRateLimitInboxRequests:       0, // disabled
+	AdvancedRateLimitFileserverRequests:  0, // disabled
+	AdvancedThrottlingMultiplier:         0, // disabled
+	AdvancedSenderMultiplier:             0, // 1 sender only, regardless of CPU
 
 	SoftwareVersion: "0.0.0-testrig",
 
@@ -59,7 +59,17 @@ var testModels = []interface{}{
 	&gtsmodel.Client{},
 	&gtsmodel.EmojiCategory{},
 	&gtsmodel.Tombstone{},
+	&gtsmodel.Blob{},
 	&gtsmodel.Report{},
+	&gtsmodel.ReportNote{},
+	&gtsmodel.AuditLogEntry{},
+	&gtsmodel.DailyActivity{},
+	&gtsmodel.Rule{},
+	&gtsmodel.IPBlock{},
+	&gtsmodel.Export{},
+	&gtsmodel.Import{},
+	&gtsmodel.UserMute{},
+	&gtsmodel.DeviceAuthorization{},
 }
 
 // NewTestDB returns a new initialized, empty database for testing.
@@ -152,18 +162,42 @@ func StandardDBSetup(db db.DB, accounts map[string]*gtsmodel.Account) {
 		}
 	}
 
+	for _, v := range NewTestRules() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
 	for _, v := range NewTestReports() {
 		if err := db.Put(ctx, v); err != nil {
 			log.Panic(nil, err)
 		}
 	}
 
+	for _, v := range NewTestReportNotes() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
 	for _, v := range NewTestDomainBlocks() {
 		if err := db.Put(ctx, v); err != nil {
 			log.Panic(nil, err)
 		}
 	}
 
+	for _, v := range NewTestEmailDomainBlocks() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
+	for _, v := range NewTestIPBlocks() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
 	for _, v := range NewTestInstances() {
 		if err := db.Put(ctx, v); err != nil {
 			log.Panic(nil, err)
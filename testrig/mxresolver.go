@@ -0,0 +1,75 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package testrig
+
+import (
+	"context"
+	"net"
+
+	"github.com/superseriousbusiness/gotosocial/internal/processing/user"
+)
+
+// NewMockMXResolver returns an MX resolver which answers from
+// the given map of domain to MX hostnames, rather than making
+// any DNS queries. If mxs is nil, NewTestMXRecords is used.
+//
+// Lookups of domains not in the map return a not found error.
+func NewMockMXResolver(mxs map[string][]string) user.MXResolver {
+	if mxs == nil {
+		mxs = NewTestMXRecords()
+	}
+	return &mockMXResolver{mxs: mxs}
+}
+
+// NewTestMXRecords returns a map of test email domains to
+// the hostnames of the mail exchangers which handle them.
+func NewTestMXRecords() map[string][]string {
+	return map[string][]string{
+		// A vanity domain whose mail is
+		// handled by a blocked provider.
+		"vanity.example.com": {
+			"mx1.disposable.example.org.",
+			"mx2.disposable.example.org.",
+		},
+		"example.org": {
+			"mail.example.org.",
+		},
+	}
+}
+
+type mockMXResolver struct {
+	mxs map[string][]string
+}
+
+func (r *mockMXResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	hosts, ok := r.mxs[name]
+	if !ok {
+		return nil, &net.DNSError{
+			Err:        "no such host",
+			Name:       name,
+			IsNotFound: true,
+		}
+	}
+
+	mxs := make([]*net.MX, len(hosts))
+	for i, host := range hosts {
+		mxs[i] = &net.MX{Host: host, Pref: uint16(10 * (i + 1))}
+	}
+
+	return mxs, nil
+}
@@ -27,7 +27,7 @@ import (
 
 // NewTestProcessor returns a Processor suitable for testing purposes
 func NewTestProcessor(state *state.State, federator federation.Federator, emailSender email.Sender, mediaManager *media.Manager) *processing.Processor {
-	p := processing.NewProcessor(NewTestTypeConverter(state.DB), federator, NewTestOauthServer(state.DB), mediaManager, state, emailSender)
+	p := processing.NewProcessor(NewTestTypeConverter(state.DB), federator, NewTestOauthServer(state.DB), mediaManager, state, emailSender, NewMockMXResolver(nil))
 	state.Workers.EnqueueClientAPI = p.EnqueueClientAPI
 	state.Workers.EnqueueFederator = p.EnqueueFederator
 	return p
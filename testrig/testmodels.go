@@ -1204,6 +1204,35 @@ func NewTestEmojiCategories() map[string]*gtsmodel.EmojiCategory {
 	}
 }
 
+func NewTestRules() map[string]*gtsmodel.Rule {
+	return map[string]*gtsmodel.Rule{
+		"rule1": {
+			ID:        "01H6Y8HD6R6C0N5M3MHW3X4PZ1",
+			CreatedAt: TimeMustParse("2022-08-10T14:21:33+02:00"),
+			UpdatedAt: TimeMustParse("2022-08-10T14:21:33+02:00"),
+			Text:      "Be nice to each other.",
+			Order:     0,
+			Deleted:   FalseBool(),
+		},
+		"rule2": {
+			ID:        "01H6Y8J2ZK0QEB9G5XG6Q7VCA2",
+			CreatedAt: TimeMustParse("2022-08-10T14:22:10+02:00"),
+			UpdatedAt: TimeMustParse("2022-08-10T14:22:10+02:00"),
+			Text:      "No advertising or spam.",
+			Order:     1,
+			Deleted:   FalseBool(),
+		},
+		"deleted_rule": {
+			ID:        "01H6Y8JF3V7RSW2T4N9K0XB8D3",
+			CreatedAt: TimeMustParse("2022-08-10T14:22:45+02:00"),
+			UpdatedAt: TimeMustParse("2022-08-12T09:00:00+02:00"),
+			Text:      "No posting about cheese.",
+			Order:     2,
+			Deleted:   TrueBool(),
+		},
+	}
+}
+
 func NewTestStatusToEmojis() map[string]*gtsmodel.StatusToEmoji {
 	return map[string]*gtsmodel.StatusToEmoji{
 		"admin_account_status_1_rainbow": {
@@ -1260,6 +1289,61 @@ func NewTestDomainBlocks() map[string]*gtsmodel.DomainBlock {
 	}
 }
 
+func NewTestEmailDomainBlocks() map[string]*gtsmodel.EmailDomainBlock {
+	return map[string]*gtsmodel.EmailDomainBlock{
+		"disposable.example.org": {
+			ID:                 "01H7Z9X6V2C0Q3M8K5R4T1BN7E",
+			CreatedAt:          TimeMustParse("2023-08-15T12:00:00+02:00"),
+			UpdatedAt:          TimeMustParse("2023-08-15T12:00:00+02:00"),
+			Domain:             "disposable.example.org",
+			CreatedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+		},
+	}
+}
+
+// NewTestIPBlocks returns a map of IP blocks keyed according to
+// their severity. Note that httptest requests come from 192.0.2.1,
+// so that range is deliberately left unblocked.
+func NewTestIPBlocks() map[string]*gtsmodel.IPBlock {
+	return map[string]*gtsmodel.IPBlock{
+		"sign_up_requires_approval": {
+			ID:                 "01H8GQ1M5X3T6YV0B2N4K7C9DE",
+			CreatedAt:          TimeMustParse("2023-08-20T12:00:00+02:00"),
+			UpdatedAt:          TimeMustParse("2023-08-20T12:00:00+02:00"),
+			IP:                 "198.51.100.0/24",
+			Severity:           gtsmodel.IPBlockSignUpRequiresApproval,
+			Comment:            "lots of sign-ups from this range",
+			CreatedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+		},
+		"sign_up_block": {
+			ID:                 "01H8GQ2B7R1F4W8Z3M6P0S5TQA",
+			CreatedAt:          TimeMustParse("2023-08-20T12:05:00+02:00"),
+			UpdatedAt:          TimeMustParse("2023-08-20T12:05:00+02:00"),
+			IP:                 "198.51.100.64/26",
+			Severity:           gtsmodel.IPBlockSignUpBlock,
+			Comment:            "spam sign-ups",
+			CreatedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+		},
+		"no_access": {
+			ID:                 "01H8GQ33DK8N2C5V9X1J4H7GRB",
+			CreatedAt:          TimeMustParse("2023-08-20T12:10:00+02:00"),
+			UpdatedAt:          TimeMustParse("2023-08-20T12:10:00+02:00"),
+			IP:                 "2001:db8::/32",
+			Severity:           gtsmodel.IPBlockNoAccess,
+			CreatedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+		},
+		"expired": {
+			ID:                 "01H8GQ3W0E6A9B2Q4T7Y1V5XWC",
+			CreatedAt:          TimeMustParse("2023-08-20T12:15:00+02:00"),
+			UpdatedAt:          TimeMustParse("2023-08-20T12:15:00+02:00"),
+			IP:                 "203.0.113.0/24",
+			Severity:           gtsmodel.IPBlockNoAccess,
+			ExpiresAt:          TimeMustParse("2023-08-21T12:15:00+02:00"),
+			CreatedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+		},
+	}
+}
+
 type filenames struct {
 	Original string
 	Small    string
@@ -2032,6 +2116,20 @@ func NewTestReports() map[string]*gtsmodel.Report {
 			ActionTaken:            "user was warned not to be a turtle anymore",
 			ActionTakenAt:          TimeMustParse("2022-05-15T17:01:56+02:00"),
 			ActionTakenByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+			AssignedAccountID:      "01F8MH17FWEB39HZJ76B6VXSKF",
+		},
+	}
+}
+
+func NewTestReportNotes() map[string]*gtsmodel.ReportNote {
+	return map[string]*gtsmodel.ReportNote{
+		"admin_account_note_on_local_account_2_report": {
+			ID:        "01H7EY2Z3N0B4QW8V5KJ6A1XCM",
+			CreatedAt: TimeMustParse("2022-05-14T13:02:45+02:00"),
+			UpdatedAt: TimeMustParse("2022-05-14T13:02:45+02:00"),
+			ReportID:  "01GP3AWY4CRDVRNZKW0TEAMB5R",
+			AccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+			Content:   "Looked at the reported post, it does seem to be a bit much. Will check with the remote admin.",
 		},
 	}
 }
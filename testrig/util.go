@@ -43,6 +43,7 @@ func StartWorkers(state *state.State) {
 	_ = state.Workers.ClientAPI.Start(1, 10)
 	_ = state.Workers.Federator.Start(1, 10)
 	_ = state.Workers.Media.Start(1, 10)
+	_ = state.Workers.Jobs.Start(1, 10)
 }
 
 func StopWorkers(state *state.State) {
@@ -50,6 +51,7 @@ func StopWorkers(state *state.State) {
 	_ = state.Workers.ClientAPI.Stop()
 	_ = state.Workers.Federator.Stop()
 	_ = state.Workers.Media.Stop()
+	_ = state.Workers.Jobs.Stop()
 }
 
 func StartTimelines(state *state.State, filter *visibility.Filter, typeConverter typeutils.TypeConverter) {
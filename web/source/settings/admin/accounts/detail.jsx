@@ -93,7 +93,7 @@ function ModifyAccount({ account }) {
 			/>
 
 			<div className="action-buttons">
-				{/* <MutationButton
+				<MutationButton
 					label="Disable"
 					name="disable"
 					result={result}
@@ -102,13 +102,37 @@ function ModifyAccount({ account }) {
 					label="Silence"
 					name="silence"
 					result={result}
-				/> */}
+				/>
+				<MutationButton
+					label="Mark media sensitive"
+					name="sensitive"
+					result={result}
+				/>
 				<MutationButton
 					label="Suspend"
 					name="suspend"
 					result={result}
 				/>
 			</div>
+
+			<h2>Undo</h2>
+			<div className="action-buttons">
+				<MutationButton
+					label="Enable"
+					name="enable"
+					result={result}
+				/>
+				<MutationButton
+					label="Unsilence"
+					name="unsilence"
+					result={result}
+				/>
+				<MutationButton
+					label="Unmark media sensitive"
+					name="unsensitive"
+					result={result}
+				/>
+			</div>
 		</form>
 	);
 }
\ No newline at end of file
@@ -84,6 +84,20 @@ function ReportDetailForm({ data: report }) {
 
 					<b>Forwarded: </b> <span>{report.forwarded ? "Yes" : "No"}</span>
 					<b>Category: </b> <span>{report.category}</span>
+					<b>Assigned to: </b>
+					{report.assigned_account
+						? <span>@{report.assigned_account.account.acct}</span>
+						: <i className="no-comment">nobody</i>
+					}
+
+					{report.rules?.length > 0 && <>
+						<b>Rules broken: </b>
+						<ol className="rules">
+							{report.rules.map((rule) => (
+								<li key={rule.id}>{rule.text}</li>
+							))}
+						</ol>
+					</>}
 
 					<b>Reason: </b>
 					{report.comment.length > 0
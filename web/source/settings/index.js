@@ -38,6 +38,9 @@ const { Sidebar, ViewRouter } = createNavigation("/settings", [
 	Menu("User", [
 		Item("Profile", { icon: "fa-user" }, require("./user/profile")),
 		Item("Settings", { icon: "fa-cogs" }, require("./user/settings")),
+		Item("Applications", { icon: "fa-plug" }, require("./user/applications")),
+		Item("Export", { icon: "fa-download" }, require("./user/export")),
+		Item("Import", { icon: "fa-upload" }, require("./user/import")),
 	]),
 	Menu("Moderation", {
 		url: "admin",
@@ -50,7 +50,9 @@ function instanceBasedQuery(args, api, extraOptions) {
 			if (token != undefined) {
 				headers.set('Authorization', token);
 			}
-			headers.set("Accept", "application/json");
+			if (!headers.has("Accept")) {
+				headers.set("Accept", "application/json");
+			}
 			return headers;
 		},
 	})(args, api, extraOptions);
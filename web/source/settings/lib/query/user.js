@@ -19,7 +19,7 @@
 
 "use strict";
 
-const { replaceCacheOnMutation } = require("./lib");
+const { replaceCacheOnMutation, editCacheOnMutation } = require("./lib");
 const base = require("./base");
 
 const endpoints = (build) => ({
@@ -39,6 +39,110 @@ const endpoints = (build) => ({
 			url: `/api/v1/user/password_change`,
 			body: data
 		})
+	}),
+	emailPreferences: build.query({
+		query: () => ({
+			url: `/api/v1/user/email_preferences`
+		})
+	}),
+	updateEmailPreferences: build.mutation({
+		query: (data) => ({
+			method: "PATCH",
+			url: `/api/v1/user/email_preferences`,
+			body: data
+		}),
+		...replaceCacheOnMutation("emailPreferences")
+	}),
+	authorizedTokens: build.query({
+		query: () => ({
+			url: `/api/v1/user/tokens`
+		})
+	}),
+	createPersonalToken: build.mutation({
+		query: (data) => ({
+			method: "POST",
+			url: `/api/v1/user/tokens`,
+			asForm: true,
+			body: data
+		}),
+		...editCacheOnMutation("authorizedTokens", {
+			update: (draft, created) => {
+				// Don't keep the token itself around
+				// in the cache once it's been shown.
+				// eslint-disable-next-line no-unused-vars
+				const { access_token, ...token } = created;
+				draft.unshift(token);
+			}
+		})
+	}),
+	invalidateToken: build.mutation({
+		query: (id) => ({
+			method: "POST",
+			url: `/api/v1/user/tokens/${id}/invalidate`
+		}),
+		...editCacheOnMutation("authorizedTokens", {
+			update: (draft, revoked) => {
+				const index = draft.findIndex((token) => token.id == revoked.id);
+				if (index != -1) {
+					draft.splice(index, 1);
+				}
+			}
+		})
+	})
+	exports: build.query({
+		query: () => ({
+			url: `/api/v1/exports`
+		})
+	}),
+	createExport: build.mutation({
+		query: () => ({
+			method: "POST",
+			url: `/api/v1/exports`
+		}),
+		...editCacheOnMutation("exports", {
+			update: (draft, created) => {
+				draft.unshift(created);
+			}
+		})
+	}),
+	downloadExport: build.mutation({
+		query: (id) => ({
+			url: `/api/v1/exports/${id}/download`,
+			headers: { "Accept": "application/zip" },
+			responseHandler: async (response) => {
+				if (!response.ok) {
+					return response.json();
+				}
+
+				// Hand the archive to the browser to save,
+				// rather than keeping it around in the store.
+				const url = URL.createObjectURL(await response.blob());
+				const link = document.createElement("a");
+				link.href = url;
+				link.download = `export-${id}.zip`;
+				link.click();
+				URL.revokeObjectURL(url);
+				return null;
+			}
+		})
+	}),
+	imports: build.query({
+		query: () => ({
+			url: `/api/v1/import`
+		})
+	}),
+	createImport: build.mutation({
+		query: (formData) => ({
+			method: "POST",
+			url: `/api/v1/import`,
+			asForm: true,
+			body: formData
+		}),
+		...editCacheOnMutation("imports", {
+			update: (draft, created) => {
+				draft.unshift(created);
+			}
+		})
 	})
 });
 
@@ -0,0 +1,155 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+"use strict";
+
+const React = require("react");
+
+const query = require("../lib/query");
+
+const { useTextInput } = require("../lib/form");
+const useFormSubmit = require("../lib/form/submit");
+const { TextInput, Select } = require("../components/form/inputs");
+
+const FormWithData = require("../lib/form/form-with-data");
+const MutationButton = require("../components/form/mutation-button");
+
+module.exports = function UserApplications() {
+	return (
+		<div className="authorized-apps">
+			<h1>Authorized applications</h1>
+			<p>
+				These applications can access your account. Revoke access for any you no longer use or don't recognize;
+				they'll be signed out, and will need to be authorized again to be used.
+			</p>
+			<FormWithData
+				dataQuery={query.useAuthorizedTokensQuery}
+				DataForm={TokenList}
+			/>
+			<PersonalTokenForm />
+		</div>
+	);
+};
+
+function PersonalTokenForm() {
+	const form = {
+		name: useTextInput("name"),
+		scope: useTextInput("scope", { defaultValue: "read" }),
+		expiresIn: useTextInput("expires_in", { defaultValue: "" }),
+	};
+
+	const [submitForm, result] = useFormSubmit(form, query.useCreatePersonalTokenMutation(), { changedOnly: false });
+
+	const day = 24 * 60 * 60;
+
+	return (
+		<form className="personal-token" onSubmit={submitForm}>
+			<h2>Create a personal access token</h2>
+			<p>
+				Personal access tokens let your own scripts and bots use the API as you, without registering an application.
+				Only give a token the scopes it needs, and keep it secret: anyone who has it can act on your behalf.
+			</p>
+			<TextInput
+				field={form.name}
+				label="Name"
+				placeholder="My backup script"
+				required
+			/>
+			<TextInput
+				field={form.scope}
+				label="Scopes, separated by spaces (eg. read write:statuses)"
+			/>
+			<Select field={form.expiresIn} label="Expires" options={
+				<>
+					<option value="">Never</option>
+					<option value={day}>After 1 day</option>
+					<option value={7 * day}>After 7 days</option>
+					<option value={30 * day}>After 30 days</option>
+					<option value={90 * day}>After 90 days</option>
+					<option value={365 * day}>After 1 year</option>
+				</>
+			} />
+			<MutationButton label="Create token" result={result} />
+			{result.isSuccess && result.data.access_token &&
+				<div className="callout">
+					<p className="callout-title">Token {result.data.name} created</p>
+					<p>Copy your new token now; it won't be shown again.</p>
+					<code className="access-token">{result.data.access_token}</code>
+				</div>
+			}
+		</form>
+	);
+}
+
+function TokenList({ data: tokens }) {
+	if (tokens.length == 0) {
+		return <p>No applications have been authorized to access your account.</p>;
+	}
+
+	return (
+		<div className="list">
+			{tokens.map((token) => (
+				<TokenEntry key={token.id} token={token} />
+			))}
+		</div>
+	);
+}
+
+function TokenEntry({ token }) {
+	const [invalidateToken, result] = query.useInvalidateTokenMutation();
+
+	const app = token.application;
+
+	return (
+		<div className="entry">
+			<div>
+				<h2>
+					{token.name
+						? <>{token.name} <span className="token-kind">(personal access token)</span></>
+						: app.website
+							? <a href={app.website} target="_blank" rel="noreferrer">{app.name}</a>
+							: app.name
+					}
+				</h2>
+				<div className="details">
+					<b>Scopes: </b>
+					<span>{token.scope}</span>
+
+					<b>{token.name ? "Created" : "Authorized"}: </b>
+					<span>{new Date(token.created_at).toLocaleString()}</span>
+
+					<b>Last used: </b>
+					<span>{token.last_used ? new Date(token.last_used).toLocaleString() : "never"}</span>
+
+					{token.expires_at && <>
+						<b>Expires: </b>
+						<span>{new Date(token.expires_at).toLocaleString()}</span>
+					</>}
+				</div>
+			</div>
+			<MutationButton
+				label="Revoke access"
+				type="button"
+				className="danger"
+				onClick={() => invalidateToken(token.id)}
+				result={result}
+			/>
+		</div>
+	);
+}
@@ -0,0 +1,106 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+"use strict";
+
+const React = require("react");
+
+const query = require("../lib/query");
+
+const FormWithData = require("../lib/form/form-with-data");
+const MutationButton = require("../components/form/mutation-button");
+
+module.exports = function UserExport() {
+	const [createExport, result] = query.useCreateExportMutation();
+
+	function submit(e) {
+		e.preventDefault();
+		createExport();
+	}
+
+	return (
+		<div className="user-export">
+			<h1>Export your data</h1>
+			<p>
+				Request an archive of your account data, to keep as a backup or take with you to another instance.
+				It includes your posts with their media, your profile, and CSV files of your follows, followers,
+				blocks, lists and bookmarks, in the same formats as Mastodon.
+			</p>
+			<p>
+				The archive is built in the background, and you'll get an email when it's ready to download.
+				You can request one export per day, and each is removed after a while.
+			</p>
+			<form onSubmit={submit}>
+				<MutationButton label="Request export" result={result} />
+			</form>
+			<FormWithData
+				dataQuery={query.useExportsQuery}
+				DataForm={ExportList}
+			/>
+		</div>
+	);
+};
+
+function ExportList({ data: exports }) {
+	if (exports.length == 0) {
+		return <p>You haven't requested any exports yet.</p>;
+	}
+
+	return (
+		<div className="list">
+			{exports.map((exp) => (
+				<ExportEntry key={exp.id} exp={exp} />
+			))}
+		</div>
+	);
+}
+
+function ExportEntry({ exp }) {
+	const [downloadExport, result] = query.useDownloadExportMutation();
+
+	function submit(e) {
+		e.preventDefault();
+		downloadExport(exp.id);
+	}
+
+	return (
+		<div className="entry">
+			<div className="details">
+				<b>Requested: </b>
+				<span>{new Date(exp.created_at).toLocaleString()}</span>
+
+				<b>Status: </b>
+				<span>
+					{exp.state == "processing" && "Processing, check back later"}
+					{exp.state == "complete" && `Ready (${(exp.size / 1024 / 1024).toFixed(1)} MiB)`}
+					{exp.state == "failed" && `Failed: ${exp.error}`}
+				</span>
+
+				{exp.expires_at && <>
+					<b>Removed: </b>
+					<span>{new Date(exp.expires_at).toLocaleString()}</span>
+				</>}
+			</div>
+			{exp.state == "complete" &&
+				<form onSubmit={submit}>
+					<MutationButton label="Download" result={result} />
+				</form>
+			}
+		</div>
+	);
+}
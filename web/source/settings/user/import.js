@@ -0,0 +1,163 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+"use strict";
+
+const React = require("react");
+
+const query = require("../lib/query");
+
+const { useTextInput, useFileInput, useBoolInput } = require("../lib/form");
+const useFormSubmit = require("../lib/form/submit");
+const { FileInput, Select, Checkbox } = require("../components/form/inputs");
+
+const { Error } = require("../components/error");
+const Loading = require("../components/loading");
+const MutationButton = require("../components/form/mutation-button");
+
+module.exports = function UserImport() {
+	const form = {
+		data: useFileInput("data"),
+		type: useTextInput("type", { defaultValue: "following" }),
+		mode: useTextInput("mode", { defaultValue: "merge" }),
+		federate: useBoolInput("federate"),
+	};
+
+	const [submitForm, result] = useFormSubmit(form, query.useCreateImportMutation(), { changedOnly: false });
+
+	// Archives can only be merged into existing posts.
+	const isArchive = form.type.value == "archive";
+	const setMode = form.mode.setter;
+	React.useEffect(() => {
+		if (isArchive) {
+			setMode("merge");
+		}
+	}, [isArchive, setMode]);
+
+	return (
+		<div className="user-import">
+			<h1>Import data</h1>
+			<p>
+				Import your follows, blocks, mutes, bookmarks or lists from a CSV file, as exported from Mastodon or from
+				another GoToSocial instance. Accounts and posts not yet known to this instance are looked up as the
+				import goes along, so large imports can take a while.
+			</p>
+			<p>
+				<b>Merge</b> keeps your existing data and adds what's in the file. <b>Overwrite</b> also removes anything
+				not in the file, for example unfollowing accounts which aren't listed. Accounts must be followed to be
+				added to a list, so import your follows before your lists.
+			</p>
+			<p>
+				You can also import your posts from the archive of an export (a zip or tar.gz file). Your public, unlisted
+				and followers-only posts are recreated here with their original dates and media, and replies to your own
+				posts are threaded together again. Boosts, polls and direct messages aren't imported.
+			</p>
+			<form onSubmit={submitForm}>
+				<Select field={form.type} label="Type of data" options={
+					<>
+						<option value="following">Following list</option>
+						<option value="blocks">Blocking list</option>
+						<option value="muting">Muted accounts</option>
+						<option value="bookmarks">Bookmarks</option>
+						<option value="lists">Lists</option>
+						<option value="archive">Posts, from an archive</option>
+					</>
+				} />
+				{isArchive
+					? <Checkbox
+						field={form.federate}
+						label="Send imported posts to my followers, as if they were new (this may flood their timelines)"
+					/>
+					: <Select field={form.mode} label="Mode" options={
+						<>
+							<option value="merge">Merge</option>
+							<option value="overwrite">Overwrite</option>
+						</>
+					} />
+				}
+				<FileInput
+					field={form.data}
+					label={isArchive ? "Archive" : "CSV file"}
+					accept={isArchive ? ".zip,.tar.gz,.tgz,application/zip,application/gzip" : ".csv,text/csv"}
+				/>
+				<MutationButton label="Import" result={result} />
+			</form>
+			<ImportList />
+		</div>
+	);
+};
+
+function ImportList() {
+	const [polling, setPolling] = React.useState(0);
+	const { data: imports, isLoading, isError, error } = query.useImportsQuery(undefined, {
+		pollingInterval: polling
+	});
+
+	// Keep checking on progress while an import is in progress.
+	const processing = imports?.some((imp) => imp.state == "processing");
+	React.useEffect(() => {
+		setPolling(processing ? 5000 : 0);
+	}, [processing]);
+
+	if (isLoading) {
+		return <Loading />;
+	} else if (isError) {
+		return <Error error={error} />;
+	} else if (imports.length == 0) {
+		return <p>You haven't imported any data yet.</p>;
+	}
+
+	return (
+		<div className="list">
+			{imports.map((imp) => (
+				<ImportEntry key={imp.id} imp={imp} />
+			))}
+		</div>
+	);
+}
+
+function ImportEntry({ imp }) {
+	return (
+		<div className="entry">
+			<div className="details">
+				<b>Uploaded: </b>
+				<span>{new Date(imp.created_at).toLocaleString()}</span>
+
+				<b>Type: </b>
+				<span>{imp.type == "archive" ? "archive" : `${imp.type} (${imp.mode})`}</span>
+
+				<b>Status: </b>
+				<span>
+					{imp.state == "processing" && `Processing, ${imp.processed} of ${imp.total} rows done`}
+					{imp.state == "complete" && `Complete, ${imp.total - imp.failed} of ${imp.total} rows imported`}
+					{imp.state == "failed" && `Failed after ${imp.processed} of ${imp.total} rows: ${imp.error}`}
+				</span>
+			</div>
+			{imp.failures.length > 0 &&
+				<details>
+					<summary>Some data couldn't be imported</summary>
+					<ul>
+						{imp.failures.map((failure, i) => (
+							<li key={i}>{failure}</li>
+						))}
+					</ul>
+				</details>
+			}
+		</div>
+	);
+}
@@ -101,8 +101,82 @@ function UserSettingsForm({ data }) {
 			<div>
 				<PasswordChange />
 			</div>
+			<FormWithData
+				dataQuery={query.useEmailPreferencesQuery}
+				DataForm={EmailPreferencesForm}
+			/>
+			{data.source.media_quota &&
+				<MediaQuota quota={data.source.media_quota} />
+			}
+		</>
+	);
+}
+
+function formatBytes(bytes) {
+	const units = ["B", "KiB", "MiB", "GiB", "TiB"];
+	let i = 0;
+	while (bytes >= 1024 && i < units.length - 1) {
+		bytes /= 1024;
+		i++;
+	}
+	return `${i == 0 ? bytes : bytes.toFixed(1)}${units[i]}`;
+}
+
+function MediaQuota({ quota }) {
+	return (
+		<div className="media-quota">
+			<h1>Media storage</h1>
+			{quota.limit > 0
+				? <>
+					<p>
+						You are using {formatBytes(quota.usage)} of your {formatBytes(quota.limit)} media storage quota.
+					</p>
+					<progress value={Math.min(quota.usage, quota.limit)} max={quota.limit} />
+				</>
+				: <p>You are using {formatBytes(quota.usage)} of media storage. There is no limit on your account.</p>
+			}
+			<p>Delete old posts with attachments to free up space, or ask an admin to raise your quota.</p>
+		</div>
+	);
+}
+
+function EmailPreferencesForm({ data }) {
+	const form = {
+		mentions: useTextInput("mentions", { source: data }),
+		direct: useTextInput("direct", { source: data }),
+		follows: useTextInput("follows", { source: data }),
+		followRequests: useTextInput("follow_requests", { source: data }),
+		reportsClosed: useTextInput("reports_closed", { source: data }),
+		digestFrequency: useTextInput("digest_frequency", { source: data }),
+	};
+
+	const [submitForm, result] = useFormSubmit(form, query.useUpdateEmailPreferencesMutation());
+
+	const preferenceOptions = (
+		<>
+			<option value="off">Don't email me</option>
+			<option value="immediate">Email me straight away</option>
+			<option value="digest">Include in my digest</option>
 		</>
 	);
+
+	return (
+		<form className="email-preferences" onSubmit={submitForm}>
+			<h1>Email notifications</h1>
+			<Select field={form.mentions} label="Mentions" options={preferenceOptions} />
+			<Select field={form.direct} label="Direct messages" options={preferenceOptions} />
+			<Select field={form.follows} label="New followers" options={preferenceOptions} />
+			<Select field={form.followRequests} label="Follow requests" options={preferenceOptions} />
+			<Select field={form.reportsClosed} label="My reports being closed" options={preferenceOptions} />
+			<Select field={form.digestFrequency} label="Send digests" options={
+				<>
+					<option value="daily">Daily</option>
+					<option value="weekly">Weekly</option>
+				</>
+			} />
+			<MutationButton label="Save email preferences" result={result} />
+		</form>
+	);
 }
 
 function PasswordChange() {